@@ -8,15 +8,21 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/esms/internal/cache"
 	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/queue"
 	"github.com/your-org/esms/internal/repository"
 	"github.com/your-org/esms/internal/service"
 	"github.com/your-org/esms/pkg/oidc"
+	"github.com/your-org/esms/pkg/saml"
 )
 
 // Config はアプリケーション設定
@@ -27,6 +33,51 @@ type Config struct {
 	OIDCIssuer   string
 	OIDCClientID string
 	OIDCSecret   string
+
+	WaitlistHoldDuration time.Duration // ウェイトリスト繰り上げ時の仮押さえの確認期限
+	HoldDuration         time.Duration // 予約フォーム入力中の仮押さえの有効期間
+
+	// IdPのクレームからロール・上長への変換設定
+	OIDCRoleClaim    string // ロールの判定に使うクレーム（例: groups, realm_access.roles）
+	OIDCRoleMapping  string // クレームの値とロールの対応（例: esms-admins=ADMIN,esms-managers=MANAGER）
+	OIDCManagerClaim string // 上長のメールアドレスを表すクレーム
+	OIDCRoleSource   string // ロール・上長の管理元（IDP: ログインごとに同期 / LOCAL: 初回ログイン時のみ）
+
+	OIDCPostLogoutRedirectURL string // IdPでのログアウト後に戻るURL
+
+	// SAML 2.0 SP の設定（SAML_* 、SPEntityID が空の場合はSAMLログインを無効にする）
+	SAML SAMLConfig
+
+	// 名前付きのIdP（AUTH_PROVIDERS で列挙し、AUTH_PROVIDER_<NAME>_* で設定）
+	IdentityProviders []IdentityProviderConfig
+
+	SCIMBearerToken string // IdPのSCIMコネクターの認証トークン（空の場合は /scim/v2 を公開しない）
+
+	MaxConcurrentSessions int // ユーザーごとの同時セッション数の上限（0の場合は無制限）
+}
+
+// SAMLConfig はSAML SPの設定
+type SAMLConfig struct {
+	SPEntityID      string        // SPのエンティティID
+	ACSURL          string        // Assertion Consumer Service のURL
+	NameIDFormat    string        // IdPに要求する NameID の形式
+	IdPMetadataURL  string        // IdPのメタデータのURL
+	IdPMetadataFile string        // IdPのメタデータのファイル（URLの代わりに指定）
+	IdPEntityID     string        // メタデータを使わない場合のIdPのエンティティID
+	IdPSSOURL       string        // メタデータを使わない場合のIdPのSSO URL（HTTP-Redirect）
+	IdPCertFile     string        // メタデータを使わない場合のIdPの署名証明書（PEM）
+	EmailAttribute  string        // メールアドレスの属性名
+	NameAttribute   string        // 表示名の属性名
+	SessionTTL      time.Duration // SAMLでログインしたセッションの有効期間
+}
+
+// IdentityProviderConfig は名前付きのIdPの設定
+type IdentityProviderConfig struct {
+	Name    string
+	Type    string   // oidc / saml
+	Domains []string // このIdPでログインするメールアドレスのドメイン（ホームレルムディスカバリー）
+	OIDC    oidc.Config
+	SAML    SAMLConfig
 }
 
 func main() {
@@ -51,9 +102,16 @@ func main() {
 	}
 	log.Println("All dependency health checks passed")
 
-	// Redis接続（将来の実装用）
-	// redisClient := initRedis(config.RedisURL)
-	// defer redisClient.Close()
+	// Redis接続（通知ジョブのキューイング用）
+	// 接続できない場合は通知なしで起動する
+	var notificationService *service.NotificationService
+	redisClient, err := initRedis(config.RedisURL)
+	if err != nil {
+		log.Printf("Warning: Redis unavailable, notifications disabled: %v", err)
+	} else {
+		defer redisClient.Client.Close()
+		log.Println("Redis connection established")
+	}
 
 	// OIDC クライアント初期化
 	oidcClient, err := initOIDCClient(config)
@@ -62,6 +120,12 @@ func main() {
 	}
 	log.Println("OIDC client initialized")
 
+	// SAML・名前付きのIdPのログインプロバイダー初期化
+	loginProviders, err := initLoginProviders(config)
+	if err != nil {
+		log.Fatalf("Failed to initialize identity providers: %v", err)
+	}
+
 	// pgxpool.Pool を *sql.DB に変換
 	db := stdlib.OpenDBFromPool(dbPool)
 	defer db.Close()
@@ -71,19 +135,122 @@ func main() {
 	resourceRepo := repository.NewResourceRepository(db)
 	reservationRepo := repository.NewReservationRepository(db)
 	auditLogRepo := repository.NewAuditLogRepository(db)
+	approverGroupRepo := repository.NewApproverGroupRepository(db)
+	approvalRepo := repository.NewApprovalRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+	checkoutRepo := repository.NewCheckoutRepository(db)
+	holdRepo := repository.NewHoldRepository(db)
+	bumpRepo := repository.NewBumpRepository(db)
+	quotaRepo := repository.NewQuotaRepository(db)
+	penaltyRepo := repository.NewPenaltyRepository(db)
+	identityConflictRepo := repository.NewIdentityConflictRepository(db)
+	provisionedGroupRepo := repository.NewProvisionedGroupRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	serviceAccountRepo := repository.NewServiceAccountRepository(db)
 
 	// サービス初期化
-	authService := service.NewAuthService(oidcClient, userRepo, auditLogRepo)
+	// jobQueue は Redis が利用できない場合 nil のまま（型付き nil を渡さないようインターフェースで保持）
+	// セッションは Redis に保存して複数インスタンスで共有する（Redis が利用できない場合はプロセス内に保持）
+	var jobQueue queue.JobQueue
+	sessionStore := service.NewMemorySessionStore()
+	if redisClient != nil {
+		jobQueue = queue.NewRedisJobQueue(redisClient, "default")
+		notificationService = service.NewNotificationService(userRepo, jobQueue, nil)
+		sessionStore = service.NewRedisSessionStore(redisClient)
+	} else {
+		log.Println("Warning: sessions are kept in memory and will not survive restarts or be shared across instances")
+	}
+	roleMapping, err := service.ParseRoleMapping(config.OIDCRoleClaim, config.OIDCRoleMapping, config.OIDCManagerClaim, config.OIDCRoleSource)
+	if err != nil {
+		log.Fatalf("Invalid OIDC role mapping: %v", err)
+	}
+	// 未設定のクライアントを型付き nil のまま渡さないようインターフェースで保持する
+	var authOIDCClient service.OIDCClient
+	if oidcClient != nil {
+		authOIDCClient = oidcClient
+	}
+	authService := service.NewAuthService(authOIDCClient, userRepo, auditLogRepo, identityConflictRepo, sessionStore, roleMapping, config.MaxConcurrentSessions, loginProviders...)
+	provisioningService := service.NewProvisioningService(userRepo, provisionedGroupRepo, auditLogRepo, sessionStore, roleMapping)
+	apiTokenService := service.NewAPITokenService(apiTokenRepo, serviceAccountRepo, userRepo, auditLogRepo)
+	if config.SCIMBearerToken == "" {
+		log.Println("SCIM_BEARER_TOKEN not set, SCIM provisioning disabled")
+	}
+	approvalService := service.NewApprovalService(
+		reservationRepo,
+		userRepo,
+		auditLogRepo,
+		approverGroupRepo,
+		approvalRepo,
+		notificationService,
+		jobQueue,
+	)
+	quotaService := service.NewQuotaService(
+		quotaRepo,
+		userRepo,
+		auditLogRepo,
+	)
+	penaltyService := service.NewPenaltyService(
+		penaltyRepo,
+		userRepo,
+		reservationRepo,
+		bumpRepo,
+		auditLogRepo,
+	)
 	reservationService := service.NewReservationService(
 		reservationRepo,
 		resourceRepo,
 		userRepo,
 		auditLogRepo,
+		approvalService,
+		jobQueue,
+		checkoutRepo,
+		quotaService,
+		penaltyService,
 	)
-	approvalService := service.NewApprovalService(
+	waitlistService := service.NewWaitlistService(
+		waitlistRepo,
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		approvalService,
+		notificationService,
+		reservationService,
+		config.WaitlistHoldDuration,
+	)
+	equipmentService := service.NewEquipmentService(
+		resourceRepo,
 		reservationRepo,
+		assetRepo,
+		checkoutRepo,
 		userRepo,
 		auditLogRepo,
+		notificationService,
+		jobQueue,
+	)
+	workspaceService := service.NewWorkspaceService(
+		resourceRepo,
+		userRepo,
+		reservationService,
+	)
+	holdService := service.NewHoldService(
+		holdRepo,
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		reservationService,
+		config.HoldDuration,
+	)
+	bumpService := service.NewBumpService(
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		bumpRepo,
+		notificationService,
+		reservationService,
 	)
 
 	// ルーター初期化
@@ -91,8 +258,18 @@ func main() {
 		authService,
 		reservationService,
 		approvalService,
+		waitlistService,
+		equipmentService,
+		workspaceService,
+		holdService,
+		bumpService,
+		quotaService,
+		penaltyService,
+		provisioningService,
+		apiTokenService,
 		userRepo,
 		resourceRepo,
+		config.SCIMBearerToken,
 	)
 
 	// HTTPサーバー設定
@@ -125,7 +302,80 @@ func loadConfig() *Config {
 		OIDCIssuer:   getEnv("OIDC_ISSUER", ""),
 		OIDCClientID: getEnv("OIDC_CLIENT_ID", ""),
 		OIDCSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+
+		WaitlistHoldDuration: getDurationEnv("WAITLIST_HOLD_DURATION", service.DefaultWaitlistHoldDuration),
+		HoldDuration:         getDurationEnv("HOLD_DURATION", service.DefaultHoldDuration),
+
+		OIDCRoleClaim:    getEnv("OIDC_ROLE_CLAIM", ""),
+		OIDCRoleMapping:  getEnv("OIDC_ROLE_MAPPING", ""),
+		OIDCManagerClaim: getEnv("OIDC_MANAGER_CLAIM", ""),
+		OIDCRoleSource:   getEnv("OIDC_ROLE_SOURCE", string(service.RoleSourceLocal)),
+
+		OIDCPostLogoutRedirectURL: getEnv("OIDC_POST_LOGOUT_REDIRECT_URL", ""),
+
+		SAML:              loadSAMLConfig("SAML_"),
+		IdentityProviders: loadIdentityProviders(),
+
+		SCIMBearerToken: getEnv("SCIM_BEARER_TOKEN", ""),
+
+		MaxConcurrentSessions: getIntEnv("MAX_CONCURRENT_SESSIONS", 0),
+	}
+}
+
+// loadSAMLConfig は prefix で始まる環境変数からSAML SPの設定を読み込みます
+func loadSAMLConfig(prefix string) SAMLConfig {
+	return SAMLConfig{
+		SPEntityID:      getEnv(prefix+"SP_ENTITY_ID", ""),
+		ACSURL:          getEnv(prefix+"ACS_URL", ""),
+		NameIDFormat:    getEnv(prefix+"NAMEID_FORMAT", saml.NameIDFormatPersistent),
+		IdPMetadataURL:  getEnv(prefix+"IDP_METADATA_URL", ""),
+		IdPMetadataFile: getEnv(prefix+"IDP_METADATA_FILE", ""),
+		IdPEntityID:     getEnv(prefix+"IDP_ENTITY_ID", ""),
+		IdPSSOURL:       getEnv(prefix+"IDP_SSO_URL", ""),
+		IdPCertFile:     getEnv(prefix+"IDP_CERT_FILE", ""),
+		EmailAttribute:  getEnv(prefix+"EMAIL_ATTRIBUTE", ""),
+		NameAttribute:   getEnv(prefix+"NAME_ATTRIBUTE", ""),
+		SessionTTL:      getDurationEnv(prefix+"SESSION_TTL", service.DefaultSAMLSessionTTL),
+	}
+}
+
+// loadIdentityProviders は AUTH_PROVIDERS（カンマ区切りの名前）で列挙した名前付きのIdPの設定を読み込みます
+// 各IdPの設定は AUTH_PROVIDER_<NAME>_*（NAME は大文字、- は _ に置き換え）で指定します
+//
+//	AUTH_PROVIDER_<NAME>_TYPE     oidc / saml
+//	AUTH_PROVIDER_<NAME>_DOMAINS  このIdPでログインするメールアドレスのドメイン（カンマ区切り）
+//	OIDC: ISSUER, CLIENT_ID, CLIENT_SECRET, REDIRECT_URL, POST_LOGOUT_REDIRECT_URL
+//	SAML: SAML_* と同じ項目（SP_ENTITY_ID, ACS_URL, IDP_METADATA_URL など）
+func loadIdentityProviders() []IdentityProviderConfig {
+	var providers []IdentityProviderConfig
+	for _, name := range splitList(getEnv("AUTH_PROVIDERS", "")) {
+		prefix := "AUTH_PROVIDER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		providers = append(providers, IdentityProviderConfig{
+			Name:    name,
+			Type:    strings.ToLower(getEnv(prefix+"TYPE", service.ProviderOIDC)),
+			Domains: splitList(getEnv(prefix+"DOMAINS", "")),
+			OIDC: oidc.Config{
+				IssuerURL:             getEnv(prefix+"ISSUER", ""),
+				ClientID:              getEnv(prefix+"CLIENT_ID", ""),
+				ClientSecret:          getEnv(prefix+"CLIENT_SECRET", ""),
+				RedirectURL:           getEnv(prefix+"REDIRECT_URL", ""),
+				PostLogoutRedirectURL: getEnv(prefix+"POST_LOGOUT_REDIRECT_URL", ""),
+			},
+			SAML: loadSAMLConfig(prefix),
+		})
+	}
+	return providers
+}
+
+// splitList はカンマ区切りの値を空要素を除いて分割します
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 // getEnv は環境変数を取得し、存在しない場合はデフォルト値を返します
@@ -136,6 +386,26 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getDurationEnv は環境変数をtime.Durationとして取得し、存在しないか不正な場合はデフォルト値を返します
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getIntEnv は環境変数をintとして取得し、存在しないか不正な場合はデフォルト値を返します
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
 // initDatabase はデータベース接続プールを初期化します
 func initDatabase(databaseURL string) (*pgxpool.Pool, error) {
 	ctx := context.Background()
@@ -163,6 +433,26 @@ func initDatabase(databaseURL string) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// initRedis はRedisクライアントを初期化します
+func initRedis(redisURL string) (*cache.RedisClient, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return &cache.RedisClient{Client: client}, nil
+}
+
 // initOIDCClient はOIDCクライアントを初期化します
 func initOIDCClient(config *Config) (*oidc.Client, error) {
 	if config.OIDCIssuer == "" {
@@ -176,11 +466,117 @@ func initOIDCClient(config *Config) (*oidc.Client, error) {
 		ClientID:     config.OIDCClientID,
 		ClientSecret: config.OIDCSecret,
 		RedirectURL:  fmt.Sprintf("http://localhost:%s/api/v1/auth/callback", config.Port),
+
+		PostLogoutRedirectURL: config.OIDCPostLogoutRedirectURL,
 	}
 
 	return oidc.NewClient(context.Background(), oidcConfig)
 }
 
+// initLoginProviders はSAML（SAML_*）と名前付きのIdP（AUTH_PROVIDERS）のログインプロバイダーを初期化します
+// OIDC_* のIdP（oidc）、SAML_* のIdP（saml）、AUTH_PROVIDERS の順に登録し、最初のものが既定のIdPになります
+func initLoginProviders(config *Config) ([]service.LoginProvider, error) {
+	var providers []service.LoginProvider
+
+	if config.SAML.SPEntityID != "" {
+		provider, err := initSAMLLoginProvider(config, config.SAML, service.ProviderOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("saml: %w", err)
+		}
+		providers = append(providers, provider)
+	} else {
+		log.Println("SAML not configured, SAML login disabled")
+	}
+
+	for _, idp := range config.IdentityProviders {
+		opts := service.ProviderOptions{Name: idp.Name, Domains: idp.Domains}
+		switch idp.Type {
+		case service.ProviderOIDC:
+			oidcConfig := idp.OIDC
+			if oidcConfig.RedirectURL == "" {
+				oidcConfig.RedirectURL = fmt.Sprintf("http://localhost:%s/api/v1/auth/callback", config.Port)
+			}
+			client, err := oidc.NewClient(context.Background(), &oidcConfig)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", idp.Name, err)
+			}
+			providers = append(providers, service.NewOIDCLoginProvider(client, opts))
+		case service.ProviderSAML:
+			provider, err := initSAMLLoginProvider(config, idp.SAML, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", idp.Name, err)
+			}
+			providers = append(providers, provider)
+		default:
+			return nil, fmt.Errorf("%s: unsupported identity provider type %q", idp.Name, idp.Type)
+		}
+		log.Printf("Identity provider %q (%s) initialized", idp.Name, idp.Type)
+	}
+
+	return providers, nil
+}
+
+// initSAMLLoginProvider はSAML SPを初期化し、SAMLのログインプロバイダーを作成します
+// IdPの情報はメタデータ（URL・ファイル）から読み込むか、エンティティID・SSO URL・証明書を個別に指定します
+func initSAMLLoginProvider(config *Config, samlConfig SAMLConfig, opts service.ProviderOptions) (service.LoginProvider, error) {
+	var idp *saml.IdPMetadata
+	switch {
+	case samlConfig.IdPMetadataURL != "":
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		metadata, err := saml.FetchIdPMetadata(ctx, samlConfig.IdPMetadataURL)
+		if err != nil {
+			return nil, err
+		}
+		idp = metadata
+	case samlConfig.IdPMetadataFile != "":
+		data, err := os.ReadFile(samlConfig.IdPMetadataFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IdP metadata file: %w", err)
+		}
+		metadata, err := saml.ParseIdPMetadata(data)
+		if err != nil {
+			return nil, err
+		}
+		idp = metadata
+	default:
+		data, err := os.ReadFile(samlConfig.IdPCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read IdP certificate file: %w", err)
+		}
+		certs, err := saml.ParseCertificatePEM(data)
+		if err != nil {
+			return nil, err
+		}
+		idp = &saml.IdPMetadata{
+			EntityID:     samlConfig.IdPEntityID,
+			SSOURL:       samlConfig.IdPSSOURL,
+			Certificates: certs,
+		}
+	}
+
+	acsURL := samlConfig.ACSURL
+	if acsURL == "" {
+		acsURL = fmt.Sprintf("http://localhost:%s/api/v1/auth/saml/acs", config.Port)
+	}
+
+	sp, err := saml.NewServiceProvider(&saml.Config{
+		EntityID:     samlConfig.SPEntityID,
+		ACSURL:       acsURL,
+		NameIDFormat: samlConfig.NameIDFormat,
+		IdP:          idp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("SAML service provider initialized (IdP: %s)", idp.EntityID)
+
+	return service.NewSAMLLoginProvider(sp, service.SAMLAttributeMapping{
+		EmailAttribute: samlConfig.EmailAttribute,
+		NameAttribute:  samlConfig.NameAttribute,
+	}, samlConfig.SessionTTL, opts), nil
+}
+
 // healthCheck は依存サービスのヘルスチェックを実行します
 func healthCheck(dbPool *pgxpool.Pool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
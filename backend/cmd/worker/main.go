@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -10,6 +11,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/your-org/esms/internal/cache"
@@ -55,6 +57,17 @@ func main() {
 
 	// リポジトリ初期化
 	userRepo := repository.NewUserRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	approverGroupRepo := repository.NewApproverGroupRepository(db)
+	approvalRepo := repository.NewApprovalRepository(db)
+	resourceRepo := repository.NewResourceRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	assetRepo := repository.NewAssetRepository(db)
+	checkoutRepo := repository.NewCheckoutRepository(db)
+	holdRepo := repository.NewHoldRepository(db)
+	bumpRepo := repository.NewBumpRepository(db)
+	penaltyRepo := repository.NewPenaltyRepository(db)
 
 	// サービス初期化
 	notificationService := service.NewNotificationService(
@@ -62,6 +75,63 @@ func main() {
 		jobQueue,
 		nil, // EmailSender は実装に応じて初期化
 	)
+	approvalService := service.NewApprovalService(
+		reservationRepo,
+		userRepo,
+		auditLogRepo,
+		approverGroupRepo,
+		approvalRepo,
+		notificationService,
+		jobQueue,
+	)
+	equipmentService := service.NewEquipmentService(
+		resourceRepo,
+		reservationRepo,
+		assetRepo,
+		checkoutRepo,
+		userRepo,
+		auditLogRepo,
+		notificationService,
+		jobQueue,
+	)
+	penaltyService := service.NewPenaltyService(
+		penaltyRepo,
+		userRepo,
+		reservationRepo,
+		bumpRepo,
+		auditLogRepo,
+	)
+	reservationService := service.NewReservationService(
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		approvalService,
+		jobQueue,
+		checkoutRepo,
+		nil,
+		penaltyService,
+	)
+	waitlistService := service.NewWaitlistService(
+		waitlistRepo,
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		approvalService,
+		notificationService,
+		reservationService,
+		cfg.WaitlistHoldDuration,
+	)
+	holdService := service.NewHoldService(
+		holdRepo,
+		reservationRepo,
+		resourceRepo,
+		userRepo,
+		auditLogRepo,
+		reservationService,
+		cfg.HoldDuration,
+	)
 
 	// ワーカー起動
 	ctx, cancel := context.WithCancel(context.Background())
@@ -74,11 +144,23 @@ func main() {
 	workerCount := 5 // デフォルト
 	for i := 0; i < workerCount; i++ {
 		wg.Add(1)
-		go worker(ctx, &wg, i, jobQueue, notificationService)
+		go worker(ctx, &wg, i, jobQueue, notificationService, approvalService, waitlistService, equipmentService, holdService, penaltyService)
 	}
 
 	log.Printf("Started %d worker(s)", workerCount)
 
+	// 定期ジョブのスケジューラー起動
+	wg.Add(1)
+	go scheduler(ctx, &wg, jobQueue, jobTypeApprovalSLACheck, cfg.ApprovalSLACheckInterval)
+	wg.Add(1)
+	go scheduler(ctx, &wg, jobQueue, jobTypeWaitlistOfferExpiry, cfg.WaitlistExpiryCheckInterval)
+	wg.Add(1)
+	go scheduler(ctx, &wg, jobQueue, jobTypeEquipmentOverdueCheck, cfg.EquipmentOverdueCheckInterval)
+	wg.Add(1)
+	go scheduler(ctx, &wg, jobQueue, jobTypeHoldExpiry, cfg.HoldExpiryCheckInterval)
+	wg.Add(1)
+	go scheduler(ctx, &wg, jobQueue, jobTypePenaltyDecay, cfg.PenaltyDecayCheckInterval)
+
 	// グレースフルシャットダウン
 	gracefulShutdown(cancel, &wg, dbPool)
 }
@@ -111,8 +193,48 @@ func initDatabase(databaseURL string) (*pgxpool.Pool, error) {
 
 
 
+// ジョブ種別
+const (
+	jobTypeSendEmail             = "send_email"
+	jobTypeCleanup               = "cleanup"
+	jobTypeApprovalSLACheck      = "approval_sla_check"
+	jobTypeWaitlistPromote       = "waitlist_promote"
+	jobTypeWaitlistOfferExpiry   = "waitlist_offer_expiry"
+	jobTypeEquipmentOverdueCheck = "equipment_overdue_check"
+	jobTypeHoldExpiry            = "hold_expiry"
+	jobTypePenaltyDecay          = "penalty_decay"
+)
+
+// scheduler は一定間隔で定期ジョブをキューに投入します
+func scheduler(ctx context.Context, wg *sync.WaitGroup, jobQueue queue.JobQueue, jobType string, interval time.Duration) {
+	defer wg.Done()
+	if interval <= 0 {
+		log.Printf("Scheduler for %s disabled", jobType)
+		return
+	}
+	log.Printf("Scheduler for %s started (interval: %s)", jobType, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Scheduler for %s stopping gracefully", jobType)
+			return
+		case <-ticker.C:
+			jobID, err := jobQueue.Enqueue(ctx, jobType, map[string]interface{}{})
+			if err != nil {
+				log.Printf("Scheduler: Failed to enqueue %s job: %v", jobType, err)
+				continue
+			}
+			log.Printf("Scheduler: Enqueued %s job %s", jobType, jobID)
+		}
+	}
+}
+
 // worker はジョブを処理するワーカー
-func worker(ctx context.Context, wg *sync.WaitGroup, id int, jobQueue queue.JobQueue, notificationService *service.NotificationService) {
+func worker(ctx context.Context, wg *sync.WaitGroup, id int, jobQueue queue.JobQueue, notificationService *service.NotificationService, approvalService *service.ApprovalService, waitlistService *service.WaitlistService, equipmentService *service.EquipmentService, holdService *service.HoldService, penaltyService *service.PenaltyService) {
 	defer wg.Done()
 	log.Printf("Worker %d started", id)
 
@@ -141,7 +263,7 @@ func worker(ctx context.Context, wg *sync.WaitGroup, id int, jobQueue queue.JobQ
 			}
 
 			// ジョブ処理（コンテキストを渡して中断可能にする）
-			if err := processJob(ctx, job, notificationService); err != nil {
+			if err := processJob(ctx, job, notificationService, approvalService, waitlistService, equipmentService, holdService, penaltyService); err != nil {
 				log.Printf("Worker %d: Failed to process job %s: %v", id, job.ID, err)
 			} else {
 				log.Printf("Worker %d: Successfully processed job %s", id, job.ID)
@@ -151,25 +273,104 @@ func worker(ctx context.Context, wg *sync.WaitGroup, id int, jobQueue queue.JobQ
 }
 
 // processJob はジョブを処理します
-func processJob(ctx context.Context, job *queue.Job, notificationService *service.NotificationService) error {
+func processJob(ctx context.Context, job *queue.Job, notificationService *service.NotificationService, approvalService *service.ApprovalService, waitlistService *service.WaitlistService, equipmentService *service.EquipmentService, holdService *service.HoldService, penaltyService *service.PenaltyService) error {
 	switch job.Type {
-	case "send_email":
+	case jobTypeSendEmail:
 		// メール送信ジョブ
 		log.Printf("Processing email job: %s", job.ID)
 		// notificationService を使用してメール送信
 		return nil
 
-	case "cleanup":
+	case jobTypeCleanup:
 		// クリーンアップジョブ
 		log.Printf("Processing cleanup job: %s", job.ID)
 		return nil
 
+	case jobTypeApprovalSLACheck:
+		// 承認SLAチェックジョブ（リマインド・エスカレーション・期限切れ処理）
+		log.Printf("Processing approval SLA check job: %s", job.ID)
+		processed, err := approvalService.ProcessSLA(ctx, time.Now())
+		if processed > 0 {
+			log.Printf("Approval SLA check: %d step(s) processed", processed)
+		}
+		return err
+
+	case jobTypeWaitlistPromote:
+		// ウェイトリスト繰り上げジョブ（キャンセル等で空いた枠を登録者へ提示）
+		log.Printf("Processing waitlist promote job: %s", job.ID)
+		resourceID, startAt, endAt, err := parseReleasedSlot(job.Payload)
+		if err != nil {
+			return err
+		}
+		offered, err := waitlistService.PromoteNext(ctx, resourceID, startAt, endAt)
+		if offered > 0 {
+			log.Printf("Waitlist promote: %d offer(s) made for resource %s", offered, resourceID)
+		}
+		return err
+
+	case jobTypeWaitlistOfferExpiry:
+		// ウェイトリスト繰り上げ提示の期限切れ処理ジョブ
+		log.Printf("Processing waitlist offer expiry job: %s", job.ID)
+		expired, err := waitlistService.ExpireOffers(ctx, time.Now())
+		if expired > 0 {
+			log.Printf("Waitlist offer expiry: %d offer(s) expired", expired)
+		}
+		return err
+
+	case jobTypeEquipmentOverdueCheck:
+		// 備品の返却遅延チェックジョブ（借用者への通知）
+		log.Printf("Processing equipment overdue check job: %s", job.ID)
+		notified, err := equipmentService.ProcessOverdue(ctx, time.Now())
+		if notified > 0 {
+			log.Printf("Equipment overdue check: %d checkout(s) overdue", notified)
+		}
+		return err
+
+	case jobTypeHoldExpiry:
+		// 予約フォーム入力中の仮押さえの期限切れ処理ジョブ
+		log.Printf("Processing hold expiry job: %s", job.ID)
+		expired, err := holdService.ExpireHolds(ctx, time.Now())
+		if expired > 0 {
+			log.Printf("Hold expiry: %d hold(s) released", expired)
+		}
+		return err
+
+	case jobTypePenaltyDecay:
+		// 90日を過ぎたキャンセルペナルティをスコアから除外するジョブ
+		log.Printf("Processing penalty decay job: %s", job.ID)
+		decayed, err := penaltyService.ExpireScores(ctx, time.Now())
+		if decayed > 0 {
+			log.Printf("Penalty decay: %d user score(s) decayed", decayed)
+		}
+		return err
+
 	default:
 		log.Printf("Unknown job type: %s", job.Type)
 		return nil
 	}
 }
 
+// parseReleasedSlot は繰り上げジョブのペイロードから解放された枠を取り出します
+func parseReleasedSlot(payload map[string]interface{}) (uuid.UUID, time.Time, time.Time, error) {
+	resourceIDStr, _ := payload["resource_id"].(string)
+	startAtStr, _ := payload["start_at"].(string)
+	endAtStr, _ := payload["end_at"].(string)
+
+	resourceID, err := uuid.Parse(resourceIDStr)
+	if err != nil {
+		return uuid.Nil, time.Time{}, time.Time{}, fmt.Errorf("invalid resource_id in payload: %w", err)
+	}
+	startAt, err := time.Parse(time.RFC3339, startAtStr)
+	if err != nil {
+		return uuid.Nil, time.Time{}, time.Time{}, fmt.Errorf("invalid start_at in payload: %w", err)
+	}
+	endAt, err := time.Parse(time.RFC3339, endAtStr)
+	if err != nil {
+		return uuid.Nil, time.Time{}, time.Time{}, fmt.Errorf("invalid end_at in payload: %w", err)
+	}
+	return resourceID, startAt, endAt, nil
+}
+
 // gracefulShutdown はグレースフルシャットダウンを処理します
 func gracefulShutdown(cancel context.CancelFunc, wg *sync.WaitGroup, dbPool *pgxpool.Pool) {
 	quit := make(chan os.Signal, 1)
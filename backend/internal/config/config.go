@@ -31,6 +31,15 @@ type Config struct {
 	OIDCRedirect        string
 	AuditSecret         string // 監査ログ署名用シークレット
 
+	// ワーカーの定期ジョブ設定
+	ApprovalSLACheckInterval      time.Duration // 承認SLAチェックの実行間隔（0以下で無効）
+	WaitlistExpiryCheckInterval   time.Duration // ウェイトリスト繰り上げ提示の期限切れチェックの実行間隔（0以下で無効）
+	WaitlistHoldDuration          time.Duration // ウェイトリスト繰り上げ時の仮押さえの確認期限
+	EquipmentOverdueCheckInterval time.Duration // 備品の返却遅延チェックの実行間隔（0以下で無効）
+	HoldExpiryCheckInterval       time.Duration // 予約フォーム入力中の仮押さえの期限切れチェックの実行間隔（0以下で無効）
+	HoldDuration                  time.Duration // 予約フォーム入力中の仮押さえの有効期間
+	PenaltyDecayCheckInterval     time.Duration // キャンセルペナルティの減衰（90日経過分の除外）の実行間隔（0以下で無効）
+
 	// AWS Secrets Manager Config
 	UseSecretsManager bool
 	AWSRegion         string
@@ -78,6 +87,13 @@ func Load() (*Config, error) {
 	cfg.RedisConnectTimeout = GetDurationEnv("REDIS_CONNECT_TIMEOUT", 5*time.Second)
 	cfg.RedisReadTimeout = GetDurationEnv("REDIS_READ_TIMEOUT", 3*time.Second)
 	cfg.RedisWriteTimeout = GetDurationEnv("REDIS_WRITE_TIMEOUT", 3*time.Second)
+	cfg.ApprovalSLACheckInterval = GetDurationEnv("APPROVAL_SLA_CHECK_INTERVAL", 5*time.Minute)
+	cfg.WaitlistExpiryCheckInterval = GetDurationEnv("WAITLIST_EXPIRY_CHECK_INTERVAL", time.Minute)
+	cfg.WaitlistHoldDuration = GetDurationEnv("WAITLIST_HOLD_DURATION", 15*time.Minute)
+	cfg.EquipmentOverdueCheckInterval = GetDurationEnv("EQUIPMENT_OVERDUE_CHECK_INTERVAL", 15*time.Minute)
+	cfg.HoldExpiryCheckInterval = GetDurationEnv("HOLD_EXPIRY_CHECK_INTERVAL", 30*time.Second)
+	cfg.HoldDuration = GetDurationEnv("HOLD_DURATION", 5*time.Minute)
+	cfg.PenaltyDecayCheckInterval = GetDurationEnv("PENALTY_DECAY_CHECK_INTERVAL", time.Hour)
 
 	return cfg, nil
 }
@@ -0,0 +1,92 @@
+// backend/internal/domain/api_token.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAPITokenScope = errors.New("invalid api token scope")
+)
+
+// APITokenScope はAPIトークンで許可する操作の範囲を表す型
+type APITokenScope string
+
+const (
+	APITokenScopeRead  APITokenScope = "read"  // 参照（GET・HEAD）
+	APITokenScopeWrite APITokenScope = "write" // 更新を含む全ての操作（read を含む）
+)
+
+// IsValid は定義済みのスコープかどうかを判定します
+func (s APITokenScope) IsValid() bool {
+	switch s {
+	case APITokenScopeRead, APITokenScopeWrite:
+		return true
+	}
+	return false
+}
+
+// ServiceAccount は特定の個人に紐付かないAPIの利用者を表す構造体
+// 会議室の表示端末や集計スクリプトなど、対話的なログインができないクライアントが使用します
+type ServiceAccount struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Role        Role       `json:"role"` // トークンで操作する際のロール
+	CreatedBy   uuid.UUID  `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DisabledAt  *time.Time `json:"disabled_at,omitempty"`
+}
+
+// IsActive はサービスアカウントが有効かどうかを判定します
+func (a *ServiceAccount) IsActive() bool {
+	return a.DisabledAt == nil
+}
+
+// APIToken は個人用アクセストークンまたはサービスアカウントのAPIキーを表す構造体
+// トークン自体は保存せず、ハッシュ値のみを保持します
+type APIToken struct {
+	ID               uuid.UUID       `json:"id"`
+	Name             string          `json:"name"`
+	Prefix           string          `json:"prefix"`                       // トークンの先頭部分（一覧での識別用）
+	TokenHash        string          `json:"-"`                            // トークンの SHA-256（16進数）
+	UserID           *uuid.UUID      `json:"user_id,omitempty"`            // 個人用アクセストークンの所有者
+	ServiceAccountID *uuid.UUID      `json:"service_account_id,omitempty"` // サービスアカウントのAPIキーの所有者
+	Scopes           []APITokenScope `json:"scopes"`
+	ExpiresAt        time.Time       `json:"expires_at"`
+	LastUsedAt       *time.Time      `json:"last_used_at,omitempty"`
+	LastUsedIP       string          `json:"last_used_ip,omitempty"`
+	CreatedBy        uuid.UUID       `json:"created_by"`
+	CreatedAt        time.Time       `json:"created_at"`
+	RevokedAt        *time.Time      `json:"revoked_at,omitempty"`
+	RevokedBy        *uuid.UUID      `json:"revoked_by,omitempty"`
+}
+
+// IsServiceAccount はサービスアカウントのAPIキーかどうかを判定します
+func (t *APIToken) IsServiceAccount() bool {
+	return t.ServiceAccountID != nil
+}
+
+// IsRevoked は無効化済みかどうかを判定します
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsUsable は指定した時刻に使用できるか（無効化されておらず有効期限内か）を判定します
+func (t *APIToken) IsUsable(now time.Time) bool {
+	return !t.IsRevoked() && now.Before(t.ExpiresAt)
+}
+
+// HasScope はスコープが許可されているかを判定します（write は read を含みます）
+func (t *APIToken) HasScope(scope APITokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == APITokenScopeWrite {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+// backend/internal/domain/api_token_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestAPIToken_IsUsable(t *testing.T) {
+	now := time.Now()
+	token := &domain.APIToken{ExpiresAt: now.Add(time.Hour)}
+
+	assert.True(t, token.IsUsable(now))
+	assert.False(t, token.IsUsable(now.Add(time.Hour)))
+
+	token.RevokedAt = &now
+	assert.False(t, token.IsUsable(now))
+}
+
+func TestAPIToken_HasScope(t *testing.T) {
+	readOnly := &domain.APIToken{Scopes: []domain.APITokenScope{domain.APITokenScopeRead}}
+	assert.True(t, readOnly.HasScope(domain.APITokenScopeRead))
+	assert.False(t, readOnly.HasScope(domain.APITokenScopeWrite))
+
+	// write は read を含む
+	readWrite := &domain.APIToken{Scopes: []domain.APITokenScope{domain.APITokenScopeWrite}}
+	assert.True(t, readWrite.HasScope(domain.APITokenScopeRead))
+	assert.True(t, readWrite.HasScope(domain.APITokenScopeWrite))
+
+	assert.False(t, (&domain.APIToken{}).HasScope(domain.APITokenScopeRead))
+}
@@ -0,0 +1,260 @@
+// backend/internal/domain/approval.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrManagerNotAssigned       = errors.New("organizer has no manager assigned")
+	ErrResourceOwnerNotAssigned = errors.New("resource has no owner assigned")
+	ErrApproverGroupNotAssigned = errors.New("approval step has no approver group assigned")
+)
+
+// ApprovalStepType は承認ステップの種別を表す型
+type ApprovalStepType string
+
+const (
+	ApprovalStepManager       ApprovalStepType = "MANAGER"        // 予約者の直属の上長
+	ApprovalStepResourceOwner ApprovalStepType = "RESOURCE_OWNER" // リソース所有者
+	ApprovalStepApproverGroup ApprovalStepType = "APPROVER_GROUP" // 承認者グループ
+)
+
+// ApprovalStepStatus は承認ステップの状態を表す型
+type ApprovalStepStatus string
+
+const (
+	ApprovalStepStatusPending  ApprovalStepStatus = "PENDING"  // 承認待ち
+	ApprovalStepStatusApproved ApprovalStepStatus = "APPROVED" // 承認済み
+	ApprovalStepStatusRejected ApprovalStepStatus = "REJECTED" // 却下
+	ApprovalStepStatusSkipped  ApprovalStepStatus = "SKIPPED"  // 前段の却下によりスキップ
+	ApprovalStepStatusExpired  ApprovalStepStatus = "EXPIRED"  // 期限切れにより仮押さえ解放
+)
+
+// ApprovalTimeoutAction は承認の最終期限を過ぎた場合の動作を表す型
+type ApprovalTimeoutAction string
+
+const (
+	ApprovalTimeoutAutoApprove ApprovalTimeoutAction = "AUTO_APPROVE" // 自動承認
+	ApprovalTimeoutAutoReject  ApprovalTimeoutAction = "AUTO_REJECT"  // 自動却下
+	ApprovalTimeoutRelease     ApprovalTimeoutAction = "RELEASE"      // 仮押さえを解放
+)
+
+// ApprovalSLAAction はSLAチェックで実行すべき処理を表す型
+type ApprovalSLAAction string
+
+const (
+	ApprovalSLAActionNone     ApprovalSLAAction = ""
+	ApprovalSLAActionRemind   ApprovalSLAAction = "REMIND"
+	ApprovalSLAActionEscalate ApprovalSLAAction = "ESCALATE"
+	ApprovalSLAActionTimeout  ApprovalSLAAction = "TIMEOUT"
+)
+
+// ApprovalPolicy は承認チェーンの定義を表す構造体
+type ApprovalPolicy struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Steps       []ApprovalPolicyStep
+
+	// SLA設定（各ステップが承認待ちになってからの経過時間。0は無効）
+	ReminderAfter    time.Duration         // 承認者へリマインドを送る
+	EscalateAfter    time.Duration         // 承認者の上長または代理承認者へエスカレーションする
+	DecisionDeadline time.Duration         // TimeoutAction を適用する
+	TimeoutAction    ApprovalTimeoutAction // 最終期限を過ぎた場合の動作
+	DelegateID       *uuid.UUID            // 承認者に上長がいない場合のエスカレーション先
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ApprovalPolicyStep は承認ポリシーの1ステップを表す構造体
+type ApprovalPolicyStep struct {
+	StepOrder       int
+	Type            ApprovalStepType
+	ApproverGroupID *uuid.UUID    // APPROVER_GROUP の場合（nil ならリソースの承認者グループ）
+	MinDuration     time.Duration // 予約時間がこれを超える場合のみ適用（0は常に適用）
+}
+
+// ReservationApproval は予約ごとの承認ステップの記録を表す構造体
+type ReservationApproval struct {
+	ID                 uuid.UUID
+	ReservationID      uuid.UUID
+	ReservationStartAt time.Time
+	StepOrder          int
+	StepType           ApprovalStepType
+	PolicyID           *uuid.UUID // ステップの元になった承認ポリシー（SLA参照用）
+	ApproverID         *uuid.UUID // 個人が承認者の場合
+	ApproverGroupID    *uuid.UUID // グループが承認者の場合
+	Status             ApprovalStepStatus
+	DecidedBy          *uuid.UUID // システムによる自動判断の場合は nil
+	DecidedAt          *time.Time
+	Comment            string
+	ActivatedAt        *time.Time // 承認待ちになった日時（SLAの起点）
+	RemindedAt         *time.Time
+	EscalatedAt        *time.Time
+	EscalatedTo        *uuid.UUID // エスカレーション先の承認者
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// DefaultApprovalPolicy は承認ポリシー未指定のリソースに適用する1段階承認ポリシーを返します
+func DefaultApprovalPolicy() *ApprovalPolicy {
+	return &ApprovalPolicy{
+		Name: "default",
+		Steps: []ApprovalPolicyStep{
+			{StepOrder: 1, Type: ApprovalStepApproverGroup},
+		},
+	}
+}
+
+// Validate は承認ポリシーの整合性を検証します
+func (p *ApprovalPolicy) Validate() error {
+	if p.Name == "" {
+		return errors.New("approval policy name is required")
+	}
+	if len(p.Steps) == 0 {
+		return errors.New("approval policy requires at least one step")
+	}
+	for _, step := range p.Steps {
+		switch step.Type {
+		case ApprovalStepManager, ApprovalStepResourceOwner, ApprovalStepApproverGroup:
+		default:
+			return errors.New("invalid approval step type")
+		}
+		if step.MinDuration < 0 {
+			return errors.New("min duration must not be negative")
+		}
+	}
+	if p.ReminderAfter < 0 || p.EscalateAfter < 0 || p.DecisionDeadline < 0 {
+		return errors.New("sla durations must not be negative")
+	}
+	switch p.TimeoutAction {
+	case "", ApprovalTimeoutAutoApprove, ApprovalTimeoutAutoReject, ApprovalTimeoutRelease:
+	default:
+		return errors.New("invalid timeout action")
+	}
+	if p.DecisionDeadline > 0 && p.TimeoutAction == "" {
+		return errors.New("timeout action is required when decision deadline is set")
+	}
+	return nil
+}
+
+// HasSLA はポリシーにSLA設定があるかを判定します
+func (p *ApprovalPolicy) HasSLA() bool {
+	return p.ReminderAfter > 0 || p.EscalateAfter > 0 || (p.DecisionDeadline > 0 && p.TimeoutAction != "")
+}
+
+// SLAActionFor は承認待ちステップに対して現時点で実行すべきSLA処理を返します
+// 最終期限 > エスカレーション > リマインドの順に優先し、各処理は1度だけ実行されます
+func (p *ApprovalPolicy) SLAActionFor(step *ReservationApproval, now time.Time) ApprovalSLAAction {
+	if !step.IsPending() || step.ActivatedAt == nil {
+		return ApprovalSLAActionNone
+	}
+
+	elapsed := now.Sub(*step.ActivatedAt)
+	if p.DecisionDeadline > 0 && p.TimeoutAction != "" && elapsed >= p.DecisionDeadline {
+		return ApprovalSLAActionTimeout
+	}
+	if p.EscalateAfter > 0 && step.EscalatedAt == nil && elapsed >= p.EscalateAfter {
+		return ApprovalSLAActionEscalate
+	}
+	if p.ReminderAfter > 0 && step.RemindedAt == nil && step.EscalatedAt == nil && elapsed >= p.ReminderAfter {
+		return ApprovalSLAActionRemind
+	}
+	return ApprovalSLAActionNone
+}
+
+// AppliesTo はステップが指定された予約に適用されるかを判定します
+func (s *ApprovalPolicyStep) AppliesTo(reservation *Reservation) bool {
+	if s.MinDuration <= 0 {
+		return true
+	}
+	return reservation.EndAt.Sub(reservation.StartAt) > s.MinDuration
+}
+
+// BuildChain はポリシーから予約の承認ステップを生成します
+// 条件を満たさないステップは含まれません。StepOrder は呼び出し側で採番し直してください
+func (p *ApprovalPolicy) BuildChain(reservation *Reservation, organizer *User, resource *Resource) ([]*ReservationApproval, error) {
+	chain := make([]*ReservationApproval, 0, len(p.Steps))
+	for _, step := range p.Steps {
+		if !step.AppliesTo(reservation) {
+			continue
+		}
+
+		approval := &ReservationApproval{
+			ID:                 uuid.New(),
+			ReservationID:      reservation.ID,
+			ReservationStartAt: reservation.StartAt,
+			StepOrder:          step.StepOrder,
+			StepType:           step.Type,
+			Status:             ApprovalStepStatusPending,
+		}
+		if p.ID != uuid.Nil {
+			approval.PolicyID = &p.ID
+		}
+
+		switch step.Type {
+		case ApprovalStepManager:
+			if organizer.ManagerID == nil {
+				return nil, ErrManagerNotAssigned
+			}
+			approval.ApproverID = organizer.ManagerID
+		case ApprovalStepResourceOwner:
+			if resource.OwnerID == nil {
+				return nil, ErrResourceOwnerNotAssigned
+			}
+			approval.ApproverID = resource.OwnerID
+		case ApprovalStepApproverGroup:
+			groupID := step.ApproverGroupID
+			if groupID == nil {
+				groupID = resource.ApproverGroupID
+			}
+			if groupID == nil {
+				return nil, ErrApproverGroupNotAssigned
+			}
+			approval.ApproverGroupID = groupID
+		}
+
+		chain = append(chain, approval)
+	}
+	return chain, nil
+}
+
+// SameApprover は2つのステップが同じ承認者を指すかを判定します
+func (a *ReservationApproval) SameApprover(other *ReservationApproval) bool {
+	if a.ApproverID != nil && other.ApproverID != nil {
+		return *a.ApproverID == *other.ApproverID
+	}
+	if a.ApproverGroupID != nil && other.ApproverGroupID != nil {
+		return *a.ApproverGroupID == *other.ApproverGroupID
+	}
+	return false
+}
+
+// IsAssignedTo は指定されたユーザーが個人として割り当てられた承認者か（エスカレーション先を含む）を判定します
+func (a *ReservationApproval) IsAssignedTo(userID uuid.UUID) bool {
+	if a.ApproverID != nil && *a.ApproverID == userID {
+		return true
+	}
+	return a.EscalatedTo != nil && *a.EscalatedTo == userID
+}
+
+// IsPending は承認待ちのステップかどうかを判定します
+func (a *ReservationApproval) IsPending() bool {
+	return a.Status == ApprovalStepStatusPending
+}
+
+// CurrentApprovalStep は承認チェーン中で次に判断すべきステップを返します
+// steps は StepOrder 昇順であることを前提とします
+func CurrentApprovalStep(steps []*ReservationApproval) *ReservationApproval {
+	for _, step := range steps {
+		if step.IsPending() {
+			return step
+		}
+	}
+	return nil
+}
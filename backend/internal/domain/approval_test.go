@@ -0,0 +1,149 @@
+// backend/internal/domain/approval_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestApprovalPolicy_BuildChain(t *testing.T) {
+	managerID := uuid.New()
+	ownerID := uuid.New()
+	facilitiesID := uuid.New()
+	startAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	policy := &domain.ApprovalPolicy{
+		Name: "executive-boardroom",
+		Steps: []domain.ApprovalPolicyStep{
+			{StepOrder: 1, Type: domain.ApprovalStepManager},
+			{StepOrder: 2, Type: domain.ApprovalStepResourceOwner},
+			{StepOrder: 3, Type: domain.ApprovalStepApproverGroup, ApproverGroupID: &facilitiesID, MinDuration: 4 * time.Hour},
+		},
+	}
+	organizer := &domain.User{ID: uuid.New(), ManagerID: &managerID}
+	resource := &domain.Resource{ID: uuid.New(), OwnerID: &ownerID}
+
+	tests := []struct {
+		name      string
+		duration  time.Duration
+		wantSteps []domain.ApprovalStepType
+	}{
+		{
+			name:      "Short booking skips facilities",
+			duration:  2 * time.Hour,
+			wantSteps: []domain.ApprovalStepType{domain.ApprovalStepManager, domain.ApprovalStepResourceOwner},
+		},
+		{
+			name:      "Exactly four hours skips facilities",
+			duration:  4 * time.Hour,
+			wantSteps: []domain.ApprovalStepType{domain.ApprovalStepManager, domain.ApprovalStepResourceOwner},
+		},
+		{
+			name:      "Long booking includes facilities",
+			duration:  5 * time.Hour,
+			wantSteps: []domain.ApprovalStepType{domain.ApprovalStepManager, domain.ApprovalStepResourceOwner, domain.ApprovalStepApproverGroup},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := &domain.Reservation{ID: uuid.New(), StartAt: startAt, EndAt: startAt.Add(tt.duration)}
+
+			chain, err := policy.BuildChain(reservation, organizer, resource)
+			require.NoError(t, err)
+			require.Len(t, chain, len(tt.wantSteps))
+			for i, step := range chain {
+				assert.Equal(t, tt.wantSteps[i], step.StepType)
+				assert.Equal(t, domain.ApprovalStepStatusPending, step.Status)
+			}
+			assert.Equal(t, managerID, *chain[0].ApproverID)
+			assert.Equal(t, ownerID, *chain[1].ApproverID)
+		})
+	}
+}
+
+func TestApprovalPolicy_BuildChain_Unresolved(t *testing.T) {
+	reservation := &domain.Reservation{ID: uuid.New(), StartAt: time.Now(), EndAt: time.Now().Add(time.Hour)}
+
+	policy := &domain.ApprovalPolicy{
+		Name:  "manager-only",
+		Steps: []domain.ApprovalPolicyStep{{StepOrder: 1, Type: domain.ApprovalStepManager}},
+	}
+	_, err := policy.BuildChain(reservation, &domain.User{ID: uuid.New()}, &domain.Resource{})
+	assert.ErrorIs(t, err, domain.ErrManagerNotAssigned)
+
+	_, err = domain.DefaultApprovalPolicy().BuildChain(reservation, &domain.User{ID: uuid.New()}, &domain.Resource{})
+	assert.ErrorIs(t, err, domain.ErrApproverGroupNotAssigned)
+}
+
+func TestCurrentApprovalStep(t *testing.T) {
+	steps := []*domain.ReservationApproval{
+		{StepOrder: 1, Status: domain.ApprovalStepStatusApproved},
+		{StepOrder: 2, Status: domain.ApprovalStepStatusPending},
+		{StepOrder: 3, Status: domain.ApprovalStepStatusPending},
+	}
+
+	current := domain.CurrentApprovalStep(steps)
+	require.NotNil(t, current)
+	assert.Equal(t, 2, current.StepOrder)
+
+	steps[1].Status = domain.ApprovalStepStatusApproved
+	steps[2].Status = domain.ApprovalStepStatusApproved
+	assert.Nil(t, domain.CurrentApprovalStep(steps))
+}
+
+func TestApprovalPolicy_SLAActionFor(t *testing.T) {
+	policy := &domain.ApprovalPolicy{
+		Name:             "sla",
+		ReminderAfter:    24 * time.Hour,
+		EscalateAfter:    48 * time.Hour,
+		DecisionDeadline: 72 * time.Hour,
+		TimeoutAction:    domain.ApprovalTimeoutRelease,
+	}
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	tests := []struct {
+		name string
+		step *domain.ReservationApproval
+		want domain.ApprovalSLAAction
+	}{
+		{"within reminder window", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(time.Hour)}, domain.ApprovalSLAActionNone},
+		{"not activated", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending}, domain.ApprovalSLAActionNone},
+		{"remind", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(25 * time.Hour)}, domain.ApprovalSLAActionRemind},
+		{"already reminded", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(25 * time.Hour), RemindedAt: at(time.Hour)}, domain.ApprovalSLAActionNone},
+		{"escalate", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(49 * time.Hour), RemindedAt: at(time.Hour)}, domain.ApprovalSLAActionEscalate},
+		{"already escalated", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(50 * time.Hour), EscalatedAt: at(time.Hour)}, domain.ApprovalSLAActionNone},
+		{"timeout", &domain.ReservationApproval{Status: domain.ApprovalStepStatusPending, ActivatedAt: at(73 * time.Hour), EscalatedAt: at(time.Hour)}, domain.ApprovalSLAActionTimeout},
+		{"decided", &domain.ReservationApproval{Status: domain.ApprovalStepStatusApproved, ActivatedAt: at(73 * time.Hour)}, domain.ApprovalSLAActionNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.SLAActionFor(tt.step, now))
+		})
+	}
+}
+
+func TestApprovalPolicy_Validate_SLA(t *testing.T) {
+	policy := &domain.ApprovalPolicy{
+		Name:             "sla",
+		Steps:            []domain.ApprovalPolicyStep{{StepOrder: 1, Type: domain.ApprovalStepManager}},
+		DecisionDeadline: 72 * time.Hour,
+	}
+	assert.Error(t, policy.Validate())
+
+	policy.TimeoutAction = domain.ApprovalTimeoutAutoReject
+	assert.NoError(t, policy.Validate())
+
+	policy.TimeoutAction = "UNKNOWN"
+	assert.Error(t, policy.Validate())
+}
@@ -0,0 +1,27 @@
+// backend/internal/domain/approver_group.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApproverGroup は承認者グループを表す構造体
+// 承認が必要なリソース（役員会議室、社用車など）の承認担当者をまとめます
+type ApproverGroup struct {
+	ID          uuid.UUID // グループID
+	Name        string    // グループ名
+	Description string    // 説明
+	CreatedAt   time.Time // 作成日時
+	UpdatedAt   time.Time // 更新日時
+}
+
+// Validate は承認者グループの整合性を検証します
+func (g *ApproverGroup) Validate() error {
+	if g.Name == "" {
+		return errors.New("approver group name is required")
+	}
+	return nil
+}
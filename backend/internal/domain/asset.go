@@ -0,0 +1,42 @@
+// backend/internal/domain/asset.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Asset はプール型備品の個体（資産タグ単位）を表す構造体
+type Asset struct {
+	ID         uuid.UUID
+	ResourceID uuid.UUID // 所属するプール型備品
+	AssetTag   string    // 資産管理番号
+	IsActive   bool
+	Notes      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ReservationAsset は予約に割り当てられた備品の個体を表す構造体
+type ReservationAsset struct {
+	ReservationID      uuid.UUID
+	ReservationStartAt time.Time
+	AssetID            uuid.UUID
+	AssetTag           string
+	ResourceID         uuid.UUID
+	AssignedBy         *uuid.UUID
+	AssignedAt         time.Time
+}
+
+// Validate は備品個体の整合性を検証します
+func (a *Asset) Validate() error {
+	if a.ResourceID == uuid.Nil {
+		return errors.New("resource id is required")
+	}
+	if a.AssetTag == "" {
+		return errors.New("asset tag is required")
+	}
+	return nil
+}
@@ -26,8 +26,79 @@ const (
 	AuditActionCheckIn     AuditAction = "CHECK_IN"
 	AuditActionCancel      AuditAction = "CANCEL"
 	AuditActionForceCancel AuditAction = "FORCE_CANCEL"
+
+	// 承認SLAによる自動処理
+	AuditActionApprovalRemind   AuditAction = "APPROVAL_REMIND"
+	AuditActionApprovalEscalate AuditAction = "APPROVAL_ESCALATE"
+	AuditActionAutoApprove      AuditAction = "AUTO_APPROVE"
+	AuditActionAutoReject       AuditAction = "AUTO_REJECT"
+	AuditActionApprovalExpire   AuditAction = "APPROVAL_EXPIRE"
+
+	// ウェイトリスト
+	AuditActionWaitlistJoin   AuditAction = "WAITLIST_JOIN"
+	AuditActionWaitlistOffer  AuditAction = "WAITLIST_OFFER"
+	AuditActionWaitlistAccept AuditAction = "WAITLIST_ACCEPT"
+	AuditActionWaitlistPass   AuditAction = "WAITLIST_PASS"
+	AuditActionWaitlistExpire AuditAction = "WAITLIST_EXPIRE"
+	AuditActionWaitlistLeave  AuditAction = "WAITLIST_LEAVE"
+
+	// 備品の個体管理
+	AuditActionAssetRegister AuditAction = "ASSET_REGISTER"
+	AuditActionAssetAssign   AuditAction = "ASSET_ASSIGN"
+
+	// 備品の貸出・返却
+	AuditActionEquipmentCheckout AuditAction = "EQUIPMENT_CHECKOUT"
+	AuditActionEquipmentReturn   AuditAction = "EQUIPMENT_RETURN"
+	AuditActionEquipmentOverdue  AuditAction = "EQUIPMENT_OVERDUE"
+
+	// 予約フォーム入力中の仮押さえ
+	AuditActionHoldPlace   AuditAction = "HOLD_PLACE"
+	AuditActionHoldConvert AuditAction = "HOLD_CONVERT"
+	AuditActionHoldRelease AuditAction = "HOLD_RELEASE"
+	AuditActionHoldExpire  AuditAction = "HOLD_EXPIRE"
+
+	// 役員予約による会議室の優先確保（押しのけた予約は FORCE_CANCEL または BUMP_RELOCATE）
+	AuditActionBump         AuditAction = "BUMP"
+	AuditActionBumpRelocate AuditAction = "BUMP_RELOCATE"
+
+	// キャンセルペナルティスコア
+	AuditActionCancelWithPenalty AuditAction = "CANCEL_WITH_PENALTY"
+	AuditActionPenaltyAdjust     AuditAction = "PENALTY_ADJUST"
+	AuditActionPenaltyExpire     AuditAction = "PENALTY_EXPIRE"
+
+	// IdPのクレームによるロール・上長の同期
+	AuditActionRoleSync AuditAction = "ROLE_SYNC"
+
+	// IdPのユーザー（issuer, sub）との紐付け
+	AuditActionIdentityLink     AuditAction = "IDENTITY_LINK"
+	AuditActionEmailChange      AuditAction = "EMAIL_CHANGE"
+	AuditActionIdentityConflict AuditAction = "IDENTITY_CONFLICT"
+	AuditActionIdentityResolve  AuditAction = "IDENTITY_RESOLVE"
+
+	// SCIM によるユーザー・グループのプロビジョニング
+	AuditActionProvisionUserCreate     AuditAction = "PROVISION_USER_CREATE"
+	AuditActionProvisionUserUpdate     AuditAction = "PROVISION_USER_UPDATE"
+	AuditActionProvisionUserDeactivate AuditAction = "PROVISION_USER_DEACTIVATE"
+	AuditActionProvisionUserDelete     AuditAction = "PROVISION_USER_DELETE"
+	AuditActionProvisionGroupCreate    AuditAction = "PROVISION_GROUP_CREATE"
+	AuditActionProvisionGroupUpdate    AuditAction = "PROVISION_GROUP_UPDATE"
+	AuditActionProvisionGroupDelete    AuditAction = "PROVISION_GROUP_DELETE"
+
+	// 個人用アクセストークン・サービスアカウントのAPIキー
+	AuditActionAPITokenCreate        AuditAction = "API_TOKEN_CREATE"
+	AuditActionAPITokenRevoke        AuditAction = "API_TOKEN_REVOKE"
+	AuditActionAPITokenUse           AuditAction = "API_TOKEN_USE"
+	AuditActionServiceAccountCreate  AuditAction = "SERVICE_ACCOUNT_CREATE"
+	AuditActionServiceAccountDisable AuditAction = "SERVICE_ACCOUNT_DISABLE"
+
+	// セッションの無効化（1件ごと、利用者・管理者による無効化と同時セッション数の上限による無効化）
+	AuditActionSessionRevoke AuditAction = "SESSION_REVOKE"
 )
 
+// SystemUserID はシステム（ワーカー等）による操作を表すユーザーID
+// 監査ログでは user_id が NULL として記録されます
+var SystemUserID = uuid.Nil
+
 // AuditLog は監査ログエンティティを表す構造体
 type AuditLog struct {
 	ID         uuid.UUID
@@ -0,0 +1,38 @@
+// backend/internal/domain/bump.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BumpOutcome は優先確保で押しのけられた回の扱いを表す型
+type BumpOutcome string
+
+const (
+	BumpOutcomeMoved     BumpOutcome = "MOVED"     // 同等の空き会議室へ移動
+	BumpOutcomeCancelled BumpOutcome = "CANCELLED" // 強制キャンセル
+)
+
+// ReservationBump は優先確保で押しのけられた予約の記録を表す構造体
+// 押しのけられた回は主催者の都合によるキャンセルではないため、ペナルティの対象外とします
+type ReservationBump struct {
+	ID                     uuid.UUID
+	ResourceID             uuid.UUID  // 優先確保した会議室
+	ReservationID          uuid.UUID  // 優先確保で作成した予約
+	BumpedBy               uuid.UUID  // 実行したユーザー（秘書の場合は代理実行者）
+	OnBehalfOf             *uuid.UUID // 秘書が代理で確保した場合の役員
+	DisplacedReservationID uuid.UUID
+	DisplacedInstanceID    uuid.UUID
+	DisplacedOrganizerID   uuid.UUID
+	Outcome                BumpOutcome
+	ReplacementResourceID  *uuid.UUID // 移動先の会議室（MOVED の場合）
+	Reason                 string
+	CreatedAt              time.Time
+}
+
+// IsMoved は押しのけられた回が別の会議室へ移動されたかどうかを判定します
+func (b *ReservationBump) IsMoved() bool {
+	return b.Outcome == BumpOutcomeMoved
+}
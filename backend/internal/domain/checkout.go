@@ -0,0 +1,105 @@
+// backend/internal/domain/checkout.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAssetCondition = errors.New("invalid asset condition")
+	ErrCheckoutAlreadyClosed = errors.New("checkout is already returned")
+)
+
+// AssetCondition は貸出・返却時の備品の状態を表す型
+type AssetCondition string
+
+const (
+	AssetConditionGood    AssetCondition = "GOOD"    // 問題なし
+	AssetConditionFair    AssetCondition = "FAIR"    // 軽微な傷・汚れあり
+	AssetConditionDamaged AssetCondition = "DAMAGED" // 破損あり
+)
+
+// IsValid は備品の状態が定義済みの値かを判定します
+func (c AssetCondition) IsValid() bool {
+	switch c {
+	case AssetConditionGood, AssetConditionFair, AssetConditionDamaged:
+		return true
+	}
+	return false
+}
+
+// EquipmentCheckout は備品の個体の貸出（持ち出しから返却まで）を表す構造体
+type EquipmentCheckout struct {
+	ID                    uuid.UUID
+	AssetID               uuid.UUID
+	AssetTag              string
+	ReservationID         uuid.UUID
+	ReservationStartAt    time.Time
+	ReservationInstanceID uuid.UUID // 貸出対象の予約インスタンス（返却期限の基準）
+	BorrowerID            uuid.UUID // 借用者（予約者）
+	CheckedOutBy          uuid.UUID // 貸出を記録したユーザー
+	CheckedOutAt          time.Time
+	CheckoutCondition     AssetCondition
+	CheckoutNotes         string
+	DueAt                 time.Time // 返却期限（予約インスタンスの終了日時）
+	ReturnedAt            *time.Time
+	ReturnedBy            *uuid.UUID
+	ReturnCondition       AssetCondition
+	DamageNotes           string
+	OverdueNotifiedAt     *time.Time // 返却遅延を借用者へ通知した日時
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// Validate は貸出記録の整合性を検証します
+func (c *EquipmentCheckout) Validate() error {
+	if c.AssetID == uuid.Nil {
+		return errors.New("asset id is required")
+	}
+	if c.BorrowerID == uuid.Nil {
+		return errors.New("borrower id is required")
+	}
+	if !c.CheckoutCondition.IsValid() {
+		return ErrInvalidAssetCondition
+	}
+	if !c.CheckedOutAt.Before(c.DueAt) {
+		return errors.New("checkout time must be before due time")
+	}
+	return nil
+}
+
+// IsOpen は未返却の貸出かどうかを判定します
+func (c *EquipmentCheckout) IsOpen() bool {
+	return c.ReturnedAt == nil
+}
+
+// IsOverdue は返却期限を過ぎても返却されていないかを判定します
+func (c *EquipmentCheckout) IsOverdue(now time.Time) bool {
+	return c.IsOpen() && now.After(c.DueAt)
+}
+
+// IsReturnedEarly は返却期限より前に返却されたかを判定します
+func (c *EquipmentCheckout) IsReturnedEarly() bool {
+	return c.ReturnedAt != nil && c.ReturnedAt.Before(c.DueAt)
+}
+
+// Return は返却を記録します。破損ありの場合は状況の記載が必要です
+func (c *EquipmentCheckout) Return(returnedBy uuid.UUID, returnedAt time.Time, condition AssetCondition, damageNotes string) error {
+	if !c.IsOpen() {
+		return ErrCheckoutAlreadyClosed
+	}
+	if !condition.IsValid() {
+		return ErrInvalidAssetCondition
+	}
+	if condition == AssetConditionDamaged && damageNotes == "" {
+		return errors.New("damage notes are required for damaged assets")
+	}
+	c.ReturnedAt = &returnedAt
+	c.ReturnedBy = &returnedBy
+	c.ReturnCondition = condition
+	c.DamageNotes = damageNotes
+	return nil
+}
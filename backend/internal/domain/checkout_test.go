@@ -0,0 +1,69 @@
+// backend/internal/domain/checkout_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestEquipmentCheckout_Return(t *testing.T) {
+	now := time.Now()
+	returnedBy := uuid.New()
+
+	tests := []struct {
+		name        string
+		returned    bool
+		condition   domain.AssetCondition
+		damageNotes string
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{name: "Good condition", condition: domain.AssetConditionGood},
+		{name: "Damaged with notes", condition: domain.AssetConditionDamaged, damageNotes: "Cracked lens"},
+		{name: "Damaged without notes", condition: domain.AssetConditionDamaged, wantAnyErr: true},
+		{name: "Invalid condition", condition: "BROKEN", wantErr: domain.ErrInvalidAssetCondition},
+		{name: "Already returned", returned: true, condition: domain.AssetConditionGood, wantErr: domain.ErrCheckoutAlreadyClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkout := &domain.EquipmentCheckout{CheckedOutAt: now.Add(-time.Hour), DueAt: now.Add(time.Hour)}
+			if tt.returned {
+				returnedAt := now.Add(-30 * time.Minute)
+				checkout.ReturnedAt = &returnedAt
+			}
+
+			err := checkout.Return(returnedBy, now, tt.condition, tt.damageNotes)
+
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+				assert.True(t, checkout.IsOpen())
+			default:
+				assert.NoError(t, err)
+				assert.False(t, checkout.IsOpen())
+				assert.Equal(t, tt.condition, checkout.ReturnCondition)
+				assert.True(t, checkout.IsReturnedEarly())
+			}
+		})
+	}
+}
+
+func TestEquipmentCheckout_IsOverdue(t *testing.T) {
+	now := time.Now()
+	dueAt := now.Add(-time.Minute)
+
+	open := &domain.EquipmentCheckout{DueAt: dueAt}
+	assert.True(t, open.IsOverdue(now))
+	assert.False(t, open.IsOverdue(dueAt.Add(-time.Second)))
+
+	returned := &domain.EquipmentCheckout{DueAt: dueAt, ReturnedAt: &now}
+	assert.False(t, returned.IsOverdue(now))
+	assert.False(t, returned.IsReturnedEarly())
+}
@@ -0,0 +1,58 @@
+// backend/internal/domain/hold.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HoldStatus は仮押さえの状態を表す型
+type HoldStatus string
+
+const (
+	HoldStatusActive    HoldStatus = "ACTIVE"    // 仮押さえ中
+	HoldStatusConverted HoldStatus = "CONVERTED" // 予約に変換済み
+	HoldStatusReleased  HoldStatus = "RELEASED"  // 本人が解放
+	HoldStatusExpired   HoldStatus = "EXPIRED"   // 有効期限切れ
+)
+
+// ResourceHold は予約フォーム入力中にリソースと時間帯を一時的に確保する仮押さえを表す構造体
+// 枠の占有は TENTATIVE のインスタンスを持つ仮押さえ予約 (ReservationID) で行います
+type ResourceHold struct {
+	ID                     uuid.UUID
+	UserID                 uuid.UUID
+	ReservationID          uuid.UUID // 枠を占有する仮押さえ予約（開始日時は StartAt と同じ）
+	StartAt                time.Time
+	EndAt                  time.Time
+	ExpiresAt              time.Time
+	Status                 HoldStatus
+	ConvertedReservationID *uuid.UUID
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+
+	// ResourceUnits は仮押さえしたリソースと数量（仮押さえ予約の割り当てから読み込む）
+	ResourceUnits map[uuid.UUID]int
+}
+
+// IsActive は仮押さえが有効期限内かどうかを判定します
+func (h *ResourceHold) IsActive(now time.Time) bool {
+	return h.Status == HoldStatusActive && now.Before(h.ExpiresAt)
+}
+
+// Covers は期間 [startAt, endAt) が仮押さえした時間帯に収まるかを判定します
+func (h *ResourceHold) Covers(startAt, endAt time.Time) bool {
+	return !startAt.Before(h.StartAt) && !endAt.After(h.EndAt)
+}
+
+// Guarantees は仮押さえによってリソースを指定数量だけ確保済みかを判定します
+func (h *ResourceHold) Guarantees(resourceID uuid.UUID, units int) bool {
+	held, ok := h.ResourceUnits[resourceID]
+	if !ok {
+		return false
+	}
+	if units < 1 {
+		units = 1
+	}
+	return held >= units
+}
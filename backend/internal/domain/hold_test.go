@@ -0,0 +1,42 @@
+// backend/internal/domain/hold_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestResourceHold_IsActive(t *testing.T) {
+	now := time.Now()
+	hold := &domain.ResourceHold{Status: domain.HoldStatusActive, ExpiresAt: now.Add(5 * time.Minute)}
+
+	assert.True(t, hold.IsActive(now))
+	assert.False(t, hold.IsActive(now.Add(5*time.Minute)))
+
+	hold.Status = domain.HoldStatusConverted
+	assert.False(t, hold.IsActive(now))
+}
+
+func TestResourceHold_CoversAndGuarantees(t *testing.T) {
+	startAt := time.Date(2026, 4, 1, 10, 0, 0, 0, time.UTC)
+	roomID, laptopID := uuid.New(), uuid.New()
+	hold := &domain.ResourceHold{
+		StartAt:       startAt,
+		EndAt:         startAt.Add(2 * time.Hour),
+		ResourceUnits: map[uuid.UUID]int{roomID: 1, laptopID: 3},
+	}
+
+	assert.True(t, hold.Covers(startAt, startAt.Add(2*time.Hour)))
+	assert.True(t, hold.Covers(startAt.Add(30*time.Minute), startAt.Add(time.Hour)))
+	assert.False(t, hold.Covers(startAt.Add(-time.Minute), startAt.Add(time.Hour)))
+	assert.False(t, hold.Covers(startAt, startAt.Add(3*time.Hour)))
+
+	assert.True(t, hold.Guarantees(roomID, 0))
+	assert.True(t, hold.Guarantees(laptopID, 3))
+	assert.False(t, hold.Guarantees(laptopID, 4))
+	assert.False(t, hold.Guarantees(uuid.New(), 1))
+}
@@ -0,0 +1,92 @@
+// backend/internal/domain/identity.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidIdentityResolution = errors.New("invalid identity resolution")
+)
+
+// IdentityConflictKind はIdPのユーザーとの突合で自動判定できなかった事象の種類を表す型
+type IdentityConflictKind string
+
+const (
+	// IdentityConflictEmailLinked は初回ログインのメールアドレスが別の (issuer, sub) に紐付いたユーザーと一致した
+	// （メールアドレスの再利用や IdP の移行が考えられるため自動では紐付けず、ログインを拒否します）
+	IdentityConflictEmailLinked IdentityConflictKind = "EMAIL_LINKED_TO_OTHER_SUBJECT"
+	// IdentityConflictMultipleEmail は初回ログインのメールアドレスが複数のユーザーと一致した（ログインを拒否します）
+	IdentityConflictMultipleEmail IdentityConflictKind = "MULTIPLE_EMAIL_MATCHES"
+	// IdentityConflictEmailTaken は連携済みユーザーの変更後のメールアドレスが他のユーザーで使用されている
+	// （ログインは許可し、メールアドレスは変更前のまま維持します）
+	IdentityConflictEmailTaken IdentityConflictKind = "EMAIL_TAKEN"
+)
+
+// IdentityResolution は突合レポートの解決方法を表す型
+type IdentityResolution string
+
+const (
+	IdentityResolutionLink    IdentityResolution = "LINK"    // 候補のユーザーに (issuer, sub) を紐付ける
+	IdentityResolutionCreate  IdentityResolution = "CREATE"  // 新しいユーザーとして作成する
+	IdentityResolutionDismiss IdentityResolution = "DISMISS" // 対応不要として閉じる
+)
+
+// IsValid は定義済みの解決方法かどうかを判定します
+func (r IdentityResolution) IsValid() bool {
+	switch r {
+	case IdentityResolutionLink, IdentityResolutionCreate, IdentityResolutionDismiss:
+		return true
+	}
+	return false
+}
+
+// IdentityConflict はIdPのユーザーとの突合レポートの1件を表す構造体
+type IdentityConflict struct {
+	ID               uuid.UUID            `json:"id"`
+	Kind             IdentityConflictKind `json:"kind"`
+	Issuer           string               `json:"issuer"`
+	Subject          string               `json:"sub"`
+	Email            string               `json:"email"` // IdPから受け取ったメールアドレス
+	Name             string               `json:"name"`
+	UserID           *uuid.UUID           `json:"user_id,omitempty"`  // 連携済みのユーザー（EMAIL_TAKEN の場合）
+	CandidateUserIDs []uuid.UUID          `json:"candidate_user_ids"` // メールアドレスが一致したユーザー
+	DetectedAt       time.Time            `json:"detected_at"`
+	ResolvedAt       *time.Time           `json:"resolved_at,omitempty"`
+	ResolvedBy       *uuid.UUID           `json:"resolved_by,omitempty"`
+	Resolution       *IdentityResolution  `json:"resolution,omitempty"`
+	ResolutionNote   string               `json:"resolution_note,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+// IsResolved は解決済みかどうかを判定します
+func (c *IdentityConflict) IsResolved() bool {
+	return c.ResolvedAt != nil
+}
+
+// CanResolve は解決方法がこの事象に適用できるかを判定します
+// EMAIL_TAKEN は (issuer, sub) が既に連携済みのため、LINK・CREATE は適用できません
+// LINK の場合は候補のユーザーのいずれかを指定する必要があります
+func (c *IdentityConflict) CanResolve(resolution IdentityResolution, userID *uuid.UUID) bool {
+	if c.IsResolved() || !resolution.IsValid() {
+		return false
+	}
+	if c.Kind == IdentityConflictEmailTaken {
+		return resolution == IdentityResolutionDismiss
+	}
+	if resolution != IdentityResolutionLink {
+		return true
+	}
+	if userID == nil {
+		return false
+	}
+	for _, candidate := range c.CandidateUserIDs {
+		if candidate == *userID {
+			return true
+		}
+	}
+	return false
+}
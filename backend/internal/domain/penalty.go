@@ -0,0 +1,134 @@
+// backend/internal/domain/penalty.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPenaltyThreshold = errors.New("invalid penalty threshold")
+)
+
+const (
+	// PenaltyScoreRotation はペナルティイベントがスコアから消滅するまでの期間（90日ローテーション）
+	PenaltyScoreRotation = 90 * 24 * time.Hour
+	// LateCancellationWindow は開始日時のこの期間内のキャンセルをペナルティの対象とする
+	LateCancellationWindow = 24 * time.Hour
+)
+
+// PenaltyEventKind はペナルティスコアを変動させた事象の種類を表す型
+type PenaltyEventKind string
+
+const (
+	PenaltyEventLateCancellation PenaltyEventKind = "LATE_CANCELLATION" // 開始24時間以内のキャンセル
+	PenaltyEventAdminAdjustment  PenaltyEventKind = "ADMIN_ADJUSTMENT"  // 管理者による調整
+)
+
+// PenaltyEvent はペナルティスコアの加算・調整の記録を表す構造体
+// スコアは ExpiresAt を過ぎていないイベントの Points の合計です
+type PenaltyEvent struct {
+	ID                 uuid.UUID        `json:"id"`
+	UserID             uuid.UUID        `json:"user_id"`
+	Kind               PenaltyEventKind `json:"kind"`
+	Points             int              `json:"points"`
+	ReservationID      *uuid.UUID       `json:"reservation_id,omitempty"`
+	ReservationStartAt *time.Time       `json:"reservation_start_at,omitempty"` // 原因となった回の開始日時
+	Reason             string           `json:"reason"`
+	CreatedBy          *uuid.UUID       `json:"created_by,omitempty"` // 管理者による調整の場合の実行者
+	OccurredAt         time.Time        `json:"occurred_at"`
+	ExpiresAt          time.Time        `json:"expires_at"`
+	ExpiredAt          *time.Time       `json:"expired_at,omitempty"` // 減衰ジョブでスコアから除外した日時
+	CreatedAt          time.Time        `json:"created_at"`
+}
+
+// IsActive はイベントが日時 now の時点でスコアに含まれるかを判定します
+func (e *PenaltyEvent) IsActive(now time.Time) bool {
+	return e.ExpiredAt == nil && now.Before(e.ExpiresAt)
+}
+
+// IsLateCancellation は日時 now のキャンセルが開始日時 startAt の回に対する直前キャンセルかを判定します
+// 開始済みでまだ終了していない回のキャンセルも含みます
+func IsLateCancellation(startAt, endAt, now time.Time) bool {
+	return endAt.After(now) && startAt.Before(now.Add(LateCancellationWindow))
+}
+
+// PenaltyThreshold はペナルティスコアに応じた予約制限を表す構造体
+// スコアが MinScore 以上のユーザーに適用し、複数該当する場合は最も厳しい制限を組み合わせます
+type PenaltyThreshold struct {
+	ID                 uuid.UUID `json:"id"`
+	Name               string    `json:"name"`
+	MinScore           int       `json:"min_score"`
+	RequiresApproval   bool      `json:"requires_approval"`              // 上長の承認を必須にする
+	MaxAdvanceDays     *int      `json:"max_advance_days,omitempty"`     // 予約可能な期間（開始日時までの日数）の上限
+	PremiumMinCapacity *int      `json:"premium_min_capacity,omitempty"` // この収容人数以上の会議室を予約できなくする
+	IsActive           bool      `json:"is_active"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// Validate はペナルティの閾値の整合性を検証します
+func (t *PenaltyThreshold) Validate() error {
+	if t.Name == "" || t.MinScore < 1 {
+		return ErrInvalidPenaltyThreshold
+	}
+	if t.MaxAdvanceDays != nil && *t.MaxAdvanceDays < 0 {
+		return ErrInvalidPenaltyThreshold
+	}
+	if t.PremiumMinCapacity != nil && *t.PremiumMinCapacity < 1 {
+		return ErrInvalidPenaltyThreshold
+	}
+	if !t.RequiresApproval && t.MaxAdvanceDays == nil && t.PremiumMinCapacity == nil {
+		return ErrInvalidPenaltyThreshold
+	}
+	return nil
+}
+
+// PenaltyRestriction はユーザーに適用される予約制限を表す構造体
+type PenaltyRestriction struct {
+	Score              int  `json:"score"`
+	RequiresApproval   bool `json:"requires_approval"`
+	MaxAdvanceDays     *int `json:"max_advance_days,omitempty"`
+	PremiumMinCapacity *int `json:"premium_min_capacity,omitempty"`
+}
+
+// RestrictionFor はスコアに該当する閾値の制限を組み合わせて返します（該当しない場合は nil）
+func RestrictionFor(score int, thresholds []*PenaltyThreshold) *PenaltyRestriction {
+	var restriction *PenaltyRestriction
+	for _, t := range thresholds {
+		if !t.IsActive || score < t.MinScore {
+			continue
+		}
+		if restriction == nil {
+			restriction = &PenaltyRestriction{Score: score}
+		}
+		if t.RequiresApproval {
+			restriction.RequiresApproval = true
+		}
+		if t.MaxAdvanceDays != nil && (restriction.MaxAdvanceDays == nil || *t.MaxAdvanceDays < *restriction.MaxAdvanceDays) {
+			restriction.MaxAdvanceDays = t.MaxAdvanceDays
+		}
+		if t.PremiumMinCapacity != nil && (restriction.PremiumMinCapacity == nil || *t.PremiumMinCapacity < *restriction.PremiumMinCapacity) {
+			restriction.PremiumMinCapacity = t.PremiumMinCapacity
+		}
+	}
+	return restriction
+}
+
+// Blocks はリソースが制限により予約できない高額会議室かを判定します
+func (r *PenaltyRestriction) Blocks(resource *Resource) bool {
+	if r.PremiumMinCapacity == nil || resource.Type != ResourceTypeMeetingRoom || resource.Capacity == nil {
+		return false
+	}
+	return *resource.Capacity >= *r.PremiumMinCapacity
+}
+
+// AllowsStart は開始日時 startAt が日時 now から予約可能な期間内かを判定します
+func (r *PenaltyRestriction) AllowsStart(startAt, now time.Time) bool {
+	if r.MaxAdvanceDays == nil {
+		return true
+	}
+	return !startAt.After(now.AddDate(0, 0, *r.MaxAdvanceDays))
+}
@@ -0,0 +1,83 @@
+// backend/internal/domain/penalty_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestIsLateCancellation(t *testing.T) {
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC)
+
+	assert.True(t, domain.IsLateCancellation(now.Add(23*time.Hour), now.Add(24*time.Hour), now))
+	assert.False(t, domain.IsLateCancellation(now.Add(25*time.Hour), now.Add(26*time.Hour), now))
+	// 開始済みで終了前の回も直前キャンセル
+	assert.True(t, domain.IsLateCancellation(now.Add(-time.Hour), now.Add(time.Hour), now))
+	// 終了済みの回は対象外
+	assert.False(t, domain.IsLateCancellation(now.Add(-2*time.Hour), now.Add(-time.Hour), now))
+}
+
+func TestPenaltyThreshold_Validate(t *testing.T) {
+	days, negative := 7, -1
+
+	valid := func() *domain.PenaltyThreshold {
+		return &domain.PenaltyThreshold{Name: "Short window", MinScore: 3, MaxAdvanceDays: &days}
+	}
+	assert.NoError(t, valid().Validate())
+
+	threshold := valid()
+	threshold.MinScore = 0
+	assert.ErrorIs(t, threshold.Validate(), domain.ErrInvalidPenaltyThreshold)
+
+	threshold = valid()
+	threshold.MaxAdvanceDays = &negative
+	assert.ErrorIs(t, threshold.Validate(), domain.ErrInvalidPenaltyThreshold)
+
+	// 制限を1つも持たない閾値は無効
+	threshold = valid()
+	threshold.MaxAdvanceDays = nil
+	assert.ErrorIs(t, threshold.Validate(), domain.ErrInvalidPenaltyThreshold)
+}
+
+func TestRestrictionFor(t *testing.T) {
+	fourteen, seven, twelve := 14, 7, 12
+	thresholds := []*domain.PenaltyThreshold{
+		{MinScore: 2, MaxAdvanceDays: &fourteen, IsActive: true},
+		{MinScore: 3, PremiumMinCapacity: &twelve, MaxAdvanceDays: &seven, IsActive: true},
+		{MinScore: 3, RequiresApproval: true, IsActive: false},
+		{MinScore: 5, RequiresApproval: true, IsActive: true},
+	}
+
+	assert.Nil(t, domain.RestrictionFor(1, thresholds))
+
+	restriction := domain.RestrictionFor(2, thresholds)
+	assert.Equal(t, 14, *restriction.MaxAdvanceDays)
+	assert.Nil(t, restriction.PremiumMinCapacity)
+
+	// 複数該当する場合は最も厳しい制限を組み合わせる（無効な閾値は除く）
+	restriction = domain.RestrictionFor(4, thresholds)
+	assert.Equal(t, 4, restriction.Score)
+	assert.Equal(t, 7, *restriction.MaxAdvanceDays)
+	assert.Equal(t, 12, *restriction.PremiumMinCapacity)
+	assert.False(t, restriction.RequiresApproval)
+
+	assert.True(t, domain.RestrictionFor(5, thresholds).RequiresApproval)
+}
+
+func TestPenaltyRestriction_BlocksAndAllowsStart(t *testing.T) {
+	twelve, seven := 12, 7
+	restriction := &domain.PenaltyRestriction{PremiumMinCapacity: &twelve, MaxAdvanceDays: &seven}
+
+	large, small := 20, 6
+	assert.True(t, restriction.Blocks(&domain.Resource{Type: domain.ResourceTypeMeetingRoom, Capacity: &large}))
+	assert.False(t, restriction.Blocks(&domain.Resource{Type: domain.ResourceTypeMeetingRoom, Capacity: &small}))
+	assert.False(t, restriction.Blocks(&domain.Resource{Type: domain.ResourceTypeEquipment, Capacity: &large}))
+
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, time.UTC)
+	assert.True(t, restriction.AllowsStart(now.AddDate(0, 0, 7), now))
+	assert.False(t, restriction.AllowsStart(now.AddDate(0, 0, 8), now))
+	assert.True(t, (&domain.PenaltyRestriction{}).AllowsStart(now.AddDate(1, 0, 0), now))
+}
@@ -0,0 +1,39 @@
+// backend/internal/domain/provisioned_group.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProvisionedGroup はIdPからSCIMでプロビジョニングされたグループを表す構造体
+// グループの表示名（または externalId）をロールのマッピングのルールと照合し、メンバーのロールを決定します
+type ProvisionedGroup struct {
+	ID          uuid.UUID   // グループID
+	ExternalID  string      // プロビジョニング元のIdPでのグループの識別子（空の場合は未設定）
+	DisplayName string      // 表示名（一意）
+	MemberIDs   []uuid.UUID // メンバーのユーザーID
+	CreatedAt   time.Time   // 作成日時
+	UpdatedAt   time.Time   // 更新日時
+}
+
+// HasMember は指定したユーザーがメンバーかどうかを判定します
+func (g *ProvisionedGroup) HasMember(userID uuid.UUID) bool {
+	for _, id := range g.MemberIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleValues はロールのマッピングのルールと照合する値（表示名と externalId）を返します
+// グループのクレームにグループ名ではなくIDを含めるIdPもあるため、両方を照合します
+func (g *ProvisionedGroup) RoleValues() []string {
+	values := []string{g.DisplayName}
+	if g.ExternalID != "" {
+		values = append(values, g.ExternalID)
+	}
+	return values
+}
@@ -0,0 +1,171 @@
+// backend/internal/domain/quota.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidQuotaRule = errors.New("invalid quota rule")
+)
+
+// QuotaScope は予約上限の集計単位を表す型
+type QuotaScope string
+
+const (
+	QuotaScopeUser       QuotaScope = "USER"       // 主催者ごと
+	QuotaScopeDepartment QuotaScope = "DEPARTMENT" // 主催者の所属部署ごと
+)
+
+// IsValid は集計単位が定義済みの値かを判定します
+func (s QuotaScope) IsValid() bool {
+	return s == QuotaScopeUser || s == QuotaScopeDepartment
+}
+
+// QuotaKind は予約上限の種類を表す型
+type QuotaKind string
+
+const (
+	QuotaKindWeeklyHours        QuotaKind = "WEEKLY_HOURS"        // 週間（月曜始まり）の利用時間
+	QuotaKindMonthlyHours       QuotaKind = "MONTHLY_HOURS"       // 月間の利用時間
+	QuotaKindConcurrentBookings QuotaKind = "CONCURRENT_BOOKINGS" // 終了していない予約（回）の数
+)
+
+// IsValid は上限の種類が定義済みの値かを判定します
+func (k QuotaKind) IsValid() bool {
+	switch k {
+	case QuotaKindWeeklyHours, QuotaKindMonthlyHours, QuotaKindConcurrentBookings:
+		return true
+	}
+	return false
+}
+
+// IsHours は利用時間の上限かを判定します
+func (k QuotaKind) IsHours() bool {
+	return k == QuotaKindWeeklyHours || k == QuotaKindMonthlyHours
+}
+
+// QuotaRule は予約上限のルールを表す構造体
+// 対象のリソース（ResourceType と MinCapacity）を含む予約の回を集計し、Limit を超える予約を拒否します
+type QuotaRule struct {
+	ID           uuid.UUID
+	Name         string
+	Scope        QuotaScope
+	Kind         QuotaKind
+	ResourceType *ResourceType // 対象のリソース種別（nil は全種別）
+	MinCapacity  *int          // 対象の会議室の収容人数の下限（大会議室など）
+	Department   *string       // DEPARTMENT スコープで対象とする部署（nil は全部署それぞれ）
+	Limit        int           // 上限（利用時間は時間単位、同時予約数は件数）
+	IsActive     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Validate は予約上限のルールの整合性を検証します
+func (q *QuotaRule) Validate() error {
+	if q.Name == "" || !q.Scope.IsValid() || !q.Kind.IsValid() || q.Limit < 0 {
+		return ErrInvalidQuotaRule
+	}
+	if q.ResourceType != nil && !q.ResourceType.IsValid() {
+		return ErrInvalidQuotaRule
+	}
+	if q.MinCapacity != nil && *q.MinCapacity < 1 {
+		return ErrInvalidQuotaRule
+	}
+	if q.Department != nil && q.Scope != QuotaScopeDepartment {
+		return ErrInvalidQuotaRule
+	}
+	return nil
+}
+
+// AppliesTo はリソースがルールの対象かを判定します
+func (q *QuotaRule) AppliesTo(resource *Resource) bool {
+	if q.ResourceType != nil && resource.Type != *q.ResourceType {
+		return false
+	}
+	if q.MinCapacity != nil && (resource.Capacity == nil || *resource.Capacity < *q.MinCapacity) {
+		return false
+	}
+	return true
+}
+
+// AppliesToUser はユーザーの予約がルールの対象かを判定します
+// 部署単位のルールは部署が設定されたユーザーのみが対象です
+func (q *QuotaRule) AppliesToUser(user *User) bool {
+	if q.Scope == QuotaScopeUser {
+		return true
+	}
+	if user.Department == nil || *user.Department == "" {
+		return false
+	}
+	return q.Department == nil || *q.Department == *user.Department
+}
+
+// Period は日時 at を含む集計期間 [start, end) を loc のタイムゾーンで返します
+// 週間は月曜0時から、月間は1日0時からの期間です。同時予約数のルールでは at から上限なしとして扱います
+func (q *QuotaRule) Period(at time.Time, loc *time.Location) (time.Time, time.Time) {
+	local := at.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	switch q.Kind {
+	case QuotaKindWeeklyHours:
+		offset := (int(day.Weekday()) + 6) % 7
+		start := day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	case QuotaKindMonthlyHours:
+		start := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	}
+	return at, time.Time{}
+}
+
+// LimitUnits は上限を集計単位（利用時間は分、同時予約数は件数）で返します
+func (q *QuotaRule) LimitUnits() int {
+	if q.Kind.IsHours() {
+		return q.Limit * 60
+	}
+	return q.Limit
+}
+
+// QuotaUsage は予約上限のルールごとの消費状況を表す構造体
+// 利用時間のルールは時間単位（小数）で、同時予約数のルールは件数で表します
+type QuotaUsage struct {
+	RuleID      uuid.UUID  `json:"rule_id"`
+	Name        string     `json:"name"`
+	Scope       QuotaScope `json:"scope"`
+	Kind        QuotaKind  `json:"kind"`
+	Department  *string    `json:"department,omitempty"`
+	Used        float64    `json:"used"`
+	Limit       int        `json:"limit"`
+	Remaining   float64    `json:"remaining"`
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
+}
+
+// NewQuotaUsage は集計単位の消費量 used からルールの消費状況を作成します
+func NewQuotaUsage(rule *QuotaRule, department *string, used int) *QuotaUsage {
+	usage := &QuotaUsage{
+		RuleID: rule.ID,
+		Name:   rule.Name,
+		Scope:  rule.Scope,
+		Kind:   rule.Kind,
+		Limit:  rule.Limit,
+	}
+	if rule.Scope == QuotaScopeDepartment {
+		usage.Department = department
+	}
+	remaining := rule.LimitUnits() - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	if rule.Kind.IsHours() {
+		usage.Used = float64(used) / 60
+		usage.Remaining = float64(remaining) / 60
+	} else {
+		usage.Used = float64(used)
+		usage.Remaining = float64(remaining)
+	}
+	return usage
+}
@@ -0,0 +1,113 @@
+// backend/internal/domain/quota_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestQuotaRule_Validate(t *testing.T) {
+	meetingRoom := domain.ResourceTypeMeetingRoom
+	sales := "Sales"
+	zero := 0
+
+	valid := func() *domain.QuotaRule {
+		return &domain.QuotaRule{
+			Name:         "Large rooms",
+			Scope:        domain.QuotaScopeDepartment,
+			Kind:         domain.QuotaKindMonthlyHours,
+			ResourceType: &meetingRoom,
+			Department:   &sales,
+			Limit:        40,
+		}
+	}
+	assert.NoError(t, valid().Validate())
+
+	rule := valid()
+	rule.Name = ""
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidQuotaRule)
+
+	rule = valid()
+	rule.Kind = "DAILY_HOURS"
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidQuotaRule)
+
+	rule = valid()
+	rule.Limit = -1
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidQuotaRule)
+
+	rule = valid()
+	rule.MinCapacity = &zero
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidQuotaRule)
+
+	// 部署の指定は部署単位のルールのみ
+	rule = valid()
+	rule.Scope = domain.QuotaScopeUser
+	assert.ErrorIs(t, rule.Validate(), domain.ErrInvalidQuotaRule)
+}
+
+func TestQuotaRule_AppliesTo(t *testing.T) {
+	meetingRoom := domain.ResourceTypeMeetingRoom
+	minCapacity := 12
+	rule := &domain.QuotaRule{ResourceType: &meetingRoom, MinCapacity: &minCapacity}
+
+	large, small := 20, 6
+	assert.True(t, rule.AppliesTo(&domain.Resource{Type: domain.ResourceTypeMeetingRoom, Capacity: &large}))
+	assert.False(t, rule.AppliesTo(&domain.Resource{Type: domain.ResourceTypeMeetingRoom, Capacity: &small}))
+	assert.False(t, rule.AppliesTo(&domain.Resource{Type: domain.ResourceTypeMeetingRoom}))
+	assert.False(t, rule.AppliesTo(&domain.Resource{Type: domain.ResourceTypeEquipment, Capacity: &large}))
+
+	assert.True(t, (&domain.QuotaRule{}).AppliesTo(&domain.Resource{Type: domain.ResourceTypeParking}))
+}
+
+func TestQuotaRule_AppliesToUser(t *testing.T) {
+	sales, legal := "Sales", "Legal"
+	user := &domain.User{Department: &sales}
+
+	assert.True(t, (&domain.QuotaRule{Scope: domain.QuotaScopeUser}).AppliesToUser(&domain.User{}))
+	assert.True(t, (&domain.QuotaRule{Scope: domain.QuotaScopeDepartment}).AppliesToUser(user))
+	assert.True(t, (&domain.QuotaRule{Scope: domain.QuotaScopeDepartment, Department: &sales}).AppliesToUser(user))
+	assert.False(t, (&domain.QuotaRule{Scope: domain.QuotaScopeDepartment, Department: &legal}).AppliesToUser(user))
+	assert.False(t, (&domain.QuotaRule{Scope: domain.QuotaScopeDepartment}).AppliesToUser(&domain.User{}))
+}
+
+func TestQuotaRule_Period(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	// 2026-04-05 (日) 23:30 JST は 3/30 (月) から始まる週、4月の月
+	at := time.Date(2026, 4, 5, 14, 30, 0, 0, time.UTC)
+
+	weekly := &domain.QuotaRule{Kind: domain.QuotaKindWeeklyHours}
+	start, end := weekly.Period(at, tokyo)
+	assert.Equal(t, time.Date(2026, 3, 30, 0, 0, 0, 0, tokyo), start)
+	assert.Equal(t, time.Date(2026, 4, 6, 0, 0, 0, 0, tokyo), end)
+
+	// UTC では同じ日時が 4/6 (月) から始まる週になる
+	start, _ = weekly.Period(at.Add(10*time.Hour), time.UTC)
+	assert.Equal(t, time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC), start)
+
+	monthly := &domain.QuotaRule{Kind: domain.QuotaKindMonthlyHours}
+	start, end = monthly.Period(at, tokyo)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, tokyo), start)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, tokyo), end)
+}
+
+func TestNewQuotaUsage(t *testing.T) {
+	sales := "Sales"
+
+	hours := &domain.QuotaRule{Name: "Weekly", Scope: domain.QuotaScopeDepartment, Kind: domain.QuotaKindWeeklyHours, Limit: 10}
+	usage := domain.NewQuotaUsage(hours, &sales, 450)
+	assert.Equal(t, 7.5, usage.Used)
+	assert.Equal(t, 2.5, usage.Remaining)
+	assert.Equal(t, &sales, usage.Department)
+
+	// 超過している場合の残りは0
+	concurrent := &domain.QuotaRule{Scope: domain.QuotaScopeUser, Kind: domain.QuotaKindConcurrentBookings, Limit: 5}
+	usage = domain.NewQuotaUsage(concurrent, &sales, 7)
+	assert.Equal(t, 7.0, usage.Used)
+	assert.Equal(t, 0.0, usage.Remaining)
+	assert.Nil(t, usage.Department)
+}
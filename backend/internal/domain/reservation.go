@@ -16,12 +16,16 @@ const (
 	ApprovalStatusPending   ApprovalStatus = "PENDING"   // 承認待ち
 	ApprovalStatusConfirmed ApprovalStatus = "CONFIRMED" // 確定済み
 	ApprovalStatusRejected  ApprovalStatus = "REJECTED"  // 却下
+	ApprovalStatusExpired   ApprovalStatus = "EXPIRED"   // 承認期限切れ（仮押さえ解放）
+	ApprovalStatusOffered   ApprovalStatus = "OFFERED"   // ウェイトリスト繰り上げの確認待ち（仮押さえ中）
+	ApprovalStatusHeld      ApprovalStatus = "HELD"      // 予約フォーム入力中の一時的な仮押さえ
 )
 
 // ReservationStatus は予約インスタンスのステータスを表す型
 type ReservationStatus string
 
 const (
+	ReservationStatusTentative ReservationStatus = "TENTATIVE"  // 仮押さえ（承認待ち）
 	ReservationStatusConfirmed ReservationStatus = "CONFIRMED"  // 確定
 	ReservationStatusCancelled ReservationStatus = "CANCELLED"  // キャンセル
 	ReservationStatusCheckedIn ReservationStatus = "CHECKED_IN" // チェックイン済み
@@ -37,7 +41,8 @@ type Reservation struct {
 	Description    string
 	StartAt        time.Time
 	EndAt          time.Time
-	RRule          string // iCalendar RFC 5545
+	RRule          string      // iCalendar RFC 5545
+	ExDates        []time.Time // 繰り返しから除外する回の開始日時（RFC 5545 EXDATE）
 	IsPrivate      bool
 	Timezone       string
 	ApprovalStatus ApprovalStatus
@@ -47,6 +52,15 @@ type Reservation struct {
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time
 
+	// ResourceUnits はプール型備品ごとの予約数量（未指定のリソースは1）
+	ResourceUnits map[uuid.UUID]int
+
+	// ResourceIDs は予約したリソース（作成時のみ設定。会議室の自動選択結果の確認用）
+	ResourceIDs []uuid.UUID
+
+	// ParticipantIDs は招待した参加者（主催者を除く。作成時のみ設定）
+	ParticipantIDs []uuid.UUID
+
 	// Relations
 	Organizer *User
 }
@@ -64,12 +78,46 @@ type ReservationInstance struct {
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 
+	// ResourceIDs はこの回だけ予約全体と異なるリソースを割り当てる場合に設定する（代替会議室など）
+	ResourceIDs []uuid.UUID
+
 	// Relations
 	Reservation  *Reservation
 	Resources    []*Resource
 	Participants []*User
 }
 
+// IsPending は承認待ちかどうかを判定します
+func (r *Reservation) IsPending() bool {
+	return r.ApprovalStatus == ApprovalStatusPending
+}
+
+// UnitsFor は指定されたリソースの予約数量を返します
+func (r *Reservation) UnitsFor(resourceID uuid.UUID) int {
+	if units, ok := r.ResourceUnits[resourceID]; ok && units > 0 {
+		return units
+	}
+	return 1
+}
+
+// InstancesSpan はインスタンス全体を覆う期間を返します
+// インスタンスが空の場合は ok = false を返します
+func InstancesSpan(instances []*ReservationInstance) (startAt, endAt time.Time, ok bool) {
+	if len(instances) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	startAt, endAt = instances[0].StartAt, instances[0].EndAt
+	for _, instance := range instances[1:] {
+		if instance.StartAt.Before(startAt) {
+			startAt = instance.StartAt
+		}
+		if instance.EndAt.After(endAt) {
+			endAt = instance.EndAt
+		}
+	}
+	return startAt, endAt, true
+}
+
 // IsRecurring は繰り返し予約かどうかを判定します
 func (r *Reservation) IsRecurring() bool {
 	return r.RRule != ""
@@ -92,6 +140,18 @@ func (r *Reservation) Validate() error {
 	return nil
 }
 
+// SeriesExpansionHorizon は終了条件 (COUNT/UNTIL) のない繰り返し予約を展開する期間の上限
+const SeriesExpansionHorizon = 366 * 24 * time.Hour
+
+// ExpandSeries は予約の全インスタンスを展開します
+// 終了条件のない繰り返し予約は開始から SeriesExpansionHorizon までを展開します
+func (r *Reservation) ExpandSeries() ([]ReservationInstance, error) {
+	if !r.IsRecurring() {
+		return r.ExpandInstances(r.StartAt, r.EndAt)
+	}
+	return r.ExpandInstances(r.StartAt, r.StartAt.Add(SeriesExpansionHorizon))
+}
+
 // ExpandInstances は指定された期間内のインスタンスを展開します
 func (r *Reservation) ExpandInstances(start, end time.Time) ([]ReservationInstance, error) {
 	if !r.IsRecurring() {
@@ -118,9 +178,16 @@ func (r *Reservation) ExpandInstances(start, end time.Time) ([]ReservationInstan
 	// RRULEの開始時間を設定
 	rule.DTStart(r.StartAt)
 
+	// 除外日 (EXDATE) を適用
+	set := &rrule.Set{}
+	set.RRule(rule)
+	for _, exdate := range r.ExDates {
+		set.ExDate(exdate)
+	}
+
 	// 指定期間内の日時を取得
 	// rrule-go の Between は start <= time < end
-	dates := rule.Between(start, end, true)
+	dates := set.Between(start, end, true)
 
 	duration := r.EndAt.Sub(r.StartAt)
 	instances := make([]ReservationInstance, 0, len(dates))
@@ -176,3 +176,61 @@ func TestReservation_ExpandInstances(t *testing.T) {
 		})
 	}
 }
+
+func TestReservation_ExpandSeries(t *testing.T) {
+	baseTime := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	reservation := domain.Reservation{
+		ID:      uuid.New(),
+		Title:   "Weekly Sync",
+		StartAt: baseTime,
+		EndAt:   baseTime.Add(1 * time.Hour),
+		RRule:   "FREQ=WEEKLY;COUNT=4",
+	}
+
+	instances, err := reservation.ExpandSeries()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 4)
+
+	// 除外日 (EXDATE) の回は展開しない
+	reservation.ExDates = []time.Time{baseTime.Add(7 * 24 * time.Hour)}
+	instances, err = reservation.ExpandSeries()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 3)
+	assert.Equal(t, baseTime.Add(14*24*time.Hour), instances[1].StartAt)
+
+	// 終了条件のない繰り返しは上限期間までを展開する
+	unbounded := domain.Reservation{
+		ID:      uuid.New(),
+		Title:   "Standup",
+		StartAt: baseTime,
+		EndAt:   baseTime.Add(15 * time.Minute),
+		RRule:   "FREQ=WEEKLY",
+	}
+	instances, err = unbounded.ExpandSeries()
+	assert.NoError(t, err)
+	assert.Len(t, instances, 53)
+}
+
+func TestReservation_UnitsFor(t *testing.T) {
+	laptopID := uuid.New()
+	reservation := domain.Reservation{ResourceUnits: map[uuid.UUID]int{laptopID: 3}}
+
+	assert.Equal(t, 3, reservation.UnitsFor(laptopID))
+	assert.Equal(t, 1, reservation.UnitsFor(uuid.New()))
+}
+
+func TestInstancesSpan(t *testing.T) {
+	base := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	instances := []*domain.ReservationInstance{
+		{StartAt: base.Add(7 * 24 * time.Hour), EndAt: base.Add(7*24*time.Hour + time.Hour)},
+		{StartAt: base, EndAt: base.Add(time.Hour)},
+	}
+
+	startAt, endAt, ok := domain.InstancesSpan(instances)
+	assert.True(t, ok)
+	assert.Equal(t, base, startAt)
+	assert.Equal(t, base.Add(7*24*time.Hour+time.Hour), endAt)
+
+	_, _, ok = domain.InstancesSpan(nil)
+	assert.False(t, ok)
+}
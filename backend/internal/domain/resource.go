@@ -3,19 +3,41 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrCapacityExceeded  = errors.New("attendees exceed room capacity")
+	ErrBelowMinOccupancy = errors.New("attendees are below the room's minimum occupancy")
+)
+
 // ResourceType はリソースの種別を表す型
 type ResourceType string
 
 const (
 	ResourceTypeMeetingRoom ResourceType = "MEETING_ROOM" // 会議室
 	ResourceTypeEquipment   ResourceType = "EQUIPMENT"    // 備品
+	ResourceTypeDesk        ResourceType = "DESK"         // フリーアドレス席
+	ResourceTypeParking     ResourceType = "PARKING"      // 駐車区画
 )
 
+// IsValid はリソース種別が定義済みの値かを判定します
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeMeetingRoom, ResourceTypeEquipment, ResourceTypeDesk, ResourceTypeParking:
+		return true
+	}
+	return false
+}
+
+// IsDayBooked は終日・半日単位で予約する種別（デスク・駐車区画）かを判定します
+func (t ResourceType) IsDayBooked() bool {
+	return t == ResourceTypeDesk || t == ResourceTypeParking
+}
+
 // Resource はリソースエンティティを表す構造体
 type Resource struct {
 	ID           uuid.UUID              // リソースID
@@ -28,6 +50,20 @@ type Resource struct {
 	IsActive     bool                   // アクティブフラグ
 	CreatedAt    time.Time              // 作成日時
 	UpdatedAt    time.Time              // 更新日時
+
+	RequiresApproval bool       // 予約に承認が必要か
+	ApproverGroupID  *uuid.UUID // 承認依頼の通知先グループ
+	ApprovalPolicyID *uuid.UUID // 承認チェーンの定義（nil の場合は承認者グループによる1段階承認）
+	OwnerID          *uuid.UUID // リソース所有者
+
+	Quantity int // 保有数量（2以上の場合はプール型備品。0は1として扱う）
+
+	Zone  *string // フロア・エリア（デスク・駐車区画のゾーン予約用）
+	Floor *int    // 所在フロア（会議室の自動選択で希望フロアからの近さを評価する）
+
+	MinOccupancy *int // 最低利用人数（会議室のみ。大会議室を少人数で占有しないための下限）
+
+	BumpRole *Role // 既存の予約を押しのけて確保できる最低ロール（会議室のみ。nil の場合は管理者のみ）
 }
 
 // IsValid はリソースが有効かどうかを判定します
@@ -35,7 +71,7 @@ func (r *Resource) IsValid() bool {
 	if r.Name == "" {
 		return false
 	}
-	if r.Type != ResourceTypeMeetingRoom && r.Type != ResourceTypeEquipment {
+	if !r.Type.IsValid() {
 		return false
 	}
 	return r.IsActive
@@ -49,9 +85,40 @@ func (r *Resource) Validate() error {
 	if r.Type == "" {
 		return errors.New("resource type is required")
 	}
+	if !r.Type.IsValid() {
+		return errors.New("invalid resource type")
+	}
 	if r.Type == ResourceTypeMeetingRoom && (r.Capacity == nil || *r.Capacity <= 0) {
 		return errors.New("capacity is required for meeting rooms")
 	}
+	if r.Type.IsDayBooked() && r.Capacity != nil {
+		return errors.New("capacity is not applicable to desks and parking spaces")
+	}
+	if r.RequiresApproval && r.ApproverGroupID == nil && r.ApprovalPolicyID == nil {
+		return errors.New("approver group or approval policy is required when approval is required")
+	}
+	if r.MinOccupancy != nil {
+		if r.Type != ResourceTypeMeetingRoom {
+			return errors.New("minimum occupancy is only applicable to meeting rooms")
+		}
+		if *r.MinOccupancy < 1 || (r.Capacity != nil && *r.MinOccupancy > *r.Capacity) {
+			return errors.New("minimum occupancy must be between 1 and capacity")
+		}
+	}
+	if r.BumpRole != nil {
+		if r.Type != ResourceTypeMeetingRoom {
+			return errors.New("bump role is only applicable to meeting rooms")
+		}
+		if !r.BumpRole.IsRanked() {
+			return errors.New("invalid bump role")
+		}
+	}
+	if r.Quantity < 0 {
+		return errors.New("quantity must not be negative")
+	}
+	if r.Quantity > 1 && r.Type != ResourceTypeEquipment {
+		return errors.New("quantity greater than 1 is only allowed for equipment")
+	}
 	return nil
 }
 
@@ -63,6 +130,18 @@ func (r *Resource) CanBeReservedBy(user *User) bool {
 	return user.CanAccessResource(r.RequiredRole)
 }
 
+// CanBeBumpedBy は指定されたユーザーがこの会議室の既存の予約を押しのけて確保できるかを判定します
+// 管理者は常に可能で、それ以外は bump_role 以上のロールが必要です
+func (r *Resource) CanBeBumpedBy(user *User) bool {
+	if !r.IsActive || !r.IsMeetingRoom() {
+		return false
+	}
+	if user.IsAdmin() {
+		return true
+	}
+	return r.BumpRole != nil && user.CanAccessResource(r.BumpRole)
+}
+
 // IsMeetingRoom は会議室かどうかを判定します
 func (r *Resource) IsMeetingRoom() bool {
 	return r.Type == ResourceTypeMeetingRoom
@@ -72,3 +151,66 @@ func (r *Resource) IsMeetingRoom() bool {
 func (r *Resource) IsEquipment() bool {
 	return r.Type == ResourceTypeEquipment
 }
+
+// IsDayBooked は終日・半日単位で予約するリソースかどうかを判定します
+func (r *Resource) IsDayBooked() bool {
+	return r.Type.IsDayBooked()
+}
+
+// InZone は指定されたゾーンに属するかどうかを判定します
+func (r *Resource) InZone(zone string) bool {
+	return r.Zone != nil && *r.Zone == zone
+}
+
+// HasAmenity は指定された設備を備えているかを判定します
+// 設備情報の値が false・0・空文字列の場合は備えていないものとして扱います
+func (r *Resource) HasAmenity(name string) bool {
+	value, ok := r.Equipment[name]
+	if !ok || value == nil {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case int:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return true
+}
+
+// CheckOccupancy は利用人数が会議室の収容人数と最低利用人数の範囲内かを検証します
+// 会議室以外のリソースは検証しません
+func (r *Resource) CheckOccupancy(headcount int) error {
+	if !r.IsMeetingRoom() {
+		return nil
+	}
+	if r.Capacity != nil && headcount > *r.Capacity {
+		return fmt.Errorf("%w: %d attendees for %s (capacity %d)", ErrCapacityExceeded, headcount, r.Name, *r.Capacity)
+	}
+	if r.MinOccupancy != nil && headcount < *r.MinOccupancy {
+		return fmt.Errorf("%w: %d attendees for %s (minimum %d)", ErrBelowMinOccupancy, headcount, r.Name, *r.MinOccupancy)
+	}
+	return nil
+}
+
+// NeedsApproval はリソースの予約に承認が必要かどうかを判定します
+func (r *Resource) NeedsApproval() bool {
+	return r.RequiresApproval
+}
+
+// TotalUnits はリソースの保有数量を返します（未設定の場合は1）
+func (r *Resource) TotalUnits() int {
+	if r.Quantity < 1 {
+		return 1
+	}
+	return r.Quantity
+}
+
+// IsPooled は数量単位で予約するプール型備品かどうかを判定します
+func (r *Resource) IsPooled() bool {
+	return r.IsEquipment() && r.TotalUnits() > 1
+}
@@ -4,12 +4,18 @@ package domain_test
 import (
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/your-org/esms/internal/domain"
 )
 
 func TestResource_Validate(t *testing.T) {
 	capacity := 10
+	minOccupancy := 4
+	groupID := uuid.New()
+	policyID := uuid.New()
+	roleManager := domain.RoleManager
+	roleAuditor := domain.RoleAuditor
 	tests := []struct {
 		name     string
 		resource domain.Resource
@@ -54,6 +60,119 @@ func TestResource_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Approval required with approver group",
+			resource: domain.Resource{
+				Name:             "Executive Boardroom",
+				Type:             domain.ResourceTypeMeetingRoom,
+				Capacity:         &capacity,
+				RequiresApproval: true,
+				ApproverGroupID:  &groupID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Approval required with approval policy",
+			resource: domain.Resource{
+				Name:             "Company Car",
+				Type:             domain.ResourceTypeEquipment,
+				RequiresApproval: true,
+				ApprovalPolicyID: &policyID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Approval required without approver group",
+			resource: domain.Resource{
+				Name:             "Company Car",
+				Type:             domain.ResourceTypeEquipment,
+				RequiresApproval: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Pooled equipment",
+			resource: domain.Resource{
+				Name:     "Laptop",
+				Type:     domain.ResourceTypeEquipment,
+				Quantity: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Pooled meeting room",
+			resource: domain.Resource{
+				Name:     "Room C",
+				Type:     domain.ResourceTypeMeetingRoom,
+				Capacity: &capacity,
+				Quantity: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid desk",
+			resource: domain.Resource{
+				Name: "Desk 3F-A-01",
+				Type: domain.ResourceTypeDesk,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Parking space with capacity",
+			resource: domain.Resource{
+				Name:     "P-01",
+				Type:     domain.ResourceTypeParking,
+				Capacity: &capacity,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Meeting room with minimum occupancy",
+			resource: domain.Resource{
+				Name:         "Boardroom",
+				Type:         domain.ResourceTypeMeetingRoom,
+				Capacity:     &capacity,
+				MinOccupancy: &minOccupancy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Minimum occupancy above capacity",
+			resource: domain.Resource{
+				Name:         "Huddle",
+				Type:         domain.ResourceTypeMeetingRoom,
+				Capacity:     &minOccupancy,
+				MinOccupancy: &capacity,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Bump role on equipment",
+			resource: domain.Resource{
+				Name:     "Projector",
+				Type:     domain.ResourceTypeEquipment,
+				BumpRole: &roleManager,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Bump role outside hierarchy",
+			resource: domain.Resource{
+				Name:     "Boardroom",
+				Type:     domain.ResourceTypeMeetingRoom,
+				Capacity: &capacity,
+				BumpRole: &roleAuditor,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown type",
+			resource: domain.Resource{
+				Name: "Locker",
+				Type: domain.ResourceType("LOCKER"),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -85,3 +204,55 @@ func TestResource_CanBeReservedBy(t *testing.T) {
 	assert.False(t, resource.CanBeReservedBy(&general))
 	assert.False(t, inactiveResource.CanBeReservedBy(&manager))
 }
+
+func TestResource_CanBeBumpedBy(t *testing.T) {
+	roleManager := domain.RoleManager
+	capacity := 10
+	boardroom := domain.Resource{Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+	executiveRoom := boardroom
+	executiveRoom.BumpRole = &roleManager
+
+	admin := &domain.User{Role: domain.RoleAdmin}
+	manager := &domain.User{Role: domain.RoleManager}
+	general := &domain.User{Role: domain.RoleGeneral}
+
+	// bump_role 未設定の会議室は管理者のみ
+	assert.True(t, boardroom.CanBeBumpedBy(admin))
+	assert.False(t, boardroom.CanBeBumpedBy(manager))
+
+	assert.True(t, executiveRoom.CanBeBumpedBy(manager))
+	assert.False(t, executiveRoom.CanBeBumpedBy(general))
+
+	projector := domain.Resource{Type: domain.ResourceTypeEquipment, IsActive: true}
+	assert.False(t, projector.CanBeBumpedBy(admin))
+}
+
+func TestResource_IsPooled(t *testing.T) {
+	single := domain.Resource{Type: domain.ResourceTypeEquipment}
+	assert.Equal(t, 1, single.TotalUnits())
+	assert.False(t, single.IsPooled())
+
+	pooled := domain.Resource{Type: domain.ResourceTypeEquipment, Quantity: 5}
+	assert.Equal(t, 5, pooled.TotalUnits())
+	assert.True(t, pooled.IsPooled())
+}
+
+func TestResource_CheckOccupancy(t *testing.T) {
+	capacity := 20
+	minOccupancy := 6
+	boardroom := domain.Resource{
+		Name:         "Boardroom",
+		Type:         domain.ResourceTypeMeetingRoom,
+		Capacity:     &capacity,
+		MinOccupancy: &minOccupancy,
+	}
+
+	assert.NoError(t, boardroom.CheckOccupancy(6))
+	assert.NoError(t, boardroom.CheckOccupancy(20))
+	assert.ErrorIs(t, boardroom.CheckOccupancy(21), domain.ErrCapacityExceeded)
+	assert.ErrorIs(t, boardroom.CheckOccupancy(1), domain.ErrBelowMinOccupancy)
+
+	// 会議室以外は検証しない
+	projector := domain.Resource{Name: "Projector", Type: domain.ResourceTypeEquipment}
+	assert.NoError(t, projector.CheckOccupancy(100))
+}
@@ -0,0 +1,94 @@
+// backend/internal/domain/room_requirement.go
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidAttendees は参加人数が不正な場合のエラー
+var ErrInvalidAttendees = errors.New("attendees must be at least 1")
+
+// RoomRequirement は会議室を自動選択するための条件を表す構造体
+type RoomRequirement struct {
+	Attendees int      // 参加人数
+	Amenities []string // 必須設備（例: "vc", "whiteboard"）
+	NearFloor *int     // 希望フロア（近い順に優先する）
+}
+
+// EquivalentTo は会議室 r と同等の会議室（同じ収容人数以上・同じ設備・近いフロア）を選ぶ条件を返します
+// 優先確保で押しのけられた予約の移動先を選ぶのに使用します
+func EquivalentTo(r *Resource) *RoomRequirement {
+	q := &RoomRequirement{NearFloor: r.Floor}
+	if r.Capacity != nil {
+		q.Attendees = *r.Capacity
+	}
+	for name := range r.Equipment {
+		if r.HasAmenity(name) {
+			q.Amenities = append(q.Amenities, name)
+		}
+	}
+	sort.Strings(q.Amenities)
+	return q
+}
+
+// Validate は選択条件を検証します
+func (q *RoomRequirement) Validate() error {
+	if q.Attendees < 1 {
+		return ErrInvalidAttendees
+	}
+	for _, amenity := range q.Amenities {
+		if strings.TrimSpace(amenity) == "" {
+			return errors.New("amenity name must not be empty")
+		}
+	}
+	return nil
+}
+
+// Fits は会議室が参加人数（収容人数・最低利用人数）と必須設備の条件を満たすかを判定します
+func (q *RoomRequirement) Fits(r *Resource) bool {
+	if !r.IsMeetingRoom() || r.Capacity == nil || r.CheckOccupancy(q.Attendees) != nil {
+		return false
+	}
+	for _, amenity := range q.Amenities {
+		if !r.HasAmenity(amenity) {
+			return false
+		}
+	}
+	return true
+}
+
+// FloorDistance は希望フロアからの距離を返します
+// 希望フロアが未指定の場合は0、会議室のフロアが不明な場合は -1 を返します
+func (q *RoomRequirement) FloorDistance(r *Resource) int {
+	if q.NearFloor == nil {
+		return 0
+	}
+	if r.Floor == nil {
+		return -1
+	}
+	d := *r.Floor - *q.NearFloor
+	if d < 0 {
+		d = -d
+	}
+	return d
+}
+
+// BetterFit は条件を満たす会議室 a が b よりも適しているかを判定します
+// 収容人数が小さい（余剰座席が少ない）順、次に希望フロアに近い順、最後に名前順で比較します
+// 少人数の打ち合わせが大会議室を占有しないよう、収容人数を最優先します
+func (q *RoomRequirement) BetterFit(a, b *Resource) bool {
+	if *a.Capacity != *b.Capacity {
+		return *a.Capacity < *b.Capacity
+	}
+	da, db := q.FloorDistance(a), q.FloorDistance(b)
+	if da != db {
+		// フロア不明 (-1) は最後に回す
+		if da < 0 || db < 0 {
+			return db < 0
+		}
+		return da < db
+	}
+	return a.Name < b.Name
+}
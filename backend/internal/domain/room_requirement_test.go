@@ -0,0 +1,87 @@
+// backend/internal/domain/room_requirement_test.go
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func newRoom(name string, capacity int, floor *int, equipment map[string]interface{}) *domain.Resource {
+	return &domain.Resource{
+		Name:      name,
+		Type:      domain.ResourceTypeMeetingRoom,
+		Capacity:  &capacity,
+		Floor:     floor,
+		Equipment: equipment,
+		IsActive:  true,
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestRoomRequirement_Validate(t *testing.T) {
+	assert.NoError(t, (&domain.RoomRequirement{Attendees: 2}).Validate())
+	assert.ErrorIs(t, (&domain.RoomRequirement{}).Validate(), domain.ErrInvalidAttendees)
+	assert.Error(t, (&domain.RoomRequirement{Attendees: 2, Amenities: []string{" "}}).Validate())
+}
+
+func TestRoomRequirement_Fits(t *testing.T) {
+	req := &domain.RoomRequirement{Attendees: 7, Amenities: []string{"vc"}}
+
+	tests := []struct {
+		name     string
+		resource *domain.Resource
+		want     bool
+	}{
+		{"Large enough with VC", newRoom("A", 8, nil, map[string]interface{}{"vc": true}), true},
+		{"Too small", newRoom("B", 6, nil, map[string]interface{}{"vc": true}), false},
+		{"VC disabled", newRoom("C", 10, nil, map[string]interface{}{"vc": false}), false},
+		{"No equipment info", newRoom("D", 10, nil, nil), false},
+		{"Below minimum occupancy", &domain.Resource{Name: "Boardroom", Type: domain.ResourceTypeMeetingRoom, Capacity: intPtr(20), MinOccupancy: intPtr(10), Equipment: map[string]interface{}{"vc": true}}, false},
+		{"Equipment resource", &domain.Resource{Name: "Projector", Type: domain.ResourceTypeEquipment}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, req.Fits(tt.resource))
+		})
+	}
+}
+
+func TestRoomRequirement_BetterFit(t *testing.T) {
+	floor := intPtr
+	req := &domain.RoomRequirement{Attendees: 4, NearFloor: floor(3)}
+
+	small := newRoom("Small", 4, floor(8), nil)
+	boardroom := newRoom("Boardroom", 20, floor(3), nil)
+	near := newRoom("Near", 6, floor(4), nil)
+	far := newRoom("Far", 6, floor(1), nil)
+	unknown := newRoom("Unknown", 6, nil, nil)
+
+	// 収容人数が最優先
+	assert.True(t, req.BetterFit(small, boardroom))
+	assert.False(t, req.BetterFit(boardroom, small))
+
+	// 同じ収容人数なら希望フロアに近い方
+	assert.True(t, req.BetterFit(near, far))
+	assert.False(t, req.BetterFit(far, near))
+
+	// フロア不明は最後
+	assert.True(t, req.BetterFit(far, unknown))
+	assert.False(t, req.BetterFit(unknown, far))
+}
+
+func TestEquivalentTo(t *testing.T) {
+	room := newRoom("Boardroom", 10, intPtr(5), map[string]interface{}{"whiteboard": true, "vc": true, "tv": false})
+
+	q := domain.EquivalentTo(room)
+
+	assert.Equal(t, 10, q.Attendees)
+	assert.Equal(t, []string{"vc", "whiteboard"}, q.Amenities)
+	assert.Equal(t, 5, *q.NearFloor)
+	assert.True(t, q.Fits(newRoom("Room B", 12, nil, map[string]interface{}{"vc": true, "whiteboard": true})))
+	assert.False(t, q.Fits(newRoom("Room C", 12, nil, map[string]interface{}{"vc": true})))
+	assert.False(t, q.Fits(newRoom("Room D", 8, nil, map[string]interface{}{"vc": true, "whiteboard": true})))
+}
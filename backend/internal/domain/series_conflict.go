@@ -0,0 +1,49 @@
+// backend/internal/domain/series_conflict.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConflictAction は繰り返し予約の競合した回の扱いを表す型
+type ConflictAction string
+
+const (
+	ConflictActionSkip       ConflictAction = "SKIP"       // その回を除外日 (EXDATE) として予約しない
+	ConflictActionSubstitute ConflictAction = "SUBSTITUTE" // その回だけ代替リソースで予約する
+)
+
+// IsValid は競合時の扱いが定義済みの値かを判定します
+func (a ConflictAction) IsValid() bool {
+	return a == ConflictActionSkip || a == ConflictActionSubstitute
+}
+
+// OccurrenceResolution は繰り返し予約の特定の回に対する競合の解決方法を表す構造体
+type OccurrenceResolution struct {
+	StartAt      time.Time               // 対象の回の開始日時
+	Action       ConflictAction          // 競合時の扱い
+	Replacements map[uuid.UUID]uuid.UUID // SUBSTITUTE の場合の置き換え（競合リソース → 代替リソース）
+}
+
+// OccurrenceConflict は繰り返し予約の1回分の競合を表す構造体
+type OccurrenceConflict struct {
+	StartAt      time.Time
+	EndAt        time.Time
+	ResourceIDs  []uuid.UUID // 空いていないリソース
+	Alternatives []*Resource // その回に空いている代替会議室の候補
+}
+
+// SeriesConflictReport は繰り返し予約の各回の競合状況（ドライラン結果）を表す構造体
+type SeriesConflictReport struct {
+	Occurrences int                   // 予約する回数（除外した回を除く）
+	Skipped     []time.Time           // 除外日 (EXDATE) として予約しない回
+	Substituted []time.Time           // 代替リソースで予約する回
+	Conflicts   []*OccurrenceConflict // 解決されていない競合
+}
+
+// HasConflicts は解決されていない競合があるかを判定します
+func (r *SeriesConflictReport) HasConflicts() bool {
+	return len(r.Conflicts) > 0
+}
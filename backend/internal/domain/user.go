@@ -29,11 +29,14 @@ const (
 // User はユーザーエンティティを表す構造体
 type User struct {
 	ID                    uuid.UUID  // ユーザーID
+	Issuer                string     // IdPの発行者（Sub と組み合わせて一意。空の場合は未連携）
 	Sub                   string     // IdPから取得したユーザー識別子（不変）
+	ExternalID            string     // プロビジョニング元のIdPでのユーザー識別子（SCIM の externalId。空の場合は未設定）
 	Email                 string     // メールアドレス
 	Name                  string     // 表示名
 	Role                  Role       // ロール
 	ManagerID             *uuid.UUID // 上長のユーザーID（承認フロー用）
+	Department            *string    // 所属部署（部署単位の予約上限の集計用）
 	PenaltyScore          int        // キャンセルペナルティスコア
 	PenaltyScoreExpireAt  *time.Time // ペナルティスコア有効期限
 	IsActive              bool       // アクティブフラグ
@@ -47,6 +50,16 @@ func (u *User) IsValid() bool {
 	return u.IsActive && u.DeletedAt == nil
 }
 
+// IsLinked はIdPのユーザー（issuer, sub）と紐付いているかを判定します
+func (u *User) IsLinked() bool {
+	return u.Issuer != "" && u.Sub != ""
+}
+
+// HasIdentity は指定したIdPのユーザーと紐付いているかを判定します
+func (u *User) HasIdentity(issuer, sub string) bool {
+	return u.IsLinked() && u.Issuer == issuer && u.Sub == sub
+}
+
 // HasRole は指定されたロールを持っているかを判定します
 func (u *User) HasRole(role Role) bool {
 	return u.Role == role
@@ -92,6 +105,26 @@ func (u *User) CanApproveReservation() bool {
 	return u.Role == RoleManager || u.Role == RoleAdmin
 }
 
+// roleHierarchy はロールの階層: GENERAL < SECRETARY < MANAGER < ADMIN
+// AUDITOR は特殊なロールで、リソース予約には関与しない
+var roleHierarchy = map[Role]int{
+	RoleGeneral:   1,
+	RoleSecretary: 2,
+	RoleManager:   3,
+	RoleAdmin:     4,
+}
+
+// IsRanked はロールの階層に含まれるか（リソースの必要ロールなどに指定できるか）を判定します
+func (r Role) IsRanked() bool {
+	_, ok := roleHierarchy[r]
+	return ok
+}
+
+// IsValid は定義済みのロールかどうかを判定します
+func (r Role) IsValid() bool {
+	return r.IsRanked() || r == RoleAuditor
+}
+
 // CanAccessResource は指定されたリソースにアクセスできるかを判定します
 // required_role が nil の場合は全員アクセス可能
 // required_role が設定されている場合は、そのロール以上が必要
@@ -100,15 +133,6 @@ func (u *User) CanAccessResource(requiredRole *Role) bool {
 		return true
 	}
 	
-	// ロールの階層: GENERAL < SECRETARY < MANAGER < ADMIN
-	// AUDITOR は特殊なロールで、リソース予約には関与しない
-	roleHierarchy := map[Role]int{
-		RoleGeneral:   1,
-		RoleSecretary: 2,
-		RoleManager:   3,
-		RoleAdmin:     4,
-	}
-	
 	userLevel, userExists := roleHierarchy[u.Role]
 	requiredLevel, requiredExists := roleHierarchy[*requiredRole]
 	
@@ -119,6 +143,23 @@ func (u *User) CanAccessResource(requiredRole *Role) bool {
 	return userLevel >= requiredLevel
 }
 
+// Outranks は指定されたユーザーよりも上位のロールかどうかを判定します
+// 階層に含まれないロール（AUDITOR）との比較は常に false です
+func (u *User) Outranks(other *User) bool {
+	userLevel, userExists := roleHierarchy[u.Role]
+	otherLevel, otherExists := roleHierarchy[other.Role]
+	if !userExists || !otherExists {
+		return false
+	}
+	return userLevel > otherLevel
+}
+
+// CanActFor は秘書として指定されたユーザー（担当役員）の代理で操作できるかを判定します
+// 秘書の担当役員は上長 (ManagerID) として登録します
+func (u *User) CanActFor(executive *User) bool {
+	return u.IsSecretary() && u.ManagerID != nil && *u.ManagerID == executive.ID
+}
+
 // HasActivePenalty はアクティブなペナルティを持っているかを判定します
 func (u *User) HasActivePenalty() bool {
 	if u.PenaltyScore <= 0 {
@@ -104,6 +104,31 @@ func TestUser_CanAccessResource(t *testing.T) {
 	}
 }
 
+func TestUser_Outranks(t *testing.T) {
+	admin := &domain.User{Role: domain.RoleAdmin}
+	manager := &domain.User{Role: domain.RoleManager}
+	general := &domain.User{Role: domain.RoleGeneral}
+	auditor := &domain.User{Role: domain.RoleAuditor}
+
+	assert.True(t, admin.Outranks(manager))
+	assert.True(t, manager.Outranks(general))
+	assert.False(t, manager.Outranks(manager))
+	assert.False(t, general.Outranks(manager))
+	assert.False(t, admin.Outranks(auditor))
+}
+
+func TestUser_CanActFor(t *testing.T) {
+	executive := &domain.User{ID: uuid.New(), Role: domain.RoleManager}
+	secretary := &domain.User{ID: uuid.New(), Role: domain.RoleSecretary, ManagerID: &executive.ID}
+	subordinate := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, ManagerID: &executive.ID}
+	other := &domain.User{ID: uuid.New(), Role: domain.RoleManager}
+
+	assert.True(t, secretary.CanActFor(executive))
+	assert.False(t, secretary.CanActFor(other))
+	// 秘書以外は上長の代理にならない
+	assert.False(t, subordinate.CanActFor(executive))
+}
+
 func TestUser_Penalty(t *testing.T) {
 	future := time.Now().Add(1 * time.Hour)
 	past := time.Now().Add(-1 * time.Hour)
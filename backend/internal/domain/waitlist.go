@@ -0,0 +1,68 @@
+// backend/internal/domain/waitlist.go
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistStatus はウェイトリスト登録の状態を表す型
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "WAITING"   // 空き待ち
+	WaitlistStatusOffered   WaitlistStatus = "OFFERED"   // 繰り上げ提示中（仮押さえ済み）
+	WaitlistStatusAccepted  WaitlistStatus = "ACCEPTED"  // 繰り上げを確定
+	WaitlistStatusPassed    WaitlistStatus = "PASSED"    // 繰り上げを辞退
+	WaitlistStatusExpired   WaitlistStatus = "EXPIRED"   // 確認期限切れ
+	WaitlistStatusCancelled WaitlistStatus = "CANCELLED" // 登録取り消し
+)
+
+// WaitlistEntry はリソースと時間帯に対する空き待ち登録を表す構造体
+type WaitlistEntry struct {
+	ID                uuid.UUID
+	ResourceID        uuid.UUID
+	UserID            uuid.UUID
+	Title             string
+	StartAt           time.Time
+	EndAt             time.Time
+	Timezone          string
+	Status            WaitlistStatus
+	HoldReservationID *uuid.UUID // 繰り上げ時に作成した仮押さえ予約（開始日時は StartAt と同じ）
+	OfferedAt         *time.Time
+	OfferExpiresAt    *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Validate はウェイトリスト登録の整合性を検証します
+func (e *WaitlistEntry) Validate() error {
+	if e.Title == "" {
+		return errors.New("title is required")
+	}
+	if !e.StartAt.Before(e.EndAt) {
+		return errors.New("start time must be before end time")
+	}
+	return nil
+}
+
+// IsActive は空き待ちまたは繰り上げ提示中かどうかを判定します
+func (e *WaitlistEntry) IsActive() bool {
+	return e.Status == WaitlistStatusWaiting || e.Status == WaitlistStatusOffered
+}
+
+// IsOfferActive は繰り上げ提示が確認期限内かどうかを判定します
+func (e *WaitlistEntry) IsOfferActive(now time.Time) bool {
+	return e.Status == WaitlistStatusOffered && e.OfferExpiresAt != nil && now.Before(*e.OfferExpiresAt)
+}
+
+// Offer は繰り上げ提示の状態に遷移させます
+func (e *WaitlistEntry) Offer(holdReservationID uuid.UUID, now time.Time, holdDuration time.Duration) {
+	expiresAt := now.Add(holdDuration)
+	e.Status = WaitlistStatusOffered
+	e.HoldReservationID = &holdReservationID
+	e.OfferedAt = &now
+	e.OfferExpiresAt = &expiresAt
+}
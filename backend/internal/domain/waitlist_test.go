@@ -0,0 +1,46 @@
+// backend/internal/domain/waitlist_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestWaitlistEntry_Validate(t *testing.T) {
+	now := time.Now()
+
+	entry := &domain.WaitlistEntry{Title: "Weekly sync", StartAt: now, EndAt: now.Add(time.Hour)}
+	assert.NoError(t, entry.Validate())
+
+	entry.Title = ""
+	assert.Error(t, entry.Validate())
+
+	entry.Title = "Weekly sync"
+	entry.EndAt = now
+	assert.Error(t, entry.Validate())
+}
+
+func TestWaitlistEntry_Offer(t *testing.T) {
+	now := time.Now()
+	holdID := uuid.New()
+	entry := &domain.WaitlistEntry{Status: domain.WaitlistStatusWaiting}
+
+	assert.True(t, entry.IsActive())
+	assert.False(t, entry.IsOfferActive(now))
+
+	entry.Offer(holdID, now, 15*time.Minute)
+
+	assert.Equal(t, domain.WaitlistStatusOffered, entry.Status)
+	assert.Equal(t, holdID, *entry.HoldReservationID)
+	assert.Equal(t, now.Add(15*time.Minute), *entry.OfferExpiresAt)
+	assert.True(t, entry.IsActive())
+	assert.True(t, entry.IsOfferActive(now.Add(14*time.Minute)))
+	assert.False(t, entry.IsOfferActive(now.Add(15*time.Minute)))
+
+	entry.Status = domain.WaitlistStatusPassed
+	assert.False(t, entry.IsActive())
+}
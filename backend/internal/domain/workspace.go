@@ -0,0 +1,72 @@
+// backend/internal/domain/workspace.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DaySlot はデスク・駐車区画の予約枠（終日・午前・午後）を表す型
+type DaySlot string
+
+const (
+	DaySlotFullDay DaySlot = "FULL_DAY" // 終日 (00:00-24:00)
+	DaySlotAM      DaySlot = "AM"       // 午前 (00:00-12:00)
+	DaySlotPM      DaySlot = "PM"       // 午後 (12:00-24:00)
+)
+
+// halfDayBoundary は午前・午後の境界となる時刻
+const halfDayBoundary = 12 * time.Hour
+
+// IsValid は予約枠が定義済みの値かを判定します
+func (s DaySlot) IsValid() bool {
+	switch s {
+	case DaySlotFullDay, DaySlotAM, DaySlotPM:
+		return true
+	}
+	return false
+}
+
+// Range は指定された日付（loc における暦日）の予約枠の開始・終了日時を返します
+// 夏時間の切り替え日でも暦日の境界に揃うよう、時刻は time.Date で組み立てます
+func (s DaySlot) Range(date time.Time, loc *time.Location) (time.Time, time.Time) {
+	y, m, d := date.In(loc).Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	noon := time.Date(y, m, d, int(halfDayBoundary/time.Hour), 0, 0, 0, loc)
+	dayEnd := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+
+	switch s {
+	case DaySlotAM:
+		return dayStart, noon
+	case DaySlotPM:
+		return noon, dayEnd
+	default:
+		return dayStart, dayEnd
+	}
+}
+
+// MatchDaySlot は期間がいずれかの予約枠に一致する場合にその枠を返します
+func MatchDaySlot(startAt, endAt time.Time, loc *time.Location) (DaySlot, bool) {
+	for _, slot := range []DaySlot{DaySlotFullDay, DaySlotAM, DaySlotPM} {
+		slotStart, slotEnd := slot.Range(startAt, loc)
+		if startAt.Equal(slotStart) && endAt.Equal(slotEnd) {
+			return slot, true
+		}
+	}
+	return "", false
+}
+
+// ZoneBooking はゾーン内リソースの予約状況（占有期間）を表す構造体
+type ZoneBooking struct {
+	ResourceID    uuid.UUID
+	ReservationID uuid.UUID
+	OrganizerID   uuid.UUID
+	StartAt       time.Time
+	EndAt         time.Time
+}
+
+// Overlaps は予約が指定された期間と重なるかを判定します
+func (b *ZoneBooking) Overlaps(startAt, endAt time.Time) bool {
+	return b.StartAt.Before(endAt) && b.EndAt.After(startAt)
+}
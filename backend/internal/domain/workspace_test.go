@@ -0,0 +1,56 @@
+// backend/internal/domain/workspace_test.go
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+)
+
+func TestDaySlot_Range(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	date := time.Date(2030, 6, 3, 15, 30, 0, 0, loc)
+
+	tests := []struct {
+		slot      domain.DaySlot
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{domain.DaySlotFullDay, time.Date(2030, 6, 3, 0, 0, 0, 0, loc), time.Date(2030, 6, 4, 0, 0, 0, 0, loc)},
+		{domain.DaySlotAM, time.Date(2030, 6, 3, 0, 0, 0, 0, loc), time.Date(2030, 6, 3, 12, 0, 0, 0, loc)},
+		{domain.DaySlotPM, time.Date(2030, 6, 3, 12, 0, 0, 0, loc), time.Date(2030, 6, 4, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.slot), func(t *testing.T) {
+			start, end := tt.slot.Range(date, loc)
+			assert.True(t, tt.wantStart.Equal(start))
+			assert.True(t, tt.wantEnd.Equal(end))
+		})
+	}
+}
+
+func TestMatchDaySlot(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	dayStart := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+
+	slot, ok := domain.MatchDaySlot(dayStart.Add(12*time.Hour), dayStart.Add(24*time.Hour), loc)
+	assert.True(t, ok)
+	assert.Equal(t, domain.DaySlotPM, slot)
+
+	slot, ok = domain.MatchDaySlot(dayStart, dayStart.Add(24*time.Hour), loc)
+	assert.True(t, ok)
+	assert.Equal(t, domain.DaySlotFullDay, slot)
+
+	_, ok = domain.MatchDaySlot(dayStart.Add(9*time.Hour), dayStart.Add(18*time.Hour), loc)
+	assert.False(t, ok)
+
+	// UTC で終日でも Asia/Tokyo の暦日には一致しない
+	utcDay := time.Date(2030, 6, 3, 0, 0, 0, 0, time.UTC)
+	_, ok = domain.MatchDaySlot(utcDay, utcDay.Add(24*time.Hour), loc)
+	assert.False(t, ok)
+}
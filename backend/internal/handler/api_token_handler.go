@@ -0,0 +1,305 @@
+// backend/internal/handler/api_token_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// APITokenServiceInterface は個人用アクセストークン・サービスアカウントのAPIキーを扱うサービスのインターフェース
+type APITokenServiceInterface interface {
+	GetToken(ctx context.Context, tokenID uuid.UUID) (*domain.APIToken, error)
+	ListUserTokens(ctx context.Context, userID uuid.UUID) ([]*domain.APIToken, error)
+	ListServiceAccountTokens(ctx context.Context, serviceAccountID uuid.UUID) ([]*domain.APIToken, error)
+	CreateUserToken(ctx context.Context, actorID, userID uuid.UUID, req service.CreateAPITokenRequest) (*domain.APIToken, string, error)
+	CreateServiceAccountToken(ctx context.Context, actorID, serviceAccountID uuid.UUID, req service.CreateAPITokenRequest) (*domain.APIToken, string, error)
+	RevokeToken(ctx context.Context, actorID, tokenID uuid.UUID) error
+	ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error)
+	CreateServiceAccount(ctx context.Context, actorID uuid.UUID, name, description string, role domain.Role) (*domain.ServiceAccount, error)
+	DisableServiceAccount(ctx context.Context, actorID, serviceAccountID uuid.UUID) (*domain.ServiceAccount, error)
+}
+
+// APITokenHandler はAPIトークン・サービスアカウント関連のHTTPハンドラー
+// トークンの作成・無効化は対話的なログインのセッションでのみ行えます（APIトークンで新たなトークンを発行させないため）
+type APITokenHandler struct {
+	apiTokenService APITokenServiceInterface
+}
+
+// NewAPITokenHandler は新しいAPITokenHandlerを作成します
+func NewAPITokenHandler(apiTokenService APITokenServiceInterface) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *APITokenHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/api-tokens", h.ListTokens).Methods("GET")
+	r.HandleFunc("/api/v1/api-tokens", h.CreateToken).Methods("POST")
+	r.HandleFunc("/api/v1/api-tokens/{id}", h.RevokeToken).Methods("DELETE")
+
+	r.HandleFunc("/api/v1/service-accounts", h.ListServiceAccounts).Methods("GET")
+	r.HandleFunc("/api/v1/service-accounts", h.CreateServiceAccount).Methods("POST")
+	r.HandleFunc("/api/v1/service-accounts/{id}", h.DisableServiceAccount).Methods("DELETE")
+	r.HandleFunc("/api/v1/service-accounts/{id}/api-tokens", h.ListServiceAccountTokens).Methods("GET")
+	r.HandleFunc("/api/v1/service-accounts/{id}/api-tokens", h.CreateServiceAccountToken).Methods("POST")
+}
+
+// CreateAPITokenResponse はAPIトークンの作成レスポンス
+// token は平文のトークンで、このレスポンスでのみ取得できます
+type CreateAPITokenResponse struct {
+	APIToken *domain.APIToken `json:"api_token"`
+	Token    string           `json:"token"`
+}
+
+// CreateServiceAccountRequest はサービスアカウントの作成リクエスト
+type CreateServiceAccountRequest struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Role        domain.Role `json:"role"` // 省略した場合は GENERAL（ADMIN は不可）
+}
+
+// ListTokens は自分の個人用アクセストークンを取得します
+// 管理者は ?user_id= で他のユーザーのトークンを取得できます
+func (h *APITokenHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	session, ok := interactiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	userID := session.UserID
+	if v := r.URL.Query().Get("user_id"); v != "" {
+		if session.Role != domain.RoleAdmin {
+			WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+			return
+		}
+		id, err := uuid.Parse(v)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+			return
+		}
+		userID = id
+	}
+
+	tokens, err := h.apiTokenService.ListUserTokens(r.Context(), userID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tokens)
+}
+
+// CreateToken は自分の個人用アクセストークンを作成します
+func (h *APITokenHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := interactiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req service.CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	token, raw, err := h.apiTokenService.CreateUserToken(r.Context(), session.UserID, session.UserID, req)
+	if err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, CreateAPITokenResponse{APIToken: token, Token: raw})
+}
+
+// RevokeToken はAPIトークンを無効化します
+// 自分の個人用アクセストークンのみ無効化でき、管理者は全てのトークンを無効化できます
+func (h *APITokenHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := interactiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	tokenID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid API token ID")
+		return
+	}
+
+	token, err := h.apiTokenService.GetToken(r.Context(), tokenID)
+	if err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+	isOwner := token.UserID != nil && *token.UserID == session.UserID
+	if !isOwner && session.Role != domain.RoleAdmin {
+		// 他のユーザーのトークンの存在を明かさない
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "API token not found")
+		return
+	}
+
+	if err := h.apiTokenService.RevokeToken(r.Context(), session.UserID, tokenID); err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListServiceAccounts はサービスアカウントを取得します（管理者のみ）
+func (h *APITokenHandler) ListServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	if _, ok := adminInteractiveSession(w, r); !ok {
+		return
+	}
+
+	accounts, err := h.apiTokenService.ListServiceAccounts(r.Context())
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, accounts)
+}
+
+// CreateServiceAccount はサービスアカウントを作成します（管理者のみ）
+func (h *APITokenHandler) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	session, ok := adminInteractiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	var req CreateServiceAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	account, err := h.apiTokenService.CreateServiceAccount(r.Context(), session.UserID, req.Name, req.Description, req.Role)
+	if err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, account)
+}
+
+// DisableServiceAccount はサービスアカウントを無効化し、そのAPIキーを全て無効化します（管理者のみ）
+func (h *APITokenHandler) DisableServiceAccount(w http.ResponseWriter, r *http.Request) {
+	session, ok := adminInteractiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	accountID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid service account ID")
+		return
+	}
+
+	account, err := h.apiTokenService.DisableServiceAccount(r.Context(), session.UserID, accountID)
+	if err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, account)
+}
+
+// ListServiceAccountTokens はサービスアカウントのAPIキーを取得します（管理者のみ）
+func (h *APITokenHandler) ListServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	if _, ok := adminInteractiveSession(w, r); !ok {
+		return
+	}
+
+	accountID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid service account ID")
+		return
+	}
+
+	tokens, err := h.apiTokenService.ListServiceAccountTokens(r.Context(), accountID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, tokens)
+}
+
+// CreateServiceAccountToken はサービスアカウントのAPIキーを作成します（管理者のみ）
+func (h *APITokenHandler) CreateServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := adminInteractiveSession(w, r)
+	if !ok {
+		return
+	}
+
+	accountID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid service account ID")
+		return
+	}
+
+	var req service.CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	token, raw, err := h.apiTokenService.CreateServiceAccountToken(r.Context(), session.UserID, accountID, req)
+	if err != nil {
+		writeAPITokenError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, CreateAPITokenResponse{APIToken: token, Token: raw})
+}
+
+// interactiveSession は対話的なログインのセッションを取得します
+// 未認証の場合は 401、APIトークンで認証している場合は 403 を書き込みます
+func interactiveSession(w http.ResponseWriter, r *http.Request) (*service.Session, bool) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return nil, false
+	}
+	if session.IsAPIToken() {
+		WriteError(w, http.StatusForbidden, "INTERACTIVE_SESSION_REQUIRED", "API tokens cannot manage API tokens")
+		return nil, false
+	}
+	return session, true
+}
+
+// adminInteractiveSession は管理者の対話的なログインのセッションを取得します
+func adminInteractiveSession(w http.ResponseWriter, r *http.Request) (*service.Session, bool) {
+	session, ok := interactiveSession(w, r)
+	if !ok {
+		return nil, false
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return nil, false
+	}
+	return session, true
+}
+
+// writeAPITokenError はAPIトークン関連のエラーをレスポンスに変換します
+func writeAPITokenError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAPITokenRequest), errors.Is(err, domain.ErrInvalidAPITokenScope):
+		WriteError(w, http.StatusUnprocessableEntity, "INVALID_API_TOKEN_REQUEST", err.Error())
+	case errors.Is(err, service.ErrUserDeactivated):
+		WriteError(w, http.StatusForbidden, "ACCOUNT_DISABLED", "Your account has been deactivated")
+	case errors.Is(err, repository.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "API token or service account not found")
+	default:
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+	}
+}
@@ -0,0 +1,237 @@
+// backend/internal/handler/api_token_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestAPITokenHandler_CreateToken(t *testing.T) {
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	tests := []struct {
+		name          string
+		session       *service.Session
+		body          string
+		setupMock     func(m *MockAPITokenService)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:    "Success",
+			session: &service.Session{UserID: userID, Role: domain.RoleGeneral},
+			body:    `{"name":"reporting","scopes":["read"]}`,
+			setupMock: func(m *MockAPITokenService) {
+				m.On("CreateUserToken", mock.Anything, userID, userID, service.CreateAPITokenRequest{
+					Name:   "reporting",
+					Scopes: []domain.APITokenScope{domain.APITokenScopeRead},
+				}).Return(&domain.APIToken{ID: tokenID, Name: "reporting", ExpiresAt: time.Now().Add(time.Hour)}, "esms_secret", nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:    "Invalid scope",
+			session: &service.Session{UserID: userID, Role: domain.RoleGeneral},
+			body:    `{"name":"reporting","scopes":["admin"]}`,
+			setupMock: func(m *MockAPITokenService) {
+				m.On("CreateUserToken", mock.Anything, userID, userID, mock.Anything).Return(nil, "", domain.ErrInvalidAPITokenScope)
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "INVALID_API_TOKEN_REQUEST",
+		},
+		{
+			name:          "API token cannot create tokens",
+			session:       &service.Session{UserID: userID, Role: domain.RoleGeneral, APITokenID: &tokenID, Scopes: []domain.APITokenScope{domain.APITokenScopeWrite}},
+			body:          `{"name":"reporting","scopes":["read"]}`,
+			setupMock:     func(m *MockAPITokenService) {},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "INTERACTIVE_SESSION_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAPITokenService)
+			tt.setupMock(mockService)
+			h := handler.NewAPITokenHandler(mockService)
+
+			req := httptest.NewRequest("POST", "/api/v1/api-tokens", bytes.NewBufferString(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, tt.session))
+			w := httptest.NewRecorder()
+			h.CreateToken(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var resp struct {
+				Data handler.CreateAPITokenResponse `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "esms_secret", resp.Data.Token)
+			assert.Equal(t, tokenID, resp.Data.APIToken.ID)
+			assert.NotContains(t, w.Body.String(), "token_hash")
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPITokenHandler_ListTokens(t *testing.T) {
+	userID, otherID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		role         domain.Role
+		query        string
+		expectedUser uuid.UUID
+		expectedCode int
+	}{
+		{name: "Own tokens", role: domain.RoleGeneral, expectedUser: userID, expectedCode: http.StatusOK},
+		{name: "Admin lists another user's tokens", role: domain.RoleAdmin, query: "?user_id=" + otherID.String(), expectedUser: otherID, expectedCode: http.StatusOK},
+		{name: "Non-admin cannot list another user's tokens", role: domain.RoleGeneral, query: "?user_id=" + otherID.String(), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAPITokenService)
+			mockService.On("ListUserTokens", mock.Anything, tt.expectedUser).Return([]*domain.APIToken{}, nil)
+			h := handler.NewAPITokenHandler(mockService)
+
+			req := httptest.NewRequest("GET", "/api/v1/api-tokens"+tt.query, nil)
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: userID, Role: tt.role}))
+			w := httptest.NewRecorder()
+			h.ListTokens(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				mockService.AssertNotCalled(t, "ListUserTokens", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAPITokenHandler_RevokeToken(t *testing.T) {
+	userID, otherID, tokenID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		role         domain.Role
+		token        *domain.APIToken
+		tokenErr     error
+		expectRevoke bool
+		expectedCode int
+	}{
+		{
+			name:         "Owner revokes own token",
+			role:         domain.RoleGeneral,
+			token:        &domain.APIToken{ID: tokenID, UserID: &userID},
+			expectRevoke: true,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "Other user's token is hidden",
+			role:         domain.RoleGeneral,
+			token:        &domain.APIToken{ID: tokenID, UserID: &otherID},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Admin revokes any token",
+			role:         domain.RoleAdmin,
+			token:        &domain.APIToken{ID: tokenID, UserID: &otherID},
+			expectRevoke: true,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "Not found",
+			role:         domain.RoleAdmin,
+			tokenErr:     repository.ErrNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAPITokenService)
+			if tt.tokenErr != nil {
+				mockService.On("GetToken", mock.Anything, tokenID).Return(nil, tt.tokenErr)
+			} else {
+				mockService.On("GetToken", mock.Anything, tokenID).Return(tt.token, nil)
+			}
+			mockService.On("RevokeToken", mock.Anything, userID, tokenID).Return(nil)
+			h := handler.NewAPITokenHandler(mockService)
+
+			req := httptest.NewRequest("DELETE", "/api/v1/api-tokens/"+tokenID.String(), nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tokenID.String()})
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: userID, Role: tt.role}))
+			w := httptest.NewRecorder()
+			h.RevokeToken(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectRevoke {
+				mockService.AssertCalled(t, "RevokeToken", mock.Anything, userID, tokenID)
+			} else {
+				mockService.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAPITokenHandler_CreateServiceAccount(t *testing.T) {
+	adminID := uuid.New()
+
+	tests := []struct {
+		name         string
+		role         domain.Role
+		setupMock    func(m *MockAPITokenService)
+		expectedCode int
+	}{
+		{
+			name: "Admin",
+			role: domain.RoleAdmin,
+			setupMock: func(m *MockAPITokenService) {
+				m.On("CreateServiceAccount", mock.Anything, adminID, "room-display", "", domain.RoleGeneral).
+					Return(&domain.ServiceAccount{ID: uuid.New(), Name: "room-display", Role: domain.RoleGeneral}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "Forbidden for non-admin",
+			role:         domain.RoleManager,
+			setupMock:    func(m *MockAPITokenService) {},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockAPITokenService)
+			tt.setupMock(mockService)
+			h := handler.NewAPITokenHandler(mockService)
+
+			req := httptest.NewRequest("POST", "/api/v1/service-accounts", bytes.NewBufferString(`{"name":"room-display","role":"GENERAL"}`))
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: adminID, Role: tt.role}))
+			w := httptest.NewRecorder()
+			h.CreateServiceAccount(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
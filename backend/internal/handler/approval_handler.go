@@ -0,0 +1,247 @@
+// backend/internal/handler/approval_handler.go
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// ApprovalHandler は承認待ち一覧・一括承認関連のHTTPハンドラー
+type ApprovalHandler struct {
+	approvalService ApprovalServiceInterface
+}
+
+// NewApprovalHandler は新しいApprovalHandlerを作成します
+func NewApprovalHandler(approvalService ApprovalServiceInterface) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *ApprovalHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/approvals", h.ListPendingApprovals).Methods("GET")
+	r.HandleFunc("/api/v1/approvals/bulk", h.BulkDecide).Methods("POST")
+}
+
+// PendingApprovalsResponse は承認待ち一覧のレスポンス
+type PendingApprovalsResponse struct {
+	Items  []*domain.Reservation `json:"items"`
+	Total  int                   `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// ListPendingApprovals はログインユーザーの判断を待っている予約一覧を取得します
+// クエリパラメータ: resource_id, from, to (RFC3339), limit, offset
+func (h *ApprovalHandler) ListPendingApprovals(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	params := r.URL.Query()
+	query := service.PendingApprovalsQuery{}
+
+	if v := params.Get("resource_id"); v != "" {
+		resourceID, err := uuid.Parse(v)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID")
+			return
+		}
+		query.ResourceID = &resourceID
+	}
+	if v := params.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_QUERY_PARAM", "from must be RFC3339")
+			return
+		}
+		query.From = &from
+	}
+	if v := params.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_QUERY_PARAM", "to must be RFC3339")
+			return
+		}
+		query.To = &to
+	}
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "to must be after from")
+		return
+	}
+
+	query.Limit = service.DefaultPendingApprovalsLimit
+	if v := params.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > service.MaxPendingApprovalsLimit {
+			WriteError(w, http.StatusBadRequest, "INVALID_QUERY_PARAM", "limit must be between 1 and 200")
+			return
+		}
+		query.Limit = limit
+	}
+	if v := params.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			WriteError(w, http.StatusBadRequest, "INVALID_QUERY_PARAM", "offset must be a non-negative integer")
+			return
+		}
+		query.Offset = offset
+	}
+
+	reservations, total, err := h.approvalService.GetPendingApprovals(r.Context(), session.UserID, query)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "LIST_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, PendingApprovalsResponse{
+		Items:  reservations,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// BulkDecisionItemRequest は一括承認・却下リクエストの1件分
+type BulkDecisionItemRequest struct {
+	ID      string `json:"id"`
+	StartAt string `json:"start_at"`
+	Action  string `json:"action"` // approve または reject
+	Reason  string `json:"reason"`
+}
+
+// BulkDecisionRequest は一括承認・却下リクエスト
+type BulkDecisionRequest struct {
+	Items []BulkDecisionItemRequest `json:"items"`
+}
+
+// BulkDecisionItemResponse は一括承認・却下の1件分の結果
+type BulkDecisionItemResponse struct {
+	ID      string    `json:"id"`
+	StartAt string    `json:"start_at"`
+	Action  string    `json:"action"`
+	Success bool      `json:"success"`
+	Error   *APIError `json:"error,omitempty"`
+}
+
+// BulkDecisionResponse は一括承認・却下のレスポンス
+type BulkDecisionResponse struct {
+	Results   []BulkDecisionItemResponse `json:"results"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+}
+
+// BulkDecide は複数の予約をまとめて承認・却下します
+// 各件は個別に処理され、結果は件ごとに返します（一部失敗でもステータスは200）
+func (h *ApprovalHandler) BulkDecide(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req BulkDecisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if len(req.Items) == 0 {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "At least one item is required")
+		return
+	}
+	if len(req.Items) > service.MaxBulkDecisionItems {
+		WriteError(w, http.StatusBadRequest, "TOO_MANY_ITEMS", "Too many items in bulk request")
+		return
+	}
+
+	// 形式が不正な件はサービスに渡さず、その件だけ失敗として返す
+	results := make([]BulkDecisionItemResponse, len(req.Items))
+	items := make([]service.BulkDecisionItem, 0, len(req.Items))
+	positions := make([]int, 0, len(req.Items))
+	for i, item := range req.Items {
+		results[i] = BulkDecisionItemResponse{ID: item.ID, StartAt: item.StartAt, Action: item.Action}
+
+		id, err := uuid.Parse(item.ID)
+		if err != nil {
+			results[i].Error = &APIError{Code: "INVALID_ID", Message: "Invalid reservation ID"}
+			continue
+		}
+		startAt, err := time.Parse(time.RFC3339, item.StartAt)
+		if err != nil {
+			results[i].Error = &APIError{Code: "INVALID_START_AT", Message: "Invalid start_at"}
+			continue
+		}
+		action := service.BulkDecisionAction(item.Action)
+		if action != service.BulkDecisionApprove && action != service.BulkDecisionReject {
+			results[i].Error = &APIError{Code: "INVALID_ACTION", Message: "action must be 'approve' or 'reject'"}
+			continue
+		}
+
+		items = append(items, service.BulkDecisionItem{
+			ReservationID: id,
+			StartAt:       startAt,
+			Action:        action,
+			Reason:        item.Reason,
+		})
+		positions = append(positions, i)
+	}
+
+	if len(items) > 0 {
+		decided, err := h.approvalService.DecideBulk(r.Context(), session.UserID, items)
+		if err != nil {
+			if errors.Is(err, service.ErrTooManyBulkItems) {
+				WriteError(w, http.StatusBadRequest, "TOO_MANY_ITEMS", "Too many items in bulk request")
+				return
+			}
+			WriteError(w, http.StatusInternalServerError, "BULK_DECISION_FAILED", err.Error())
+			return
+		}
+		for j, result := range decided {
+			if result.Err != nil {
+				results[positions[j]].Error = bulkDecisionError(result.Err)
+			}
+		}
+	}
+
+	resp := BulkDecisionResponse{Results: results}
+	for i := range results {
+		results[i].Success = results[i].Error == nil
+		if results[i].Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+// bulkDecisionError は承認・却下のエラーを件ごとのエラー情報に変換します
+func bulkDecisionError(err error) *APIError {
+	switch {
+	case errors.Is(err, service.ErrNotApprover):
+		return &APIError{Code: "FORBIDDEN", Message: "User is not an approver"}
+	case errors.Is(err, service.ErrAlreadyApproved):
+		return &APIError{Code: "ALREADY_APPROVED", Message: "Reservation is already approved"}
+	case errors.Is(err, service.ErrAlreadyRejected):
+		return &APIError{Code: "ALREADY_REJECTED", Message: "Reservation is already rejected"}
+	case errors.Is(err, service.ErrNotPendingApproval):
+		return &APIError{Code: "NOT_PENDING", Message: "Reservation is not pending approval"}
+	case errors.Is(err, repository.ErrNotFound):
+		return &APIError{Code: "NOT_FOUND", Message: "Reservation not found"}
+	default:
+		return &APIError{Code: "DECISION_FAILED", Message: err.Error()}
+	}
+}
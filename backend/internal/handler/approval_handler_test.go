@@ -0,0 +1,169 @@
+// backend/internal/handler/approval_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestApprovalHandler_ListPendingApprovals(t *testing.T) {
+	mockApp := new(MockApprovalService)
+	h := handler.NewApprovalHandler(mockApp)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	resourceID := uuid.New()
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		query         string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success with filters",
+			query: fmt.Sprintf("?resource_id=%s&from=%s&limit=20&offset=40", resourceID, from.Format(time.RFC3339)),
+			setupMock: func() {
+				mockApp.On("GetPendingApprovals", mock.Anything, userID, service.PendingApprovalsQuery{
+					ResourceID: &resourceID,
+					From:       &from,
+					Limit:      20,
+					Offset:     40,
+				}).Return([]*domain.Reservation{{ID: uuid.New()}}, 41, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "Default pagination",
+			query: "",
+			setupMock: func() {
+				mockApp.On("GetPendingApprovals", mock.Anything, userID, service.PendingApprovalsQuery{
+					Limit: service.DefaultPendingApprovalsLimit,
+				}).Return([]*domain.Reservation{}, 0, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Invalid resource ID",
+			query:         "?resource_id=not-a-uuid",
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_RESOURCE_ID",
+		},
+		{
+			name:          "Limit out of range",
+			query:         "?limit=500",
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_QUERY_PARAM",
+		},
+		{
+			name:          "Invalid time range",
+			query:         "?from=2025-06-02T00:00:00Z&to=2025-06-01T00:00:00Z",
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_TIME_RANGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockApp.ExpectedCalls = nil
+			mockApp.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", "/api/v1/approvals"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.ListPendingApprovals(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockApp.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApprovalHandler_BulkDecide(t *testing.T) {
+	mockApp := new(MockApprovalService)
+	h := handler.NewApprovalHandler(mockApp)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	approveID := uuid.New()
+	rejectID := uuid.New()
+	startAt := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mockApp.On("DecideBulk", mock.Anything, userID, mock.MatchedBy(func(items []service.BulkDecisionItem) bool {
+		return len(items) == 2 && items[0].ReservationID == approveID && items[1].Reason == "room closed"
+	})).Return([]service.BulkDecisionResult{
+		{Item: service.BulkDecisionItem{ReservationID: approveID, Action: service.BulkDecisionApprove}},
+		{Item: service.BulkDecisionItem{ReservationID: rejectID, Action: service.BulkDecisionReject}, Err: service.ErrNotApprover},
+	}, nil)
+
+	body := map[string]interface{}{
+		"items": []map[string]string{
+			{"id": approveID.String(), "start_at": startAt.Format(time.RFC3339), "action": "approve"},
+			{"id": "bad-id", "start_at": startAt.Format(time.RFC3339), "action": "approve"},
+			{"id": rejectID.String(), "start_at": startAt.Format(time.RFC3339), "action": "reject", "reason": "room closed"},
+		},
+	}
+	bodyBytes, _ := json.Marshal(body)
+	req := httptest.NewRequest("POST", "/api/v1/approvals/bulk", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	h.BulkDecide(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Data handler.BulkDecisionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Results, 3)
+	assert.True(t, resp.Data.Results[0].Success)
+	assert.Equal(t, "INVALID_ID", resp.Data.Results[1].Error.Code)
+	assert.Equal(t, "FORBIDDEN", resp.Data.Results[2].Error.Code)
+	assert.Equal(t, 1, resp.Data.Succeeded)
+	assert.Equal(t, 2, resp.Data.Failed)
+	mockApp.AssertExpectations(t)
+}
+
+func TestApprovalHandler_BulkDecide_EmptyItems(t *testing.T) {
+	mockApp := new(MockApprovalService)
+	h := handler.NewApprovalHandler(mockApp)
+
+	req := httptest.NewRequest("POST", "/api/v1/approvals/bulk", bytes.NewReader([]byte(`{"items":[]}`)))
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: uuid.New()})
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	h.BulkDecide(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockApp.AssertNotCalled(t, "DecideBulk", mock.Anything, mock.Anything, mock.Anything)
+}
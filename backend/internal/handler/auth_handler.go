@@ -3,21 +3,34 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/your-org/esms/internal/service"
 )
 
+// SessionCookieName はセッションIDを保持するCookieの名前
+const SessionCookieName = "session_id"
+
+// AuthStateCookieName は認証開始時に発行した state をログインを開始したブラウザに紐付けるCookieの名前
+const AuthStateCookieName = "auth_state"
+
 // AuthServiceInterface は認証サービスのインターフェース
 type AuthServiceInterface interface {
-	GetAuthURL(state string) string
-	HandleCallback(ctx context.Context, code, state string) (*service.Session, error)
-	Logout(ctx context.Context, sessionID string, userID uuid.UUID) error
+	HandleCallback(ctx context.Context, code, state, previousSessionID string) (*service.Session, error)
+	Logout(ctx context.Context, sessionID string, userID uuid.UUID) (string, error)
 	RefreshSession(ctx context.Context, sessionID string) (*service.Session, error)
-	GetSession(sessionID string) (*service.Session, error)
+	GetSession(ctx context.Context, sessionID string) (*service.Session, error)
+	ResolveProvider(providerName, email string) (string, error)
+	ListProviders() []service.ProviderInfo
+	BeginLogin(ctx context.Context, providerName string) (string, string, error)
+	CompleteLogin(ctx context.Context, protocol, state string, params service.CallbackParams, previousSessionID string) (*service.Session, error)
+	ProviderMetadata(providerName string) ([]byte, error)
 }
 
 // AuthHandler は認証関連のHTTPハンドラー
@@ -34,23 +47,54 @@ func NewAuthHandler(authService AuthServiceInterface) *AuthHandler {
 
 // RegisterRoutes はルートを登録します
 func (h *AuthHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/auth/providers", h.Providers).Methods("GET")
 	r.HandleFunc("/api/v1/auth/login", h.Login).Methods("GET")
 	r.HandleFunc("/api/v1/auth/callback", h.Callback).Methods("GET")
-	r.HandleFunc("/api/v1/auth/logout", h.Logout).Methods("POST")
 	r.HandleFunc("/api/v1/auth/refresh", h.Refresh).Methods("POST")
+	r.HandleFunc("/api/v1/auth/saml/login", h.SAMLLogin).Methods("GET")
+	r.HandleFunc("/api/v1/auth/saml/acs", h.SAMLACS).Methods("POST")
+	r.HandleFunc("/api/v1/auth/saml/metadata", h.SAMLMetadata).Methods("GET")
+}
+
+// RegisterProtectedRoutes は認証が必要なルートを登録します
+// ログアウトはセッションが必要なため、認証ミドルウェアを通るこちらに登録します
+func (h *AuthHandler) RegisterProtectedRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/auth/csrf", h.CSRFToken).Methods("GET")
+	r.HandleFunc("/api/v1/auth/logout", h.Logout).Methods("POST")
+}
+
+// Providers はログインに使用できるIdPの一覧を返します
+func (h *AuthHandler) Providers(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"providers": h.authService.ListProviders(),
+	})
 }
 
-// Login はOIDC認証を開始します
+// Login はIdPでの認証を開始します
+// IdPは provider パラメータで指定するか、email パラメータのドメインから決定します（どちらもない場合は既定のIdP）
+// state はサーバーで生成し、コールバックで照合するため HttpOnly Cookie にも設定します
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	state := r.URL.Query().Get("state")
-	if state == "" {
-		state = "random-state" // 本番環境では適切なstate生成
+	query := r.URL.Query()
+	providerName, err := h.authService.ResolveProvider(query.Get("provider"), query.Get("email"))
+	if err != nil {
+		if errors.Is(err, service.ErrProviderNotFound) {
+			WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Unknown identity provider")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
 	}
 
-	authURL := h.authService.GetAuthURL(state)
+	authURL, state, err := h.authService.BeginLogin(r.Context(), providerName)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
 
+	setAuthStateCookie(w, state)
 	WriteJSON(w, http.StatusOK, map[string]string{
 		"auth_url": authURL,
+		"provider": providerName,
 	})
 }
 
@@ -63,48 +107,120 @@ func (h *AuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Missing code parameter")
 		return
 	}
+	// ログインを開始したブラウザ以外に返された state は受け付けない（ログインCSRF対策）
+	if !authStateMatches(r, state) {
+		h.writeLoginResult(w, nil, service.ErrInvalidState)
+		return
+	}
+
+	// ログイン前のセッションCookieは引き継がず、新しいセッションIDに切り替える（セッション固定攻撃対策）
+	var previousSessionID string
+	if cookie, err := r.Cookie(SessionCookieName); err == nil {
+		previousSessionID = cookie.Value
+	}
+
+	session, err := h.authService.HandleCallback(withClientInfo(r), code, state, previousSessionID)
+	h.writeLoginResult(w, session, err)
+}
 
-	session, err := h.authService.HandleCallback(r.Context(), code, state)
+// SAMLLogin はSAML認証（SP-initiated）を開始します
+// provider パラメータで名前付きのSAMLのIdPを指定できます（省略時は SAML_* で設定したIdP）
+// RelayState として渡す state はサーバーで生成し、ACSで照合するため HttpOnly Cookie にも設定します
+func (h *AuthHandler) SAMLLogin(w http.ResponseWriter, r *http.Request) {
+	authURL, state, err := h.authService.BeginLogin(r.Context(), samlProviderName(r))
 	if err != nil {
-		WriteError(w, http.StatusUnauthorized, "AUTH_FAILED", err.Error())
+		if errors.Is(err, service.ErrProviderNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "SAML is not configured")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
 
-	// セッションIDをCookieに設定
-	// 本番環境では session.ID を使用すべきだが、現状の HandleCallback は *Session を返しており
-	// Session 構造体に ID フィールドがない可能性があるため、アクセストークンを代用するか、
-	// AuthService 側で SessionID を返すように修正が必要。
-	// ここでは仮に "session-token" とするが、実際には session オブジェクトから適切なIDを取得すべき。
-	// auth_service.go の Session 定義を見ると ID フィールドがない。
-	// しかし、HandleCallback 内で sessionID := uuid.New().String() を生成して map に保存している。
-	// HandleCallback の戻り値に sessionID を含めるか、Session 構造体に ID を追加するのが正しい。
-	// 今回はリファクタリングの範囲を広げすぎないため、AccessToken をセッションIDとして扱う(middlewareの実装に合わせる)
-	// ただし、middleware.go では `session, err := m.authService.GetSession(token)` としており、
-	// auth_service.go の GetSession は sessionID をキーにしている。
-	// auth_service.go の HandleCallback は `return session, nil` しており、sessionID を返していない。
-	// これは設計上の不整合。
-	// 修正案: HandleCallback が (*Session, string, error) を返すようにするか、Session に ID を持たせる。
-	// ここでは、AuthService の HandleCallback を修正するのは手間がかかるため、
-	// AuthHandler 側で Cookie にセットする値は一旦 AccessToken (or Mock value) とするが、
-	// 正しくは SessionID であるべき。
-	// 既存のコード `WriteJSON` では `"session_id": "session-token"` とハードコードされていた。
-	// これを改善する。
-
-	// TODO: AuthService.HandleCallback の戻り値を修正して sessionID を取得できるようにすべき。
-	// 今回は Cookie 設定のロジックを追加することに注力し、値は仮置きする。
-	sessionID := "session-token" // 仮の値。本来は AuthService から返却されるべき。
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    sessionID,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true, // 本番環境ではtrue (HTTPS必須)
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   3600, // 1時間
+	setAuthStateCookie(w, state)
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"auth_url": authURL,
 	})
+}
+
+// SAMLACS はIdPから HTTP-POST バインディングで送られる SAMLResponse を処理します（Assertion Consumer Service）
+func (h *AuthHandler) SAMLACS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid form data")
+		return
+	}
+
+	samlResponse := r.PostForm.Get("SAMLResponse")
+	if samlResponse == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Missing SAMLResponse parameter")
+		return
+	}
+
+	// RelayState には認証開始時の state がそのまま返される（IdPは state に紐付けて保存したプロバイダーで決まる）
+	relayState := r.PostForm.Get("RelayState")
+	if !authStateMatches(r, relayState) {
+		h.writeLoginResult(w, nil, service.ErrInvalidState)
+		return
+	}
+
+	var previousSessionID string
+	if cookie, err := r.Cookie(SessionCookieName); err == nil {
+		previousSessionID = cookie.Value
+	}
+
+	session, err := h.authService.CompleteLogin(withClientInfo(r), service.ProviderSAML, relayState,
+		service.CallbackParams{SAMLResponse: samlResponse}, previousSessionID)
+	h.writeLoginResult(w, session, err)
+}
+
+// SAMLMetadata はIdPに登録するSPのメタデータを返します
+// provider パラメータで名前付きのSAMLのIdPを指定できます（省略時は SAML_* で設定したIdP）
+func (h *AuthHandler) SAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.authService.ProviderMetadata(samlProviderName(r))
+	if err != nil {
+		if errors.Is(err, service.ErrProviderNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "SAML is not configured")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(metadata)
+}
+
+// samlProviderName はSAMLのエンドポイントで使用するログインプロバイダーの名前を返します
+func samlProviderName(r *http.Request) string {
+	if name := r.URL.Query().Get("provider"); name != "" {
+		return name
+	}
+	return service.ProviderSAML
+}
+
+// writeLoginResult はログインの結果（セッションCookieとセッション情報、またはエラー）を書き込みます
+func (h *AuthHandler) writeLoginResult(w http.ResponseWriter, session *service.Session, err error) {
+	if err != nil {
+		if errors.Is(err, service.ErrIdentityConflict) {
+			// メールアドレスでのアカウントの紐付けが曖昧な場合は管理者の対応（突合レポートの解決）を待つ
+			WriteError(w, http.StatusConflict, "IDENTITY_CONFLICT", "Your account could not be matched automatically. Please contact an administrator.")
+			return
+		}
+		if errors.Is(err, service.ErrUserDeactivated) {
+			WriteError(w, http.StatusForbidden, "ACCOUNT_DISABLED", "Your account has been deactivated")
+			return
+		}
+		WriteError(w, http.StatusUnauthorized, "AUTH_FAILED", err.Error())
+		return
+	}
 
+	setSessionCookie(w, session)
+
+	// Cookie を扱えないAPIクライアントは session_id を Bearer トークンとして使用する
 	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"session_id": session.ID,
+		"csrf_token": session.CSRFToken,
 		"user_id":    session.UserID,
 		"email":      session.Email,
 		"name":       session.Name,
@@ -121,40 +237,41 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// CookieからセッションIDを取得
-	cookie, err := r.Cookie("session_id")
-	var sessionID string
-	if err == nil {
-		sessionID = cookie.Value
-	} else {
-		// ヘッダーからの取得もサポート（後方互換性）
-		authHeader := r.Header.Get("Authorization")
-		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-			sessionID = authHeader[7:]
-		}
-	}
-
+	var logoutURL string
+	sessionID, _, _ := sessionIDFromRequest(r)
 	if sessionID != "" {
-		err := h.authService.Logout(r.Context(), sessionID, session.UserID)
+		var err error
+		logoutURL, err = h.authService.Logout(r.Context(), sessionID, session.UserID)
 		if err != nil {
 			WriteError(w, http.StatusInternalServerError, "LOGOUT_FAILED", err.Error())
 			return
 		}
 	}
 
-	// Cookieを削除
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   -1,
-	})
+	clearSessionCookie(w)
 
-	WriteJSON(w, http.StatusOK, map[string]string{
+	// logout_url がある場合、SPA はブラウザをIdPのログアウト画面にリダイレクトしてIdPのセッションも終了させる
+	response := map[string]string{
 		"message": "Logged out successfully",
+	}
+	if logoutURL != "" {
+		response["logout_url"] = logoutURL
+	}
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// CSRFToken はセッションに紐づくCSRFトークンを返します
+// SPA は更新系リクエストの X-CSRF-Token ヘッダーにこの値を設定します
+func (h *AuthHandler) CSRFToken(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"csrf_token": session.CSRFToken,
 	})
 }
 
@@ -166,7 +283,7 @@ type RefreshRequest struct {
 // Refresh はセッションをリフレッシュします
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	// CookieからセッションIDを取得
-	cookie, err := r.Cookie("session_id")
+	cookie, err := r.Cookie(SessionCookieName)
 	var sessionID string
 	if err == nil {
 		sessionID = cookie.Value
@@ -189,18 +306,64 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Cookieを更新（有効期限延長など）
+	// リフレッシュ後はセッションIDが切り替わるため Cookie も更新する
+	setSessionCookie(w, session)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"session_id": session.ID,
+		"expires_at": session.ExpiresAt,
+	})
+}
+
+// setSessionCookie はセッションIDを HttpOnly Cookie に設定します（有効期限はセッションに合わせる）
+func setSessionCookie(w http.ResponseWriter, session *service.Session) {
+	maxAge := int(time.Until(session.ExpiresAt).Seconds())
+	if maxAge < 1 {
+		maxAge = 1
+	}
 	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    sessionID,
+		Name:     SessionCookieName,
+		Value:    session.ID,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   true,
+		Secure:   true, // HTTPS必須
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   3600, // 延長
+		MaxAge:   maxAge,
 	})
+}
 
-	WriteJSON(w, http.StatusOK, map[string]interface{}{
-		"expires_at": session.ExpiresAt,
+// setAuthStateCookie は認証開始時の state を HttpOnly Cookie に設定します（有効期限は state の保存期間に合わせる）
+// SAML の ACS は IdP からのクロスサイトの POST で呼ばれるため SameSite=None とします
+func setAuthStateCookie(w http.ResponseWriter, state string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     AuthStateCookieName,
+		Value:    state,
+		Path:     "/api/v1/auth",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+		MaxAge:   int(service.AuthRequestTTL.Seconds()),
+	})
+}
+
+// authStateMatches はコールバックの state がブラウザの state Cookie と一致するかを返します
+func authStateMatches(r *http.Request, state string) bool {
+	cookie, err := r.Cookie(AuthStateCookieName)
+	if err != nil || cookie.Value == "" || state == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) == 1
+}
+
+// clearSessionCookie はセッションCookieを削除します
+func clearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
 	})
 }
@@ -4,8 +4,11 @@ package handler_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,23 +24,113 @@ func TestAuthHandler_Login(t *testing.T) {
 	mockAuth := new(MockAuthService)
 	h := handler.NewAuthHandler(mockAuth)
 
-	mockAuth.On("GetAuthURL", "test-state").Return("http://auth.example.com?state=test-state")
+	mockAuth.On("ResolveProvider", "", "").Return(service.ProviderOIDC, nil)
+	mockAuth.On("BeginLogin", mock.Anything, service.ProviderOIDC).Return("http://auth.example.com?state=server-state", "server-state", nil)
 
-	req := httptest.NewRequest("GET", "/api/v1/auth/login?state=test-state", nil)
+	// クライアントが指定した state は使用しない
+	req := httptest.NewRequest("GET", "/api/v1/auth/login?state=client-state", nil)
 	w := httptest.NewRecorder()
 
 	h.Login(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "http://auth.example.com?state=test-state")
+	assert.Contains(t, w.Body.String(), "http://auth.example.com?state=server-state")
+
+	// サーバーで生成した state をブラウザに紐付ける
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, handler.AuthStateCookieName, cookies[0].Name)
+	assert.Equal(t, "server-state", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, int(service.AuthRequestTTL.Seconds()), cookies[0].MaxAge)
 	mockAuth.AssertExpectations(t)
 }
 
+func TestAuthHandler_Login_SelectsProvider(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		provider     string
+		email        string
+		resolved     string
+		resolveErr   error
+		wantStatus   int
+		wantProvider string
+	}{
+		{
+			name:         "email domain",
+			query:        "state=s1&email=taro@corp-b.example.com",
+			email:        "taro@corp-b.example.com",
+			resolved:     "corp-b",
+			wantStatus:   http.StatusOK,
+			wantProvider: "corp-b",
+		},
+		{
+			name:         "explicit provider",
+			query:        "state=s1&provider=corp-c",
+			provider:     "corp-c",
+			resolved:     "corp-c",
+			wantStatus:   http.StatusOK,
+			wantProvider: "corp-c",
+		},
+		{
+			name:       "unknown provider",
+			query:      "state=s1&provider=unknown",
+			provider:   "unknown",
+			resolveErr: fmt.Errorf("%w: unknown", service.ErrProviderNotFound),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := new(MockAuthService)
+			h := handler.NewAuthHandler(mockAuth)
+
+			mockAuth.On("ResolveProvider", tt.provider, tt.email).Return(tt.resolved, tt.resolveErr)
+			if tt.resolveErr == nil {
+				mockAuth.On("BeginLogin", mock.Anything, tt.resolved).Return("https://idp.example.com/authorize", "s1", nil)
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/auth/login?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			h.Login(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantProvider != "" {
+				assert.Contains(t, w.Body.String(), `"provider":"`+tt.wantProvider+`"`)
+			}
+			mockAuth.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_Providers(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("ListProviders").Return([]service.ProviderInfo{
+		{Name: "corp-b", Protocol: service.ProviderSAML},
+		{Name: service.ProviderOIDC, Protocol: service.ProviderOIDC, Default: true},
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/providers", nil)
+	w := httptest.NewRecorder()
+
+	h.Providers(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `{"name":"corp-b","protocol":"saml","default":false}`)
+}
+
 func TestAuthHandler_Callback_Success(t *testing.T) {
 	mockAuth := new(MockAuthService)
 	h := handler.NewAuthHandler(mockAuth)
 
 	session := &service.Session{
+		ID:        "new-session-id",
 		UserID:    uuid.New(),
 		Email:     "test@example.com",
 		Name:      "Test User",
@@ -45,9 +138,12 @@ func TestAuthHandler_Callback_Success(t *testing.T) {
 		ExpiresAt: time.Now().Add(1 * time.Hour),
 	}
 
-	mockAuth.On("HandleCallback", mock.Anything, "valid-code", "valid-state").Return(session, nil)
+	// ログイン前のセッションIDは新しいセッションに引き継がない
+	mockAuth.On("HandleCallback", mock.Anything, "valid-code", "valid-state", "fixated-session-id").Return(session, nil)
 
 	req := httptest.NewRequest("GET", "/api/v1/auth/callback?code=valid-code&state=valid-state", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "fixated-session-id"})
+	req.AddCookie(&http.Cookie{Name: handler.AuthStateCookieName, Value: "valid-state"})
 	w := httptest.NewRecorder()
 
 	h.Callback(w, req)
@@ -65,7 +161,8 @@ func TestAuthHandler_Callback_Success(t *testing.T) {
 		}
 	}
 	assert.NotNil(t, sessionCookie)
-	assert.Equal(t, "session-token", sessionCookie.Value) // Mock value from handler
+	assert.Equal(t, "new-session-id", sessionCookie.Value)
+	assert.True(t, sessionCookie.MaxAge > 0)
 	assert.True(t, sessionCookie.HttpOnly)
 	assert.True(t, sessionCookie.Secure)
 	assert.Equal(t, http.SameSiteLaxMode, sessionCookie.SameSite)
@@ -88,9 +185,10 @@ func TestAuthHandler_Callback_AuthFailed(t *testing.T) {
 	mockAuth := new(MockAuthService)
 	h := handler.NewAuthHandler(mockAuth)
 
-	mockAuth.On("HandleCallback", mock.Anything, "invalid-code", "valid-state").Return(nil, errors.New("auth failed"))
+	mockAuth.On("HandleCallback", mock.Anything, "invalid-code", "valid-state", "").Return(nil, errors.New("auth failed"))
 
 	req := httptest.NewRequest("GET", "/api/v1/auth/callback?code=invalid-code&state=valid-state", nil)
+	req.AddCookie(&http.Cookie{Name: handler.AuthStateCookieName, Value: "valid-state"})
 	w := httptest.NewRecorder()
 
 	h.Callback(w, req)
@@ -98,6 +196,69 @@ func TestAuthHandler_Callback_AuthFailed(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+func TestAuthHandler_Callback_IdentityConflict(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("HandleCallback", mock.Anything, "valid-code", "valid-state", "").Return(nil, fmt.Errorf("failed to sync user: %w", service.ErrIdentityConflict))
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/callback?code=valid-code&state=valid-state", nil)
+	req.AddCookie(&http.Cookie{Name: handler.AuthStateCookieName, Value: "valid-state"})
+	w := httptest.NewRecorder()
+
+	h.Callback(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "IDENTITY_CONFLICT")
+	assert.Empty(t, w.Result().Cookies())
+}
+
+func TestAuthHandler_Callback_UserDeactivated(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("HandleCallback", mock.Anything, "valid-code", "valid-state", "").Return(nil, fmt.Errorf("failed to sync user: %w", service.ErrUserDeactivated))
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/callback?code=valid-code&state=valid-state", nil)
+	req.AddCookie(&http.Cookie{Name: handler.AuthStateCookieName, Value: "valid-state"})
+	w := httptest.NewRecorder()
+
+	h.Callback(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "ACCOUNT_DISABLED")
+	assert.Empty(t, w.Result().Cookies())
+}
+
+func TestAuthHandler_Callback_StateMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+	}{
+		{name: "no state cookie"},
+		{name: "different state", cookie: &http.Cookie{Name: handler.AuthStateCookieName, Value: "attacker-state"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := new(MockAuthService)
+			h := handler.NewAuthHandler(mockAuth)
+
+			req := httptest.NewRequest("GET", "/api/v1/auth/callback?code=valid-code&state=valid-state", nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			w := httptest.NewRecorder()
+
+			h.Callback(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Empty(t, w.Result().Cookies())
+			mockAuth.AssertNotCalled(t, "HandleCallback", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestAuthHandler_Logout(t *testing.T) {
 	mockAuth := new(MockAuthService)
 	h := handler.NewAuthHandler(mockAuth)
@@ -105,7 +266,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 	userID := uuid.New()
 	session := &service.Session{UserID: userID}
 
-	mockAuth.On("Logout", mock.Anything, "session-id", userID).Return(nil)
+	mockAuth.On("Logout", mock.Anything, "session-id", userID).Return("", nil)
 
 	req := httptest.NewRequest("POST", "/api/v1/auth/logout", nil)
 	// Set context with session
@@ -132,4 +293,206 @@ func TestAuthHandler_Logout(t *testing.T) {
 	assert.NotNil(t, sessionCookie)
 	assert.Equal(t, "", sessionCookie.Value)
 	assert.True(t, sessionCookie.MaxAge < 0)
+	assert.NotContains(t, w.Body.String(), "logout_url")
+}
+
+func TestAuthHandler_Logout_IdPLogoutURL(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID, Provider: "corp-c"}
+
+	// セッションを発行したIdPのログアウトURLを返す
+	mockAuth.On("Logout", mock.Anything, "session-id", userID).Return("https://corp-c.example.com/logout?id_token_hint=xxx", nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, session))
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "session-id"})
+	w := httptest.NewRecorder()
+
+	h.Logout(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"logout_url":"https://corp-c.example.com/logout?id_token_hint=xxx"`)
+}
+
+func TestAuthHandler_Refresh_RotatesSessionCookie(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("RefreshSession", mock.Anything, "old-session-id").Return(&service.Session{
+		ID:        "rotated-session-id",
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().Add(1 * time.Hour),
+	}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "old-session-id"})
+	w := httptest.NewRecorder()
+
+	h.Refresh(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "rotated-session-id", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+	mockAuth.AssertExpectations(t)
+}
+
+func TestAuthHandler_CSRFToken(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/csrf", nil)
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: uuid.New(), CSRFToken: "csrf-token"})
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	h.CSRFToken(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"csrf_token":"csrf-token"`)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	// 未認証の場合はトークンを返さない
+	w = httptest.NewRecorder()
+	h.CSRFToken(w, httptest.NewRequest("GET", "/api/v1/auth/csrf", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthHandler_SAMLLogin(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("BeginLogin", mock.Anything, service.ProviderSAML).Return("https://idp.example.com/saml/sso?SAMLRequest=xxx&RelayState=server-state", "server-state", nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/saml/login", nil)
+	w := httptest.NewRecorder()
+
+	h.SAMLLogin(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://idp.example.com/saml/sso?SAMLRequest=xxx")
+
+	// ACS は IdP からのクロスサイトの POST で呼ばれるため SameSite=None で送信させる
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "server-state", cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteNoneMode, cookies[0].SameSite)
+	mockAuth.AssertExpectations(t)
+}
+
+func TestAuthHandler_SAMLLogin_NotConfigured(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("BeginLogin", mock.Anything, service.ProviderSAML).Return("", "", service.ErrProviderNotFound)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/saml/login", nil)
+	w := httptest.NewRecorder()
+
+	h.SAMLLogin(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAuthHandler_SAMLACS(t *testing.T) {
+	session := &service.Session{
+		ID:        "new-session-id",
+		UserID:    uuid.New(),
+		Email:     "test@example.com",
+		Role:      domain.RoleGeneral,
+		ExpiresAt: time.Now().Add(1 * time.Hour),
+	}
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		setup      func(m *MockAuthService)
+		wantStatus int
+		wantCookie bool
+	}{
+		{
+			name: "success",
+			form: url.Values{"SAMLResponse": {"base64-response"}, "RelayState": {"valid-state"}},
+			setup: func(m *MockAuthService) {
+				m.On("CompleteLogin", mock.Anything, service.ProviderSAML, "valid-state", service.CallbackParams{SAMLResponse: "base64-response"}, "").Return(session, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantCookie: true,
+		},
+		{
+			name:       "missing SAMLResponse",
+			form:       url.Values{"RelayState": {"valid-state"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "RelayState not issued to this browser",
+			form:       url.Values{"SAMLResponse": {"base64-response"}, "RelayState": {"attacker-state"}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid response",
+			form: url.Values{"SAMLResponse": {"tampered"}, "RelayState": {"valid-state"}},
+			setup: func(m *MockAuthService) {
+				m.On("CompleteLogin", mock.Anything, service.ProviderSAML, "valid-state", mock.Anything, "").Return(nil, errors.New("invalid signature"))
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "identity conflict",
+			form: url.Values{"SAMLResponse": {"base64-response"}, "RelayState": {"valid-state"}},
+			setup: func(m *MockAuthService) {
+				m.On("CompleteLogin", mock.Anything, service.ProviderSAML, "valid-state", mock.Anything, "").Return(nil, fmt.Errorf("failed to sync user: %w", service.ErrIdentityConflict))
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := new(MockAuthService)
+			h := handler.NewAuthHandler(mockAuth)
+			if tt.setup != nil {
+				tt.setup(mockAuth)
+			}
+
+			req := httptest.NewRequest("POST", "/api/v1/auth/saml/acs", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.AddCookie(&http.Cookie{Name: handler.AuthStateCookieName, Value: "valid-state"})
+			w := httptest.NewRecorder()
+
+			h.SAMLACS(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantCookie {
+				cookies := w.Result().Cookies()
+				assert.Len(t, cookies, 1)
+				assert.Equal(t, "new-session-id", cookies[0].Value)
+				assert.True(t, cookies[0].HttpOnly)
+			} else {
+				assert.Empty(t, w.Result().Cookies())
+			}
+			mockAuth.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_SAMLMetadata(t *testing.T) {
+	mockAuth := new(MockAuthService)
+	h := handler.NewAuthHandler(mockAuth)
+
+	mockAuth.On("ProviderMetadata", service.ProviderSAML).Return([]byte("<md:EntityDescriptor/>"), nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/saml/metadata", nil)
+	w := httptest.NewRecorder()
+
+	h.SAMLMetadata(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/samlmetadata+xml", w.Header().Get("Content-Type"))
+	assert.Equal(t, "<md:EntityDescriptor/>", w.Body.String())
 }
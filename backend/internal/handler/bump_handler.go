@@ -0,0 +1,157 @@
+// backend/internal/handler/bump_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// BumpServiceInterface は会議室の優先確保サービスのインターフェース
+type BumpServiceInterface interface {
+	Bump(ctx context.Context, req *service.BumpRequest) (*service.BumpResult, error)
+}
+
+// BumpHandler は役員予約による会議室の優先確保関連のHTTPハンドラー
+type BumpHandler struct {
+	bumpService BumpServiceInterface
+}
+
+// NewBumpHandler は新しいBumpHandlerを作成します
+func NewBumpHandler(bumpService BumpServiceInterface) *BumpHandler {
+	return &BumpHandler{
+		bumpService: bumpService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *BumpHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/events/bump", h.Bump).Methods("POST")
+}
+
+// BumpRequest は会議室の優先確保リクエスト
+type BumpRequest struct {
+	ResourceID     string    `json:"resource_id"`
+	OnBehalfOf     string    `json:"on_behalf_of"` // 秘書が担当役員の代理で確保する場合の役員のユーザーID
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+	Timezone       string    `json:"timezone"`
+	IsPrivate      bool      `json:"is_private"`
+	ParticipantIDs []string  `json:"participant_ids"`
+	Reason         string    `json:"reason"` // 押しのけられる予約の主催者へ通知する理由
+}
+
+// Bump は既存の予約を押しのけて会議室を確保します
+// 押しのけた予約は同等の空き会議室へ移動し、移動先がなければ強制キャンセルします
+func (h *BumpHandler) Bump(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req BumpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if req.Title == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TITLE", "Title is required")
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_REASON", "Reason is required")
+		return
+	}
+	if req.Timezone == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone is required")
+		return
+	}
+	if req.StartAt.IsZero() || req.EndAt.IsZero() {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "StartAt and EndAt are required")
+		return
+	}
+	if !req.EndAt.After(req.StartAt) {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "EndAt must be after StartAt")
+		return
+	}
+
+	resourceID, err := uuid.Parse(req.ResourceID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID")
+		return
+	}
+
+	var onBehalfOf *uuid.UUID
+	if req.OnBehalfOf != "" {
+		id, err := uuid.Parse(req.OnBehalfOf)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid on_behalf_of user ID")
+			return
+		}
+		onBehalfOf = &id
+	}
+
+	participantIDs := make([]uuid.UUID, len(req.ParticipantIDs))
+	for i, id := range req.ParticipantIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_PARTICIPANT_ID", "Invalid participant ID")
+			return
+		}
+		participantIDs[i] = parsed
+	}
+
+	result, err := h.bumpService.Bump(r.Context(), &service.BumpRequest{
+		ActorID:        session.UserID,
+		OnBehalfOf:     onBehalfOf,
+		ResourceID:     resourceID,
+		Title:          req.Title,
+		Description:    req.Description,
+		StartAt:        req.StartAt,
+		EndAt:          req.EndAt,
+		Timezone:       req.Timezone,
+		IsPrivate:      req.IsPrivate,
+		ParticipantIDs: participantIDs,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		writeBumpError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, result)
+}
+
+// writeBumpError は優先確保サービスのエラーをHTTPレスポンスに変換します
+// 予約の作成時のエラーは予約作成と同じレスポンスにします
+func writeBumpError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrBumpReasonRequired):
+		WriteError(w, http.StatusBadRequest, "INVALID_REASON", err.Error())
+	case errors.Is(err, service.ErrBumpForbidden):
+		WriteError(w, http.StatusForbidden, "BUMP_FORBIDDEN", err.Error())
+	case errors.Is(err, service.ErrBumpOutranked):
+		WriteError(w, http.StatusConflict, "BUMP_OUTRANKED", err.Error())
+	case errors.Is(err, service.ErrNothingToBump):
+		WriteError(w, http.StatusConflict, "NOTHING_TO_BUMP", err.Error())
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Resource or user not found")
+	default:
+		writeCreateReservationError(w, err)
+	}
+}
@@ -0,0 +1,125 @@
+// backend/internal/handler/bump_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestBumpHandler_Bump(t *testing.T) {
+	mockBump := new(MockBumpService)
+	h := handler.NewBumpHandler(mockBump)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	resourceID := uuid.New()
+	executiveID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+	validBody := func() map[string]interface{} {
+		return map[string]interface{}{
+			"resource_id":  resourceID.String(),
+			"on_behalf_of": executiveID.String(),
+			"title":        "Executive committee",
+			"start_at":     startAt,
+			"end_at":       startAt.Add(2 * time.Hour),
+			"timezone":     "Asia/Tokyo",
+			"reason":       "Executive committee",
+		}
+	}
+
+	tests := []struct {
+		name          string
+		body          func() map[string]interface{}
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: validBody,
+			setupMock: func() {
+				mockBump.On("Bump", mock.Anything, mock.MatchedBy(func(req *service.BumpRequest) bool {
+					return req.ActorID == userID && req.ResourceID == resourceID && *req.OnBehalfOf == executiveID
+				})).Return(&service.BumpResult{
+					Reservation: &domain.Reservation{ID: uuid.New()},
+					Displaced:   []*domain.ReservationBump{{ID: uuid.New(), Outcome: domain.BumpOutcomeCancelled}},
+				}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Missing reason",
+			body: func() map[string]interface{} {
+				body := validBody()
+				delete(body, "reason")
+				return body
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_REASON",
+		},
+		{
+			name: "Room policy forbids",
+			body: validBody,
+			setupMock: func() {
+				mockBump.On("Bump", mock.Anything, mock.Anything).Return(nil, service.ErrBumpForbidden)
+			},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "BUMP_FORBIDDEN",
+		},
+		{
+			name: "Outranked",
+			body: validBody,
+			setupMock: func() {
+				mockBump.On("Bump", mock.Anything, mock.Anything).Return(nil, service.ErrBumpOutranked)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "BUMP_OUTRANKED",
+		},
+		{
+			name: "Room is free",
+			body: validBody,
+			setupMock: func() {
+				mockBump.On("Bump", mock.Anything, mock.Anything).Return(nil, service.ErrNothingToBump)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "NOTHING_TO_BUMP",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBump.ExpectedCalls = nil
+			mockBump.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(tt.body())
+			req := httptest.NewRequest("POST", "/api/v1/events/bump", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.Bump(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockBump.AssertExpectations(t)
+		})
+	}
+}
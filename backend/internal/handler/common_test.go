@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
 	"github.com/your-org/esms/internal/service"
 )
 
@@ -15,22 +16,17 @@ type MockAuthService struct {
 	mock.Mock
 }
 
-func (m *MockAuthService) GetAuthURL(state string) string {
-	args := m.Called(state)
-	return args.String(0)
-}
-
-func (m *MockAuthService) HandleCallback(ctx context.Context, code, state string) (*service.Session, error) {
-	args := m.Called(ctx, code, state)
+func (m *MockAuthService) HandleCallback(ctx context.Context, code, state, previousSessionID string) (*service.Session, error) {
+	args := m.Called(ctx, code, state, previousSessionID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*service.Session), args.Error(1)
 }
 
-func (m *MockAuthService) Logout(ctx context.Context, sessionID string, userID uuid.UUID) error {
+func (m *MockAuthService) Logout(ctx context.Context, sessionID string, userID uuid.UUID) (string, error) {
 	args := m.Called(ctx, sessionID, userID)
-	return args.Error(0)
+	return args.String(0), args.Error(1)
 }
 
 func (m *MockAuthService) RefreshSession(ctx context.Context, sessionID string) (*service.Session, error) {
@@ -41,14 +37,45 @@ func (m *MockAuthService) RefreshSession(ctx context.Context, sessionID string)
 	return args.Get(0).(*service.Session), args.Error(1)
 }
 
-func (m *MockAuthService) GetSession(sessionID string) (*service.Session, error) {
-	args := m.Called(sessionID)
+func (m *MockAuthService) GetSession(ctx context.Context, sessionID string) (*service.Session, error) {
+	args := m.Called(ctx, sessionID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*service.Session), args.Error(1)
 }
 
+func (m *MockAuthService) ResolveProvider(providerName, email string) (string, error) {
+	args := m.Called(providerName, email)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthService) ListProviders() []service.ProviderInfo {
+	args := m.Called()
+	return args.Get(0).([]service.ProviderInfo)
+}
+
+func (m *MockAuthService) BeginLogin(ctx context.Context, providerName string) (string, string, error) {
+	args := m.Called(ctx, providerName)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockAuthService) CompleteLogin(ctx context.Context, protocol, state string, params service.CallbackParams, previousSessionID string) (*service.Session, error) {
+	args := m.Called(ctx, protocol, state, params, previousSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Session), args.Error(1)
+}
+
+func (m *MockAuthService) ProviderMetadata(providerName string) ([]byte, error) {
+	args := m.Called(providerName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 // MockReservationService for handler tests
 type MockReservationService struct {
 	mock.Mock
@@ -67,6 +94,14 @@ func (m *MockReservationService) CancelReservation(ctx context.Context, id uuid.
 	return args.Error(0)
 }
 
+func (m *MockReservationService) CheckConflicts(ctx context.Context, req *service.CreateReservationRequest) (*domain.SeriesConflictReport, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SeriesConflictReport), args.Error(1)
+}
+
 // MockApprovalService for handler tests
 type MockApprovalService struct {
 	mock.Mock
@@ -81,3 +116,494 @@ func (m *MockApprovalService) RejectReservation(ctx context.Context, reservation
 	args := m.Called(ctx, reservationID, startAt, approverID, reason)
 	return args.Error(0)
 }
+
+func (m *MockApprovalService) GetPendingApprovals(ctx context.Context, approverID uuid.UUID, query service.PendingApprovalsQuery) ([]*domain.Reservation, int, error) {
+	args := m.Called(ctx, approverID, query)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Reservation), args.Int(1), args.Error(2)
+}
+
+func (m *MockApprovalService) DecideBulk(ctx context.Context, approverID uuid.UUID, items []service.BulkDecisionItem) ([]service.BulkDecisionResult, error) {
+	args := m.Called(ctx, approverID, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.BulkDecisionResult), args.Error(1)
+}
+
+// MockWaitlistService for handler tests
+type MockWaitlistService struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistService) Join(ctx context.Context, req *service.JoinWaitlistRequest) (*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistService) ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistService) Leave(ctx context.Context, entryID, userID uuid.UUID) error {
+	args := m.Called(ctx, entryID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistService) Accept(ctx context.Context, entryID, userID uuid.UUID) (*domain.Reservation, error) {
+	args := m.Called(ctx, entryID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reservation), args.Error(1)
+}
+
+func (m *MockWaitlistService) Decline(ctx context.Context, entryID, userID uuid.UUID) error {
+	args := m.Called(ctx, entryID, userID)
+	return args.Error(0)
+}
+
+// MockEquipmentService for handler tests
+type MockEquipmentService struct {
+	mock.Mock
+}
+
+func (m *MockEquipmentService) GetAvailability(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (*service.EquipmentAvailability, error) {
+	args := m.Called(ctx, resourceID, startAt, endAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.EquipmentAvailability), args.Error(1)
+}
+
+func (m *MockEquipmentService) RegisterAsset(ctx context.Context, actorID, resourceID uuid.UUID, assetTag, notes string) (*domain.Asset, error) {
+	args := m.Called(ctx, actorID, resourceID, assetTag, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Asset), args.Error(1)
+}
+
+func (m *MockEquipmentService) ListAssets(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error) {
+	args := m.Called(ctx, resourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Asset), args.Error(1)
+}
+
+func (m *MockEquipmentService) AssignAssets(ctx context.Context, reservationID uuid.UUID, startAt time.Time, actorID uuid.UUID, assetTags []string) ([]*domain.ReservationAsset, error) {
+	args := m.Called(ctx, reservationID, startAt, actorID, assetTags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReservationAsset), args.Error(1)
+}
+
+func (m *MockEquipmentService) Checkout(ctx context.Context, actorID uuid.UUID, assetTag string, req *service.CheckoutRequest) (*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, actorID, assetTag, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EquipmentCheckout), args.Error(1)
+}
+
+func (m *MockEquipmentService) Return(ctx context.Context, actorID uuid.UUID, assetTag string, condition domain.AssetCondition, damageNotes string) (*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, actorID, assetTag, condition, damageNotes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EquipmentCheckout), args.Error(1)
+}
+
+func (m *MockEquipmentService) ListCheckoutHistory(ctx context.Context, assetTag string) ([]*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, assetTag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EquipmentCheckout), args.Error(1)
+}
+
+// MockWorkspaceService for handler tests
+type MockWorkspaceService struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceService) BookInZone(ctx context.Context, req *service.ZoneBookingRequest) ([]*service.ZoneBookingResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*service.ZoneBookingResult), args.Error(1)
+}
+
+func (m *MockWorkspaceService) GetZoneOccupancy(ctx context.Context, resourceType domain.ResourceType, zone string, from time.Time, days int, timezone string) ([]*service.ZoneOccupancy, error) {
+	args := m.Called(ctx, resourceType, zone, from, days, timezone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*service.ZoneOccupancy), args.Error(1)
+}
+
+// MockHoldService for handler tests
+type MockHoldService struct {
+	mock.Mock
+}
+
+func (m *MockHoldService) PlaceHold(ctx context.Context, req *service.PlaceHoldRequest) (*domain.ResourceHold, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourceHold), args.Error(1)
+}
+
+func (m *MockHoldService) ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceHold), args.Error(1)
+}
+
+func (m *MockHoldService) ConvertHold(ctx context.Context, holdID uuid.UUID, req *service.CreateReservationRequest) (*domain.Reservation, error) {
+	args := m.Called(ctx, holdID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Reservation), args.Error(1)
+}
+
+func (m *MockHoldService) ReleaseHold(ctx context.Context, holdID, userID uuid.UUID) error {
+	args := m.Called(ctx, holdID, userID)
+	return args.Error(0)
+}
+
+// MockBumpService for handler tests
+type MockBumpService struct {
+	mock.Mock
+}
+
+func (m *MockBumpService) Bump(ctx context.Context, req *service.BumpRequest) (*service.BumpResult, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.BumpResult), args.Error(1)
+}
+
+// MockQuotaService for handler tests
+type MockQuotaService struct {
+	mock.Mock
+}
+
+func (m *MockQuotaService) CreateRule(ctx context.Context, actorID uuid.UUID, rule *domain.QuotaRule) error {
+	args := m.Called(ctx, actorID, rule)
+	return args.Error(0)
+}
+
+func (m *MockQuotaService) ListRules(ctx context.Context) ([]*domain.QuotaRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRule), args.Error(1)
+}
+
+func (m *MockQuotaService) DeleteRule(ctx context.Context, actorID, ruleID uuid.UUID) error {
+	args := m.Called(ctx, actorID, ruleID)
+	return args.Error(0)
+}
+
+func (m *MockQuotaService) Usage(ctx context.Context, userID uuid.UUID, loc *time.Location, now time.Time) ([]*domain.QuotaUsage, error) {
+	args := m.Called(ctx, userID, loc, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaUsage), args.Error(1)
+}
+
+// MockPenaltyService for handler tests
+type MockPenaltyService struct {
+	mock.Mock
+}
+
+func (m *MockPenaltyService) History(ctx context.Context, userID uuid.UUID) (*service.PenaltyHistory, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PenaltyHistory), args.Error(1)
+}
+
+func (m *MockPenaltyService) AdjustScore(ctx context.Context, actorID, userID uuid.UUID, points int, reason string) (*domain.PenaltyEvent, error) {
+	args := m.Called(ctx, actorID, userID, points, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PenaltyEvent), args.Error(1)
+}
+
+func (m *MockPenaltyService) CreateThreshold(ctx context.Context, actorID uuid.UUID, threshold *domain.PenaltyThreshold) error {
+	args := m.Called(ctx, actorID, threshold)
+	return args.Error(0)
+}
+
+func (m *MockPenaltyService) ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PenaltyThreshold), args.Error(1)
+}
+
+func (m *MockPenaltyService) DeleteThreshold(ctx context.Context, actorID, thresholdID uuid.UUID) error {
+	args := m.Called(ctx, actorID, thresholdID)
+	return args.Error(0)
+}
+
+type MockIdentityService struct {
+	mock.Mock
+}
+
+func (m *MockIdentityService) ListIdentityConflicts(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error) {
+	args := m.Called(ctx, includeResolved)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.IdentityConflict), args.Error(1)
+}
+
+func (m *MockIdentityService) ResolveIdentityConflict(ctx context.Context, actorID, conflictID uuid.UUID, resolution domain.IdentityResolution, userID *uuid.UUID, note string) (*domain.IdentityConflict, error) {
+	args := m.Called(ctx, actorID, conflictID, resolution, userID, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdentityConflict), args.Error(1)
+}
+
+type MockProvisioningService struct {
+	mock.Mock
+}
+
+func (m *MockProvisioningService) ListUsers(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProvisioningService) GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockProvisioningService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockProvisioningService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockProvisioningService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProvisioningService) ListUserGroups(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisioningService) ListGroups(ctx context.Context, filter repository.ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.ProvisionedGroup), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProvisioningService) GetGroup(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisioningService) CreateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisioningService) UpdateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisioningService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockAPITokenService struct {
+	mock.Mock
+}
+
+func (m *MockAPITokenService) Authenticate(ctx context.Context, rawToken, ip string) (*service.Session, error) {
+	args := m.Called(ctx, rawToken, ip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Session), args.Error(1)
+}
+
+func (m *MockAPITokenService) GetToken(ctx context.Context, tokenID uuid.UUID) (*domain.APIToken, error) {
+	args := m.Called(ctx, tokenID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenService) ListUserTokens(ctx context.Context, userID uuid.UUID) ([]*domain.APIToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenService) ListServiceAccountTokens(ctx context.Context, serviceAccountID uuid.UUID) ([]*domain.APIToken, error) {
+	args := m.Called(ctx, serviceAccountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenService) CreateUserToken(ctx context.Context, actorID, userID uuid.UUID, req service.CreateAPITokenRequest) (*domain.APIToken, string, error) {
+	args := m.Called(ctx, actorID, userID, req)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *MockAPITokenService) CreateServiceAccountToken(ctx context.Context, actorID, serviceAccountID uuid.UUID, req service.CreateAPITokenRequest) (*domain.APIToken, string, error) {
+	args := m.Called(ctx, actorID, serviceAccountID, req)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*domain.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *MockAPITokenService) RevokeToken(ctx context.Context, actorID, tokenID uuid.UUID) error {
+	args := m.Called(ctx, actorID, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenService) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockAPITokenService) CreateServiceAccount(ctx context.Context, actorID uuid.UUID, name, description string, role domain.Role) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, actorID, name, description, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockAPITokenService) DisableServiceAccount(ctx context.Context, actorID, serviceAccountID uuid.UUID) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, actorID, serviceAccountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*service.SessionInfo, error) {
+	args := m.Called(ctx, userID, currentSessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*service.SessionInfo), args.Error(1)
+}
+
+func (m *MockSessionService) RevokeSession(ctx context.Context, actorID, userID uuid.UUID, sessionInfoID string) error {
+	args := m.Called(ctx, actorID, userID, sessionInfoID)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) RevokeUserSessions(ctx context.Context, actorID, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, actorID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockAuditLogRepository for handler tests
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, log *domain.AuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.AuditLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) GetByEntityID(ctx context.Context, entityID uuid.UUID, limit int) ([]*domain.AuditLog, error) {
+	args := m.Called(ctx, entityID, limit)
+	return args.Get(0).([]*domain.AuditLog), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) List(ctx context.Context, filter domain.AuditLogFilter, offset, limit int) ([]*domain.AuditLog, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	return args.Get(0).([]*domain.AuditLog), args.Get(1).(int64), args.Error(2)
+}
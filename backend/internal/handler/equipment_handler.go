@@ -0,0 +1,330 @@
+// backend/internal/handler/equipment_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// EquipmentServiceInterface は備品管理サービスのインターフェース
+type EquipmentServiceInterface interface {
+	GetAvailability(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (*service.EquipmentAvailability, error)
+	RegisterAsset(ctx context.Context, actorID, resourceID uuid.UUID, assetTag, notes string) (*domain.Asset, error)
+	ListAssets(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error)
+	AssignAssets(ctx context.Context, reservationID uuid.UUID, startAt time.Time, actorID uuid.UUID, assetTags []string) ([]*domain.ReservationAsset, error)
+	Checkout(ctx context.Context, actorID uuid.UUID, assetTag string, req *service.CheckoutRequest) (*domain.EquipmentCheckout, error)
+	Return(ctx context.Context, actorID uuid.UUID, assetTag string, condition domain.AssetCondition, damageNotes string) (*domain.EquipmentCheckout, error)
+	ListCheckoutHistory(ctx context.Context, assetTag string) ([]*domain.EquipmentCheckout, error)
+}
+
+// EquipmentHandler はプール型備品の数量・個体管理関連のHTTPハンドラー
+type EquipmentHandler struct {
+	equipmentService EquipmentServiceInterface
+}
+
+// NewEquipmentHandler は新しいEquipmentHandlerを作成します
+func NewEquipmentHandler(equipmentService EquipmentServiceInterface) *EquipmentHandler {
+	return &EquipmentHandler{
+		equipmentService: equipmentService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *EquipmentHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/resources/{id}/availability", h.GetAvailability).Methods("GET")
+	r.HandleFunc("/api/v1/resources/{id}/assets", h.ListAssets).Methods("GET")
+	r.HandleFunc("/api/v1/resources/{id}/assets", h.RegisterAsset).Methods("POST")
+	r.HandleFunc("/api/v1/events/{id}/assets", h.AssignAssets).Methods("POST")
+	r.HandleFunc("/api/v1/assets/{tag}/checkout", h.Checkout).Methods("POST")
+	r.HandleFunc("/api/v1/assets/{tag}/return", h.Return).Methods("POST")
+	r.HandleFunc("/api/v1/assets/{tag}/checkouts", h.ListCheckoutHistory).Methods("GET")
+}
+
+// AvailabilityResponse は空き数量のレスポンス
+type AvailabilityResponse struct {
+	ResourceID     uuid.UUID `json:"resource_id"`
+	Quantity       int       `json:"quantity"`
+	AvailableUnits int       `json:"available_units"`
+	StartAt        time.Time `json:"start_at"`
+	EndAt          time.Time `json:"end_at"`
+}
+
+// GetAvailability は指定期間におけるリソースの空き数量を取得します
+// クエリパラメータ: start_at, end_at (RFC3339)
+func (h *EquipmentHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid resource ID")
+		return
+	}
+
+	params := r.URL.Query()
+	startAt, err := time.Parse(time.RFC3339, params.Get("start_at"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_START_AT", "Invalid start_at parameter")
+		return
+	}
+	endAt, err := time.Parse(time.RFC3339, params.Get("end_at"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_END_AT", "Invalid end_at parameter")
+		return
+	}
+	if !endAt.After(startAt) {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "end_at must be after start_at")
+		return
+	}
+
+	availability, err := h.equipmentService.GetAvailability(r.Context(), id, startAt, endAt)
+	if err != nil {
+		writeEquipmentError(w, err, "AVAILABILITY_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, AvailabilityResponse{
+		ResourceID:     availability.ResourceID,
+		Quantity:       availability.Quantity,
+		AvailableUnits: availability.AvailableUnits,
+		StartAt:        availability.StartAt,
+		EndAt:          availability.EndAt,
+	})
+}
+
+// ListAssets は備品に登録された個体の一覧を取得します
+func (h *EquipmentHandler) ListAssets(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid resource ID")
+		return
+	}
+
+	assets, err := h.equipmentService.ListAssets(r.Context(), id)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "LIST_FAILED", err.Error())
+		return
+	}
+	if assets == nil {
+		assets = []*domain.Asset{}
+	}
+
+	WriteJSON(w, http.StatusOK, assets)
+}
+
+// RegisterAssetRequest は備品個体の登録リクエスト
+type RegisterAssetRequest struct {
+	AssetTag string `json:"asset_tag"`
+	Notes    string `json:"notes"`
+}
+
+// RegisterAsset は備品に個体（資産タグ）を登録します
+func (h *EquipmentHandler) RegisterAsset(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid resource ID")
+		return
+	}
+
+	var req RegisterAssetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if req.AssetTag == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_ASSET_TAG", "Asset tag is required")
+		return
+	}
+
+	asset, err := h.equipmentService.RegisterAsset(r.Context(), session.UserID, id, req.AssetTag, req.Notes)
+	if err != nil {
+		writeEquipmentError(w, err, "CREATE_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, asset)
+}
+
+// AssignAssetsRequest は予約への個体割り当てリクエスト
+type AssignAssetsRequest struct {
+	AssetTags []string `json:"asset_tags"`
+}
+
+// AssignAssets は予約に備品の個体を資産タグで割り当てます
+// クエリパラメータ: start_at (RFC3339)
+func (h *EquipmentHandler) AssignAssets(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid reservation ID")
+		return
+	}
+
+	startAt, err := time.Parse(time.RFC3339, r.URL.Query().Get("start_at"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_START_AT", "Invalid start_at parameter")
+		return
+	}
+
+	var req AssignAssetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if len(req.AssetTags) == 0 {
+		WriteError(w, http.StatusBadRequest, "INVALID_ASSET_TAG", "At least one asset tag is required")
+		return
+	}
+
+	assignments, err := h.equipmentService.AssignAssets(r.Context(), id, startAt, session.UserID, req.AssetTags)
+	if err != nil {
+		writeEquipmentError(w, err, "ASSIGN_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, assignments)
+}
+
+// CheckoutRequest は備品の貸出リクエスト
+type CheckoutRequest struct {
+	ReservationID string `json:"reservation_id"`
+	StartAt       string `json:"start_at"` // 予約の開始日時 (RFC3339)
+	Condition     string `json:"condition"`
+	Notes         string `json:"notes"`
+}
+
+// Checkout は予約に割り当てられた備品の個体の持ち出しを記録します
+func (h *EquipmentHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req CheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	reservationID, err := uuid.Parse(req.ReservationID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid reservation ID")
+		return
+	}
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_START_AT", "Invalid start_at")
+		return
+	}
+	condition := domain.AssetCondition(req.Condition)
+	if condition == "" {
+		condition = domain.AssetConditionGood
+	}
+
+	checkout, err := h.equipmentService.Checkout(r.Context(), session.UserID, mux.Vars(r)["tag"], &service.CheckoutRequest{
+		ReservationID: reservationID,
+		StartAt:       startAt,
+		Condition:     condition,
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		writeEquipmentError(w, err, "CHECKOUT_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, checkout)
+}
+
+// ReturnRequest は備品の返却リクエスト
+type ReturnRequest struct {
+	Condition   string `json:"condition"`
+	DamageNotes string `json:"damage_notes"`
+}
+
+// Return は備品の個体の返却を記録します
+func (h *EquipmentHandler) Return(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if req.Condition == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_CONDITION", "Condition is required")
+		return
+	}
+
+	checkout, err := h.equipmentService.Return(r.Context(), session.UserID, mux.Vars(r)["tag"], domain.AssetCondition(req.Condition), req.DamageNotes)
+	if err != nil {
+		writeEquipmentError(w, err, "RETURN_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, checkout)
+}
+
+// ListCheckoutHistory は個体の貸出・返却履歴を新しい順に取得します
+func (h *EquipmentHandler) ListCheckoutHistory(w http.ResponseWriter, r *http.Request) {
+	checkouts, err := h.equipmentService.ListCheckoutHistory(r.Context(), mux.Vars(r)["tag"])
+	if err != nil {
+		writeEquipmentError(w, err, "LIST_FAILED")
+		return
+	}
+	if checkouts == nil {
+		checkouts = []*domain.EquipmentCheckout{}
+	}
+
+	WriteJSON(w, http.StatusOK, checkouts)
+}
+
+// writeEquipmentError は備品管理サービスのエラーをHTTPレスポンスに変換します
+func writeEquipmentError(w http.ResponseWriter, err error, fallbackCode string) {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, service.ErrNotEquipment), errors.Is(err, service.ErrAssetNotInPool),
+		errors.Is(err, service.ErrDuplicateAssetTag), errors.Is(err, service.ErrAssetTagsRequired):
+		WriteError(w, http.StatusBadRequest, "INVALID_ASSET", err.Error())
+	case errors.Is(err, service.ErrAssetUnavailable):
+		WriteError(w, http.StatusConflict, "ASSET_UNAVAILABLE", err.Error())
+	case errors.Is(err, service.ErrTooManyAssets):
+		WriteError(w, http.StatusConflict, "TOO_MANY_ASSETS", err.Error())
+	case errors.Is(err, service.ErrInvalidTimeRange):
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", err.Error())
+	case errors.Is(err, service.ErrAssetCheckedOut):
+		WriteError(w, http.StatusConflict, "ASSET_CHECKED_OUT", err.Error())
+	case errors.Is(err, service.ErrAssetNotCheckedOut), errors.Is(err, domain.ErrCheckoutAlreadyClosed):
+		WriteError(w, http.StatusConflict, "ASSET_NOT_CHECKED_OUT", err.Error())
+	case errors.Is(err, service.ErrAssetNotAssigned), errors.Is(err, service.ErrReservationNotReady):
+		WriteError(w, http.StatusConflict, "CHECKOUT_NOT_ALLOWED", err.Error())
+	case errors.Is(err, domain.ErrInvalidAssetCondition):
+		WriteError(w, http.StatusBadRequest, "INVALID_CONDITION", err.Error())
+	default:
+		WriteError(w, http.StatusBadRequest, fallbackCode, err.Error())
+	}
+}
@@ -0,0 +1,171 @@
+// backend/internal/handler/equipment_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestEquipmentHandler_GetAvailability(t *testing.T) {
+	mockEquipment := new(MockEquipmentService)
+	h := handler.NewEquipmentHandler(mockEquipment)
+
+	resourceID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(2 * time.Hour)
+
+	tests := []struct {
+		name          string
+		query         string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success",
+			query: "?start_at=" + startAt.Format(time.RFC3339) + "&end_at=" + endAt.Format(time.RFC3339),
+			setupMock: func() {
+				mockEquipment.On("GetAvailability", mock.Anything, resourceID, startAt, endAt).Return(&service.EquipmentAvailability{
+					ResourceID:     resourceID,
+					Quantity:       5,
+					AvailableUnits: 2,
+					StartAt:        startAt,
+					EndAt:          endAt,
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Missing end_at",
+			query:         "?start_at=" + startAt.Format(time.RFC3339),
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_END_AT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEquipment.ExpectedCalls = nil
+			mockEquipment.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", "/api/v1/resources/"+resourceID.String()+"/availability"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": resourceID.String()})
+
+			w := httptest.NewRecorder()
+			h.GetAvailability(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			} else {
+				assert.Contains(t, w.Body.String(), `"available_units":2`)
+			}
+			mockEquipment.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEquipmentHandler_AssignAssets(t *testing.T) {
+	mockEquipment := new(MockEquipmentService)
+	h := handler.NewEquipmentHandler(mockEquipment)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	reservationID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	mockEquipment.On("AssignAssets", mock.Anything, reservationID, startAt, userID, []string{"LT-001", "LT-002"}).
+		Return(nil, service.ErrTooManyAssets)
+
+	bodyBytes, _ := json.Marshal(map[string]interface{}{"asset_tags": []string{"LT-001", "LT-002"}})
+	req := httptest.NewRequest("POST", "/api/v1/events/"+reservationID.String()+"/assets?start_at="+startAt.Format(time.RFC3339), bytes.NewReader(bodyBytes))
+	req = mux.SetURLVars(req, map[string]string{"id": reservationID.String()})
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	h.AssignAssets(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "TOO_MANY_ASSETS")
+	mockEquipment.AssertExpectations(t)
+}
+
+func TestEquipmentHandler_Return(t *testing.T) {
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+
+	tests := []struct {
+		name          string
+		body          map[string]interface{}
+		setupMock     func(m *MockEquipmentService)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{"condition": "DAMAGED", "damage_notes": "Cracked lens"},
+			setupMock: func(m *MockEquipmentService) {
+				returnedAt := time.Now()
+				m.On("Return", mock.Anything, userID, "PJ-001", domain.AssetConditionDamaged, "Cracked lens").
+					Return(&domain.EquipmentCheckout{ID: uuid.New(), AssetTag: "PJ-001", ReturnedAt: &returnedAt}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Missing condition",
+			body:          map[string]interface{}{},
+			setupMock:     func(m *MockEquipmentService) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_CONDITION",
+		},
+		{
+			name: "Not checked out",
+			body: map[string]interface{}{"condition": "GOOD"},
+			setupMock: func(m *MockEquipmentService) {
+				m.On("Return", mock.Anything, userID, "PJ-001", domain.AssetConditionGood, "").
+					Return(nil, service.ErrAssetNotCheckedOut)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "ASSET_NOT_CHECKED_OUT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEquipment := new(MockEquipmentService)
+			h := handler.NewEquipmentHandler(mockEquipment)
+			tt.setupMock(mockEquipment)
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/v1/assets/PJ-001/return", bytes.NewReader(bodyBytes))
+			req = mux.SetURLVars(req, map[string]string{"tag": "PJ-001"})
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, session))
+
+			w := httptest.NewRecorder()
+			h.Return(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockEquipment.AssertExpectations(t)
+		})
+	}
+}
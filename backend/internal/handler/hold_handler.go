@@ -0,0 +1,222 @@
+// backend/internal/handler/hold_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// HoldServiceInterface は仮押さえサービスのインターフェース
+type HoldServiceInterface interface {
+	PlaceHold(ctx context.Context, req *service.PlaceHoldRequest) (*domain.ResourceHold, error)
+	ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error)
+	ConvertHold(ctx context.Context, holdID uuid.UUID, req *service.CreateReservationRequest) (*domain.Reservation, error)
+	ReleaseHold(ctx context.Context, holdID, userID uuid.UUID) error
+}
+
+// HoldHandler は予約フォーム入力中の仮押さえ関連のHTTPハンドラー
+type HoldHandler struct {
+	holdService HoldServiceInterface
+}
+
+// NewHoldHandler は新しいHoldHandlerを作成します
+func NewHoldHandler(holdService HoldServiceInterface) *HoldHandler {
+	return &HoldHandler{
+		holdService: holdService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *HoldHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/holds", h.PlaceHold).Methods("POST")
+	r.HandleFunc("/api/v1/holds", h.ListHolds).Methods("GET")
+	r.HandleFunc("/api/v1/holds/{id}", h.ReleaseHold).Methods("DELETE")
+	r.HandleFunc("/api/v1/holds/{id}/convert", h.ConvertHold).Methods("POST")
+}
+
+// PlaceHoldRequest は仮押さえリクエスト
+type PlaceHoldRequest struct {
+	ResourceIDs []string       `json:"resource_ids"`
+	Units       map[string]int `json:"units"`
+	StartAt     time.Time      `json:"start_at"`
+	EndAt       time.Time      `json:"end_at"`
+	Timezone    string         `json:"timezone"`
+}
+
+// PlaceHold はリソースと時間帯を短時間だけ仮押さえします
+func (h *HoldHandler) PlaceHold(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req PlaceHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if req.Timezone == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone is required")
+		return
+	}
+	if req.StartAt.IsZero() || req.EndAt.IsZero() {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "StartAt and EndAt are required")
+		return
+	}
+	if !req.EndAt.After(req.StartAt) {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "EndAt must be after StartAt")
+		return
+	}
+
+	resourceIDs := make([]uuid.UUID, len(req.ResourceIDs))
+	for i, id := range req.ResourceIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID")
+			return
+		}
+		resourceIDs[i] = parsed
+	}
+
+	var units map[uuid.UUID]int
+	if len(req.Units) > 0 {
+		units = make(map[uuid.UUID]int, len(req.Units))
+		for id, n := range req.Units {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID in units")
+				return
+			}
+			if n < 1 {
+				WriteError(w, http.StatusBadRequest, "INVALID_UNITS", "Units must be at least 1")
+				return
+			}
+			units[parsed] = n
+		}
+	}
+
+	hold, err := h.holdService.PlaceHold(r.Context(), &service.PlaceHoldRequest{
+		UserID:      session.UserID,
+		ResourceIDs: resourceIDs,
+		Units:       units,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		Timezone:    req.Timezone,
+	})
+	if err != nil {
+		writeHoldError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, hold)
+}
+
+// ListHolds はログインユーザーの有効な仮押さえ一覧を取得します
+func (h *HoldHandler) ListHolds(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	holds, err := h.holdService.ListMine(r.Context(), session.UserID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "LIST_FAILED", err.Error())
+		return
+	}
+	if holds == nil {
+		holds = []*domain.ResourceHold{}
+	}
+
+	WriteJSON(w, http.StatusOK, holds)
+}
+
+// ConvertHold は仮押さえを予約に変換します
+// リクエストボディは予約作成 (POST /api/v1/events) と同じで、resource_ids を省略すると仮押さえしたリソースを予約します
+func (h *HoldHandler) ConvertHold(w http.ResponseWriter, r *http.Request) {
+	session, id, ok := holdRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	req, ok := parseCreateReservationRequest(w, r, session.UserID)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.holdService.ConvertHold(r.Context(), id, req)
+	if err != nil {
+		writeHoldError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, reservation)
+}
+
+// ReleaseHold は仮押さえを解放します
+func (h *HoldHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	session, id, ok := holdRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.holdService.ReleaseHold(r.Context(), id, session.UserID); err != nil {
+		writeHoldError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Hold released successfully",
+	})
+}
+
+// holdRequestContext はセッションとパスの仮押さえIDを取得します
+// 取得できない場合はエラーレスポンスを書き込み false を返します
+func holdRequestContext(w http.ResponseWriter, r *http.Request) (*service.Session, uuid.UUID, bool) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid hold ID")
+		return nil, uuid.Nil, false
+	}
+
+	return session, id, true
+}
+
+// writeHoldError は仮押さえサービスのエラーをHTTPレスポンスに変換します
+// 予約への変換時のエラーは予約作成と同じレスポンスにします
+func writeHoldError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrHoldWithoutResources):
+		WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", err.Error())
+	case errors.Is(err, service.ErrTooManyHolds):
+		WriteError(w, http.StatusTooManyRequests, "TOO_MANY_HOLDS", err.Error())
+	case errors.Is(err, service.ErrHoldInactive):
+		WriteError(w, http.StatusConflict, "HOLD_INACTIVE", err.Error())
+	case errors.Is(err, service.ErrHoldExpired):
+		WriteError(w, http.StatusGone, "HOLD_EXPIRED", err.Error())
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Hold not found")
+	default:
+		writeCreateReservationError(w, err)
+	}
+}
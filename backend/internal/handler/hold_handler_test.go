@@ -0,0 +1,223 @@
+// backend/internal/handler/hold_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestHoldHandler_PlaceHold(t *testing.T) {
+	mockHold := new(MockHoldService)
+	h := handler.NewHoldHandler(mockHold)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	resourceID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		body          map[string]interface{}
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"start_at":     startAt,
+				"end_at":       startAt.Add(time.Hour),
+				"timezone":     "Asia/Tokyo",
+			},
+			setupMock: func() {
+				mockHold.On("PlaceHold", mock.Anything, mock.MatchedBy(func(req *service.PlaceHoldRequest) bool {
+					return req.UserID == userID && len(req.ResourceIDs) == 1 && req.ResourceIDs[0] == resourceID
+				})).Return(&domain.ResourceHold{ID: uuid.New(), Status: domain.HoldStatusActive}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Resource already held",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"start_at":     startAt,
+				"end_at":       startAt.Add(time.Hour),
+				"timezone":     "Asia/Tokyo",
+			},
+			setupMock: func() {
+				mockHold.On("PlaceHold", mock.Anything, mock.Anything).Return(nil, service.ErrResourceNotAvailable)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "RESOURCE_CONFLICT",
+		},
+		{
+			name: "Too many holds",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"start_at":     startAt,
+				"end_at":       startAt.Add(time.Hour),
+				"timezone":     "Asia/Tokyo",
+			},
+			setupMock: func() {
+				mockHold.On("PlaceHold", mock.Anything, mock.Anything).Return(nil, service.ErrTooManyHolds)
+			},
+			expectedCode:  http.StatusTooManyRequests,
+			expectedError: "TOO_MANY_HOLDS",
+		},
+		{
+			name: "Invalid time range",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"start_at":     startAt,
+				"end_at":       startAt,
+				"timezone":     "Asia/Tokyo",
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_TIME_RANGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHold.ExpectedCalls = nil
+			mockHold.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/v1/holds", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.PlaceHold(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockHold.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHoldHandler_ConvertHold(t *testing.T) {
+	mockHold := new(MockHoldService)
+	h := handler.NewHoldHandler(mockHold)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	holdID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+	body := map[string]interface{}{
+		"title":    "Design review",
+		"start_at": startAt,
+		"end_at":   startAt.Add(time.Hour),
+		"timezone": "Asia/Tokyo",
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockHold.On("ConvertHold", mock.Anything, holdID, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return req.OrganizerID == userID && req.Title == "Design review"
+				})).Return(&domain.Reservation{ID: uuid.New(), ApprovalStatus: domain.ApprovalStatusConfirmed}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Hold expired",
+			setupMock: func() {
+				mockHold.On("ConvertHold", mock.Anything, holdID, mock.Anything).Return(nil, service.ErrHoldExpired)
+			},
+			expectedCode:  http.StatusGone,
+			expectedError: "HOLD_EXPIRED",
+		},
+		{
+			name: "Not owner",
+			setupMock: func() {
+				mockHold.On("ConvertHold", mock.Anything, holdID, mock.Anything).Return(nil, service.ErrUnauthorized)
+			},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "FORBIDDEN",
+		},
+		{
+			name: "Capacity exceeded",
+			setupMock: func() {
+				mockHold.On("ConvertHold", mock.Anything, holdID, mock.Anything).Return(nil, domain.ErrCapacityExceeded)
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "CAPACITY_EXCEEDED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHold.ExpectedCalls = nil
+			mockHold.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(body)
+			req := httptest.NewRequest("POST", "/api/v1/holds/"+holdID.String()+"/convert", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": holdID.String()})
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.ConvertHold(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockHold.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHoldHandler_ReleaseHold(t *testing.T) {
+	mockHold := new(MockHoldService)
+	h := handler.NewHoldHandler(mockHold)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	holdID := uuid.New()
+
+	mockHold.On("ReleaseHold", mock.Anything, holdID, userID).Return(service.ErrHoldInactive)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/holds/"+holdID.String(), nil)
+	req = mux.SetURLVars(req, map[string]string{"id": holdID.String()})
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+	req = req.WithContext(ctx)
+
+	w := httptest.NewRecorder()
+	h.ReleaseHold(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "HOLD_INACTIVE")
+	mockHold.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+// backend/internal/handler/identity_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// IdentityServiceInterface はIdPのユーザーとの突合レポートを扱うサービスのインターフェース
+type IdentityServiceInterface interface {
+	ListIdentityConflicts(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error)
+	ResolveIdentityConflict(ctx context.Context, actorID, conflictID uuid.UUID, resolution domain.IdentityResolution, userID *uuid.UUID, note string) (*domain.IdentityConflict, error)
+}
+
+// IdentityHandler はIdPのユーザーとの突合レポート関連のHTTPハンドラー（管理者のみ）
+type IdentityHandler struct {
+	identityService IdentityServiceInterface
+}
+
+// NewIdentityHandler は新しいIdentityHandlerを作成します
+func NewIdentityHandler(identityService IdentityServiceInterface) *IdentityHandler {
+	return &IdentityHandler{
+		identityService: identityService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *IdentityHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/identity-conflicts", h.ListConflicts).Methods("GET")
+	r.HandleFunc("/api/v1/identity-conflicts/{id}/resolve", h.ResolveConflict).Methods("POST")
+}
+
+// ResolveIdentityConflictRequest は突合レポートの解決リクエスト
+type ResolveIdentityConflictRequest struct {
+	Resolution domain.IdentityResolution `json:"resolution"`        // LINK, CREATE, DISMISS
+	UserID     *uuid.UUID                `json:"user_id,omitempty"` // LINK の場合の紐付け先
+	Note       string                    `json:"note"`
+}
+
+// ListConflicts は突合レポートを取得します（?include_resolved=true で解決済みも含む）
+func (h *IdentityHandler) ListConflicts(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	includeResolved := r.URL.Query().Get("include_resolved") == "true"
+	conflicts, err := h.identityService.ListIdentityConflicts(r.Context(), includeResolved)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, conflicts)
+}
+
+// ResolveConflict は突合レポートを解決します
+func (h *IdentityHandler) ResolveConflict(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	conflictID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid identity conflict ID")
+		return
+	}
+
+	var req ResolveIdentityConflictRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	conflict, err := h.identityService.ResolveIdentityConflict(r.Context(), session.UserID, conflictID, req.Resolution, req.UserID, req.Note)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidIdentityResolution) {
+			WriteError(w, http.StatusUnprocessableEntity, "INVALID_IDENTITY_RESOLUTION", err.Error())
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "Identity conflict or user not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, conflict)
+}
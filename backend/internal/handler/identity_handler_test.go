@@ -0,0 +1,144 @@
+// backend/internal/handler/identity_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestIdentityHandler_ListConflicts(t *testing.T) {
+	mockIdentity := new(MockIdentityService)
+	h := handler.NewIdentityHandler(mockIdentity)
+
+	tests := []struct {
+		name          string
+		role          domain.Role
+		query         string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Open conflicts",
+			role:  domain.RoleAdmin,
+			query: "",
+			setupMock: func() {
+				mockIdentity.On("ListIdentityConflicts", mock.Anything, false).Return([]*domain.IdentityConflict{
+					{ID: uuid.New(), Kind: domain.IdentityConflictMultipleEmail, Email: "user@example.com", DetectedAt: time.Now()},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "Including resolved",
+			role:  domain.RoleAdmin,
+			query: "?include_resolved=true",
+			setupMock: func() {
+				mockIdentity.On("ListIdentityConflicts", mock.Anything, true).Return([]*domain.IdentityConflict{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Forbidden for non-admin",
+			role:          domain.RoleAuditor,
+			setupMock:     func() {},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdentity.ExpectedCalls = nil
+			mockIdentity.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", "/api/v1/identity-conflicts"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: uuid.New(), Role: tt.role})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.ListConflicts(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockIdentity.AssertExpectations(t)
+		})
+	}
+}
+
+func TestIdentityHandler_ResolveConflict(t *testing.T) {
+	mockIdentity := new(MockIdentityService)
+	h := handler.NewIdentityHandler(mockIdentity)
+
+	adminID := uuid.New()
+	conflictID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				resolution := domain.IdentityResolutionLink
+				mockIdentity.On("ResolveIdentityConflict", mock.Anything, adminID, conflictID, domain.IdentityResolutionLink, &userID, "same person").
+					Return(&domain.IdentityConflict{ID: conflictID, Resolution: &resolution}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Invalid resolution",
+			setupMock: func() {
+				mockIdentity.On("ResolveIdentityConflict", mock.Anything, adminID, conflictID, domain.IdentityResolutionLink, &userID, "same person").
+					Return(nil, domain.ErrInvalidIdentityResolution)
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "INVALID_IDENTITY_RESOLUTION",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdentity.ExpectedCalls = nil
+			mockIdentity.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"resolution": "LINK", "user_id": userID, "note": "same person"})
+			req := httptest.NewRequest("POST", "/api/v1/identity-conflicts/"+conflictID.String()+"/resolve", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": conflictID.String()})
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: adminID, Role: domain.RoleAdmin})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.ResolveConflict(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockIdentity.AssertExpectations(t)
+		})
+	}
+}
@@ -3,12 +3,15 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/your-org/esms/internal/domain"
 	"github.com/your-org/esms/internal/service"
 	"golang.org/x/time/rate"
 )
@@ -21,18 +24,36 @@ const (
 	ContextKeySession ContextKey = "session"
 	// ContextKeyRequestID はリクエストIDのコンテキストキー
 	ContextKeyRequestID ContextKey = "request_id"
+	// ContextKeyAuthMethod はセッションIDの受け渡し方法（AuthMethodBearer / AuthMethodCookie）のコンテキストキー
+	ContextKeyAuthMethod ContextKey = "auth_method"
 )
 
+// AuthMethod はセッションIDの受け渡し方法を表す型
+type AuthMethod string
+
+const (
+	AuthMethodBearer AuthMethod = "bearer" // Authorization ヘッダー（APIクライアント）
+	AuthMethodCookie AuthMethod = "cookie" // セッションCookie（ブラウザ）
+)
+
+// APITokenAuthenticator はAPIトークン（個人用アクセストークン・サービスアカウントのAPIキー）を検証するインターフェース
+type APITokenAuthenticator interface {
+	Authenticate(ctx context.Context, rawToken, ip string) (*service.Session, error)
+}
+
 // Middleware はミドルウェアの集合
 type Middleware struct {
 	authService AuthServiceInterface
+	apiTokens   APITokenAuthenticator
 	rateLimiter *RateLimiter
 }
 
 // NewMiddleware は新しいMiddlewareを作成します
-func NewMiddleware(authService AuthServiceInterface) *Middleware {
+// apiTokens が nil の場合、APIトークンによる認証は行いません
+func NewMiddleware(authService AuthServiceInterface, apiTokens APITokenAuthenticator) *Middleware {
 	return &Middleware{
 		authService: authService,
+		apiTokens:   apiTokens,
 		rateLimiter: NewRateLimiter(100, 10), // 100 req/sec, burst 10
 	}
 }
@@ -103,37 +124,74 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 }
 
 // Authentication は認証を行うミドルウェア
+// Bearer トークンがAPIトークン（service.APITokenPrefix で始まる）の場合は、トークンの所有者を表すセッションに変換し、
+// トークンのスコープで許可されていない操作は 403 とします
 func (m *Middleware) Authentication(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Authorizationヘッダーからトークンを取得
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		// Bearer トークンまたはセッションCookieからセッションIDを取得
+		sessionID, method, err := sessionIDFromRequest(r)
+		if err != nil {
+			http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
 			return
 		}
-
-		// Bearer トークンを抽出
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
+		if sessionID == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		token := parts[1]
-
-		// セッションIDからセッションを取得
-		session, err := m.authService.GetSession(token)
+		// セッションIDまたはAPIトークンからセッションを取得
+		var session *service.Session
+		if method == AuthMethodBearer && m.apiTokens != nil && service.IsAPIToken(sessionID) {
+			session, err = m.apiTokens.Authenticate(r.Context(), sessionID, getIP(r))
+		} else {
+			// 端末の情報を渡してセッションの最終アクセス日時を更新する
+			session, err = m.authService.GetSession(withClientInfo(r), sessionID)
+		}
 		if err != nil {
 			http.Error(w, "Invalid session", http.StatusUnauthorized)
 			return
 		}
+		if !session.HasScope(requiredScope(r)) {
+			http.Error(w, "Insufficient token scope", http.StatusForbidden)
+			return
+		}
 
-		// セッション情報をコンテキストに追加
+		// セッション情報と受け渡し方法をコンテキストに追加
 		ctx := context.WithValue(r.Context(), ContextKeySession, session)
+		ctx = context.WithValue(ctx, ContextKeyAuthMethod, method)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// requiredScope はリクエストに必要なAPIトークンのスコープを返します（参照系は read、それ以外は write）
+func requiredScope(r *http.Request) domain.APITokenScope {
+	if r.Method == "GET" || r.Method == "HEAD" || r.Method == "OPTIONS" {
+		return domain.APITokenScopeRead
+	}
+	return domain.APITokenScopeWrite
+}
+
+// errInvalidAuthorizationHeader は Authorization ヘッダーが Bearer 形式でない場合のエラー
+var errInvalidAuthorizationHeader = errors.New("invalid authorization header")
+
+// sessionIDFromRequest はリクエストからセッションIDを取り出します
+// Authorization ヘッダー（APIクライアント）を優先し、なければセッションCookie（ブラウザ）を使用します
+// どちらもない場合は空文字を返します
+func sessionIDFromRequest(r *http.Request) (string, AuthMethod, error) {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" || parts[1] == "" {
+			return "", "", errInvalidAuthorizationHeader
+		}
+		return parts[1], AuthMethodBearer, nil
+	}
+
+	if cookie, err := r.Cookie(SessionCookieName); err == nil {
+		return cookie.Value, AuthMethodCookie, nil
+	}
+	return "", "", nil
+}
+
 // RequireRole は指定されたロールを要求するミドルウェア
 func (m *Middleware) RequireRole(role string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -170,7 +228,9 @@ func (m *Middleware) RateLimit(next http.Handler) http.Handler {
 	})
 }
 
-// CSRF はCSRF対策を行うミドルウェア（簡易実装）
+// CSRF はCSRF対策を行うミドルウェア（シンクロナイザートークン方式、Authentication の後に適用）
+// Cookie で認証した更新系リクエストは、X-CSRF-Token ヘッダーがセッションに紐づくトークンと一致する必要があります
+// Bearer トークンで認証したAPIクライアントはブラウザが自動送信しないため対象外です
 func (m *Middleware) CSRF(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// GET, HEAD, OPTIONS は CSRF チェック不要
@@ -179,6 +239,11 @@ func (m *Middleware) CSRF(next http.Handler) http.Handler {
 			return
 		}
 
+		if method, _ := r.Context().Value(ContextKeyAuthMethod).(AuthMethod); method == AuthMethodBearer {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		// X-CSRF-Token ヘッダーをチェック
 		csrfToken := r.Header.Get("X-CSRF-Token")
 		if csrfToken == "" {
@@ -186,8 +251,12 @@ func (m *Middleware) CSRF(next http.Handler) http.Handler {
 			return
 		}
 
-		// TODO: トークンの検証
-		// 本番環境では適切なCSRFトークン検証を実装
+		// セッションのトークンと定数時間で比較
+		session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+		if !ok || session.CSRFToken == "" || subtle.ConstantTimeCompare([]byte(csrfToken), []byte(session.CSRFToken)) != 1 {
+			http.Error(w, "CSRF token invalid", http.StatusForbidden)
+			return
+		}
 
 		next.ServeHTTP(w, r)
 	})
@@ -217,6 +286,14 @@ func getIP(r *http.Request) string {
 	return ip
 }
 
+// withClientInfo はリクエスト元の端末の情報（IPアドレス・User-Agent）を設定したコンテキストを返します
+func withClientInfo(r *http.Request) context.Context {
+	return service.WithClientInfo(r.Context(), service.ClientInfo{
+		IPAddress: getIP(r),
+		UserAgent: r.UserAgent(),
+	})
+}
+
 // RateLimiter はIPアドレスベースのレート制限
 type RateLimiter struct {
 	limiters map[string]*rate.Limiter
@@ -0,0 +1,239 @@
+// backend/internal/handler/penalty_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// PenaltyServiceInterface はキャンセルペナルティサービスのインターフェース
+type PenaltyServiceInterface interface {
+	History(ctx context.Context, userID uuid.UUID) (*service.PenaltyHistory, error)
+	AdjustScore(ctx context.Context, actorID, userID uuid.UUID, points int, reason string) (*domain.PenaltyEvent, error)
+	CreateThreshold(ctx context.Context, actorID uuid.UUID, threshold *domain.PenaltyThreshold) error
+	ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error)
+	DeleteThreshold(ctx context.Context, actorID, thresholdID uuid.UUID) error
+}
+
+// PenaltyHandler はキャンセルペナルティ関連のHTTPハンドラー
+type PenaltyHandler struct {
+	penaltyService PenaltyServiceInterface
+}
+
+// NewPenaltyHandler は新しいPenaltyHandlerを作成します
+func NewPenaltyHandler(penaltyService PenaltyServiceInterface) *PenaltyHandler {
+	return &PenaltyHandler{
+		penaltyService: penaltyService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *PenaltyHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/users/me/penalties", h.GetMyHistory).Methods("GET")
+	r.HandleFunc("/api/v1/users/{id}/penalties", h.GetUserHistory).Methods("GET")
+	r.HandleFunc("/api/v1/users/{id}/penalties", h.AdjustScore).Methods("POST")
+	r.HandleFunc("/api/v1/penalty-thresholds", h.ListThresholds).Methods("GET")
+	r.HandleFunc("/api/v1/penalty-thresholds", h.CreateThreshold).Methods("POST")
+	r.HandleFunc("/api/v1/penalty-thresholds/{id}", h.DeleteThreshold).Methods("DELETE")
+}
+
+// AdjustPenaltyRequest はペナルティスコアの調整リクエスト
+type AdjustPenaltyRequest struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+// CreatePenaltyThresholdRequest は予約制限の閾値の作成リクエスト
+type CreatePenaltyThresholdRequest struct {
+	Name               string `json:"name"`
+	MinScore           int    `json:"min_score"`
+	RequiresApproval   bool   `json:"requires_approval"`
+	MaxAdvanceDays     *int   `json:"max_advance_days,omitempty"`
+	PremiumMinCapacity *int   `json:"premium_min_capacity,omitempty"`
+}
+
+// GetMyHistory はログインユーザーのペナルティスコアと履歴を取得します
+func (h *PenaltyHandler) GetMyHistory(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	h.writeHistory(w, r, session.UserID)
+}
+
+// GetUserHistory は指定したユーザーのペナルティスコアと履歴を取得します（管理者のみ）
+func (h *PenaltyHandler) GetUserHistory(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	userID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return
+	}
+
+	h.writeHistory(w, r, userID)
+}
+
+// writeHistory はユーザーのペナルティスコアと履歴をレスポンスに書き込みます
+func (h *PenaltyHandler) writeHistory(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	history, err := h.penaltyService.History(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, history)
+}
+
+// AdjustScore はユーザーのペナルティスコアを調整します（管理者のみ）
+func (h *PenaltyHandler) AdjustScore(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	userID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid user ID")
+		return
+	}
+
+	var req AdjustPenaltyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	event, err := h.penaltyService.AdjustScore(r.Context(), session.UserID, userID, req.Points, req.Reason)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPenaltyPoints) {
+			WriteError(w, http.StatusBadRequest, "INVALID_PENALTY_ADJUSTMENT", "Points must be non-zero and a reason is required")
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "ADJUST_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, event)
+}
+
+// ListThresholds は予約制限の閾値一覧を取得します（管理者のみ）
+func (h *PenaltyHandler) ListThresholds(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	thresholds, err := h.penaltyService.ListThresholds(r.Context())
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, thresholds)
+}
+
+// CreateThreshold は予約制限の閾値を作成します（管理者のみ）
+func (h *PenaltyHandler) CreateThreshold(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	var req CreatePenaltyThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	threshold := &domain.PenaltyThreshold{
+		Name:               req.Name,
+		MinScore:           req.MinScore,
+		RequiresApproval:   req.RequiresApproval,
+		MaxAdvanceDays:     req.MaxAdvanceDays,
+		PremiumMinCapacity: req.PremiumMinCapacity,
+	}
+	if err := h.penaltyService.CreateThreshold(r.Context(), session.UserID, threshold); err != nil {
+		if errors.Is(err, domain.ErrInvalidPenaltyThreshold) {
+			WriteError(w, http.StatusBadRequest, "INVALID_PENALTY_THRESHOLD", "Name, a min_score of at least 1 and at least one restriction are required")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, threshold)
+}
+
+// DeleteThreshold は予約制限の閾値を削除します（管理者のみ）
+func (h *PenaltyHandler) DeleteThreshold(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid penalty threshold ID")
+		return
+	}
+
+	if err := h.penaltyService.DeleteThreshold(r.Context(), session.UserID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "Penalty threshold not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Penalty threshold deleted successfully",
+	})
+}
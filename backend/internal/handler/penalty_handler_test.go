@@ -0,0 +1,208 @@
+// backend/internal/handler/penalty_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestPenaltyHandler_GetMyHistory(t *testing.T) {
+	mockPenalty := new(MockPenaltyService)
+	h := handler.NewPenaltyHandler(mockPenalty)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID, Role: domain.RoleGeneral}
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockPenalty.On("History", mock.Anything, userID).Return(&service.PenaltyHistory{
+					UserID: userID,
+					Score:  2,
+					Events: []*domain.PenaltyEvent{{ID: uuid.New(), UserID: userID, Kind: domain.PenaltyEventLateCancellation, Points: 1}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "User not found",
+			setupMock: func() {
+				mockPenalty.On("History", mock.Anything, userID).Return(nil, repository.ErrNotFound)
+			},
+			expectedCode:  http.StatusNotFound,
+			expectedError: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPenalty.ExpectedCalls = nil
+			mockPenalty.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", "/api/v1/users/me/penalties", nil)
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.GetMyHistory(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			} else {
+				assert.Contains(t, w.Body.String(), `"score":2`)
+				assert.Contains(t, w.Body.String(), `"LATE_CANCELLATION"`)
+			}
+			mockPenalty.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPenaltyHandler_AdjustScore(t *testing.T) {
+	mockPenalty := new(MockPenaltyService)
+	h := handler.NewPenaltyHandler(mockPenalty)
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		role          domain.Role
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			role: domain.RoleAdmin,
+			setupMock: func() {
+				mockPenalty.On("AdjustScore", mock.Anything, adminID, userID, -1, "Room was closed").
+					Return(&domain.PenaltyEvent{ID: uuid.New(), UserID: userID, Kind: domain.PenaltyEventAdminAdjustment, Points: -1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Invalid adjustment",
+			role: domain.RoleAdmin,
+			setupMock: func() {
+				mockPenalty.On("AdjustScore", mock.Anything, adminID, userID, -1, "Room was closed").Return(nil, service.ErrInvalidPenaltyPoints)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_PENALTY_ADJUSTMENT",
+		},
+		{
+			name:          "Forbidden for non-admin",
+			role:          domain.RoleManager,
+			setupMock:     func() {},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPenalty.ExpectedCalls = nil
+			mockPenalty.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{"points": -1, "reason": "Room was closed"})
+			req := httptest.NewRequest("POST", "/api/v1/users/"+userID.String()+"/penalties", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			req = mux.SetURLVars(req, map[string]string{"id": userID.String()})
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: adminID, Role: tt.role})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.AdjustScore(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockPenalty.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPenaltyHandler_CreateThreshold(t *testing.T) {
+	mockPenalty := new(MockPenaltyService)
+	h := handler.NewPenaltyHandler(mockPenalty)
+
+	adminID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockPenalty.On("CreateThreshold", mock.Anything, adminID, mock.MatchedBy(func(threshold *domain.PenaltyThreshold) bool {
+					return threshold.MinScore == 3 && *threshold.PremiumMinCapacity == 12 && *threshold.MaxAdvanceDays == 7
+				})).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Invalid threshold",
+			setupMock: func() {
+				mockPenalty.On("CreateThreshold", mock.Anything, adminID, mock.Anything).Return(domain.ErrInvalidPenaltyThreshold)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_PENALTY_THRESHOLD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPenalty.ExpectedCalls = nil
+			mockPenalty.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(map[string]interface{}{
+				"name":                 "Restricted",
+				"min_score":            3,
+				"max_advance_days":     7,
+				"premium_min_capacity": 12,
+			})
+			req := httptest.NewRequest("POST", "/api/v1/penalty-thresholds", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: adminID, Role: domain.RoleAdmin})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.CreateThreshold(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockPenalty.AssertExpectations(t)
+		})
+	}
+}
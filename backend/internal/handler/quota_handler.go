@@ -0,0 +1,179 @@
+// backend/internal/handler/quota_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// QuotaServiceInterface は予約上限サービスのインターフェース
+type QuotaServiceInterface interface {
+	CreateRule(ctx context.Context, actorID uuid.UUID, rule *domain.QuotaRule) error
+	ListRules(ctx context.Context) ([]*domain.QuotaRule, error)
+	DeleteRule(ctx context.Context, actorID, ruleID uuid.UUID) error
+	Usage(ctx context.Context, userID uuid.UUID, loc *time.Location, now time.Time) ([]*domain.QuotaUsage, error)
+}
+
+// QuotaHandler は予約上限関連のHTTPハンドラー
+type QuotaHandler struct {
+	quotaService QuotaServiceInterface
+}
+
+// NewQuotaHandler は新しいQuotaHandlerを作成します
+func NewQuotaHandler(quotaService QuotaServiceInterface) *QuotaHandler {
+	return &QuotaHandler{
+		quotaService: quotaService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *QuotaHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/quotas/usage", h.GetUsage).Methods("GET")
+	r.HandleFunc("/api/v1/quotas", h.ListRules).Methods("GET")
+	r.HandleFunc("/api/v1/quotas", h.CreateRule).Methods("POST")
+	r.HandleFunc("/api/v1/quotas/{id}", h.DeleteRule).Methods("DELETE")
+}
+
+// CreateQuotaRuleRequest は予約上限のルールの作成リクエスト
+type CreateQuotaRuleRequest struct {
+	Name         string               `json:"name"`
+	Scope        domain.QuotaScope    `json:"scope"`
+	Kind         domain.QuotaKind     `json:"kind"`
+	ResourceType *domain.ResourceType `json:"resource_type,omitempty"`
+	MinCapacity  *int                 `json:"min_capacity,omitempty"`
+	Department   *string              `json:"department,omitempty"`
+	Limit        int                  `json:"limit"`
+}
+
+// GetUsage はログインユーザーに適用される予約上限ごとの消費状況を取得します
+// 週間・月間の集計期間は timezone クエリパラメータのタイムゾーンで区切ります（省略時は UTC）
+func (h *QuotaHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	loc := time.UTC
+	if tz := r.URL.Query().Get("timezone"); tz != "" {
+		parsed, err := time.LoadLocation(tz)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Invalid timezone")
+			return
+		}
+		loc = parsed
+	}
+
+	usages, err := h.quotaService.Usage(r.Context(), session.UserID, loc, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "User not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, usages)
+}
+
+// ListRules は予約上限のルール一覧を取得します（管理者のみ）
+func (h *QuotaHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	rules, err := h.quotaService.ListRules(r.Context())
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, rules)
+}
+
+// CreateRule は予約上限のルールを作成します（管理者のみ）
+func (h *QuotaHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	var req CreateQuotaRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	rule := &domain.QuotaRule{
+		Name:         req.Name,
+		Scope:        req.Scope,
+		Kind:         req.Kind,
+		ResourceType: req.ResourceType,
+		MinCapacity:  req.MinCapacity,
+		Department:   req.Department,
+		Limit:        req.Limit,
+	}
+	if err := h.quotaService.CreateRule(r.Context(), session.UserID, rule); err != nil {
+		if errors.Is(err, domain.ErrInvalidQuotaRule) {
+			WriteError(w, http.StatusBadRequest, "INVALID_QUOTA_RULE", "Name, scope, kind and a non-negative limit are required; department is only allowed for department quotas")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "CREATE_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, rule)
+}
+
+// DeleteRule は予約上限のルールを削除します（管理者のみ）
+func (h *QuotaHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid quota rule ID")
+		return
+	}
+
+	if err := h.quotaService.DeleteRule(r.Context(), session.UserID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			WriteError(w, http.StatusNotFound, "NOT_FOUND", "Quota rule not found")
+			return
+		}
+		WriteError(w, http.StatusInternalServerError, "DELETE_FAILED", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Quota rule deleted successfully",
+	})
+}
@@ -0,0 +1,153 @@
+// backend/internal/handler/quota_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestQuotaHandler_GetUsage(t *testing.T) {
+	mockQuota := new(MockQuotaService)
+	h := handler.NewQuotaHandler(mockQuota)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID, Role: domain.RoleGeneral}
+
+	tests := []struct {
+		name          string
+		query         string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:  "Success",
+			query: "?timezone=Asia/Tokyo",
+			setupMock: func() {
+				mockQuota.On("Usage", mock.Anything, userID, mock.MatchedBy(func(loc *time.Location) bool {
+					return loc.String() == "Asia/Tokyo"
+				}), mock.Anything).Return([]*domain.QuotaUsage{
+					{Name: "Large rooms", Kind: domain.QuotaKindWeeklyHours, Used: 2.5, Limit: 10, Remaining: 7.5},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:          "Invalid timezone",
+			query:         "?timezone=Mars/Olympus",
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_TIMEZONE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuota.ExpectedCalls = nil
+			mockQuota.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("GET", "/api/v1/quotas/usage"+tt.query, nil)
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.GetUsage(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			} else {
+				assert.Contains(t, w.Body.String(), `"remaining":7.5`)
+			}
+			mockQuota.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQuotaHandler_CreateRule(t *testing.T) {
+	mockQuota := new(MockQuotaService)
+	h := handler.NewQuotaHandler(mockQuota)
+
+	adminID := uuid.New()
+	validBody := map[string]interface{}{
+		"name":          "Large rooms per department",
+		"scope":         "DEPARTMENT",
+		"kind":          "MONTHLY_HOURS",
+		"resource_type": "MEETING_ROOM",
+		"min_capacity":  12,
+		"limit":         40,
+	}
+
+	tests := []struct {
+		name          string
+		role          domain.Role
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			role: domain.RoleAdmin,
+			setupMock: func() {
+				mockQuota.On("CreateRule", mock.Anything, adminID, mock.MatchedBy(func(rule *domain.QuotaRule) bool {
+					return rule.Scope == domain.QuotaScopeDepartment && *rule.ResourceType == domain.ResourceTypeMeetingRoom && *rule.MinCapacity == 12 && rule.Limit == 40
+				})).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Invalid rule",
+			role: domain.RoleAdmin,
+			setupMock: func() {
+				mockQuota.On("CreateRule", mock.Anything, adminID, mock.Anything).Return(domain.ErrInvalidQuotaRule)
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_QUOTA_RULE",
+		},
+		{
+			name:          "Forbidden for non-admin",
+			role:          domain.RoleManager,
+			setupMock:     func() {},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockQuota.ExpectedCalls = nil
+			mockQuota.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(validBody)
+			req := httptest.NewRequest("POST", "/api/v1/quotas", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, &service.Session{UserID: adminID, Role: tt.role})
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.CreateRule(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockQuota.AssertExpectations(t)
+		})
+	}
+}
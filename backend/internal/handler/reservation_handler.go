@@ -4,6 +4,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -17,12 +18,15 @@ import (
 type ReservationServiceInterface interface {
 	CreateReservation(ctx context.Context, req *service.CreateReservationRequest) (*domain.Reservation, error)
 	CancelReservation(ctx context.Context, id uuid.UUID, startAt time.Time, userID uuid.UUID) error
+	CheckConflicts(ctx context.Context, req *service.CreateReservationRequest) (*domain.SeriesConflictReport, error)
 }
 
 // ApprovalServiceInterface は承認サービスのインターフェース
 type ApprovalServiceInterface interface {
 	ApproveReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID) error
 	RejectReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID, reason string) error
+	GetPendingApprovals(ctx context.Context, approverID uuid.UUID, query service.PendingApprovalsQuery) ([]*domain.Reservation, int, error)
+	DecideBulk(ctx context.Context, approverID uuid.UUID, items []service.BulkDecisionItem) ([]service.BulkDecisionResult, error)
 }
 
 // ReservationHandler は予約関連のHTTPハンドラー
@@ -45,6 +49,7 @@ func NewReservationHandler(
 // RegisterRoutes はルートを登録します
 func (h *ReservationHandler) RegisterRoutes(r *mux.Router) {
 	r.HandleFunc("/api/v1/events", h.CreateReservation).Methods("POST")
+	r.HandleFunc("/api/v1/events/conflicts", h.CheckConflicts).Methods("POST")
 	r.HandleFunc("/api/v1/events/{id}", h.GetReservation).Methods("GET")
 	r.HandleFunc("/api/v1/events/{id}", h.CancelReservation).Methods("DELETE")
 	r.HandleFunc("/api/v1/events/{id}/approve", h.ApproveReservation).Methods("POST")
@@ -59,9 +64,34 @@ type CreateReservationRequest struct {
 	StartAt     time.Time `json:"start_at"`
 	EndAt       time.Time `json:"end_at"`
 	Timezone    string    `json:"timezone"`
+	RRule       string    `json:"rrule"` // 繰り返しルール（RFC 5545 RRULE。例: FREQ=WEEKLY;COUNT=10）
+
+	Units map[string]int `json:"units"` // リソースIDごとの予約数量（プール型備品用）
+
+	Room *RoomRequirementRequest `json:"room"` // 会議室の自動選択条件（resource_ids には備品のみ指定する）
+
+	ParticipantIDs    []string `json:"participant_ids"`    // 招待する参加者
+	OverrideOccupancy bool     `json:"override_occupancy"` // 収容人数・最低利用人数の検証を省略する（管理者のみ）
+
+	Resolutions []OccurrenceResolutionRequest `json:"resolutions"` // 繰り返し予約で競合した回の扱い
+}
+
+// OccurrenceResolutionRequest は繰り返し予約の競合した回の扱い
+type OccurrenceResolutionRequest struct {
+	StartAt      time.Time             `json:"start_at"`
+	Action       domain.ConflictAction `json:"action"`       // SKIP または SUBSTITUTE
+	Replacements map[string]string     `json:"replacements"` // SUBSTITUTE の場合: 競合リソースID → 代替リソースID
+}
+
+// RoomRequirementRequest は会議室の自動選択条件
+type RoomRequirementRequest struct {
+	Attendees int      `json:"attendees"`
+	Amenities []string `json:"amenities"`
+	NearFloor *int     `json:"near_floor"`
 }
 
 // CreateReservation は予約を作成します
+// 繰り返し予約で解決されていない競合がある場合は 409 SERIES_CONFLICT と各回の競合状況を返します
 func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Request) {
 	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
 	if !ok {
@@ -69,24 +99,63 @@ func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	serviceReq, ok := parseCreateReservationRequest(w, r, session.UserID)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationService.CreateReservation(r.Context(), serviceReq)
+	if err != nil {
+		writeCreateReservationError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, reservation)
+}
+
+// CheckConflicts は予約を作成せずに繰り返し予約の各回の競合状況を返します（ドライラン）
+func (h *ReservationHandler) CheckConflicts(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	serviceReq, ok := parseCreateReservationRequest(w, r, session.UserID)
+	if !ok {
+		return
+	}
+
+	report, err := h.reservationService.CheckConflicts(r.Context(), serviceReq)
+	if err != nil {
+		writeCreateReservationError(w, err)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, report)
+}
+
+// parseCreateReservationRequest は予約作成リクエストを読み取り検証します
+// 不正な場合はエラーレスポンスを書き込み false を返します
+func parseCreateReservationRequest(w http.ResponseWriter, r *http.Request, organizerID uuid.UUID) (*service.CreateReservationRequest, bool) {
 	var req CreateReservationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
-		return
+		return nil, false
 	}
 
 	// バリデーション
 	if req.Timezone == "" {
 		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone is required")
-		return
+		return nil, false
 	}
 	if req.StartAt.IsZero() || req.EndAt.IsZero() {
 		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "StartAt and EndAt are required")
-		return
+		return nil, false
 	}
 	if req.EndAt.Before(req.StartAt) {
 		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "EndAt must be after StartAt")
-		return
+		return nil, false
 	}
 
 	// UUIDに変換
@@ -95,32 +164,169 @@ func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Re
 		parsed, err := uuid.Parse(id)
 		if err != nil {
 			WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID")
-			return
+			return nil, false
 		}
 		resourceIDs[i] = parsed
 	}
 
+	var units map[uuid.UUID]int
+	if len(req.Units) > 0 {
+		units = make(map[uuid.UUID]int, len(req.Units))
+		for id, n := range req.Units {
+			parsed, err := uuid.Parse(id)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID in units")
+				return nil, false
+			}
+			if n < 1 {
+				WriteError(w, http.StatusBadRequest, "INVALID_UNITS", "Units must be at least 1")
+				return nil, false
+			}
+			units[parsed] = n
+		}
+	}
+
+	participantIDs := make([]uuid.UUID, len(req.ParticipantIDs))
+	for i, id := range req.ParticipantIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_PARTICIPANT_ID", "Invalid participant ID")
+			return nil, false
+		}
+		participantIDs[i] = parsed
+	}
+
+	resolutions := make([]*domain.OccurrenceResolution, 0, len(req.Resolutions))
+	for _, res := range req.Resolutions {
+		resolution := &domain.OccurrenceResolution{StartAt: res.StartAt, Action: res.Action}
+		if res.StartAt.IsZero() || !res.Action.IsValid() {
+			WriteError(w, http.StatusBadRequest, "INVALID_RESOLUTION", "Each resolution needs start_at and an action of SKIP or SUBSTITUTE")
+			return nil, false
+		}
+		if res.Action == domain.ConflictActionSubstitute {
+			if len(res.Replacements) == 0 {
+				WriteError(w, http.StatusBadRequest, "INVALID_RESOLUTION", "SUBSTITUTE requires replacements")
+				return nil, false
+			}
+			resolution.Replacements = make(map[uuid.UUID]uuid.UUID, len(res.Replacements))
+			for original, replacement := range res.Replacements {
+				originalID, err := uuid.Parse(original)
+				if err != nil {
+					WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID in replacements")
+					return nil, false
+				}
+				replacementID, err := uuid.Parse(replacement)
+				if err != nil {
+					WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID in replacements")
+					return nil, false
+				}
+				resolution.Replacements[originalID] = replacementID
+			}
+		}
+		resolutions = append(resolutions, resolution)
+	}
+
+	var room *domain.RoomRequirement
+	if req.Room != nil {
+		if req.Room.Attendees < 1 {
+			WriteError(w, http.StatusBadRequest, "INVALID_ATTENDEES", "Attendees must be at least 1")
+			return nil, false
+		}
+		room = &domain.RoomRequirement{
+			Attendees: req.Room.Attendees,
+			Amenities: req.Room.Amenities,
+			NearFloor: req.Room.NearFloor,
+		}
+	}
+
 	serviceReq := &service.CreateReservationRequest{
-		OrganizerID: session.UserID,
+		OrganizerID: organizerID,
 		ResourceIDs: resourceIDs,
 		Title:       req.Title,
 		Description: req.Description,
 		StartAt:     req.StartAt,
 		EndAt:       req.EndAt,
+		RRule:       req.RRule,
 		Timezone:    req.Timezone,
+		Units:       units,
+		Room:        room,
+
+		ParticipantIDs:    participantIDs,
+		OverrideOccupancy: req.OverrideOccupancy,
+		Resolutions:       resolutions,
 	}
+	return serviceReq, true
+}
 
-	reservation, err := h.reservationService.CreateReservation(r.Context(), serviceReq)
-	if err != nil {
-		if err == service.ErrResourceNotAvailable {
-			WriteError(w, http.StatusConflict, "RESOURCE_CONFLICT", "One or more resources are not available")
-			return
-		}
-		WriteError(w, http.StatusBadRequest, "CREATE_FAILED", err.Error())
+// writeCreateReservationError は予約作成エラーをレスポンスに変換します
+func writeCreateReservationError(w http.ResponseWriter, err error) {
+	var conflictErr *service.SeriesConflictError
+	if errors.As(err, &conflictErr) {
+		WriteErrorWithData(w, http.StatusConflict, "SERIES_CONFLICT", "Some occurrences conflict with existing bookings; skip or substitute them", conflictErr.Report)
 		return
 	}
-
-	WriteJSON(w, http.StatusCreated, reservation)
+	var quotaErr *service.QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		WriteErrorWithData(w, http.StatusUnprocessableEntity, "QUOTA_EXCEEDED", quotaErr.Error(), quotaErr.Usage)
+		return
+	}
+	if errors.Is(err, service.ErrInvalidResolution) {
+		WriteError(w, http.StatusBadRequest, "INVALID_RESOLUTION", "Resolutions must refer to occurrences and resources of the series")
+		return
+	}
+	if errors.Is(err, service.ErrNoOccurrences) {
+		WriteError(w, http.StatusBadRequest, "NO_OCCURRENCES", "All occurrences were skipped")
+		return
+	}
+	if err == service.ErrResourceNotAvailable {
+		WriteError(w, http.StatusConflict, "RESOURCE_CONFLICT", "One or more resources are not available")
+		return
+	}
+	if errors.Is(err, domain.ErrCapacityExceeded) {
+		WriteError(w, http.StatusUnprocessableEntity, "CAPACITY_EXCEEDED", err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrBelowMinOccupancy) {
+		WriteError(w, http.StatusUnprocessableEntity, "BELOW_MIN_OCCUPANCY", err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrOccupancyOverrideForbidden) {
+		WriteError(w, http.StatusForbidden, "OCCUPANCY_OVERRIDE_FORBIDDEN", "Only administrators can override room capacity rules")
+		return
+	}
+	if errors.Is(err, service.ErrNoSuitableRoom) {
+		WriteError(w, http.StatusConflict, "NO_SUITABLE_ROOM", "No available meeting room matches the requirements")
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidAttendees) {
+		WriteError(w, http.StatusBadRequest, "INVALID_ATTENDEES", "Attendees must be at least 1")
+		return
+	}
+	if errors.Is(err, service.ErrInsufficientUnits) {
+		WriteError(w, http.StatusConflict, "INSUFFICIENT_UNITS", "Not enough units are available for the requested time")
+		return
+	}
+	if errors.Is(err, service.ErrOverdueEquipment) {
+		WriteError(w, http.StatusForbidden, "OVERDUE_EQUIPMENT", "Overdue equipment must be returned before making a new reservation")
+		return
+	}
+	if errors.Is(err, service.ErrInvalidDaySlot) {
+		WriteError(w, http.StatusBadRequest, "INVALID_DAY_SLOT", "Desks and parking spaces must be booked for a full day or half day")
+		return
+	}
+	if errors.Is(err, service.ErrApprovalChainUnresolved) {
+		WriteError(w, http.StatusUnprocessableEntity, "APPROVAL_CHAIN_UNRESOLVED", err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrPenaltyRestricted) {
+		WriteError(w, http.StatusForbidden, "PENALTY_RESTRICTED", err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrPenaltyAdvanceWindow) {
+		WriteError(w, http.StatusUnprocessableEntity, "PENALTY_ADVANCE_WINDOW", err.Error())
+		return
+	}
+	WriteError(w, http.StatusBadRequest, "CREATE_FAILED", err.Error())
 }
 
 // GetReservation は予約を取得します
@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,7 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 	userID := uuid.New()
 	session := &service.Session{UserID: userID}
 	resourceID := uuid.New()
+	alternativeID := uuid.New()
 
 	tests := []struct {
 		name          string
@@ -87,6 +90,227 @@ func TestReservationHandler_CreateReservation(t *testing.T) {
 			expectedCode:  http.StatusConflict,
 			expectedError: "RESOURCE_CONFLICT",
 		},
+		{
+			name: "Unprocessable - Approval Chain Unresolved",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Test Meeting",
+				"start_at":     "2025-06-01T10:00:00Z",
+				"end_at":       "2025-06-01T11:00:00Z",
+				"timezone":     "UTC",
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: %w", service.ErrApprovalChainUnresolved, domain.ErrManagerNotAssigned))
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "APPROVAL_CHAIN_UNRESOLVED",
+		},
+		{
+			name: "Success - Room Requirement",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Client Call",
+				"start_at":     "2025-06-01T10:00:00Z",
+				"end_at":       "2025-06-01T11:00:00Z",
+				"timezone":     "UTC",
+				"room":         map[string]interface{}{"attendees": 7, "amenities": []string{"vc"}, "near_floor": 3},
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return req.Room != nil && req.Room.Attendees == 7 && *req.Room.NearFloor == 3 && len(req.ResourceIDs) == 1
+				})).Return(&domain.Reservation{ID: uuid.New()}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Validation Error - Invalid Attendees",
+			body: map[string]interface{}{
+				"title":    "Client Call",
+				"start_at": "2025-06-01T10:00:00Z",
+				"end_at":   "2025-06-01T11:00:00Z",
+				"timezone": "UTC",
+				"room":     map[string]interface{}{"attendees": 0},
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_ATTENDEES",
+		},
+		{
+			name: "Conflict Error - No Suitable Room",
+			body: map[string]interface{}{
+				"title":    "Client Call",
+				"start_at": "2025-06-01T10:00:00Z",
+				"end_at":   "2025-06-01T11:00:00Z",
+				"timezone": "UTC",
+				"room":     map[string]interface{}{"attendees": 30},
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, service.ErrNoSuitableRoom)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "NO_SUITABLE_ROOM",
+		},
+		{
+			name: "Validation Error - Invalid Participant ID",
+			body: map[string]interface{}{
+				"resource_ids":    []string{resourceID.String()},
+				"title":           "Test Meeting",
+				"start_at":        "2025-06-01T10:00:00Z",
+				"end_at":          "2025-06-01T11:00:00Z",
+				"timezone":        "UTC",
+				"participant_ids": []string{"not-a-uuid"},
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_PARTICIPANT_ID",
+		},
+		{
+			name: "Unprocessable - Capacity Exceeded",
+			body: map[string]interface{}{
+				"resource_ids":    []string{resourceID.String()},
+				"title":           "Test Meeting",
+				"start_at":        "2025-06-01T10:00:00Z",
+				"end_at":          "2025-06-01T11:00:00Z",
+				"timezone":        "UTC",
+				"participant_ids": []string{uuid.New().String(), uuid.New().String()},
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return len(req.ParticipantIDs) == 2
+				})).Return(nil, fmt.Errorf("%w: 3 attendees for Huddle (capacity 2)", domain.ErrCapacityExceeded))
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "CAPACITY_EXCEEDED",
+		},
+		{
+			name: "Unprocessable - Below Minimum Occupancy",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Test Meeting",
+				"start_at":     "2025-06-01T10:00:00Z",
+				"end_at":       "2025-06-01T11:00:00Z",
+				"timezone":     "UTC",
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: 1 attendees for Boardroom (minimum 6)", domain.ErrBelowMinOccupancy))
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "BELOW_MIN_OCCUPANCY",
+		},
+		{
+			name: "Unprocessable - Quota Exceeded",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Test Meeting",
+				"start_at":     "2025-06-01T10:00:00Z",
+				"end_at":       "2025-06-01T11:00:00Z",
+				"timezone":     "UTC",
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, &service.QuotaExceededError{
+					Usage: &domain.QuotaUsage{Name: "Large rooms", Kind: domain.QuotaKindWeeklyHours, Used: 9, Limit: 10, Remaining: 1},
+				})
+			},
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: `"remaining":1`,
+		},
+		{
+			name: "Forbidden - Penalty Restricted",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Test Meeting",
+				"start_at":     "2025-06-01T10:00:00Z",
+				"end_at":       "2025-06-01T11:00:00Z",
+				"timezone":     "UTC",
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: Board Room", service.ErrPenaltyRestricted))
+			},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "PENALTY_RESTRICTED",
+		},
+		{
+			name: "Forbidden - Occupancy Override",
+			body: map[string]interface{}{
+				"resource_ids":       []string{resourceID.String()},
+				"title":              "Test Meeting",
+				"start_at":           "2025-06-01T10:00:00Z",
+				"end_at":             "2025-06-01T11:00:00Z",
+				"timezone":           "UTC",
+				"override_occupancy": true,
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return req.OverrideOccupancy
+				})).Return(nil, service.ErrOccupancyOverrideForbidden)
+			},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "OCCUPANCY_OVERRIDE_FORBIDDEN",
+		},
+		{
+			name: "Conflict Error - Series Conflict",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Weekly Sync",
+				"start_at":     "2025-06-02T10:00:00Z",
+				"end_at":       "2025-06-02T11:00:00Z",
+				"timezone":     "UTC",
+				"rrule":        "FREQ=WEEKLY;COUNT=4",
+			},
+			setupMock: func() {
+				report := &domain.SeriesConflictReport{
+					Occurrences: 4,
+					Conflicts: []*domain.OccurrenceConflict{{
+						StartAt:     time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC),
+						EndAt:       time.Date(2025, 6, 9, 11, 0, 0, 0, time.UTC),
+						ResourceIDs: []uuid.UUID{resourceID},
+					}},
+				}
+				mockRes.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return req.RRule == "FREQ=WEEKLY;COUNT=4"
+				})).Return(nil, &service.SeriesConflictError{Report: report})
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "SERIES_CONFLICT",
+		},
+		{
+			name: "Validation Error - Invalid Resolution",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Weekly Sync",
+				"start_at":     "2025-06-02T10:00:00Z",
+				"end_at":       "2025-06-02T11:00:00Z",
+				"timezone":     "UTC",
+				"rrule":        "FREQ=WEEKLY;COUNT=4",
+				"resolutions":  []map[string]interface{}{{"start_at": "2025-06-09T10:00:00Z", "action": "MOVE"}},
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_RESOLUTION",
+		},
+		{
+			name: "Success - Series With Resolutions",
+			body: map[string]interface{}{
+				"resource_ids": []string{resourceID.String()},
+				"title":        "Weekly Sync",
+				"start_at":     "2025-06-02T10:00:00Z",
+				"end_at":       "2025-06-02T11:00:00Z",
+				"timezone":     "UTC",
+				"rrule":        "FREQ=WEEKLY;COUNT=4",
+				"resolutions": []map[string]interface{}{
+					{"start_at": "2025-06-09T10:00:00Z", "action": "SKIP"},
+					{"start_at": "2025-06-16T10:00:00Z", "action": "SUBSTITUTE", "replacements": map[string]string{resourceID.String(): alternativeID.String()}},
+				},
+			},
+			setupMock: func() {
+				mockRes.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+					return len(req.Resolutions) == 2 &&
+						req.Resolutions[0].Action == domain.ConflictActionSkip &&
+						req.Resolutions[1].Replacements[resourceID] == alternativeID
+				})).Return(&domain.Reservation{ID: uuid.New()}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
 	}
 
 	for _, tt := range tests {
@@ -128,3 +352,46 @@ func TestReservationHandler_CreateReservation_Unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestReservationHandler_CheckConflicts(t *testing.T) {
+	mockRes := new(MockReservationService)
+	mockApp := new(MockApprovalService)
+	h := handler.NewReservationHandler(mockRes, mockApp)
+
+	session := &service.Session{UserID: uuid.New()}
+	resourceID := uuid.New()
+	report := &domain.SeriesConflictReport{
+		Occurrences: 4,
+		Conflicts: []*domain.OccurrenceConflict{{
+			StartAt:     time.Date(2025, 6, 9, 10, 0, 0, 0, time.UTC),
+			EndAt:       time.Date(2025, 6, 9, 11, 0, 0, 0, time.UTC),
+			ResourceIDs: []uuid.UUID{resourceID},
+		}},
+	}
+	mockRes.On("CheckConflicts", mock.Anything, mock.MatchedBy(func(req *service.CreateReservationRequest) bool {
+		return req.OrganizerID == session.UserID && req.RRule == "FREQ=WEEKLY;COUNT=4"
+	})).Return(report, nil)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"resource_ids": []string{resourceID.String()},
+		"title":        "Weekly Sync",
+		"start_at":     "2025-06-02T10:00:00Z",
+		"end_at":       "2025-06-02T11:00:00Z",
+		"timezone":     "UTC",
+		"rrule":        "FREQ=WEEKLY;COUNT=4",
+	})
+	req := httptest.NewRequest("POST", "/api/v1/events/conflicts", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, session))
+	w := httptest.NewRecorder()
+
+	h.CheckConflicts(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data domain.SeriesConflictReport `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 4, resp.Data.Occurrences)
+	assert.Len(t, resp.Data.Conflicts, 1)
+	mockRes.AssertExpectations(t)
+}
@@ -82,6 +82,20 @@ type CreateResourceRequest struct {
 	Location    string                 `json:"location"`
 	Capacity    *int                   `json:"capacity"`
 	Attributes  map[string]interface{} `json:"attributes"`
+
+	RequiresApproval bool       `json:"requires_approval"`
+	ApproverGroupID  *uuid.UUID `json:"approver_group_id"`
+	ApprovalPolicyID *uuid.UUID `json:"approval_policy_id"`
+	OwnerID          *uuid.UUID `json:"owner_id"`
+
+	Quantity *int `json:"quantity"` // プール型備品の保有数量（未指定は1）
+
+	Zone  string `json:"zone"`  // デスク・駐車区画のフロア・エリア
+	Floor *int   `json:"floor"` // 所在フロア（会議室の自動選択用）
+
+	MinOccupancy *int `json:"min_occupancy"` // 最低利用人数（会議室のみ）
+
+	BumpRole *domain.Role `json:"bump_role"` // 既存の予約を押しのけて確保できる最低ロール（会議室のみ。未指定は管理者のみ）
 }
 
 // CreateResource はリソースを作成します
@@ -101,14 +115,46 @@ func (h *ResourceHandler) CreateResource(w http.ResponseWriter, r *http.Request)
 		WriteError(w, http.StatusBadRequest, "INVALID_TYPE", "Type is required")
 		return
 	}
+	if !validResourceType(w, req.Type, req.Capacity) {
+		return
+	}
+	if req.RequiresApproval && req.ApproverGroupID == nil && req.ApprovalPolicyID == nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_APPROVER_GROUP", "Approver group or approval policy is required when approval is required")
+		return
+	}
+	if !validQuantity(w, req.Type, req.Quantity) {
+		return
+	}
+	if !validMinOccupancy(w, req.Type, req.Capacity, req.MinOccupancy) {
+		return
+	}
+	if !validBumpRole(w, req.Type, req.BumpRole) {
+		return
+	}
 
 	resource := &domain.Resource{
-		ID:       uuid.New(),
-		Name:     req.Name,
-		Type:     req.Type,
-		Location: &req.Location,
-		Capacity: req.Capacity,
-		IsActive: true,
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Type:      req.Type,
+		Location:  &req.Location,
+		Capacity:  req.Capacity,
+		Equipment: req.Attributes,
+		Floor:     req.Floor,
+		IsActive:  true,
+
+		MinOccupancy: req.MinOccupancy,
+		BumpRole:     req.BumpRole,
+
+		RequiresApproval: req.RequiresApproval,
+		ApproverGroupID:  req.ApproverGroupID,
+		ApprovalPolicyID: req.ApprovalPolicyID,
+		OwnerID:          req.OwnerID,
+	}
+	if req.Quantity != nil {
+		resource.Quantity = *req.Quantity
+	}
+	if req.Zone != "" {
+		resource.Zone = &req.Zone
 	}
 
 	if err := h.resourceRepo.Create(r.Context(), resource); err != nil {
@@ -145,12 +191,46 @@ func (h *ResourceHandler) UpdateResource(w http.ResponseWriter, r *http.Request)
 		WriteError(w, http.StatusBadRequest, "INVALID_NAME", "Name is required")
 		return
 	}
+	if req.RequiresApproval && req.ApproverGroupID == nil && req.ApprovalPolicyID == nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_APPROVER_GROUP", "Approver group or approval policy is required when approval is required")
+		return
+	}
+	if !validResourceType(w, resource.Type, req.Capacity) {
+		return
+	}
+	if !validQuantity(w, resource.Type, req.Quantity) {
+		return
+	}
+	if !validMinOccupancy(w, resource.Type, req.Capacity, req.MinOccupancy) {
+		return
+	}
+	if !validBumpRole(w, resource.Type, req.BumpRole) {
+		return
+	}
 
 	resource.Name = req.Name
 	if req.Location != "" {
 		resource.Location = &req.Location
 	}
 	resource.Capacity = req.Capacity
+	resource.RequiresApproval = req.RequiresApproval
+	resource.ApproverGroupID = req.ApproverGroupID
+	resource.ApprovalPolicyID = req.ApprovalPolicyID
+	resource.OwnerID = req.OwnerID
+	if req.Quantity != nil {
+		resource.Quantity = *req.Quantity
+	}
+	if req.Zone != "" {
+		resource.Zone = &req.Zone
+	}
+	if req.Attributes != nil {
+		resource.Equipment = req.Attributes
+	}
+	if req.Floor != nil {
+		resource.Floor = req.Floor
+	}
+	resource.MinOccupancy = req.MinOccupancy
+	resource.BumpRole = req.BumpRole
 
 	if err := h.resourceRepo.Update(r.Context(), resource); err != nil {
 		WriteError(w, http.StatusInternalServerError, "UPDATE_FAILED", err.Error())
@@ -178,3 +258,68 @@ func (h *ResourceHandler) DeleteResource(w http.ResponseWriter, r *http.Request)
 		"message": "Resource deleted successfully",
 	})
 }
+
+// validResourceType はリソース種別と種別ごとの属性を検証し、不正な場合はエラーレスポンスを書き込みます
+// デスク・駐車区画は1人で利用するため収容人数を指定できません
+func validResourceType(w http.ResponseWriter, resourceType domain.ResourceType, capacity *int) bool {
+	if !resourceType.IsValid() {
+		WriteError(w, http.StatusBadRequest, "INVALID_TYPE", "Type must be one of MEETING_ROOM, EQUIPMENT, DESK, PARKING")
+		return false
+	}
+	if resourceType.IsDayBooked() && capacity != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_CAPACITY", "Capacity is not applicable to desks and parking spaces")
+		return false
+	}
+	return true
+}
+
+// validQuantity は保有数量の指定を検証し、不正な場合はエラーレスポンスを書き込みます
+// 2以上の数量（プール型）は備品にのみ指定できます
+func validQuantity(w http.ResponseWriter, resourceType domain.ResourceType, quantity *int) bool {
+	if quantity == nil {
+		return true
+	}
+	if *quantity < 1 {
+		WriteError(w, http.StatusBadRequest, "INVALID_QUANTITY", "Quantity must be at least 1")
+		return false
+	}
+	if *quantity > 1 && resourceType != domain.ResourceTypeEquipment {
+		WriteError(w, http.StatusBadRequest, "INVALID_QUANTITY", "Quantity greater than 1 is only allowed for equipment")
+		return false
+	}
+	return true
+}
+
+// validMinOccupancy は最低利用人数の指定を検証し、不正な場合はエラーレスポンスを書き込みます
+// 最低利用人数は会議室にのみ指定でき、収容人数を超えることはできません
+func validMinOccupancy(w http.ResponseWriter, resourceType domain.ResourceType, capacity, minOccupancy *int) bool {
+	if minOccupancy == nil {
+		return true
+	}
+	if resourceType != domain.ResourceTypeMeetingRoom {
+		WriteError(w, http.StatusBadRequest, "INVALID_MIN_OCCUPANCY", "Minimum occupancy is only applicable to meeting rooms")
+		return false
+	}
+	if *minOccupancy < 1 || (capacity != nil && *minOccupancy > *capacity) {
+		WriteError(w, http.StatusBadRequest, "INVALID_MIN_OCCUPANCY", "Minimum occupancy must be between 1 and capacity")
+		return false
+	}
+	return true
+}
+
+// validBumpRole は優先確保できる最低ロールの指定を検証し、不正な場合はエラーレスポンスを書き込みます
+// 優先確保は会議室にのみ設定でき、ロールの階層に含まれるロールを指定する必要があります
+func validBumpRole(w http.ResponseWriter, resourceType domain.ResourceType, bumpRole *domain.Role) bool {
+	if bumpRole == nil {
+		return true
+	}
+	if resourceType != domain.ResourceTypeMeetingRoom {
+		WriteError(w, http.StatusBadRequest, "INVALID_BUMP_ROLE", "Bump role is only applicable to meeting rooms")
+		return false
+	}
+	if !bumpRole.IsRanked() {
+		WriteError(w, http.StatusBadRequest, "INVALID_BUMP_ROLE", "Bump role must be one of GENERAL, SECRETARY, MANAGER, ADMIN")
+		return false
+	}
+	return true
+}
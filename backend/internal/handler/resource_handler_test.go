@@ -53,6 +53,35 @@ func (m *MockResourceRepository) FindAvailable(ctx context.Context, startAt, end
 	return args.Get(0).([]*domain.Resource), args.Error(1)
 }
 
+func (m *MockResourceRepository) FindAvailableRooms(ctx context.Context, startAt, endAt time.Time, minCapacity int) ([]*domain.Resource, error) {
+	args := m.Called(ctx, startAt, endAt, minCapacity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) AvailableUnits(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error) {
+	args := m.Called(ctx, resourceID, startAt, endAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockResourceRepository) ListByZone(ctx context.Context, resourceType domain.ResourceType, zone string) ([]*domain.Resource, error) {
+	args := m.Called(ctx, resourceType, zone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) ListZoneBookings(ctx context.Context, resourceType domain.ResourceType, zone string, startAt, endAt time.Time) ([]*domain.ZoneBooking, error) {
+	args := m.Called(ctx, resourceType, zone, startAt, endAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ZoneBooking), args.Error(1)
+}
+
 func TestResourceHandler_ListResources(t *testing.T) {
 	mockRepo := new(MockResourceRepository)
 	h := handler.NewResourceHandler(mockRepo)
@@ -142,6 +171,34 @@ func TestResourceHandler_CreateResource(t *testing.T) {
 			expectedCode: http.StatusBadRequest,
 			expectedErr:  "INVALID_TYPE",
 		},
+		{
+			name: "Requires approval",
+			body: `{
+				"name": "Executive Boardroom",
+				"type": "MEETING_ROOM",
+				"capacity": 20,
+				"requires_approval": true,
+				"approver_group_id": "4f1c1b2e-8a4d-4e7b-9a55-2f0f6c3d9b10"
+			}`,
+			setupMock: func(m *MockResourceRepository) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(r *domain.Resource) bool {
+					return r.RequiresApproval && r.ApproverGroupID != nil
+				})).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Requires approval without approver group",
+			body: `{
+				"name": "Executive Boardroom",
+				"type": "MEETING_ROOM",
+				"capacity": 20,
+				"requires_approval": true
+			}`,
+			setupMock:    func(m *MockResourceRepository) {},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "INVALID_APPROVER_GROUP",
+		},
 	}
 
 	for _, tt := range tests {
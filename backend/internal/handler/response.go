@@ -47,3 +47,20 @@ func WriteError(w http.ResponseWriter, statusCode int, code, message string) {
 
 	json.NewEncoder(w).Encode(response)
 }
+
+// WriteErrorWithData は追加情報を含むエラーレスポンスを書き込みます
+func WriteErrorWithData(w http.ResponseWriter, statusCode int, code, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	response := APIResponse{
+		Success: false,
+		Data:    data,
+		Error: &APIError{
+			Code:    code,
+			Message: message,
+		},
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
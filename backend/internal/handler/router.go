@@ -20,11 +20,21 @@ func NewRouter(
 	authService *service.AuthService,
 	reservationService *service.ReservationService,
 	approvalService *service.ApprovalService,
+	waitlistService *service.WaitlistService,
+	equipmentService *service.EquipmentService,
+	workspaceService *service.WorkspaceService,
+	holdService *service.HoldService,
+	bumpService *service.BumpService,
+	quotaService *service.QuotaService,
+	penaltyService *service.PenaltyService,
+	provisioningService *service.ProvisioningService,
+	apiTokenService *service.APITokenService,
 	userRepo repository.UserRepository,
 	resourceRepo repository.ResourceRepository,
+	scimBearerToken string,
 ) *Router {
 	r := mux.NewRouter()
-	mw := NewMiddleware(authService)
+	mw := NewMiddleware(authService, apiTokenService)
 
 	router := &Router{
 		router: r,
@@ -44,14 +54,56 @@ func NewRouter(
 	authHandler := NewAuthHandler(authService)
 	authHandler.RegisterRoutes(r)
 
+	// SCIMエンドポイント（セッションではなくIdPのコネクターのBearerトークンで認証）
+	if scimBearerToken != "" {
+		scimHandler := NewSCIMHandler(provisioningService, scimBearerToken)
+		scimHandler.RegisterRoutes(r)
+	}
+
 	// 認証が必要なルート
-	protected := r.PathPrefix("/api/v1").Subrouter()
+	// 各ハンドラーは /api/v1 から始まる完全なパスで登録するため、サブルーターにはパスの接頭辞を付けない
+	protected := r.NewRoute().Subrouter()
 	protected.Use(mw.Authentication)
 	protected.Use(mw.CSRF)
 
+	authHandler.RegisterProtectedRoutes(protected)
+
 	reservationHandler := NewReservationHandler(reservationService, approvalService)
 	reservationHandler.RegisterRoutes(protected)
 
+	approvalHandler := NewApprovalHandler(approvalService)
+	approvalHandler.RegisterRoutes(protected)
+
+	waitlistHandler := NewWaitlistHandler(waitlistService)
+	waitlistHandler.RegisterRoutes(protected)
+
+	equipmentHandler := NewEquipmentHandler(equipmentService)
+	equipmentHandler.RegisterRoutes(protected)
+
+	workspaceHandler := NewWorkspaceHandler(workspaceService)
+	workspaceHandler.RegisterRoutes(protected)
+
+	holdHandler := NewHoldHandler(holdService)
+	holdHandler.RegisterRoutes(protected)
+
+	bumpHandler := NewBumpHandler(bumpService)
+	bumpHandler.RegisterRoutes(protected)
+
+	quotaHandler := NewQuotaHandler(quotaService)
+	quotaHandler.RegisterRoutes(protected)
+
+	penaltyHandler := NewPenaltyHandler(penaltyService)
+	penaltyHandler.RegisterRoutes(protected)
+
+	identityHandler := NewIdentityHandler(authService)
+	identityHandler.RegisterRoutes(protected)
+
+	sessionHandler := NewSessionHandler(authService)
+	sessionHandler.RegisterRoutes(protected)
+
+	apiTokenHandler := NewAPITokenHandler(apiTokenService)
+	apiTokenHandler.RegisterRoutes(protected)
+
 	resourceHandler := NewResourceHandler(resourceRepo)
 	resourceHandler.RegisterRoutes(protected)
 
@@ -0,0 +1,115 @@
+// backend/internal/handler/router_test.go
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestRouter_Logout(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setup       func(req *http.Request)
+		wantStatus  int
+		wantRevoked bool
+	}{
+		{
+			name:        "Bearer session",
+			setup:       func(req *http.Request) { req.Header.Set("Authorization", "Bearer session-id") },
+			wantStatus:  http.StatusOK,
+			wantRevoked: true,
+		},
+		{
+			name: "Cookie session with CSRF token",
+			setup: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: handler.SessionCookieName, Value: "session-id"})
+				req.Header.Set("X-CSRF-Token", "csrf-token")
+			},
+			wantStatus:  http.StatusOK,
+			wantRevoked: true,
+		},
+		{
+			name: "Cookie session without CSRF token",
+			setup: func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: handler.SessionCookieName, Value: "session-id"})
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Not authenticated",
+			setup:      func(req *http.Request) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := service.NewMemorySessionStore()
+			require.NoError(t, store.SaveSession(ctx, "session-id", &service.Session{
+				ID:        "session-id",
+				UserID:    userID,
+				CSRFToken: "csrf-token",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}))
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockAuditLogRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+				return l.Action == domain.AuditActionLogout && l.UserID == userID
+			})).Return(nil)
+
+			authService := service.NewAuthService(nil, nil, mockAuditLogRepo, nil, store, service.RoleMapping{}, 0)
+			router := handler.NewRouter(authService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "").GetRouter()
+
+			req := httptest.NewRequest("POST", "/api/v1/auth/logout", nil)
+			tt.setup(req)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			_, err := store.GetSession(ctx, "session-id")
+			if tt.wantRevoked {
+				assert.Error(t, err)
+				mockAuditLogRepo.AssertExpectations(t)
+			} else {
+				assert.NoError(t, err)
+				mockAuditLogRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRouter_ProtectedRoutesUseFullPath(t *testing.T) {
+	ctx := context.Background()
+	store := service.NewMemorySessionStore()
+	require.NoError(t, store.SaveSession(ctx, "session-id", &service.Session{
+		ID:        "session-id",
+		UserID:    uuid.New(),
+		CSRFToken: "csrf-token",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	authService := service.NewAuthService(nil, nil, new(MockAuditLogRepository), nil, store, service.RoleMapping{}, 0)
+	router := handler.NewRouter(authService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "").GetRouter()
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/csrf", nil)
+	req.Header.Set("Authorization", "Bearer session-id")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "csrf-token")
+}
@@ -0,0 +1,646 @@
+// backend/internal/handler/scim_handler.go
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+	"github.com/your-org/esms/pkg/scim"
+)
+
+const (
+	// scimDefaultCount は count を省略した場合の1ページの件数
+	scimDefaultCount = 100
+	// scimMaxCount は1ページの最大件数
+	scimMaxCount = 200
+)
+
+// ProvisioningServiceInterface はSCIMによるユーザー・グループのプロビジョニングを扱うサービスのインターフェース
+type ProvisioningServiceInterface interface {
+	ListUsers(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	ListUserGroups(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error)
+	ListGroups(ctx context.Context, filter repository.ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error)
+	GetGroup(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error)
+	CreateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error)
+	UpdateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error)
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+}
+
+// SCIMHandler はIdPのコネクターからのSCIM 2.0のリクエストを処理するHTTPハンドラー
+// セッションではなく、IdPに登録したBearerトークンで認証します
+type SCIMHandler struct {
+	provisioningService ProvisioningServiceInterface
+	tokenHash           [sha256.Size]byte
+}
+
+// NewSCIMHandler は新しいSCIMHandlerを作成します
+// bearerToken はIdPのコネクターに設定する共有のトークンです
+func NewSCIMHandler(provisioningService ProvisioningServiceInterface, bearerToken string) *SCIMHandler {
+	return &SCIMHandler{
+		provisioningService: provisioningService,
+		tokenHash:           sha256.Sum256([]byte(bearerToken)),
+	}
+}
+
+// RegisterRoutes はルートを登録します（/api/v1 の認証・CSRF検証は適用しません）
+func (h *SCIMHandler) RegisterRoutes(r *mux.Router) {
+	s := r.PathPrefix("/scim/v2").Subrouter()
+	s.Use(h.Authenticate)
+
+	s.HandleFunc("/ServiceProviderConfig", h.ServiceProviderConfig).Methods("GET")
+
+	s.HandleFunc("/Users", h.ListUsers).Methods("GET")
+	s.HandleFunc("/Users", h.CreateUser).Methods("POST")
+	s.HandleFunc("/Users/{id}", h.GetUser).Methods("GET")
+	s.HandleFunc("/Users/{id}", h.ReplaceUser).Methods("PUT")
+	s.HandleFunc("/Users/{id}", h.PatchUser).Methods("PATCH")
+	s.HandleFunc("/Users/{id}", h.DeleteUser).Methods("DELETE")
+
+	s.HandleFunc("/Groups", h.ListGroups).Methods("GET")
+	s.HandleFunc("/Groups", h.CreateGroup).Methods("POST")
+	s.HandleFunc("/Groups/{id}", h.GetGroup).Methods("GET")
+	s.HandleFunc("/Groups/{id}", h.ReplaceGroup).Methods("PUT")
+	s.HandleFunc("/Groups/{id}", h.PatchGroup).Methods("PATCH")
+	s.HandleFunc("/Groups/{id}", h.DeleteGroup).Methods("DELETE")
+}
+
+// Authenticate はIdPのコネクターのBearerトークンを検証するミドルウェア
+// トークンの長さによる処理時間の差が出ないよう、ハッシュ値を定数時間で比較します
+func (h *SCIMHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		hash := sha256.Sum256([]byte(token))
+		if !ok || token == "" || subtle.ConstantTimeCompare(hash[:], h.tokenHash[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			writeSCIMError(w, http.StatusUnauthorized, "", "Invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ServiceProviderConfig は対応している機能を返します（RFC 7643 5）
+func (h *SCIMHandler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	unsupported := map[string]bool{"supported": false}
+	writeSCIM(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxCount},
+		"changePassword": unsupported,
+		"sort":           unsupported,
+		"etag":           unsupported,
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication scheme using a bearer token issued for the IdP connector",
+			"primary":     true,
+		}},
+	})
+}
+
+// ListUsers はユーザーを検索します（filter は userName・externalId・emails.value の eq に対応）
+func (h *SCIMHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := parsePagination(r)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	var filter repository.UserFilter
+	if expression := r.URL.Query().Get("filter"); expression != "" {
+		parsed, err := scim.ParseFilter(expression)
+		if err != nil {
+			writeSCIMServiceError(w, err)
+			return
+		}
+		for _, condition := range parsed {
+			value := condition.Value
+			switch strings.ToLower(condition.Attribute) {
+			case "username", "emails.value", "emails":
+				filter.Email = &value
+			case "externalid":
+				filter.ExternalID = &value
+			default:
+				writeSCIMError(w, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("Filtering by %s is not supported", condition.Attribute))
+				return
+			}
+		}
+	}
+
+	users, total, err := h.provisioningService.ListUsers(r.Context(), filter, startIndex-1, count)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	// 一覧では所属グループを返さない（個別の取得で返す）
+	resources := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, toSCIMUser(r, user, nil))
+	}
+	writeSCIM(w, http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+// GetUser はユーザーを取得します
+func (h *SCIMHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	h.writeUser(w, r, http.StatusOK, user)
+}
+
+// CreateUser はユーザーを作成します
+func (h *SCIMHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var resource scim.User
+	if err := decodeSCIM(r, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	user := &domain.User{}
+	if err := applySCIMUser(user, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	created, err := h.provisioningService.CreateUser(r.Context(), user)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.writeUser(w, r, http.StatusCreated, created)
+}
+
+// ReplaceUser はユーザーの属性を置き換えます（PUT）
+func (h *SCIMHandler) ReplaceUser(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	var resource scim.User
+	if err := decodeSCIM(r, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.updateUser(w, r, current, &resource)
+}
+
+// PatchUser はユーザーの属性を部分的に更新します（PATCH）
+func (h *SCIMHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.loadUser(w, r)
+	if !ok {
+		return
+	}
+	var request scim.PatchRequest
+	if err := decodeSCIM(r, &request); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	resource := toSCIMUser(r, current, nil)
+	if err := resource.ApplyPatch(request.Operations); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.updateUser(w, r, current, resource)
+}
+
+// DeleteUser はユーザーを削除します（論理削除）
+func (h *SCIMHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.provisioningService.DeleteUser(r.Context(), id); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateUser はリソースの内容でユーザーを更新してレスポンスを書き込みます
+func (h *SCIMHandler) updateUser(w http.ResponseWriter, r *http.Request, current *domain.User, resource *scim.User) {
+	user := *current
+	if err := applySCIMUser(&user, resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	updated, err := h.provisioningService.UpdateUser(r.Context(), &user)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.writeUser(w, r, http.StatusOK, updated)
+}
+
+// loadUser はパスのIDのユーザーを取得します（見つからない場合はエラーを書き込みます）
+func (h *SCIMHandler) loadUser(w http.ResponseWriter, r *http.Request) (*domain.User, bool) {
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return nil, false
+	}
+	user, err := h.provisioningService.GetUser(r.Context(), id)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return nil, false
+	}
+	return user, true
+}
+
+// writeUser は所属グループを含めたユーザーのリソースを書き込みます
+func (h *SCIMHandler) writeUser(w http.ResponseWriter, r *http.Request, status int, user *domain.User) {
+	groups, err := h.provisioningService.ListUserGroups(r.Context(), user.ID)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	resource := toSCIMUser(r, user, groups)
+	w.Header().Set("Location", resource.Meta.Location)
+	writeSCIM(w, status, resource)
+}
+
+// ListGroups はグループを検索します（filter は displayName・externalId の eq に対応）
+// excludedAttributes=members の場合はメンバーを返しません
+func (h *SCIMHandler) ListGroups(w http.ResponseWriter, r *http.Request) {
+	startIndex, count, err := parsePagination(r)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	var filter repository.ProvisionedGroupFilter
+	if expression := r.URL.Query().Get("filter"); expression != "" {
+		parsed, err := scim.ParseFilter(expression)
+		if err != nil {
+			writeSCIMServiceError(w, err)
+			return
+		}
+		for _, condition := range parsed {
+			value := condition.Value
+			switch strings.ToLower(condition.Attribute) {
+			case "displayname":
+				filter.DisplayName = &value
+			case "externalid":
+				filter.ExternalID = &value
+			default:
+				writeSCIMError(w, http.StatusBadRequest, scim.ErrorTypeInvalidFilter, fmt.Sprintf("Filtering by %s is not supported", condition.Attribute))
+				return
+			}
+		}
+	}
+
+	groups, total, err := h.provisioningService.ListGroups(r.Context(), filter, startIndex-1, count)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	excludeMembers := excludesAttribute(r, "members")
+	resources := make([]interface{}, 0, len(groups))
+	for _, group := range groups {
+		resource := toSCIMGroup(r, group)
+		if excludeMembers {
+			resource.Members = nil
+		}
+		resources = append(resources, resource)
+	}
+	writeSCIM(w, http.StatusOK, scim.NewListResponse(resources, total, startIndex))
+}
+
+// GetGroup はグループを取得します
+func (h *SCIMHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	group, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	resource := toSCIMGroup(r, group)
+	if excludesAttribute(r, "members") {
+		resource.Members = nil
+	}
+	writeSCIM(w, http.StatusOK, resource)
+}
+
+// CreateGroup はグループを作成します
+func (h *SCIMHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var resource scim.Group
+	if err := decodeSCIM(r, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	group := &domain.ProvisionedGroup{}
+	if err := applySCIMGroup(group, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	created, err := h.provisioningService.CreateGroup(r.Context(), group)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusCreated, created)
+}
+
+// ReplaceGroup はグループの属性とメンバーを置き換えます（PUT）
+func (h *SCIMHandler) ReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	var resource scim.Group
+	if err := decodeSCIM(r, &resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.updateGroup(w, r, current, &resource)
+}
+
+// PatchGroup はグループの属性・メンバーを部分的に更新します（PATCH）
+func (h *SCIMHandler) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.loadGroup(w, r)
+	if !ok {
+		return
+	}
+	var request scim.PatchRequest
+	if err := decodeSCIM(r, &request); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+
+	resource := toSCIMGroup(r, current)
+	if err := resource.ApplyPatch(request.Operations); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.updateGroup(w, r, current, resource)
+}
+
+// DeleteGroup はグループを削除します
+func (h *SCIMHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.provisioningService.DeleteGroup(r.Context(), id); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateGroup はリソースの内容でグループを更新してレスポンスを書き込みます
+func (h *SCIMHandler) updateGroup(w http.ResponseWriter, r *http.Request, current *domain.ProvisionedGroup, resource *scim.Group) {
+	group := *current
+	if err := applySCIMGroup(&group, resource); err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	updated, err := h.provisioningService.UpdateGroup(r.Context(), &group)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return
+	}
+	h.writeGroup(w, r, http.StatusOK, updated)
+}
+
+// loadGroup はパスのIDのグループを取得します（見つからない場合はエラーを書き込みます）
+func (h *SCIMHandler) loadGroup(w http.ResponseWriter, r *http.Request) (*domain.ProvisionedGroup, bool) {
+	id, ok := scimResourceID(w, r)
+	if !ok {
+		return nil, false
+	}
+	group, err := h.provisioningService.GetGroup(r.Context(), id)
+	if err != nil {
+		writeSCIMServiceError(w, err)
+		return nil, false
+	}
+	return group, true
+}
+
+// writeGroup はグループのリソースを書き込みます
+func (h *SCIMHandler) writeGroup(w http.ResponseWriter, r *http.Request, status int, group *domain.ProvisionedGroup) {
+	resource := toSCIMGroup(r, group)
+	w.Header().Set("Location", resource.Meta.Location)
+	writeSCIM(w, status, resource)
+}
+
+// toSCIMUser はユーザーをSCIMのリソースに変換します
+func toSCIMUser(r *http.Request, user *domain.User, groups []*domain.ProvisionedGroup) *scim.User {
+	active := user.IsActive
+	created, lastModified := user.CreatedAt, user.UpdatedAt
+	resource := &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          user.ID.String(),
+		ExternalID:  user.ExternalID,
+		UserName:    user.Email,
+		Name:        &scim.Name{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []scim.Email{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &scim.Meta{
+			ResourceType: scim.ResourceTypeUser,
+			Created:      &created,
+			LastModified: &lastModified,
+			Location:     scimLocation(r, "Users", user.ID),
+		},
+	}
+	if user.Department != nil || user.ManagerID != nil {
+		resource.Schemas = append(resource.Schemas, scim.SchemaEnterpriseUser)
+		resource.Enterprise = &scim.EnterpriseUser{}
+		if user.Department != nil {
+			resource.Enterprise.Department = *user.Department
+		}
+		if user.ManagerID != nil {
+			resource.Enterprise.Manager = &scim.Reference{
+				Value: user.ManagerID.String(),
+				Ref:   scimLocation(r, "Users", *user.ManagerID),
+			}
+		}
+	}
+	for _, group := range groups {
+		resource.Groups = append(resource.Groups, scim.Reference{
+			Value:   group.ID.String(),
+			Ref:     scimLocation(r, "Groups", group.ID),
+			Display: group.DisplayName,
+		})
+	}
+	return resource
+}
+
+// applySCIMUser はSCIMのリソースの属性をユーザーに反映します
+// userName がメールアドレスでない場合は emails の主たるメールアドレスを使用します
+func applySCIMUser(user *domain.User, resource *scim.User) error {
+	user.Email = resource.UserName
+	if !strings.Contains(user.Email, "@") {
+		user.Email = resource.PrimaryEmail()
+	}
+	user.Name = resource.FormattedName()
+	user.ExternalID = resource.ExternalID
+	user.IsActive = resource.IsActive()
+	user.Department = nil
+	user.ManagerID = nil
+
+	if resource.Enterprise != nil {
+		if department := resource.Enterprise.Department; department != "" {
+			user.Department = &department
+		}
+		if resource.Enterprise.Manager != nil && resource.Enterprise.Manager.Value != "" {
+			managerID, err := uuid.Parse(resource.Enterprise.Manager.Value)
+			if err != nil {
+				return fmt.Errorf("%w: manager %q is not a user id", scim.ErrInvalidValue, resource.Enterprise.Manager.Value)
+			}
+			user.ManagerID = &managerID
+		}
+	}
+	return nil
+}
+
+// toSCIMGroup はグループをSCIMのリソースに変換します
+func toSCIMGroup(r *http.Request, group *domain.ProvisionedGroup) *scim.Group {
+	created, lastModified := group.CreatedAt, group.UpdatedAt
+	resource := &scim.Group{
+		Schemas:     []string{scim.SchemaGroup},
+		ID:          group.ID.String(),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []scim.Reference{},
+		Meta: &scim.Meta{
+			ResourceType: scim.ResourceTypeGroup,
+			Created:      &created,
+			LastModified: &lastModified,
+			Location:     scimLocation(r, "Groups", group.ID),
+		},
+	}
+	for _, memberID := range group.MemberIDs {
+		resource.Members = append(resource.Members, scim.Reference{
+			Value: memberID.String(),
+			Ref:   scimLocation(r, "Users", memberID),
+		})
+	}
+	return resource
+}
+
+// applySCIMGroup はSCIMのリソースの属性とメンバーをグループに反映します
+func applySCIMGroup(group *domain.ProvisionedGroup, resource *scim.Group) error {
+	group.DisplayName = resource.DisplayName
+	group.ExternalID = resource.ExternalID
+	group.MemberIDs = []uuid.UUID{}
+	for _, member := range resource.Members {
+		memberID, err := uuid.Parse(member.Value)
+		if err != nil {
+			return fmt.Errorf("%w: member %q is not a user id", scim.ErrInvalidValue, member.Value)
+		}
+		group.MemberIDs = append(group.MemberIDs, memberID)
+	}
+	return nil
+}
+
+// scimLocation はリソースのURLを返します
+func scimLocation(r *http.Request, resourceType string, id uuid.UUID) string {
+	scheme := "https"
+	if r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/scim/v2/%s/%s", scheme, r.Host, resourceType, id)
+}
+
+// scimResourceID はパスのリソースIDを読み取ります（不正な場合は 404 を書き込みます）
+func scimResourceID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeSCIMError(w, http.StatusNotFound, "", "Resource not found")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parsePagination は startIndex（1始まり）と count を読み取ります
+func parsePagination(r *http.Request) (int, int, error) {
+	startIndex, count := 1, scimDefaultCount
+	query := r.URL.Query()
+	if v := query.Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: startIndex must be an integer", scim.ErrInvalidValue)
+		}
+		if n > 1 {
+			startIndex = n
+		}
+	}
+	if v := query.Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: count must be an integer", scim.ErrInvalidValue)
+		}
+		count = min(max(n, 0), scimMaxCount)
+	}
+	return startIndex, count, nil
+}
+
+// excludesAttribute は excludedAttributes に指定した属性が含まれるかを判定します
+func excludesAttribute(r *http.Request, attribute string) bool {
+	for _, excluded := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(excluded), attribute) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSCIM はリクエストの本文を読み取ります
+func decodeSCIM(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", scim.ErrInvalidSyntax, err)
+	}
+	return nil
+}
+
+// writeSCIM はSCIMのレスポンスを書き込みます
+func writeSCIM(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", scim.ContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeSCIMError はSCIMのエラーレスポンス（RFC 7644 3.12）を書き込みます
+func writeSCIMError(w http.ResponseWriter, status int, scimType, detail string) {
+	writeSCIM(w, status, &scim.Error{
+		Schemas:  []string{scim.SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+// writeSCIMServiceError はサービス・リクエストの解析のエラーをSCIMのエラーレスポンスに変換します
+func writeSCIMServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		writeSCIMError(w, http.StatusNotFound, "", "Resource not found")
+	case errors.Is(err, service.ErrProvisioningConflict):
+		writeSCIMError(w, http.StatusConflict, scim.ErrorTypeUniqueness, err.Error())
+	case errors.Is(err, service.ErrInvalidProvisioning):
+		writeSCIMError(w, http.StatusBadRequest, scim.ErrorTypeInvalidValue, err.Error())
+	case scim.ErrorType(err) != "":
+		writeSCIMError(w, http.StatusBadRequest, scim.ErrorType(err), err.Error())
+	default:
+		writeSCIMError(w, http.StatusInternalServerError, "", "Internal server error")
+	}
+}
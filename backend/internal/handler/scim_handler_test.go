@@ -0,0 +1,439 @@
+// backend/internal/handler/scim_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+	"github.com/your-org/esms/pkg/scim"
+)
+
+const scimTestToken = "scim-test-token"
+
+var (
+	scimUserID    = uuid.MustParse("b5a1c3f0-0d4e-4f6a-9b7c-2d8e1f3a4b5c")
+	scimManagerID = uuid.MustParse("7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f")
+	scimGroupID   = uuid.MustParse("c2d3e4f5-a6b7-4c8d-9e0f-1a2b3c4d5e6f")
+	scimAlice     = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	scimBob       = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	scimCarol     = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+)
+
+// newSCIMRouter はSCIMのルートを登録したルーターを作成します
+func newSCIMRouter(svc *MockProvisioningService) *mux.Router {
+	r := mux.NewRouter()
+	handler.NewSCIMHandler(svc, scimTestToken).RegisterRoutes(r)
+	return r
+}
+
+// scimRequest は記録したIdPのリクエスト（testdata/scim）を再生するリクエストを作成します
+func scimRequest(t *testing.T, method, path, fixture string) *http.Request {
+	t.Helper()
+	var body []byte
+	if fixture != "" {
+		var err error
+		body, err = os.ReadFile(filepath.Join("testdata", "scim", fixture))
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", scim.ContentType)
+	req.Header.Set("Authorization", "Bearer "+scimTestToken)
+	return req
+}
+
+func scimTestUser() *domain.User {
+	return &domain.User{
+		ID:       scimUserID,
+		Email:    "taro.yamada@example.com",
+		Name:     "山田 太郎",
+		Role:     domain.RoleGeneral,
+		IsActive: true,
+	}
+}
+
+func scimTestGroup() *domain.ProvisionedGroup {
+	return &domain.ProvisionedGroup{
+		ID:          scimGroupID,
+		DisplayName: "esms-managers",
+		MemberIDs:   []uuid.UUID{scimAlice, scimBob},
+	}
+}
+
+func TestSCIMHandler_Authenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "missing token", authorization: ""},
+		{name: "wrong token", authorization: "Bearer wrong-token"},
+		{name: "wrong scheme", authorization: "Basic " + scimTestToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockProvisioningService)
+			req := httptest.NewRequest("GET", "/scim/v2/Users", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			newSCIMRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, scim.ContentType, w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+			svc.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSCIMHandler_CreateUser(t *testing.T) {
+	svc := new(MockProvisioningService)
+	svc.On("CreateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == "taro.yamada@example.com" &&
+			u.Name == "山田 太郎" &&
+			u.ExternalID == "3f8a2c1e-9b7d-4e6f-a5c4-1d2e3f4a5b6c" &&
+			u.IsActive &&
+			u.Department != nil && *u.Department == "営業部" &&
+			u.ManagerID != nil && *u.ManagerID == scimManagerID
+	})).Return(func() *domain.User {
+		user := scimTestUser()
+		department := "営業部"
+		user.Department = &department
+		user.ManagerID = &scimManagerID
+		return user
+	}(), nil)
+	svc.On("ListUserGroups", mock.Anything, scimUserID).Return([]*domain.ProvisionedGroup{}, nil)
+
+	w := httptest.NewRecorder()
+	newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "POST", "/scim/v2/Users", "entra_create_user.json"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "http://example.com/scim/v2/Users/"+scimUserID.String(), w.Header().Get("Location"))
+
+	var resource scim.User
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resource))
+	assert.Equal(t, scimUserID.String(), resource.ID)
+	assert.Equal(t, "taro.yamada@example.com", resource.UserName)
+	assert.Equal(t, "営業部", resource.Enterprise.Department)
+	assert.Equal(t, scimManagerID.String(), resource.Enterprise.Manager.Value)
+	svc.AssertExpectations(t)
+}
+
+func TestSCIMHandler_CreateUser_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		serviceErr   error
+		expectedCode int
+		expectedType string
+	}{
+		{
+			name:         "Invalid JSON",
+			body:         `{"userName":`,
+			expectedCode: http.StatusBadRequest,
+			expectedType: scim.ErrorTypeInvalidSyntax,
+		},
+		{
+			name:         "Manager is not a user id",
+			body:         `{"userName":"taro@example.com","urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"manager":{"value":"E0001"}}}`,
+			expectedCode: http.StatusBadRequest,
+			expectedType: scim.ErrorTypeInvalidValue,
+		},
+		{
+			name:         "Duplicate user",
+			body:         `{"userName":"taro@example.com"}`,
+			serviceErr:   service.ErrProvisioningConflict,
+			expectedCode: http.StatusConflict,
+			expectedType: scim.ErrorTypeUniqueness,
+		},
+		{
+			name:         "Missing email",
+			body:         `{"userName":"taro"}`,
+			serviceErr:   service.ErrInvalidProvisioning,
+			expectedCode: http.StatusBadRequest,
+			expectedType: scim.ErrorTypeInvalidValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockProvisioningService)
+			if tt.serviceErr != nil {
+				svc.On("CreateUser", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest("POST", "/scim/v2/Users", bytes.NewBufferString(tt.body))
+			req.Header.Set("Authorization", "Bearer "+scimTestToken)
+			w := httptest.NewRecorder()
+			newSCIMRouter(svc).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			var resource scim.Error
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resource))
+			assert.Equal(t, tt.expectedType, resource.ScimType)
+		})
+	}
+}
+
+func TestSCIMHandler_UpdateUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		fixture string
+		want    func(t *testing.T, u *domain.User)
+	}{
+		{
+			name:    "Entra ID deactivation",
+			method:  "PATCH",
+			fixture: "entra_deactivate_user.json",
+			want: func(t *testing.T, u *domain.User) {
+				assert.False(t, u.IsActive)
+				assert.Equal(t, "taro.yamada@example.com", u.Email)
+			},
+		},
+		{
+			name:    "Okta deactivation",
+			method:  "PATCH",
+			fixture: "okta_deactivate_user.json",
+			want: func(t *testing.T, u *domain.User) {
+				assert.False(t, u.IsActive)
+			},
+		},
+		{
+			name:    "Entra ID attribute update",
+			method:  "PATCH",
+			fixture: "entra_update_user.json",
+			want: func(t *testing.T, u *domain.User) {
+				assert.True(t, u.IsActive)
+				assert.Equal(t, "taro.sato@example.com", u.Email)
+				assert.Equal(t, "佐藤 太郎", u.Name)
+				require.NotNil(t, u.Department)
+				assert.Equal(t, "経理部", *u.Department)
+			},
+		},
+		{
+			name:    "Okta replace",
+			method:  "PUT",
+			fixture: "okta_replace_user.json",
+			want: func(t *testing.T, u *domain.User) {
+				assert.True(t, u.IsActive)
+				assert.Equal(t, "Yamada Taro", u.Name)
+				assert.Equal(t, domain.RoleGeneral, u.Role)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockProvisioningService)
+			svc.On("GetUser", mock.Anything, scimUserID).Return(scimTestUser(), nil)
+			var updated *domain.User
+			svc.On("UpdateUser", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*domain.User)
+			}).Return(scimTestUser(), nil)
+			svc.On("ListUserGroups", mock.Anything, scimUserID).Return([]*domain.ProvisionedGroup{scimTestGroup()}, nil)
+
+			w := httptest.NewRecorder()
+			newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, tt.method, "/scim/v2/Users/"+scimUserID.String(), tt.fixture))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			require.NotNil(t, updated)
+			assert.Equal(t, scimUserID, updated.ID)
+			tt.want(t, updated)
+
+			var resource scim.User
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resource))
+			require.Len(t, resource.Groups, 1)
+			assert.Equal(t, "esms-managers", resource.Groups[0].Display)
+		})
+	}
+}
+
+func TestSCIMHandler_GetUser_NotFound(t *testing.T) {
+	svc := new(MockProvisioningService)
+	svc.On("GetUser", mock.Anything, scimUserID).Return(nil, repository.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "GET", "/scim/v2/Users/"+scimUserID.String(), ""))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), scim.SchemaError)
+}
+
+func TestSCIMHandler_DeleteUser(t *testing.T) {
+	svc := new(MockProvisioningService)
+	svc.On("DeleteUser", mock.Anything, scimUserID).Return(nil)
+
+	w := httptest.NewRecorder()
+	newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "DELETE", "/scim/v2/Users/"+scimUserID.String(), ""))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestSCIMHandler_ListUsers(t *testing.T) {
+	email := "taro.yamada@example.com"
+	externalID := "00u1ab2cd3"
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter repository.UserFilter
+		expectedOffset int
+		expectedLimit  int
+		expectedCode   int
+	}{
+		{
+			name:           "Filter by userName",
+			query:          `?filter=userName+eq+%22taro.yamada%40example.com%22`,
+			expectedFilter: repository.UserFilter{Email: &email},
+			expectedLimit:  100,
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:           "Filter by externalId with pagination",
+			query:          `?filter=externalId+eq+%2200u1ab2cd3%22&startIndex=11&count=10`,
+			expectedFilter: repository.UserFilter{ExternalID: &externalID},
+			expectedOffset: 10,
+			expectedLimit:  10,
+			expectedCode:   http.StatusOK,
+		},
+		{
+			name:         "Unsupported attribute",
+			query:        `?filter=title+eq+%22Manager%22`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unsupported operator",
+			query:        `?filter=userName+sw+%22taro%22`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockProvisioningService)
+			svc.On("ListUsers", mock.Anything, tt.expectedFilter, tt.expectedOffset, tt.expectedLimit).
+				Return([]*domain.User{scimTestUser()}, int64(1), nil)
+
+			w := httptest.NewRecorder()
+			newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "GET", "/scim/v2/Users"+tt.query, ""))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				assert.Contains(t, w.Body.String(), scim.ErrorTypeInvalidFilter)
+				return
+			}
+
+			var list struct {
+				TotalResults int64       `json:"totalResults"`
+				StartIndex   int         `json:"startIndex"`
+				Resources    []scim.User `json:"Resources"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+			assert.Equal(t, int64(1), list.TotalResults)
+			assert.Equal(t, tt.expectedOffset+1, list.StartIndex)
+			require.Len(t, list.Resources, 1)
+			assert.Equal(t, "taro.yamada@example.com", list.Resources[0].UserName)
+		})
+	}
+}
+
+func TestSCIMHandler_CreateGroup(t *testing.T) {
+	svc := new(MockProvisioningService)
+	svc.On("CreateGroup", mock.Anything, mock.MatchedBy(func(g *domain.ProvisionedGroup) bool {
+		return g.DisplayName == "esms-managers" &&
+			g.ExternalID == "8c1d2e3f-4a5b-4c6d-9e7f-0a1b2c3d4e5f" &&
+			len(g.MemberIDs) == 0
+	})).Return(&domain.ProvisionedGroup{ID: scimGroupID, DisplayName: "esms-managers", MemberIDs: []uuid.UUID{}}, nil)
+
+	w := httptest.NewRecorder()
+	newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "POST", "/scim/v2/Groups", "entra_create_group.json"))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "http://example.com/scim/v2/Groups/"+scimGroupID.String(), w.Header().Get("Location"))
+	svc.AssertExpectations(t)
+}
+
+func TestSCIMHandler_PatchGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		wantName    string
+		wantMembers []uuid.UUID
+	}{
+		{
+			name:        "Entra ID add member",
+			fixture:     "entra_add_member.json",
+			wantName:    "esms-managers",
+			wantMembers: []uuid.UUID{scimAlice, scimBob, scimCarol},
+		},
+		{
+			name:        "Entra ID remove member",
+			fixture:     "entra_remove_member.json",
+			wantName:    "esms-managers",
+			wantMembers: []uuid.UUID{scimBob},
+		},
+		{
+			name:        "Okta remove member",
+			fixture:     "okta_remove_member.json",
+			wantName:    "esms-managers",
+			wantMembers: []uuid.UUID{scimAlice},
+		},
+		{
+			name:        "Okta rename",
+			fixture:     "okta_rename_group.json",
+			wantName:    "esms-admins",
+			wantMembers: []uuid.UUID{scimAlice, scimBob},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := new(MockProvisioningService)
+			svc.On("GetGroup", mock.Anything, scimGroupID).Return(scimTestGroup(), nil)
+			var updated *domain.ProvisionedGroup
+			svc.On("UpdateGroup", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*domain.ProvisionedGroup)
+			}).Return(scimTestGroup(), nil)
+
+			w := httptest.NewRecorder()
+			newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "PATCH", "/scim/v2/Groups/"+scimGroupID.String(), tt.fixture))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			require.NotNil(t, updated)
+			assert.Equal(t, scimGroupID, updated.ID)
+			assert.Equal(t, tt.wantName, updated.DisplayName)
+			assert.Equal(t, tt.wantMembers, updated.MemberIDs)
+		})
+	}
+}
+
+func TestSCIMHandler_ListGroups_ExcludeMembers(t *testing.T) {
+	name := "esms-managers"
+	svc := new(MockProvisioningService)
+	svc.On("ListGroups", mock.Anything, repository.ProvisionedGroupFilter{DisplayName: &name}, 0, 100).
+		Return([]*domain.ProvisionedGroup{scimTestGroup()}, int64(1), nil)
+
+	w := httptest.NewRecorder()
+	newSCIMRouter(svc).ServeHTTP(w, scimRequest(t, "GET", `/scim/v2/Groups?filter=displayName+eq+%22esms-managers%22&excludedAttributes=members`, ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "esms-managers")
+	assert.NotContains(t, w.Body.String(), scimAlice.String())
+}
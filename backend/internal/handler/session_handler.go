@@ -0,0 +1,187 @@
+// backend/internal/handler/session_handler.go
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+// SessionServiceInterface はログインセッションの一覧・無効化を扱うサービスのインターフェース
+type SessionServiceInterface interface {
+	ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*service.SessionInfo, error)
+	RevokeSession(ctx context.Context, actorID, userID uuid.UUID, sessionInfoID string) error
+	RevokeUserSessions(ctx context.Context, actorID, userID uuid.UUID) (int, error)
+}
+
+// SessionHandler はログインセッションの管理のHTTPハンドラー
+// 利用者は自分のセッションを、管理者は全てのユーザーのセッションを参照・無効化できます
+type SessionHandler struct {
+	sessionService SessionServiceInterface
+}
+
+// NewSessionHandler は新しいSessionHandlerを作成します
+func NewSessionHandler(sessionService SessionServiceInterface) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *SessionHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/sessions", h.ListMySessions).Methods("GET")
+	r.HandleFunc("/api/v1/sessions", h.RevokeMySessions).Methods("DELETE")
+	r.HandleFunc("/api/v1/sessions/{session_id}", h.RevokeMySession).Methods("DELETE")
+
+	r.HandleFunc("/api/v1/users/{id}/sessions", h.ListUserSessions).Methods("GET")
+	r.HandleFunc("/api/v1/users/{id}/sessions", h.RevokeUserSessions).Methods("DELETE")
+	r.HandleFunc("/api/v1/users/{id}/sessions/{session_id}", h.RevokeUserSession).Methods("DELETE")
+}
+
+// ListMySessions は自分の有効なセッションの一覧を返します
+func (h *SessionHandler) ListMySessions(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(r.Context(), session.UserID, session.ID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sessions)
+}
+
+// RevokeMySessions は自分の全てのセッション（リクエストに使用したセッションを含む）を無効化します
+func (h *SessionHandler) RevokeMySessions(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeUserSessions(r.Context(), session.UserID, session.UserID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	clearCookieIfSessionAuth(w, r)
+
+	WriteJSON(w, http.StatusOK, map[string]int{
+		"revoked_sessions": revoked,
+	})
+}
+
+// RevokeMySession は自分のセッションを1件無効化します
+func (h *SessionHandler) RevokeMySession(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	sessionInfoID := mux.Vars(r)["session_id"]
+	if err := h.sessionService.RevokeSession(r.Context(), session.UserID, session.UserID, sessionInfoID); err != nil {
+		writeSessionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListUserSessions はユーザーの有効なセッションの一覧を返します（管理者のみ）
+func (h *SessionHandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	session, userID, ok := adminSessionAndUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.ListSessions(r.Context(), userID, session.ID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, sessions)
+}
+
+// RevokeUserSessions はユーザーの全てのセッションを無効化します（管理者のみ、アカウントの侵害・退職時の強制ログアウト）
+func (h *SessionHandler) RevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	session, userID, ok := adminSessionAndUserID(w, r)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeUserSessions(r.Context(), session.UserID, userID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		return
+	}
+	if userID == session.UserID {
+		clearCookieIfSessionAuth(w, r)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]int{
+		"revoked_sessions": revoked,
+	})
+}
+
+// RevokeUserSession はユーザーのセッションを1件無効化します（管理者のみ）
+func (h *SessionHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	session, userID, ok := adminSessionAndUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessionInfoID := mux.Vars(r)["session_id"]
+	if err := h.sessionService.RevokeSession(r.Context(), session.UserID, userID, sessionInfoID); err != nil {
+		writeSessionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// adminSessionAndUserID は管理者のセッションとパスのユーザーIDを取得します
+func adminSessionAndUserID(w http.ResponseWriter, r *http.Request) (*service.Session, uuid.UUID, bool) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return nil, uuid.Nil, false
+	}
+	if session.Role != domain.RoleAdmin {
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", "Admin access required")
+		return nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID")
+		return nil, uuid.Nil, false
+	}
+	return session, userID, true
+}
+
+// clearCookieIfSessionAuth はセッションCookieで認証したリクエストの場合にCookieを削除します
+func clearCookieIfSessionAuth(w http.ResponseWriter, r *http.Request) {
+	if method, _ := r.Context().Value(ContextKeyAuthMethod).(AuthMethod); method == AuthMethodCookie {
+		clearSessionCookie(w)
+	}
+}
+
+// writeSessionError はセッション関連のエラーをレスポンスに変換します
+func writeSessionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrSessionNotFound) {
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Session not found")
+		return
+	}
+	WriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+}
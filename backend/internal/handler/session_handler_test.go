@@ -0,0 +1,126 @@
+// backend/internal/handler/session_handler_test.go
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func sessionRequest(method, target string, session *service.Session, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, nil)
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+	ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+	ctx = context.WithValue(ctx, handler.ContextKeyAuthMethod, handler.AuthMethodCookie)
+	return req.WithContext(ctx)
+}
+
+func TestSessionHandler_ListMySessions(t *testing.T) {
+	userID := uuid.New()
+	mockService := new(MockSessionService)
+	mockService.On("ListSessions", mock.Anything, userID, "current-session").Return([]*service.SessionInfo{
+		{ID: "a1b2", Device: "Chrome on Windows", IPAddress: "192.0.2.1", Provider: "oidc", Current: true},
+	}, nil)
+	h := handler.NewSessionHandler(mockService)
+
+	w := httptest.NewRecorder()
+	h.ListMySessions(w, sessionRequest("GET", "/api/v1/sessions", &service.Session{ID: "current-session", UserID: userID, Role: domain.RoleGeneral}, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []service.SessionInfo `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Data, 1)
+	assert.True(t, resp.Data[0].Current)
+	assert.NotContains(t, w.Body.String(), "current-session")
+}
+
+func TestSessionHandler_RevokeMySession(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		revokeErr    error
+		expectedCode int
+	}{
+		{name: "Success", expectedCode: http.StatusNoContent},
+		{name: "Unknown or another user's session", revokeErr: service.ErrSessionNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSessionService)
+			mockService.On("RevokeSession", mock.Anything, userID, userID, "a1b2").Return(tt.revokeErr)
+			h := handler.NewSessionHandler(mockService)
+
+			w := httptest.NewRecorder()
+			h.RevokeMySession(w, sessionRequest("DELETE", "/api/v1/sessions/a1b2", &service.Session{UserID: userID, Role: domain.RoleGeneral}, map[string]string{"session_id": "a1b2"}))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSessionHandler_RevokeMySessions_ClearsCookie(t *testing.T) {
+	userID := uuid.New()
+	mockService := new(MockSessionService)
+	mockService.On("RevokeUserSessions", mock.Anything, userID, userID).Return(3, nil)
+	h := handler.NewSessionHandler(mockService)
+
+	w := httptest.NewRecorder()
+	h.RevokeMySessions(w, sessionRequest("DELETE", "/api/v1/sessions", &service.Session{UserID: userID, Role: domain.RoleGeneral}, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"revoked_sessions":3`)
+	assert.Contains(t, w.Header().Get("Set-Cookie"), handler.SessionCookieName+"=;")
+}
+
+func TestSessionHandler_RevokeUserSessions(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		role         domain.Role
+		userID       string
+		expectRevoke bool
+		expectedCode int
+	}{
+		{name: "Admin revokes all sessions of a user", role: domain.RoleAdmin, userID: userID.String(), expectRevoke: true, expectedCode: http.StatusOK},
+		{name: "Forbidden for non-admin", role: domain.RoleManager, userID: userID.String(), expectedCode: http.StatusForbidden},
+		{name: "Invalid user ID", role: domain.RoleAdmin, userID: "not-a-uuid", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSessionService)
+			mockService.On("RevokeUserSessions", mock.Anything, adminID, userID).Return(2, nil)
+			h := handler.NewSessionHandler(mockService)
+
+			w := httptest.NewRecorder()
+			h.RevokeUserSessions(w, sessionRequest("DELETE", "/api/v1/users/"+tt.userID+"/sessions", &service.Session{UserID: adminID, Role: tt.role}, map[string]string{"id": tt.userID}))
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectRevoke {
+				mockService.AssertExpectations(t)
+				// 他のユーザーのセッションを無効化しても管理者自身のCookieは残す
+				assert.Empty(t, w.Header().Get("Set-Cookie"))
+			} else {
+				mockService.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
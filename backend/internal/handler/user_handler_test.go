@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/esms/internal/domain"
 	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/repository"
 	"github.com/your-org/esms/internal/service"
 )
 
@@ -42,6 +43,30 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetBySubject(ctx context.Context, issuer, sub string) (*domain.User, error) {
+	args := m.Called(ctx, issuer, sub)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
@@ -0,0 +1,214 @@
+// backend/internal/handler/waitlist_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// WaitlistServiceInterface はウェイトリストサービスのインターフェース
+type WaitlistServiceInterface interface {
+	Join(ctx context.Context, req *service.JoinWaitlistRequest) (*domain.WaitlistEntry, error)
+	ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error)
+	Leave(ctx context.Context, entryID, userID uuid.UUID) error
+	Accept(ctx context.Context, entryID, userID uuid.UUID) (*domain.Reservation, error)
+	Decline(ctx context.Context, entryID, userID uuid.UUID) error
+}
+
+// WaitlistHandler はウェイトリスト関連のHTTPハンドラー
+type WaitlistHandler struct {
+	waitlistService WaitlistServiceInterface
+}
+
+// NewWaitlistHandler は新しいWaitlistHandlerを作成します
+func NewWaitlistHandler(waitlistService WaitlistServiceInterface) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *WaitlistHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/waitlist", h.JoinWaitlist).Methods("POST")
+	r.HandleFunc("/api/v1/waitlist", h.ListWaitlist).Methods("GET")
+	r.HandleFunc("/api/v1/waitlist/{id}", h.LeaveWaitlist).Methods("DELETE")
+	r.HandleFunc("/api/v1/waitlist/{id}/accept", h.AcceptOffer).Methods("POST")
+	r.HandleFunc("/api/v1/waitlist/{id}/decline", h.DeclineOffer).Methods("POST")
+}
+
+// JoinWaitlistRequest はウェイトリスト登録リクエスト
+type JoinWaitlistRequest struct {
+	ResourceID string    `json:"resource_id"`
+	Title      string    `json:"title"`
+	StartAt    time.Time `json:"start_at"`
+	EndAt      time.Time `json:"end_at"`
+	Timezone   string    `json:"timezone"`
+}
+
+// JoinWaitlist は満室のリソースと時間帯のウェイトリストに登録します
+func (h *WaitlistHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req JoinWaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+
+	// バリデーション
+	if req.Timezone == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone is required")
+		return
+	}
+	if req.StartAt.IsZero() || req.EndAt.IsZero() {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "StartAt and EndAt are required")
+		return
+	}
+	if !req.EndAt.After(req.StartAt) {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIME_RANGE", "EndAt must be after StartAt")
+		return
+	}
+	resourceID, err := uuid.Parse(req.ResourceID)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_RESOURCE_ID", "Invalid resource ID")
+		return
+	}
+
+	entry, err := h.waitlistService.Join(r.Context(), &service.JoinWaitlistRequest{
+		UserID:     session.UserID,
+		ResourceID: resourceID,
+		Title:      req.Title,
+		StartAt:    req.StartAt,
+		EndAt:      req.EndAt,
+		Timezone:   req.Timezone,
+	})
+	if err != nil {
+		writeWaitlistError(w, err, "JOIN_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, entry)
+}
+
+// ListWaitlist はログインユーザーの有効なウェイトリスト登録一覧を取得します
+func (h *WaitlistHandler) ListWaitlist(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	entries, err := h.waitlistService.ListMine(r.Context(), session.UserID)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, "LIST_FAILED", err.Error())
+		return
+	}
+	if entries == nil {
+		entries = []*domain.WaitlistEntry{}
+	}
+
+	WriteJSON(w, http.StatusOK, entries)
+}
+
+// LeaveWaitlist はウェイトリスト登録を取り消します
+func (h *WaitlistHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	session, id, ok := waitlistRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.waitlistService.Leave(r.Context(), id, session.UserID); err != nil {
+		writeWaitlistError(w, err, "LEAVE_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Left waitlist successfully",
+	})
+}
+
+// AcceptOffer は繰り上げ提示を確定して予約に変換します
+func (h *WaitlistHandler) AcceptOffer(w http.ResponseWriter, r *http.Request) {
+	session, id, ok := waitlistRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.waitlistService.Accept(r.Context(), id, session.UserID)
+	if err != nil {
+		writeWaitlistError(w, err, "ACCEPT_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, reservation)
+}
+
+// DeclineOffer は繰り上げ提示を辞退し、次の登録者へ譲ります
+func (h *WaitlistHandler) DeclineOffer(w http.ResponseWriter, r *http.Request) {
+	session, id, ok := waitlistRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.waitlistService.Decline(r.Context(), id, session.UserID); err != nil {
+		writeWaitlistError(w, err, "DECLINE_FAILED")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Waitlist offer declined",
+	})
+}
+
+// waitlistRequestContext はセッションとパスのウェイトリスト登録IDを取得します
+// 取得できない場合はエラーレスポンスを書き込み false を返します
+func waitlistRequestContext(w http.ResponseWriter, r *http.Request) (*service.Session, uuid.UUID, bool) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return nil, uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_ID", "Invalid waitlist entry ID")
+		return nil, uuid.Nil, false
+	}
+
+	return session, id, true
+}
+
+// writeWaitlistError はウェイトリストサービスのエラーをHTTPレスポンスに変換します
+func writeWaitlistError(w http.ResponseWriter, err error, fallbackCode string) {
+	switch {
+	case errors.Is(err, service.ErrSlotAvailable):
+		WriteError(w, http.StatusConflict, "SLOT_AVAILABLE", err.Error())
+	case errors.Is(err, service.ErrAlreadyWaitlisted):
+		WriteError(w, http.StatusConflict, "ALREADY_WAITLISTED", err.Error())
+	case errors.Is(err, service.ErrWaitlistEntryInactive), errors.Is(err, service.ErrWaitlistNotOffered):
+		WriteError(w, http.StatusConflict, "WAITLIST_NOT_OFFERED", err.Error())
+	case errors.Is(err, service.ErrWaitlistOfferExpired):
+		WriteError(w, http.StatusGone, "OFFER_EXPIRED", err.Error())
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		WriteError(w, http.StatusNotFound, "NOT_FOUND", "Waitlist entry not found")
+	case errors.Is(err, service.ErrApprovalChainUnresolved):
+		WriteError(w, http.StatusUnprocessableEntity, "APPROVAL_CHAIN_UNRESOLVED", err.Error())
+	default:
+		WriteError(w, http.StatusBadRequest, fallbackCode, err.Error())
+	}
+}
@@ -0,0 +1,171 @@
+// backend/internal/handler/waitlist_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestWaitlistHandler_JoinWaitlist(t *testing.T) {
+	mockWaitlist := new(MockWaitlistService)
+	h := handler.NewWaitlistHandler(mockWaitlist)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	resourceID := uuid.New()
+	startAt := time.Date(2030, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		body          map[string]interface{}
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{
+				"resource_id": resourceID.String(),
+				"title":       "Design review",
+				"start_at":    startAt,
+				"end_at":      startAt.Add(time.Hour),
+				"timezone":    "Asia/Tokyo",
+			},
+			setupMock: func() {
+				mockWaitlist.On("Join", mock.Anything, mock.MatchedBy(func(req *service.JoinWaitlistRequest) bool {
+					return req.UserID == userID && req.ResourceID == resourceID
+				})).Return(&domain.WaitlistEntry{ID: uuid.New(), Status: domain.WaitlistStatusWaiting}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Slot available",
+			body: map[string]interface{}{
+				"resource_id": resourceID.String(),
+				"title":       "Design review",
+				"start_at":    startAt,
+				"end_at":      startAt.Add(time.Hour),
+				"timezone":    "Asia/Tokyo",
+			},
+			setupMock: func() {
+				mockWaitlist.On("Join", mock.Anything, mock.Anything).Return(nil, service.ErrSlotAvailable)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "SLOT_AVAILABLE",
+		},
+		{
+			name: "Invalid resource ID",
+			body: map[string]interface{}{
+				"resource_id": "not-a-uuid",
+				"title":       "Design review",
+				"start_at":    startAt,
+				"end_at":      startAt.Add(time.Hour),
+				"timezone":    "Asia/Tokyo",
+			},
+			setupMock:     func() {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_RESOURCE_ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWaitlist.ExpectedCalls = nil
+			mockWaitlist.Calls = nil
+
+			tt.setupMock()
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/v1/waitlist", bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.JoinWaitlist(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockWaitlist.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWaitlistHandler_AcceptOffer(t *testing.T) {
+	mockWaitlist := new(MockWaitlistService)
+	h := handler.NewWaitlistHandler(mockWaitlist)
+
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	entryID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMock     func()
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockWaitlist.On("Accept", mock.Anything, entryID, userID).
+					Return(&domain.Reservation{ID: uuid.New(), ApprovalStatus: domain.ApprovalStatusConfirmed}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Offer expired",
+			setupMock: func() {
+				mockWaitlist.On("Accept", mock.Anything, entryID, userID).Return(nil, service.ErrWaitlistOfferExpired)
+			},
+			expectedCode:  http.StatusGone,
+			expectedError: "OFFER_EXPIRED",
+		},
+		{
+			name: "Not owner",
+			setupMock: func() {
+				mockWaitlist.On("Accept", mock.Anything, entryID, userID).Return(nil, service.ErrUnauthorized)
+			},
+			expectedCode:  http.StatusForbidden,
+			expectedError: "FORBIDDEN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWaitlist.ExpectedCalls = nil
+			mockWaitlist.Calls = nil
+
+			tt.setupMock()
+
+			req := httptest.NewRequest("POST", "/api/v1/waitlist/"+entryID.String()+"/accept", nil)
+			req = mux.SetURLVars(req, map[string]string{"id": entryID.String()})
+			ctx := context.WithValue(req.Context(), handler.ContextKeySession, session)
+			req = req.WithContext(ctx)
+
+			w := httptest.NewRecorder()
+			h.AcceptOffer(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			mockWaitlist.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// backend/internal/handler/workspace_handler.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+// WorkspaceServiceInterface はデスク・駐車区画のゾーン予約サービスのインターフェース
+type WorkspaceServiceInterface interface {
+	BookInZone(ctx context.Context, req *service.ZoneBookingRequest) ([]*service.ZoneBookingResult, error)
+	GetZoneOccupancy(ctx context.Context, resourceType domain.ResourceType, zone string, from time.Time, days int, timezone string) ([]*service.ZoneOccupancy, error)
+}
+
+// WorkspaceHandler はデスク・駐車区画のゾーン予約関連のHTTPハンドラー
+type WorkspaceHandler struct {
+	workspaceService WorkspaceServiceInterface
+}
+
+// NewWorkspaceHandler は新しいWorkspaceHandlerを作成します
+func NewWorkspaceHandler(workspaceService WorkspaceServiceInterface) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		workspaceService: workspaceService,
+	}
+}
+
+// RegisterRoutes はルートを登録します
+func (h *WorkspaceHandler) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/api/v1/zones/{zone}/bookings", h.BookInZone).Methods("POST")
+	r.HandleFunc("/api/v1/zones/{zone}/occupancy", h.GetOccupancy).Methods("GET")
+}
+
+// dateLayout はゾーン予約で使用する日付の形式
+const dateLayout = "2006-01-02"
+
+// ZoneBookingRequest はゾーン予約リクエスト
+type ZoneBookingRequest struct {
+	Type     domain.ResourceType `json:"type"`  // DESK または PARKING
+	Dates    []string            `json:"dates"` // YYYY-MM-DD
+	Slot     domain.DaySlot      `json:"slot"`  // FULL_DAY, AM, PM（未指定は FULL_DAY）
+	Timezone string              `json:"timezone"`
+	Title    string              `json:"title"`
+}
+
+// ZoneBookingResponse はゾーン予約の日付ごとの結果
+type ZoneBookingResponse struct {
+	Date          string    `json:"date"`
+	ResourceID    uuid.UUID `json:"resource_id"`
+	ResourceName  string    `json:"resource_name"`
+	ReservationID uuid.UUID `json:"reservation_id"`
+	StartAt       time.Time `json:"start_at"`
+	EndAt         time.Time `json:"end_at"`
+}
+
+// BookInZone はゾーン内の空いているデスク・駐車区画を日付ごとに自動で選んで予約します
+func (h *WorkspaceHandler) BookInZone(w http.ResponseWriter, r *http.Request) {
+	session, ok := r.Context().Value(ContextKeySession).(*service.Session)
+	if !ok {
+		WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Not authenticated")
+		return
+	}
+
+	var req ZoneBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		return
+	}
+	if req.Timezone == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone is required")
+		return
+	}
+	if req.Slot == "" {
+		req.Slot = domain.DaySlotFullDay
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Invalid timezone")
+		return
+	}
+
+	dates := make([]time.Time, 0, len(req.Dates))
+	for _, d := range req.Dates {
+		date, err := time.ParseInLocation(dateLayout, d, loc)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "INVALID_DATE", "Dates must be in YYYY-MM-DD format")
+			return
+		}
+		dates = append(dates, date)
+	}
+
+	results, err := h.workspaceService.BookInZone(r.Context(), &service.ZoneBookingRequest{
+		OrganizerID: session.UserID,
+		Type:        req.Type,
+		Zone:        mux.Vars(r)["zone"],
+		Dates:       dates,
+		Slot:        req.Slot,
+		Timezone:    req.Timezone,
+		Title:       req.Title,
+	})
+	if err != nil {
+		writeWorkspaceError(w, err, "BOOKING_FAILED")
+		return
+	}
+
+	response := make([]ZoneBookingResponse, len(results))
+	for i, result := range results {
+		response[i] = ZoneBookingResponse{
+			Date:          result.Date.Format(dateLayout),
+			ResourceID:    result.Resource.ID,
+			ResourceName:  result.Resource.Name,
+			ReservationID: result.Reservation.ID,
+			StartAt:       result.Reservation.StartAt,
+			EndAt:         result.Reservation.EndAt,
+		}
+	}
+
+	WriteJSON(w, http.StatusCreated, response)
+}
+
+// ResourceOccupancyResponse はリソース1件の1日の予約状況
+type ResourceOccupancyResponse struct {
+	ResourceID   uuid.UUID  `json:"resource_id"`
+	ResourceName string     `json:"resource_name"`
+	AMBookedBy   *uuid.UUID `json:"am_booked_by"`
+	PMBookedBy   *uuid.UUID `json:"pm_booked_by"`
+}
+
+// ZoneOccupancyResponse はゾーンの1日の占有状況
+type ZoneOccupancyResponse struct {
+	Date       string                      `json:"date"`
+	Total      int                         `json:"total"`
+	AMOccupied int                         `json:"am_occupied"`
+	PMOccupied int                         `json:"pm_occupied"`
+	Resources  []ResourceOccupancyResponse `json:"resources"`
+}
+
+// GetOccupancy はゾーンの日ごとの占有状況を取得します
+// クエリパラメータ: type (DESK/PARKING), date (YYYY-MM-DD), days (既定1), timezone
+func (h *WorkspaceHandler) GetOccupancy(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	timezone := params.Get("timezone")
+	if timezone == "" {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "timezone is required")
+		return
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_TIMEZONE", "Invalid timezone")
+		return
+	}
+
+	date, err := time.ParseInLocation(dateLayout, params.Get("date"), loc)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "INVALID_DATE", "date must be in YYYY-MM-DD format")
+		return
+	}
+
+	days := 1
+	if d := params.Get("days"); d != "" {
+		days, err = strconv.Atoi(d)
+		if err != nil || days < 1 || days > service.MaxZoneBookingDays {
+			WriteError(w, http.StatusBadRequest, "INVALID_DAYS", "days must be between 1 and 31")
+			return
+		}
+	}
+
+	occupancies, err := h.workspaceService.GetZoneOccupancy(r.Context(), domain.ResourceType(params.Get("type")), mux.Vars(r)["zone"], date, days, timezone)
+	if err != nil {
+		writeWorkspaceError(w, err, "OCCUPANCY_FAILED")
+		return
+	}
+
+	response := make([]ZoneOccupancyResponse, len(occupancies))
+	for i, o := range occupancies {
+		resources := make([]ResourceOccupancyResponse, len(o.Resources))
+		for j, ro := range o.Resources {
+			resources[j] = ResourceOccupancyResponse{
+				ResourceID:   ro.ResourceID,
+				ResourceName: ro.ResourceName,
+				AMBookedBy:   ro.AMBookedBy,
+				PMBookedBy:   ro.PMBookedBy,
+			}
+		}
+		response[i] = ZoneOccupancyResponse{
+			Date:       o.Date.Format(dateLayout),
+			Total:      o.Total,
+			AMOccupied: o.AMOccupied,
+			PMOccupied: o.PMOccupied,
+			Resources:  resources,
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, response)
+}
+
+// writeWorkspaceError はゾーン予約サービスのエラーをHTTPレスポンスに変換します
+func writeWorkspaceError(w http.ResponseWriter, err error, fallbackCode string) {
+	switch {
+	case errors.Is(err, service.ErrNotDayBookedType):
+		WriteError(w, http.StatusBadRequest, "INVALID_TYPE", err.Error())
+	case errors.Is(err, service.ErrZoneRequired), errors.Is(err, service.ErrDatesRequired):
+		WriteError(w, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+	case errors.Is(err, service.ErrInvalidDaySlot):
+		WriteError(w, http.StatusBadRequest, "INVALID_DAY_SLOT", err.Error())
+	case errors.Is(err, service.ErrNoFreeResourceZone):
+		WriteError(w, http.StatusConflict, "NO_FREE_RESOURCE", err.Error())
+	case errors.Is(err, service.ErrResourceNotAvailable):
+		WriteError(w, http.StatusConflict, "RESOURCE_NOT_AVAILABLE", err.Error())
+	case errors.Is(err, service.ErrOverdueEquipment):
+		WriteError(w, http.StatusForbidden, "OVERDUE_EQUIPMENT", err.Error())
+	case errors.Is(err, service.ErrUnauthorized):
+		WriteError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	default:
+		WriteError(w, http.StatusBadRequest, fallbackCode, err.Error())
+	}
+}
@@ -0,0 +1,113 @@
+// backend/internal/handler/workspace_handler_test.go
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/handler"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestWorkspaceHandler_BookInZone(t *testing.T) {
+	userID := uuid.New()
+	session := &service.Session{UserID: userID}
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	day := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+	desk := &domain.Resource{ID: uuid.New(), Name: "Desk A", Type: domain.ResourceTypeDesk}
+
+	tests := []struct {
+		name          string
+		body          map[string]interface{}
+		setupMock     func(m *MockWorkspaceService)
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name: "Success",
+			body: map[string]interface{}{"type": "DESK", "dates": []string{"2030-06-03"}, "slot": "AM", "timezone": "Asia/Tokyo"},
+			setupMock: func(m *MockWorkspaceService) {
+				m.On("BookInZone", mock.Anything, mock.MatchedBy(func(req *service.ZoneBookingRequest) bool {
+					return req.OrganizerID == userID && req.Zone == "3F-A" && req.Slot == domain.DaySlotAM &&
+						len(req.Dates) == 1 && req.Dates[0].Equal(day)
+				})).Return([]*service.ZoneBookingResult{{
+					Date:        day,
+					Resource:    desk,
+					Reservation: &domain.Reservation{ID: uuid.New(), StartAt: day, EndAt: day.Add(12 * time.Hour)},
+				}}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:          "Invalid date",
+			body:          map[string]interface{}{"type": "DESK", "dates": []string{"06/03/2030"}, "timezone": "Asia/Tokyo"},
+			setupMock:     func(m *MockWorkspaceService) {},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "INVALID_DATE",
+		},
+		{
+			name: "Zone full",
+			body: map[string]interface{}{"type": "DESK", "dates": []string{"2030-06-03"}, "timezone": "Asia/Tokyo"},
+			setupMock: func(m *MockWorkspaceService) {
+				m.On("BookInZone", mock.Anything, mock.AnythingOfType("*service.ZoneBookingRequest")).
+					Return(nil, service.ErrNoFreeResourceZone)
+			},
+			expectedCode:  http.StatusConflict,
+			expectedError: "NO_FREE_RESOURCE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWorkspace := new(MockWorkspaceService)
+			h := handler.NewWorkspaceHandler(mockWorkspace)
+			tt.setupMock(mockWorkspace)
+
+			bodyBytes, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest("POST", "/api/v1/zones/3F-A/bookings", bytes.NewReader(bodyBytes))
+			req = mux.SetURLVars(req, map[string]string{"zone": "3F-A"})
+			req = req.WithContext(context.WithValue(req.Context(), handler.ContextKeySession, session))
+
+			w := httptest.NewRecorder()
+			h.BookInZone(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedError)
+			} else {
+				assert.Contains(t, w.Body.String(), `"date":"2030-06-03"`)
+			}
+			mockWorkspace.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkspaceHandler_GetOccupancy(t *testing.T) {
+	mockWorkspace := new(MockWorkspaceService)
+	h := handler.NewWorkspaceHandler(mockWorkspace)
+
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	day := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+	mockWorkspace.On("GetZoneOccupancy", mock.Anything, domain.ResourceTypeParking, "B1", day, 5, "Asia/Tokyo").
+		Return([]*service.ZoneOccupancy{{Date: day, Total: 10, AMOccupied: 4, PMOccupied: 6}}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/zones/B1/occupancy?type=PARKING&date=2030-06-03&days=5&timezone=Asia/Tokyo", nil)
+	req = mux.SetURLVars(req, map[string]string{"zone": "B1"})
+
+	w := httptest.NewRecorder()
+	h.GetOccupancy(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pm_occupied":6`)
+	mockWorkspace.AssertExpectations(t)
+}
@@ -0,0 +1,220 @@
+// backend/internal/repository/api_token_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// APITokenRepository は個人用アクセストークン・サービスアカウントのAPIキーへのアクセスを提供するインターフェース
+type APITokenRepository interface {
+	Create(ctx context.Context, token *domain.APIToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.APIToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error)
+	List(ctx context.Context, filter APITokenFilter) ([]*domain.APIToken, error)
+	Revoke(ctx context.Context, id, revokedBy uuid.UUID, revokedAt time.Time) error
+	RevokeByServiceAccount(ctx context.Context, serviceAccountID, revokedBy uuid.UUID, revokedAt time.Time) (int64, error)
+	RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time, ip string) error
+}
+
+// APITokenFilter はAPIトークンの検索条件
+type APITokenFilter struct {
+	UserID           *uuid.UUID
+	ServiceAccountID *uuid.UUID
+	IncludeRevoked   bool // 無効化済みのトークンを含める
+}
+
+// postgresAPITokenRepository はPostgreSQLを使用したAPITokenRepositoryの実装
+type postgresAPITokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository は新しいAPITokenRepositoryを作成します
+func NewAPITokenRepository(db *sql.DB) APITokenRepository {
+	return &postgresAPITokenRepository{db: db}
+}
+
+// apiTokenColumns はAPIトークンの取得で共通して使用する列
+const apiTokenColumns = `
+	id, name, prefix, token_hash, user_id, service_account_id, scopes, expires_at,
+	last_used_at, last_used_ip, created_by, created_at, revoked_at, revoked_by
+`
+
+// Create はAPIトークンを作成します
+func (r *postgresAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	scopes, err := json.Marshal(token.Scopes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scopes: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_tokens (id, name, prefix, token_hash, user_id, service_account_id, scopes, expires_at, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		token.ID,
+		token.Name,
+		token.Prefix,
+		token.TokenHash,
+		token.UserID,
+		token.ServiceAccountID,
+		scopes,
+		token.ExpiresAt,
+		token.CreatedBy,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create api token: %w", err)
+	}
+	return nil
+}
+
+// GetByID はAPIトークンを取得します
+func (r *postgresAPITokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE id = $1`
+	return r.get(ctx, query, id)
+}
+
+// GetByHash はトークンのハッシュ値からAPIトークンを取得します
+func (r *postgresAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+	return r.get(ctx, query, tokenHash)
+}
+
+func (r *postgresAPITokenRepository) get(ctx context.Context, query string, arg interface{}) (*domain.APIToken, error) {
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+	return token, nil
+}
+
+// List はAPIトークンを作成日時の新しい順に取得します
+func (r *postgresAPITokenRepository) List(ctx context.Context, filter APITokenFilter) ([]*domain.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE 1=1`
+	args := []interface{}{}
+	argCount := 1
+
+	if filter.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", argCount)
+		args = append(args, *filter.UserID)
+		argCount++
+	}
+	if filter.ServiceAccountID != nil {
+		query += fmt.Sprintf(" AND service_account_id = $%d", argCount)
+		args = append(args, *filter.ServiceAccountID)
+		argCount++
+	}
+	if !filter.IncludeRevoked {
+		query += " AND revoked_at IS NULL"
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*domain.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return tokens, nil
+}
+
+// Revoke は有効なAPIトークンを無効化します（無効化済みの場合は ErrNotFound）
+func (r *postgresAPITokenRepository) Revoke(ctx context.Context, id, revokedBy uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE api_tokens SET revoked_at = $1, revoked_by = $2 WHERE id = $3 AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, revokedAt, revokedBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RevokeByServiceAccount はサービスアカウントの有効なAPIトークンを全て無効化し、無効化した件数を返します
+func (r *postgresAPITokenRepository) RevokeByServiceAccount(ctx context.Context, serviceAccountID, revokedBy uuid.UUID, revokedAt time.Time) (int64, error) {
+	query := `UPDATE api_tokens SET revoked_at = $1, revoked_by = $2 WHERE service_account_id = $3 AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, revokedAt, revokedBy, serviceAccountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke api tokens: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// RecordUse はAPIトークンの最終使用日時と接続元IPアドレスを記録します
+func (r *postgresAPITokenRepository) RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time, ip string) error {
+	query := `UPDATE api_tokens SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`
+	if _, err := r.db.ExecContext(ctx, query, usedAt, ip, id); err != nil {
+		return fmt.Errorf("failed to record api token use: %w", err)
+	}
+	return nil
+}
+
+// scanAPIToken はAPIトークンの行を読み取ります
+func scanAPIToken(row userScanner) (*domain.APIToken, error) {
+	var token domain.APIToken
+	var scopes []byte
+	var lastUsedIP sql.NullString
+	var createdBy *uuid.UUID
+	err := row.Scan(
+		&token.ID,
+		&token.Name,
+		&token.Prefix,
+		&token.TokenHash,
+		&token.UserID,
+		&token.ServiceAccountID,
+		&scopes,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&lastUsedIP,
+		&createdBy,
+		&token.CreatedAt,
+		&token.RevokedAt,
+		&token.RevokedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) > 0 {
+		if err := json.Unmarshal(scopes, &token.Scopes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scopes: %w", err)
+		}
+	}
+	token.LastUsedIP = lastUsedIP.String
+	if createdBy != nil {
+		token.CreatedBy = *createdBy
+	}
+	return &token, nil
+}
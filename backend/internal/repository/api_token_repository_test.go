@@ -0,0 +1,153 @@
+// backend/internal/repository/api_token_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var apiTokenColumns = []string{"id", "name", "prefix", "token_hash", "user_id", "service_account_id", "scopes", "expires_at",
+	"last_used_at", "last_used_ip", "created_by", "created_at", "revoked_at", "revoked_by"}
+
+func TestAPITokenRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAPITokenRepository(db)
+
+	now := time.Now()
+	userID := uuid.New()
+	token := &domain.APIToken{
+		ID:        uuid.New(),
+		Name:      "reporting",
+		Prefix:    "esms_AbCdEfG",
+		TokenHash: "hash",
+		UserID:    &userID,
+		Scopes:    []domain.APITokenScope{domain.APITokenScopeRead},
+		ExpiresAt: now.Add(24 * time.Hour),
+		CreatedBy: userID,
+		CreatedAt: now,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO api_tokens`)).
+		WithArgs(token.ID, "reporting", "esms_AbCdEfG", "hash", token.UserID, token.ServiceAccountID, []byte(`["read"]`),
+			token.ExpiresAt, userID, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.Create(context.Background(), token)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPITokenRepository_GetByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAPITokenRepository(db)
+
+	now := time.Now()
+	tokenID, accountID, creatorID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("Found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM api_tokens WHERE token_hash = $1`)).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(apiTokenColumns).AddRow(
+				tokenID, "room-display", "esms_AbCdEfG", "hash", nil, accountID, []byte(`["read","write"]`), now.Add(time.Hour),
+				now, "192.0.2.1", creatorID, now, nil, nil))
+
+		token, err := repo.GetByHash(context.Background(), "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, tokenID, token.ID)
+		assert.Nil(t, token.UserID)
+		assert.Equal(t, accountID, *token.ServiceAccountID)
+		assert.Equal(t, []domain.APITokenScope{domain.APITokenScopeRead, domain.APITokenScopeWrite}, token.Scopes)
+		assert.Equal(t, "192.0.2.1", token.LastUsedIP)
+		assert.Equal(t, creatorID, token.CreatedBy)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM api_tokens WHERE token_hash = $1`)).
+			WithArgs("unknown").
+			WillReturnRows(sqlmock.NewRows(apiTokenColumns))
+
+		_, err := repo.GetByHash(context.Background(), "unknown")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPITokenRepository_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAPITokenRepository(db)
+
+	now := time.Now()
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM api_tokens WHERE 1=1 AND user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC, id`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(apiTokenColumns).AddRow(
+			uuid.New(), "reporting", "esms_AbCdEfG", "hash", userID, nil, []byte(`["read"]`), now.Add(time.Hour),
+			nil, nil, userID, now, nil, nil))
+
+	tokens, err := repo.List(context.Background(), repository.APITokenFilter{UserID: &userID})
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Nil(t, tokens[0].LastUsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPITokenRepository_Revoke(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAPITokenRepository(db)
+
+	now := time.Now()
+	tokenID, actorID := uuid.New(), uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE api_tokens SET revoked_at = $1, revoked_by = $2 WHERE id = $3 AND revoked_at IS NULL`)).
+		WithArgs(now, actorID, tokenID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Revoke(context.Background(), tokenID, actorID, now))
+
+	// 無効化済みの場合
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE api_tokens SET revoked_at = $1, revoked_by = $2 WHERE id = $3 AND revoked_at IS NULL`)).
+		WithArgs(now, actorID, tokenID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.Revoke(context.Background(), tokenID, actorID, now), repository.ErrNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPITokenRepository_RecordUse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAPITokenRepository(db)
+
+	now := time.Now()
+	tokenID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE api_tokens SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`)).
+		WithArgs(now, "192.0.2.1", tokenID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.RecordUse(context.Background(), tokenID, now, "192.0.2.1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
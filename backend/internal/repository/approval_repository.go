@@ -0,0 +1,319 @@
+// backend/internal/repository/approval_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// ApprovalRepository は承認ポリシーと予約の承認ステップへのアクセスを提供するインターフェース
+type ApprovalRepository interface {
+	CreatePolicy(ctx context.Context, policy *domain.ApprovalPolicy) error
+	GetPolicyByID(ctx context.Context, id uuid.UUID) (*domain.ApprovalPolicy, error)
+	CreateSteps(ctx context.Context, steps []*domain.ReservationApproval) error
+	ListSteps(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationApproval, error)
+	UpdateStep(ctx context.Context, step *domain.ReservationApproval) error
+	ListActiveSteps(ctx context.Context) ([]*domain.ReservationApproval, error)
+}
+
+// postgresApprovalRepository はPostgreSQLを使用したApprovalRepositoryの実装
+type postgresApprovalRepository struct {
+	db *sql.DB
+}
+
+// NewApprovalRepository は新しいApprovalRepositoryを作成します
+func NewApprovalRepository(db *sql.DB) ApprovalRepository {
+	return &postgresApprovalRepository{db: db}
+}
+
+// CreatePolicy はトランザクション内で承認ポリシーとそのステップを作成します
+func (r *postgresApprovalRepository) CreatePolicy(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO approval_policies (id, name, description, reminder_after_minutes, escalate_after_minutes,
+		                               decision_deadline_minutes, timeout_action, delegate_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	var timeoutAction *domain.ApprovalTimeoutAction
+	if policy.TimeoutAction != "" {
+		timeoutAction = &policy.TimeoutAction
+	}
+	_, err = tx.ExecContext(ctx, query,
+		policy.ID,
+		policy.Name,
+		policy.Description,
+		int(policy.ReminderAfter/time.Minute),
+		int(policy.EscalateAfter/time.Minute),
+		int(policy.DecisionDeadline/time.Minute),
+		timeoutAction,
+		policy.DelegateID,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create approval policy: %w", err)
+	}
+
+	stepQuery := `
+		INSERT INTO approval_policy_steps (policy_id, step_order, step_type, approver_group_id, min_duration_minutes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, step := range policy.Steps {
+		_, err = tx.ExecContext(ctx, stepQuery,
+			policy.ID,
+			step.StepOrder,
+			step.Type,
+			step.ApproverGroupID,
+			int(step.MinDuration/time.Minute),
+			policy.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create approval policy step: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetPolicyByID はIDで承認ポリシーとそのステップを取得します
+func (r *postgresApprovalRepository) GetPolicyByID(ctx context.Context, id uuid.UUID) (*domain.ApprovalPolicy, error) {
+	query := `
+		SELECT id, name, description, reminder_after_minutes, escalate_after_minutes,
+		       decision_deadline_minutes, timeout_action, delegate_id, created_at, updated_at
+		FROM approval_policies
+		WHERE id = $1
+	`
+	row := r.db.QueryRowContext(ctx, query, id)
+
+	var policy domain.ApprovalPolicy
+	var description, timeoutAction sql.NullString
+	var reminderAfter, escalateAfter, decisionDeadline int
+	err := row.Scan(
+		&policy.ID,
+		&policy.Name,
+		&description,
+		&reminderAfter,
+		&escalateAfter,
+		&decisionDeadline,
+		&timeoutAction,
+		&policy.DelegateID,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get approval policy by id: %w", err)
+	}
+	policy.Description = description.String
+	policy.ReminderAfter = time.Duration(reminderAfter) * time.Minute
+	policy.EscalateAfter = time.Duration(escalateAfter) * time.Minute
+	policy.DecisionDeadline = time.Duration(decisionDeadline) * time.Minute
+	policy.TimeoutAction = domain.ApprovalTimeoutAction(timeoutAction.String)
+
+	stepQuery := `
+		SELECT step_order, step_type, approver_group_id, min_duration_minutes
+		FROM approval_policy_steps
+		WHERE policy_id = $1
+		ORDER BY step_order
+	`
+	rows, err := r.db.QueryContext(ctx, stepQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policy steps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step domain.ApprovalPolicyStep
+		var minDurationMinutes int
+		err := rows.Scan(
+			&step.StepOrder,
+			&step.Type,
+			&step.ApproverGroupID,
+			&minDurationMinutes,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval policy step: %w", err)
+		}
+		step.MinDuration = time.Duration(minDurationMinutes) * time.Minute
+		policy.Steps = append(policy.Steps, step)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// CreateSteps はトランザクション内で予約の承認ステップを一括作成します
+func (r *postgresApprovalRepository) CreateSteps(ctx context.Context, steps []*domain.ReservationApproval) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO reservation_approvals (id, reservation_id, reservation_start_at, step_order, step_type, policy_id,
+		                                   approver_id, approver_group_id, status, activated_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	for _, step := range steps {
+		_, err = tx.ExecContext(ctx, query,
+			step.ID,
+			step.ReservationID,
+			step.ReservationStartAt,
+			step.StepOrder,
+			step.StepType,
+			step.PolicyID,
+			step.ApproverID,
+			step.ApproverGroupID,
+			step.Status,
+			step.ActivatedAt,
+			step.CreatedAt,
+			step.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create reservation approval step: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListSteps は予約の承認ステップを StepOrder 昇順で取得します
+func (r *postgresApprovalRepository) ListSteps(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationApproval, error) {
+	query := `
+		SELECT id, reservation_id, reservation_start_at, step_order, step_type, policy_id, approver_id, approver_group_id,
+		       status, decided_by, decided_at, comment, activated_at, reminded_at, escalated_at, escalated_to,
+		       created_at, updated_at
+		FROM reservation_approvals
+		WHERE reservation_id = $1
+		ORDER BY step_order
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reservation approval steps: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReservationApprovals(rows)
+}
+
+// ListActiveSteps はSLA設定の対象となる承認待ちステップを全予約分取得します
+func (r *postgresApprovalRepository) ListActiveSteps(ctx context.Context) ([]*domain.ReservationApproval, error) {
+	query := `
+		SELECT id, reservation_id, reservation_start_at, step_order, step_type, policy_id, approver_id, approver_group_id,
+		       status, decided_by, decided_at, comment, activated_at, reminded_at, escalated_at, escalated_to,
+		       created_at, updated_at
+		FROM reservation_approvals
+		WHERE status = 'PENDING'
+		  AND activated_at IS NOT NULL
+		  AND policy_id IS NOT NULL
+		ORDER BY activated_at
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active approval steps: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReservationApprovals(rows)
+}
+
+// scanReservationApprovals は承認ステップの行を読み取ります
+func scanReservationApprovals(rows *sql.Rows) ([]*domain.ReservationApproval, error) {
+	var steps []*domain.ReservationApproval
+	for rows.Next() {
+		var step domain.ReservationApproval
+		var comment sql.NullString
+		err := rows.Scan(
+			&step.ID,
+			&step.ReservationID,
+			&step.ReservationStartAt,
+			&step.StepOrder,
+			&step.StepType,
+			&step.PolicyID,
+			&step.ApproverID,
+			&step.ApproverGroupID,
+			&step.Status,
+			&step.DecidedBy,
+			&step.DecidedAt,
+			&comment,
+			&step.ActivatedAt,
+			&step.RemindedAt,
+			&step.EscalatedAt,
+			&step.EscalatedTo,
+			&step.CreatedAt,
+			&step.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation approval step: %w", err)
+		}
+		step.Comment = comment.String
+		steps = append(steps, &step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return steps, nil
+}
+
+// UpdateStep は承認ステップの判断結果とSLA処理の記録を更新します
+func (r *postgresApprovalRepository) UpdateStep(ctx context.Context, step *domain.ReservationApproval) error {
+	step.UpdatedAt = time.Now()
+	query := `
+		UPDATE reservation_approvals
+		SET status = $1, decided_by = $2, decided_at = $3, comment = $4, activated_at = $5,
+		    reminded_at = $6, escalated_at = $7, escalated_to = $8, updated_at = $9
+		WHERE id = $10
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		step.Status,
+		step.DecidedBy,
+		step.DecidedAt,
+		step.Comment,
+		step.ActivatedAt,
+		step.RemindedAt,
+		step.EscalatedAt,
+		step.EscalatedTo,
+		step.UpdatedAt,
+		step.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation approval step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
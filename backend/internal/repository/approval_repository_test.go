@@ -0,0 +1,129 @@
+// backend/internal/repository/approval_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+func TestApprovalRepository_GetPolicyByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApprovalRepository(db)
+	ctx := context.Background()
+
+	policyID := uuid.New()
+	groupID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, description, reminder_after_minutes, escalate_after_minutes, decision_deadline_minutes, timeout_action, delegate_id, created_at, updated_at FROM approval_policies`)).
+		WithArgs(policyID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "reminder_after_minutes", "escalate_after_minutes", "decision_deadline_minutes", "timeout_action", "delegate_id", "created_at", "updated_at"}).
+			AddRow(policyID, "executive", nil, 60*24, 60*48, 60*72, "RELEASE", nil, now, now))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT step_order, step_type, approver_group_id, min_duration_minutes FROM approval_policy_steps`)).
+		WithArgs(policyID).
+		WillReturnRows(sqlmock.NewRows([]string{"step_order", "step_type", "approver_group_id", "min_duration_minutes"}).
+			AddRow(1, domain.ApprovalStepManager, nil, 0).
+			AddRow(2, domain.ApprovalStepApproverGroup, groupID, 240))
+
+	policy, err := repo.GetPolicyByID(ctx, policyID)
+	assert.NoError(t, err)
+	assert.Len(t, policy.Steps, 2)
+	assert.Equal(t, domain.ApprovalStepManager, policy.Steps[0].Type)
+	assert.Equal(t, 4*time.Hour, policy.Steps[1].MinDuration)
+	assert.Equal(t, groupID, *policy.Steps[1].ApproverGroupID)
+	assert.Equal(t, 24*time.Hour, policy.ReminderAfter)
+	assert.Equal(t, 72*time.Hour, policy.DecisionDeadline)
+	assert.Equal(t, domain.ApprovalTimeoutRelease, policy.TimeoutAction)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApprovalRepository_CreateSteps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApprovalRepository(db)
+	ctx := context.Background()
+
+	managerID := uuid.New()
+	step := &domain.ReservationApproval{
+		ID:                 uuid.New(),
+		ReservationID:      uuid.New(),
+		ReservationStartAt: time.Now(),
+		StepOrder:          1,
+		StepType:           domain.ApprovalStepManager,
+		ApproverID:         &managerID,
+		Status:             domain.ApprovalStepStatusPending,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_approvals`)).
+		WithArgs(step.ID, step.ReservationID, step.ReservationStartAt, step.StepOrder, step.StepType, step.PolicyID, step.ApproverID, step.ApproverGroupID, step.Status, step.ActivatedAt, step.CreatedAt, step.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateSteps(ctx, []*domain.ReservationApproval{step})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApprovalRepository_UpdateStep_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApprovalRepository(db)
+	ctx := context.Background()
+
+	step := &domain.ReservationApproval{ID: uuid.New(), Status: domain.ApprovalStepStatusApproved}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_approvals`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.UpdateStep(ctx, step)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApprovalRepository_ListActiveSteps(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApprovalRepository(db)
+	ctx := context.Background()
+
+	policyID := uuid.New()
+	managerID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "reservation_id", "reservation_start_at", "step_order", "step_type", "policy_id", "approver_id", "approver_group_id",
+		"status", "decided_by", "decided_at", "comment", "activated_at", "reminded_at", "escalated_at", "escalated_to", "created_at", "updated_at"}).
+		AddRow(uuid.New(), uuid.New(), now, 1, domain.ApprovalStepManager, policyID, managerID, nil,
+			domain.ApprovalStepStatusPending, nil, nil, nil, now.Add(-time.Hour), nil, nil, nil, now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, reservation_id, reservation_start_at, step_order, step_type, policy_id`)).
+		WillReturnRows(rows)
+
+	steps, err := repo.ListActiveSteps(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, steps, 1)
+	assert.Equal(t, policyID, *steps[0].PolicyID)
+	assert.NotNil(t, steps[0].ActivatedAt)
+	assert.Nil(t, steps[0].EscalatedTo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,165 @@
+// backend/internal/repository/approver_group_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// ApproverGroupRepository は承認者グループデータへのアクセスを提供するインターフェース
+type ApproverGroupRepository interface {
+	Create(ctx context.Context, group *domain.ApproverGroup) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ApproverGroup, error)
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.User, error)
+	IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error)
+}
+
+// postgresApproverGroupRepository はPostgreSQLを使用したApproverGroupRepositoryの実装
+type postgresApproverGroupRepository struct {
+	db *sql.DB
+}
+
+// NewApproverGroupRepository は新しいApproverGroupRepositoryを作成します
+func NewApproverGroupRepository(db *sql.DB) ApproverGroupRepository {
+	return &postgresApproverGroupRepository{db: db}
+}
+
+func (r *postgresApproverGroupRepository) Create(ctx context.Context, group *domain.ApproverGroup) error {
+	query := `
+		INSERT INTO approver_groups (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		group.ID,
+		group.Name,
+		group.Description,
+		group.CreatedAt,
+		group.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create approver group: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresApproverGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ApproverGroup, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM approver_groups
+		WHERE id = $1
+	`
+	row := r.db.QueryRowContext(ctx, query, id)
+
+	var group domain.ApproverGroup
+	var description sql.NullString
+	err := row.Scan(
+		&group.ID,
+		&group.Name,
+		&description,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get approver group by id: %w", err)
+	}
+	group.Description = description.String
+	return &group, nil
+}
+
+func (r *postgresApproverGroupRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO approver_group_members (group_id, user_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (group_id, user_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, groupID, userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add approver group member: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresApproverGroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	query := `DELETE FROM approver_group_members WHERE group_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, groupID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove approver group member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListMembers は承認者グループに所属するアクティブなユーザーを取得します
+func (r *postgresApproverGroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.User, error) {
+	query := `
+		SELECT u.id, u.email, u.name, u.role, u.created_at, u.updated_at
+		FROM approver_group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = $1
+		  AND u.is_active = true
+		  AND u.deleted_at IS NULL
+		ORDER BY u.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approver group members: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.Name,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approver group member: %w", err)
+		}
+		user.IsActive = true
+		users = append(users, &user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
+// IsMember はユーザーが承認者グループに所属しているかを判定します
+func (r *postgresApproverGroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM approver_group_members WHERE group_id = $1 AND user_id = $2
+		)
+	`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, groupID, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check approver group membership: %w", err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,108 @@
+// backend/internal/repository/approver_group_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+func TestApproverGroupRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApproverGroupRepository(db)
+	ctx := context.Background()
+
+	group := &domain.ApproverGroup{
+		ID:          uuid.New(),
+		Name:        "Facilities",
+		Description: "総務部 施設管理チーム",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO approver_groups`)).
+		WithArgs(group.ID, group.Name, group.Description, group.CreatedAt, group.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = repo.Create(ctx, group)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproverGroupRepository_ListMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApproverGroupRepository(db)
+	ctx := context.Background()
+
+	groupID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "created_at", "updated_at"}).
+		AddRow(userID, "approver@example.com", "Approver", domain.RoleManager, now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT u.id, u.email, u.name, u.role, u.created_at, u.updated_at FROM approver_group_members m`)).
+		WithArgs(groupID).
+		WillReturnRows(rows)
+
+	members, err := repo.ListMembers(ctx, groupID)
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, userID, members[0].ID)
+	assert.Equal(t, "approver@example.com", members[0].Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproverGroupRepository_RemoveMember_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApproverGroupRepository(db)
+	ctx := context.Background()
+
+	groupID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM approver_group_members`)).
+		WithArgs(groupID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.RemoveMember(ctx, groupID, userID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApproverGroupRepository_IsMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewApproverGroupRepository(db)
+	ctx := context.Background()
+
+	groupID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WithArgs(groupID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	isMember, err := repo.IsMember(ctx, groupID, userID)
+	assert.NoError(t, err)
+	assert.True(t, isMember)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
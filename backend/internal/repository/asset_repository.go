@@ -0,0 +1,221 @@
+// backend/internal/repository/asset_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// AssetRepository はプール型備品の個体と予約への割り当てへのアクセスを提供するインターフェース
+type AssetRepository interface {
+	Create(ctx context.Context, asset *domain.Asset) error
+	GetByTag(ctx context.Context, assetTag string) (*domain.Asset, error)
+	ListByResource(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error)
+	AssignToReservation(ctx context.Context, assignments []*domain.ReservationAsset) error
+	ListAssignments(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationAsset, error)
+	IsAssignedInPeriod(ctx context.Context, assetID uuid.UUID, startAt, endAt time.Time, excludeReservationID uuid.UUID) (bool, error)
+}
+
+// postgresAssetRepository はPostgreSQLを使用したAssetRepositoryの実装
+type postgresAssetRepository struct {
+	db *sql.DB
+}
+
+// NewAssetRepository は新しいAssetRepositoryを作成します
+func NewAssetRepository(db *sql.DB) AssetRepository {
+	return &postgresAssetRepository{db: db}
+}
+
+// Create は備品の個体を登録します
+func (r *postgresAssetRepository) Create(ctx context.Context, asset *domain.Asset) error {
+	query := `
+		INSERT INTO resource_assets (id, resource_id, asset_tag, is_active, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		asset.ID,
+		asset.ResourceID,
+		asset.AssetTag,
+		asset.IsActive,
+		asset.Notes,
+		asset.CreatedAt,
+		asset.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create asset: %w", err)
+	}
+	return nil
+}
+
+// GetByTag は資産タグで備品の個体を取得します
+func (r *postgresAssetRepository) GetByTag(ctx context.Context, assetTag string) (*domain.Asset, error) {
+	query := `
+		SELECT id, resource_id, asset_tag, is_active, notes, created_at, updated_at
+		FROM resource_assets
+		WHERE asset_tag = $1
+	`
+	row := r.db.QueryRowContext(ctx, query, assetTag)
+
+	var asset domain.Asset
+	var notes sql.NullString
+	err := row.Scan(
+		&asset.ID,
+		&asset.ResourceID,
+		&asset.AssetTag,
+		&asset.IsActive,
+		&notes,
+		&asset.CreatedAt,
+		&asset.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get asset by tag: %w", err)
+	}
+	asset.Notes = notes.String
+	return &asset, nil
+}
+
+// ListByResource はプール型備品に属する個体を資産タグ順に取得します
+func (r *postgresAssetRepository) ListByResource(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error) {
+	query := `
+		SELECT id, resource_id, asset_tag, is_active, notes, created_at, updated_at
+		FROM resource_assets
+		WHERE resource_id = $1
+		ORDER BY asset_tag
+	`
+	rows, err := r.db.QueryContext(ctx, query, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	defer rows.Close()
+
+	var assets []*domain.Asset
+	for rows.Next() {
+		var asset domain.Asset
+		var notes sql.NullString
+		err := rows.Scan(
+			&asset.ID,
+			&asset.ResourceID,
+			&asset.AssetTag,
+			&asset.IsActive,
+			&notes,
+			&asset.CreatedAt,
+			&asset.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan asset: %w", err)
+		}
+		asset.Notes = notes.String
+		assets = append(assets, &asset)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return assets, nil
+}
+
+// AssignToReservation はトランザクション内で予約に備品の個体を割り当てます
+func (r *postgresAssetRepository) AssignToReservation(ctx context.Context, assignments []*domain.ReservationAsset) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO reservation_assets (reservation_id, reservation_start_at, asset_id, assigned_by, assigned_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, assignment := range assignments {
+		_, err = tx.ExecContext(ctx, query,
+			assignment.ReservationID,
+			assignment.ReservationStartAt,
+			assignment.AssetID,
+			assignment.AssignedBy,
+			assignment.AssignedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to assign asset: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListAssignments は予約に割り当てられた備品の個体を取得します
+func (r *postgresAssetRepository) ListAssignments(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationAsset, error) {
+	query := `
+		SELECT ra.reservation_id, ra.reservation_start_at, ra.asset_id, a.asset_tag, a.resource_id, ra.assigned_by, ra.assigned_at
+		FROM reservation_assets ra
+		JOIN resource_assets a ON a.id = ra.asset_id
+		WHERE ra.reservation_id = $1
+		ORDER BY a.asset_tag
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []*domain.ReservationAsset
+	for rows.Next() {
+		var assignment domain.ReservationAsset
+		err := rows.Scan(
+			&assignment.ReservationID,
+			&assignment.ReservationStartAt,
+			&assignment.AssetID,
+			&assignment.AssetTag,
+			&assignment.ResourceID,
+			&assignment.AssignedBy,
+			&assignment.AssignedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan asset assignment: %w", err)
+		}
+		assignments = append(assignments, &assignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return assignments, nil
+}
+
+// IsAssignedInPeriod は個体が指定された期間に重なる他の有効な予約へ割り当て済みかを判定します
+// 早期返却で備品の割り当てを解放した回は、解放した日時までを割り当て期間とします
+func (r *postgresAssetRepository) IsAssignedInPeriod(ctx context.Context, assetID uuid.UUID, startAt, endAt time.Time, excludeReservationID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM reservation_assets ra
+			JOIN resource_assets a ON a.id = ra.asset_id
+			JOIN reservation_instances ri ON ri.reservation_id = ra.reservation_id
+			LEFT JOIN reservation_resources rr ON rr.reservation_instance_id = ri.id AND rr.resource_id = a.resource_id
+			WHERE ra.asset_id = $1
+			  AND ra.reservation_id <> $4
+			  AND ri.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+			  AND ri.start_at < $3
+			  AND COALESCE(rr.released_at, ri.end_at) > $2
+		)
+	`
+	var assigned bool
+	if err := r.db.QueryRowContext(ctx, query, assetID, startAt, endAt, excludeReservationID).Scan(&assigned); err != nil {
+		return false, fmt.Errorf("failed to check asset assignment: %w", err)
+	}
+	return assigned, nil
+}
@@ -36,13 +36,19 @@ func (r *postgresAuditLogRepository) Create(ctx context.Context, log *domain.Aud
 		return fmt.Errorf("failed to marshal details: %w", err)
 	}
 
+	// システムによる操作は user_id を NULL として記録
+	var userID interface{} = log.UserID
+	if log.UserID == domain.SystemUserID {
+		userID = nil
+	}
+
 	query := `
 		INSERT INTO audit_logs (id, user_id, action, target_type, target_id, details, ip_address, user_agent, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err = r.db.ExecContext(ctx, query,
 		log.ID,
-		log.UserID,
+		userID,
 		log.Action,
 		log.TargetType,
 		log.TargetID,
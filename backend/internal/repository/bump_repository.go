@@ -0,0 +1,125 @@
+// backend/internal/repository/bump_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// BumpRepository は会議室の優先確保で押しのけられた予約の記録へのアクセスを提供するインターフェース
+type BumpRepository interface {
+	Apply(ctx context.Context, bumps []*domain.ReservationBump) error
+	ListDisplacedInstanceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// postgresBumpRepository はPostgreSQLを使用したBumpRepositoryの実装
+type postgresBumpRepository struct {
+	db *sql.DB
+}
+
+// NewBumpRepository は新しいBumpRepositoryを作成します
+func NewBumpRepository(db *sql.DB) BumpRepository {
+	return &postgresBumpRepository{db: db}
+}
+
+// Apply は押しのけた各回の移動（リソースの付け替え）・強制キャンセルと記録の作成を1つのトランザクションで行います
+// いずれかの回を変更できなかった場合は全ての変更を取り消し、対象の回が見つからない場合は ErrNotFound を返します
+func (r *postgresBumpRepository) Apply(ctx context.Context, bumps []*domain.ReservationBump) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	reassignQuery := `
+		UPDATE reservation_resources
+		SET resource_id = $1
+		WHERE reservation_instance_id = $2 AND resource_id = $3
+	`
+	cancelQuery := `
+		UPDATE reservation_instances
+		SET status = $1, updated_at = $2
+		WHERE id = $3
+	`
+	insertQuery := `
+		INSERT INTO reservation_bumps (id, resource_id, reservation_id, bumped_by, on_behalf_of, displaced_reservation_id,
+		                               displaced_instance_id, displaced_organizer_id, outcome, replacement_resource_id, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	for _, bump := range bumps {
+		var result sql.Result
+		if bump.IsMoved() {
+			result, err = tx.ExecContext(ctx, reassignQuery, *bump.ReplacementResourceID, bump.DisplacedInstanceID, bump.ResourceID)
+		} else {
+			result, err = tx.ExecContext(ctx, cancelQuery, domain.ReservationStatusCancelled, time.Now(), bump.DisplacedInstanceID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to displace reservation instance: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, insertQuery,
+			bump.ID,
+			bump.ResourceID,
+			bump.ReservationID,
+			bump.BumpedBy,
+			bump.OnBehalfOf,
+			bump.DisplacedReservationID,
+			bump.DisplacedInstanceID,
+			bump.DisplacedOrganizerID,
+			bump.Outcome,
+			bump.ReplacementResourceID,
+			bump.Reason,
+			bump.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create reservation bump: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListDisplacedInstanceIDs は予約のうち優先確保で押しのけられた回のIDを返します
+// 押しのけられた回は主催者の都合ではないため、ペナルティの判定から除外します
+func (r *postgresBumpRepository) ListDisplacedInstanceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT displaced_instance_id
+		FROM reservation_bumps
+		WHERE displaced_reservation_id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list displaced instances: %w", err)
+	}
+	defer rows.Close()
+
+	var instanceIDs []uuid.UUID
+	for rows.Next() {
+		var instanceID uuid.UUID
+		if err := rows.Scan(&instanceID); err != nil {
+			return nil, fmt.Errorf("failed to scan displaced instance: %w", err)
+		}
+		instanceIDs = append(instanceIDs, instanceID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return instanceIDs, nil
+}
@@ -0,0 +1,88 @@
+// backend/internal/repository/bump_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+func newTestBump(outcome domain.BumpOutcome, replacementID *uuid.UUID) *domain.ReservationBump {
+	return &domain.ReservationBump{
+		ID:                     uuid.New(),
+		ResourceID:             uuid.New(),
+		ReservationID:          uuid.New(),
+		BumpedBy:               uuid.New(),
+		DisplacedReservationID: uuid.New(),
+		DisplacedInstanceID:    uuid.New(),
+		DisplacedOrganizerID:   uuid.New(),
+		Outcome:                outcome,
+		ReplacementResourceID:  replacementID,
+		Reason:                 "Board meeting",
+		CreatedAt:              time.Now(),
+	}
+}
+
+func TestBumpRepository_Apply(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBumpRepository(db)
+	ctx := context.Background()
+
+	replacementID := uuid.New()
+	moved := newTestBump(domain.BumpOutcomeMoved, &replacementID)
+	cancelled := newTestBump(domain.BumpOutcomeCancelled, nil)
+
+	// 移動・強制キャンセルと記録の作成を1つのトランザクションで行う
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_resources`)).
+		WithArgs(replacementID, moved.DisplacedInstanceID, moved.ResourceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_bumps`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_instances`)).
+		WithArgs(domain.ReservationStatusCancelled, sqlmock.AnyArg(), cancelled.DisplacedInstanceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_bumps`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Apply(ctx, []*domain.ReservationBump{moved, cancelled}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBumpRepository_Apply_RollsBackWhenInstanceNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBumpRepository(db)
+	ctx := context.Background()
+
+	first := newTestBump(domain.BumpOutcomeCancelled, nil)
+	second := newTestBump(domain.BumpOutcomeCancelled, nil)
+
+	// 2件目の回が見つからない場合は1件目の変更も取り消す
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_instances`)).
+		WithArgs(domain.ReservationStatusCancelled, sqlmock.AnyArg(), first.DisplacedInstanceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_bumps`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_instances`)).
+		WithArgs(domain.ReservationStatusCancelled, sqlmock.AnyArg(), second.DisplacedInstanceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	assert.ErrorIs(t, repo.Apply(ctx, []*domain.ReservationBump{first, second}), repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,245 @@
+// backend/internal/repository/checkout_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// CheckoutRepository は備品の貸出・返却記録へのアクセスを提供するインターフェース
+type CheckoutRepository interface {
+	Create(ctx context.Context, checkout *domain.EquipmentCheckout) error
+	GetOpenByAsset(ctx context.Context, assetID uuid.UUID) (*domain.EquipmentCheckout, error)
+	Update(ctx context.Context, checkout *domain.EquipmentCheckout) error
+	ListByAsset(ctx context.Context, assetID uuid.UUID) ([]*domain.EquipmentCheckout, error)
+	ListOverdue(ctx context.Context, now time.Time) ([]*domain.EquipmentCheckout, error)
+	HasOverdue(ctx context.Context, borrowerID uuid.UUID, now time.Time) (bool, error)
+	CountOpenByInstance(ctx context.Context, reservationInstanceID, resourceID uuid.UUID) (int, error)
+}
+
+// postgresCheckoutRepository はPostgreSQLを使用したCheckoutRepositoryの実装
+type postgresCheckoutRepository struct {
+	db *sql.DB
+}
+
+// NewCheckoutRepository は新しいCheckoutRepositoryを作成します
+func NewCheckoutRepository(db *sql.DB) CheckoutRepository {
+	return &postgresCheckoutRepository{db: db}
+}
+
+// checkoutColumns は貸出記録の取得で共通して使用する列
+const checkoutColumns = `
+	c.id, c.asset_id, a.asset_tag, c.reservation_id, c.reservation_start_at, c.reservation_instance_id,
+	c.borrower_id, c.checked_out_by, c.checked_out_at, c.checkout_condition, c.checkout_notes, c.due_at,
+	c.returned_at, c.returned_by, c.return_condition, c.damage_notes, c.overdue_notified_at,
+	c.created_at, c.updated_at
+`
+
+// Create は貸出を記録します
+func (r *postgresCheckoutRepository) Create(ctx context.Context, checkout *domain.EquipmentCheckout) error {
+	query := `
+		INSERT INTO equipment_checkouts (id, asset_id, reservation_id, reservation_start_at, reservation_instance_id,
+		                                 borrower_id, checked_out_by, checked_out_at, checkout_condition, checkout_notes,
+		                                 due_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		checkout.ID,
+		checkout.AssetID,
+		checkout.ReservationID,
+		checkout.ReservationStartAt,
+		checkout.ReservationInstanceID,
+		checkout.BorrowerID,
+		checkout.CheckedOutBy,
+		checkout.CheckedOutAt,
+		checkout.CheckoutCondition,
+		checkout.CheckoutNotes,
+		checkout.DueAt,
+		checkout.CreatedAt,
+		checkout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create equipment checkout: %w", err)
+	}
+	return nil
+}
+
+// GetOpenByAsset は個体の未返却の貸出を取得します
+func (r *postgresCheckoutRepository) GetOpenByAsset(ctx context.Context, assetID uuid.UUID) (*domain.EquipmentCheckout, error) {
+	query := `
+		SELECT ` + checkoutColumns + `
+		FROM equipment_checkouts c
+		JOIN resource_assets a ON a.id = c.asset_id
+		WHERE c.asset_id = $1 AND c.returned_at IS NULL
+	`
+	rows, err := r.db.QueryContext(ctx, query, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open equipment checkout: %w", err)
+	}
+	defer rows.Close()
+
+	checkouts, err := scanEquipmentCheckouts(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkouts) == 0 {
+		return nil, ErrNotFound
+	}
+	return checkouts[0], nil
+}
+
+// Update は返却と返却遅延通知の記録を更新します
+func (r *postgresCheckoutRepository) Update(ctx context.Context, checkout *domain.EquipmentCheckout) error {
+	checkout.UpdatedAt = time.Now()
+	query := `
+		UPDATE equipment_checkouts
+		SET returned_at = $1, returned_by = $2, return_condition = $3, damage_notes = $4,
+		    overdue_notified_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+	var returnCondition *domain.AssetCondition
+	if checkout.ReturnCondition != "" {
+		returnCondition = &checkout.ReturnCondition
+	}
+	result, err := r.db.ExecContext(ctx, query,
+		checkout.ReturnedAt,
+		checkout.ReturnedBy,
+		returnCondition,
+		checkout.DamageNotes,
+		checkout.OverdueNotifiedAt,
+		checkout.UpdatedAt,
+		checkout.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update equipment checkout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListByAsset は個体の貸出履歴を新しい順に取得します
+func (r *postgresCheckoutRepository) ListByAsset(ctx context.Context, assetID uuid.UUID) ([]*domain.EquipmentCheckout, error) {
+	query := `
+		SELECT ` + checkoutColumns + `
+		FROM equipment_checkouts c
+		JOIN resource_assets a ON a.id = c.asset_id
+		WHERE c.asset_id = $1
+		ORDER BY c.checked_out_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment checkouts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEquipmentCheckouts(rows)
+}
+
+// ListOverdue は返却期限を過ぎ、まだ借用者へ通知していない未返却の貸出を取得します
+func (r *postgresCheckoutRepository) ListOverdue(ctx context.Context, now time.Time) ([]*domain.EquipmentCheckout, error) {
+	query := `
+		SELECT ` + checkoutColumns + `
+		FROM equipment_checkouts c
+		JOIN resource_assets a ON a.id = c.asset_id
+		WHERE c.returned_at IS NULL
+		  AND c.due_at < $1
+		  AND c.overdue_notified_at IS NULL
+		ORDER BY c.due_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue equipment checkouts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEquipmentCheckouts(rows)
+}
+
+// HasOverdue は借用者が返却期限を過ぎた未返却の備品を持っているかを判定します
+func (r *postgresCheckoutRepository) HasOverdue(ctx context.Context, borrowerID uuid.UUID, now time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM equipment_checkouts
+			WHERE borrower_id = $1
+			  AND returned_at IS NULL
+			  AND due_at < $2
+		)
+	`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, borrowerID, now).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check overdue equipment checkouts: %w", err)
+	}
+	return exists, nil
+}
+
+// CountOpenByInstance は予約インスタンスに対する指定されたリソース（備品）の未返却の貸出件数を取得します
+func (r *postgresCheckoutRepository) CountOpenByInstance(ctx context.Context, reservationInstanceID, resourceID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM equipment_checkouts ec
+		JOIN resource_assets a ON a.id = ec.asset_id
+		WHERE ec.reservation_instance_id = $1 AND a.resource_id = $2 AND ec.returned_at IS NULL
+	`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, reservationInstanceID, resourceID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count open equipment checkouts: %w", err)
+	}
+	return count, nil
+}
+
+// scanEquipmentCheckouts は貸出記録の行を読み取ります
+func scanEquipmentCheckouts(rows *sql.Rows) ([]*domain.EquipmentCheckout, error) {
+	var checkouts []*domain.EquipmentCheckout
+	for rows.Next() {
+		var checkout domain.EquipmentCheckout
+		var checkoutNotes, returnCondition, damageNotes sql.NullString
+		err := rows.Scan(
+			&checkout.ID,
+			&checkout.AssetID,
+			&checkout.AssetTag,
+			&checkout.ReservationID,
+			&checkout.ReservationStartAt,
+			&checkout.ReservationInstanceID,
+			&checkout.BorrowerID,
+			&checkout.CheckedOutBy,
+			&checkout.CheckedOutAt,
+			&checkout.CheckoutCondition,
+			&checkoutNotes,
+			&checkout.DueAt,
+			&checkout.ReturnedAt,
+			&checkout.ReturnedBy,
+			&returnCondition,
+			&damageNotes,
+			&checkout.OverdueNotifiedAt,
+			&checkout.CreatedAt,
+			&checkout.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan equipment checkout: %w", err)
+		}
+		checkout.CheckoutNotes = checkoutNotes.String
+		checkout.ReturnCondition = domain.AssetCondition(returnCondition.String)
+		checkout.DamageNotes = damageNotes.String
+		checkouts = append(checkouts, &checkout)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return checkouts, nil
+}
@@ -0,0 +1,83 @@
+// backend/internal/repository/checkout_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var checkoutColumns = []string{"id", "asset_id", "asset_tag", "reservation_id", "reservation_start_at", "reservation_instance_id",
+	"borrower_id", "checked_out_by", "checked_out_at", "checkout_condition", "checkout_notes", "due_at",
+	"returned_at", "returned_by", "return_condition", "damage_notes", "overdue_notified_at", "created_at", "updated_at"}
+
+func TestCheckoutRepository_ListOverdue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewCheckoutRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	checkoutID, borrowerID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`AND c.overdue_notified_at IS NULL`)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows(checkoutColumns).
+			AddRow(checkoutID, uuid.New(), "PJ-001", uuid.New(), now.Add(-3*time.Hour), uuid.New(),
+				borrowerID, borrowerID, now.Add(-3*time.Hour), "GOOD", nil, now.Add(-time.Hour),
+				nil, nil, nil, nil, nil, now, now))
+
+	checkouts, err := repo.ListOverdue(ctx, now)
+	assert.NoError(t, err)
+	assert.Len(t, checkouts, 1)
+	assert.Equal(t, checkoutID, checkouts[0].ID)
+	assert.Equal(t, "PJ-001", checkouts[0].AssetTag)
+	assert.Equal(t, domain.AssetConditionGood, checkouts[0].CheckoutCondition)
+	assert.True(t, checkouts[0].IsOverdue(now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckoutRepository_GetOpenByAsset_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewCheckoutRepository(db)
+	assetID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE c.asset_id = $1 AND c.returned_at IS NULL`)).
+		WithArgs(assetID).
+		WillReturnRows(sqlmock.NewRows(checkoutColumns))
+
+	_, err = repo.GetOpenByAsset(context.Background(), assetID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckoutRepository_HasOverdue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewCheckoutRepository(db)
+	borrowerID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM equipment_checkouts`)).
+		WithArgs(borrowerID, now).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	overdue, err := repo.HasOverdue(context.Background(), borrowerID, now)
+	assert.NoError(t, err)
+	assert.True(t, overdue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,176 @@
+// backend/internal/repository/hold_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// HoldRepository は予約フォーム入力中の仮押さえへのアクセスを提供するインターフェース
+type HoldRepository interface {
+	Create(ctx context.Context, hold *domain.ResourceHold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ResourceHold, error)
+	Update(ctx context.Context, hold *domain.ResourceHold) error
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error)
+	ListExpired(ctx context.Context, now time.Time) ([]*domain.ResourceHold, error)
+}
+
+// postgresHoldRepository はPostgreSQLを使用したHoldRepositoryの実装
+type postgresHoldRepository struct {
+	db *sql.DB
+}
+
+// NewHoldRepository は新しいHoldRepositoryを作成します
+func NewHoldRepository(db *sql.DB) HoldRepository {
+	return &postgresHoldRepository{db: db}
+}
+
+// Create は仮押さえを作成します
+func (r *postgresHoldRepository) Create(ctx context.Context, hold *domain.ResourceHold) error {
+	query := `
+		INSERT INTO resource_holds (id, user_id, reservation_id, start_at, end_at, expires_at, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		hold.ID,
+		hold.UserID,
+		hold.ReservationID,
+		hold.StartAt,
+		hold.EndAt,
+		hold.ExpiresAt,
+		hold.Status,
+		hold.CreatedAt,
+		hold.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create resource hold: %w", err)
+	}
+	return nil
+}
+
+// GetByID はIDで仮押さえを取得します
+func (r *postgresHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ResourceHold, error) {
+	query := `
+		SELECT id, user_id, reservation_id, start_at, end_at, expires_at, status,
+		       converted_reservation_id, created_at, updated_at
+		FROM resource_holds
+		WHERE id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource hold by id: %w", err)
+	}
+	defer rows.Close()
+
+	holds, err := scanResourceHolds(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, ErrNotFound
+	}
+	return holds[0], nil
+}
+
+// Update は仮押さえの状態と変換先の予約を更新します
+// 状態が ACTIVE の仮押さえのみ更新し、変換と期限切れの処理が競合した場合は ErrNotFound を返します
+func (r *postgresHoldRepository) Update(ctx context.Context, hold *domain.ResourceHold) error {
+	hold.UpdatedAt = time.Now()
+	query := `
+		UPDATE resource_holds
+		SET status = $1, converted_reservation_id = $2, updated_at = $3
+		WHERE id = $4 AND status = 'ACTIVE'
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		hold.Status,
+		hold.ConvertedReservationID,
+		hold.UpdatedAt,
+		hold.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update resource hold: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ListActiveByUser はユーザーの有効な仮押さえを有効期限順に取得します
+func (r *postgresHoldRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error) {
+	query := `
+		SELECT id, user_id, reservation_id, start_at, end_at, expires_at, status,
+		       converted_reservation_id, created_at, updated_at
+		FROM resource_holds
+		WHERE user_id = $1
+		  AND status = 'ACTIVE'
+		ORDER BY expires_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource holds by user: %w", err)
+	}
+	defer rows.Close()
+
+	return scanResourceHolds(rows)
+}
+
+// ListExpired は有効期限を過ぎた仮押さえを取得します
+func (r *postgresHoldRepository) ListExpired(ctx context.Context, now time.Time) ([]*domain.ResourceHold, error) {
+	query := `
+		SELECT id, user_id, reservation_id, start_at, end_at, expires_at, status,
+		       converted_reservation_id, created_at, updated_at
+		FROM resource_holds
+		WHERE status = 'ACTIVE'
+		  AND expires_at <= $1
+		ORDER BY expires_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired resource holds: %w", err)
+	}
+	defer rows.Close()
+
+	return scanResourceHolds(rows)
+}
+
+// scanResourceHolds は仮押さえの行を読み取ります
+func scanResourceHolds(rows *sql.Rows) ([]*domain.ResourceHold, error) {
+	var holds []*domain.ResourceHold
+	for rows.Next() {
+		var hold domain.ResourceHold
+		err := rows.Scan(
+			&hold.ID,
+			&hold.UserID,
+			&hold.ReservationID,
+			&hold.StartAt,
+			&hold.EndAt,
+			&hold.ExpiresAt,
+			&hold.Status,
+			&hold.ConvertedReservationID,
+			&hold.CreatedAt,
+			&hold.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan resource hold: %w", err)
+		}
+		holds = append(holds, &hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return holds, nil
+}
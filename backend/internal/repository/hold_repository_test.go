@@ -0,0 +1,64 @@
+// backend/internal/repository/hold_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var holdColumns = []string{"id", "user_id", "reservation_id", "start_at", "end_at", "expires_at", "status",
+	"converted_reservation_id", "created_at", "updated_at"}
+
+func TestHoldRepository_ListExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewHoldRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	startAt := now.Add(24 * time.Hour)
+	holdID, reservationID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, reservation_id, start_at, end_at, expires_at, status,`)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows(holdColumns).
+			AddRow(holdID, uuid.New(), reservationID, startAt, startAt.Add(time.Hour), now.Add(-time.Minute), "ACTIVE", nil, now, now))
+
+	holds, err := repo.ListExpired(ctx, now)
+	assert.NoError(t, err)
+	assert.Len(t, holds, 1)
+	assert.Equal(t, holdID, holds[0].ID)
+	assert.Equal(t, reservationID, holds[0].ReservationID)
+	assert.Equal(t, domain.HoldStatusActive, holds[0].Status)
+	assert.Nil(t, holds[0].ConvertedReservationID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHoldRepository_Update_NotActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewHoldRepository(db)
+	ctx := context.Background()
+
+	hold := &domain.ResourceHold{ID: uuid.New(), Status: domain.HoldStatusExpired}
+
+	// 既に変換・解放済みの仮押さえは更新されない
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE resource_holds`)).
+		WithArgs(domain.HoldStatusExpired, hold.ConvertedReservationID, sqlmock.AnyArg(), hold.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Update(ctx, hold), repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
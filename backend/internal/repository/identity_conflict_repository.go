@@ -0,0 +1,178 @@
+// backend/internal/repository/identity_conflict_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// IdentityConflictRepository はIdPのユーザーとの突合レポートへのアクセスを提供するインターフェース
+type IdentityConflictRepository interface {
+	Record(ctx context.Context, conflict *domain.IdentityConflict) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.IdentityConflict, error)
+	List(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error)
+	Resolve(ctx context.Context, conflict *domain.IdentityConflict) error
+}
+
+// postgresIdentityConflictRepository はPostgreSQLを使用したIdentityConflictRepositoryの実装
+type postgresIdentityConflictRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityConflictRepository は新しいIdentityConflictRepositoryを作成します
+func NewIdentityConflictRepository(db *sql.DB) IdentityConflictRepository {
+	return &postgresIdentityConflictRepository{db: db}
+}
+
+// identityConflictColumns は突合レポートの取得で共通して使用する列
+const identityConflictColumns = `
+	id, kind, issuer, sub, email, name, user_id, candidate_user_ids, detected_at,
+	resolved_at, resolved_by, resolution, resolution_note, created_at
+`
+
+// Record は突合レポートを記録します
+// 同じ事象（種類・issuer・sub）の未解決のレコードがある場合は新たに作成せず、検出内容と検出日時を更新します
+func (r *postgresIdentityConflictRepository) Record(ctx context.Context, conflict *domain.IdentityConflict) error {
+	candidates, err := json.Marshal(conflict.CandidateUserIDs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidate user ids: %w", err)
+	}
+
+	query := `
+		INSERT INTO identity_conflicts (id, kind, issuer, sub, email, name, user_id, candidate_user_ids, detected_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (kind, issuer, sub) WHERE resolved_at IS NULL
+		DO UPDATE SET email = EXCLUDED.email, name = EXCLUDED.name, user_id = EXCLUDED.user_id,
+		              candidate_user_ids = EXCLUDED.candidate_user_ids, detected_at = EXCLUDED.detected_at
+		RETURNING id, created_at
+	`
+	err = r.db.QueryRowContext(ctx, query,
+		conflict.ID,
+		conflict.Kind,
+		conflict.Issuer,
+		conflict.Subject,
+		conflict.Email,
+		conflict.Name,
+		conflict.UserID,
+		candidates,
+		conflict.DetectedAt,
+		conflict.CreatedAt,
+	).Scan(&conflict.ID, &conflict.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record identity conflict: %w", err)
+	}
+	return nil
+}
+
+// GetByID は突合レポートを取得します
+func (r *postgresIdentityConflictRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.IdentityConflict, error) {
+	query := `SELECT ` + identityConflictColumns + ` FROM identity_conflicts WHERE id = $1`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity conflict: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts, err := scanIdentityConflicts(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		return nil, ErrNotFound
+	}
+	return conflicts[0], nil
+}
+
+// List は突合レポートを検出日時の新しい順に取得します
+func (r *postgresIdentityConflictRepository) List(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error) {
+	query := `
+		SELECT ` + identityConflictColumns + `
+		FROM identity_conflicts
+		WHERE $1 OR resolved_at IS NULL
+		ORDER BY detected_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, includeResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIdentityConflicts(rows)
+}
+
+// Resolve は未解決の突合レポートを解決済みにします
+func (r *postgresIdentityConflictRepository) Resolve(ctx context.Context, conflict *domain.IdentityConflict) error {
+	query := `
+		UPDATE identity_conflicts
+		SET resolved_at = $1, resolved_by = $2, resolution = $3, resolution_note = $4
+		WHERE id = $5 AND resolved_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		conflict.ResolvedAt,
+		conflict.ResolvedBy,
+		conflict.Resolution,
+		conflict.ResolutionNote,
+		conflict.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve identity conflict: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanIdentityConflicts は突合レポートの行を読み取ります
+func scanIdentityConflicts(rows *sql.Rows) ([]*domain.IdentityConflict, error) {
+	var conflicts []*domain.IdentityConflict
+	for rows.Next() {
+		var conflict domain.IdentityConflict
+		var candidates []byte
+		var resolution sql.NullString
+		err := rows.Scan(
+			&conflict.ID,
+			&conflict.Kind,
+			&conflict.Issuer,
+			&conflict.Subject,
+			&conflict.Email,
+			&conflict.Name,
+			&conflict.UserID,
+			&candidates,
+			&conflict.DetectedAt,
+			&conflict.ResolvedAt,
+			&conflict.ResolvedBy,
+			&resolution,
+			&conflict.ResolutionNote,
+			&conflict.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity conflict: %w", err)
+		}
+		if len(candidates) > 0 {
+			if err := json.Unmarshal(candidates, &conflict.CandidateUserIDs); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal candidate user ids: %w", err)
+			}
+		}
+		if resolution.Valid {
+			r := domain.IdentityResolution(resolution.String)
+			conflict.Resolution = &r
+		}
+		conflicts = append(conflicts, &conflict)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return conflicts, nil
+}
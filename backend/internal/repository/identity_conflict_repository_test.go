@@ -0,0 +1,95 @@
+// backend/internal/repository/identity_conflict_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var identityConflictColumns = []string{"id", "kind", "issuer", "sub", "email", "name", "user_id", "candidate_user_ids", "detected_at",
+	"resolved_at", "resolved_by", "resolution", "resolution_note", "created_at"}
+
+func TestIdentityConflictRepository_Record(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewIdentityConflictRepository(db)
+
+	now := time.Now()
+	existingID := uuid.New()
+	candidateID := uuid.New()
+	conflict := &domain.IdentityConflict{
+		ID:               uuid.New(),
+		Kind:             domain.IdentityConflictMultipleEmail,
+		Issuer:           "https://idp.example.com",
+		Subject:          "user-sub",
+		Email:            "user@example.com",
+		CandidateUserIDs: []uuid.UUID{candidateID},
+		DetectedAt:       now,
+		CreatedAt:        now,
+	}
+
+	// 同じ事象の未解決レコードがある場合はそのIDを返す
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (kind, issuer, sub) WHERE resolved_at IS NULL`)).
+		WithArgs(conflict.ID, conflict.Kind, conflict.Issuer, conflict.Subject, conflict.Email, conflict.Name, conflict.UserID,
+			[]byte(`["`+candidateID.String()+`"]`), now, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(existingID, now.Add(-time.Hour)))
+
+	err = repo.Record(context.Background(), conflict)
+	assert.NoError(t, err)
+	assert.Equal(t, existingID, conflict.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdentityConflictRepository_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewIdentityConflictRepository(db)
+
+	now := time.Now()
+	candidateID := uuid.New()
+	adminID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE $1 OR resolved_at IS NULL`)).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows(identityConflictColumns).
+			AddRow(uuid.New(), "MULTIPLE_EMAIL_MATCHES", "https://idp.example.com", "user-sub", "user@example.com", "User", nil,
+				[]byte(`["`+candidateID.String()+`"]`), now, nil, nil, nil, "", now).
+			AddRow(uuid.New(), "EMAIL_TAKEN", "https://idp.example.com", "other-sub", "taken@example.com", "Other", uuid.New(),
+				[]byte(`[]`), now, now, adminID, "DISMISS", "duplicate mailbox", now))
+
+	conflicts, err := repo.List(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 2)
+	assert.Equal(t, []uuid.UUID{candidateID}, conflicts[0].CandidateUserIDs)
+	assert.False(t, conflicts[0].IsResolved())
+	assert.Nil(t, conflicts[0].Resolution)
+	assert.True(t, conflicts[1].IsResolved())
+	assert.Equal(t, domain.IdentityResolutionDismiss, *conflicts[1].Resolution)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdentityConflictRepository_Resolve_AlreadyResolved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewIdentityConflictRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE id = $5 AND resolved_at IS NULL`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Resolve(context.Background(), &domain.IdentityConflict{ID: uuid.New()})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
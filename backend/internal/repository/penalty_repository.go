@@ -0,0 +1,251 @@
+// backend/internal/repository/penalty_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// PenaltyRepository はキャンセルペナルティスコアの履歴と予約制限の閾値へのアクセスを提供するインターフェース
+type PenaltyRepository interface {
+	CreateEvent(ctx context.Context, event *domain.PenaltyEvent) error
+	ListEventsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PenaltyEvent, error)
+	ExpireEvents(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	RecalculateScore(ctx context.Context, userID uuid.UUID) (int, *time.Time, error)
+
+	CreateThreshold(ctx context.Context, threshold *domain.PenaltyThreshold) error
+	ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error)
+	DeleteThreshold(ctx context.Context, id uuid.UUID) error
+}
+
+// postgresPenaltyRepository はPostgreSQLを使用したPenaltyRepositoryの実装
+type postgresPenaltyRepository struct {
+	db *sql.DB
+}
+
+// NewPenaltyRepository は新しいPenaltyRepositoryを作成します
+func NewPenaltyRepository(db *sql.DB) PenaltyRepository {
+	return &postgresPenaltyRepository{db: db}
+}
+
+// CreateEvent はペナルティスコアの加算・調整を記録します
+func (r *postgresPenaltyRepository) CreateEvent(ctx context.Context, event *domain.PenaltyEvent) error {
+	query := `
+		INSERT INTO penalty_events (id, user_id, kind, points, reservation_id, reservation_start_at, reason, created_by, occurred_at, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID,
+		event.UserID,
+		event.Kind,
+		event.Points,
+		event.ReservationID,
+		event.ReservationStartAt,
+		event.Reason,
+		event.CreatedBy,
+		event.OccurredAt,
+		event.ExpiresAt,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create penalty event: %w", err)
+	}
+	return nil
+}
+
+// ListEventsByUser はユーザーのペナルティスコアの履歴を新しい順に取得します（消滅済みのイベントを含む）
+func (r *postgresPenaltyRepository) ListEventsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PenaltyEvent, error) {
+	query := `
+		SELECT id, user_id, kind, points, reservation_id, reservation_start_at, reason, created_by,
+		       occurred_at, expires_at, expired_at, created_at
+		FROM penalty_events
+		WHERE user_id = $1
+		ORDER BY occurred_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list penalty events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.PenaltyEvent
+	for rows.Next() {
+		var event domain.PenaltyEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Kind,
+			&event.Points,
+			&event.ReservationID,
+			&event.ReservationStartAt,
+			&event.Reason,
+			&event.CreatedBy,
+			&event.OccurredAt,
+			&event.ExpiresAt,
+			&event.ExpiredAt,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan penalty event: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return events, nil
+}
+
+// ExpireEvents は消滅日時を過ぎたイベントをスコアから除外し、スコアが変動したユーザーを返します
+func (r *postgresPenaltyRepository) ExpireEvents(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	query := `
+		UPDATE penalty_events
+		SET expired_at = $1
+		WHERE expired_at IS NULL
+		  AND expires_at <= $1
+		RETURNING user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire penalty events: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan penalty event: %w", err)
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// RecalculateScore はユーザーのスコアを有効なイベントの合計（0未満にはしない）で更新し、
+// 更新後のスコアと全てのイベントが消滅する日時を返します
+func (r *postgresPenaltyRepository) RecalculateScore(ctx context.Context, userID uuid.UUID) (int, *time.Time, error) {
+	query := `
+		UPDATE users
+		SET penalty_score = GREATEST(COALESCE(active.score, 0), 0),
+		    penalty_score_expire_at = active.expire_at,
+		    updated_at = NOW()
+		FROM (
+		    SELECT SUM(points) AS score, MAX(expires_at) AS expire_at
+		    FROM penalty_events
+		    WHERE user_id = $1
+		      AND expired_at IS NULL
+		) active
+		WHERE users.id = $1
+		RETURNING users.penalty_score, users.penalty_score_expire_at
+	`
+	var score int
+	var expireAt *time.Time
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&score, &expireAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil, ErrNotFound
+		}
+		return 0, nil, fmt.Errorf("failed to recalculate penalty score: %w", err)
+	}
+	return score, expireAt, nil
+}
+
+// CreateThreshold は予約制限の閾値を作成します
+func (r *postgresPenaltyRepository) CreateThreshold(ctx context.Context, threshold *domain.PenaltyThreshold) error {
+	query := `
+		INSERT INTO penalty_thresholds (id, name, min_score, requires_approval, max_advance_days, premium_min_capacity, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		threshold.ID,
+		threshold.Name,
+		threshold.MinScore,
+		threshold.RequiresApproval,
+		threshold.MaxAdvanceDays,
+		threshold.PremiumMinCapacity,
+		threshold.IsActive,
+		threshold.CreatedAt,
+		threshold.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create penalty threshold: %w", err)
+	}
+	return nil
+}
+
+// ListThresholds は予約制限の閾値をスコアの昇順で取得します
+func (r *postgresPenaltyRepository) ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error) {
+	query := `
+		SELECT id, name, min_score, requires_approval, max_advance_days, premium_min_capacity, is_active, created_at, updated_at
+		FROM penalty_thresholds
+		ORDER BY min_score, created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list penalty thresholds: %w", err)
+	}
+	defer rows.Close()
+
+	var thresholds []*domain.PenaltyThreshold
+	for rows.Next() {
+		var threshold domain.PenaltyThreshold
+		err := rows.Scan(
+			&threshold.ID,
+			&threshold.Name,
+			&threshold.MinScore,
+			&threshold.RequiresApproval,
+			&threshold.MaxAdvanceDays,
+			&threshold.PremiumMinCapacity,
+			&threshold.IsActive,
+			&threshold.CreatedAt,
+			&threshold.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan penalty threshold: %w", err)
+		}
+		thresholds = append(thresholds, &threshold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return thresholds, nil
+}
+
+// DeleteThreshold は予約制限の閾値を削除します
+func (r *postgresPenaltyRepository) DeleteThreshold(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM penalty_thresholds WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete penalty threshold: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
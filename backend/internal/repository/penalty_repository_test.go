@@ -0,0 +1,106 @@
+// backend/internal/repository/penalty_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/repository"
+)
+
+func TestPenaltyRepository_ExpireEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewPenaltyRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	userA, userB := uuid.New(), uuid.New()
+
+	// 同じユーザーの複数イベントが消滅しても再計算は1回で済むよう重複を除く
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE penalty_events`)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userA).AddRow(userB).AddRow(userA))
+
+	userIDs, err := repo.ExpireEvents(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{userA, userB}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPenaltyRepository_RecalculateScore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewPenaltyRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	expireAt := time.Now().Add(90 * 24 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE users`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"penalty_score", "penalty_score_expire_at"}).AddRow(2, expireAt))
+
+	score, gotExpireAt, err := repo.RecalculateScore(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, score)
+	assert.True(t, gotExpireAt.Equal(expireAt))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE users`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"penalty_score", "penalty_score_expire_at"}))
+
+	_, _, err = repo.RecalculateScore(ctx, userID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPenaltyRepository_ListThresholds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewPenaltyRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	thresholdID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, min_score, requires_approval, max_advance_days, premium_min_capacity, is_active, created_at, updated_at`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "min_score", "requires_approval", "max_advance_days", "premium_min_capacity", "is_active", "created_at", "updated_at"}).
+			AddRow(thresholdID, "No premium rooms", 3, false, nil, 12, true, now, now))
+
+	thresholds, err := repo.ListThresholds(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, thresholds, 1)
+	assert.Equal(t, thresholdID, thresholds[0].ID)
+	assert.Nil(t, thresholds[0].MaxAdvanceDays)
+	assert.Equal(t, 12, *thresholds[0].PremiumMinCapacity)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPenaltyRepository_DeleteThreshold_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewPenaltyRepository(db)
+
+	id := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM penalty_thresholds WHERE id = $1`)).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteThreshold(context.Background(), id)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,281 @@
+// backend/internal/repository/provisioned_group_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// ProvisionedGroupRepository はIdPからプロビジョニングされたグループへのアクセスを提供するインターフェース
+type ProvisionedGroupRepository interface {
+	Create(ctx context.Context, group *domain.ProvisionedGroup) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error)
+	List(ctx context.Context, filter ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error)
+	ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error)
+	Update(ctx context.Context, group *domain.ProvisionedGroup) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// ProvisionedGroupFilter はグループの一覧の絞り込み条件
+type ProvisionedGroupFilter struct {
+	DisplayName *string // 表示名
+	ExternalID  *string // プロビジョニング元のIdPでのグループの識別子
+}
+
+// postgresProvisionedGroupRepository はPostgreSQLを使用したProvisionedGroupRepositoryの実装
+type postgresProvisionedGroupRepository struct {
+	db *sql.DB
+}
+
+// NewProvisionedGroupRepository は新しいProvisionedGroupRepositoryを作成します
+func NewProvisionedGroupRepository(db *sql.DB) ProvisionedGroupRepository {
+	return &postgresProvisionedGroupRepository{db: db}
+}
+
+// provisionedGroupColumns はグループの取得で共通して使用する列
+const provisionedGroupColumns = `id, external_id, display_name, created_at, updated_at`
+
+// Create はグループとメンバーを作成します
+func (r *postgresProvisionedGroupRepository) Create(ctx context.Context, group *domain.ProvisionedGroup) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO provisioned_groups (id, external_id, display_name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		group.ID,
+		nullableString(group.ExternalID),
+		group.DisplayName,
+		group.CreatedAt,
+		group.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create provisioned group: %w", err)
+	}
+	if err := insertGroupMembers(ctx, tx, group); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresProvisionedGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error) {
+	query := `
+		SELECT ` + provisionedGroupColumns + `
+		FROM provisioned_groups
+		WHERE id = $1
+	`
+	group, err := scanProvisionedGroup(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get provisioned group: %w", err)
+	}
+	if err := r.loadMembers(ctx, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// List はグループを表示名の順に取得し、絞り込み条件に一致する総件数とともに返します
+func (r *postgresProvisionedGroupRepository) List(ctx context.Context, filter ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error) {
+	where := ` WHERE 1=1`
+	var args []interface{}
+	argCount := 1
+
+	if filter.DisplayName != nil {
+		where += fmt.Sprintf(" AND display_name = $%d", argCount)
+		args = append(args, *filter.DisplayName)
+		argCount++
+	}
+	if filter.ExternalID != nil {
+		where += fmt.Sprintf(" AND external_id = $%d", argCount)
+		args = append(args, *filter.ExternalID)
+		argCount++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM provisioned_groups`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count provisioned groups: %w", err)
+	}
+
+	query := `SELECT ` + provisionedGroupColumns + ` FROM provisioned_groups` + where +
+		fmt.Sprintf(" ORDER BY display_name, id LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	groups, err := r.queryGroups(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+// ListByMember は指定したユーザーが所属するグループを全て取得します
+func (r *postgresProvisionedGroupRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error) {
+	query := `
+		SELECT ` + provisionedGroupColumns + `
+		FROM provisioned_groups
+		WHERE id IN (SELECT group_id FROM provisioned_group_members WHERE user_id = $1)
+		ORDER BY display_name, id
+	`
+	return r.queryGroups(ctx, query, userID)
+}
+
+// Update はグループの属性を更新し、メンバーを置き換えます
+func (r *postgresProvisionedGroupRepository) Update(ctx context.Context, group *domain.ProvisionedGroup) error {
+	group.UpdatedAt = time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE provisioned_groups
+		SET external_id = $1, display_name = $2, updated_at = $3
+		WHERE id = $4
+	`
+	result, err := tx.ExecContext(ctx, query,
+		nullableString(group.ExternalID),
+		group.DisplayName,
+		group.UpdatedAt,
+		group.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update provisioned group: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM provisioned_group_members WHERE group_id = $1`, group.ID); err != nil {
+		return fmt.Errorf("failed to delete provisioned group members: %w", err)
+	}
+	if err := insertGroupMembers(ctx, tx, group); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete はグループを削除します（メンバーは ON DELETE CASCADE で削除されます）
+func (r *postgresProvisionedGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM provisioned_groups WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete provisioned group: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// queryGroups はグループの一覧を取得し、それぞれのメンバーを読み込みます
+func (r *postgresProvisionedGroupRepository) queryGroups(ctx context.Context, query string, args ...interface{}) ([]*domain.ProvisionedGroup, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provisioned groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*domain.ProvisionedGroup
+	for rows.Next() {
+		group, err := scanProvisionedGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan provisioned group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	for _, group := range groups {
+		if err := r.loadMembers(ctx, group); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// loadMembers はグループのメンバーのユーザーIDを読み込みます
+func (r *postgresProvisionedGroupRepository) loadMembers(ctx context.Context, group *domain.ProvisionedGroup) error {
+	query := `
+		SELECT user_id
+		FROM provisioned_group_members
+		WHERE group_id = $1
+		ORDER BY user_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, group.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list provisioned group members: %w", err)
+	}
+	defer rows.Close()
+
+	group.MemberIDs = []uuid.UUID{}
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return fmt.Errorf("failed to scan provisioned group member: %w", err)
+		}
+		group.MemberIDs = append(group.MemberIDs, userID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows iteration error: %w", err)
+	}
+	return nil
+}
+
+// insertGroupMembers はグループのメンバーを登録します
+func insertGroupMembers(ctx context.Context, tx *sql.Tx, group *domain.ProvisionedGroup) error {
+	query := `
+		INSERT INTO provisioned_group_members (group_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	for _, userID := range group.MemberIDs {
+		if _, err := tx.ExecContext(ctx, query, group.ID, userID); err != nil {
+			return fmt.Errorf("failed to add provisioned group member: %w", err)
+		}
+	}
+	return nil
+}
+
+// scanProvisionedGroup はグループの行を読み取ります（external_id が NULL の場合は空文字列）
+func scanProvisionedGroup(row userScanner) (*domain.ProvisionedGroup, error) {
+	var group domain.ProvisionedGroup
+	var externalID sql.NullString
+	err := row.Scan(
+		&group.ID,
+		&externalID,
+		&group.DisplayName,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	group.ExternalID = externalID.String
+	return &group, nil
+}
@@ -0,0 +1,127 @@
+// backend/internal/repository/provisioned_group_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var provisionedGroupColumns = []string{"id", "external_id", "display_name", "created_at", "updated_at"}
+
+func TestProvisionedGroupRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewProvisionedGroupRepository(db)
+
+	now := time.Now()
+	memberID := uuid.New()
+	group := &domain.ProvisionedGroup{
+		ID:          uuid.New(),
+		DisplayName: "esms-admins",
+		MemberIDs:   []uuid.UUID{memberID},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO provisioned_groups`)).
+		WithArgs(group.ID, nil, "esms-admins", now, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO provisioned_group_members`)).
+		WithArgs(group.ID, memberID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.Create(context.Background(), group)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProvisionedGroupRepository_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewProvisionedGroupRepository(db)
+
+	now := time.Now()
+	groupID, memberID := uuid.New(), uuid.New()
+	displayName := "esms-managers"
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM provisioned_groups WHERE 1=1 AND display_name = $1`)).
+		WithArgs(displayName).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM provisioned_groups WHERE 1=1 AND display_name = $1 ORDER BY display_name, id LIMIT $2 OFFSET $3`)).
+		WithArgs(displayName, 100, 0).
+		WillReturnRows(sqlmock.NewRows(provisionedGroupColumns).AddRow(groupID, "a1b2c3", displayName, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM provisioned_group_members WHERE group_id = $1`)).
+		WithArgs(groupID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(memberID))
+
+	groups, total, err := repo.List(context.Background(), repository.ProvisionedGroupFilter{DisplayName: &displayName}, 0, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, groups, 1)
+	assert.Equal(t, "a1b2c3", groups[0].ExternalID)
+	assert.Equal(t, []uuid.UUID{memberID}, groups[0].MemberIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProvisionedGroupRepository_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewProvisionedGroupRepository(db)
+
+	memberID := uuid.New()
+	group := &domain.ProvisionedGroup{
+		ID:          uuid.New(),
+		ExternalID:  "a1b2c3",
+		DisplayName: "esms-admins",
+		MemberIDs:   []uuid.UUID{memberID},
+	}
+
+	// メンバーは全て置き換える
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE provisioned_groups SET external_id = $1, display_name = $2, updated_at = $3 WHERE id = $4`)).
+		WithArgs("a1b2c3", "esms-admins", sqlmock.AnyArg(), group.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM provisioned_group_members WHERE group_id = $1`)).
+		WithArgs(group.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO provisioned_group_members`)).
+		WithArgs(group.ID, memberID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.Update(context.Background(), group)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProvisionedGroupRepository_Update_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewProvisionedGroupRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE provisioned_groups`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = repo.Update(context.Background(), &domain.ProvisionedGroup{ID: uuid.New(), DisplayName: "missing"})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,226 @@
+// backend/internal/repository/quota_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// QuotaRepository は予約上限のルールと消費量の集計へのアクセスを提供するインターフェース
+type QuotaRepository interface {
+	Create(ctx context.Context, rule *domain.QuotaRule) error
+	List(ctx context.Context) ([]*domain.QuotaRule, error)
+	ListActive(ctx context.Context) ([]*domain.QuotaRule, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	BookedMinutes(ctx context.Context, filter QuotaUsageFilter) (int, error)
+	CountUpcoming(ctx context.Context, filter QuotaUsageFilter) (int, error)
+}
+
+// QuotaUsageFilter は予約上限の消費量の集計条件
+// 仮押さえとキャンセルされた回は集計しません
+type QuotaUsageFilter struct {
+	OrganizerID  *uuid.UUID           // 主催者で絞り込む（USER スコープ）
+	Department   *string              // 主催者の所属部署で絞り込む（DEPARTMENT スコープ）
+	ResourceType *domain.ResourceType // 対象のリソース種別
+	MinCapacity  *int                 // 対象の会議室の収容人数の下限
+	From         time.Time            // 集計期間の開始（CountUpcoming では現在日時）
+	To           time.Time            // 集計期間の終了（CountUpcoming では使用しない）
+}
+
+// postgresQuotaRepository はPostgreSQLを使用したQuotaRepositoryの実装
+type postgresQuotaRepository struct {
+	db *sql.DB
+}
+
+// NewQuotaRepository は新しいQuotaRepositoryを作成します
+func NewQuotaRepository(db *sql.DB) QuotaRepository {
+	return &postgresQuotaRepository{db: db}
+}
+
+// Create は予約上限のルールを作成します
+func (r *postgresQuotaRepository) Create(ctx context.Context, rule *domain.QuotaRule) error {
+	query := `
+		INSERT INTO booking_quotas (id, name, scope, kind, resource_type, min_capacity, department, limit_value, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		rule.ID,
+		rule.Name,
+		rule.Scope,
+		rule.Kind,
+		rule.ResourceType,
+		rule.MinCapacity,
+		rule.Department,
+		rule.Limit,
+		rule.IsActive,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create booking quota: %w", err)
+	}
+	return nil
+}
+
+// List は予約上限のルールを全て取得します
+func (r *postgresQuotaRepository) List(ctx context.Context) ([]*domain.QuotaRule, error) {
+	query := `
+		SELECT id, name, scope, kind, resource_type, min_capacity, department, limit_value, is_active, created_at, updated_at
+		FROM booking_quotas
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list booking quotas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanQuotaRules(rows)
+}
+
+// ListActive は有効な予約上限のルールを取得します
+func (r *postgresQuotaRepository) ListActive(ctx context.Context) ([]*domain.QuotaRule, error) {
+	query := `
+		SELECT id, name, scope, kind, resource_type, min_capacity, department, limit_value, is_active, created_at, updated_at
+		FROM booking_quotas
+		WHERE is_active = true
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active booking quotas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanQuotaRules(rows)
+}
+
+// Delete は予約上限のルールを削除します
+func (r *postgresQuotaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM booking_quotas WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete booking quota: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// BookedMinutes は集計期間に重なる予約の回の利用時間（分）の合計を返します
+// 対象のリソースを複数含む回も1回として数え、集計期間の外にはみ出した時間は含めません
+func (r *postgresQuotaRepository) BookedMinutes(ctx context.Context, filter QuotaUsageFilter) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (LEAST(ri.end_at, $2) - GREATEST(ri.start_at, $1))) / 60), 0)::bigint
+		FROM reservation_instances ri
+		JOIN reservations res ON res.id = ri.reservation_id AND res.start_at = ri.reservation_start_at
+		JOIN users u ON u.id = res.organizer_id
+		WHERE res.deleted_at IS NULL
+		  AND res.approval_status NOT IN ('HELD', 'OFFERED')
+		  AND ri.status <> 'CANCELLED'
+		  AND ri.start_at < $2
+		  AND ri.end_at > $1
+		  AND ($3::uuid IS NULL OR res.organizer_id = $3)
+		  AND ($4::text IS NULL OR u.department = $4)
+		  AND EXISTS (
+		      SELECT 1
+		      FROM reservation_resources rr
+		      JOIN resources r ON r.id = rr.resource_id
+		      WHERE rr.reservation_instance_id = ri.id
+		        AND ($5::text IS NULL OR r.type = $5)
+		        AND ($6::int IS NULL OR r.capacity >= $6)
+		  )
+	`
+	var minutes int
+	err := r.db.QueryRowContext(ctx, query,
+		filter.From,
+		filter.To,
+		filter.OrganizerID,
+		filter.Department,
+		filter.ResourceType,
+		filter.MinCapacity,
+	).Scan(&minutes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to sum booked minutes: %w", err)
+	}
+	return minutes, nil
+}
+
+// CountUpcoming は終了していない有効な予約の回の数を返します
+func (r *postgresQuotaRepository) CountUpcoming(ctx context.Context, filter QuotaUsageFilter) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM reservation_instances ri
+		JOIN reservations res ON res.id = ri.reservation_id AND res.start_at = ri.reservation_start_at
+		JOIN users u ON u.id = res.organizer_id
+		WHERE res.deleted_at IS NULL
+		  AND res.approval_status NOT IN ('HELD', 'OFFERED')
+		  AND ri.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+		  AND ri.end_at > $1
+		  AND ($2::uuid IS NULL OR res.organizer_id = $2)
+		  AND ($3::text IS NULL OR u.department = $3)
+		  AND EXISTS (
+		      SELECT 1
+		      FROM reservation_resources rr
+		      JOIN resources r ON r.id = rr.resource_id
+		      WHERE rr.reservation_instance_id = ri.id
+		        AND ($4::text IS NULL OR r.type = $4)
+		        AND ($5::int IS NULL OR r.capacity >= $5)
+		  )
+	`
+	var count int
+	err := r.db.QueryRowContext(ctx, query,
+		filter.From,
+		filter.OrganizerID,
+		filter.Department,
+		filter.ResourceType,
+		filter.MinCapacity,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count upcoming reservations: %w", err)
+	}
+	return count, nil
+}
+
+// scanQuotaRules は予約上限のルールの行を読み取ります
+func scanQuotaRules(rows *sql.Rows) ([]*domain.QuotaRule, error) {
+	var rules []*domain.QuotaRule
+	for rows.Next() {
+		var rule domain.QuotaRule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Scope,
+			&rule.Kind,
+			&rule.ResourceType,
+			&rule.MinCapacity,
+			&rule.Department,
+			&rule.Limit,
+			&rule.IsActive,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking quota: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,113 @@
+// backend/internal/repository/quota_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var quotaColumns = []string{"id", "name", "scope", "kind", "resource_type", "min_capacity", "department",
+	"limit_value", "is_active", "created_at", "updated_at"}
+
+func TestQuotaRepository_ListActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewQuotaRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	ruleID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, scope, kind, resource_type, min_capacity, department, limit_value, is_active, created_at, updated_at`)).
+		WillReturnRows(sqlmock.NewRows(quotaColumns).
+			AddRow(ruleID, "Large rooms", "DEPARTMENT", "MONTHLY_HOURS", "MEETING_ROOM", 12, nil, 40, true, now, now))
+
+	rules, err := repo.ListActive(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, ruleID, rules[0].ID)
+	assert.Equal(t, domain.QuotaScopeDepartment, rules[0].Scope)
+	assert.Equal(t, domain.QuotaKindMonthlyHours, rules[0].Kind)
+	assert.Equal(t, domain.ResourceTypeMeetingRoom, *rules[0].ResourceType)
+	assert.Equal(t, 12, *rules[0].MinCapacity)
+	assert.Nil(t, rules[0].Department)
+	assert.Equal(t, 40, rules[0].Limit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQuotaRepository_BookedMinutes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewQuotaRepository(db)
+	ctx := context.Background()
+
+	from := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	sales := "Sales"
+	meetingRoom := domain.ResourceTypeMeetingRoom
+
+	// 部署単位の集計では主催者を絞り込まない
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (LEAST(ri.end_at, $2) - GREATEST(ri.start_at, $1))) / 60), 0)::bigint`)).
+		WithArgs(from, to, nil, "Sales", "MEETING_ROOM", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"minutes"}).AddRow(450))
+
+	minutes, err := repo.BookedMinutes(ctx, repository.QuotaUsageFilter{
+		Department:   &sales,
+		ResourceType: &meetingRoom,
+		From:         from,
+		To:           to,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 450, minutes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQuotaRepository_CountUpcoming(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewQuotaRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	organizerID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*)`)).
+		WithArgs(now, organizerID, nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountUpcoming(ctx, repository.QuotaUsageFilter{OrganizerID: &organizerID, From: now})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQuotaRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewQuotaRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM booking_quotas WHERE id = $1`)).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.ErrorIs(t, repo.Delete(ctx, id), repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
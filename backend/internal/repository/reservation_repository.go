@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -20,6 +21,23 @@ type ReservationRepository interface {
 	Update(ctx context.Context, reservation *domain.Reservation) error
 	Delete(ctx context.Context, id uuid.UUID, startAt time.Time) error
 	GetInstancesByReservationID(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationInstance, error)
+	UpdateInstancesStatus(ctx context.Context, reservationID uuid.UUID, status domain.ReservationStatus) error
+	ListPendingApprovals(ctx context.Context, filter PendingApprovalFilter) ([]*domain.Reservation, int, error)
+	GetResourceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error)
+	GetResourceUnits(ctx context.Context, reservationID uuid.UUID) (map[uuid.UUID]int, error)
+	ReleaseInstanceResource(ctx context.Context, instanceID, resourceID uuid.UUID, releasedAt time.Time) (time.Time, error)
+	ListOverlappingInstances(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.ReservationInstance, error)
+}
+
+// PendingApprovalFilter は承認待ち一覧の検索条件
+type PendingApprovalFilter struct {
+	ApproverID        uuid.UUID  // 現在のステップの承認者（個人・エスカレーション先・グループメンバー）
+	IncludeUnassigned bool       // 承認チェーンを持たない予約も含める（管理者用）
+	ResourceID        *uuid.UUID // 指定されたリソースを含む予約に絞り込む
+	From              *time.Time // 開始日時の下限
+	To                *time.Time // 開始日時の上限（この日時を含まない）
+	Limit             int
+	Offset            int
 }
 
 // postgresReservationRepository はPostgreSQLを使用したReservationRepositoryの実装
@@ -34,10 +52,14 @@ func NewReservationRepository(db *sql.DB) ReservationRepository {
 
 func (r *postgresReservationRepository) Create(ctx context.Context, reservation *domain.Reservation) error {
 	query := `
-		INSERT INTO reservations (id, organizer_id, title, description, start_at, end_at, rrule, is_private, timezone, approval_status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO reservations (id, organizer_id, title, description, start_at, end_at, rrule, exdates, is_private, timezone, approval_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	exdatesJSON, err := marshalExDates(reservation.ExDates)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query,
 		reservation.ID,
 		reservation.OrganizerID,
 		reservation.Title,
@@ -45,6 +67,7 @@ func (r *postgresReservationRepository) Create(ctx context.Context, reservation
 		reservation.StartAt,
 		reservation.EndAt,
 		reservation.RRule,
+		exdatesJSON,
 		reservation.IsPrivate,
 		reservation.Timezone,
 		reservation.ApprovalStatus,
@@ -67,9 +90,13 @@ func (r *postgresReservationRepository) CreateWithInstances(ctx context.Context,
 
 	// 予約を作成
 	query := `
-		INSERT INTO reservations (id, organizer_id, title, description, start_at, end_at, rrule, is_private, timezone, approval_status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO reservations (id, organizer_id, title, description, start_at, end_at, rrule, exdates, is_private, timezone, approval_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
+	exdatesJSON, err := marshalExDates(reservation.ExDates)
+	if err != nil {
+		return err
+	}
 	_, err = tx.ExecContext(ctx, query,
 		reservation.ID,
 		reservation.OrganizerID,
@@ -78,6 +105,7 @@ func (r *postgresReservationRepository) CreateWithInstances(ctx context.Context,
 		reservation.StartAt,
 		reservation.EndAt,
 		reservation.RRule,
+		exdatesJSON,
 		reservation.IsPrivate,
 		reservation.Timezone,
 		reservation.ApprovalStatus,
@@ -108,21 +136,38 @@ func (r *postgresReservationRepository) CreateWithInstances(ctx context.Context,
 			return fmt.Errorf("failed to create reservation instance: %w", err)
 		}
 
-		// リソース割り当てを作成
+		// リソース割り当てを作成（インスタンス固有の割り当てがあればそちらを使う）
 		resourceQuery := `
-			INSERT INTO reservation_resources (reservation_instance_id, resource_id, created_at)
-			VALUES ($1, $2, $3)
+			INSERT INTO reservation_resources (reservation_instance_id, resource_id, units, created_at)
+			VALUES ($1, $2, $3, $4)
 		`
-		for _, resourceID := range resourceIDs {
+		instanceResourceIDs := resourceIDs
+		if instance.ResourceIDs != nil {
+			instanceResourceIDs = instance.ResourceIDs
+		}
+		for _, resourceID := range instanceResourceIDs {
 			_, err = tx.ExecContext(ctx, resourceQuery,
 				instance.ID,
 				resourceID,
+				reservation.UnitsFor(resourceID),
 				time.Now(),
 			)
 			if err != nil {
 				return fmt.Errorf("failed to create reservation resource: %w", err)
 			}
 		}
+
+		// 招待した参加者を回答待ちとして登録
+		participantQuery := `
+			INSERT INTO reservation_participants (reservation_instance_id, user_id, role, status, created_at)
+			VALUES ($1, $2, 'ATTENDEE', 'NEEDS_ACTION', $3)
+		`
+		for _, userID := range reservation.ParticipantIDs {
+			_, err = tx.ExecContext(ctx, participantQuery, instance.ID, userID, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to create reservation participant: %w", err)
+			}
+		}
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -134,13 +179,14 @@ func (r *postgresReservationRepository) CreateWithInstances(ctx context.Context,
 
 func (r *postgresReservationRepository) GetByID(ctx context.Context, id uuid.UUID, startAt time.Time) (*domain.Reservation, error) {
 	query := `
-		SELECT id, organizer_id, title, description, start_at, end_at, rrule, is_private, timezone, approval_status, version, created_at, updated_at
+		SELECT id, organizer_id, title, description, start_at, end_at, rrule, exdates, is_private, timezone, approval_status, version, created_at, updated_at
 		FROM reservations
 		WHERE id = $1 AND start_at = $2
 	`
 	row := r.db.QueryRowContext(ctx, query, id, startAt)
 
 	var reservation domain.Reservation
+	var exdatesJSON []byte
 	err := row.Scan(
 		&reservation.ID,
 		&reservation.OrganizerID,
@@ -149,6 +195,7 @@ func (r *postgresReservationRepository) GetByID(ctx context.Context, id uuid.UUI
 		&reservation.StartAt,
 		&reservation.EndAt,
 		&reservation.RRule,
+		&exdatesJSON,
 		&reservation.IsPrivate,
 		&reservation.Timezone,
 		&reservation.ApprovalStatus,
@@ -162,6 +209,11 @@ func (r *postgresReservationRepository) GetByID(ctx context.Context, id uuid.UUI
 		}
 		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
 	}
+	if len(exdatesJSON) > 0 {
+		if err := json.Unmarshal(exdatesJSON, &reservation.ExDates); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal exdates: %w", err)
+		}
+	}
 	return &reservation, nil
 }
 
@@ -251,3 +303,260 @@ func (r *postgresReservationRepository) GetInstancesByReservationID(ctx context.
 
 	return instances, nil
 }
+
+// UpdateInstancesStatus は予約に属する全インスタンスのステータスを更新します
+// 承認待ちの仮押さえ (TENTATIVE) の確定・解放に使用します
+func (r *postgresReservationRepository) UpdateInstancesStatus(ctx context.Context, reservationID uuid.UUID, status domain.ReservationStatus) error {
+	query := `
+		UPDATE reservation_instances
+		SET status = $1, updated_at = $2
+		WHERE reservation_id = $3
+	`
+	_, err := r.db.ExecContext(ctx, query, status, time.Now(), reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation instances status: %w", err)
+	}
+	return nil
+}
+
+// ListPendingApprovals は指定された承認者の判断を待っている予約を開始日時順に取得します
+// 2つ目の戻り値はページングを考慮しない総件数です
+func (r *postgresReservationRepository) ListPendingApprovals(ctx context.Context, filter PendingApprovalFilter) ([]*domain.Reservation, int, error) {
+	// approval_status = 'PENDING' AND deleted_at IS NULL で idx_reservations_approval_status を使用する
+	query := `
+		SELECT r.id, r.organizer_id, r.title, r.description, r.start_at, r.end_at, r.rrule, r.is_private, r.timezone,
+		       r.approval_status, r.version, r.created_at, r.updated_at, COUNT(*) OVER() AS total_count
+		FROM reservations r
+		LEFT JOIN LATERAL (
+			SELECT ra.approver_id, ra.approver_group_id, ra.escalated_to
+			FROM reservation_approvals ra
+			WHERE ra.reservation_id = r.id AND ra.status = 'PENDING'
+			ORDER BY ra.step_order
+			LIMIT 1
+		) cur ON true
+		WHERE r.approval_status = 'PENDING'
+		  AND r.deleted_at IS NULL
+		  AND r.organizer_id <> $1
+		  AND (
+		        cur.approver_id = $1
+		     OR cur.escalated_to = $1
+		     OR cur.approver_group_id IN (SELECT group_id FROM approver_group_members WHERE user_id = $1)
+		     OR ($2 AND NOT EXISTS (SELECT 1 FROM reservation_approvals ra2 WHERE ra2.reservation_id = r.id))
+		  )
+		  AND ($3::uuid IS NULL OR EXISTS (
+		        SELECT 1
+		        FROM reservation_instances ri
+		        JOIN reservation_resources rr ON rr.reservation_instance_id = ri.id
+		        WHERE ri.reservation_id = r.id AND rr.resource_id = $3
+		  ))
+		  AND ($4::timestamptz IS NULL OR r.start_at >= $4)
+		  AND ($5::timestamptz IS NULL OR r.start_at < $5)
+		ORDER BY r.start_at, r.id
+		LIMIT $6 OFFSET $7
+	`
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.ApproverID,
+		filter.IncludeUnassigned,
+		filter.ResourceID,
+		filter.From,
+		filter.To,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+	defer rows.Close()
+
+	reservations := []*domain.Reservation{}
+	total := 0
+	for rows.Next() {
+		var reservation domain.Reservation
+		var description, rrule sql.NullString
+		err := rows.Scan(
+			&reservation.ID,
+			&reservation.OrganizerID,
+			&reservation.Title,
+			&description,
+			&reservation.StartAt,
+			&reservation.EndAt,
+			&rrule,
+			&reservation.IsPrivate,
+			&reservation.Timezone,
+			&reservation.ApprovalStatus,
+			&reservation.Version,
+			&reservation.CreatedAt,
+			&reservation.UpdatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan pending approval: %w", err)
+		}
+		reservation.Description = description.String
+		reservation.RRule = rrule.String
+		reservations = append(reservations, &reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return reservations, total, nil
+}
+
+// GetResourceIDs は予約に割り当てられたリソースのIDを取得します
+func (r *postgresReservationRepository) GetResourceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT rr.resource_id
+		FROM reservation_resources rr
+		JOIN reservation_instances ri ON ri.id = rr.reservation_instance_id
+		WHERE ri.reservation_id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation resources: %w", err)
+	}
+	defer rows.Close()
+
+	var resourceIDs []uuid.UUID
+	for rows.Next() {
+		var resourceID uuid.UUID
+		if err := rows.Scan(&resourceID); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation resource: %w", err)
+		}
+		resourceIDs = append(resourceIDs, resourceID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return resourceIDs, nil
+}
+
+// GetResourceUnits は予約に割り当てられたリソースごとの予約数量を取得します
+func (r *postgresReservationRepository) GetResourceUnits(ctx context.Context, reservationID uuid.UUID) (map[uuid.UUID]int, error) {
+	query := `
+		SELECT rr.resource_id, MAX(rr.units)
+		FROM reservation_resources rr
+		JOIN reservation_instances ri ON ri.id = rr.reservation_instance_id
+		WHERE ri.reservation_id = $1
+		GROUP BY rr.resource_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation resource units: %w", err)
+	}
+	defer rows.Close()
+
+	units := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var resourceID uuid.UUID
+		var n int
+		if err := rows.Scan(&resourceID, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan reservation resource units: %w", err)
+		}
+		units[resourceID] = n
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return units, nil
+}
+
+// ReleaseInstanceResource は利用中の予約インスタンスのうち指定されたリソースの割り当てだけを解放し、解放した枠の終了日時を返します
+// 同じインスタンスで予約した他のリソースとインスタンスの終了日時は変更しません
+// 指定日時がインスタンスの期間外、または解放済みの場合は ErrNotFound を返します
+func (r *postgresReservationRepository) ReleaseInstanceResource(ctx context.Context, instanceID, resourceID uuid.UUID, releasedAt time.Time) (time.Time, error) {
+	query := `
+		UPDATE reservation_resources rr
+		SET released_at = $3
+		FROM reservation_instances ri
+		WHERE rr.reservation_instance_id = $1
+		  AND rr.resource_id = $2
+		  AND rr.released_at IS NULL
+		  AND ri.id = rr.reservation_instance_id
+		  AND ri.start_at < $3
+		  AND ri.end_at > $3
+		RETURNING ri.end_at
+	`
+	var endAt time.Time
+	err := r.db.QueryRowContext(ctx, query, instanceID, resourceID, releasedAt).Scan(&endAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, ErrNotFound
+		}
+		return time.Time{}, fmt.Errorf("failed to release reservation resource: %w", err)
+	}
+	return endAt, nil
+}
+
+// ListOverlappingInstances は指定されたリソースについて、期間 [startAt, endAt) に重なる有効な予約インスタンスを取得します
+// 各インスタンスには親予約（ID・主催者・タイトル・期間・承認状態）を Reservation に設定します
+func (r *postgresReservationRepository) ListOverlappingInstances(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.ReservationInstance, error) {
+	query := `
+		SELECT ri.id, ri.reservation_id, ri.reservation_start_at, ri.start_at, ri.end_at, ri.status, ri.created_at, ri.updated_at,
+		       res.organizer_id, res.title, res.end_at, res.approval_status
+		FROM reservation_resources rr
+		JOIN reservation_instances ri ON ri.id = rr.reservation_instance_id
+		JOIN reservations res ON res.id = ri.reservation_id AND res.start_at = ri.reservation_start_at
+		WHERE rr.resource_id = $1
+		  AND res.deleted_at IS NULL
+		  AND ri.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+		  AND ri.start_at < $3
+		  AND COALESCE(rr.released_at, ri.end_at) > $2
+		ORDER BY ri.start_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, resourceID, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overlapping instances: %w", err)
+	}
+	defer rows.Close()
+
+	var instances []*domain.ReservationInstance
+	for rows.Next() {
+		var instance domain.ReservationInstance
+		var reservation domain.Reservation
+		err := rows.Scan(
+			&instance.ID,
+			&instance.ReservationID,
+			&instance.ReservationStartAt,
+			&instance.StartAt,
+			&instance.EndAt,
+			&instance.Status,
+			&instance.CreatedAt,
+			&instance.UpdatedAt,
+			&reservation.OrganizerID,
+			&reservation.Title,
+			&reservation.EndAt,
+			&reservation.ApprovalStatus,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation instance: %w", err)
+		}
+		reservation.ID = instance.ReservationID
+		reservation.StartAt = instance.ReservationStartAt
+		instance.Reservation = &reservation
+		instances = append(instances, &instance)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return instances, nil
+}
+
+// marshalExDates は除外日をJSONに変換します（未設定の場合は NULL）
+func marshalExDates(exdates []time.Time) (interface{}, error) {
+	if len(exdates) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(exdates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal exdates: %w", err)
+	}
+	return b, nil
+}
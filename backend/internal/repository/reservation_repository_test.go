@@ -3,6 +3,7 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"regexp"
 	"testing"
 	"time"
@@ -43,6 +44,7 @@ func TestReservationRepository_Create(t *testing.T) {
 			reservation.StartAt,
 			reservation.EndAt,
 			reservation.RRule,
+			nil,
 			reservation.IsPrivate,
 			reservation.Timezone,
 			reservation.ApprovalStatus,
@@ -90,13 +92,13 @@ func TestReservationRepository_CreateWithInstances(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservations`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_instances`)).
 		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_resources`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
@@ -104,3 +106,215 @@ func TestReservationRepository_CreateWithInstances(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestReservationRepository_CreateWithInstances_Participants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	participantID := uuid.New()
+	reservation := &domain.Reservation{
+		ID:             uuid.New(),
+		OrganizerID:    uuid.New(),
+		Title:          "Design Review",
+		StartAt:        time.Now(),
+		EndAt:          time.Now().Add(1 * time.Hour),
+		ApprovalStatus: domain.ApprovalStatusConfirmed,
+		ParticipantIDs: []uuid.UUID{participantID},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	instance := &domain.ReservationInstance{
+		ID:                 uuid.New(),
+		ReservationID:      reservation.ID,
+		ReservationStartAt: reservation.StartAt,
+		StartAt:            reservation.StartAt,
+		EndAt:              reservation.EndAt,
+		Status:             domain.ReservationStatusConfirmed,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservations`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_instances`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_resources`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// 招待者はインスタンスごとに回答待ちとして登録される
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_participants`)).
+		WithArgs(instance.ID, participantID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateWithInstances(ctx, reservation, []*domain.ReservationInstance{instance}, []uuid.UUID{uuid.New()})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_CreateWithInstances_SeriesResolutions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	start := time.Date(2030, 3, 4, 1, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	reservation := &domain.Reservation{
+		ID:             uuid.New(),
+		OrganizerID:    uuid.New(),
+		Title:          "Weekly Sync",
+		StartAt:        start,
+		EndAt:          start.Add(time.Hour),
+		RRule:          "FREQ=WEEKLY;COUNT=3",
+		ExDates:        []time.Time{start.Add(week)},
+		ApprovalStatus: domain.ApprovalStatusConfirmed,
+	}
+	roomID := uuid.New()
+	alternativeID := uuid.New()
+	regular := &domain.ReservationInstance{ID: uuid.New(), ReservationID: reservation.ID, StartAt: start, EndAt: start.Add(time.Hour)}
+	substituted := &domain.ReservationInstance{ID: uuid.New(), ReservationID: reservation.ID, StartAt: start.Add(2 * week), EndAt: start.Add(2*week + time.Hour), ResourceIDs: []uuid.UUID{alternativeID}}
+
+	mock.ExpectBegin()
+	// 除外日は JSON 配列として保存される
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservations`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), []byte(`["2030-03-11T01:00:00Z"]`), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_instances`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_resources`)).
+		WithArgs(regular.ID, roomID, 1, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_instances`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// 代替リソースはその回だけに割り当てられる
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO reservation_resources`)).
+		WithArgs(substituted.ID, alternativeID, 1, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.CreateWithInstances(ctx, reservation, []*domain.ReservationInstance{regular, substituted}, []uuid.UUID{roomID})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_UpdateInstancesStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	reservationID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE reservation_instances`)).
+		WithArgs(domain.ReservationStatusConfirmed, sqlmock.AnyArg(), reservationID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.UpdateInstancesStatus(ctx, reservationID, domain.ReservationStatusConfirmed)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_ListPendingApprovals(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	approverID := uuid.New()
+	resourceID := uuid.New()
+	now := time.Now()
+
+	filter := repository.PendingApprovalFilter{
+		ApproverID: approverID,
+		ResourceID: &resourceID,
+		Limit:      20,
+		Offset:     20,
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "organizer_id", "title", "description", "start_at", "end_at", "rrule", "is_private", "timezone",
+		"approval_status", "version", "created_at", "updated_at", "total_count"}).
+		AddRow(uuid.New(), uuid.New(), "Board Meeting", "", now, now.Add(time.Hour), nil, false, "Asia/Tokyo",
+			domain.ApprovalStatusPending, 1, now, now, 21)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.organizer_id, r.title`)).
+		WithArgs(approverID, false, &resourceID, nil, nil, 20, 20).
+		WillReturnRows(rows)
+
+	reservations, total, err := repo.ListPendingApprovals(ctx, filter)
+	assert.NoError(t, err)
+	assert.Len(t, reservations, 1)
+	assert.Equal(t, 21, total)
+	assert.Equal(t, domain.ApprovalStatusPending, reservations[0].ApprovalStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_ListOverlappingInstances(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	resourceID, instanceID, reservationID, organizerID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	rows := sqlmock.NewRows([]string{"id", "reservation_id", "reservation_start_at", "start_at", "end_at", "status", "created_at", "updated_at",
+		"organizer_id", "title", "end_at", "approval_status"}).
+		AddRow(instanceID, reservationID, startAt, startAt, endAt, domain.ReservationStatusConfirmed, startAt, startAt,
+			organizerID, "Team sync", endAt, domain.ApprovalStatusConfirmed)
+
+	// 有効なインスタンスのうち期間が重なるものを取得する
+	mock.ExpectQuery(`WHERE rr.resource_id = \$1(.|\n)+ri.status IN \('TENTATIVE', 'CONFIRMED', 'CHECKED_IN'\)`).
+		WithArgs(resourceID, startAt, endAt).
+		WillReturnRows(rows)
+
+	instances, err := repo.ListOverlappingInstances(ctx, resourceID, startAt, endAt)
+	assert.NoError(t, err)
+	assert.Len(t, instances, 1)
+	assert.Equal(t, instanceID, instances[0].ID)
+	assert.Equal(t, reservationID, instances[0].Reservation.ID)
+	assert.Equal(t, organizerID, instances[0].Reservation.OrganizerID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReservationRepository_ReleaseInstanceResource(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewReservationRepository(db)
+	ctx := context.Background()
+
+	instanceID, resourceID := uuid.New(), uuid.New()
+	releasedAt := time.Now().Truncate(time.Second)
+	endAt := releasedAt.Add(time.Hour)
+
+	// 指定されたリソースの割り当てだけを解放し、インスタンスの終了日時は変更しない
+	mock.ExpectQuery(`UPDATE reservation_resources rr\s+SET released_at = \$3(.|\n)+RETURNING ri.end_at`).
+		WithArgs(instanceID, resourceID, releasedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"end_at"}).AddRow(endAt))
+
+	released, err := repo.ReleaseInstanceResource(ctx, instanceID, resourceID, releasedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, endAt, released)
+
+	// 期間外・解放済みの場合
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE reservation_resources rr`)).
+		WithArgs(instanceID, resourceID, releasedAt).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.ReleaseInstanceResource(ctx, instanceID, resourceID, releasedAt)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
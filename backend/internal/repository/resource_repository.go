@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -19,8 +20,35 @@ type ResourceRepository interface {
 	Update(ctx context.Context, resource *domain.Resource) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindAvailable(ctx context.Context, startAt, endAt time.Time) ([]*domain.Resource, error)
+	FindAvailableRooms(ctx context.Context, startAt, endAt time.Time, minCapacity int) ([]*domain.Resource, error)
+	AvailableUnits(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error)
+	ListByZone(ctx context.Context, resourceType domain.ResourceType, zone string) ([]*domain.Resource, error)
+	ListZoneBookings(ctx context.Context, resourceType domain.ResourceType, zone string, startAt, endAt time.Time) ([]*domain.ZoneBooking, error)
 }
 
+// peakUnitsSubquery は期間 [$1, $2) 内でリソース r が同時に予約されている数量の最大値を求める副問い合わせ
+// 同時使用数は重複するいずれかのインスタンスの開始時点（または期間の開始時点）で最大になる
+// 承認待ちの仮押さえ (TENTATIVE) も枠を占有しているものとして扱う
+// 早期に解放された割り当て (released_at) は解放した日時までを占有期間とする
+const peakUnitsSubquery = `
+	SELECT COALESCE(MAX(peak.used), 0)
+	FROM (
+		SELECT SUM(rr2.units) AS used
+		FROM reservation_resources rr1
+		JOIN reservation_instances ri1 ON ri1.id = rr1.reservation_instance_id
+		JOIN reservation_resources rr2 ON rr2.resource_id = rr1.resource_id
+		JOIN reservation_instances ri2 ON ri2.id = rr2.reservation_instance_id
+		WHERE rr1.resource_id = r.id
+		  AND ri1.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+		  AND ri1.start_at < $2
+		  AND COALESCE(rr1.released_at, ri1.end_at) > $1
+		  AND ri2.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+		  AND ri2.start_at <= GREATEST(ri1.start_at, $1)
+		  AND COALESCE(rr2.released_at, ri2.end_at) > GREATEST(ri1.start_at, $1)
+		GROUP BY rr1.reservation_instance_id
+	) peak
+`
+
 // postgresResourceRepository はPostgreSQLを使用したResourceRepositoryの実装
 type postgresResourceRepository struct {
 	db *sql.DB
@@ -33,14 +61,29 @@ func NewResourceRepository(db *sql.DB) ResourceRepository {
 
 func (r *postgresResourceRepository) Create(ctx context.Context, resource *domain.Resource) error {
 	query := `
-		INSERT INTO resources (id, name, type, capacity, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO resources (id, name, type, capacity, requires_approval, approver_group_id, approval_policy_id, owner_id, quantity, zone, location, equipment, floor, min_occupancy, bump_role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	equipmentJSON, err := marshalEquipment(resource.Equipment)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query,
 		resource.ID,
 		resource.Name,
 		resource.Type,
 		resource.Capacity,
+		resource.RequiresApproval,
+		resource.ApproverGroupID,
+		resource.ApprovalPolicyID,
+		resource.OwnerID,
+		resource.TotalUnits(),
+		resource.Zone,
+		resource.Location,
+		equipmentJSON,
+		resource.Floor,
+		resource.MinOccupancy,
+		resource.BumpRole,
 		resource.CreatedAt,
 		resource.UpdatedAt,
 	)
@@ -52,18 +95,32 @@ func (r *postgresResourceRepository) Create(ctx context.Context, resource *domai
 
 func (r *postgresResourceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Resource, error) {
 	query := `
-		SELECT id, name, type, capacity, created_at, updated_at
+		SELECT id, name, type, capacity, requires_approval, approver_group_id, approval_policy_id, owner_id, quantity, zone, location, equipment, floor, min_occupancy, bump_role, is_active, required_role, created_at, updated_at
 		FROM resources
 		WHERE id = $1
 	`
 	row := r.db.QueryRowContext(ctx, query, id)
 
 	var resource domain.Resource
+	var equipmentJSON []byte
 	err := row.Scan(
 		&resource.ID,
 		&resource.Name,
 		&resource.Type,
 		&resource.Capacity,
+		&resource.RequiresApproval,
+		&resource.ApproverGroupID,
+		&resource.ApprovalPolicyID,
+		&resource.OwnerID,
+		&resource.Quantity,
+		&resource.Zone,
+		&resource.Location,
+		&equipmentJSON,
+		&resource.Floor,
+		&resource.MinOccupancy,
+		&resource.BumpRole,
+		&resource.IsActive,
+		&resource.RequiredRole,
 		&resource.CreatedAt,
 		&resource.UpdatedAt,
 	)
@@ -73,6 +130,9 @@ func (r *postgresResourceRepository) GetByID(ctx context.Context, id uuid.UUID)
 		}
 		return nil, fmt.Errorf("failed to get resource by id: %w", err)
 	}
+	if resource.Equipment, err = unmarshalEquipment(equipmentJSON); err != nil {
+		return nil, err
+	}
 	return &resource, nil
 }
 
@@ -80,13 +140,30 @@ func (r *postgresResourceRepository) Update(ctx context.Context, resource *domai
 	resource.UpdatedAt = time.Now()
 	query := `
 		UPDATE resources
-		SET name = $1, type = $2, capacity = $3, updated_at = $4
-		WHERE id = $5
+		SET name = $1, type = $2, capacity = $3, requires_approval = $4, approver_group_id = $5,
+		    approval_policy_id = $6, owner_id = $7, quantity = $8, zone = $9, location = $10,
+		    equipment = $11, floor = $12, min_occupancy = $13, bump_role = $14, updated_at = $15
+		WHERE id = $16
 	`
+	equipmentJSON, err := marshalEquipment(resource.Equipment)
+	if err != nil {
+		return err
+	}
 	result, err := r.db.ExecContext(ctx, query,
 		resource.Name,
 		resource.Type,
 		resource.Capacity,
+		resource.RequiresApproval,
+		resource.ApproverGroupID,
+		resource.ApprovalPolicyID,
+		resource.OwnerID,
+		resource.TotalUnits(),
+		resource.Zone,
+		resource.Location,
+		equipmentJSON,
+		resource.Floor,
+		resource.MinOccupancy,
+		resource.BumpRole,
 		resource.UpdatedAt,
 		resource.ID,
 	)
@@ -124,21 +201,15 @@ func (r *postgresResourceRepository) Delete(ctx context.Context, id uuid.UUID) e
 }
 
 // FindAvailable は指定された期間に空いているリソースを取得します
-// 重複する予約が存在しないリソースを返します
+// 期間内の同時予約数が保有数量に満たない（1単位以上空いている）リソースを返します
+// 単一リソース（保有数量1）の場合は重複する予約が存在しないことと同じです
 func (r *postgresResourceRepository) FindAvailable(ctx context.Context, startAt, endAt time.Time) ([]*domain.Resource, error) {
-	// 指定期間に重複する予約があるリソースを除外するクエリ
-	// reservation_instances テーブルを使用
+	// reservation_resources 経由で reservation_instances を参照する
 	// 重複条件: (start < endAt AND end > startAt)
 	query := `
-		SELECT r.id, r.name, r.type, r.capacity, r.created_at, r.updated_at
+		SELECT r.id, r.name, r.type, r.capacity, r.requires_approval, r.approver_group_id, r.approval_policy_id, r.owner_id, r.quantity, r.zone, r.location, r.equipment, r.floor, r.min_occupancy, r.bump_role, r.is_active, r.required_role, r.created_at, r.updated_at
 		FROM resources r
-		WHERE NOT EXISTS (
-			SELECT 1
-			FROM reservation_instances ri
-			WHERE ri.resource_id = r.id
-			  AND ri.start_at < $2
-			  AND ri.end_at > $1
-		)
+		WHERE r.quantity > (` + peakUnitsSubquery + `)
 		ORDER BY r.name
 	`
 
@@ -148,26 +219,176 @@ func (r *postgresResourceRepository) FindAvailable(ctx context.Context, startAt,
 	}
 	defer rows.Close()
 
+	return scanResources(rows)
+}
+
+// FindAvailableRooms は指定された期間に空いている有効な会議室のうち、収容人数が minCapacity 以上のものを収容人数の小さい順に取得します
+func (r *postgresResourceRepository) FindAvailableRooms(ctx context.Context, startAt, endAt time.Time, minCapacity int) ([]*domain.Resource, error) {
+	query := `
+		SELECT r.id, r.name, r.type, r.capacity, r.requires_approval, r.approver_group_id, r.approval_policy_id, r.owner_id, r.quantity, r.zone, r.location, r.equipment, r.floor, r.min_occupancy, r.bump_role, r.is_active, r.required_role, r.created_at, r.updated_at
+		FROM resources r
+		WHERE r.type = 'MEETING_ROOM'
+		  AND r.is_active = true
+		  AND r.capacity >= $3
+		  AND r.quantity > (` + peakUnitsSubquery + `)
+		ORDER BY r.capacity, r.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, startAt, endAt, minCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available rooms: %w", err)
+	}
+	defer rows.Close()
+
+	return scanResources(rows)
+}
+
+// AvailableUnits は指定された期間にリソースの空いている数量を取得します
+func (r *postgresResourceRepository) AvailableUnits(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error) {
+	query := `
+		SELECT r.quantity - (` + peakUnitsSubquery + `)
+		FROM resources r
+		WHERE r.id = $3
+	`
+	var available int
+	err := r.db.QueryRowContext(ctx, query, startAt, endAt, resourceID).Scan(&available)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to get available units: %w", err)
+	}
+	if available < 0 {
+		available = 0
+	}
+	return available, nil
+}
+
+// ListByZone は指定された種別・ゾーンに属する有効なリソースを名前順に取得します
+func (r *postgresResourceRepository) ListByZone(ctx context.Context, resourceType domain.ResourceType, zone string) ([]*domain.Resource, error) {
+	query := `
+		SELECT r.id, r.name, r.type, r.capacity, r.requires_approval, r.approver_group_id, r.approval_policy_id, r.owner_id, r.quantity, r.zone, r.location, r.equipment, r.floor, r.min_occupancy, r.bump_role, r.is_active, r.required_role, r.created_at, r.updated_at
+		FROM resources r
+		WHERE r.type = $1 AND r.zone = $2 AND r.is_active = true
+		ORDER BY r.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, resourceType, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources by zone: %w", err)
+	}
+	defer rows.Close()
+
+	return scanResources(rows)
+}
+
+// ListZoneBookings は指定された種別・ゾーンのリソースについて、期間 [startAt, endAt) に重なる有効な予約を取得します
+func (r *postgresResourceRepository) ListZoneBookings(ctx context.Context, resourceType domain.ResourceType, zone string, startAt, endAt time.Time) ([]*domain.ZoneBooking, error) {
+	query := `
+		SELECT rr.resource_id, ri.reservation_id, res.organizer_id, ri.start_at, COALESCE(rr.released_at, ri.end_at)
+		FROM reservation_resources rr
+		JOIN resources r ON r.id = rr.resource_id
+		JOIN reservation_instances ri ON ri.id = rr.reservation_instance_id
+		JOIN reservations res ON res.id = ri.reservation_id AND res.start_at = ri.reservation_start_at
+		WHERE r.type = $1
+		  AND r.zone = $2
+		  AND res.deleted_at IS NULL
+		  AND ri.status IN ('TENTATIVE', 'CONFIRMED', 'CHECKED_IN')
+		  AND ri.start_at < $4
+		  AND COALESCE(rr.released_at, ri.end_at) > $3
+		ORDER BY ri.start_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, resourceType, zone, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*domain.ZoneBooking
+	for rows.Next() {
+		var booking domain.ZoneBooking
+		err := rows.Scan(
+			&booking.ResourceID,
+			&booking.ReservationID,
+			&booking.OrganizerID,
+			&booking.StartAt,
+			&booking.EndAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan zone booking: %w", err)
+		}
+		bookings = append(bookings, &booking)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return bookings, nil
+}
+
+// scanResources はリソースの行を読み取ります
+func scanResources(rows *sql.Rows) ([]*domain.Resource, error) {
 	var resources []*domain.Resource
 	for rows.Next() {
 		var r domain.Resource
+		var equipmentJSON []byte
 		err := rows.Scan(
 			&r.ID,
 			&r.Name,
 			&r.Type,
 			&r.Capacity,
+			&r.RequiresApproval,
+			&r.ApproverGroupID,
+			&r.ApprovalPolicyID,
+			&r.OwnerID,
+			&r.Quantity,
+			&r.Zone,
+			&r.Location,
+			&equipmentJSON,
+			&r.Floor,
+			&r.MinOccupancy,
+			&r.BumpRole,
+			&r.IsActive,
+			&r.RequiredRole,
 			&r.CreatedAt,
 			&r.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan resource: %w", err)
 		}
+		if r.Equipment, err = unmarshalEquipment(equipmentJSON); err != nil {
+			return nil, err
+		}
 		resources = append(resources, &r)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
 	return resources, nil
 }
+
+// marshalEquipment は設備情報をJSONに変換します（未設定の場合は NULL）
+func marshalEquipment(equipment map[string]interface{}) (interface{}, error) {
+	if len(equipment) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(equipment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal equipment: %w", err)
+	}
+	return b, nil
+}
+
+// unmarshalEquipment はJSONの設備情報を読み取ります
+func unmarshalEquipment(data []byte) (map[string]interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var equipment map[string]interface{}
+	if err := json.Unmarshal(data, &equipment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal equipment: %w", err)
+	}
+	return equipment, nil
+}
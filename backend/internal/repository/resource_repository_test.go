@@ -26,21 +26,26 @@ func TestResourceRepository_FindAvailable(t *testing.T) {
 	endAt := startAt.Add(1 * time.Hour)
 
 	capacity := 10
+	// 予約できるかの判定に使うため、有効フラグと必要ロールも読み取る
+	managerRole := domain.RoleManager
 	expectedResource := &domain.Resource{
-		ID:        uuid.New(),
-		Name:      "Meeting Room A",
-		Type:      domain.ResourceTypeMeetingRoom,
-		Capacity:  &capacity,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:           uuid.New(),
+		Name:         "Meeting Room A",
+		Type:         domain.ResourceTypeMeetingRoom,
+		Capacity:     &capacity,
+		Quantity:     1,
+		RequiredRole: &managerRole,
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "name", "type", "capacity", "created_at", "updated_at"}).
-		AddRow(expectedResource.ID, expectedResource.Name, expectedResource.Type, expectedResource.Capacity, expectedResource.CreatedAt, expectedResource.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "type", "capacity", "requires_approval", "approver_group_id", "approval_policy_id", "owner_id", "quantity", "zone", "location", "equipment", "floor", "min_occupancy", "bump_role", "is_active", "required_role", "created_at", "updated_at"}).
+		AddRow(expectedResource.ID, expectedResource.Name, expectedResource.Type, expectedResource.Capacity, false, nil, nil, nil, 1, nil, nil, nil, nil, nil, nil, true, "MANAGER", expectedResource.CreatedAt, expectedResource.UpdatedAt)
 
 	// クエリのマッチング
-	// NOT EXISTS 句を含むクエリが正しく発行されるか確認
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.name, r.type, r.capacity, r.created_at, r.updated_at FROM resources r WHERE NOT EXISTS`)).
+	// 期間内の同時予約数が保有数量に満たないリソースを絞り込むクエリが正しく発行されるか確認
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.id, r.name, r.type, r.capacity, r.requires_approval, r.approver_group_id, r.approval_policy_id, r.owner_id, r.quantity, r.zone, r.location, r.equipment, r.floor, r.min_occupancy, r.bump_role, r.is_active, r.required_role, r.created_at, r.updated_at FROM resources r WHERE r.quantity > (`)).
 		WithArgs(startAt, endAt).
 		WillReturnRows(rows)
 
@@ -70,10 +75,75 @@ func TestResourceRepository_Create(t *testing.T) {
 	}
 
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO resources`)).
-		WithArgs(resource.ID, resource.Name, resource.Type, resource.Capacity, resource.CreatedAt, resource.UpdatedAt).
+		WithArgs(resource.ID, resource.Name, resource.Type, resource.Capacity, resource.RequiresApproval, resource.ApproverGroupID, resource.ApprovalPolicyID, resource.OwnerID, 1, resource.Zone, resource.Location, nil, resource.Floor, resource.MinOccupancy, resource.BumpRole, resource.CreatedAt, resource.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Create(ctx, resource)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestResourceRepository_FindAvailableRooms(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewResourceRepository(db)
+	ctx := context.Background()
+
+	startAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(1 * time.Hour)
+
+	capacity := 8
+	floor := 3
+	location := "3F East"
+	expectedResource := &domain.Resource{
+		ID:        uuid.New(),
+		Name:      "Room 3A",
+		Type:      domain.ResourceTypeMeetingRoom,
+		Capacity:  &capacity,
+		Location:  &location,
+		Equipment: map[string]interface{}{"vc": true},
+		Floor:     &floor,
+		Quantity:  1,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "type", "capacity", "requires_approval", "approver_group_id", "approval_policy_id", "owner_id", "quantity", "zone", "location", "equipment", "floor", "min_occupancy", "bump_role", "is_active", "required_role", "created_at", "updated_at"}).
+		AddRow(expectedResource.ID, expectedResource.Name, expectedResource.Type, expectedResource.Capacity, false, nil, nil, nil, 1, nil, location, []byte(`{"vc": true}`), floor, nil, nil, true, nil, expectedResource.CreatedAt, expectedResource.UpdatedAt)
+
+	// 会議室に限定し、収容人数の下限で絞り込んで小さい順に並べるか確認
+	mock.ExpectQuery(`WHERE r.type = 'MEETING_ROOM'\s+AND r.is_active = true\s+AND r.capacity >= \$3(.|\n)+ORDER BY r.capacity, r.name`).
+		WithArgs(startAt, endAt, 7).
+		WillReturnRows(rows)
+
+	resources, err := repo.FindAvailableRooms(ctx, startAt, endAt, 7)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, expectedResource, resources[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResourceRepository_AvailableUnits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewResourceRepository(db)
+	ctx := context.Background()
+
+	resourceID := uuid.New()
+	startAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(2 * time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.quantity - (`)).
+		WithArgs(startAt, endAt, resourceID).
+		WillReturnRows(sqlmock.NewRows([]string{"available"}).AddRow(3))
+
+	available, err := repo.AvailableUnits(ctx, resourceID, startAt, endAt)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, available)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
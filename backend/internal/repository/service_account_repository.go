@@ -0,0 +1,142 @@
+// backend/internal/repository/service_account_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// ServiceAccountRepository はサービスアカウントへのアクセスを提供するインターフェース
+type ServiceAccountRepository interface {
+	Create(ctx context.Context, account *domain.ServiceAccount) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ServiceAccount, error)
+	List(ctx context.Context) ([]*domain.ServiceAccount, error)
+	Update(ctx context.Context, account *domain.ServiceAccount) error
+}
+
+// postgresServiceAccountRepository はPostgreSQLを使用したServiceAccountRepositoryの実装
+type postgresServiceAccountRepository struct {
+	db *sql.DB
+}
+
+// NewServiceAccountRepository は新しいServiceAccountRepositoryを作成します
+func NewServiceAccountRepository(db *sql.DB) ServiceAccountRepository {
+	return &postgresServiceAccountRepository{db: db}
+}
+
+// serviceAccountColumns はサービスアカウントの取得で共通して使用する列
+const serviceAccountColumns = `id, name, description, role, created_by, created_at, updated_at, disabled_at`
+
+// Create はサービスアカウントを作成します
+func (r *postgresServiceAccountRepository) Create(ctx context.Context, account *domain.ServiceAccount) error {
+	query := `
+		INSERT INTO service_accounts (id, name, description, role, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		account.ID,
+		account.Name,
+		account.Description,
+		account.Role,
+		account.CreatedBy,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create service account: %w", err)
+	}
+	return nil
+}
+
+// GetByID はサービスアカウントを取得します
+func (r *postgresServiceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts WHERE id = $1`
+	account, err := scanServiceAccount(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+	return account, nil
+}
+
+// List はサービスアカウントを名前順に取得します
+func (r *postgresServiceAccountRepository) List(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	query := `SELECT ` + serviceAccountColumns + ` FROM service_accounts ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*domain.ServiceAccount
+	for rows.Next() {
+		account, err := scanServiceAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan service account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return accounts, nil
+}
+
+// Update はサービスアカウントの属性を更新します
+func (r *postgresServiceAccountRepository) Update(ctx context.Context, account *domain.ServiceAccount) error {
+	query := `
+		UPDATE service_accounts
+		SET name = $1, description = $2, role = $3, updated_at = $4, disabled_at = $5
+		WHERE id = $6
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		account.Name,
+		account.Description,
+		account.Role,
+		account.UpdatedAt,
+		account.DisabledAt,
+		account.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanServiceAccount はサービスアカウントの行を読み取ります
+func scanServiceAccount(row userScanner) (*domain.ServiceAccount, error) {
+	var account domain.ServiceAccount
+	var createdBy *uuid.UUID
+	err := row.Scan(
+		&account.ID,
+		&account.Name,
+		&account.Description,
+		&account.Role,
+		&createdBy,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+		&account.DisabledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if createdBy != nil {
+		account.CreatedBy = *createdBy
+	}
+	return &account, nil
+}
@@ -0,0 +1,71 @@
+// backend/internal/repository/service_account_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var serviceAccountColumns = []string{"id", "name", "description", "role", "created_by", "created_at", "updated_at", "disabled_at"}
+
+func TestServiceAccountRepository_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewServiceAccountRepository(db)
+
+	now := time.Now()
+	accountID, creatorID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM service_accounts WHERE id = $1`)).
+		WithArgs(accountID).
+		WillReturnRows(sqlmock.NewRows(serviceAccountColumns).AddRow(
+			accountID, "room-display", "会議室前の表示端末", "GENERAL", creatorID, now, now, nil))
+
+	account, err := repo.GetByID(context.Background(), accountID)
+	assert.NoError(t, err)
+	assert.Equal(t, "room-display", account.Name)
+	assert.Equal(t, domain.RoleGeneral, account.Role)
+	assert.True(t, account.IsActive())
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM service_accounts WHERE id = $1`)).
+		WithArgs(accountID).
+		WillReturnRows(sqlmock.NewRows(serviceAccountColumns))
+
+	_, err = repo.GetByID(context.Background(), accountID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestServiceAccountRepository_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewServiceAccountRepository(db)
+
+	now := time.Now()
+	account := &domain.ServiceAccount{
+		ID:         uuid.New(),
+		Name:       "room-display",
+		Role:       domain.RoleGeneral,
+		UpdatedAt:  now,
+		DisabledAt: &now,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE service_accounts`)).
+		WithArgs("room-display", "", domain.RoleGeneral, now, account.DisabledAt, account.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.Update(context.Background(), account))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -20,10 +20,19 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	GetBySubject(ctx context.Context, issuer, sub string) (*domain.User, error)
+	ListByEmail(ctx context.Context, email string) ([]*domain.User, error)
+	List(ctx context.Context, filter UserFilter, offset, limit int) ([]*domain.User, int64, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// UserFilter はユーザーの一覧の絞り込み条件（削除されたユーザーは常に除外します）
+type UserFilter struct {
+	Email      *string // メールアドレス（大文字小文字を区別しない）
+	ExternalID *string // プロビジョニング元のIdPでのユーザー識別子
+}
+
 // postgresUserRepository はPostgreSQLを使用したUserRepositoryの実装
 type postgresUserRepository struct {
 	db *sql.DB
@@ -34,16 +43,25 @@ func NewUserRepository(db *sql.DB) UserRepository {
 	return &postgresUserRepository{db: db}
 }
 
+// userColumns はユーザーの取得で共通して使用する列
+const userColumns = `id, issuer, sub, external_id, email, name, role, manager_id, department, penalty_score, penalty_score_expire_at, is_active, created_at, updated_at, deleted_at`
+
 func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, email, name, role, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (id, issuer, sub, external_id, email, name, role, manager_id, department, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
+		nullableString(user.Issuer),
+		nullableString(user.Sub),
+		nullableString(user.ExternalID),
 		user.Email,
 		user.Name,
 		user.Role,
+		user.ManagerID,
+		user.Department,
+		user.IsActive,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -55,68 +73,145 @@ func (r *postgresUserRepository) Create(ctx context.Context, user *domain.User)
 
 func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
-	row := r.db.QueryRowContext(ctx, query, id)
-
-	var user domain.User
-	err := row.Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
-	return &user, nil
+	return user, nil
 }
 
 func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, name, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
-	row := r.db.QueryRowContext(ctx, query, email)
-
-	var user domain.User
-	err := row.Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
-	return &user, nil
+	return user, nil
+}
+
+// GetBySubject はIdPの (issuer, sub) に紐付いたユーザーを取得します
+func (r *postgresUserRepository) GetBySubject(ctx context.Context, issuer, sub string) (*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE issuer = $1 AND sub = $2
+	`
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, issuer, sub))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by subject: %w", err)
+	}
+	return user, nil
+}
+
+// ListByEmail はメールアドレスが一致する（大文字小文字を区別しない）削除されていないユーザーを全て取得します
+func (r *postgresUserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by email: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return users, nil
+}
+
+// List は削除されていないユーザーを作成日時の順に取得し、絞り込み条件に一致する総件数とともに返します
+func (r *postgresUserRepository) List(ctx context.Context, filter UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	where := ` WHERE deleted_at IS NULL`
+	var args []interface{}
+	argCount := 1
+
+	if filter.Email != nil {
+		where += fmt.Sprintf(" AND LOWER(email) = LOWER($%d)", argCount)
+		args = append(args, *filter.Email)
+		argCount++
+	}
+	if filter.ExternalID != nil {
+		where += fmt.Sprintf(" AND external_id = $%d", argCount)
+		args = append(args, *filter.ExternalID)
+		argCount++
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + where +
+		fmt.Sprintf(" ORDER BY created_at, id LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return users, total, nil
 }
 
 func (r *postgresUserRepository) Update(ctx context.Context, user *domain.User) error {
 	user.UpdatedAt = time.Now()
 	query := `
 		UPDATE users
-		SET email = $1, name = $2, role = $3, updated_at = $4
-		WHERE id = $5
+		SET issuer = $1, sub = $2, external_id = $3, email = $4, name = $5, role = $6, manager_id = $7,
+		    department = $8, is_active = $9, updated_at = $10, deleted_at = $11
+		WHERE id = $12
 	`
 	result, err := r.db.ExecContext(ctx, query,
+		nullableString(user.Issuer),
+		nullableString(user.Sub),
+		nullableString(user.ExternalID),
 		user.Email,
 		user.Name,
 		user.Role,
+		user.ManagerID,
+		user.Department,
+		user.IsActive,
 		user.UpdatedAt,
+		user.DeletedAt,
 		user.ID,
 	)
 	if err != nil {
@@ -151,3 +246,46 @@ func (r *postgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error
 
 	return nil
 }
+
+// userScanner は *sql.Row と *sql.Rows の共通インターフェース
+type userScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser はユーザーの行を読み取ります（issuer・sub・external_id が NULL の場合は空文字列）
+func scanUser(row userScanner) (*domain.User, error) {
+	var user domain.User
+	var issuer, sub, externalID sql.NullString
+	err := row.Scan(
+		&user.ID,
+		&issuer,
+		&sub,
+		&externalID,
+		&user.Email,
+		&user.Name,
+		&user.Role,
+		&user.ManagerID,
+		&user.Department,
+		&user.PenaltyScore,
+		&user.PenaltyScoreExpireAt,
+		&user.IsActive,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	user.Issuer = issuer.String
+	user.Sub = sub.String
+	user.ExternalID = externalID.String
+	return &user, nil
+}
+
+// nullableString は空文字列を NULL として書き込むための値を返します
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
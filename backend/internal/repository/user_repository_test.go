@@ -15,6 +15,9 @@ import (
 	"github.com/your-org/esms/internal/repository"
 )
 
+var userColumns = []string{"id", "issuer", "sub", "external_id", "email", "name", "role", "manager_id", "department", "penalty_score",
+	"penalty_score_expire_at", "is_active", "created_at", "updated_at", "deleted_at"}
+
 func TestUserRepository_Create(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -25,15 +28,18 @@ func TestUserRepository_Create(t *testing.T) {
 
 	user := &domain.User{
 		ID:        uuid.New(),
+		Issuer:    "https://idp.example.com",
+		Sub:       "user-sub",
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Role:      domain.RoleGeneral,
+		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO users`)).
-		WithArgs(user.ID, user.Email, user.Name, user.Role, user.CreatedAt, user.UpdatedAt).
+		WithArgs(user.ID, user.Issuer, user.Sub, nil, user.Email, user.Name, user.Role, user.ManagerID, user.Department, true, user.CreatedAt, user.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Create(ctx, user)
@@ -54,14 +60,17 @@ func TestUserRepository_GetByID(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "Test User",
 		Role:      domain.RoleGeneral,
+		Issuer:    "https://idp.example.com",
+		Sub:       "user-sub",
+		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "email", "name", "role", "created_at", "updated_at"}).
-		AddRow(expectedUser.ID, expectedUser.Email, expectedUser.Name, expectedUser.Role, expectedUser.CreatedAt, expectedUser.UpdatedAt)
+	rows := sqlmock.NewRows(userColumns).
+		AddRow(expectedUser.ID, expectedUser.Issuer, expectedUser.Sub, nil, expectedUser.Email, expectedUser.Name, expectedUser.Role, expectedUser.ManagerID, expectedUser.Department, expectedUser.PenaltyScore, expectedUser.PenaltyScoreExpireAt, expectedUser.IsActive, expectedUser.CreatedAt, expectedUser.UpdatedAt, expectedUser.DeletedAt)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, name, role, created_at, updated_at FROM users WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, issuer, sub, external_id, email, name, role, manager_id, department, penalty_score, penalty_score_expire_at, is_active, created_at, updated_at, deleted_at FROM users WHERE id = $1`)).
 		WithArgs(expectedUser.ID).
 		WillReturnRows(rows)
 
@@ -79,7 +88,7 @@ func TestUserRepository_GetByID_NotFound(t *testing.T) {
 	repo := repository.NewUserRepository(db)
 	ctx := context.Background()
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, email, name, role, created_at, updated_at FROM users WHERE id = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, issuer, sub, external_id, email, name, role, manager_id, department, penalty_score, penalty_score_expire_at, is_active, created_at, updated_at, deleted_at FROM users WHERE id = $1`)).
 		WithArgs(sqlmock.AnyArg()).
 		WillReturnError(sql.ErrNoRows)
 
@@ -98,17 +107,19 @@ func TestUserRepository_Update(t *testing.T) {
 	repo := repository.NewUserRepository(db)
 	ctx := context.Background()
 
+	managerID := uuid.New()
 	user := &domain.User{
-		ID:    uuid.New(),
-		Email: "updated@example.com",
-		Name:  "Updated User",
-		Role:  domain.RoleManager,
+		ID:        uuid.New(),
+		Email:     "updated@example.com",
+		Name:      "Updated User",
+		Role:      domain.RoleManager,
+		ManagerID: &managerID,
 	}
 
 	// Update内部でtime.Now()が呼ばれるため、AnyArgを使用するか、実装側で時刻を受け取るようにするか。
 	// ここではAnyArgを使用する。
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET email = $1, name = $2, role = $3, updated_at = $4 WHERE id = $5`)).
-		WithArgs(user.Email, user.Name, user.Role, sqlmock.AnyArg(), user.ID).
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE users SET issuer = $1, sub = $2, external_id = $3, email = $4, name = $5, role = $6, manager_id = $7, department = $8, is_active = $9, updated_at = $10, deleted_at = $11 WHERE id = $12`)).
+		WithArgs(nil, nil, nil, user.Email, user.Name, user.Role, user.ManagerID, user.Department, user.IsActive, sqlmock.AnyArg(), user.DeletedAt, user.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Update(ctx, user)
@@ -133,3 +144,81 @@ func TestUserRepository_Delete(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserRepository_GetBySubject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM users WHERE issuer = $1 AND sub = $2`)).
+		WithArgs("https://idp.example.com", "user-sub").
+		WillReturnRows(sqlmock.NewRows(userColumns).
+			AddRow(userID, "https://idp.example.com", "user-sub", nil, "test@example.com", "Test User", domain.RoleGeneral, nil, nil, 0, nil, true, now, now, nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM users WHERE issuer = $1 AND sub = $2`)).
+		WithArgs("https://idp.example.com", "unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	user, err := repo.GetBySubject(ctx, "https://idp.example.com", "user-sub")
+	assert.NoError(t, err)
+	assert.Equal(t, userID, user.ID)
+	assert.True(t, user.HasIdentity("https://idp.example.com", "user-sub"))
+
+	_, err = repo.GetBySubject(ctx, "https://idp.example.com", "unknown")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_ListByEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUserRepository(db)
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL`)).
+		WithArgs("User@Example.com").
+		WillReturnRows(sqlmock.NewRows(userColumns).
+			AddRow(uuid.New(), nil, nil, nil, "user@example.com", "Unlinked", domain.RoleGeneral, nil, nil, 0, nil, true, now, now, nil).
+			AddRow(uuid.New(), "https://idp.example.com", "user-sub", nil, "USER@example.com", "Linked", domain.RoleGeneral, nil, nil, 0, nil, true, now, now, nil))
+
+	users, err := repo.ListByEmail(context.Background(), "User@Example.com")
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	// issuer・sub が NULL のユーザーは未連携
+	assert.False(t, users[0].IsLinked())
+	assert.True(t, users[1].IsLinked())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewUserRepository(db)
+
+	now := time.Now()
+	email, externalID := "User@Example.com", "00u1ab2cd3"
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND LOWER(email) = LOWER($1) AND external_id = $2`)).
+		WithArgs(email, externalID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM users WHERE deleted_at IS NULL AND LOWER(email) = LOWER($1) AND external_id = $2 ORDER BY created_at, id LIMIT $3 OFFSET $4`)).
+		WithArgs(email, externalID, 10, 0).
+		WillReturnRows(sqlmock.NewRows(userColumns).
+			AddRow(uuid.New(), nil, nil, externalID, "user@example.com", "Provisioned", domain.RoleGeneral, nil, "Sales", 0, nil, false, now, now, nil))
+
+	users, total, err := repo.List(context.Background(), repository.UserFilter{Email: &email, ExternalID: &externalID}, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, users, 1)
+	assert.Equal(t, externalID, users[0].ExternalID)
+	assert.Equal(t, "Sales", *users[0].Department)
+	assert.False(t, users[0].IsActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
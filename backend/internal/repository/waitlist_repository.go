@@ -0,0 +1,220 @@
+// backend/internal/repository/waitlist_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+)
+
+// WaitlistRepository はウェイトリスト登録へのアクセスを提供するインターフェース
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *domain.WaitlistEntry) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.WaitlistEntry, error)
+	Update(ctx context.Context, entry *domain.WaitlistEntry) error
+	HasActiveEntry(ctx context.Context, resourceID, userID uuid.UUID, startAt, endAt time.Time) (bool, error)
+	ListWaiting(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.WaitlistEntry, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error)
+	ListExpiredOffers(ctx context.Context, now time.Time) ([]*domain.WaitlistEntry, error)
+}
+
+// postgresWaitlistRepository はPostgreSQLを使用したWaitlistRepositoryの実装
+type postgresWaitlistRepository struct {
+	db *sql.DB
+}
+
+// NewWaitlistRepository は新しいWaitlistRepositoryを作成します
+func NewWaitlistRepository(db *sql.DB) WaitlistRepository {
+	return &postgresWaitlistRepository{db: db}
+}
+
+// Create はウェイトリスト登録を作成します
+func (r *postgresWaitlistRepository) Create(ctx context.Context, entry *domain.WaitlistEntry) error {
+	query := `
+		INSERT INTO waitlist_entries (id, resource_id, user_id, title, start_at, end_at, timezone, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		entry.ID,
+		entry.ResourceID,
+		entry.UserID,
+		entry.Title,
+		entry.StartAt,
+		entry.EndAt,
+		entry.Timezone,
+		entry.Status,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return nil
+}
+
+// GetByID はIDでウェイトリスト登録を取得します
+func (r *postgresWaitlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WaitlistEntry, error) {
+	query := `
+		SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,
+		       hold_reservation_id, offered_at, offer_expires_at, created_at, updated_at
+		FROM waitlist_entries
+		WHERE id = $1
+	`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist entry by id: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanWaitlistEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+	return entries[0], nil
+}
+
+// Update はウェイトリスト登録の状態と繰り上げ情報を更新します
+func (r *postgresWaitlistRepository) Update(ctx context.Context, entry *domain.WaitlistEntry) error {
+	entry.UpdatedAt = time.Now()
+	query := `
+		UPDATE waitlist_entries
+		SET status = $1, hold_reservation_id = $2, offered_at = $3, offer_expires_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		entry.Status,
+		entry.HoldReservationID,
+		entry.OfferedAt,
+		entry.OfferExpiresAt,
+		entry.UpdatedAt,
+		entry.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// HasActiveEntry は同じユーザーが同じ枠に空き待ち中または繰り上げ提示中の登録を持つかを判定します
+func (r *postgresWaitlistRepository) HasActiveEntry(ctx context.Context, resourceID, userID uuid.UUID, startAt, endAt time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM waitlist_entries
+			WHERE resource_id = $1 AND user_id = $2 AND start_at = $3 AND end_at = $4
+			  AND status IN ('WAITING', 'OFFERED')
+		)
+	`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, resourceID, userID, startAt, endAt).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check waitlist entry: %w", err)
+	}
+	return exists, nil
+}
+
+// ListWaiting は指定された期間に重なる空き待ち登録を登録順に取得します
+func (r *postgresWaitlistRepository) ListWaiting(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.WaitlistEntry, error) {
+	query := `
+		SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,
+		       hold_reservation_id, offered_at, offer_expires_at, created_at, updated_at
+		FROM waitlist_entries
+		WHERE resource_id = $1
+		  AND status = 'WAITING'
+		  AND start_at < $3
+		  AND end_at > $2
+		ORDER BY created_at, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, resourceID, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting entries: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWaitlistEntries(rows)
+}
+
+// ListActiveByUser はユーザーの空き待ち中・繰り上げ提示中の登録を開始日時順に取得します
+func (r *postgresWaitlistRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error) {
+	query := `
+		SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,
+		       hold_reservation_id, offered_at, offer_expires_at, created_at, updated_at
+		FROM waitlist_entries
+		WHERE user_id = $1
+		  AND status IN ('WAITING', 'OFFERED')
+		ORDER BY start_at, created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries by user: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWaitlistEntries(rows)
+}
+
+// ListExpiredOffers は確認期限を過ぎた繰り上げ提示を取得します
+func (r *postgresWaitlistRepository) ListExpiredOffers(ctx context.Context, now time.Time) ([]*domain.WaitlistEntry, error) {
+	query := `
+		SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,
+		       hold_reservation_id, offered_at, offer_expires_at, created_at, updated_at
+		FROM waitlist_entries
+		WHERE status = 'OFFERED'
+		  AND offer_expires_at <= $1
+		ORDER BY offer_expires_at
+	`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired waitlist offers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWaitlistEntries(rows)
+}
+
+// scanWaitlistEntries はウェイトリスト登録の行を読み取ります
+func scanWaitlistEntries(rows *sql.Rows) ([]*domain.WaitlistEntry, error) {
+	var entries []*domain.WaitlistEntry
+	for rows.Next() {
+		var entry domain.WaitlistEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ResourceID,
+			&entry.UserID,
+			&entry.Title,
+			&entry.StartAt,
+			&entry.EndAt,
+			&entry.Timezone,
+			&entry.Status,
+			&entry.HoldReservationID,
+			&entry.OfferedAt,
+			&entry.OfferExpiresAt,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return entries, nil
+}
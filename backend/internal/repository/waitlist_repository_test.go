@@ -0,0 +1,85 @@
+// backend/internal/repository/waitlist_repository_test.go
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var waitlistColumns = []string{"id", "resource_id", "user_id", "title", "start_at", "end_at", "timezone", "status",
+	"hold_reservation_id", "offered_at", "offer_expires_at", "created_at", "updated_at"}
+
+func TestWaitlistRepository_ListWaiting(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewWaitlistRepository(db)
+	ctx := context.Background()
+
+	resourceID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(time.Hour)
+	now := time.Now()
+	firstID, secondID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,`)).
+		WithArgs(resourceID, startAt, endAt).
+		WillReturnRows(sqlmock.NewRows(waitlistColumns).
+			AddRow(firstID, resourceID, uuid.New(), "Design review", startAt, endAt, "Asia/Tokyo", "WAITING", nil, nil, nil, now, now).
+			AddRow(secondID, resourceID, uuid.New(), "1on1", startAt, startAt.Add(30*time.Minute), "Asia/Tokyo", "WAITING", nil, nil, nil, now, now))
+
+	entries, err := repo.ListWaiting(ctx, resourceID, startAt, endAt)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, firstID, entries[0].ID)
+	assert.Equal(t, domain.WaitlistStatusWaiting, entries[0].Status)
+	assert.Nil(t, entries[0].HoldReservationID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWaitlistRepository_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewWaitlistRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	entry := &domain.WaitlistEntry{ID: uuid.New(), Status: domain.WaitlistStatusWaiting}
+	entry.Offer(uuid.New(), now, 15*time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE waitlist_entries`)).
+		WithArgs(domain.WaitlistStatusOffered, entry.HoldReservationID, entry.OfferedAt, entry.OfferExpiresAt, sqlmock.AnyArg(), entry.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.Update(ctx, entry))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWaitlistRepository_GetByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewWaitlistRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, resource_id, user_id, title, start_at, end_at, timezone, status,`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(waitlistColumns))
+
+	_, err = repo.GetByID(ctx, id)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
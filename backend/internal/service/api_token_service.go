@@ -0,0 +1,392 @@
+// backend/internal/service/api_token_service.go
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrInvalidAPIToken        = errors.New("invalid api token")
+	ErrInvalidAPITokenRequest = errors.New("invalid api token request")
+)
+
+const (
+	// APITokenPrefix はAPIトークンの接頭辞（セッションIDと区別し、漏洩時に検出しやすくするため）
+	APITokenPrefix = "esms_"
+	// DefaultAPITokenTTL は有効期限を指定しない場合のAPIトークンの有効期間
+	DefaultAPITokenTTL = 90 * 24 * time.Hour
+	// MaxAPITokenTTL はAPIトークンの有効期間の上限
+	MaxAPITokenTTL = 365 * 24 * time.Hour
+
+	// apiTokenDisplayLength は一覧での識別用に保存するトークンの先頭部分の長さ
+	apiTokenDisplayLength = len(APITokenPrefix) + 8
+	// apiTokenUseRecordInterval は最終使用日時・監査ログを記録する間隔（リクエストごとに書き込まないため）
+	apiTokenUseRecordInterval = 5 * time.Minute
+)
+
+// IsAPIToken は Bearer トークンがAPIトークン（セッションIDでない）かどうかを判定します
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// hashAPIToken はAPIトークンのハッシュ値（SHA-256 の16進数）を返します
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPITokenRequest はAPIトークンの作成リクエスト
+type CreateAPITokenRequest struct {
+	Name      string                 `json:"name"`
+	Scopes    []domain.APITokenScope `json:"scopes"`               // read, write
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"` // 省略した場合は DefaultAPITokenTTL 後
+}
+
+// APITokenService は個人用アクセストークン・サービスアカウントのAPIキーを扱うサービス
+// トークンはハッシュ値のみを保存し、作成時に一度だけ返します
+type APITokenService struct {
+	tokenRepo          repository.APITokenRepository
+	serviceAccountRepo repository.ServiceAccountRepository
+	userRepo           repository.UserRepository
+	auditLogRepo       repository.AuditLogRepository
+}
+
+// NewAPITokenService は新しいAPITokenServiceを作成します
+func NewAPITokenService(
+	tokenRepo repository.APITokenRepository,
+	serviceAccountRepo repository.ServiceAccountRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+) *APITokenService {
+	return &APITokenService{
+		tokenRepo:          tokenRepo,
+		serviceAccountRepo: serviceAccountRepo,
+		userRepo:           userRepo,
+		auditLogRepo:       auditLogRepo,
+	}
+}
+
+// Authenticate はAPIトークンを検証し、トークンの所有者を表すセッションを返します
+// 個人用アクセストークンは所有者の現在のロールで、サービスアカウントのAPIキーはサービスアカウントのロールで操作します
+// （サービスアカウントは特定の個人に紐付かないため、UserID は domain.SystemUserID になります）
+// 無効化・期限切れのトークンや、無効化された所有者のトークンは ErrInvalidAPIToken を返します
+func (s *APITokenService) Authenticate(ctx context.Context, rawToken, ip string) (*Session, error) {
+	token, err := s.tokenRepo.GetByHash(ctx, hashAPIToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidAPIToken
+		}
+		return nil, fmt.Errorf("failed to get api token: %w", err)
+	}
+
+	now := time.Now()
+	if !token.IsUsable(now) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	session := &Session{
+		ExpiresAt:        token.ExpiresAt,
+		CreatedAt:        token.CreatedAt,
+		APITokenID:       &token.ID,
+		ServiceAccountID: token.ServiceAccountID,
+		Scopes:           token.Scopes,
+	}
+	if token.IsServiceAccount() {
+		account, err := s.serviceAccountRepo.GetByID(ctx, *token.ServiceAccountID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrInvalidAPIToken
+			}
+			return nil, fmt.Errorf("failed to get service account: %w", err)
+		}
+		if !account.IsActive() {
+			return nil, ErrInvalidAPIToken
+		}
+		session.UserID = domain.SystemUserID
+		session.Name = account.Name
+		session.Role = account.Role
+	} else {
+		user, err := s.userRepo.GetByID(ctx, *token.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrInvalidAPIToken
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsValid() {
+			return nil, ErrInvalidAPIToken
+		}
+		session.UserID = user.ID
+		session.Email = user.Email
+		session.Name = user.Name
+		session.Role = user.Role
+	}
+
+	s.recordUse(ctx, token, session, now, ip)
+	return session, nil
+}
+
+// recordUse はAPIトークンの最終使用日時を更新し、監査ログに記録します
+// 前回の記録から apiTokenUseRecordInterval 以内の使用は記録しません（認証自体は失敗させません）
+func (s *APITokenService) recordUse(ctx context.Context, token *domain.APIToken, session *Session, now time.Time, ip string) {
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) < apiTokenUseRecordInterval {
+		return
+	}
+	if err := s.tokenRepo.RecordUse(ctx, token.ID, now, ip); err != nil {
+		log.Printf("Failed to record api token use: %v", err)
+		return
+	}
+
+	details := map[string]interface{}{
+		"name":   token.Name,
+		"prefix": token.Prefix,
+	}
+	if token.LastUsedAt != nil {
+		details["previous_used_at"] = token.LastUsedAt.Format(time.RFC3339)
+	}
+	if token.ServiceAccountID != nil {
+		details["service_account_id"] = token.ServiceAccountID.String()
+	}
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     session.UserID,
+		Action:     domain.AuditActionAPITokenUse,
+		TargetType: "api_token",
+		TargetID:   token.ID.String(),
+		Details:    details,
+		IPAddress:  ip,
+		CreatedAt:  now,
+	})
+}
+
+// GetToken はAPIトークンを取得します
+func (s *APITokenService) GetToken(ctx context.Context, tokenID uuid.UUID) (*domain.APIToken, error) {
+	return s.tokenRepo.GetByID(ctx, tokenID)
+}
+
+// ListUserTokens はユーザーの有効な（無効化されていない）個人用アクセストークンを取得します
+func (s *APITokenService) ListUserTokens(ctx context.Context, userID uuid.UUID) ([]*domain.APIToken, error) {
+	return s.tokenRepo.List(ctx, repository.APITokenFilter{UserID: &userID})
+}
+
+// ListServiceAccountTokens はサービスアカウントの有効な（無効化されていない）APIキーを取得します
+func (s *APITokenService) ListServiceAccountTokens(ctx context.Context, serviceAccountID uuid.UUID) ([]*domain.APIToken, error) {
+	return s.tokenRepo.List(ctx, repository.APITokenFilter{ServiceAccountID: &serviceAccountID})
+}
+
+// CreateUserToken はユーザーの個人用アクセストークンを作成し、トークンとその平文を返します
+// 平文のトークンは保存しないため、作成時にのみ取得できます
+func (s *APITokenService) CreateUserToken(ctx context.Context, actorID, userID uuid.UUID, req CreateAPITokenRequest) (*domain.APIToken, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !user.IsValid() {
+		return nil, "", ErrUserDeactivated
+	}
+
+	token := &domain.APIToken{UserID: &user.ID}
+	return s.createToken(ctx, actorID, token, req)
+}
+
+// CreateServiceAccountToken はサービスアカウントのAPIキーを作成し、トークンとその平文を返します
+func (s *APITokenService) CreateServiceAccountToken(ctx context.Context, actorID, serviceAccountID uuid.UUID, req CreateAPITokenRequest) (*domain.APIToken, string, error) {
+	account, err := s.serviceAccountRepo.GetByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !account.IsActive() {
+		return nil, "", fmt.Errorf("%w: service account is disabled", ErrInvalidAPITokenRequest)
+	}
+
+	token := &domain.APIToken{ServiceAccountID: &account.ID}
+	return s.createToken(ctx, actorID, token, req)
+}
+
+// createToken はリクエストを検証してトークンを発行します
+func (s *APITokenService) createToken(ctx context.Context, actorID uuid.UUID, token *domain.APIToken, req CreateAPITokenRequest) (*domain.APIToken, string, error) {
+	now := time.Now()
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidAPITokenRequest)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPITokenScope)
+	}
+	for _, scope := range req.Scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("%w: %s", domain.ErrInvalidAPITokenScope, scope)
+		}
+	}
+	expiresAt := now.Add(DefaultAPITokenTTL)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	if !expiresAt.After(now) || expiresAt.Sub(now) > MaxAPITokenTTL {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future and within %d days", ErrInvalidAPITokenRequest, int(MaxAPITokenTTL.Hours()/24))
+	}
+
+	secret, err := newRandomToken()
+	if err != nil {
+		return nil, "", err
+	}
+	rawToken := APITokenPrefix + secret
+
+	token.ID = uuid.New()
+	token.Name = name
+	token.Prefix = rawToken[:apiTokenDisplayLength]
+	token.TokenHash = hashAPIToken(rawToken)
+	token.Scopes = req.Scopes
+	token.ExpiresAt = expiresAt
+	token.CreatedBy = actorID
+	token.CreatedAt = now
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	details := map[string]interface{}{
+		"name":       token.Name,
+		"prefix":     token.Prefix,
+		"scopes":     token.Scopes,
+		"expires_at": token.ExpiresAt.Format(time.RFC3339),
+	}
+	if token.UserID != nil {
+		details["user_id"] = token.UserID.String()
+	}
+	if token.ServiceAccountID != nil {
+		details["service_account_id"] = token.ServiceAccountID.String()
+	}
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionAPITokenCreate,
+		TargetType: "api_token",
+		TargetID:   token.ID.String(),
+		Details:    details,
+		CreatedAt:  now,
+	})
+
+	return token, rawToken, nil
+}
+
+// RevokeToken はAPIトークンを無効化します（無効化済みの場合は repository.ErrNotFound）
+func (s *APITokenService) RevokeToken(ctx context.Context, actorID, tokenID uuid.UUID) error {
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.tokenRepo.Revoke(ctx, tokenID, actorID, now); err != nil {
+		return err
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionAPITokenRevoke,
+		TargetType: "api_token",
+		TargetID:   token.ID.String(),
+		Details: map[string]interface{}{
+			"name":   token.Name,
+			"prefix": token.Prefix,
+		},
+		CreatedAt: now,
+	})
+	return nil
+}
+
+// ListServiceAccounts はサービスアカウントを取得します
+func (s *APITokenService) ListServiceAccounts(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	return s.serviceAccountRepo.List(ctx)
+}
+
+// CreateServiceAccount はサービスアカウントを作成します
+func (s *APITokenService) CreateServiceAccount(ctx context.Context, actorID uuid.UUID, name, description string, role domain.Role) (*domain.ServiceAccount, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAPITokenRequest)
+	}
+	if role == "" {
+		role = domain.RoleGeneral
+	}
+	// 漏洩した場合の影響を抑えるため、管理者のロールは付与しない
+	if !role.IsValid() || role == domain.RoleAdmin {
+		return nil, fmt.Errorf("%w: role %s cannot be assigned to a service account", ErrInvalidAPITokenRequest, role)
+	}
+
+	now := time.Now()
+	account := &domain.ServiceAccount{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		Role:        role,
+		CreatedBy:   actorID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.serviceAccountRepo.Create(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionServiceAccountCreate,
+		TargetType: "service_account",
+		TargetID:   account.ID.String(),
+		Details: map[string]interface{}{
+			"name": account.Name,
+			"role": account.Role,
+		},
+		CreatedAt: now,
+	})
+	return account, nil
+}
+
+// DisableServiceAccount はサービスアカウントを無効化し、そのAPIキーを全て無効化します
+func (s *APITokenService) DisableServiceAccount(ctx context.Context, actorID, serviceAccountID uuid.UUID) (*domain.ServiceAccount, error) {
+	account, err := s.serviceAccountRepo.GetByID(ctx, serviceAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive() {
+		return account, nil
+	}
+
+	now := time.Now()
+	account.DisabledAt = &now
+	account.UpdatedAt = now
+	if err := s.serviceAccountRepo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to disable service account: %w", err)
+	}
+	revoked, err := s.tokenRepo.RevokeByServiceAccount(ctx, account.ID, actorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionServiceAccountDisable,
+		TargetType: "service_account",
+		TargetID:   account.ID.String(),
+		Details: map[string]interface{}{
+			"name":           account.Name,
+			"revoked_tokens": revoked,
+		},
+		CreatedAt: now,
+	})
+	return account, nil
+}
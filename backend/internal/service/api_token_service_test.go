@@ -0,0 +1,332 @@
+// backend/internal/service/api_token_service_test.go
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestAPITokenService_CreateUserToken(t *testing.T) {
+	userID := uuid.New()
+	tooLate := time.Now().Add(service.MaxAPITokenTTL + time.Hour)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		user    *domain.User
+		req     service.CreateAPITokenRequest
+		wantErr error
+	}{
+		{
+			name: "Read-only token with default expiry",
+			user: &domain.User{ID: userID, IsActive: true},
+			req:  service.CreateAPITokenRequest{Name: "reporting", Scopes: []domain.APITokenScope{domain.APITokenScopeRead}},
+		},
+		{
+			name:    "Missing name",
+			user:    &domain.User{ID: userID, IsActive: true},
+			req:     service.CreateAPITokenRequest{Name: " ", Scopes: []domain.APITokenScope{domain.APITokenScopeRead}},
+			wantErr: service.ErrInvalidAPITokenRequest,
+		},
+		{
+			name:    "Unknown scope",
+			user:    &domain.User{ID: userID, IsActive: true},
+			req:     service.CreateAPITokenRequest{Name: "reporting", Scopes: []domain.APITokenScope{"admin"}},
+			wantErr: domain.ErrInvalidAPITokenScope,
+		},
+		{
+			name:    "No scopes",
+			user:    &domain.User{ID: userID, IsActive: true},
+			req:     service.CreateAPITokenRequest{Name: "reporting"},
+			wantErr: domain.ErrInvalidAPITokenScope,
+		},
+		{
+			name:    "Expiry beyond the maximum",
+			user:    &domain.User{ID: userID, IsActive: true},
+			req:     service.CreateAPITokenRequest{Name: "reporting", Scopes: []domain.APITokenScope{domain.APITokenScopeRead}, ExpiresAt: &tooLate},
+			wantErr: service.ErrInvalidAPITokenRequest,
+		},
+		{
+			name:    "Expiry in the past",
+			user:    &domain.User{ID: userID, IsActive: true},
+			req:     service.CreateAPITokenRequest{Name: "reporting", Scopes: []domain.APITokenScope{domain.APITokenScopeRead}, ExpiresAt: &past},
+			wantErr: service.ErrInvalidAPITokenRequest,
+		},
+		{
+			name:    "Deactivated user",
+			user:    &domain.User{ID: userID, IsActive: false},
+			req:     service.CreateAPITokenRequest{Name: "reporting", Scopes: []domain.APITokenScope{domain.APITokenScopeRead}},
+			wantErr: service.ErrUserDeactivated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPITokenRepo := new(MockAPITokenRepository)
+			mockServiceAccountRepo := new(MockServiceAccountRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+			mockUserRepo.On("GetByID", mock.Anything, userID).Return(tt.user, nil)
+			mockAPITokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			token, raw, err := svc.CreateUserToken(context.Background(), userID, userID, tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAPITokenRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+
+			// 平文のトークンは保存せず、ハッシュ値と識別用の先頭部分のみを保存する
+			assert.True(t, service.IsAPIToken(raw))
+			assert.True(t, strings.HasPrefix(raw, token.Prefix))
+			assert.NotContains(t, token.TokenHash, raw)
+			assert.Len(t, token.TokenHash, 64)
+			assert.Equal(t, userID, *token.UserID)
+			assert.Nil(t, token.ServiceAccountID)
+			assert.WithinDuration(t, time.Now().Add(service.DefaultAPITokenTTL), token.ExpiresAt, time.Minute)
+			assert.Equal(t, []domain.AuditAction{domain.AuditActionAPITokenCreate}, auditActions(mockAuditLogRepo))
+		})
+	}
+}
+
+func TestAPITokenService_Authenticate(t *testing.T) {
+	userID, accountID := uuid.New(), uuid.New()
+	now := time.Now()
+	recently := now.Add(-time.Minute)
+	longAgo := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		token       *domain.APIToken
+		setupMocks  func(*MockUserRepository, *MockServiceAccountRepository)
+		wantErr     error
+		wantRecord  bool
+		wantUserID  uuid.UUID
+		wantRole    domain.Role
+		wantAccount bool
+	}{
+		{
+			name:  "Personal token acts with the owner's current role",
+			token: &domain.APIToken{UserID: &userID, Scopes: []domain.APITokenScope{domain.APITokenScopeRead}, ExpiresAt: now.Add(time.Hour)},
+			setupMocks: func(mu *MockUserRepository, _ *MockServiceAccountRepository) {
+				mu.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Role: domain.RoleManager, IsActive: true}, nil)
+			},
+			wantRecord: true,
+			wantUserID: userID,
+			wantRole:   domain.RoleManager,
+		},
+		{
+			name:  "Recently used token is not recorded again",
+			token: &domain.APIToken{UserID: &userID, Scopes: []domain.APITokenScope{domain.APITokenScopeRead}, ExpiresAt: now.Add(time.Hour), LastUsedAt: &recently},
+			setupMocks: func(mu *MockUserRepository, _ *MockServiceAccountRepository) {
+				mu.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Role: domain.RoleGeneral, IsActive: true}, nil)
+			},
+			wantUserID: userID,
+			wantRole:   domain.RoleGeneral,
+		},
+		{
+			name:  "Service account key is not tied to a person",
+			token: &domain.APIToken{ServiceAccountID: &accountID, Scopes: []domain.APITokenScope{domain.APITokenScopeWrite}, ExpiresAt: now.Add(time.Hour), LastUsedAt: &longAgo},
+			setupMocks: func(_ *MockUserRepository, ma *MockServiceAccountRepository) {
+				ma.On("GetByID", mock.Anything, accountID).Return(&domain.ServiceAccount{ID: accountID, Name: "room-display", Role: domain.RoleGeneral}, nil)
+			},
+			wantRecord:  true,
+			wantUserID:  domain.SystemUserID,
+			wantRole:    domain.RoleGeneral,
+			wantAccount: true,
+		},
+		{
+			name:       "Revoked token",
+			token:      &domain.APIToken{UserID: &userID, ExpiresAt: now.Add(time.Hour), RevokedAt: &recently},
+			setupMocks: func(*MockUserRepository, *MockServiceAccountRepository) {},
+			wantErr:    service.ErrInvalidAPIToken,
+		},
+		{
+			name:       "Expired token",
+			token:      &domain.APIToken{UserID: &userID, ExpiresAt: now.Add(-time.Second)},
+			setupMocks: func(*MockUserRepository, *MockServiceAccountRepository) {},
+			wantErr:    service.ErrInvalidAPIToken,
+		},
+		{
+			name:  "Deactivated owner",
+			token: &domain.APIToken{UserID: &userID, ExpiresAt: now.Add(time.Hour)},
+			setupMocks: func(mu *MockUserRepository, _ *MockServiceAccountRepository) {
+				mu.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, IsActive: false}, nil)
+			},
+			wantErr: service.ErrInvalidAPIToken,
+		},
+		{
+			name:  "Disabled service account",
+			token: &domain.APIToken{ServiceAccountID: &accountID, ExpiresAt: now.Add(time.Hour)},
+			setupMocks: func(_ *MockUserRepository, ma *MockServiceAccountRepository) {
+				ma.On("GetByID", mock.Anything, accountID).Return(&domain.ServiceAccount{ID: accountID, DisabledAt: &longAgo}, nil)
+			},
+			wantErr: service.ErrInvalidAPIToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPITokenRepo := new(MockAPITokenRepository)
+			mockServiceAccountRepo := new(MockServiceAccountRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+			tt.token.ID = uuid.New()
+			mockAPITokenRepo.On("GetByHash", mock.Anything, mock.Anything).Return(tt.token, nil)
+			mockAPITokenRepo.On("RecordUse", mock.Anything, tt.token.ID, mock.Anything, "192.0.2.1").Return(nil)
+			tt.setupMocks(mockUserRepo, mockServiceAccountRepo)
+
+			session, err := svc.Authenticate(context.Background(), service.APITokenPrefix+"secret", "192.0.2.1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAPITokenRepo.AssertNotCalled(t, "RecordUse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.True(t, session.IsAPIToken())
+			assert.Equal(t, tt.token.ID, *session.APITokenID)
+			assert.Equal(t, tt.wantUserID, session.UserID)
+			assert.Equal(t, tt.wantRole, session.Role)
+			assert.Equal(t, tt.wantAccount, session.ServiceAccountID != nil)
+			assert.Equal(t, tt.token.Scopes, session.Scopes)
+
+			if tt.wantRecord {
+				mockAPITokenRepo.AssertCalled(t, "RecordUse", mock.Anything, tt.token.ID, mock.Anything, "192.0.2.1")
+				assert.Equal(t, []domain.AuditAction{domain.AuditActionAPITokenUse}, auditActions(mockAuditLogRepo))
+			} else {
+				mockAPITokenRepo.AssertNotCalled(t, "RecordUse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				assert.Empty(t, auditActions(mockAuditLogRepo))
+			}
+		})
+	}
+}
+
+func TestAPITokenService_Authenticate_UnknownToken(t *testing.T) {
+	mockAPITokenRepo := new(MockAPITokenRepository)
+	mockServiceAccountRepo := new(MockServiceAccountRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+	mockAPITokenRepo.On("GetByHash", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	_, err := svc.Authenticate(context.Background(), service.APITokenPrefix+"unknown", "192.0.2.1")
+	assert.ErrorIs(t, err, service.ErrInvalidAPIToken)
+}
+
+func TestAPITokenService_CreatedTokenAuthenticates(t *testing.T) {
+	mockAPITokenRepo := new(MockAPITokenRepository)
+	mockServiceAccountRepo := new(MockServiceAccountRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+	userID := uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Role: domain.RoleGeneral, IsActive: true}, nil)
+
+	var stored *domain.APIToken
+	mockAPITokenRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.APIToken)
+	}).Return(nil)
+
+	_, raw, err := svc.CreateUserToken(context.Background(), userID, userID, service.CreateAPITokenRequest{
+		Name:   "reporting",
+		Scopes: []domain.APITokenScope{domain.APITokenScopeRead},
+	})
+	require.NoError(t, err)
+
+	// 保存したハッシュ値で検索できること
+	mockAPITokenRepo.On("GetByHash", mock.Anything, stored.TokenHash).Return(stored, nil)
+	mockAPITokenRepo.On("RecordUse", mock.Anything, stored.ID, mock.Anything, mock.Anything).Return(nil)
+
+	session, err := svc.Authenticate(context.Background(), raw, "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+}
+
+func TestAPITokenService_RevokeToken(t *testing.T) {
+	mockAPITokenRepo := new(MockAPITokenRepository)
+	mockServiceAccountRepo := new(MockServiceAccountRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+	tokenID, actorID := uuid.New(), uuid.New()
+	mockAPITokenRepo.On("GetByID", mock.Anything, tokenID).Return(&domain.APIToken{ID: tokenID, Name: "reporting"}, nil)
+	mockAPITokenRepo.On("Revoke", mock.Anything, tokenID, actorID, mock.Anything).Return(nil)
+
+	err := svc.RevokeToken(context.Background(), actorID, tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionAPITokenRevoke}, auditActions(mockAuditLogRepo))
+}
+
+func TestAPITokenService_CreateServiceAccount(t *testing.T) {
+	mockAPITokenRepo := new(MockAPITokenRepository)
+	mockServiceAccountRepo := new(MockServiceAccountRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+	mockServiceAccountRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	actorID := uuid.New()
+
+	account, err := svc.CreateServiceAccount(context.Background(), actorID, "room-display", "会議室前の表示端末", "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleGeneral, account.Role)
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionServiceAccountCreate}, auditActions(mockAuditLogRepo))
+
+	// 管理者のロールは付与できない
+	_, err = svc.CreateServiceAccount(context.Background(), actorID, "admin-bot", "", domain.RoleAdmin)
+	assert.ErrorIs(t, err, service.ErrInvalidAPITokenRequest)
+}
+
+func TestAPITokenService_DisableServiceAccount(t *testing.T) {
+	mockAPITokenRepo := new(MockAPITokenRepository)
+	mockServiceAccountRepo := new(MockServiceAccountRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	svc := service.NewAPITokenService(mockAPITokenRepo, mockServiceAccountRepo, mockUserRepo, mockAuditLogRepo)
+
+	accountID, actorID := uuid.New(), uuid.New()
+	mockServiceAccountRepo.On("GetByID", mock.Anything, accountID).Return(&domain.ServiceAccount{ID: accountID, Name: "room-display"}, nil)
+	mockServiceAccountRepo.On("Update", mock.Anything, mock.MatchedBy(func(a *domain.ServiceAccount) bool {
+		return !a.IsActive()
+	})).Return(nil)
+	mockAPITokenRepo.On("RevokeByServiceAccount", mock.Anything, accountID, actorID, mock.Anything).Return(int64(2), nil)
+
+	account, err := svc.DisableServiceAccount(context.Background(), actorID, accountID)
+	require.NoError(t, err)
+	assert.False(t, account.IsActive())
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionServiceAccountDisable}, auditActions(mockAuditLogRepo))
+	mockAPITokenRepo.AssertExpectations(t)
+}
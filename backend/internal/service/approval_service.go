@@ -9,36 +9,186 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/queue"
 	"github.com/your-org/esms/internal/repository"
 )
 
 var (
-	ErrAlreadyApproved = errors.New("reservation is already approved")
-	ErrAlreadyRejected = errors.New("reservation is already rejected")
-	ErrNotApprover     = errors.New("user is not an approver for this reservation")
+	ErrAlreadyApproved         = errors.New("reservation is already approved")
+	ErrAlreadyRejected         = errors.New("reservation is already rejected")
+	ErrNotPendingApproval      = errors.New("reservation is not pending approval")
+	ErrNotApprover             = errors.New("user is not an approver for this reservation")
+	ErrApprovalChainUnresolved = errors.New("approval chain cannot be resolved")
+	ErrTooManyBulkItems        = errors.New("too many items in bulk request")
+	ErrInvalidBulkAction       = errors.New("invalid bulk decision action")
 )
 
+const (
+	// DefaultPendingApprovalsLimit は承認待ち一覧の既定の取得件数
+	DefaultPendingApprovalsLimit = 50
+	// MaxPendingApprovalsLimit は承認待ち一覧の最大取得件数
+	MaxPendingApprovalsLimit = 200
+	// MaxBulkDecisionItems は一括承認・却下で1度に処理できる最大件数
+	MaxBulkDecisionItems = 100
+)
+
+// PendingApprovalsQuery は承認待ち一覧の取得条件
+type PendingApprovalsQuery struct {
+	ResourceID *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// BulkDecisionAction は一括処理での判断の種類を表す型
+type BulkDecisionAction string
+
+const (
+	BulkDecisionApprove BulkDecisionAction = "approve"
+	BulkDecisionReject  BulkDecisionAction = "reject"
+)
+
+// BulkDecisionItem は一括承認・却下の1件分の指示
+type BulkDecisionItem struct {
+	ReservationID uuid.UUID
+	StartAt       time.Time
+	Action        BulkDecisionAction
+	Reason        string
+}
+
+// BulkDecisionResult は一括承認・却下の1件分の結果（Err が nil なら成功）
+type BulkDecisionResult struct {
+	Item BulkDecisionItem
+	Err  error
+}
+
 // ApprovalService は承認に関するビジネスロジックを提供します
 type ApprovalService struct {
-	reservationRepo repository.ReservationRepository
-	userRepo        repository.UserRepository
-	auditLogRepo    repository.AuditLogRepository
+	reservationRepo     repository.ReservationRepository
+	userRepo            repository.UserRepository
+	auditLogRepo        repository.AuditLogRepository
+	approverGroupRepo   repository.ApproverGroupRepository
+	approvalRepo        repository.ApprovalRepository
+	notificationService *NotificationService
+	jobQueue            queue.JobQueue
 }
 
 // NewApprovalService は新しいApprovalServiceを作成します
+// notificationService が nil の場合、通知は送信されません
+// jobQueue が nil の場合、却下・期限切れで解放した枠のウェイトリスト繰り上げは行いません
 func NewApprovalService(
 	reservationRepo repository.ReservationRepository,
 	userRepo repository.UserRepository,
 	auditLogRepo repository.AuditLogRepository,
+	approverGroupRepo repository.ApproverGroupRepository,
+	approvalRepo repository.ApprovalRepository,
+	notificationService *NotificationService,
+	jobQueue queue.JobQueue,
 ) *ApprovalService {
 	return &ApprovalService{
-		reservationRepo: reservationRepo,
-		userRepo:        userRepo,
-		auditLogRepo:    auditLogRepo,
+		reservationRepo:     reservationRepo,
+		userRepo:            userRepo,
+		auditLogRepo:        auditLogRepo,
+		approverGroupRepo:   approverGroupRepo,
+		approvalRepo:        approvalRepo,
+		notificationService: notificationService,
+		jobQueue:            jobQueue,
+	}
+}
+
+// BuildApprovalChain は予約対象のリソースの承認ポリシーから承認チェーンを組み立てます
+// 承認ポリシー未指定のリソースは承認者グループによる1段階承認となります
+// 複数リソースで同じ承認者のステップが重複する場合、そのステップは1つにまとめます
+func (s *ApprovalService) BuildApprovalChain(ctx context.Context, reservation *domain.Reservation, resources []*domain.Resource, organizer *domain.User) ([]*domain.ReservationApproval, error) {
+	var chain []*domain.ReservationApproval
+	for _, resource := range resources {
+		if !resource.NeedsApproval() {
+			continue
+		}
+
+		policy := domain.DefaultApprovalPolicy()
+		if resource.ApprovalPolicyID != nil {
+			p, err := s.approvalRepo.GetPolicyByID(ctx, *resource.ApprovalPolicyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get approval policy: %w", err)
+			}
+			policy = p
+		}
+
+		steps, err := policy.BuildChain(reservation, organizer, resource)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrApprovalChainUnresolved, err)
+		}
+
+		for _, step := range steps {
+			if containsApprover(chain, step) {
+				continue
+			}
+			chain = append(chain, step)
+		}
+	}
+
+	numberApprovalChain(chain)
+	return chain, nil
+}
+
+// WithManagerApproval は承認チェーンの先頭に予約者の上長の承認ステップを加えます
+// ペナルティスコアによって承認が必須になった予約に使用し、上長が既にチェーンに含まれる場合は加えません
+func (s *ApprovalService) WithManagerApproval(reservation *domain.Reservation, chain []*domain.ReservationApproval, organizer *domain.User) ([]*domain.ReservationApproval, error) {
+	policy := &domain.ApprovalPolicy{
+		Name:  "penalty",
+		Steps: []domain.ApprovalPolicyStep{{StepOrder: 1, Type: domain.ApprovalStepManager}},
+	}
+	steps, err := policy.BuildChain(reservation, organizer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrApprovalChainUnresolved, err)
+	}
+	if containsApprover(chain, steps[0]) {
+		return chain, nil
+	}
+
+	for _, step := range chain {
+		step.ActivatedAt = nil
+	}
+	chain = append(steps, chain...)
+	numberApprovalChain(chain)
+	return chain, nil
+}
+
+// numberApprovalChain はチェーン全体で StepOrder を採番し直し、最初のステップを承認待ちにします
+func numberApprovalChain(chain []*domain.ReservationApproval) {
+	now := time.Now()
+	for i, step := range chain {
+		step.StepOrder = i + 1
+		step.CreatedAt = now
+		step.UpdatedAt = now
+	}
+
+	// 最初のステップはチェーン作成時点から承認待ち（SLAの起点）
+	if len(chain) > 0 {
+		chain[0].ActivatedAt = &now
+	}
+}
+
+// RequestApproval は承認チェーンを記録し、最初のステップの承認者へ承認依頼を通知します
+func (s *ApprovalService) RequestApproval(ctx context.Context, reservation *domain.Reservation, chain []*domain.ReservationApproval, organizer *domain.User) error {
+	if len(chain) == 0 {
+		return nil
 	}
+
+	if err := s.approvalRepo.CreateSteps(ctx, chain); err != nil {
+		return fmt.Errorf("failed to create approval steps: %w", err)
+	}
+
+	// 通知失敗で承認依頼を失敗にしない
+	_ = s.notifyStepApprovers(ctx, reservation, chain[0], organizer)
+
+	return nil
 }
 
-// ApproveReservation は予約を承認します
+// ApproveReservation は承認チェーンの現在のステップを承認します
+// 全てのステップが承認された時点で予約は確定されます
 func (s *ApprovalService) ApproveReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID) error {
 	// 予約取得
 	reservation, err := s.reservationRepo.GetByID(ctx, reservationID, startAt)
@@ -53,47 +203,51 @@ func (s *ApprovalService) ApproveReservation(ctx context.Context, reservationID
 	if reservation.ApprovalStatus == domain.ApprovalStatusRejected {
 		return ErrAlreadyRejected
 	}
+	// 期限切れ・仮押さえ・繰り上げ確認待ちの予約は承認フローの対象外
+	if !reservation.IsPending() {
+		return ErrNotPendingApproval
+	}
 
-	// 承認者権限チェック
+	// 承認者取得
 	approver, err := s.userRepo.GetByID(ctx, approverID)
 	if err != nil {
 		return fmt.Errorf("failed to get approver: %w", err)
 	}
 
-	if !s.canApprove(approver, reservation) {
-		return ErrNotApprover
+	steps, err := s.approvalRepo.ListSteps(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to list approval steps: %w", err)
 	}
 
-	// 承認処理
-	reservation.ApprovalStatus = domain.ApprovalStatusConfirmed
-	reservation.UpdatedBy = &approverID
-	reservation.UpdatedAt = time.Now()
-
-	err = s.reservationRepo.Update(ctx, reservation)
+	// 承認者権限チェック
+	current := domain.CurrentApprovalStep(steps)
+	ok, err := s.canApprove(ctx, approver, reservation, current)
 	if err != nil {
-		return fmt.Errorf("failed to update reservation: %w", err)
+		return err
+	}
+	if !ok {
+		return ErrNotApprover
 	}
 
-	// 監査ログ記録
-	auditLog := &domain.AuditLog{
-		ID:         uuid.New(),
-		UserID:     approverID,
-		Action:     domain.AuditActionApprove,
-		TargetType: "reservation",
-		TargetID:   reservationID.String(),
-		Details: map[string]interface{}{
-			"title":           reservation.Title,
-			"organizer_id":    reservation.OrganizerID.String(),
-			"approval_status": string(domain.ApprovalStatusConfirmed),
-		},
-		CreatedAt: time.Now(),
+	now := time.Now()
+	if current != nil {
+		// ステップの承認
+		current.Status = domain.ApprovalStepStatusApproved
+		current.DecidedBy = &approverID
+		current.DecidedAt = &now
+		if err := s.approvalRepo.UpdateStep(ctx, current); err != nil {
+			return fmt.Errorf("failed to update approval step: %w", err)
+		}
 	}
-	_ = s.auditLogRepo.Create(ctx, auditLog)
 
-	return nil
+	// 監査ログ記録
+	s.recordDecision(ctx, reservation, approverID, domain.AuditActionApprove, current, "")
+
+	return s.advanceChain(ctx, reservation, steps, approverID, now)
 }
 
-// RejectReservation は予約を却下します
+// RejectReservation は承認チェーンの現在のステップで予約を却下します
+// 却下された時点でチェーンは終了し、残りのステップはスキップされます
 func (s *ApprovalService) RejectReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID, reason string) error {
 	// 予約取得
 	reservation, err := s.reservationRepo.GetByID(ctx, reservationID, startAt)
@@ -108,76 +262,525 @@ func (s *ApprovalService) RejectReservation(ctx context.Context, reservationID u
 	if reservation.ApprovalStatus == domain.ApprovalStatusRejected {
 		return ErrAlreadyRejected
 	}
+	// 期限切れ・仮押さえ・繰り上げ確認待ちの予約は承認フローの対象外
+	if !reservation.IsPending() {
+		return ErrNotPendingApproval
+	}
 
-	// 承認者権限チェック
+	// 承認者取得
 	approver, err := s.userRepo.GetByID(ctx, approverID)
 	if err != nil {
 		return fmt.Errorf("failed to get approver: %w", err)
 	}
 
-	if !s.canApprove(approver, reservation) {
+	steps, err := s.approvalRepo.ListSteps(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("failed to list approval steps: %w", err)
+	}
+
+	// 承認者権限チェック
+	current := domain.CurrentApprovalStep(steps)
+	ok, err := s.canApprove(ctx, approver, reservation, current)
+	if err != nil {
+		return err
+	}
+	if !ok {
 		return ErrNotApprover
 	}
 
 	// 却下処理
-	reservation.ApprovalStatus = domain.ApprovalStatusRejected
-	reservation.UpdatedBy = &approverID
-	reservation.UpdatedAt = time.Now()
+	err = s.closeChain(ctx, reservation, steps, current, domain.ApprovalStepStatusRejected, domain.ApprovalStatusRejected, approverID, reason)
+	if err != nil {
+		return err
+	}
 
-	err = s.reservationRepo.Update(ctx, reservation)
+	// 監査ログ記録
+	s.recordDecision(ctx, reservation, approverID, domain.AuditActionReject, current, reason)
+
+	s.notifyClosed(ctx, reservation, reason)
+
+	return nil
+}
+
+// ProcessSLA は承認待ちステップのSLAを確認し、リマインド・エスカレーション・期限切れ処理を実行します
+// ワーカーから定期的に呼び出されることを想定しています。処理したステップ数を返します
+func (s *ApprovalService) ProcessSLA(ctx context.Context, now time.Time) (int, error) {
+	steps, err := s.approvalRepo.ListActiveSteps(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active approval steps: %w", err)
+	}
+
+	policies := make(map[uuid.UUID]*domain.ApprovalPolicy)
+	processed := 0
+	var errs []error
+	for _, step := range steps {
+		if step.PolicyID == nil {
+			continue
+		}
+
+		policy, ok := policies[*step.PolicyID]
+		if !ok {
+			policy, err = s.approvalRepo.GetPolicyByID(ctx, *step.PolicyID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get approval policy: %w", err))
+				continue
+			}
+			policies[*step.PolicyID] = policy
+		}
+
+		action := policy.SLAActionFor(step, now)
+		if action == domain.ApprovalSLAActionNone {
+			continue
+		}
+
+		// 1件の失敗で他のステップの処理を止めない
+		if err := s.applySLAAction(ctx, policy, step, action, now); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply approval sla to step %s: %w", step.ID, err))
+			continue
+		}
+		processed++
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// applySLAAction は承認ステップに対してSLA処理を1件実行します
+func (s *ApprovalService) applySLAAction(ctx context.Context, policy *domain.ApprovalPolicy, step *domain.ReservationApproval, action domain.ApprovalSLAAction, now time.Time) error {
+	reservation, err := s.reservationRepo.GetByID(ctx, step.ReservationID, step.ReservationStartAt)
+	if err != nil {
+		return fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if !reservation.IsPending() {
+		return nil
+	}
+
+	switch action {
+	case domain.ApprovalSLAActionRemind:
+		return s.remindStep(ctx, reservation, step, now)
+	case domain.ApprovalSLAActionEscalate:
+		return s.escalateStep(ctx, policy, reservation, step, now)
+	case domain.ApprovalSLAActionTimeout:
+		return s.timeoutStep(ctx, policy, reservation, step, now)
+	}
+	return nil
+}
+
+// remindStep は承認者へ再度承認依頼を通知します
+func (s *ApprovalService) remindStep(ctx context.Context, reservation *domain.Reservation, step *domain.ReservationApproval, now time.Time) error {
+	step.RemindedAt = &now
+	if err := s.approvalRepo.UpdateStep(ctx, step); err != nil {
+		return fmt.Errorf("failed to update approval step: %w", err)
+	}
+
+	if organizer, err := s.userRepo.GetByID(ctx, reservation.OrganizerID); err == nil {
+		_ = s.notifyStepApprovers(ctx, reservation, step, organizer)
+	}
+
+	s.recordSLAEvent(ctx, reservation, domain.AuditActionApprovalRemind, step, nil)
+
+	return nil
+}
+
+// escalateStep は承認者の上長、いなければポリシーの代理承認者へ承認権限を広げます
+// エスカレーション先が見つからない場合も記録だけ行い、再エスカレーションはしません
+func (s *ApprovalService) escalateStep(ctx context.Context, policy *domain.ApprovalPolicy, reservation *domain.Reservation, step *domain.ReservationApproval, now time.Time) error {
+	target, err := s.escalationTarget(ctx, policy, reservation, step)
+	if err != nil {
+		return err
+	}
+
+	step.EscalatedAt = &now
+	step.EscalatedTo = target
+	if err := s.approvalRepo.UpdateStep(ctx, step); err != nil {
+		return fmt.Errorf("failed to update approval step: %w", err)
+	}
+
+	details := map[string]interface{}{}
+	if target != nil {
+		details["escalated_to"] = target.String()
+		if s.notificationService != nil {
+			organizer, orgErr := s.userRepo.GetByID(ctx, reservation.OrganizerID)
+			escalatee, escErr := s.userRepo.GetByID(ctx, *target)
+			if orgErr == nil && escErr == nil {
+				_ = s.notificationService.NotifyApprovalRequested(ctx, reservation, organizer, escalatee)
+			}
+		}
+	}
+
+	s.recordSLAEvent(ctx, reservation, domain.AuditActionApprovalEscalate, step, details)
+
+	return nil
+}
+
+// escalationTarget はエスカレーション先のユーザーIDを決定します
+func (s *ApprovalService) escalationTarget(ctx context.Context, policy *domain.ApprovalPolicy, reservation *domain.Reservation, step *domain.ReservationApproval) (*uuid.UUID, error) {
+	if step.ApproverID != nil {
+		approver, err := s.userRepo.GetByID(ctx, *step.ApproverID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get approver: %w", err)
+		}
+		// 予約者自身へのエスカレーションは行わない
+		if approver.ManagerID != nil && *approver.ManagerID != reservation.OrganizerID {
+			return approver.ManagerID, nil
+		}
+	}
+
+	if policy.DelegateID != nil && *policy.DelegateID != reservation.OrganizerID {
+		return policy.DelegateID, nil
+	}
+
+	return nil, nil
+}
+
+// timeoutStep は最終期限を過ぎたステップにポリシーの既定動作を適用します
+func (s *ApprovalService) timeoutStep(ctx context.Context, policy *domain.ApprovalPolicy, reservation *domain.Reservation, step *domain.ReservationApproval, now time.Time) error {
+	steps, err := s.approvalRepo.ListSteps(ctx, reservation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list approval steps: %w", err)
+	}
+
+	// 取得後に判断された場合は何もしない
+	current := domain.CurrentApprovalStep(steps)
+	if current == nil || current.ID != step.ID {
+		return nil
+	}
+
+	switch policy.TimeoutAction {
+	case domain.ApprovalTimeoutAutoApprove:
+		current.Status = domain.ApprovalStepStatusApproved
+		current.DecidedAt = &now
+		if err := s.approvalRepo.UpdateStep(ctx, current); err != nil {
+			return fmt.Errorf("failed to update approval step: %w", err)
+		}
+		s.recordSLAEvent(ctx, reservation, domain.AuditActionAutoApprove, current, nil)
+		return s.advanceChain(ctx, reservation, steps, domain.SystemUserID, now)
+
+	case domain.ApprovalTimeoutAutoReject:
+		reason := "承認期限を過ぎたため自動的に却下されました"
+		err := s.closeChain(ctx, reservation, steps, current, domain.ApprovalStepStatusRejected, domain.ApprovalStatusRejected, domain.SystemUserID, reason)
+		if err != nil {
+			return err
+		}
+		s.recordSLAEvent(ctx, reservation, domain.AuditActionAutoReject, current, nil)
+		s.notifyClosed(ctx, reservation, reason)
+
+	case domain.ApprovalTimeoutRelease:
+		reason := "承認期限を過ぎたため仮押さえを解放しました"
+		err := s.closeChain(ctx, reservation, steps, current, domain.ApprovalStepStatusExpired, domain.ApprovalStatusExpired, domain.SystemUserID, reason)
+		if err != nil {
+			return err
+		}
+		s.recordSLAEvent(ctx, reservation, domain.AuditActionApprovalExpire, current, nil)
+		s.notifyClosed(ctx, reservation, reason)
+	}
+
+	return nil
+}
+
+// advanceChain は後続ステップがあればその承認者へ依頼し、なければ予約を確定します
+// actorID が domain.SystemUserID の場合は自動承認として扱います
+func (s *ApprovalService) advanceChain(ctx context.Context, reservation *domain.Reservation, steps []*domain.ReservationApproval, actorID uuid.UUID, now time.Time) error {
+	// 後続ステップがあれば次の承認者へ依頼して終了
+	if next := domain.CurrentApprovalStep(steps); next != nil {
+		next.ActivatedAt = &now
+		if err := s.approvalRepo.UpdateStep(ctx, next); err != nil {
+			return fmt.Errorf("failed to activate next approval step: %w", err)
+		}
+		if organizer, err := s.userRepo.GetByID(ctx, reservation.OrganizerID); err == nil {
+			_ = s.notifyStepApprovers(ctx, reservation, next, organizer)
+		}
+		return nil
+	}
+
+	// 全ステップ承認済み: 予約を確定
+	reservation.ApprovalStatus = domain.ApprovalStatusConfirmed
+	reservation.UpdatedBy = actorRef(actorID)
+	reservation.UpdatedAt = now
+
+	err := s.reservationRepo.Update(ctx, reservation)
 	if err != nil {
 		return fmt.Errorf("failed to update reservation: %w", err)
 	}
 
-	// 監査ログ記録
+	// 仮押さえを確定
+	err = s.reservationRepo.UpdateInstancesStatus(ctx, reservation.ID, domain.ReservationStatusConfirmed)
+	if err != nil {
+		return fmt.Errorf("failed to confirm reservation instances: %w", err)
+	}
+
+	if s.notificationService != nil {
+		if organizer, err := s.userRepo.GetByID(ctx, reservation.OrganizerID); err == nil {
+			_ = s.notificationService.NotifyReservationApproved(ctx, reservation, organizer)
+		}
+	}
+
+	return nil
+}
+
+// closeChain は現在のステップを stepStatus で終了し、残りのステップをスキップして仮押さえを解放します
+func (s *ApprovalService) closeChain(
+	ctx context.Context,
+	reservation *domain.Reservation,
+	steps []*domain.ReservationApproval,
+	current *domain.ReservationApproval,
+	stepStatus domain.ApprovalStepStatus,
+	approvalStatus domain.ApprovalStatus,
+	actorID uuid.UUID,
+	reason string,
+) error {
+	now := time.Now()
+	for _, step := range steps {
+		if !step.IsPending() {
+			continue
+		}
+		if step == current {
+			step.Status = stepStatus
+			step.DecidedBy = actorRef(actorID)
+			step.DecidedAt = &now
+			step.Comment = reason
+		} else {
+			step.Status = domain.ApprovalStepStatusSkipped
+		}
+		if err := s.approvalRepo.UpdateStep(ctx, step); err != nil {
+			return fmt.Errorf("failed to update approval step: %w", err)
+		}
+	}
+
+	reservation.ApprovalStatus = approvalStatus
+	reservation.UpdatedBy = actorRef(actorID)
+	reservation.UpdatedAt = now
+
+	err := s.reservationRepo.Update(ctx, reservation)
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	// 解放する枠はキャンセル前のインスタンスから求める
+	var released []releasedSlot
+	if s.jobQueue != nil {
+		released, err = collectReleasedSlots(ctx, s.reservationRepo, reservation.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 仮押さえを解放
+	err = s.reservationRepo.UpdateInstancesStatus(ctx, reservation.ID, domain.ReservationStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to release reservation instances: %w", err)
+	}
+
+	// 解放された枠をウェイトリストの待機者へ繰り上げる
+	enqueueWaitlistPromotionJobs(ctx, s.jobQueue, released)
+
+	return nil
+}
+
+// notifyClosed は却下・期限切れを予約者へ通知します
+func (s *ApprovalService) notifyClosed(ctx context.Context, reservation *domain.Reservation, reason string) {
+	if s.notificationService == nil {
+		return
+	}
+	if organizer, err := s.userRepo.GetByID(ctx, reservation.OrganizerID); err == nil {
+		_ = s.notificationService.NotifyReservationRejected(ctx, reservation, organizer, reason)
+	}
+}
+
+// canApprove は指定されたユーザーが承認チェーンの現在のステップを判断できるかチェックします
+// 承認チェーンを持たない予約（チェーン導入前に作成されたもの）は管理者のみ判断できます
+// エスカレーション先のユーザーは元の承認者に加えて判断できます
+func (s *ApprovalService) canApprove(ctx context.Context, user *domain.User, reservation *domain.Reservation, step *domain.ReservationApproval) (bool, error) {
+	// 予約者自身は承認できない
+	if user.ID == reservation.OrganizerID {
+		return false, nil
+	}
+
+	if step == nil {
+		return user.IsAdmin(), nil
+	}
+
+	if step.IsAssignedTo(user.ID) {
+		return true, nil
+	}
+
+	if step.ApproverGroupID != nil {
+		isMember, err := s.approverGroupRepo.IsMember(ctx, *step.ApproverGroupID, user.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check approver group membership: %w", err)
+		}
+		return isMember, nil
+	}
+
+	return false, nil
+}
+
+// notifyStepApprovers は承認ステップの承認者へ承認依頼を通知します
+func (s *ApprovalService) notifyStepApprovers(ctx context.Context, reservation *domain.Reservation, step *domain.ReservationApproval, organizer *domain.User) error {
+	if s.notificationService == nil {
+		return nil
+	}
+
+	var approvers []*domain.User
+	switch {
+	case step.ApproverID != nil:
+		approver, err := s.userRepo.GetByID(ctx, *step.ApproverID)
+		if err != nil {
+			return fmt.Errorf("failed to get approver: %w", err)
+		}
+		approvers = append(approvers, approver)
+	case step.ApproverGroupID != nil:
+		members, err := s.approverGroupRepo.ListMembers(ctx, *step.ApproverGroupID)
+		if err != nil {
+			return fmt.Errorf("failed to list approvers: %w", err)
+		}
+		approvers = members
+	}
+
+	for _, approver := range approvers {
+		if approver.ID == reservation.OrganizerID {
+			continue
+		}
+		if err := s.notificationService.NotifyApprovalRequested(ctx, reservation, organizer, approver); err != nil {
+			return fmt.Errorf("failed to notify approver: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordDecision は承認・却下の監査ログを記録します
+func (s *ApprovalService) recordDecision(ctx context.Context, reservation *domain.Reservation, approverID uuid.UUID, action domain.AuditAction, step *domain.ReservationApproval, reason string) {
+	approvalStatus := domain.ApprovalStatusRejected
+	if action == domain.AuditActionApprove {
+		approvalStatus = domain.ApprovalStatusConfirmed
+	}
+
+	details := map[string]interface{}{
+		"title":           reservation.Title,
+		"organizer_id":    reservation.OrganizerID.String(),
+		"approval_status": string(approvalStatus),
+	}
+	if step != nil {
+		details["step_order"] = step.StepOrder
+		details["step_type"] = string(step.StepType)
+	}
+	if reason != "" {
+		details["reason"] = reason
+	}
+
 	auditLog := &domain.AuditLog{
 		ID:         uuid.New(),
 		UserID:     approverID,
-		Action:     domain.AuditActionReject,
+		Action:     action,
 		TargetType: "reservation",
-		TargetID:   reservationID.String(),
-		Details: map[string]interface{}{
-			"title":           reservation.Title,
-			"organizer_id":    reservation.OrganizerID.String(),
-			"approval_status": string(domain.ApprovalStatusRejected),
-			"reason":          reason,
-		},
-		CreatedAt: time.Now(),
+		TargetID:   reservation.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
 	}
 	_ = s.auditLogRepo.Create(ctx, auditLog)
-
-	return nil
 }
 
-// canApprove は指定されたユーザーが予約を承認できるかチェックします
-func (s *ApprovalService) canApprove(user *domain.User, reservation *domain.Reservation) bool {
-	// 管理者は全ての予約を承認可能
-	if user.Role == domain.RoleAdmin {
-		return true
+// recordSLAEvent はSLA処理によるシステム操作の監査ログを記録します
+func (s *ApprovalService) recordSLAEvent(ctx context.Context, reservation *domain.Reservation, action domain.AuditAction, step *domain.ReservationApproval, extra map[string]interface{}) {
+	details := map[string]interface{}{
+		"title":           reservation.Title,
+		"organizer_id":    reservation.OrganizerID.String(),
+		"approval_status": string(reservation.ApprovalStatus),
+		"step_order":      step.StepOrder,
+		"step_type":       string(step.StepType),
+	}
+	for k, v := range extra {
+		details[k] = v
 	}
 
-	// マネージャーは自分以外の予約を承認可能
-	if user.Role == domain.RoleManager && user.ID != reservation.OrganizerID {
-		return true
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     domain.SystemUserID,
+		Action:     action,
+		TargetType: "reservation",
+		TargetID:   reservation.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
 	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
+
+// actorRef は操作者IDを記録用のポインタに変換します（システム操作の場合は nil）
+func actorRef(actorID uuid.UUID) *uuid.UUID {
+	if actorID == domain.SystemUserID {
+		return nil
+	}
+	return &actorID
+}
 
+// containsApprover はチェーン内に同じ承認者のステップが既に存在するかを判定します
+func containsApprover(chain []*domain.ReservationApproval, step *domain.ReservationApproval) bool {
+	for _, existing := range chain {
+		if existing.StepType == step.StepType && existing.SameApprover(step) {
+			return true
+		}
+	}
 	return false
 }
 
-// GetPendingApprovals は承認待ちの予約一覧を取得します（簡易実装）
-func (s *ApprovalService) GetPendingApprovals(ctx context.Context, approverID uuid.UUID) ([]*domain.Reservation, error) {
-	// 承認者権限チェック
+// GetPendingApprovals は指定された承認者の判断を待っている予約一覧を取得します
+// 管理者には承認チェーンを持たない予約（チェーン導入前に作成されたもの）も含まれます
+// 2つ目の戻り値はページングを考慮しない総件数です
+func (s *ApprovalService) GetPendingApprovals(ctx context.Context, approverID uuid.UUID, query PendingApprovalsQuery) ([]*domain.Reservation, int, error) {
 	approver, err := s.userRepo.GetByID(ctx, approverID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get approver: %w", err)
+		return nil, 0, fmt.Errorf("failed to get approver: %w", err)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultPendingApprovalsLimit
+	}
+	if limit > MaxPendingApprovalsLimit {
+		limit = MaxPendingApprovalsLimit
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter := repository.PendingApprovalFilter{
+		ApproverID:        approver.ID,
+		IncludeUnassigned: approver.IsAdmin(),
+		ResourceID:        query.ResourceID,
+		From:              query.From,
+		To:                query.To,
+		Limit:             limit,
+		Offset:            offset,
+	}
+	reservations, total, err := s.reservationRepo.ListPendingApprovals(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pending approvals: %w", err)
+	}
+
+	return reservations, total, nil
+}
+
+// DecideBulk は複数の予約をまとめて承認・却下します
+// 各件は個別に処理され、1件の失敗は他の件に影響しません。結果は items と同じ順序で返します
+func (s *ApprovalService) DecideBulk(ctx context.Context, approverID uuid.UUID, items []BulkDecisionItem) ([]BulkDecisionResult, error) {
+	if len(items) > MaxBulkDecisionItems {
+		return nil, ErrTooManyBulkItems
 	}
 
-	// 管理者またはマネージャーのみ承認待ち一覧を取得可能
-	if approver.Role != domain.RoleAdmin && approver.Role != domain.RoleManager {
-		return nil, ErrUnauthorized
+	results := make([]BulkDecisionResult, 0, len(items))
+	for _, item := range items {
+		var err error
+		switch item.Action {
+		case BulkDecisionApprove:
+			err = s.ApproveReservation(ctx, item.ReservationID, item.StartAt, approverID)
+		case BulkDecisionReject:
+			err = s.RejectReservation(ctx, item.ReservationID, item.StartAt, approverID, item.Reason)
+		default:
+			err = ErrInvalidBulkAction
+		}
+		results = append(results, BulkDecisionResult{Item: item, Err: err})
 	}
 
-	// TODO: リポジトリに承認待ち一覧取得メソッドを追加する必要がある
-	// 現時点では空のリストを返す
-	return []*domain.Reservation{}, nil
+	return results, nil
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
 	"github.com/your-org/esms/internal/service"
 )
 
@@ -17,8 +19,9 @@ func TestApprovalService_ApproveReservation_Success(t *testing.T) {
 	mockReservationRepo := new(MockReservationRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
 
-	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
 
 	ctx := context.Background()
 	reservationID := uuid.New()
@@ -33,6 +36,7 @@ func TestApprovalService_ApproveReservation_Success(t *testing.T) {
 		ApprovalStatus: domain.ApprovalStatusPending,
 	}
 
+	// 承認チェーンを持たない予約は管理者が承認できる
 	approver := &domain.User{
 		ID:       approverID,
 		Role:     domain.RoleAdmin,
@@ -41,9 +45,11 @@ func TestApprovalService_ApproveReservation_Success(t *testing.T) {
 
 	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
 	mockUserRepo.On("GetByID", ctx, approverID).Return(approver, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return([]*domain.ReservationApproval{}, nil)
 	mockReservationRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
 		return r.ApprovalStatus == domain.ApprovalStatusConfirmed
 	})).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, reservationID, domain.ReservationStatusConfirmed).Return(nil)
 	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
 
 	err := svc.ApproveReservation(ctx, reservationID, startAt, approverID)
@@ -58,7 +64,7 @@ func TestApprovalService_ApproveReservation_AlreadyApproved(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
 
-	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil)
 
 	ctx := context.Background()
 	reservationID := uuid.New()
@@ -78,12 +84,55 @@ func TestApprovalService_ApproveReservation_AlreadyApproved(t *testing.T) {
 	assert.Equal(t, service.ErrAlreadyApproved, err)
 }
 
+func TestApprovalService_ApproveReservation_NotPending(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.ApprovalStatus
+	}{
+		{name: "Expired", status: domain.ApprovalStatusExpired},
+		{name: "Held", status: domain.ApprovalStatusHeld},
+		{name: "Offered", status: domain.ApprovalStatusOffered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockApprovalRepo := new(MockApprovalRepository)
+
+			svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+			ctx := context.Background()
+			reservationID := uuid.New()
+			startAt := time.Now()
+
+			reservation := &domain.Reservation{
+				ID:             reservationID,
+				OrganizerID:    uuid.New(),
+				ApprovalStatus: tt.status,
+			}
+
+			mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+
+			// 管理者であっても承認待ちでない予約は確定できない
+			err := svc.ApproveReservation(ctx, reservationID, startAt, uuid.New())
+
+			assert.ErrorIs(t, err, service.ErrNotPendingApproval)
+			assert.Equal(t, tt.status, reservation.ApprovalStatus)
+			mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			mockReservationRepo.AssertNotCalled(t, "UpdateInstancesStatus", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestApprovalService_ApproveReservation_NotApprover(t *testing.T) {
 	mockReservationRepo := new(MockReservationRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
 
-	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
 
 	ctx := context.Background()
 	reservationID := uuid.New()
@@ -104,6 +153,7 @@ func TestApprovalService_ApproveReservation_NotApprover(t *testing.T) {
 
 	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
 	mockUserRepo.On("GetByID", ctx, approverID).Return(approver, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return([]*domain.ReservationApproval{}, nil)
 
 	err := svc.ApproveReservation(ctx, reservationID, startAt, approverID)
 
@@ -111,16 +161,154 @@ func TestApprovalService_ApproveReservation_NotApprover(t *testing.T) {
 	assert.Equal(t, service.ErrNotApprover, err)
 }
 
+func TestApprovalService_ApproveReservation_ManagerNotInChain(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	reservationID := uuid.New()
+	directManagerID := uuid.New()
+	otherManagerID := uuid.New()
+	startAt := time.Now()
+
+	reservation := &domain.Reservation{
+		ID:             reservationID,
+		OrganizerID:    uuid.New(),
+		ApprovalStatus: domain.ApprovalStatusPending,
+	}
+
+	// 直属の上長ではないマネージャーは承認できない
+	otherManager := &domain.User{ID: otherManagerID, Role: domain.RoleManager, IsActive: true}
+	steps := []*domain.ReservationApproval{
+		{ID: uuid.New(), StepOrder: 1, StepType: domain.ApprovalStepManager, ApproverID: &directManagerID, Status: domain.ApprovalStepStatusPending},
+	}
+
+	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+	mockUserRepo.On("GetByID", ctx, otherManagerID).Return(otherManager, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return(steps, nil)
+
+	err := svc.ApproveReservation(ctx, reservationID, startAt, otherManagerID)
+
+	assert.Equal(t, service.ErrNotApprover, err)
+	mockApprovalRepo.AssertNotCalled(t, "UpdateStep", mock.Anything, mock.Anything)
+}
+
+func TestApprovalService_ApproveReservation_AdvancesChain(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	reservationID := uuid.New()
+	managerID := uuid.New()
+	ownerID := uuid.New()
+	startAt := time.Now()
+
+	reservation := &domain.Reservation{
+		ID:             reservationID,
+		OrganizerID:    uuid.New(),
+		ApprovalStatus: domain.ApprovalStatusPending,
+	}
+
+	manager := &domain.User{ID: managerID, Role: domain.RoleManager, IsActive: true}
+	steps := []*domain.ReservationApproval{
+		{ID: uuid.New(), StepOrder: 1, StepType: domain.ApprovalStepManager, ApproverID: &managerID, Status: domain.ApprovalStepStatusPending},
+		{ID: uuid.New(), StepOrder: 2, StepType: domain.ApprovalStepResourceOwner, ApproverID: &ownerID, Status: domain.ApprovalStepStatusPending},
+	}
+
+	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+	mockUserRepo.On("GetByID", ctx, managerID).Return(manager, nil)
+	mockUserRepo.On("GetByID", ctx, reservation.OrganizerID).Return(&domain.User{ID: reservation.OrganizerID}, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return(steps, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 1 && s.Status == domain.ApprovalStepStatusApproved && *s.DecidedBy == managerID
+	})).Return(nil)
+	// 次のステップが承認待ちになりSLAの起点が記録される
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 2 && s.IsPending() && s.ActivatedAt != nil
+	})).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	err := svc.ApproveReservation(ctx, reservationID, startAt, managerID)
+
+	// 後続ステップが残っているため予約は承認待ちのまま
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusPending, reservation.ApprovalStatus)
+	mockApprovalRepo.AssertExpectations(t)
+	mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockReservationRepo.AssertNotCalled(t, "UpdateInstancesStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApprovalService_ApproveReservation_FinalStepConfirms(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApproverGroupRepo := new(MockApproverGroupRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, mockApproverGroupRepo, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	reservationID := uuid.New()
+	managerID := uuid.New()
+	facilitiesID := uuid.New()
+	memberID := uuid.New()
+	startAt := time.Now()
+
+	reservation := &domain.Reservation{
+		ID:             reservationID,
+		OrganizerID:    uuid.New(),
+		ApprovalStatus: domain.ApprovalStatusPending,
+	}
+
+	member := &domain.User{ID: memberID, Role: domain.RoleGeneral, IsActive: true}
+	steps := []*domain.ReservationApproval{
+		{ID: uuid.New(), StepOrder: 1, StepType: domain.ApprovalStepManager, ApproverID: &managerID, Status: domain.ApprovalStepStatusApproved},
+		{ID: uuid.New(), StepOrder: 2, StepType: domain.ApprovalStepApproverGroup, ApproverGroupID: &facilitiesID, Status: domain.ApprovalStepStatusPending},
+	}
+
+	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+	mockUserRepo.On("GetByID", ctx, memberID).Return(member, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return(steps, nil)
+	mockApproverGroupRepo.On("IsMember", ctx, facilitiesID, memberID).Return(true, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 2 && s.Status == domain.ApprovalStepStatusApproved
+	})).Return(nil)
+	mockReservationRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.ApprovalStatus == domain.ApprovalStatusConfirmed
+	})).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, reservationID, domain.ReservationStatusConfirmed).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	err := svc.ApproveReservation(ctx, reservationID, startAt, memberID)
+
+	assert.NoError(t, err)
+	mockReservationRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockApproverGroupRepo.AssertExpectations(t)
+}
+
 func TestApprovalService_RejectReservation_Success(t *testing.T) {
 	mockReservationRepo := new(MockReservationRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+	mockJobQueue := new(MockJobQueue)
 
-	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, mockJobQueue)
 
 	ctx := context.Background()
 	reservationID := uuid.New()
 	approverID := uuid.New()
+	ownerID := uuid.New()
+	roomID := uuid.New()
 	startAt := time.Now()
 	reason := "リソースが不足しています"
 
@@ -138,11 +326,34 @@ func TestApprovalService_RejectReservation_Success(t *testing.T) {
 		IsActive: true,
 	}
 
+	steps := []*domain.ReservationApproval{
+		{ID: uuid.New(), StepOrder: 1, StepType: domain.ApprovalStepManager, ApproverID: &approverID, Status: domain.ApprovalStepStatusPending},
+		{ID: uuid.New(), StepOrder: 2, StepType: domain.ApprovalStepResourceOwner, ApproverID: &ownerID, Status: domain.ApprovalStepStatusPending},
+	}
+
 	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
 	mockUserRepo.On("GetByID", ctx, approverID).Return(approver, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return(steps, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 1 && s.Status == domain.ApprovalStepStatusRejected && s.Comment == reason
+	})).Return(nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 2 && s.Status == domain.ApprovalStepStatusSkipped
+	})).Return(nil)
 	mockReservationRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
 		return r.ApprovalStatus == domain.ApprovalStatusRejected
 	})).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, reservationID, domain.ReservationStatusCancelled).Return(nil)
+	// 解放した仮押さえの枠はウェイトリストへ繰り上げる
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, reservationID).Return([]*domain.ReservationInstance{
+		{ReservationID: reservationID, StartAt: startAt, EndAt: startAt.Add(time.Hour)},
+	}, nil)
+	mockReservationRepo.On("GetResourceIDs", ctx, reservationID).Return([]uuid.UUID{roomID}, nil)
+	mockJobQueue.On("Enqueue", ctx, "waitlist_promote", map[string]interface{}{
+		"resource_id": roomID.String(),
+		"start_at":    startAt.Format(time.RFC3339),
+		"end_at":      startAt.Add(time.Hour).Format(time.RFC3339),
+	}).Return("job-1", nil)
 	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
 
 	err := svc.RejectReservation(ctx, reservationID, startAt, approverID, reason)
@@ -150,14 +361,17 @@ func TestApprovalService_RejectReservation_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockReservationRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockJobQueue.AssertExpectations(t)
 }
 
 func TestApprovalService_RejectReservation_ManagerCannotRejectOwnReservation(t *testing.T) {
 	mockReservationRepo := new(MockReservationRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
 
-	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
 
 	ctx := context.Background()
 	managerID := uuid.New()
@@ -178,9 +392,444 @@ func TestApprovalService_RejectReservation_ManagerCannotRejectOwnReservation(t *
 
 	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
 	mockUserRepo.On("GetByID", ctx, managerID).Return(manager, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservationID).Return([]*domain.ReservationApproval{}, nil)
 
 	err := svc.RejectReservation(ctx, reservationID, startAt, managerID, "test")
 
 	assert.Error(t, err)
 	assert.Equal(t, service.ErrNotApprover, err)
 }
+
+func TestApprovalService_RejectReservation_NotPending(t *testing.T) {
+	tests := []struct {
+		name   string
+		status domain.ApprovalStatus
+	}{
+		{name: "Expired", status: domain.ApprovalStatusExpired},
+		{name: "Held", status: domain.ApprovalStatusHeld},
+		{name: "Offered", status: domain.ApprovalStatusOffered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockApprovalRepo := new(MockApprovalRepository)
+
+			svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+			ctx := context.Background()
+			reservationID := uuid.New()
+			startAt := time.Now()
+
+			reservation := &domain.Reservation{
+				ID:             reservationID,
+				OrganizerID:    uuid.New(),
+				ApprovalStatus: tt.status,
+			}
+
+			mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+
+			// 管理者であっても承認待ちでない予約は却下できない
+			err := svc.RejectReservation(ctx, reservationID, startAt, uuid.New(), "test")
+
+			assert.ErrorIs(t, err, service.ErrNotPendingApproval)
+			assert.Equal(t, tt.status, reservation.ApprovalStatus)
+			mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			mockReservationRepo.AssertNotCalled(t, "UpdateInstancesStatus", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApprovalService_BuildApprovalChain(t *testing.T) {
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(nil, nil, nil, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	managerID := uuid.New()
+	ownerID := uuid.New()
+	policyID := uuid.New()
+	facilitiesID := uuid.New()
+	startAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	organizer := &domain.User{ID: uuid.New(), ManagerID: &managerID}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: organizer.ID, StartAt: startAt, EndAt: startAt.Add(5 * time.Hour)}
+
+	policy := &domain.ApprovalPolicy{
+		ID:   policyID,
+		Name: "executive",
+		Steps: []domain.ApprovalPolicyStep{
+			{StepOrder: 1, Type: domain.ApprovalStepManager},
+			{StepOrder: 2, Type: domain.ApprovalStepResourceOwner},
+			{StepOrder: 3, Type: domain.ApprovalStepApproverGroup, ApproverGroupID: &facilitiesID, MinDuration: 4 * time.Hour},
+		},
+	}
+	resources := []*domain.Resource{
+		{ID: uuid.New(), RequiresApproval: true, ApprovalPolicyID: &policyID, OwnerID: &ownerID},
+		{ID: uuid.New(), RequiresApproval: true, ApprovalPolicyID: &policyID, OwnerID: &ownerID},
+		{ID: uuid.New()},
+	}
+
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+
+	chain, err := svc.BuildApprovalChain(ctx, reservation, resources, organizer)
+
+	// 同じ承認者のステップは1つにまとめられる
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+	for i, step := range chain {
+		assert.Equal(t, i+1, step.StepOrder)
+		assert.Equal(t, reservation.ID, step.ReservationID)
+	}
+	assert.Equal(t, managerID, *chain[0].ApproverID)
+	assert.Equal(t, ownerID, *chain[1].ApproverID)
+	assert.Equal(t, facilitiesID, *chain[2].ApproverGroupID)
+}
+
+func TestApprovalService_BuildApprovalChain_NoManager(t *testing.T) {
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(nil, nil, nil, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	policyID := uuid.New()
+	organizer := &domain.User{ID: uuid.New()}
+	reservation := &domain.Reservation{ID: uuid.New(), StartAt: time.Now(), EndAt: time.Now().Add(time.Hour)}
+
+	policy := &domain.ApprovalPolicy{
+		ID:    policyID,
+		Name:  "manager",
+		Steps: []domain.ApprovalPolicyStep{{StepOrder: 1, Type: domain.ApprovalStepManager}},
+	}
+	resources := []*domain.Resource{{ID: uuid.New(), RequiresApproval: true, ApprovalPolicyID: &policyID}}
+
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+
+	_, err := svc.BuildApprovalChain(ctx, reservation, resources, organizer)
+
+	assert.ErrorIs(t, err, service.ErrApprovalChainUnresolved)
+	assert.ErrorIs(t, err, domain.ErrManagerNotAssigned)
+}
+
+func TestApprovalService_RequestApproval_NotifiesApproverGroup(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApproverGroupRepo := new(MockApproverGroupRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+	mockJobQueue := new(MockJobQueue)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, mockApproverGroupRepo, mockApprovalRepo, notificationService, nil)
+
+	ctx := context.Background()
+	groupID := uuid.New()
+	organizer := &domain.User{ID: uuid.New(), Email: "organizer@example.com", Name: "Organizer"}
+	approver1 := &domain.User{ID: uuid.New(), Email: "approver1@example.com"}
+	approver2 := &domain.User{ID: uuid.New(), Email: "approver2@example.com"}
+
+	reservation := &domain.Reservation{
+		ID:             uuid.New(),
+		OrganizerID:    organizer.ID,
+		Title:          "Board Meeting",
+		StartAt:        time.Now().Add(24 * time.Hour),
+		EndAt:          time.Now().Add(25 * time.Hour),
+		ApprovalStatus: domain.ApprovalStatusPending,
+	}
+
+	chain := []*domain.ReservationApproval{
+		{ID: uuid.New(), ReservationID: reservation.ID, StepOrder: 1, StepType: domain.ApprovalStepApproverGroup, ApproverGroupID: &groupID, Status: domain.ApprovalStepStatusPending},
+	}
+
+	mockApprovalRepo.On("CreateSteps", ctx, chain).Return(nil)
+	mockApproverGroupRepo.On("ListMembers", ctx, groupID).Return([]*domain.User{approver1, approver2}, nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(payload map[string]interface{}) bool {
+		return payload["to"] == approver1.Email || payload["to"] == approver2.Email
+	})).Return("job-id", nil)
+
+	err := svc.RequestApproval(ctx, reservation, chain, organizer)
+
+	assert.NoError(t, err)
+	mockApprovalRepo.AssertExpectations(t)
+	mockApproverGroupRepo.AssertExpectations(t)
+	mockJobQueue.AssertNumberOfCalls(t, "Enqueue", 2)
+}
+
+func TestApprovalService_ProcessSLA_Remind(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	now := time.Now()
+	policyID := uuid.New()
+	managerID := uuid.New()
+	activatedAt := now.Add(-25 * time.Hour)
+
+	policy := &domain.ApprovalPolicy{ID: policyID, Name: "sla", ReminderAfter: 24 * time.Hour, EscalateAfter: 48 * time.Hour}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), StartAt: now.Add(72 * time.Hour), ApprovalStatus: domain.ApprovalStatusPending}
+	step := &domain.ReservationApproval{
+		ID: uuid.New(), ReservationID: reservation.ID, ReservationStartAt: reservation.StartAt, StepOrder: 1,
+		StepType: domain.ApprovalStepManager, PolicyID: &policyID, ApproverID: &managerID,
+		Status: domain.ApprovalStepStatusPending, ActivatedAt: &activatedAt,
+	}
+
+	mockApprovalRepo.On("ListActiveSteps", ctx).Return([]*domain.ReservationApproval{step}, nil)
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, reservation.StartAt).Return(reservation, nil)
+	mockUserRepo.On("GetByID", ctx, reservation.OrganizerID).Return(&domain.User{ID: reservation.OrganizerID}, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.RemindedAt != nil && s.EscalatedAt == nil
+	})).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionApprovalRemind && l.UserID == domain.SystemUserID
+	})).Return(nil)
+
+	processed, err := svc.ProcessSLA(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mockApprovalRepo.AssertExpectations(t)
+	mockAuditLogRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_ProcessSLA_EscalateToApproversManager(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	now := time.Now()
+	policyID := uuid.New()
+	managerID := uuid.New()
+	directorID := uuid.New()
+	delegateID := uuid.New()
+	activatedAt := now.Add(-49 * time.Hour)
+	remindedAt := now.Add(-25 * time.Hour)
+
+	policy := &domain.ApprovalPolicy{ID: policyID, Name: "sla", ReminderAfter: 24 * time.Hour, EscalateAfter: 48 * time.Hour, DelegateID: &delegateID}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), ApprovalStatus: domain.ApprovalStatusPending}
+	step := &domain.ReservationApproval{
+		ID: uuid.New(), ReservationID: reservation.ID, StepOrder: 1,
+		StepType: domain.ApprovalStepManager, PolicyID: &policyID, ApproverID: &managerID,
+		Status: domain.ApprovalStepStatusPending, ActivatedAt: &activatedAt, RemindedAt: &remindedAt,
+	}
+
+	mockApprovalRepo.On("ListActiveSteps", ctx).Return([]*domain.ReservationApproval{step}, nil)
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, mock.Anything).Return(reservation, nil)
+	mockUserRepo.On("GetByID", ctx, managerID).Return(&domain.User{ID: managerID, ManagerID: &directorID}, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.EscalatedAt != nil && s.EscalatedTo != nil && *s.EscalatedTo == directorID
+	})).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionApprovalEscalate && l.Details["escalated_to"] == directorID.String()
+	})).Return(nil)
+
+	processed, err := svc.ProcessSLA(ctx, now)
+
+	// 承認者に上長がいるため代理承認者ではなく上長へエスカレーションされる
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.True(t, step.IsAssignedTo(directorID))
+	mockApprovalRepo.AssertExpectations(t)
+	mockAuditLogRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_ProcessSLA_TimeoutAutoApprove(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	now := time.Now()
+	policyID := uuid.New()
+	managerID := uuid.New()
+	activatedAt := now.Add(-73 * time.Hour)
+
+	policy := &domain.ApprovalPolicy{ID: policyID, Name: "sla", DecisionDeadline: 72 * time.Hour, TimeoutAction: domain.ApprovalTimeoutAutoApprove}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), ApprovalStatus: domain.ApprovalStatusPending}
+	step := &domain.ReservationApproval{
+		ID: uuid.New(), ReservationID: reservation.ID, StepOrder: 1,
+		StepType: domain.ApprovalStepManager, PolicyID: &policyID, ApproverID: &managerID,
+		Status: domain.ApprovalStepStatusPending, ActivatedAt: &activatedAt,
+	}
+
+	mockApprovalRepo.On("ListActiveSteps", ctx).Return([]*domain.ReservationApproval{step}, nil)
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, mock.Anything).Return(reservation, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservation.ID).Return([]*domain.ReservationApproval{step}, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.Status == domain.ApprovalStepStatusApproved && s.DecidedBy == nil && s.DecidedAt != nil
+	})).Return(nil)
+	mockReservationRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.ApprovalStatus == domain.ApprovalStatusConfirmed && r.UpdatedBy == nil
+	})).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, reservation.ID, domain.ReservationStatusConfirmed).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionAutoApprove && l.UserID == domain.SystemUserID
+	})).Return(nil)
+
+	processed, err := svc.ProcessSLA(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mockReservationRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockAuditLogRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_ProcessSLA_TimeoutRelease(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	now := time.Now()
+	policyID := uuid.New()
+	managerID := uuid.New()
+	ownerID := uuid.New()
+	activatedAt := now.Add(-73 * time.Hour)
+
+	policy := &domain.ApprovalPolicy{ID: policyID, Name: "sla", DecisionDeadline: 72 * time.Hour, TimeoutAction: domain.ApprovalTimeoutRelease}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), ApprovalStatus: domain.ApprovalStatusPending}
+	steps := []*domain.ReservationApproval{
+		{ID: uuid.New(), ReservationID: reservation.ID, StepOrder: 1, StepType: domain.ApprovalStepManager, PolicyID: &policyID,
+			ApproverID: &managerID, Status: domain.ApprovalStepStatusPending, ActivatedAt: &activatedAt},
+		{ID: uuid.New(), ReservationID: reservation.ID, StepOrder: 2, StepType: domain.ApprovalStepResourceOwner, PolicyID: &policyID,
+			ApproverID: &ownerID, Status: domain.ApprovalStepStatusPending},
+	}
+
+	mockApprovalRepo.On("ListActiveSteps", ctx).Return(steps[:1], nil)
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, mock.Anything).Return(reservation, nil)
+	mockApprovalRepo.On("ListSteps", ctx, reservation.ID).Return(steps, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 1 && s.Status == domain.ApprovalStepStatusExpired
+	})).Return(nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.MatchedBy(func(s *domain.ReservationApproval) bool {
+		return s.StepOrder == 2 && s.Status == domain.ApprovalStepStatusSkipped
+	})).Return(nil)
+	mockReservationRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.ApprovalStatus == domain.ApprovalStatusExpired
+	})).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, reservation.ID, domain.ReservationStatusCancelled).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionApprovalExpire
+	})).Return(nil)
+
+	processed, err := svc.ProcessSLA(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mockReservationRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockAuditLogRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_GetPendingApprovals(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	managerID := uuid.New()
+	adminID := uuid.New()
+
+	manager := &domain.User{ID: managerID, Role: domain.RoleManager, IsActive: true}
+	admin := &domain.User{ID: adminID, Role: domain.RoleAdmin, IsActive: true}
+	pending := []*domain.Reservation{{ID: uuid.New(), ApprovalStatus: domain.ApprovalStatusPending}}
+
+	mockUserRepo.On("GetByID", ctx, managerID).Return(manager, nil)
+	mockUserRepo.On("GetByID", ctx, adminID).Return(admin, nil)
+	// 上限を超える件数は切り詰められ、チェーン外の予約は含まれない
+	mockReservationRepo.On("ListPendingApprovals", ctx, repository.PendingApprovalFilter{
+		ApproverID: managerID,
+		Limit:      service.MaxPendingApprovalsLimit,
+	}).Return(pending, 1, nil)
+	// 管理者には承認チェーンを持たない予約も含まれる
+	mockReservationRepo.On("ListPendingApprovals", ctx, repository.PendingApprovalFilter{
+		ApproverID:        adminID,
+		IncludeUnassigned: true,
+		Limit:             service.DefaultPendingApprovalsLimit,
+		Offset:            50,
+	}).Return([]*domain.Reservation{}, 1, nil)
+
+	reservations, total, err := svc.GetPendingApprovals(ctx, managerID, service.PendingApprovalsQuery{Limit: 1000})
+	require.NoError(t, err)
+	assert.Len(t, reservations, 1)
+	assert.Equal(t, 1, total)
+
+	reservations, total, err = svc.GetPendingApprovals(ctx, adminID, service.PendingApprovalsQuery{Offset: 50})
+	require.NoError(t, err)
+	assert.Empty(t, reservations)
+	assert.Equal(t, 1, total)
+
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestApprovalService_DecideBulk(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	svc := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+
+	ctx := context.Background()
+	managerID := uuid.New()
+	startAt := time.Now()
+	manager := &domain.User{ID: managerID, Role: domain.RoleManager, IsActive: true}
+
+	approvable := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), ApprovalStatus: domain.ApprovalStatusPending}
+	rejected := &domain.Reservation{ID: uuid.New(), OrganizerID: uuid.New(), ApprovalStatus: domain.ApprovalStatusRejected}
+	step := &domain.ReservationApproval{ID: uuid.New(), StepOrder: 1, StepType: domain.ApprovalStepManager, ApproverID: &managerID, Status: domain.ApprovalStepStatusPending}
+
+	mockUserRepo.On("GetByID", ctx, managerID).Return(manager, nil)
+	mockUserRepo.On("GetByID", ctx, approvable.OrganizerID).Return(&domain.User{ID: approvable.OrganizerID}, nil)
+	mockReservationRepo.On("GetByID", ctx, approvable.ID, startAt).Return(approvable, nil)
+	mockReservationRepo.On("GetByID", ctx, rejected.ID, startAt).Return(rejected, nil)
+	mockApprovalRepo.On("ListSteps", ctx, approvable.ID).Return([]*domain.ReservationApproval{step}, nil)
+	mockApprovalRepo.On("UpdateStep", ctx, mock.Anything).Return(nil)
+	mockReservationRepo.On("Update", ctx, mock.Anything).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, approvable.ID, domain.ReservationStatusConfirmed).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	results, err := svc.DecideBulk(ctx, managerID, []service.BulkDecisionItem{
+		{ReservationID: approvable.ID, StartAt: startAt, Action: service.BulkDecisionApprove},
+		{ReservationID: rejected.ID, StartAt: startAt, Action: service.BulkDecisionReject, Reason: "duplicate"},
+		{ReservationID: uuid.New(), StartAt: startAt, Action: "hold"},
+	})
+
+	// 1件の失敗は他の件に影響せず、結果は指示と同じ順序で返る
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, service.ErrAlreadyRejected)
+	assert.ErrorIs(t, results[2].Err, service.ErrInvalidBulkAction)
+	assert.Equal(t, domain.ApprovalStatusConfirmed, approvable.ApprovalStatus)
+}
+
+func TestApprovalService_DecideBulk_TooManyItems(t *testing.T) {
+	svc := service.NewApprovalService(nil, nil, nil, nil, nil, nil, nil)
+
+	items := make([]service.BulkDecisionItem, service.MaxBulkDecisionItems+1)
+	_, err := svc.DecideBulk(context.Background(), uuid.New(), items)
+
+	assert.ErrorIs(t, err, service.ErrTooManyBulkItems)
+}
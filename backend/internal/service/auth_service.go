@@ -3,10 +3,13 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -22,17 +25,66 @@ var (
 	ErrInsufficientPermission = errors.New("insufficient permission")
 	ErrSessionNotFound        = errors.New("session not found")
 	ErrInvalidState           = errors.New("invalid state")
+	ErrIdentityConflict       = errors.New("identity conflict")
+	ErrRefreshNotSupported    = errors.New("session cannot be refreshed")
+	ErrUserDeactivated        = errors.New("user is deactivated")
 )
 
-// Session はユーザーセッション情報
+// Session はユーザーセッション情報（SessionStore にJSONとして保存します）
 type Session struct {
-	UserID       uuid.UUID
-	Email        string
-	Name         string
-	Role         domain.Role
-	AccessToken  string
-	RefreshToken string
-	ExpiresAt    time.Time
+	ID           string      `json:"id"` // 不透明なセッションID（Cookie または Bearer トークンとして使用）
+	UserID       uuid.UUID   `json:"user_id"`
+	Email        string      `json:"email"`
+	Name         string      `json:"name"`
+	Role         domain.Role `json:"role"`
+	AccessToken  string      `json:"access_token"`
+	RefreshToken string      `json:"refresh_token"`
+	ExpiresAt    time.Time   `json:"expires_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+	CSRFToken    string      `json:"csrf_token"`         // セッションに紐づくCSRFトークン（Cookie認証の更新系リクエストで必須）
+	Provider     string      `json:"provider,omitempty"` // セッションを発行したログインプロバイダー（空の場合は ProviderOIDC）
+	IDToken      string      `json:"id_token,omitempty"` // OIDC のIDトークン（IdPでのログアウトに使用）
+
+	// 端末の情報（セッションの一覧に表示）
+	IPAddress  string    `json:"ip_address,omitempty"` // 最後にアクセスしたIPアドレス
+	UserAgent  string    `json:"user_agent,omitempty"`
+	LastSeenAt time.Time `json:"last_seen_at"` // 最終アクセス日時（sessionActivityInterval ごとに更新）
+
+	// APIトークンで認証した場合のみ設定（SessionStore には保存しません）
+	APITokenID       *uuid.UUID             `json:"api_token_id,omitempty"`
+	ServiceAccountID *uuid.UUID             `json:"service_account_id,omitempty"` // サービスアカウントのAPIキーの場合
+	Scopes           []domain.APITokenScope `json:"scopes,omitempty"`
+}
+
+// IsAPIToken はAPIトークンで認証したセッションかどうかを判定します
+func (s *Session) IsAPIToken() bool {
+	return s.APITokenID != nil
+}
+
+// HasScope はスコープが許可されているかを判定します
+// 対話的なログインのセッションは全ての操作を許可します（write は read を含みます）
+func (s *Session) HasScope(scope domain.APITokenScope) bool {
+	if !s.IsAPIToken() {
+		return true
+	}
+	for _, granted := range s.Scopes {
+		if granted == scope || granted == domain.APITokenScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// randomTokenBytes はセッションID・CSRFトークンの乱数のバイト数（32byte以上）
+const randomTokenBytes = 32
+
+// newRandomToken は推測不能な不透明なトークンを生成します
+func newRandomToken() (string, error) {
+	b := make([]byte, randomTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 // OIDCClient はOIDCクライアントのインターフェース
@@ -44,6 +96,7 @@ type OIDCClient interface {
 	ValidateToken(ctx context.Context, accessToken string) error
 	RefreshToken(ctx context.Context, refreshToken string) (*oauth2.Token, error)
 	ParseIDTokenClaimsWithValidation(ctx context.Context, rawIDToken, expectedNonce, accessToken string) (*pkgoidc.TokenClaims, error)
+	EndSessionURL(idTokenHint string) string
 }
 
 // AuthService は認証に関するビジネスロジックを提供します
@@ -52,274 +105,614 @@ type AuthService struct {
 	userRepo     repository.UserRepository
 	auditLogRepo repository.AuditLogRepository
 
-	// セッションストア（簡易実装: メモリ内）
-	// 本番環境ではRedisなどの永続化ストアを使用
-	sessions map[string]*Session
-	// state検証用（簡易実装）
-	states map[string]time.Time
-	// PKCE code_verifier保存用（stateをキーとする）
-	codeVerifiers map[string]string
-	// nonce保存用（stateをキーとする）
-	nonces map[string]string
+	// IdPのユーザーとの突合で自動判定できなかった事象の記録先
+	identityConflictRepo repository.IdentityConflictRepository
+
+	// セッションと state・code_verifier・nonce の保存先（APIインスタンス間で共有）
+	sessionStore SessionStore
+
+	// IdPのクレームからロール・上長への変換設定
+	roleMapping RoleMapping
 
-	// マップへのアクセスを保護するためのMutex
-	mu sync.RWMutex
+	// ユーザーごとの同時セッション数の上限（0以下の場合は無制限）
+	maxSessions int
+
+	// 名前ごとのログインプロバイダー（OIDC・SAML）
+	providers map[string]LoginProvider
+	// ログインプロバイダーの指定もメールアドレスのドメインの一致もない場合に使用するプロバイダー（最初に登録したもの）
+	defaultProvider string
+	// メールアドレスのドメイン（小文字）からログインプロバイダーの名前への対応（ホームレルムディスカバリー）
+	domainProviders map[string]string
 }
 
 // NewAuthService は新しいAuthServiceを作成します
+// OIDCのプロバイダーは oidcClient から作成し（名前は ProviderOIDC）、SAMLや名前付きのIdPなどのプロバイダーは providers で追加します
+// 最初に登録したプロバイダーを既定のプロバイダーとします
+// maxSessions はユーザーごとの同時セッション数の上限で、超えた場合は最終アクセスの古いセッションから無効化します（0以下の場合は無制限）
 func NewAuthService(
 	oidcClient OIDCClient,
 	userRepo repository.UserRepository,
 	auditLogRepo repository.AuditLogRepository,
+	identityConflictRepo repository.IdentityConflictRepository,
+	sessionStore SessionStore,
+	roleMapping RoleMapping,
+	maxSessions int,
+	providers ...LoginProvider,
 ) *AuthService {
-	return &AuthService{
-		oidcClient:    oidcClient,
-		userRepo:      userRepo,
-		auditLogRepo:  auditLogRepo,
-		sessions:      make(map[string]*Session),
-		states:        make(map[string]time.Time),
-		codeVerifiers: make(map[string]string),
-		nonces:        make(map[string]string),
+	s := &AuthService{
+		oidcClient:           oidcClient,
+		userRepo:             userRepo,
+		auditLogRepo:         auditLogRepo,
+		identityConflictRepo: identityConflictRepo,
+		sessionStore:         sessionStore,
+		roleMapping:          roleMapping,
+		maxSessions:          maxSessions,
+		providers:            make(map[string]LoginProvider),
+		domainProviders:      make(map[string]string),
+	}
+	if oidcClient != nil {
+		s.registerProvider(NewOIDCLoginProvider(oidcClient, ProviderOptions{}))
 	}
+	for _, provider := range providers {
+		s.registerProvider(provider)
+	}
+	return s
 }
 
-// GetAuthURL は認証URLを生成します
-func (s *AuthService) GetAuthURL(state string) string {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// registerProvider はログインプロバイダーとそのメールアドレスのドメインを登録します
+func (s *AuthService) registerProvider(provider LoginProvider) {
+	name := provider.Name()
+	if _, exists := s.providers[name]; exists {
+		log.Printf("Warning: login provider %q is registered more than once; the last one is used", name)
+	}
+	s.providers[name] = provider
+	if s.defaultProvider == "" {
+		s.defaultProvider = name
+	}
+	for _, emailDomain := range provider.Domains() {
+		emailDomain = strings.ToLower(strings.TrimSpace(emailDomain))
+		if emailDomain == "" {
+			continue
+		}
+		if other, exists := s.domainProviders[emailDomain]; exists && other != name {
+			log.Printf("Warning: email domain %q is assigned to both %q and %q; %q is used", emailDomain, other, name, name)
+		}
+		s.domainProviders[emailDomain] = name
+	}
+}
+
+// GetAuthURL は既定のログインプロバイダーの認証URLと state を生成します
+func (s *AuthService) GetAuthURL(ctx context.Context) (string, string, error) {
+	return s.BeginLogin(ctx, s.defaultProvider)
+}
 
-	// state を保存（CSRF対策）
-	s.states[state] = time.Now().Add(10 * time.Minute)
+// HandleCallback はOIDCの認証コールバックを処理し、新しいセッションを作成します
+func (s *AuthService) HandleCallback(ctx context.Context, code, state, previousSessionID string) (*Session, error) {
+	return s.CompleteLogin(ctx, ProviderOIDC, state, CallbackParams{Code: code}, previousSessionID)
+}
 
-	// PKCE challenge生成
-	pkceParams, err := pkgoidc.GeneratePKCEChallenge()
-	if err != nil {
-		// エラー時は警告ログを出力してPKCEなしで続行
-		// 本番環境ではエラーを返すべき
-		log.Printf("Warning: Failed to generate PKCE challenge: %v", err)
-		pkceParams = &pkgoidc.PKCEParams{}
-	} else {
-		// code_verifierを保存
-		s.codeVerifiers[state] = pkceParams.CodeVerifier
+// provider は名前からログインプロバイダーを取得します
+func (s *AuthService) provider(name string) (LoginProvider, error) {
+	provider, ok := s.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotFound, name)
 	}
+	return provider, nil
+}
 
-	// nonce生成
-	nonce, err := pkgoidc.GenerateNonce()
-	if err != nil {
-		// エラー時は警告ログを出力してnonceなしで続行
-		log.Printf("Warning: Failed to generate nonce: %v", err)
-		nonce = ""
-	} else {
-		// nonceを保存
-		s.nonces[state] = nonce
+// ResolveProvider はログインに使用するプロバイダーの名前を決定します（ホームレルムディスカバリー）
+// providerName が指定された場合はそのプロバイダー、指定がない場合はメールアドレスのドメイン（サブドメインを含む）に
+// 対応するプロバイダー、いずれもない場合は既定のプロバイダーを返します
+func (s *AuthService) ResolveProvider(providerName, email string) (string, error) {
+	if providerName != "" {
+		if _, err := s.provider(providerName); err != nil {
+			return "", err
+		}
+		return providerName, nil
 	}
 
-	// AuthURLParamsを作成
-	params := pkgoidc.AuthURLParams{
-		State:         state,
-		Nonce:         nonce,
-		CodeChallenge: pkceParams.CodeChallenge,
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		emailDomain := strings.ToLower(strings.TrimSpace(email[at+1:]))
+		for emailDomain != "" {
+			if name, ok := s.domainProviders[emailDomain]; ok {
+				return name, nil
+			}
+			// 親ドメインで再検索（例: mail.example.co.jp → example.co.jp）
+			dot := strings.Index(emailDomain, ".")
+			if dot < 0 {
+				break
+			}
+			emailDomain = emailDomain[dot+1:]
+		}
 	}
 
-	return s.oidcClient.GetAuthURL(params)
+	if s.defaultProvider == "" {
+		return "", fmt.Errorf("%w: no login provider configured", ErrProviderNotFound)
+	}
+	return s.defaultProvider, nil
 }
 
-// HandleCallback は認証コールバックを処理します
-func (s *AuthService) HandleCallback(ctx context.Context, code, state string) (*Session, error) {
-	// state検証（CSRF対策）
-	if !s.validateState(state) {
-		return nil, ErrInvalidState
+// ProviderInfo はログイン画面に表示するログインプロバイダーの情報
+type ProviderInfo struct {
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+	Default  bool   `json:"default"`
+}
+
+// ListProviders は登録されているログインプロバイダーを名前順に返します
+func (s *AuthService) ListProviders() []ProviderInfo {
+	infos := make([]ProviderInfo, 0, len(s.providers))
+	for name, provider := range s.providers {
+		infos = append(infos, ProviderInfo{
+			Name:     name,
+			Protocol: provider.Protocol(),
+			Default:  name == s.defaultProvider,
+		})
 	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
 
-	// code_verifierとnonceを取得
-	s.mu.Lock()
-	codeVerifier := s.codeVerifiers[state]
-	nonce := s.nonces[state]
-	// 使用済みのため削除
-	delete(s.codeVerifiers, state)
-	delete(s.nonces, state)
-	s.mu.Unlock()
+// sessionProvider はセッションを発行したログインプロバイダーを取得します
+func (s *AuthService) sessionProvider(session *Session) (LoginProvider, error) {
+	name := session.Provider
+	if name == "" {
+		// プロバイダーを記録する前に発行されたセッションはOIDCで発行されたもの
+		name = ProviderOIDC
+	}
+	return s.provider(name)
+}
 
-	// 認証コードをトークンに交換（PKCEのcode_verifierを渡す）
-	token, err := s.oidcClient.ExchangeCode(ctx, code, codeVerifier)
+// BeginLogin はログインプロバイダーの認証画面へのリダイレクト先URLと state を生成します
+// state は推測できないようサーバーで生成し、コールバックの検証値とともに保存して（CSRF対策）コールバックで一度だけ取り出します
+// 呼び出し側は state をログインを開始したブラウザに紐付け、コールバックで一致を確認します
+func (s *AuthService) BeginLogin(ctx context.Context, providerName string) (string, string, error) {
+	provider, err := s.provider(providerName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
+		return "", "", err
 	}
 
-	// IDトークンを検証
-	rawIDToken, ok := token.Extra("id_token").(string)
-	if !ok {
-		return nil, errors.New("no id_token in token response")
+	state, err := newRandomToken()
+	if err != nil {
+		return "", "", err
 	}
 
-	// nonce検証を含むIDトークンのクレーム検証
-	claims, err := s.oidcClient.ParseIDTokenClaimsWithValidation(ctx, rawIDToken, nonce, token.AccessToken)
+	authReq := &AuthRequest{Provider: provider.Name()}
+	authURL, err := provider.AuthURL(ctx, state, authReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify ID token with nonce: %w", err)
+		return "", "", err
+	}
+
+	if err := s.sessionStore.SaveAuthRequest(ctx, state, authReq, AuthRequestTTL); err != nil {
+		return "", "", fmt.Errorf("failed to save auth request: %w", err)
 	}
+	return authURL, state, nil
+}
 
-	// ユーザー情報を取得（claimsから直接取得も可能だが、既存のフローを維持）
-	idToken, err := s.oidcClient.VerifyIDToken(ctx, rawIDToken)
+// CompleteLogin はログインプロバイダーからのコールバックを検証し、ユーザーを同期して新しいセッションを作成します
+// コールバックのURLはプロトコルごとに共通のため、プロバイダーは state に紐付けて保存した名前から決定し、
+// protocol（コールバックを受け取ったプロトコル）と一致しない場合は拒否します
+// セッション固定攻撃を防ぐため、ログイン前のセッションID（previousSessionID）は再利用せず破棄します
+func (s *AuthService) CompleteLogin(ctx context.Context, protocol, state string, params CallbackParams, previousSessionID string) (*Session, error) {
+	// state検証（CSRF対策）と検証値の取得（使用済みとして同時に削除）
+	authReq, err := s.sessionStore.ConsumeAuthRequest(ctx, state)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify ID token: %w", err)
+		return nil, err
 	}
 
-	userInfo, err := s.oidcClient.GetUserInfo(ctx, idToken)
+	providerName := authReq.Provider
+	if providerName == "" {
+		providerName = protocol
+	}
+	provider, err := s.provider(providerName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
+		return nil, err
+	}
+	// 別のプロトコルで開始した state は受け付けない
+	if provider.Protocol() != protocol {
+		return nil, ErrInvalidState
+	}
+
+	identity, err := provider.Authenticate(ctx, params, authReq)
+	if err != nil {
+		return nil, err
 	}
 
 	// ユーザーをDBに同期
-	user, err := s.syncUser(ctx, userInfo)
+	user, err := s.syncUser(ctx, identity)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sync user: %w", err)
 	}
 
 	// セッションを作成
-	sessionID := uuid.New().String()
+	sessionID, err := newRandomToken()
+	if err != nil {
+		return nil, err
+	}
+	// CSRFトークンもログインごとに発行し直す
+	csrfToken, err := newRandomToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	client, _ := clientInfoFromContext(ctx)
 	session := &Session{
+		ID:           sessionID,
 		UserID:       user.ID,
 		Email:        user.Email,
 		Name:         user.Name,
 		Role:         user.Role,
-		AccessToken:  token.AccessToken,
-		RefreshToken: token.RefreshToken,
-		ExpiresAt:    token.Expiry,
+		AccessToken:  identity.AccessToken,
+		RefreshToken: identity.RefreshToken,
+		ExpiresAt:    identity.ExpiresAt,
+		CreatedAt:    now,
+		CSRFToken:    csrfToken,
+		Provider:     provider.Name(),
+		IDToken:      identity.IDToken,
+		IPAddress:    client.IPAddress,
+		UserAgent:    client.UserAgent,
+		LastSeenAt:   now,
 	}
 
-	s.mu.Lock()
-	s.sessions[sessionID] = session
-	s.mu.Unlock()
+	if err := s.sessionStore.SaveSession(ctx, sessionID, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if previousSessionID != "" {
+		_ = s.sessionStore.DeleteSession(ctx, previousSessionID)
+	}
+	if err := s.enforceSessionLimit(ctx, user.ID, sessionID); err != nil {
+		return nil, err
+	}
 
 	// 監査ログ記録
+	details := map[string]interface{}{
+		"email":    user.Email,
+		"provider": provider.Name(),
+		"subject":  identity.Subject,
+	}
+	for k, v := range identity.AuditDetails {
+		details[k] = v
+	}
 	auditLog := &domain.AuditLog{
 		ID:         uuid.New(),
 		UserID:     user.ID,
 		Action:     domain.AuditActionLogin,
 		TargetType: "user",
 		TargetID:   user.ID.String(),
-		Details: map[string]interface{}{
-			"email":           user.Email,
-			"nonce_validated": nonce != "",
-			"pkce_used":       codeVerifier != "",
-			"subject":         claims.Subject,
-		},
-		CreatedAt: time.Now(),
+		Details:    details,
+		CreatedAt:  time.Now(),
 	}
 	_ = s.auditLogRepo.Create(ctx, auditLog)
 
 	return session, nil
 }
 
-// syncUser はOIDCユーザー情報をDBに同期します
-func (s *AuthService) syncUser(ctx context.Context, userInfo *pkgoidc.UserInfo) (*domain.User, error) {
-	// メールアドレスで既存ユーザーを検索
-	user, err := s.userRepo.GetByEmail(ctx, userInfo.Email)
+// ProviderMetadata はログインプロバイダーが公開するSPのメタデータを返します（SAML）
+func (s *AuthService) ProviderMetadata(providerName string) ([]byte, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	metadataProvider, ok := provider.(MetadataProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMetadataNotSupported, providerName)
+	}
+	return metadataProvider.Metadata()
+}
+
+// syncUser はIdPのユーザーの (issuer, sub) をキーにユーザーをDBに同期します
+// 連携済みのユーザーが見つからない場合は、未連携のユーザーをメールアドレスで探して初回ログイン時に紐付けます
+// メールアドレスでの紐付けが曖昧な場合は突合レポートに記録し、ErrIdentityConflict を返します
+// 無効化・削除されたユーザー（SCIMによるプロビジョニング解除など）は ErrUserDeactivated を返します
+// ロール・上長は RoleMapping の設定に従い、新規ユーザーは常に、既存ユーザーは管理元がIdPの場合にクレームから同期します
+func (s *AuthService) syncUser(ctx context.Context, identity *ExternalIdentity) (*domain.User, error) {
+	issuer, subject := identity.Issuer, identity.Subject
+	if subject == "" {
+		return nil, errors.New("no subject in identity")
+	}
+
+	user, err := s.userRepo.GetBySubject(ctx, issuer, subject)
 	if err == nil {
-		// 既存ユーザーの情報を更新
-		user.Name = userInfo.Name
-		user.UpdatedAt = time.Now()
-		if err := s.userRepo.Update(ctx, user); err != nil {
-			return nil, err
+		if !user.IsValid() {
+			return nil, ErrUserDeactivated
+		}
+		return s.updateSyncedUser(ctx, user, identity)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get user by subject: %w", err)
+	}
+
+	// 未連携のユーザー（メールアドレスのみで登録されたユーザー）を探す
+	candidates, err := s.userRepo.ListByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users by email: %w", err)
+	}
+	switch {
+	case len(candidates) == 0:
+		return s.createSyncedUser(ctx, identity)
+	case len(candidates) == 1 && !candidates[0].IsLinked():
+		if !candidates[0].IsValid() {
+			return nil, ErrUserDeactivated
 		}
-		return user, nil
+		return s.linkSyncedUser(ctx, candidates[0], identity)
 	}
 
-	// 新規ユーザーを作成
-	user = &domain.User{
+	// 別の (issuer, sub) に紐付いたユーザーや複数のユーザーと一致した場合は自動では判断しない
+	kind := domain.IdentityConflictMultipleEmail
+	if len(candidates) == 1 {
+		kind = domain.IdentityConflictEmailLinked
+	}
+	s.recordIdentityConflict(ctx, kind, identity, nil, candidates)
+	return nil, ErrIdentityConflict
+}
+
+// createSyncedUser はIdPのユーザーと紐付いた新規ユーザーを作成します
+func (s *AuthService) createSyncedUser(ctx context.Context, identity *ExternalIdentity) (*domain.User, error) {
+	user := &domain.User{
 		ID:        uuid.New(),
-		Email:     userInfo.Email,
-		Name:      userInfo.Name,
+		Issuer:    identity.Issuer,
+		Sub:       identity.Subject,
+		Email:     identity.Email,
+		Name:      identity.Name,
 		Role:      domain.RoleGeneral, // デフォルトロール
 		IsActive:  true,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	s.applyRoleMapping(ctx, user, identity.Claims)
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	s.auditRoleSync(ctx, user, domain.RoleGeneral, nil)
 
 	return user, nil
 }
 
-// validateState はstateを検証します
-func (s *AuthService) validateState(state string) bool {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// linkSyncedUser は未連携のユーザーにIdPの (issuer, sub) を紐付けます（初回ログイン時）
+func (s *AuthService) linkSyncedUser(ctx context.Context, user *domain.User, identity *ExternalIdentity) (*domain.User, error) {
+	previousSub := user.Sub
+	user.Issuer = identity.Issuer
+	user.Sub = identity.Subject
 
-	expiresAt, ok := s.states[state]
-	if !ok {
-		return false
+	if _, err := s.updateSyncedUser(ctx, user, identity); err != nil {
+		return nil, err
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     domain.SystemUserID,
+		Action:     domain.AuditActionIdentityLink,
+		TargetType: "user",
+		TargetID:   user.ID.String(),
+		Details: map[string]interface{}{
+			"email":        user.Email,
+			"issuer":       identity.Issuer,
+			"subject":      identity.Subject,
+			"previous_sub": previousSub,
+		},
+		CreatedAt: time.Now(),
+	})
+	return user, nil
+}
+
+// updateSyncedUser は連携済みユーザーの属性（表示名・メールアドレス・ロール・上長）をIdPの値で更新します
+// 変更後のメールアドレスが他のユーザーで使用されている場合は突合レポートに記録し、メールアドレスは変更しません
+func (s *AuthService) updateSyncedUser(ctx context.Context, user *domain.User, identity *ExternalIdentity) (*domain.User, error) {
+	previousRole, previousManagerID := user.Role, user.ManagerID
+	previousEmail := user.Email
+
+	user.Name = identity.Name
+	if identity.Email != "" && identity.Email != user.Email {
+		others, err := s.userRepo.ListByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users by email: %w", err)
+		}
+		others = excludeUser(others, user.ID)
+		if len(others) > 0 {
+			s.recordIdentityConflict(ctx, domain.IdentityConflictEmailTaken, identity, &user.ID, others)
+		} else {
+			user.Email = identity.Email
+		}
+	}
+	if s.roleMapping.Source == RoleSourceIDP {
+		s.applyRoleMapping(ctx, user, identity.Claims)
 	}
 
-	// 有効期限チェック
-	if time.Now().After(expiresAt) {
-		delete(s.states, state)
-		return false
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
 	}
 
-	// 使用済みstateを削除
-	delete(s.states, state)
-	return true
+	if user.Email != previousEmail {
+		_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+			ID:         uuid.New(),
+			UserID:     domain.SystemUserID,
+			Action:     domain.AuditActionEmailChange,
+			TargetType: "user",
+			TargetID:   user.ID.String(),
+			Details: map[string]interface{}{
+				"previous_email": previousEmail,
+				"email":          user.Email,
+				"subject":        identity.Subject,
+			},
+			CreatedAt: time.Now(),
+		})
+	}
+	s.auditRoleSync(ctx, user, previousRole, previousManagerID)
+	return user, nil
 }
 
-// GetSession はセッションIDからセッション情報を取得します
-func (s *AuthService) GetSession(sessionID string) (*Session, error) {
-	s.mu.RLock()
-	session, ok := s.sessions[sessionID]
-	s.mu.RUnlock()
+// excludeUser はユーザーの一覧から指定したユーザーを除きます
+func excludeUser(users []*domain.User, userID uuid.UUID) []*domain.User {
+	var others []*domain.User
+	for _, u := range users {
+		if u.ID != userID {
+			others = append(others, u)
+		}
+	}
+	return others
+}
+
+// applyRoleMapping はクレームからロール・上長を決定してユーザーに反映します
+// クレームが含まれない場合や上長がユーザーとして登録されていない場合は現在の値を維持します
+func (s *AuthService) applyRoleMapping(ctx context.Context, user *domain.User, claims map[string]interface{}) {
+	if role, ok := s.roleMapping.ResolveRole(claims); ok {
+		user.Role = role
+	}
 
+	managerEmail, ok := s.roleMapping.ResolveManagerEmail(claims)
 	if !ok {
-		return nil, ErrSessionNotFound
+		return
+	}
+	manager, err := s.userRepo.GetByEmail(ctx, managerEmail)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Printf("Warning: failed to resolve manager %s for user %s: %v", managerEmail, user.Email, err)
+		}
+		return
 	}
+	if manager.ID != user.ID {
+		user.ManagerID = &manager.ID
+	}
+}
 
-	// セッション有効期限チェック
-	if time.Now().After(session.ExpiresAt) {
-		s.mu.Lock()
-		delete(s.sessions, sessionID)
-		s.mu.Unlock()
-		return nil, ErrSessionNotFound
+// auditRoleSync はIdPとの同期でロール・上長が変わった場合に監査ログを記録します
+func (s *AuthService) auditRoleSync(ctx context.Context, user *domain.User, previousRole domain.Role, previousManagerID *uuid.UUID) {
+	managerChanged := !sameUserID(previousManagerID, user.ManagerID)
+	if user.Role == previousRole && !managerChanged {
+		return
 	}
 
+	details := map[string]interface{}{
+		"email":         user.Email,
+		"previous_role": previousRole,
+		"role":          user.Role,
+		"role_claim":    s.roleMapping.RoleClaim,
+		"source":        RoleSourceIDP,
+	}
+	if managerChanged {
+		details["previous_manager_id"] = previousManagerID
+		details["manager_id"] = user.ManagerID
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     domain.SystemUserID,
+		Action:     domain.AuditActionRoleSync,
+		TargetType: "user",
+		TargetID:   user.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// sameUserID は任意のユーザーIDが等しいかを判定します
+func sameUserID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// GetSession はセッションIDからセッション情報を取得します
+// コンテキストに端末の情報（WithClientInfo）がある場合は、最終アクセス日時とIPアドレスを更新します
+func (s *AuthService) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	session, err := s.sessionStore.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if client, ok := clientInfoFromContext(ctx); ok {
+		s.touchSession(ctx, sessionID, session, client)
+	}
 	return session, nil
 }
 
+// touchSession はセッションの最終アクセス日時と端末の情報を更新します
+// 書き込みを抑えるため、IPアドレス・User-Agent が変わらない間は sessionActivityInterval ごとに更新します
+// 更新に失敗しても認証は失敗させません
+func (s *AuthService) touchSession(ctx context.Context, sessionID string, session *Session, client ClientInfo) {
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) < sessionActivityInterval &&
+		client.IPAddress == session.IPAddress && client.UserAgent == session.UserAgent {
+		return
+	}
+
+	session.LastSeenAt = now
+	session.IPAddress = client.IPAddress
+	session.UserAgent = client.UserAgent
+	_ = s.sessionStore.UpdateSession(ctx, sessionID, session)
+}
+
 // ValidateToken はアクセストークンを検証します
 func (s *AuthService) ValidateToken(ctx context.Context, accessToken string) error {
 	return s.oidcClient.ValidateToken(ctx, accessToken)
 }
 
-// RefreshSession はリフレッシュトークンを使用してセッションを更新します
+// RefreshSession はセッションを発行したIdPでリフレッシュトークンを使用してセッションを更新します
+// セッションIDは新しいものに切り替え、古いIDは無効化します
+// リフレッシュトークンのないセッション（SAML）は更新できないため、有効期限後に再ログインが必要です
 func (s *AuthService) RefreshSession(ctx context.Context, sessionID string) (*Session, error) {
-	session, err := s.GetSession(sessionID)
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.sessionProvider(session)
 	if err != nil {
 		return nil, err
 	}
+	refresher, ok := provider.(TokenRefresher)
+	if !ok || session.RefreshToken == "" {
+		return nil, ErrRefreshNotSupported
+	}
 
 	// リフレッシュトークンで新しいアクセストークンを取得
-	newToken, err := s.oidcClient.RefreshToken(ctx, session.RefreshToken)
+	newToken, err := refresher.RefreshToken(ctx, session.RefreshToken)
 	if err != nil {
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 
-	// セッション情報を更新
-	s.mu.Lock()
+	rotatedID, err := newRandomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// セッション情報を更新（有効期間も新しいトークンの有効期限に合わせる）
+	session.ID = rotatedID
 	session.AccessToken = newToken.AccessToken
 	session.ExpiresAt = newToken.Expiry
 	if newToken.RefreshToken != "" {
 		session.RefreshToken = newToken.RefreshToken
 	}
-	s.mu.Unlock()
+	if idToken, ok := newToken.Extra("id_token").(string); ok && idToken != "" {
+		session.IDToken = idToken
+	}
+	if err := s.sessionStore.SaveSession(ctx, rotatedID, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if err := s.sessionStore.DeleteSession(ctx, sessionID); err != nil {
+		return nil, fmt.Errorf("failed to delete session: %w", err)
+	}
 
 	return session, nil
 }
 
-// Logout はセッションを削除します
-func (s *AuthService) Logout(ctx context.Context, sessionID string, userID uuid.UUID) error {
-	s.mu.Lock()
-	delete(s.sessions, sessionID)
-	s.mu.Unlock()
+// Logout はセッションを削除し、セッションを発行したIdPのログアウトURLを返します
+// IdPのログアウトに対応していないプロバイダー（SAML）の場合、ログアウトURLは空文字です
+func (s *AuthService) Logout(ctx context.Context, sessionID string, userID uuid.UUID) (string, error) {
+	var logoutURL, providerName string
+	if session, err := s.sessionStore.GetSession(ctx, sessionID); err == nil {
+		if provider, err := s.sessionProvider(session); err == nil {
+			providerName = provider.Name()
+			if p, ok := provider.(LogoutURLProvider); ok {
+				logoutURL = p.LogoutURL(session)
+			}
+		}
+	}
+
+	if err := s.sessionStore.DeleteSession(ctx, sessionID); err != nil {
+		return "", fmt.Errorf("failed to delete session: %w", err)
+	}
 
 	// 監査ログ記録
 	auditLog := &domain.AuditLog{
@@ -330,11 +723,136 @@ func (s *AuthService) Logout(ctx context.Context, sessionID string, userID uuid.
 		TargetID:   userID.String(),
 		CreatedAt:  time.Now(),
 	}
+	if providerName != "" {
+		auditLog.Details = map[string]interface{}{
+			"provider": providerName,
+		}
+	}
 	_ = s.auditLogRepo.Create(ctx, auditLog)
 
+	return logoutURL, nil
+}
+
+// RevokeUserSessions はユーザーの全てのセッションを無効化し（強制ログアウト）、無効化した件数を返します
+// 監査ログは無効化を行ったユーザー（actorID）で SESSION_REVOKE（reason: revoked_all）として記録します
+func (s *AuthService) RevokeUserSessions(ctx context.Context, actorID, userID uuid.UUID) (int, error) {
+	revoked, err := s.sessionStore.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionSessionRevoke,
+		TargetType: "user",
+		TargetID:   userID.String(),
+		Details: map[string]interface{}{
+			"reason":           "revoked_all",
+			"revoked_sessions": revoked,
+		},
+		CreatedAt: time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+
+	return revoked, nil
+}
+
+// ListSessions はユーザーの有効なセッションを最終アクセスの新しい順に返します
+// currentSessionID（リクエストに使用したセッション）に一致するセッションは Current を true にします
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID string) ([]*SessionInfo, error) {
+	sessions, err := s.sessionStore.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	infos := make([]*SessionInfo, 0, len(sessions))
+	for _, session := range sessions {
+		infos = append(infos, newSessionInfo(session, currentSessionID))
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].LastSeenAt.After(infos[j].LastSeenAt)
+	})
+	return infos, nil
+}
+
+// RevokeSession はユーザーのセッションを1件無効化します
+// セッションは SessionInfo.ID（セッションIDのハッシュ）で指定し、ユーザーのセッションでない場合は ErrSessionNotFound を返します
+func (s *AuthService) RevokeSession(ctx context.Context, actorID, userID uuid.UUID, sessionInfoID string) error {
+	sessions, err := s.sessionStore.ListUserSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if sessionHandle(session.ID) != sessionInfoID {
+			continue
+		}
+		if err := s.sessionStore.DeleteSession(ctx, session.ID); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		s.auditSessionRevoke(ctx, actorID, session, "revoked")
+		return nil
+	}
+	return ErrSessionNotFound
+}
+
+// enforceSessionLimit は同時セッション数の上限を超えた分を、最終アクセスの古いセッションから無効化します
+// ログイン直後のセッション（keepSessionID）は無効化しません
+func (s *AuthService) enforceSessionLimit(ctx context.Context, userID uuid.UUID, keepSessionID string) error {
+	if s.maxSessions <= 0 {
+		return nil
+	}
+
+	sessions, err := s.sessionStore.ListUserSessions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(sessions) <= s.maxSessions {
+		return nil
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.Before(sessions[j].LastSeenAt)
+	})
+	excess := len(sessions) - s.maxSessions
+	for _, session := range sessions {
+		if excess == 0 {
+			break
+		}
+		if session.ID == keepSessionID {
+			continue
+		}
+		if err := s.sessionStore.DeleteSession(ctx, session.ID); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+		s.auditSessionRevoke(ctx, userID, session, "session_limit")
+		excess--
+	}
 	return nil
 }
 
+// auditSessionRevoke はセッション1件の無効化を監査ログに記録します
+// reason は revoked（利用者・管理者による無効化）または session_limit（同時セッション数の上限）です
+func (s *AuthService) auditSessionRevoke(ctx context.Context, actorID uuid.UUID, session *Session, reason string) {
+	info := newSessionInfo(session, "")
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionSessionRevoke,
+		TargetType: "user",
+		TargetID:   session.UserID.String(),
+		Details: map[string]interface{}{
+			"session":    info.ID,
+			"reason":     reason,
+			"provider":   info.Provider,
+			"ip_address": info.IPAddress,
+			"device":     info.Device,
+		},
+		CreatedAt: time.Now(),
+	})
+}
+
 // CheckPermission は権限をチェックします
 func (s *AuthService) CheckPermission(session *Session, requiredRole domain.Role) error {
 	if session == nil {
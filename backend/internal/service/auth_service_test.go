@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
 	"github.com/your-org/esms/internal/service"
 	"github.com/your-org/esms/pkg/oidc"
 	"golang.org/x/oauth2"
@@ -72,25 +73,39 @@ func (m *MockOIDCClient) ParseIDTokenClaimsWithValidation(ctx context.Context, r
 	return args.Get(0).(*oidc.TokenClaims), args.Error(1)
 }
 
+func (m *MockOIDCClient) EndSessionURL(idTokenHint string) string {
+	args := m.Called(idTokenHint)
+	return args.String(0)
+}
+
 func TestAuthService_GetAuthURL(t *testing.T) {
 	mockOIDC := new(MockOIDCClient)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditRepo := new(MockAuditLogRepository)
 
-	svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo)
+	svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0)
 
-	state := "test-state"
-	expectedURL := "http://auth.example.com?state=" + state
+	expectedURL := "http://auth.example.com"
 
 	// Mock expects AuthURLParams, not just state string
+	var issuedState string
 	mockOIDC.On("GetAuthURL", mock.MatchedBy(func(params oidc.AuthURLParams) bool {
-		return params.State == state && params.Nonce != "" && params.CodeChallenge != ""
+		issuedState = params.State
+		return params.State != "" && params.Nonce != "" && params.CodeChallenge != ""
 	})).Return(expectedURL)
 
-	url := svc.GetAuthURL(state)
+	url, state, err := svc.GetAuthURL(context.Background())
 
+	assert.NoError(t, err)
 	assert.Equal(t, expectedURL, url)
+	// state はサーバーで推測できない値を生成する
+	assert.Equal(t, issuedState, state)
+	assert.GreaterOrEqual(t, len(state), 43)
 	mockOIDC.AssertExpectations(t)
+
+	_, another, err := svc.GetAuthURL(context.Background())
+	assert.NoError(t, err)
+	assert.NotEqual(t, state, another)
 }
 
 func TestAuthService_HandleCallback(t *testing.T) {
@@ -121,6 +136,7 @@ func TestAuthService_HandleCallback(t *testing.T) {
 
 				// ParseIDTokenClaimsWithValidation is called with nonce and access token
 				mo.On("ParseIDTokenClaimsWithValidation", mock.Anything, "raw-id-token", mock.AnythingOfType("string"), "access-token").Return(&oidc.TokenClaims{
+					Issuer:  "https://idp.example.com",
 					Subject: "user-sub-123",
 					Email:   "new@example.com",
 				}, nil)
@@ -131,9 +147,11 @@ func TestAuthService_HandleCallback(t *testing.T) {
 					Name:  "New User",
 				}, nil)
 
-				mu.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, errors.New("not found"))
+				mu.On("GetBySubject", mock.Anything, "https://idp.example.com", "user-sub-123").Return(nil, repository.ErrNotFound)
+				mu.On("ListByEmail", mock.Anything, "new@example.com").Return([]*domain.User{}, nil)
 				mu.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
-					return u.Email == "new@example.com" && u.Name == "New User"
+					return u.Email == "new@example.com" && u.Name == "New User" &&
+						u.HasIdentity("https://idp.example.com", "user-sub-123")
 				})).Return(nil)
 
 				ma.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
@@ -168,20 +186,21 @@ func TestAuthService_HandleCallback(t *testing.T) {
 			mockUserRepo := new(MockUserRepository)
 			mockAuditRepo := new(MockAuditLogRepository)
 
-			svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo)
+			svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0)
 
 			tt.setupMocks(mockOIDC, mockUserRepo, mockAuditRepo)
 
 			// Pre-set state for valid cases
+			state := tt.state
 			if tt.state == "valid-state" {
 				// GetAuthURL expectation with AuthURLParams
-				mockOIDC.On("GetAuthURL", mock.MatchedBy(func(params oidc.AuthURLParams) bool {
-					return params.State == "valid-state"
-				})).Return("http://auth.example.com?state=valid-state").Once()
-				svc.GetAuthURL("valid-state")
+				mockOIDC.On("GetAuthURL", mock.AnythingOfType("oidc.AuthURLParams")).Return("http://auth.example.com").Once()
+				var err error
+				_, state, err = svc.GetAuthURL(context.Background())
+				assert.NoError(t, err)
 			}
 
-			session, err := svc.HandleCallback(context.Background(), tt.code, tt.state)
+			session, err := svc.HandleCallback(context.Background(), tt.code, state, "")
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -194,11 +213,76 @@ func TestAuthService_HandleCallback(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, session)
+				assert.NotEmpty(t, session.ID)
+
+				// 同じ state は再利用できない
+				_, err = svc.HandleCallback(context.Background(), tt.code, state, "")
+				assert.ErrorIs(t, err, service.ErrInvalidState)
 			}
 		})
 	}
 }
 
+func TestAuthService_HandleCallback_DiscardsPreviousSession(t *testing.T) {
+	mockOIDC := new(MockOIDCClient)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	store := service.NewMemorySessionStore()
+	svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+	ctx := context.Background()
+
+	// 攻撃者が事前に用意したセッションID
+	user := &domain.User{ID: uuid.New(), Issuer: "https://idp.example.com", Sub: "sub", Email: "user@example.com", Role: domain.RoleGeneral, IsActive: true}
+	assert.NoError(t, store.SaveSession(ctx, "fixated", &service.Session{ID: "fixated", UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	token := (&oauth2.Token{AccessToken: "access-token", Expiry: time.Now().Add(time.Hour)}).WithExtra(map[string]interface{}{"id_token": "raw-id-token"})
+	mockOIDC.On("GetAuthURL", mock.Anything).Return("http://auth.example.com")
+	mockOIDC.On("ExchangeCode", mock.Anything, "code", mock.AnythingOfType("string")).Return(token, nil)
+	mockOIDC.On("ParseIDTokenClaimsWithValidation", mock.Anything, "raw-id-token", mock.AnythingOfType("string"), "access-token").Return(&oidc.TokenClaims{Issuer: "https://idp.example.com", Subject: "sub"}, nil)
+	mockOIDC.On("VerifyIDToken", mock.Anything, "raw-id-token").Return(&coreosoidc.IDToken{}, nil)
+	mockOIDC.On("GetUserInfo", mock.Anything, mock.Anything).Return(&oidc.UserInfo{Email: user.Email, Name: "User"}, nil)
+	mockUserRepo.On("GetBySubject", mock.Anything, "https://idp.example.com", "sub").Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, state, err := svc.GetAuthURL(ctx)
+	assert.NoError(t, err)
+	session, err := svc.HandleCallback(ctx, "code", state, "fixated")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "fixated", session.ID)
+	assert.NotEmpty(t, session.CSRFToken)
+	assert.NotEqual(t, session.ID, session.CSRFToken)
+	assert.GreaterOrEqual(t, len(session.ID), 43) // 32byte の base64url
+
+	_, err = svc.GetSession(ctx, "fixated")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	found, err := svc.GetSession(ctx, session.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, found.UserID)
+}
+
+func TestAuthService_RefreshSession_RotatesSessionID(t *testing.T) {
+	mockOIDC := new(MockOIDCClient)
+	store := service.NewMemorySessionStore()
+	svc := service.NewAuthService(mockOIDC, new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	assert.NoError(t, store.SaveSession(ctx, "old", &service.Session{ID: "old", UserID: userID, RefreshToken: "refresh-token", ExpiresAt: time.Now().Add(time.Minute)}))
+	mockOIDC.On("RefreshToken", mock.Anything, "refresh-token").Return(&oauth2.Token{AccessToken: "new-access-token", Expiry: time.Now().Add(time.Hour)}, nil)
+
+	session, err := svc.RefreshSession(ctx, "old")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "old", session.ID)
+	assert.Equal(t, "refresh-token", session.RefreshToken)
+
+	_, err = svc.GetSession(ctx, "old")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	found, err := svc.GetSession(ctx, session.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-access-token", found.AccessToken)
+}
+
 func TestAuthService_CheckPermission(t *testing.T) {
 	// This test doesn't require OIDC client, so we can test it directly
 	// Note: This is a conceptual test
@@ -316,3 +400,273 @@ func TestSession_Structure(t *testing.T) {
 	assert.Equal(t, domain.RoleGeneral, session.Role)
 	assert.True(t, time.Now().Before(session.ExpiresAt))
 }
+
+// loginWithClaims は指定したクレームを持つIDトークンでログインします
+func loginWithClaims(t *testing.T, svc *service.AuthService, mockOIDC *MockOIDCClient, email string, rawClaims map[string]interface{}) (*service.Session, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	token := (&oauth2.Token{AccessToken: "access-token", Expiry: time.Now().Add(time.Hour)}).WithExtra(map[string]interface{}{"id_token": "raw-id-token"})
+	mockOIDC.On("GetAuthURL", mock.Anything).Return("http://auth.example.com")
+	mockOIDC.On("ExchangeCode", mock.Anything, "code", mock.AnythingOfType("string")).Return(token, nil)
+	mockOIDC.On("ParseIDTokenClaimsWithValidation", mock.Anything, "raw-id-token", mock.AnythingOfType("string"), "access-token").Return(&oidc.TokenClaims{Issuer: "https://idp.example.com", Subject: "sub"}, nil)
+	mockOIDC.On("VerifyIDToken", mock.Anything, "raw-id-token").Return(&coreosoidc.IDToken{}, nil)
+	mockOIDC.On("GetUserInfo", mock.Anything, mock.Anything).Return(&oidc.UserInfo{Email: email, Name: "User", RawClaims: rawClaims}, nil)
+
+	_, state, err := svc.GetAuthURL(ctx)
+	assert.NoError(t, err)
+	return svc.HandleCallback(ctx, "code", state, "")
+}
+
+func TestAuthService_HandleCallback_RoleMapping(t *testing.T) {
+	managerID := uuid.New()
+	manager := &domain.User{ID: managerID, Email: "boss@example.com", Role: domain.RoleManager}
+	claims := map[string]interface{}{
+		"groups":        []interface{}{"all-staff", "esms-admins"},
+		"manager_email": "boss@example.com",
+	}
+
+	tests := []struct {
+		name            string
+		source          string
+		existing        *domain.User
+		expectedRole    domain.Role
+		expectedManager *uuid.UUID
+		expectAudit     bool
+	}{
+		{
+			name:            "New user gets mapped role and manager",
+			source:          "LOCAL",
+			expectedRole:    domain.RoleAdmin,
+			expectedManager: &managerID,
+			expectAudit:     true,
+		},
+		{
+			name:            "IdP-managed role overwrites existing user",
+			source:          "IDP",
+			existing:        &domain.User{ID: uuid.New(), Email: "user@example.com", Role: domain.RoleGeneral, IsActive: true},
+			expectedRole:    domain.RoleAdmin,
+			expectedManager: &managerID,
+			expectAudit:     true,
+		},
+		{
+			name:         "Locally-managed role is kept",
+			source:       "LOCAL",
+			existing:     &domain.User{ID: uuid.New(), Email: "user@example.com", Role: domain.RoleSecretary, IsActive: true},
+			expectedRole: domain.RoleSecretary,
+		},
+		{
+			name:            "Unchanged role is not audited",
+			source:          "IDP",
+			existing:        &domain.User{ID: uuid.New(), Email: "user@example.com", Role: domain.RoleAdmin, ManagerID: &managerID, IsActive: true},
+			expectedRole:    domain.RoleAdmin,
+			expectedManager: &managerID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOIDC := new(MockOIDCClient)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+
+			mapping, err := service.ParseRoleMapping("groups", "esms-admins=ADMIN", "manager_email", tt.source)
+			assert.NoError(t, err)
+			svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), mapping, 0)
+
+			mockUserRepo.On("GetByEmail", mock.Anything, "boss@example.com").Return(manager, nil).Maybe()
+			matchesUser := mock.MatchedBy(func(u *domain.User) bool {
+				return u.Role == tt.expectedRole && sameManager(u.ManagerID, tt.expectedManager)
+			})
+			if tt.existing != nil {
+				tt.existing.Issuer, tt.existing.Sub = "https://idp.example.com", "sub"
+				mockUserRepo.On("GetBySubject", mock.Anything, "https://idp.example.com", "sub").Return(tt.existing, nil)
+				mockUserRepo.On("Update", mock.Anything, matchesUser).Return(nil)
+			} else {
+				mockUserRepo.On("GetBySubject", mock.Anything, "https://idp.example.com", "sub").Return(nil, repository.ErrNotFound)
+				mockUserRepo.On("ListByEmail", mock.Anything, "user@example.com").Return([]*domain.User{}, nil)
+				mockUserRepo.On("Create", mock.Anything, matchesUser).Return(nil)
+			}
+			mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			session, err := loginWithClaims(t, svc, mockOIDC, "user@example.com", claims)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRole, session.Role)
+
+			audited := false
+			for _, call := range mockAuditRepo.Calls {
+				if log := call.Arguments.Get(1).(*domain.AuditLog); log.Action == domain.AuditActionRoleSync {
+					audited = true
+					assert.Equal(t, tt.expectedRole, log.Details["role"])
+					assert.Equal(t, domain.SystemUserID, log.UserID)
+				}
+			}
+			assert.Equal(t, tt.expectAudit, audited)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func sameManager(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func TestAuthService_HandleCallback_IdentitySync(t *testing.T) {
+	const issuer, subject = "https://idp.example.com", "sub"
+	otherID := uuid.New()
+
+	tests := []struct {
+		name          string
+		email         string
+		setupMocks    func(*MockUserRepository, *MockIdentityConflictRepository)
+		expectedError error
+		expectedAudit domain.AuditAction
+	}{
+		{
+			name:  "Email-only user is linked on first login",
+			email: "user@example.com",
+			setupMocks: func(mu *MockUserRepository, mc *MockIdentityConflictRepository) {
+				unlinked := &domain.User{ID: uuid.New(), Sub: "seeded-sub", Email: "User@example.com", Role: domain.RoleManager, IsActive: true}
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(nil, repository.ErrNotFound)
+				mu.On("ListByEmail", mock.Anything, "user@example.com").Return([]*domain.User{unlinked}, nil)
+				mu.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.ID == unlinked.ID && u.HasIdentity(issuer, subject) && u.Role == domain.RoleManager
+				})).Return(nil)
+			},
+			expectedAudit: domain.AuditActionIdentityLink,
+		},
+		{
+			name:  "Email change is an attribute update",
+			email: "renamed@example.com",
+			setupMocks: func(mu *MockUserRepository, mc *MockIdentityConflictRepository) {
+				linked := &domain.User{ID: uuid.New(), Issuer: issuer, Sub: subject, Email: "user@example.com", Role: domain.RoleGeneral, IsActive: true}
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(linked, nil)
+				mu.On("ListByEmail", mock.Anything, "renamed@example.com").Return([]*domain.User{}, nil)
+				mu.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.ID == linked.ID && u.Email == "renamed@example.com"
+				})).Return(nil)
+			},
+			expectedAudit: domain.AuditActionEmailChange,
+		},
+		{
+			name:  "Email change to an address in use keeps the old email",
+			email: "taken@example.com",
+			setupMocks: func(mu *MockUserRepository, mc *MockIdentityConflictRepository) {
+				linked := &domain.User{ID: uuid.New(), Issuer: issuer, Sub: subject, Email: "user@example.com", Role: domain.RoleGeneral, IsActive: true}
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(linked, nil)
+				mu.On("ListByEmail", mock.Anything, "taken@example.com").Return([]*domain.User{{ID: otherID, Email: "taken@example.com"}}, nil)
+				mu.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.ID == linked.ID && u.Email == "user@example.com"
+				})).Return(nil)
+				mc.On("Record", mock.Anything, mock.MatchedBy(func(c *domain.IdentityConflict) bool {
+					return c.Kind == domain.IdentityConflictEmailTaken && *c.UserID == linked.ID && c.CandidateUserIDs[0] == otherID
+				})).Return(nil)
+			},
+			expectedAudit: domain.AuditActionIdentityConflict,
+		},
+		{
+			name:  "Email linked to another subject is rejected",
+			email: "user@example.com",
+			setupMocks: func(mu *MockUserRepository, mc *MockIdentityConflictRepository) {
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(nil, repository.ErrNotFound)
+				mu.On("ListByEmail", mock.Anything, "user@example.com").Return([]*domain.User{{ID: otherID, Issuer: issuer, Sub: "former-employee"}}, nil)
+				mc.On("Record", mock.Anything, mock.MatchedBy(func(c *domain.IdentityConflict) bool {
+					return c.Kind == domain.IdentityConflictEmailLinked && c.Subject == subject
+				})).Return(nil)
+			},
+			expectedError: service.ErrIdentityConflict,
+			expectedAudit: domain.AuditActionIdentityConflict,
+		},
+		{
+			name:  "Multiple email matches are rejected",
+			email: "user@example.com",
+			setupMocks: func(mu *MockUserRepository, mc *MockIdentityConflictRepository) {
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(nil, repository.ErrNotFound)
+				mu.On("ListByEmail", mock.Anything, "user@example.com").Return([]*domain.User{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+				mc.On("Record", mock.Anything, mock.MatchedBy(func(c *domain.IdentityConflict) bool {
+					return c.Kind == domain.IdentityConflictMultipleEmail && len(c.CandidateUserIDs) == 2
+				})).Return(nil)
+			},
+			expectedError: service.ErrIdentityConflict,
+			expectedAudit: domain.AuditActionIdentityConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOIDC := new(MockOIDCClient)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+			mockConflictRepo := new(MockIdentityConflictRepository)
+			svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, mockConflictRepo, service.NewMemorySessionStore(), service.RoleMapping{}, 0)
+
+			tt.setupMocks(mockUserRepo, mockConflictRepo)
+			mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			session, err := loginWithClaims(t, svc, mockOIDC, tt.email, nil)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			audited := false
+			for _, call := range mockAuditRepo.Calls {
+				if call.Arguments.Get(1).(*domain.AuditLog).Action == tt.expectedAudit {
+					audited = true
+				}
+			}
+			assert.True(t, audited)
+			mockUserRepo.AssertExpectations(t)
+			mockConflictRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_HandleCallback_UserDeactivated(t *testing.T) {
+	const issuer, subject = "https://idp.example.com", "sub"
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		setupMocks func(*MockUserRepository)
+	}{
+		{
+			name: "Deactivated linked user",
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(&domain.User{ID: uuid.New(), Issuer: issuer, Sub: subject, Email: "user@example.com"}, nil)
+			},
+		},
+		{
+			name: "Deleted linked user",
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(&domain.User{ID: uuid.New(), Issuer: issuer, Sub: subject, Email: "user@example.com", IsActive: true, DeletedAt: &deletedAt}, nil)
+			},
+		},
+		{
+			name: "Deactivated provisioned user is not linked",
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, issuer, subject).Return(nil, repository.ErrNotFound)
+				mu.On("ListByEmail", mock.Anything, "user@example.com").Return([]*domain.User{{ID: uuid.New(), Email: "user@example.com"}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOIDC := new(MockOIDCClient)
+			mockUserRepo := new(MockUserRepository)
+			svc := service.NewAuthService(mockOIDC, mockUserRepo, new(MockAuditLogRepository), new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0)
+			tt.setupMocks(mockUserRepo)
+
+			session, err := loginWithClaims(t, svc, mockOIDC, "user@example.com", nil)
+			assert.ErrorIs(t, err, service.ErrUserDeactivated)
+			assert.Nil(t, session)
+			mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// backend/internal/service/bump_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrBumpReasonRequired = errors.New("a reason is required to bump existing reservations")
+	ErrBumpForbidden      = errors.New("user is not allowed to bump reservations in this room")
+	ErrBumpOutranked      = errors.New("existing reservation belongs to a user of equal or higher rank")
+	ErrNothingToBump      = errors.New("room is free for the requested time; book it normally")
+)
+
+// BumpService は役員予約による会議室の優先確保（既存の予約の移動・強制キャンセル）に関するビジネスロジックを提供します
+type BumpService struct {
+	reservationRepo     repository.ReservationRepository
+	resourceRepo        repository.ResourceRepository
+	userRepo            repository.UserRepository
+	auditLogRepo        repository.AuditLogRepository
+	bumpRepo            repository.BumpRepository
+	notificationService *NotificationService
+	reservationService  *ReservationService
+}
+
+// NewBumpService は新しいBumpServiceを作成します
+// notificationService が nil の場合、押しのけられた予約の主催者への通知は行いません
+func NewBumpService(
+	reservationRepo repository.ReservationRepository,
+	resourceRepo repository.ResourceRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	bumpRepo repository.BumpRepository,
+	notificationService *NotificationService,
+	reservationService *ReservationService,
+) *BumpService {
+	return &BumpService{
+		reservationRepo:     reservationRepo,
+		resourceRepo:        resourceRepo,
+		userRepo:            userRepo,
+		auditLogRepo:        auditLogRepo,
+		bumpRepo:            bumpRepo,
+		notificationService: notificationService,
+		reservationService:  reservationService,
+	}
+}
+
+// BumpRequest は会議室の優先確保リクエスト
+type BumpRequest struct {
+	ActorID        uuid.UUID  // 実行するユーザー
+	OnBehalfOf     *uuid.UUID // 秘書が担当役員の代理で確保する場合の役員
+	ResourceID     uuid.UUID  // 確保する会議室
+	Title          string
+	Description    string
+	StartAt        time.Time
+	EndAt          time.Time
+	Timezone       string
+	IsPrivate      bool
+	ParticipantIDs []uuid.UUID
+	Reason         string // 押しのけられる主催者へ通知する理由（必須）
+}
+
+// BumpResult は優先確保の結果
+type BumpResult struct {
+	Reservation *domain.Reservation       `json:"reservation"`
+	Displaced   []*domain.ReservationBump `json:"displaced"`
+}
+
+// Bump は既存の予約を押しのけて会議室を確保します
+// 押しのけた回は同等の空き会議室があれば移動し、なければ強制キャンセルします
+// 管理者、または会議室の bump_role 以上のロールを持つユーザー（秘書は担当役員の代理）が実行でき、
+// 押しのけられる予約の主催者より上位のロールである必要があります（管理者は制限なし）
+func (s *BumpService) Bump(ctx context.Context, req *BumpRequest) (*BumpResult, error) {
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, ErrBumpReasonRequired
+	}
+	if !req.EndAt.After(req.StartAt) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	actor, err := s.userRepo.GetByID(ctx, req.ActorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	beneficiary, err := s.beneficiary(ctx, actor, req.OnBehalfOf)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.resourceRepo.GetByID(ctx, req.ResourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	if !room.CanBeBumpedBy(beneficiary) {
+		return nil, ErrBumpForbidden
+	}
+
+	// 押しのける予約の主催者を確認する（同格以上の予約は押しのけられない）
+	displaced, err := s.reservationRepo.ListOverlappingInstances(ctx, room.ID, req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overlapping reservations: %w", err)
+	}
+	if len(displaced) == 0 {
+		return nil, ErrNothingToBump
+	}
+	organizers := make(map[uuid.UUID]*domain.User, len(displaced))
+	for _, inst := range displaced {
+		organizerID := inst.Reservation.OrganizerID
+		if _, ok := organizers[organizerID]; ok {
+			continue
+		}
+		organizer, err := s.userRepo.GetByID(ctx, organizerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get organizer: %w", err)
+		}
+		if !beneficiary.IsAdmin() && !beneficiary.Outranks(organizer) {
+			return nil, ErrBumpOutranked
+		}
+		organizers[organizerID] = organizer
+	}
+
+	// 既存の予約を押しのける前に優先確保の予約を作成する（作成できなければ何も変更しない）
+	reservation, err := s.reservationService.CreateReservation(ctx, &CreateReservationRequest{
+		OrganizerID:    beneficiary.ID,
+		ResourceIDs:    []uuid.UUID{room.ID},
+		Title:          req.Title,
+		Description:    req.Description,
+		StartAt:        req.StartAt,
+		EndAt:          req.EndAt,
+		IsPrivate:      req.IsPrivate,
+		Timezone:       req.Timezone,
+		ParticipantIDs: req.ParticipantIDs,
+		preempt:        room.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 押しのける各回の移動先を決める（移動先を決められない場合は優先確保の予約を取り消す）
+	result := &BumpResult{Reservation: reservation}
+	replacements := make([]*domain.Resource, len(displaced))
+	for i, inst := range displaced {
+		bump, replacement, err := s.planDisplacement(ctx, req, reservation, room, inst, organizers[inst.Reservation.OrganizerID])
+		if err != nil {
+			s.reservationService.discardReservation(ctx, reservation)
+			return nil, err
+		}
+		result.Displaced = append(result.Displaced, bump)
+		replacements[i] = replacement
+	}
+
+	// 押しのけた回の移動・強制キャンセルは1つのトランザクションで行い、失敗した場合は優先確保の予約も取り消す
+	if err := s.bumpRepo.Apply(ctx, result.Displaced); err != nil {
+		s.reservationService.discardReservation(ctx, reservation)
+		return nil, fmt.Errorf("failed to displace reservations: %w", err)
+	}
+
+	for i, inst := range displaced {
+		bump := result.Displaced[i]
+		s.recordDisplacementAudit(ctx, req, reservation, room, inst, replacements[i])
+		if !bump.IsMoved() {
+			s.promoteReleasedResources(ctx, inst, room.ID)
+		}
+
+		if s.notificationService != nil {
+			_ = s.notificationService.NotifyReservationBumped(ctx, bump, inst, room, replacements[i], organizers[inst.Reservation.OrganizerID])
+		}
+	}
+
+	details := map[string]interface{}{
+		"resource_id": room.ID.String(),
+		"reason":      req.Reason,
+		"displaced":   len(result.Displaced),
+	}
+	if req.OnBehalfOf != nil {
+		details["on_behalf_of"] = req.OnBehalfOf.String()
+	}
+	s.recordAudit(ctx, actor.ID, domain.AuditActionBump, reservation.ID, details)
+
+	return result, nil
+}
+
+// beneficiary は優先確保した予約の主催者を返します
+// 秘書が担当役員の代理で実行する場合は役員、それ以外は実行者本人です
+func (s *BumpService) beneficiary(ctx context.Context, actor *domain.User, onBehalfOf *uuid.UUID) (*domain.User, error) {
+	if onBehalfOf == nil || *onBehalfOf == actor.ID {
+		return actor, nil
+	}
+	executive, err := s.userRepo.GetByID(ctx, *onBehalfOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !actor.CanActFor(executive) {
+		return nil, ErrUnauthorized
+	}
+	return executive, nil
+}
+
+// planDisplacement は押しのけられた回を同等の空き会議室へ移動するか、移動先がない場合は強制キャンセルするかを決めます
+// 押しのけられた回は主催者の都合ではないため、記録を残してペナルティの対象外とします
+func (s *BumpService) planDisplacement(ctx context.Context, req *BumpRequest, reservation *domain.Reservation, room *domain.Resource, inst *domain.ReservationInstance, organizer *domain.User) (*domain.ReservationBump, *domain.Resource, error) {
+	bump := &domain.ReservationBump{
+		ID:                     uuid.New(),
+		ResourceID:             room.ID,
+		ReservationID:          reservation.ID,
+		BumpedBy:               req.ActorID,
+		OnBehalfOf:             req.OnBehalfOf,
+		DisplacedReservationID: inst.ReservationID,
+		DisplacedInstanceID:    inst.ID,
+		DisplacedOrganizerID:   organizer.ID,
+		Outcome:                domain.BumpOutcomeCancelled,
+		Reason:                 req.Reason,
+		CreatedAt:              time.Now(),
+	}
+
+	replacement, err := s.equivalentRoom(ctx, room, inst, organizer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if replacement != nil {
+		bump.Outcome = domain.BumpOutcomeMoved
+		bump.ReplacementResourceID = &replacement.ID
+	}
+	return bump, replacement, nil
+}
+
+// recordDisplacementAudit は押しのけた回の移動・強制キャンセルの監査ログを記録します
+func (s *BumpService) recordDisplacementAudit(ctx context.Context, req *BumpRequest, reservation *domain.Reservation, room *domain.Resource, inst *domain.ReservationInstance, replacement *domain.Resource) {
+	action := domain.AuditActionForceCancel
+	details := map[string]interface{}{
+		"instance_id":         inst.ID.String(),
+		"start_at":            inst.StartAt,
+		"end_at":              inst.EndAt,
+		"resource_id":         room.ID.String(),
+		"bump_reservation_id": reservation.ID.String(),
+		"reason":              req.Reason,
+		"penalty_waived":      true,
+	}
+	if replacement != nil {
+		action = domain.AuditActionBumpRelocate
+		details["replacement_resource_id"] = replacement.ID.String()
+	}
+	s.recordAudit(ctx, req.ActorID, action, inst.ReservationID, details)
+}
+
+// promoteReleasedResources は強制キャンセルした回と一緒に予約されていたリソースのウェイトリスト繰り上げを登録します
+// 押しのけた会議室は優先確保の予約が使用するため対象外です（繰り上げの失敗は優先確保を妨げない）
+func (s *BumpService) promoteReleasedResources(ctx context.Context, inst *domain.ReservationInstance, roomID uuid.UUID) {
+	if s.reservationService.jobQueue == nil {
+		return
+	}
+	resourceIDs, err := s.reservationRepo.GetResourceIDs(ctx, inst.ReservationID)
+	if err != nil {
+		return
+	}
+
+	var released []releasedSlot
+	for _, resourceID := range resourceIDs {
+		if resourceID == roomID {
+			continue
+		}
+		released = append(released, releasedSlot{resourceID: resourceID, startAt: inst.StartAt, endAt: inst.EndAt})
+	}
+	s.reservationService.enqueueWaitlistPromotion(ctx, released)
+}
+
+// equivalentRoom は押しのけられた回の移動先として、同等の条件を満たす空き会議室から最も適したものを返します
+// 仮押さえ・承認が必要な会議室・主催者が予約できない会議室には移動しません。候補がない場合は nil を返します
+func (s *BumpService) equivalentRoom(ctx context.Context, room *domain.Resource, inst *domain.ReservationInstance, organizer *domain.User) (*domain.Resource, error) {
+	if inst.Reservation.ApprovalStatus == domain.ApprovalStatusHeld {
+		return nil, nil
+	}
+
+	requirement := domain.EquivalentTo(room)
+	rooms, err := s.resourceRepo.FindAvailableRooms(ctx, inst.StartAt, inst.EndAt, requirement.Attendees)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available rooms: %w", err)
+	}
+
+	var best *domain.Resource
+	for _, candidate := range rooms {
+		if candidate.ID == room.ID || candidate.NeedsApproval() || !requirement.Fits(candidate) || !candidate.CanBeReservedBy(organizer) {
+			continue
+		}
+		if best == nil || requirement.BetterFit(candidate, best) {
+			best = candidate
+		}
+	}
+	return best, nil
+}
+
+// recordAudit は優先確保の監査ログを記録します（エラーは無視）
+func (s *BumpService) recordAudit(ctx context.Context, userID uuid.UUID, action domain.AuditAction, reservationID uuid.UUID, details map[string]interface{}) {
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		TargetType: "reservation",
+		TargetID:   reservationID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
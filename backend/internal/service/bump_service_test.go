@@ -0,0 +1,392 @@
+// backend/internal/service/bump_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func newBumpRoom(name string, capacity int, bumpRole *domain.Role) *domain.Resource {
+	return &domain.Resource{
+		ID:        uuid.New(),
+		Name:      name,
+		Type:      domain.ResourceTypeMeetingRoom,
+		Capacity:  &capacity,
+		Equipment: map[string]interface{}{"vc": true},
+		BumpRole:  bumpRole,
+		IsActive:  true,
+	}
+}
+
+func newDisplacedInstance(organizerID uuid.UUID, startAt time.Time) *domain.ReservationInstance {
+	reservationID := uuid.New()
+	return &domain.ReservationInstance{
+		ID:                 uuid.New(),
+		ReservationID:      reservationID,
+		ReservationStartAt: startAt,
+		StartAt:            startAt,
+		EndAt:              startAt.Add(time.Hour),
+		Status:             domain.ReservationStatusConfirmed,
+		Reservation: &domain.Reservation{
+			ID:             reservationID,
+			OrganizerID:    organizerID,
+			Title:          "Team sync",
+			StartAt:        startAt,
+			EndAt:          startAt.Add(time.Hour),
+			ApprovalStatus: domain.ApprovalStatusConfirmed,
+		},
+	}
+}
+
+func TestBumpService_Bump_RelocatesToEquivalentRoom(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	admin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin, IsActive: true}
+	organizer := &domain.User{ID: uuid.New(), Email: "member@example.com", Role: domain.RoleGeneral, IsActive: true}
+	room := newBumpRoom("Board Room", 10, nil)
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+	displaced := newDisplacedInstance(organizer.ID, startAt)
+
+	// 設備が足りない会議室・大きすぎる会議室より、同等で最小の会議室を選ぶ
+	noVC := newBumpRoom("Room without VC", 10, nil)
+	noVC.Equipment = nil
+	large := newBumpRoom("Large Room", 20, nil)
+	equivalent := newBumpRoom("Room B", 12, nil)
+
+	mockUserRepo.On("GetByID", ctx, admin.ID).Return(admin, nil)
+	mockUserRepo.On("GetByID", ctx, organizer.ID).Return(organizer, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockReservationRepo.On("ListOverlappingInstances", ctx, room.ID, startAt, endAt).Return([]*domain.ReservationInstance{displaced}, nil)
+	// 既存の予約があるため優先確保する会議室は空き一覧に含まれない
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx,
+		mock.MatchedBy(func(r *domain.Reservation) bool {
+			return r.OrganizerID == admin.ID && r.ApprovalStatus == domain.ApprovalStatusConfirmed
+		}),
+		mock.Anything,
+		[]uuid.UUID{room.ID},
+	).Return(nil)
+	mockResourceRepo.On("FindAvailableRooms", ctx, startAt, endAt, 10).Return([]*domain.Resource{noVC, equivalent, large}, nil)
+	mockBumpRepo.On("Apply", ctx, mock.MatchedBy(func(bumps []*domain.ReservationBump) bool {
+		return len(bumps) == 1 && bumps[0].Outcome == domain.BumpOutcomeMoved && *bumps[0].ReplacementResourceID == equivalent.ID &&
+			bumps[0].DisplacedOrganizerID == organizer.ID && bumps[0].Reason == "Board meeting"
+	})).Return(nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(p map[string]interface{}) bool {
+		return p["to"] == organizer.Email
+	})).Return("job-1", nil)
+
+	result, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    admin.ID,
+		ResourceID: room.ID,
+		Title:      "Board meeting",
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Timezone:   "Asia/Tokyo",
+		Reason:     "Board meeting",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Displaced, 1)
+	assert.True(t, result.Displaced[0].IsMoved())
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionBumpRelocate && l.TargetID == displaced.ReservationID.String()
+	}))
+	mockJobQueue.AssertExpectations(t)
+	mockBumpRepo.AssertExpectations(t)
+}
+
+func TestBumpService_Bump_SecretaryForceCancels(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	managerRole := domain.RoleManager
+	executive := &domain.User{ID: uuid.New(), Role: domain.RoleManager, IsActive: true}
+	secretary := &domain.User{ID: uuid.New(), Role: domain.RoleSecretary, ManagerID: &executive.ID, IsActive: true}
+	organizer := &domain.User{ID: uuid.New(), Email: "member@example.com", Role: domain.RoleGeneral, IsActive: true}
+	room := newBumpRoom("Executive Room", 8, &managerRole)
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+	displaced := newDisplacedInstance(organizer.ID, startAt)
+
+	mockUserRepo.On("GetByID", ctx, secretary.ID).Return(secretary, nil)
+	mockUserRepo.On("GetByID", ctx, executive.ID).Return(executive, nil)
+	mockUserRepo.On("GetByID", ctx, organizer.ID).Return(organizer, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockReservationRepo.On("ListOverlappingInstances", ctx, room.ID, startAt, endAt).Return([]*domain.ReservationInstance{displaced}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	// 予約は役員の名義で作成する
+	mockReservationRepo.On("CreateWithInstances", ctx,
+		mock.MatchedBy(func(r *domain.Reservation) bool { return r.OrganizerID == executive.ID }),
+		mock.Anything,
+		[]uuid.UUID{room.ID},
+	).Return(nil)
+	// 同等の空き会議室がない
+	mockResourceRepo.On("FindAvailableRooms", ctx, startAt, endAt, 8).Return([]*domain.Resource{}, nil)
+	// 一緒に予約されていたプロジェクターはウェイトリストへ繰り上げる（会議室は優先確保の予約が使う）
+	projectorID := uuid.New()
+	mockReservationRepo.On("GetResourceIDs", ctx, displaced.ReservationID).Return([]uuid.UUID{room.ID, projectorID}, nil)
+	mockJobQueue.On("Enqueue", ctx, "waitlist_promote", map[string]interface{}{
+		"resource_id": projectorID.String(),
+		"start_at":    displaced.StartAt.Format(time.RFC3339),
+		"end_at":      displaced.EndAt.Format(time.RFC3339),
+	}).Return("job-2", nil).Once()
+	mockBumpRepo.On("Apply", ctx, mock.MatchedBy(func(bumps []*domain.ReservationBump) bool {
+		return len(bumps) == 1 && bumps[0].Outcome == domain.BumpOutcomeCancelled && bumps[0].BumpedBy == secretary.ID && *bumps[0].OnBehalfOf == executive.ID
+	})).Return(nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.Anything).Return("job-1", nil)
+
+	result, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    secretary.ID,
+		OnBehalfOf: &executive.ID,
+		ResourceID: room.ID,
+		Title:      "Executive committee",
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Timezone:   "Asia/Tokyo",
+		Reason:     "Executive committee",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, executive.ID, result.Reservation.OrganizerID)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionForceCancel && l.UserID == secretary.ID && l.Details["penalty_waived"] == true
+	}))
+	mockBumpRepo.AssertExpectations(t)
+	mockJobQueue.AssertExpectations(t)
+}
+
+func TestBumpService_Bump_DisplaceFailureRollsBack(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	admin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin, IsActive: true}
+	organizer := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newBumpRoom("Board Room", 10, nil)
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+	displaced := newDisplacedInstance(organizer.ID, startAt)
+
+	mockUserRepo.On("GetByID", ctx, admin.ID).Return(admin, nil)
+	mockUserRepo.On("GetByID", ctx, organizer.ID).Return(organizer, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockReservationRepo.On("ListOverlappingInstances", ctx, room.ID, startAt, endAt).Return([]*domain.ReservationInstance{displaced}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.Anything, mock.Anything, []uuid.UUID{room.ID}).Return(nil)
+	mockResourceRepo.On("FindAvailableRooms", ctx, startAt, endAt, 10).Return([]*domain.Resource{}, nil)
+	// 押しのけた回の変更が失敗した場合（トランザクションは取り消される）
+	mockBumpRepo.On("Apply", ctx, mock.Anything).Return(repository.ErrNotFound)
+	mockReservationRepo.On("Delete", ctx, mock.AnythingOfType("uuid.UUID"), startAt).Return(nil)
+
+	_, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    admin.ID,
+		ResourceID: room.ID,
+		Title:      "Board meeting",
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Timezone:   "Asia/Tokyo",
+		Reason:     "Board meeting",
+	})
+
+	// 優先確保の予約も取り消し、押しのけの記録・通知は行わない
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	mockReservationRepo.AssertCalled(t, "Delete", ctx, mock.AnythingOfType("uuid.UUID"), startAt)
+	mockAuditLogRepo.AssertNotCalled(t, "Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionForceCancel || l.Action == domain.AuditActionBump
+	}))
+	mockJobQueue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBumpService_Bump_Outranked(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	managerRole := domain.RoleManager
+	manager := &domain.User{ID: uuid.New(), Role: domain.RoleManager, IsActive: true}
+	organizer := &domain.User{ID: uuid.New(), Role: domain.RoleManager, IsActive: true}
+	room := newBumpRoom("Executive Room", 8, &managerRole)
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+	mockUserRepo.On("GetByID", ctx, organizer.ID).Return(organizer, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockReservationRepo.On("ListOverlappingInstances", ctx, room.ID, startAt, endAt).
+		Return([]*domain.ReservationInstance{newDisplacedInstance(organizer.ID, startAt)}, nil)
+
+	// 同格の主催者の予約は押しのけられない
+	_, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    manager.ID,
+		ResourceID: room.ID,
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Reason:     "Client visit",
+	})
+
+	assert.ErrorIs(t, err, service.ErrBumpOutranked)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBumpService_Bump_RoomPolicyForbids(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	// bump_role が未設定の会議室は管理者のみ優先確保できる
+	manager := &domain.User{ID: uuid.New(), Role: domain.RoleManager, IsActive: true}
+	room := newBumpRoom("Room A", 8, nil)
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+
+	_, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    manager.ID,
+		ResourceID: room.ID,
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Reason:     "Client visit",
+	})
+
+	assert.ErrorIs(t, err, service.ErrBumpForbidden)
+	mockReservationRepo.AssertNotCalled(t, "ListOverlappingInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBumpService_Bump_SecretaryForOtherExecutive(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	ctx := context.Background()
+
+	// 秘書の担当役員は別のユーザー
+	otherExecutiveID := uuid.New()
+	executive := &domain.User{ID: uuid.New(), Role: domain.RoleManager, IsActive: true}
+	secretary := &domain.User{ID: uuid.New(), Role: domain.RoleSecretary, ManagerID: &otherExecutiveID, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, secretary.ID).Return(secretary, nil)
+	mockUserRepo.On("GetByID", ctx, executive.ID).Return(executive, nil)
+
+	_, err := svc.Bump(ctx, &service.BumpRequest{
+		ActorID:    secretary.ID,
+		OnBehalfOf: &executive.ID,
+		ResourceID: uuid.New(),
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Reason:     "Executive committee",
+	})
+
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
+func TestBumpService_Bump_ReasonRequired(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewBumpService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		mockBumpRepo, notificationService, reservationService)
+
+	startAt := time.Now().Add(24 * time.Hour)
+
+	_, err := svc.Bump(context.Background(), &service.BumpRequest{
+		ActorID:    uuid.New(),
+		ResourceID: uuid.New(),
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Reason:     "  ",
+	})
+
+	assert.ErrorIs(t, err, service.ErrBumpReasonRequired)
+}
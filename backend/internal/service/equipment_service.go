@@ -0,0 +1,540 @@
+// backend/internal/service/equipment_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/queue"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrNotEquipment           = errors.New("resource is not equipment")
+	ErrAssetNotInPool         = errors.New("asset does not belong to the reserved equipment")
+	ErrAssetUnavailable       = errors.New("asset is inactive or assigned to another reservation")
+	ErrTooManyAssets          = errors.New("more assets than reserved units")
+	ErrDuplicateAssetTag      = errors.New("asset tag is specified more than once")
+	ErrAssetTagsRequired      = errors.New("at least one asset tag is required")
+	ErrNoReservationInstances = errors.New("reservation has no instances")
+	ErrAssetNotAssigned       = errors.New("asset is not assigned to the reservation")
+	ErrAssetCheckedOut        = errors.New("asset is already checked out")
+	ErrAssetNotCheckedOut     = errors.New("asset is not checked out")
+	ErrReservationNotReady    = errors.New("reservation is not confirmed or has no upcoming instance")
+)
+
+// EquipmentService はプール型備品の数量・個体管理と貸出・返却に関するビジネスロジックを提供します
+type EquipmentService struct {
+	resourceRepo        repository.ResourceRepository
+	reservationRepo     repository.ReservationRepository
+	assetRepo           repository.AssetRepository
+	checkoutRepo        repository.CheckoutRepository
+	userRepo            repository.UserRepository
+	auditLogRepo        repository.AuditLogRepository
+	notificationService *NotificationService
+	jobQueue            queue.JobQueue
+}
+
+// NewEquipmentService は新しいEquipmentServiceを作成します
+// notificationService が nil の場合、返却遅延の通知は送信されません
+// jobQueue が nil の場合、早期返却で解放した枠のウェイトリスト繰り上げは行いません
+func NewEquipmentService(
+	resourceRepo repository.ResourceRepository,
+	reservationRepo repository.ReservationRepository,
+	assetRepo repository.AssetRepository,
+	checkoutRepo repository.CheckoutRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	notificationService *NotificationService,
+	jobQueue queue.JobQueue,
+) *EquipmentService {
+	return &EquipmentService{
+		resourceRepo:        resourceRepo,
+		reservationRepo:     reservationRepo,
+		assetRepo:           assetRepo,
+		checkoutRepo:        checkoutRepo,
+		userRepo:            userRepo,
+		auditLogRepo:        auditLogRepo,
+		notificationService: notificationService,
+		jobQueue:            jobQueue,
+	}
+}
+
+// EquipmentAvailability は指定期間におけるリソースの空き数量
+type EquipmentAvailability struct {
+	ResourceID     uuid.UUID
+	Quantity       int
+	AvailableUnits int
+	StartAt        time.Time
+	EndAt          time.Time
+}
+
+// GetAvailability は指定期間におけるリソースの空き数量を取得します
+func (s *EquipmentService) GetAvailability(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (*EquipmentAvailability, error) {
+	if !startAt.Before(endAt) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	resource, err := s.resourceRepo.GetByID(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+
+	available, err := s.resourceRepo.AvailableUnits(ctx, resourceID, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available units: %w", err)
+	}
+
+	return &EquipmentAvailability{
+		ResourceID:     resource.ID,
+		Quantity:       resource.TotalUnits(),
+		AvailableUnits: available,
+		StartAt:        startAt,
+		EndAt:          endAt,
+	}, nil
+}
+
+// RegisterAsset は備品に個体（資産タグ）を登録します。管理者のみ実行できます
+func (s *EquipmentService) RegisterAsset(ctx context.Context, actorID, resourceID uuid.UUID, assetTag, notes string) (*domain.Asset, error) {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !actor.IsAdmin() {
+		return nil, ErrUnauthorized
+	}
+
+	resource, err := s.resourceRepo.GetByID(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	if !resource.IsEquipment() {
+		return nil, ErrNotEquipment
+	}
+
+	now := time.Now()
+	asset := &domain.Asset{
+		ID:         uuid.New(),
+		ResourceID: resourceID,
+		AssetTag:   strings.TrimSpace(assetTag),
+		IsActive:   true,
+		Notes:      notes,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := asset.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.assetRepo.Create(ctx, asset); err != nil {
+		return nil, fmt.Errorf("failed to create asset: %w", err)
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionAssetRegister,
+		TargetType: "resource",
+		TargetID:   resourceID.String(),
+		Details: map[string]interface{}{
+			"asset_id":  asset.ID.String(),
+			"asset_tag": asset.AssetTag,
+		},
+		CreatedAt: now,
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+
+	return asset, nil
+}
+
+// ListAssets は備品に登録された個体の一覧を取得します
+func (s *EquipmentService) ListAssets(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error) {
+	assets, err := s.assetRepo.ListByResource(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	return assets, nil
+}
+
+// AssignAssets は予約に備品の個体を資産タグで割り当てます
+// 予約者本人または管理者のみ実行でき、割り当て数は備品ごとの予約数量を超えられません
+func (s *EquipmentService) AssignAssets(ctx context.Context, reservationID uuid.UUID, startAt time.Time, actorID uuid.UUID, assetTags []string) ([]*domain.ReservationAsset, error) {
+	if len(assetTags) == 0 {
+		return nil, ErrAssetTagsRequired
+	}
+
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID, startAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	if reservation.OrganizerID != actorID {
+		actor, err := s.userRepo.GetByID(ctx, actorID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !actor.IsAdmin() {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	units, err := s.reservationRepo.GetResourceUnits(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation units: %w", err)
+	}
+
+	instances, err := s.reservationRepo.GetInstancesByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation instances: %w", err)
+	}
+	spanStart, spanEnd, ok := domain.InstancesSpan(instances)
+	if !ok {
+		return nil, ErrNoReservationInstances
+	}
+
+	existing, err := s.assetRepo.ListAssignments(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset assignments: %w", err)
+	}
+	assigned := make(map[uuid.UUID]int)
+	seen := make(map[uuid.UUID]bool)
+	for _, a := range existing {
+		assigned[a.ResourceID]++
+		seen[a.AssetID] = true
+	}
+
+	now := time.Now()
+	assignments := make([]*domain.ReservationAsset, 0, len(assetTags))
+	for _, tag := range assetTags {
+		asset, err := s.assetRepo.GetByTag(ctx, strings.TrimSpace(tag))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get asset %q: %w", tag, err)
+		}
+		if seen[asset.ID] {
+			return nil, ErrDuplicateAssetTag
+		}
+		seen[asset.ID] = true
+
+		reserved, ok := units[asset.ResourceID]
+		if !ok {
+			return nil, ErrAssetNotInPool
+		}
+		if !asset.IsActive {
+			return nil, ErrAssetUnavailable
+		}
+		busy, err := s.assetRepo.IsAssignedInPeriod(ctx, asset.ID, spanStart, spanEnd, reservationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check asset assignment: %w", err)
+		}
+		if busy {
+			return nil, ErrAssetUnavailable
+		}
+
+		assigned[asset.ResourceID]++
+		if assigned[asset.ResourceID] > reserved {
+			return nil, ErrTooManyAssets
+		}
+
+		assignments = append(assignments, &domain.ReservationAsset{
+			ReservationID:      reservation.ID,
+			ReservationStartAt: reservation.StartAt,
+			AssetID:            asset.ID,
+			AssetTag:           asset.AssetTag,
+			ResourceID:         asset.ResourceID,
+			AssignedBy:         &actorID,
+			AssignedAt:         now,
+		})
+	}
+
+	if err := s.assetRepo.AssignToReservation(ctx, assignments); err != nil {
+		return nil, fmt.Errorf("failed to assign assets: %w", err)
+	}
+
+	tags := make([]string, len(assignments))
+	for i, a := range assignments {
+		tags[i] = a.AssetTag
+	}
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionAssetAssign,
+		TargetType: "reservation",
+		TargetID:   reservationID.String(),
+		Details: map[string]interface{}{
+			"asset_tags": tags,
+		},
+		CreatedAt: now,
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+
+	return assignments, nil
+}
+
+// CheckoutRequest は備品の貸出リクエスト
+type CheckoutRequest struct {
+	ReservationID uuid.UUID
+	StartAt       time.Time // 予約の開始日時（パーティションキー）
+	Condition     domain.AssetCondition
+	Notes         string
+}
+
+// Checkout は予約に割り当てられた備品の個体の持ち出しを記録します
+// 予約者本人または管理者のみ実行でき、返却期限は利用中または次回の予約インスタンスの終了日時になります
+func (s *EquipmentService) Checkout(ctx context.Context, actorID uuid.UUID, assetTag string, req *CheckoutRequest) (*domain.EquipmentCheckout, error) {
+	if !req.Condition.IsValid() {
+		return nil, domain.ErrInvalidAssetCondition
+	}
+
+	asset, err := s.assetRepo.GetByTag(ctx, strings.TrimSpace(assetTag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+	if !asset.IsActive {
+		return nil, ErrAssetUnavailable
+	}
+
+	reservation, err := s.reservationRepo.GetByID(ctx, req.ReservationID, req.StartAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if err := s.authorizeOwnerOrAdmin(ctx, actorID, reservation.OrganizerID); err != nil {
+		return nil, err
+	}
+	if reservation.ApprovalStatus != domain.ApprovalStatusConfirmed {
+		return nil, ErrReservationNotReady
+	}
+
+	assignments, err := s.assetRepo.ListAssignments(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list asset assignments: %w", err)
+	}
+	assigned := false
+	for _, a := range assignments {
+		if a.AssetID == asset.ID {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		return nil, ErrAssetNotAssigned
+	}
+
+	if _, err := s.checkoutRepo.GetOpenByAsset(ctx, asset.ID); err == nil {
+		return nil, ErrAssetCheckedOut
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get open checkout: %w", err)
+	}
+
+	now := time.Now()
+	instances, err := s.reservationRepo.GetInstancesByReservationID(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation instances: %w", err)
+	}
+	instance := currentOrNextInstance(instances, now)
+	if instance == nil {
+		return nil, ErrReservationNotReady
+	}
+
+	checkout := &domain.EquipmentCheckout{
+		ID:                    uuid.New(),
+		AssetID:               asset.ID,
+		AssetTag:              asset.AssetTag,
+		ReservationID:         reservation.ID,
+		ReservationStartAt:    reservation.StartAt,
+		ReservationInstanceID: instance.ID,
+		BorrowerID:            reservation.OrganizerID,
+		CheckedOutBy:          actorID,
+		CheckedOutAt:          now,
+		CheckoutCondition:     req.Condition,
+		CheckoutNotes:         req.Notes,
+		DueAt:                 instance.EndAt,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	if err := checkout.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkoutRepo.Create(ctx, checkout); err != nil {
+		return nil, fmt.Errorf("failed to create checkout: %w", err)
+	}
+
+	s.recordCheckoutAudit(ctx, actorID, domain.AuditActionEquipmentCheckout, checkout, map[string]interface{}{
+		"condition": string(checkout.CheckoutCondition),
+		"due_at":    checkout.DueAt.Format(time.RFC3339),
+	})
+
+	return checkout, nil
+}
+
+// Return は備品の個体の返却を記録します。借用者本人または管理者のみ実行できます
+// 返却期限より前に予約インスタンスの同じ備品の全個体が返却された場合、その備品の残りの時間を空きとして解放します
+func (s *EquipmentService) Return(ctx context.Context, actorID uuid.UUID, assetTag string, condition domain.AssetCondition, damageNotes string) (*domain.EquipmentCheckout, error) {
+	asset, err := s.assetRepo.GetByTag(ctx, strings.TrimSpace(assetTag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	checkout, err := s.checkoutRepo.GetOpenByAsset(ctx, asset.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAssetNotCheckedOut
+		}
+		return nil, fmt.Errorf("failed to get open checkout: %w", err)
+	}
+	if err := s.authorizeOwnerOrAdmin(ctx, actorID, checkout.BorrowerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := checkout.Return(actorID, now, condition, strings.TrimSpace(damageNotes)); err != nil {
+		return nil, err
+	}
+	if err := s.checkoutRepo.Update(ctx, checkout); err != nil {
+		return nil, fmt.Errorf("failed to update checkout: %w", err)
+	}
+
+	if checkout.IsReturnedEarly() {
+		if err := s.releaseEarly(ctx, checkout.ReservationInstanceID, asset.ResourceID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	details := map[string]interface{}{
+		"condition": string(checkout.ReturnCondition),
+		"overdue":   now.After(checkout.DueAt),
+	}
+	if checkout.DamageNotes != "" {
+		details["damage_notes"] = checkout.DamageNotes
+	}
+	s.recordCheckoutAudit(ctx, actorID, domain.AuditActionEquipmentReturn, checkout, details)
+
+	return checkout, nil
+}
+
+// releaseEarly は予約インスタンスで貸し出した備品が全て返却されていれば、その備品の割り当てを解放します
+// 同じインスタンスで予約した会議室などの他のリソースは解放せず、解放した枠はウェイトリストへ繰り上げます
+func (s *EquipmentService) releaseEarly(ctx context.Context, instanceID, resourceID uuid.UUID, now time.Time) error {
+	open, err := s.checkoutRepo.CountOpenByInstance(ctx, instanceID, resourceID)
+	if err != nil {
+		return fmt.Errorf("failed to count open checkouts: %w", err)
+	}
+	if open > 0 {
+		return nil
+	}
+
+	endAt, err := s.reservationRepo.ReleaseInstanceResource(ctx, instanceID, resourceID, now)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to release reservation resource: %w", err)
+	}
+
+	enqueueWaitlistPromotionJobs(ctx, s.jobQueue, []releasedSlot{{resourceID: resourceID, startAt: now, endAt: endAt}})
+	return nil
+}
+
+// ListCheckoutHistory は個体の貸出・返却履歴を新しい順に取得します
+func (s *EquipmentService) ListCheckoutHistory(ctx context.Context, assetTag string) ([]*domain.EquipmentCheckout, error) {
+	asset, err := s.assetRepo.GetByTag(ctx, strings.TrimSpace(assetTag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	checkouts, err := s.checkoutRepo.ListByAsset(ctx, asset.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkouts: %w", err)
+	}
+	return checkouts, nil
+}
+
+// ProcessOverdue は返却期限を過ぎた未返却の貸出を検出し、借用者へ通知します
+// 各貸出の通知は1度だけ行われ、処理した件数を返します
+func (s *EquipmentService) ProcessOverdue(ctx context.Context, now time.Time) (int, error) {
+	checkouts, err := s.checkoutRepo.ListOverdue(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list overdue checkouts: %w", err)
+	}
+
+	processed := 0
+	var errs []error
+	for _, checkout := range checkouts {
+		if s.notificationService != nil {
+			if borrower, err := s.userRepo.GetByID(ctx, checkout.BorrowerID); err == nil {
+				_ = s.notificationService.NotifyEquipmentOverdue(ctx, checkout, borrower)
+			}
+		}
+
+		notifiedAt := now
+		checkout.OverdueNotifiedAt = &notifiedAt
+		if err := s.checkoutRepo.Update(ctx, checkout); err != nil {
+			errs = append(errs, fmt.Errorf("failed to mark checkout %s as notified: %w", checkout.ID, err))
+			continue
+		}
+		processed++
+
+		s.recordCheckoutAudit(ctx, domain.SystemUserID, domain.AuditActionEquipmentOverdue, checkout, map[string]interface{}{
+			"borrower_id": checkout.BorrowerID.String(),
+			"due_at":      checkout.DueAt.Format(time.RFC3339),
+		})
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// authorizeOwnerOrAdmin は操作者が対象ユーザー本人または管理者かを確認します
+func (s *EquipmentService) authorizeOwnerOrAdmin(ctx context.Context, actorID, ownerID uuid.UUID) error {
+	if actorID == ownerID {
+		return nil
+	}
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !actor.IsAdmin() {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// recordCheckoutAudit は貸出・返却に関する監査ログを記録します
+func (s *EquipmentService) recordCheckoutAudit(ctx context.Context, actorID uuid.UUID, action domain.AuditAction, checkout *domain.EquipmentCheckout, details map[string]interface{}) {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["asset_tag"] = checkout.AssetTag
+	details["reservation_id"] = checkout.ReservationID.String()
+
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     action,
+		TargetType: "equipment_checkout",
+		TargetID:   checkout.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
+
+// currentOrNextInstance は利用中または次に開始する有効な予約インスタンスを返します
+// instances は開始日時の昇順であることを前提とします
+func currentOrNextInstance(instances []*domain.ReservationInstance, now time.Time) *domain.ReservationInstance {
+	for _, instance := range instances {
+		switch instance.Status {
+		case domain.ReservationStatusConfirmed, domain.ReservationStatusCheckedIn:
+		default:
+			continue
+		}
+		if instance.EndAt.After(now) {
+			return instance
+		}
+	}
+	return nil
+}
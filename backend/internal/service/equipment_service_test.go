@@ -0,0 +1,306 @@
+// backend/internal/service/equipment_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestEquipmentService_GetAvailability(t *testing.T) {
+	mockResourceRepo := new(MockResourceRepository)
+	svc := service.NewEquipmentService(mockResourceRepo, new(MockReservationRepository), new(MockAssetRepository), new(MockCheckoutRepository), new(MockUserRepository), new(MockAuditLogRepository), nil, nil)
+
+	ctx := context.Background()
+	resource := &domain.Resource{ID: uuid.New(), Name: "Laptop", Type: domain.ResourceTypeEquipment, Quantity: 5, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+
+	mockResourceRepo.On("GetByID", ctx, resource.ID).Return(resource, nil)
+	mockResourceRepo.On("AvailableUnits", ctx, resource.ID, startAt, endAt).Return(2, nil)
+
+	availability, err := svc.GetAvailability(ctx, resource.ID, startAt, endAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, availability.Quantity)
+	assert.Equal(t, 2, availability.AvailableUnits)
+}
+
+func TestEquipmentService_AssignAssets(t *testing.T) {
+	ctx := context.Background()
+	organizerID := uuid.New()
+	laptopID := uuid.New()
+	reservationID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+
+	reservation := &domain.Reservation{ID: reservationID, OrganizerID: organizerID, StartAt: startAt, EndAt: endAt}
+	instances := []*domain.ReservationInstance{{ID: uuid.New(), ReservationID: reservationID, StartAt: startAt, EndAt: endAt}}
+	lt001 := &domain.Asset{ID: uuid.New(), ResourceID: laptopID, AssetTag: "LT-001", IsActive: true}
+	lt002 := &domain.Asset{ID: uuid.New(), ResourceID: laptopID, AssetTag: "LT-002", IsActive: true}
+
+	tests := []struct {
+		name      string
+		tags      []string
+		units     int
+		busy      bool
+		wantErr   error
+		wantCount int
+	}{
+		{name: "Assigns within reserved units", tags: []string{"LT-001", "LT-002"}, units: 2, wantCount: 2},
+		{name: "More assets than reserved units", tags: []string{"LT-001", "LT-002"}, units: 1, wantErr: service.ErrTooManyAssets},
+		{name: "Asset already assigned elsewhere", tags: []string{"LT-001"}, units: 2, busy: true, wantErr: service.ErrAssetUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			svc := service.NewEquipmentService(new(MockResourceRepository), mockReservationRepo, mockAssetRepo, new(MockCheckoutRepository), new(MockUserRepository), mockAuditLogRepo, nil, nil)
+
+			mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+			mockReservationRepo.On("GetResourceUnits", ctx, reservationID).Return(map[uuid.UUID]int{laptopID: tt.units}, nil)
+			mockReservationRepo.On("GetInstancesByReservationID", ctx, reservationID).Return(instances, nil)
+			mockAssetRepo.On("ListAssignments", ctx, reservationID).Return([]*domain.ReservationAsset{}, nil)
+			mockAssetRepo.On("GetByTag", ctx, "LT-001").Return(lt001, nil)
+			mockAssetRepo.On("GetByTag", ctx, "LT-002").Return(lt002, nil)
+			mockAssetRepo.On("IsAssignedInPeriod", ctx, mock.Anything, startAt, endAt, reservationID).Return(tt.busy, nil)
+			mockAssetRepo.On("AssignToReservation", ctx, mock.AnythingOfType("[]*domain.ReservationAsset")).Return(nil)
+			mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			assignments, err := svc.AssignAssets(ctx, reservationID, startAt, organizerID, tt.tags)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockAssetRepo.AssertNotCalled(t, "AssignToReservation", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, assignments, tt.wantCount)
+			assert.Equal(t, "LT-001", assignments[0].AssetTag)
+		})
+	}
+}
+
+func TestEquipmentService_AssignAssets_NotOrganizer(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewEquipmentService(new(MockResourceRepository), mockReservationRepo, new(MockAssetRepository), new(MockCheckoutRepository), mockUserRepo, new(MockAuditLogRepository), nil, nil)
+
+	ctx := context.Background()
+	reservationID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	other := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+
+	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(&domain.Reservation{ID: reservationID, OrganizerID: uuid.New(), StartAt: startAt}, nil)
+	mockUserRepo.On("GetByID", ctx, other.ID).Return(other, nil)
+
+	_, err := svc.AssignAssets(ctx, reservationID, startAt, other.ID, []string{"LT-001"})
+
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
+func TestEquipmentService_Checkout(t *testing.T) {
+	ctx := context.Background()
+	organizerID := uuid.New()
+	reservationID := uuid.New()
+	startAt := time.Now().Add(-30 * time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+
+	reservation := &domain.Reservation{ID: reservationID, OrganizerID: organizerID, StartAt: startAt, EndAt: endAt, ApprovalStatus: domain.ApprovalStatusConfirmed}
+	instance := &domain.ReservationInstance{ID: uuid.New(), ReservationID: reservationID, StartAt: startAt, EndAt: endAt, Status: domain.ReservationStatusConfirmed}
+	projector := &domain.Asset{ID: uuid.New(), ResourceID: uuid.New(), AssetTag: "PJ-001", IsActive: true}
+
+	tests := []struct {
+		name        string
+		assignments []*domain.ReservationAsset
+		openErr     error
+		wantErr     error
+	}{
+		{
+			name:        "Checks out assigned asset",
+			assignments: []*domain.ReservationAsset{{ReservationID: reservationID, AssetID: projector.ID}},
+			openErr:     repository.ErrNotFound,
+		},
+		{
+			name:        "Asset not assigned to reservation",
+			assignments: []*domain.ReservationAsset{},
+			wantErr:     service.ErrAssetNotAssigned,
+		},
+		{
+			name:        "Asset already checked out",
+			assignments: []*domain.ReservationAsset{{ReservationID: reservationID, AssetID: projector.ID}},
+			wantErr:     service.ErrAssetCheckedOut,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockCheckoutRepo := new(MockCheckoutRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			svc := service.NewEquipmentService(new(MockResourceRepository), mockReservationRepo, mockAssetRepo, mockCheckoutRepo, new(MockUserRepository), mockAuditLogRepo, nil, nil)
+
+			mockAssetRepo.On("GetByTag", ctx, "PJ-001").Return(projector, nil)
+			mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+			mockAssetRepo.On("ListAssignments", ctx, reservationID).Return(tt.assignments, nil)
+			if tt.openErr != nil {
+				mockCheckoutRepo.On("GetOpenByAsset", ctx, projector.ID).Return(nil, tt.openErr)
+			} else {
+				mockCheckoutRepo.On("GetOpenByAsset", ctx, projector.ID).Return(&domain.EquipmentCheckout{ID: uuid.New()}, nil)
+			}
+			mockReservationRepo.On("GetInstancesByReservationID", ctx, reservationID).Return([]*domain.ReservationInstance{instance}, nil)
+			mockCheckoutRepo.On("Create", ctx, mock.AnythingOfType("*domain.EquipmentCheckout")).Return(nil)
+			mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			checkout, err := svc.Checkout(ctx, organizerID, "PJ-001", &service.CheckoutRequest{
+				ReservationID: reservationID,
+				StartAt:       startAt,
+				Condition:     domain.AssetConditionGood,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockCheckoutRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, organizerID, checkout.BorrowerID)
+			assert.Equal(t, instance.ID, checkout.ReservationInstanceID)
+			assert.Equal(t, endAt, checkout.DueAt)
+		})
+	}
+}
+
+func TestEquipmentService_Return_ReleasesEarly(t *testing.T) {
+	ctx := context.Background()
+	borrowerID := uuid.New()
+	instanceID := uuid.New()
+	instanceEndAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	projector := &domain.Asset{ID: uuid.New(), ResourceID: uuid.New(), AssetTag: "PJ-001", IsActive: true}
+
+	tests := []struct {
+		name        string
+		dueAt       time.Time
+		openCount   int
+		releaseErr  error
+		wantRelease bool
+		wantPromote bool
+	}{
+		{name: "Last asset returned early", dueAt: instanceEndAt, openCount: 0, wantRelease: true, wantPromote: true},
+		{name: "Other assets still out", dueAt: instanceEndAt, openCount: 1},
+		{name: "Returned after due", dueAt: time.Now().Add(-time.Hour)},
+		{name: "Already released", dueAt: instanceEndAt, openCount: 0, releaseErr: repository.ErrNotFound, wantRelease: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockAssetRepo := new(MockAssetRepository)
+			mockCheckoutRepo := new(MockCheckoutRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockJobQueue := new(MockJobQueue)
+			svc := service.NewEquipmentService(new(MockResourceRepository), mockReservationRepo, mockAssetRepo, mockCheckoutRepo, new(MockUserRepository), mockAuditLogRepo, nil, mockJobQueue)
+
+			checkout := &domain.EquipmentCheckout{
+				ID:                    uuid.New(),
+				AssetID:               projector.ID,
+				AssetTag:              projector.AssetTag,
+				ReservationInstanceID: instanceID,
+				BorrowerID:            borrowerID,
+				CheckedOutAt:          time.Now().Add(-2 * time.Hour),
+				CheckoutCondition:     domain.AssetConditionGood,
+				DueAt:                 tt.dueAt,
+			}
+
+			mockAssetRepo.On("GetByTag", ctx, "PJ-001").Return(projector, nil)
+			mockCheckoutRepo.On("GetOpenByAsset", ctx, projector.ID).Return(checkout, nil)
+			mockCheckoutRepo.On("Update", ctx, checkout).Return(nil)
+			// 同じ回の同じ備品の貸出だけを数える（会議室などの他のリソースは解放しない）
+			mockCheckoutRepo.On("CountOpenByInstance", ctx, instanceID, projector.ResourceID).Return(tt.openCount, nil)
+			mockReservationRepo.On("ReleaseInstanceResource", ctx, instanceID, projector.ResourceID, mock.AnythingOfType("time.Time")).Return(instanceEndAt, tt.releaseErr)
+			mockJobQueue.On("Enqueue", ctx, "waitlist_promote", mock.Anything).Return("job-1", nil)
+			mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			returned, err := svc.Return(ctx, borrowerID, "PJ-001", domain.AssetConditionDamaged, "Cracked lens")
+
+			assert.NoError(t, err)
+			assert.False(t, returned.IsOpen())
+			assert.Equal(t, "Cracked lens", returned.DamageNotes)
+			if tt.wantRelease {
+				mockReservationRepo.AssertCalled(t, "ReleaseInstanceResource", ctx, instanceID, projector.ResourceID, *returned.ReturnedAt)
+			} else {
+				mockReservationRepo.AssertNotCalled(t, "ReleaseInstanceResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantPromote {
+				mockJobQueue.AssertCalled(t, "Enqueue", ctx, "waitlist_promote", map[string]interface{}{
+					"resource_id": projector.ResourceID.String(),
+					"start_at":    returned.ReturnedAt.Format(time.RFC3339),
+					"end_at":      instanceEndAt.Format(time.RFC3339),
+				})
+			} else {
+				mockJobQueue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEquipmentService_Return_NotCheckedOut(t *testing.T) {
+	mockAssetRepo := new(MockAssetRepository)
+	mockCheckoutRepo := new(MockCheckoutRepository)
+	svc := service.NewEquipmentService(new(MockResourceRepository), new(MockReservationRepository), mockAssetRepo, mockCheckoutRepo, new(MockUserRepository), new(MockAuditLogRepository), nil, nil)
+
+	ctx := context.Background()
+	projector := &domain.Asset{ID: uuid.New(), AssetTag: "PJ-001", IsActive: true}
+
+	mockAssetRepo.On("GetByTag", ctx, "PJ-001").Return(projector, nil)
+	mockCheckoutRepo.On("GetOpenByAsset", ctx, projector.ID).Return(nil, repository.ErrNotFound)
+
+	_, err := svc.Return(ctx, uuid.New(), "PJ-001", domain.AssetConditionGood, "")
+
+	assert.ErrorIs(t, err, service.ErrAssetNotCheckedOut)
+}
+
+func TestEquipmentService_ProcessOverdue(t *testing.T) {
+	mockCheckoutRepo := new(MockCheckoutRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewEquipmentService(new(MockResourceRepository), new(MockReservationRepository), new(MockAssetRepository), mockCheckoutRepo, mockUserRepo, mockAuditLogRepo, notificationService, nil)
+
+	ctx := context.Background()
+	now := time.Now()
+	borrower := &domain.User{ID: uuid.New(), Email: "borrower@example.com", IsActive: true}
+	checkout := &domain.EquipmentCheckout{
+		ID:           uuid.New(),
+		AssetTag:     "PJ-001",
+		BorrowerID:   borrower.ID,
+		CheckedOutAt: now.Add(-3 * time.Hour),
+		DueAt:        now.Add(-time.Hour),
+	}
+
+	mockCheckoutRepo.On("ListOverdue", ctx, now).Return([]*domain.EquipmentCheckout{checkout}, nil)
+	mockUserRepo.On("GetByID", ctx, borrower.ID).Return(borrower, nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(payload map[string]interface{}) bool {
+		return payload["to"] == borrower.Email
+	})).Return("job-1", nil)
+	mockCheckoutRepo.On("Update", ctx, checkout).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	processed, err := svc.ProcessOverdue(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.NotNil(t, checkout.OverdueNotifiedAt)
+	mockJobQueue.AssertExpectations(t)
+}
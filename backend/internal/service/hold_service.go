@@ -0,0 +1,417 @@
+// backend/internal/service/hold_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrHoldWithoutResources = errors.New("at least one resource is required to place a hold")
+	ErrTooManyHolds         = errors.New("user has reached the maximum number of active holds")
+	ErrHoldInactive         = errors.New("hold is no longer active")
+	ErrHoldExpired          = errors.New("hold has expired")
+)
+
+// DefaultHoldDuration は予約フォーム入力中の仮押さえの既定の有効期間
+const DefaultHoldDuration = 5 * time.Minute
+
+// MaxActiveHoldsPerUser はユーザーが同時に持てる仮押さえの上限（枠の買い占めを防ぐ）
+const MaxActiveHoldsPerUser = 3
+
+// holdTitle は仮押さえ予約の件名
+const holdTitle = "Hold"
+
+// HoldService は予約フォーム入力中の仮押さえに関するビジネスロジックを提供します
+// 仮押さえは TENTATIVE のインスタンスを持つ予約として枠を占有するため、空き状況の確認では予約済みとして扱われます
+type HoldService struct {
+	holdRepo           repository.HoldRepository
+	reservationRepo    repository.ReservationRepository
+	resourceRepo       repository.ResourceRepository
+	userRepo           repository.UserRepository
+	auditLogRepo       repository.AuditLogRepository
+	reservationService *ReservationService
+	holdDuration       time.Duration
+}
+
+// NewHoldService は新しいHoldServiceを作成します
+// holdDuration が0以下の場合は DefaultHoldDuration を使用します
+func NewHoldService(
+	holdRepo repository.HoldRepository,
+	reservationRepo repository.ReservationRepository,
+	resourceRepo repository.ResourceRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	reservationService *ReservationService,
+	holdDuration time.Duration,
+) *HoldService {
+	if holdDuration <= 0 {
+		holdDuration = DefaultHoldDuration
+	}
+	return &HoldService{
+		holdRepo:           holdRepo,
+		reservationRepo:    reservationRepo,
+		resourceRepo:       resourceRepo,
+		userRepo:           userRepo,
+		auditLogRepo:       auditLogRepo,
+		reservationService: reservationService,
+		holdDuration:       holdDuration,
+	}
+}
+
+// PlaceHoldRequest は仮押さえリクエスト
+type PlaceHoldRequest struct {
+	UserID      uuid.UUID
+	ResourceIDs []uuid.UUID
+	Units       map[uuid.UUID]int // プール型備品の数量（未指定のリソースは1）
+	StartAt     time.Time
+	EndAt       time.Time
+	Timezone    string
+}
+
+// PlaceHold はリソースと時間帯を短時間だけ仮押さえします
+// 有効期限までに ConvertHold で予約に変換しない場合、ワーカーが ExpireHolds で解放します
+func (s *HoldService) PlaceHold(ctx context.Context, req *PlaceHoldRequest) (*domain.ResourceHold, error) {
+	if !req.StartAt.Before(req.EndAt) {
+		return nil, ErrInvalidTimeRange
+	}
+	if len(req.ResourceIDs) == 0 {
+		return nil, ErrHoldWithoutResources
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	active, err := s.holdRepo.ListActiveByUser(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active holds: %w", err)
+	}
+	if len(active) >= MaxActiveHoldsPerUser {
+		return nil, ErrTooManyHolds
+	}
+
+	// リソース存在確認と権限チェック
+	units := make(map[uuid.UUID]int, len(req.ResourceIDs))
+	resources := make([]*domain.Resource, 0, len(req.ResourceIDs))
+	for _, resourceID := range req.ResourceIDs {
+		resource, err := s.resourceRepo.GetByID(ctx, resourceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource: %w", err)
+		}
+		if !resource.CanBeReservedBy(user) {
+			return nil, ErrUnauthorized
+		}
+		resources = append(resources, resource)
+		if n, ok := req.Units[resourceID]; ok {
+			if n < 1 {
+				return nil, ErrInvalidUnits
+			}
+			if n > resource.TotalUnits() {
+				return nil, ErrInsufficientUnits
+			}
+			units[resourceID] = n
+		}
+	}
+
+	// 予約と同じ空き状況の確認を行う（他の仮押さえも予約済みとして扱われる）
+	unavailable, shortOfUnits, err := s.reservationService.unavailableResources(ctx, req.ResourceIDs, units, req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(unavailable) > 0 {
+		if shortOfUnits {
+			return nil, ErrInsufficientUnits
+		}
+		return nil, ErrResourceNotAvailable
+	}
+
+	now := time.Now()
+	reservation := &domain.Reservation{
+		ID:             uuid.New(),
+		OrganizerID:    req.UserID,
+		Title:          holdTitle,
+		StartAt:        req.StartAt,
+		EndAt:          req.EndAt,
+		IsPrivate:      true,
+		Timezone:       req.Timezone,
+		ApprovalStatus: domain.ApprovalStatusHeld,
+		ResourceUnits:  units,
+		ResourceIDs:    req.ResourceIDs,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	instance := &domain.ReservationInstance{
+		ID:                 uuid.New(),
+		ReservationID:      reservation.ID,
+		ReservationStartAt: reservation.StartAt,
+		StartAt:            reservation.StartAt,
+		EndAt:              reservation.EndAt,
+		Status:             domain.ReservationStatusTentative,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	// 予約できない枠（予約上限の超過・ペナルティスコアによる制限）は仮押さえもしない
+	// 上長の承認が必要な制限は予約への変換時に承認チェーンへ加える
+	if _, err := s.reservationService.checkBookingLimits(ctx, user, reservation, []*domain.ReservationInstance{instance}, resources); err != nil {
+		return nil, err
+	}
+
+	err = s.reservationRepo.CreateWithInstances(ctx, reservation, []*domain.ReservationInstance{instance}, req.ResourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hold reservation: %w", err)
+	}
+
+	hold := &domain.ResourceHold{
+		ID:            uuid.New(),
+		UserID:        req.UserID,
+		ReservationID: reservation.ID,
+		StartAt:       req.StartAt,
+		EndAt:         req.EndAt,
+		ExpiresAt:     now.Add(s.holdDuration),
+		Status:        domain.HoldStatusActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ResourceUnits: holdUnits(req.ResourceIDs, units),
+	}
+	if err := s.holdRepo.Create(ctx, hold); err != nil {
+		_ = s.reservationRepo.Delete(ctx, reservation.ID, reservation.StartAt)
+		return nil, fmt.Errorf("failed to create hold: %w", err)
+	}
+
+	s.recordAudit(ctx, req.UserID, domain.AuditActionHoldPlace, hold, map[string]interface{}{
+		"resources":  len(req.ResourceIDs),
+		"expires_at": hold.ExpiresAt,
+	})
+
+	return hold, nil
+}
+
+// ListMine はユーザーの有効な仮押さえを取得します
+func (s *HoldService) ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error) {
+	holds, err := s.holdRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holds: %w", err)
+	}
+	for _, hold := range holds {
+		if err := s.loadResourceUnits(ctx, hold); err != nil {
+			return nil, err
+		}
+	}
+	return holds, nil
+}
+
+// ConvertHold は仮押さえを予約に変換します
+// 予約の作成は ReservationService.CreateReservation と同じ検証を行い、仮押さえで確保済みのリソースは空きとして扱います
+// req のリソースが未指定の場合は仮押さえしたリソースを使用します
+func (s *HoldService) ConvertHold(ctx context.Context, holdID uuid.UUID, req *CreateReservationRequest) (*domain.Reservation, error) {
+	hold, err := s.getOwnedHold(ctx, holdID, req.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Status != domain.HoldStatusActive {
+		return nil, ErrHoldInactive
+	}
+	if !hold.IsActive(time.Now()) {
+		return nil, ErrHoldExpired
+	}
+	if err := s.checkHoldSlot(ctx, hold); err != nil {
+		return nil, err
+	}
+	if err := s.loadResourceUnits(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	if len(req.ResourceIDs) == 0 && req.Room == nil {
+		for resourceID, n := range hold.ResourceUnits {
+			req.ResourceIDs = append(req.ResourceIDs, resourceID)
+			if n > 1 {
+				if req.Units == nil {
+					req.Units = make(map[uuid.UUID]int)
+				}
+				req.Units[resourceID] = n
+			}
+		}
+	}
+	req.Hold = hold
+
+	reservation, err := s.reservationService.CreateReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 変換と期限切れの解放が競合した場合は、解放済みの枠に予約を作成しないよう取り消す
+	hold.Status = domain.HoldStatusConverted
+	hold.ConvertedReservationID = &reservation.ID
+	if err := s.holdRepo.Update(ctx, hold); err != nil {
+		_ = s.reservationRepo.Delete(ctx, reservation.ID, reservation.StartAt)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrHoldExpired
+		}
+		return nil, fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	// 予約が枠を占有したので仮押さえ予約を削除する
+	if err := s.reservationRepo.Delete(ctx, hold.ReservationID, hold.StartAt); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to release hold reservation: %w", err)
+	}
+
+	s.recordAudit(ctx, req.OrganizerID, domain.AuditActionHoldConvert, hold, map[string]interface{}{
+		"converted_reservation_id": reservation.ID.String(),
+		"approval":                 string(reservation.ApprovalStatus),
+	})
+
+	return reservation, nil
+}
+
+// ReleaseHold は仮押さえを解放します
+func (s *HoldService) ReleaseHold(ctx context.Context, holdID, userID uuid.UUID) error {
+	hold, err := s.getOwnedHold(ctx, holdID, userID)
+	if err != nil {
+		return err
+	}
+	if hold.Status != domain.HoldStatusActive {
+		return ErrHoldInactive
+	}
+
+	if err := s.closeHold(ctx, hold, domain.HoldStatusReleased); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, userID, domain.AuditActionHoldRelease, hold, nil)
+	return nil
+}
+
+// ExpireHolds は有効期限を過ぎた仮押さえを解放します
+// ワーカーから定期的に呼び出されることを想定しています。解放した件数を返します
+func (s *HoldService) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	holds, err := s.holdRepo.ListExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired holds: %w", err)
+	}
+
+	expired := 0
+	var errs []error
+	for _, hold := range holds {
+		if err := s.closeHold(ctx, hold, domain.HoldStatusExpired); err != nil {
+			// 期限切れ直前に変換・解放された仮押さえは飛ばす
+			if !errors.Is(err, ErrHoldInactive) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		expired++
+
+		s.recordAudit(ctx, domain.SystemUserID, domain.AuditActionHoldExpire, hold, nil)
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// closeHold は仮押さえを終了して仮押さえ予約を削除し、空いた枠をウェイトリストの登録者へ繰り上げます
+// 状態の更新を先に行い、変換と同時に処理された場合は ErrHoldInactive を返します
+func (s *HoldService) closeHold(ctx context.Context, hold *domain.ResourceHold, status domain.HoldStatus) error {
+	released, err := s.reservationService.releasedSlots(ctx, hold.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	hold.Status = status
+	if err := s.holdRepo.Update(ctx, hold); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrHoldInactive
+		}
+		return fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	err = s.reservationRepo.Delete(ctx, hold.ReservationID, hold.StartAt)
+	// 予約側で既に取り消されている場合はそのまま終了する
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to release hold reservation: %w", err)
+	}
+
+	s.reservationService.enqueueWaitlistPromotion(ctx, released)
+	return nil
+}
+
+// getOwnedHold は仮押さえを取得し、本人の仮押さえであることを確認します
+func (s *HoldService) getOwnedHold(ctx context.Context, holdID, userID uuid.UUID) (*domain.ResourceHold, error) {
+	hold, err := s.holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+	if hold.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return hold, nil
+}
+
+// checkHoldSlot は仮押さえ予約が枠を占有し続けているかを確認します
+// 会議室の優先確保で押しのけられた（インスタンスがキャンセルされた）仮押さえは変換できません
+func (s *HoldService) checkHoldSlot(ctx context.Context, hold *domain.ResourceHold) error {
+	instances, err := s.reservationRepo.GetInstancesByReservationID(ctx, hold.ReservationID)
+	if err != nil {
+		return fmt.Errorf("failed to get hold instances: %w", err)
+	}
+	for _, inst := range instances {
+		if inst.Status != domain.ReservationStatusTentative {
+			return ErrHoldInactive
+		}
+	}
+	return nil
+}
+
+// loadResourceUnits は仮押さえ予約に割り当てたリソースと数量を読み込みます
+func (s *HoldService) loadResourceUnits(ctx context.Context, hold *domain.ResourceHold) error {
+	units, err := s.reservationRepo.GetResourceUnits(ctx, hold.ReservationID)
+	if err != nil {
+		return fmt.Errorf("failed to get hold resources: %w", err)
+	}
+	hold.ResourceUnits = units
+	return nil
+}
+
+// holdUnits は仮押さえしたリソースごとの数量を返します（未指定のリソースは1）
+func holdUnits(resourceIDs []uuid.UUID, units map[uuid.UUID]int) map[uuid.UUID]int {
+	held := make(map[uuid.UUID]int, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		held[resourceID] = 1
+		if n, ok := units[resourceID]; ok {
+			held[resourceID] = n
+		}
+	}
+	return held
+}
+
+// recordAudit は仮押さえ操作の監査ログを記録します
+func (s *HoldService) recordAudit(ctx context.Context, userID uuid.UUID, action domain.AuditAction, hold *domain.ResourceHold, extra map[string]interface{}) {
+	details := map[string]interface{}{
+		"user_id":        hold.UserID.String(),
+		"reservation_id": hold.ReservationID.String(),
+		"start_at":       hold.StartAt,
+		"end_at":         hold.EndAt,
+		"status":         string(hold.Status),
+	}
+	for k, v := range extra {
+		details[k] = v
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		TargetType: "resource_hold",
+		TargetID:   hold.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
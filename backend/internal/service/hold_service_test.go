@@ -0,0 +1,445 @@
+// backend/internal/service/hold_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func newHoldRoom() *domain.Resource {
+	capacity := 8
+	return &domain.Resource{ID: uuid.New(), Name: "Room A", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+}
+
+func newActiveHold(userID uuid.UUID, startAt time.Time) *domain.ResourceHold {
+	return &domain.ResourceHold{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ReservationID: uuid.New(),
+		StartAt:       startAt,
+		EndAt:         startAt.Add(time.Hour),
+		ExpiresAt:     time.Now().Add(3 * time.Minute),
+		Status:        domain.HoldStatusActive,
+	}
+}
+
+func TestHoldService_PlaceHold_Success(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newHoldRoom()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockHoldRepo.On("ListActiveByUser", ctx, user.ID).Return([]*domain.ResourceHold{}, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{room}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx,
+		mock.MatchedBy(func(r *domain.Reservation) bool {
+			return r.ApprovalStatus == domain.ApprovalStatusHeld && r.OrganizerID == user.ID
+		}),
+		mock.MatchedBy(func(instances []*domain.ReservationInstance) bool {
+			return len(instances) == 1 && instances[0].Status == domain.ReservationStatusTentative
+		}),
+		[]uuid.UUID{room.ID},
+	).Return(nil)
+	mockHoldRepo.On("Create", ctx, mock.AnythingOfType("*domain.ResourceHold")).Return(nil)
+
+	before := time.Now()
+	hold, err := svc.PlaceHold(ctx, &service.PlaceHoldRequest{
+		UserID:      user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.HoldStatusActive, hold.Status)
+	assert.Equal(t, map[uuid.UUID]int{room.ID: 1}, hold.ResourceUnits)
+	// 既定の有効期間は5分
+	assert.WithinDuration(t, before.Add(service.DefaultHoldDuration), hold.ExpiresAt, time.Second)
+	mockReservationRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestHoldService_PlaceHold_NotAvailable(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newHoldRoom()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockHoldRepo.On("ListActiveByUser", ctx, user.ID).Return([]*domain.ResourceHold{}, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	// 他のユーザーの予約・仮押さえで埋まっている
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+
+	_, err := svc.PlaceHold(ctx, &service.PlaceHoldRequest{
+		UserID:      user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrResourceNotAvailable)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHoldService_PlaceHold_TooManyHolds(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+	active := make([]*domain.ResourceHold, service.MaxActiveHoldsPerUser)
+	for i := range active {
+		active[i] = newActiveHold(user.ID, startAt)
+	}
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockHoldRepo.On("ListActiveByUser", ctx, user.ID).Return(active, nil)
+
+	_, err := svc.PlaceHold(ctx, &service.PlaceHoldRequest{
+		UserID:      user.ID,
+		ResourceIDs: []uuid.UUID{uuid.New()},
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrTooManyHolds)
+}
+
+func heldInstances() []*domain.ReservationInstance {
+	return []*domain.ReservationInstance{{ID: uuid.New(), Status: domain.ReservationStatusTentative}}
+}
+
+func TestHoldService_ConvertHold_Success(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newHoldRoom()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+	hold := newActiveHold(user.ID, startAt)
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, hold.ReservationID).Return(heldInstances(), nil)
+	mockReservationRepo.On("GetResourceUnits", ctx, hold.ReservationID).Return(map[uuid.UUID]int{room.ID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	// 仮押さえ自体が枠を占有しているため空き一覧には含まれない
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx,
+		mock.MatchedBy(func(r *domain.Reservation) bool {
+			return r.ApprovalStatus == domain.ApprovalStatusConfirmed && r.Title == "Design review"
+		}),
+		mock.Anything,
+		[]uuid.UUID{room.ID},
+	).Return(nil)
+	mockHoldRepo.On("Update", ctx, mock.MatchedBy(func(h *domain.ResourceHold) bool {
+		return h.Status == domain.HoldStatusConverted && h.ConvertedReservationID != nil
+	})).Return(nil)
+	mockReservationRepo.On("Delete", ctx, hold.ReservationID, hold.StartAt).Return(nil)
+
+	// リソースを省略すると仮押さえしたリソースを予約する
+	reservation, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		Title:       "Design review",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, *hold.ConvertedReservationID, reservation.ID)
+	mockReservationRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestHoldService_ConvertHold_OutsideHeldTime(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newHoldRoom()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	hold := newActiveHold(user.ID, startAt)
+	endAt := hold.EndAt.Add(time.Hour)
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, hold.ReservationID).Return(heldInstances(), nil)
+	mockReservationRepo.On("GetResourceUnits", ctx, hold.ReservationID).Return(map[uuid.UUID]int{room.ID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+
+	// 仮押さえした時間帯を超える予約は通常どおり空き状況を確認する
+	_, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		Title:       "Design review",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrResourceNotAvailable)
+	mockHoldRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestHoldService_ConvertHold_Expired(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	userID := uuid.New()
+	hold := newActiveHold(userID, time.Now().Add(24*time.Hour))
+	hold.ExpiresAt = time.Now().Add(-time.Second)
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+	_, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{OrganizerID: userID})
+
+	assert.ErrorIs(t, err, service.ErrHoldExpired)
+}
+
+func TestHoldService_ConvertHold_NotOwner(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	hold := newActiveHold(uuid.New(), time.Now().Add(24*time.Hour))
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+	_, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{OrganizerID: uuid.New()})
+
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
+func TestHoldService_ConvertHold_Bumped(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	userID := uuid.New()
+	hold := newActiveHold(userID, time.Now().Add(24*time.Hour))
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	// 会議室の優先確保で仮押さえ予約のインスタンスがキャンセルされた
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, hold.ReservationID).Return([]*domain.ReservationInstance{
+		{ID: uuid.New(), Status: domain.ReservationStatusCancelled},
+	}, nil)
+
+	_, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{OrganizerID: userID})
+
+	assert.ErrorIs(t, err, service.ErrHoldInactive)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHoldService_ConvertHold_ExpiredDuringConversion(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	room := newHoldRoom()
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	endAt := startAt.Add(time.Hour)
+	hold := newActiveHold(user.ID, startAt)
+
+	mockHoldRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, hold.ReservationID).Return(heldInstances(), nil)
+	mockReservationRepo.On("GetResourceUnits", ctx, hold.ReservationID).Return(map[uuid.UUID]int{room.ID: 1}, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.Anything, mock.Anything, []uuid.UUID{room.ID}).Return(nil)
+	// ワーカーが先に期限切れとして解放した
+	mockHoldRepo.On("Update", ctx, mock.Anything).Return(repository.ErrNotFound)
+	mockReservationRepo.On("Delete", ctx, mock.Anything, startAt).Return(nil)
+
+	_, err := svc.ConvertHold(ctx, hold.ID, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		Title:       "Design review",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrHoldExpired)
+	// 作成した予約を取り消し、仮押さえ予約はワーカーに任せる
+	mockReservationRepo.AssertNumberOfCalls(t, "Delete", 1)
+	mockReservationRepo.AssertNotCalled(t, "Delete", ctx, hold.ReservationID, hold.StartAt)
+}
+
+func TestHoldService_ExpireHolds(t *testing.T) {
+	mockHoldRepo := new(MockHoldRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+
+	now := time.Now()
+	resourceID := uuid.New()
+	startAt := now.Add(24 * time.Hour).Truncate(time.Hour)
+	expired := newActiveHold(uuid.New(), startAt)
+	expired.ExpiresAt = now.Add(-time.Minute)
+	converted := newActiveHold(uuid.New(), startAt)
+	converted.ExpiresAt = now.Add(-time.Minute)
+
+	mockHoldRepo.On("ListExpired", ctx, now).Return([]*domain.ResourceHold{expired, converted}, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, expired.ReservationID).Return([]*domain.ReservationInstance{
+		{StartAt: expired.StartAt, EndAt: expired.EndAt},
+	}, nil)
+	mockReservationRepo.On("GetResourceIDs", ctx, expired.ReservationID).Return([]uuid.UUID{resourceID}, nil)
+	mockHoldRepo.On("Update", ctx, expired).Return(nil)
+	mockReservationRepo.On("Delete", ctx, expired.ReservationID, expired.StartAt).Return(nil)
+	mockJobQueue.On("Enqueue", ctx, "waitlist_promote", map[string]interface{}{
+		"resource_id": resourceID.String(),
+		"start_at":    expired.StartAt.Format(time.RFC3339),
+		"end_at":      expired.EndAt.Format(time.RFC3339),
+	}).Return("job-1", nil)
+
+	// 期限切れ直前に予約へ変換された仮押さえは更新されない
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, converted.ReservationID).Return([]*domain.ReservationInstance{}, nil)
+	mockHoldRepo.On("Update", ctx, converted).Return(repository.ErrNotFound)
+
+	count, err := svc.ExpireHolds(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, domain.HoldStatusExpired, expired.Status)
+	mockReservationRepo.AssertNotCalled(t, "Delete", ctx, converted.ReservationID, converted.StartAt)
+	mockJobQueue.AssertExpectations(t)
+}
@@ -0,0 +1,147 @@
+// backend/internal/service/identity_reconciliation.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+// recordIdentityConflict はIdPのユーザーとの突合で自動判定できなかった事象を突合レポートに記録します
+// 記録に失敗してもログインの処理は継続します（拒否するかどうかは呼び出し元が判断します）
+func (s *AuthService) recordIdentityConflict(ctx context.Context, kind domain.IdentityConflictKind, identity *ExternalIdentity, userID *uuid.UUID, candidates []*domain.User) {
+	now := time.Now()
+	conflict := &domain.IdentityConflict{
+		ID:               uuid.New(),
+		Kind:             kind,
+		Issuer:           identity.Issuer,
+		Subject:          identity.Subject,
+		Email:            identity.Email,
+		Name:             identity.Name,
+		UserID:           userID,
+		CandidateUserIDs: make([]uuid.UUID, 0, len(candidates)),
+		DetectedAt:       now,
+		CreatedAt:        now,
+	}
+	for _, candidate := range candidates {
+		conflict.CandidateUserIDs = append(conflict.CandidateUserIDs, candidate.ID)
+	}
+
+	if err := s.identityConflictRepo.Record(ctx, conflict); err != nil {
+		log.Printf("Warning: failed to record identity conflict for %s: %v", identity.Email, err)
+		return
+	}
+
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     domain.SystemUserID,
+		Action:     domain.AuditActionIdentityConflict,
+		TargetType: "identity_conflict",
+		TargetID:   conflict.ID.String(),
+		Details: map[string]interface{}{
+			"kind":               kind,
+			"email":              identity.Email,
+			"issuer":             identity.Issuer,
+			"subject":            identity.Subject,
+			"candidate_user_ids": conflict.CandidateUserIDs,
+		},
+		CreatedAt: now,
+	})
+}
+
+// ListIdentityConflicts は突合レポートを取得します（includeResolved が false の場合は未解決のみ）
+func (s *AuthService) ListIdentityConflicts(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error) {
+	return s.identityConflictRepo.List(ctx, includeResolved)
+}
+
+// ResolveIdentityConflict は突合レポートを管理者の判断で解決します
+//
+//	LINK:    候補のユーザー（userID）に (issuer, sub) を紐付けます（既存の紐付けは置き換えます）
+//	CREATE:  IdPのユーザーを新しいユーザーとして作成します（ロールは次回以降のログインで同期されます）
+//	DISMISS: 対応不要として閉じます
+func (s *AuthService) ResolveIdentityConflict(ctx context.Context, actorID, conflictID uuid.UUID, resolution domain.IdentityResolution, userID *uuid.UUID, note string) (*domain.IdentityConflict, error) {
+	conflict, err := s.identityConflictRepo.GetByID(ctx, conflictID)
+	if err != nil {
+		return nil, err
+	}
+	if !conflict.CanResolve(resolution, userID) {
+		return nil, domain.ErrInvalidIdentityResolution
+	}
+
+	// 検出後に (issuer, sub) が別のユーザーに紐付けられていないか確認する
+	if resolution != domain.IdentityResolutionDismiss {
+		linked, err := s.userRepo.GetBySubject(ctx, conflict.Issuer, conflict.Subject)
+		if err == nil && (resolution == domain.IdentityResolutionCreate || linked.ID != *userID) {
+			return nil, fmt.Errorf("%w: identity is already linked to user %s", domain.ErrInvalidIdentityResolution, linked.ID)
+		}
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get user by subject: %w", err)
+		}
+	}
+
+	var user *domain.User
+	switch resolution {
+	case domain.IdentityResolutionLink:
+		user, err = s.userRepo.GetByID(ctx, *userID)
+		if err != nil {
+			return nil, err
+		}
+		user.Issuer = conflict.Issuer
+		user.Sub = conflict.Subject
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to link user: %w", err)
+		}
+	case domain.IdentityResolutionCreate:
+		user = &domain.User{
+			ID:        uuid.New(),
+			Issuer:    conflict.Issuer,
+			Sub:       conflict.Subject,
+			Email:     conflict.Email,
+			Name:      conflict.Name,
+			Role:      domain.RoleGeneral,
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	now := time.Now()
+	conflict.ResolvedAt = &now
+	conflict.ResolvedBy = &actorID
+	conflict.Resolution = &resolution
+	conflict.ResolutionNote = note
+	if err := s.identityConflictRepo.Resolve(ctx, conflict); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"kind":       conflict.Kind,
+		"resolution": resolution,
+		"issuer":     conflict.Issuer,
+		"subject":    conflict.Subject,
+		"note":       note,
+	}
+	if user != nil {
+		details["user_id"] = user.ID
+	}
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     actorID,
+		Action:     domain.AuditActionIdentityResolve,
+		TargetType: "identity_conflict",
+		TargetID:   conflict.ID.String(),
+		Details:    details,
+		CreatedAt:  now,
+	})
+
+	return conflict, nil
+}
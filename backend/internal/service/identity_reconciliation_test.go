@@ -0,0 +1,127 @@
+// backend/internal/service/identity_reconciliation_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestAuthService_ResolveIdentityConflict(t *testing.T) {
+	adminID := uuid.New()
+	candidateID := uuid.New()
+
+	newConflict := func(kind domain.IdentityConflictKind) *domain.IdentityConflict {
+		return &domain.IdentityConflict{
+			ID:               uuid.New(),
+			Kind:             kind,
+			Issuer:           "https://idp.example.com",
+			Subject:          "new-sub",
+			Email:            "user@example.com",
+			Name:             "User",
+			CandidateUserIDs: []uuid.UUID{candidateID},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		kind          domain.IdentityConflictKind
+		resolution    domain.IdentityResolution
+		userID        *uuid.UUID
+		setupMocks    func(*MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:       "Link to candidate",
+			kind:       domain.IdentityConflictEmailLinked,
+			resolution: domain.IdentityResolutionLink,
+			userID:     &candidateID,
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, "https://idp.example.com", "new-sub").Return(nil, repository.ErrNotFound)
+				mu.On("GetByID", mock.Anything, candidateID).Return(&domain.User{ID: candidateID, Issuer: "https://idp.example.com", Sub: "old-sub"}, nil)
+				mu.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.HasIdentity("https://idp.example.com", "new-sub")
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Create new user",
+			kind:       domain.IdentityConflictMultipleEmail,
+			resolution: domain.IdentityResolutionCreate,
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, "https://idp.example.com", "new-sub").Return(nil, repository.ErrNotFound)
+				mu.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Email == "user@example.com" && u.Role == domain.RoleGeneral && u.HasIdentity("https://idp.example.com", "new-sub")
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Dismiss email taken",
+			kind:       domain.IdentityConflictEmailTaken,
+			resolution: domain.IdentityResolutionDismiss,
+			setupMocks: func(mu *MockUserRepository) {},
+		},
+		{
+			name:          "Link to non-candidate is rejected",
+			kind:          domain.IdentityConflictEmailLinked,
+			resolution:    domain.IdentityResolutionLink,
+			userID:        &adminID,
+			setupMocks:    func(mu *MockUserRepository) {},
+			expectedError: domain.ErrInvalidIdentityResolution,
+		},
+		{
+			name:          "Create for email taken is rejected",
+			kind:          domain.IdentityConflictEmailTaken,
+			resolution:    domain.IdentityResolutionCreate,
+			setupMocks:    func(mu *MockUserRepository) {},
+			expectedError: domain.ErrInvalidIdentityResolution,
+		},
+		{
+			name:       "Identity linked after detection is rejected",
+			kind:       domain.IdentityConflictMultipleEmail,
+			resolution: domain.IdentityResolutionCreate,
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("GetBySubject", mock.Anything, "https://idp.example.com", "new-sub").Return(&domain.User{ID: uuid.New()}, nil)
+			},
+			expectedError: domain.ErrInvalidIdentityResolution,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+			mockConflictRepo := new(MockIdentityConflictRepository)
+			svc := service.NewAuthService(new(MockOIDCClient), mockUserRepo, mockAuditRepo, mockConflictRepo, service.NewMemorySessionStore(), service.RoleMapping{}, 0)
+
+			conflict := newConflict(tt.kind)
+			mockConflictRepo.On("GetByID", mock.Anything, conflict.ID).Return(conflict, nil)
+			tt.setupMocks(mockUserRepo)
+			if tt.expectedError == nil {
+				mockConflictRepo.On("Resolve", mock.Anything, conflict).Return(nil)
+				mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+					return l.Action == domain.AuditActionIdentityResolve && l.UserID == adminID
+				})).Return(nil)
+			}
+
+			resolved, err := svc.ResolveIdentityConflict(context.Background(), adminID, conflict.ID, tt.resolution, tt.userID, "checked with HR")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, resolved.IsResolved())
+				assert.Equal(t, tt.resolution, *resolved.Resolution)
+				assert.Equal(t, adminID, *resolved.ResolvedBy)
+			}
+			mockUserRepo.AssertExpectations(t)
+			mockConflictRepo.AssertExpectations(t)
+			mockAuditRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+// backend/internal/service/login_provider_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coreosoidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+	"github.com/your-org/esms/pkg/oidc"
+	"golang.org/x/oauth2"
+)
+
+// newMultiProviderAuthService は既定のOIDC（oidc）・SAML（corp-b）・名前付きのOIDC（corp-c）を登録したAuthServiceを作成します
+func newMultiProviderAuthService(defaultOIDC, corpC *MockOIDCClient, userRepo *MockUserRepository, auditRepo *MockAuditLogRepository) *service.AuthService {
+	return service.NewAuthService(defaultOIDC, userRepo, auditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0,
+		service.NewSAMLLoginProvider(new(MockSAMLServiceProvider), service.SAMLAttributeMapping{}, 0, service.ProviderOptions{
+			Name:    "corp-b",
+			Domains: []string{"corp-b.example.com"},
+		}),
+		service.NewOIDCLoginProvider(corpC, service.ProviderOptions{
+			Name:    "corp-c",
+			Domains: []string{"Corp-C.example.com", "corp-c.example.net"},
+		}),
+	)
+}
+
+func TestAuthService_ResolveProvider(t *testing.T) {
+	svc := newMultiProviderAuthService(new(MockOIDCClient), new(MockOIDCClient), new(MockUserRepository), new(MockAuditLogRepository))
+
+	tests := []struct {
+		name         string
+		providerName string
+		email        string
+		want         string
+		wantErr      error
+	}{
+		{name: "explicit provider", providerName: "corp-b", email: "taro@corp-c.example.com", want: "corp-b"},
+		{name: "unknown provider", providerName: "unknown", wantErr: service.ErrProviderNotFound},
+		{name: "email domain", email: "taro@corp-b.example.com", want: "corp-b"},
+		{name: "case insensitive domain", email: "Taro@CORP-C.EXAMPLE.COM", want: "corp-c"},
+		{name: "subdomain", email: "taro@mail.corp-c.example.net", want: "corp-c"},
+		{name: "unknown domain", email: "taro@other.example.com", want: service.ProviderOIDC},
+		{name: "no email", want: service.ProviderOIDC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.ResolveProvider(tt.providerName, tt.email)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthService_ResolveProvider_NoProviders(t *testing.T) {
+	svc := service.NewAuthService(nil, new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0)
+
+	_, err := svc.ResolveProvider("", "taro@example.com")
+	assert.ErrorIs(t, err, service.ErrProviderNotFound)
+}
+
+func TestAuthService_ListProviders(t *testing.T) {
+	svc := newMultiProviderAuthService(new(MockOIDCClient), new(MockOIDCClient), new(MockUserRepository), new(MockAuditLogRepository))
+
+	assert.Equal(t, []service.ProviderInfo{
+		{Name: "corp-b", Protocol: service.ProviderSAML},
+		{Name: "corp-c", Protocol: service.ProviderOIDC},
+		{Name: service.ProviderOIDC, Protocol: service.ProviderOIDC, Default: true},
+	}, svc.ListProviders())
+}
+
+func TestAuthService_NamedProvider_SessionLifecycle(t *testing.T) {
+	defaultOIDC := new(MockOIDCClient)
+	corpC := new(MockOIDCClient)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	svc := newMultiProviderAuthService(defaultOIDC, corpC, mockUserRepo, mockAuditRepo)
+	ctx := context.Background()
+
+	token := (&oauth2.Token{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{"id_token": "raw-id-token"})
+
+	corpC.On("GetAuthURL", mock.AnythingOfType("oidc.AuthURLParams")).Return("https://corp-c.example.com/authorize")
+	corpC.On("ExchangeCode", mock.Anything, "valid-code", mock.AnythingOfType("string")).Return(token, nil)
+	corpC.On("ParseIDTokenClaimsWithValidation", mock.Anything, "raw-id-token", mock.AnythingOfType("string"), "access-token").Return(&oidc.TokenClaims{
+		Issuer:  "https://corp-c.example.com",
+		Subject: "corp-c-sub",
+	}, nil)
+	corpC.On("VerifyIDToken", mock.Anything, "raw-id-token").Return(&coreosoidc.IDToken{}, nil)
+	corpC.On("GetUserInfo", mock.Anything, mock.Anything).Return(&oidc.UserInfo{
+		Email: "taro@corp-c.example.com",
+		Name:  "Corp C User",
+	}, nil)
+	mockUserRepo.On("GetBySubject", mock.Anything, "https://corp-c.example.com", "corp-c-sub").Return(nil, repository.ErrNotFound)
+	mockUserRepo.On("ListByEmail", mock.Anything, "taro@corp-c.example.com").Return([]*domain.User{}, nil)
+	mockUserRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionLogin && l.Details["provider"] == "corp-c"
+	})).Return(nil)
+
+	// ログイン: コールバックのURLは共通でも、state に紐付けたIdPでコードを交換する
+	_, state, err := svc.BeginLogin(ctx, "corp-c")
+	require.NoError(t, err)
+	session, err := svc.HandleCallback(ctx, "valid-code", state, "")
+	require.NoError(t, err)
+	assert.Equal(t, "corp-c", session.Provider)
+	assert.Equal(t, "raw-id-token", session.IDToken)
+
+	// リフレッシュ: セッションを発行したIdPでトークンを更新する
+	corpC.On("RefreshToken", mock.Anything, "refresh-token").Return((&oauth2.Token{
+		AccessToken: "new-access-token",
+		Expiry:      time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{"id_token": "new-id-token"}), nil)
+
+	refreshed, err := svc.RefreshSession(ctx, session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "corp-c", refreshed.Provider)
+	assert.Equal(t, "new-access-token", refreshed.AccessToken)
+	assert.Equal(t, "new-id-token", refreshed.IDToken)
+
+	// ログアウト: セッションを発行したIdPのログアウトURLを返す
+	corpC.On("EndSessionURL", "new-id-token").Return("https://corp-c.example.com/logout")
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionLogout && l.Details["provider"] == "corp-c"
+	})).Return(nil)
+
+	logoutURL, err := svc.Logout(ctx, refreshed.ID, refreshed.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://corp-c.example.com/logout", logoutURL)
+
+	_, err = svc.GetSession(ctx, refreshed.ID)
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+
+	// 既定のIdPのクライアントは使用しない
+	defaultOIDC.AssertNotCalled(t, "ExchangeCode", mock.Anything, mock.Anything, mock.Anything)
+	defaultOIDC.AssertNotCalled(t, "RefreshToken", mock.Anything, mock.Anything)
+	defaultOIDC.AssertNotCalled(t, "EndSessionURL", mock.Anything)
+	corpC.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuthService_Logout_ProviderWithoutLogoutURL(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	mockAuditRepo := new(MockAuditLogRepository)
+	svc := service.NewAuthService(new(MockOIDCClient), new(MockUserRepository), mockAuditRepo, new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0,
+		service.NewSAMLLoginProvider(new(MockSAMLServiceProvider), service.SAMLAttributeMapping{}, 0, service.ProviderOptions{}))
+	ctx := context.Background()
+
+	session := &service.Session{ID: "saml-session", Provider: service.ProviderSAML, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, store.SaveSession(ctx, session.ID, session))
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	// SAMLのセッションはローカルのセッションのみ削除する
+	logoutURL, err := svc.Logout(ctx, session.ID, session.UserID)
+	assert.NoError(t, err)
+	assert.Empty(t, logoutURL)
+
+	_, err = store.GetSession(ctx, session.ID)
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+}
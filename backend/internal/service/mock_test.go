@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
 )
 
 // Mock Repositories
@@ -29,6 +30,22 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetBySubject(ctx context.Context, issuer, sub string) (*domain.User, error) {
+	args := m.Called(ctx, issuer, sub)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
@@ -47,14 +64,57 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) List(ctx context.Context, offset, limit int) ([]*domain.User, int64, error) {
-	args := m.Called(ctx, offset, limit)
+func (m *MockUserRepository) List(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
 }
 
+type MockProvisionedGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockProvisionedGroupRepository) Create(ctx context.Context, group *domain.ProvisionedGroup) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockProvisionedGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisionedGroupRepository) List(ctx context.Context, filter repository.ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error) {
+	args := m.Called(ctx, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.ProvisionedGroup), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProvisionedGroupRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ProvisionedGroup), args.Error(1)
+}
+
+func (m *MockProvisionedGroupRepository) Update(ctx context.Context, group *domain.ProvisionedGroup) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockProvisionedGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 type MockAuditLogRepository struct {
 	mock.Mock
 }
@@ -128,6 +188,48 @@ func (m *MockReservationRepository) GetInstancesByReservationID(ctx context.Cont
 	return args.Get(0).([]*domain.ReservationInstance), args.Error(1)
 }
 
+func (m *MockReservationRepository) UpdateInstancesStatus(ctx context.Context, reservationID uuid.UUID, status domain.ReservationStatus) error {
+	args := m.Called(ctx, reservationID, status)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) ListPendingApprovals(ctx context.Context, filter repository.PendingApprovalFilter) ([]*domain.Reservation, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.Reservation), args.Int(1), args.Error(2)
+}
+
+func (m *MockReservationRepository) GetResourceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetResourceUnits(ctx context.Context, reservationID uuid.UUID) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+func (m *MockReservationRepository) ReleaseInstanceResource(ctx context.Context, instanceID, resourceID uuid.UUID, releasedAt time.Time) (time.Time, error) {
+	args := m.Called(ctx, instanceID, resourceID, releasedAt)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockReservationRepository) ListOverlappingInstances(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.ReservationInstance, error) {
+	args := m.Called(ctx, resourceID, startAt, endAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReservationInstance), args.Error(1)
+}
+
 type MockResourceRepository struct {
 	mock.Mock
 }
@@ -162,3 +264,512 @@ func (m *MockResourceRepository) FindAvailable(ctx context.Context, startAt, end
 	}
 	return args.Get(0).([]*domain.Resource), args.Error(1)
 }
+
+func (m *MockResourceRepository) FindAvailableRooms(ctx context.Context, startAt, endAt time.Time, minCapacity int) ([]*domain.Resource, error) {
+	args := m.Called(ctx, startAt, endAt, minCapacity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) AvailableUnits(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error) {
+	args := m.Called(ctx, resourceID, startAt, endAt)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockResourceRepository) ListByZone(ctx context.Context, resourceType domain.ResourceType, zone string) ([]*domain.Resource, error) {
+	args := m.Called(ctx, resourceType, zone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Resource), args.Error(1)
+}
+
+func (m *MockResourceRepository) ListZoneBookings(ctx context.Context, resourceType domain.ResourceType, zone string, startAt, endAt time.Time) ([]*domain.ZoneBooking, error) {
+	args := m.Called(ctx, resourceType, zone, startAt, endAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ZoneBooking), args.Error(1)
+}
+
+type MockApproverGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockApproverGroupRepository) Create(ctx context.Context, group *domain.ApproverGroup) error {
+	args := m.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (m *MockApproverGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ApproverGroup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ApproverGroup), args.Error(1)
+}
+
+func (m *MockApproverGroupRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockApproverGroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockApproverGroupRepository) ListMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.User, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockApproverGroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockApprovalRepository struct {
+	mock.Mock
+}
+
+func (m *MockApprovalRepository) CreatePolicy(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockApprovalRepository) GetPolicyByID(ctx context.Context, id uuid.UUID) (*domain.ApprovalPolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ApprovalPolicy), args.Error(1)
+}
+
+func (m *MockApprovalRepository) CreateSteps(ctx context.Context, steps []*domain.ReservationApproval) error {
+	args := m.Called(ctx, steps)
+	return args.Error(0)
+}
+
+func (m *MockApprovalRepository) ListSteps(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationApproval, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReservationApproval), args.Error(1)
+}
+
+func (m *MockApprovalRepository) UpdateStep(ctx context.Context, step *domain.ReservationApproval) error {
+	args := m.Called(ctx, step)
+	return args.Error(0)
+}
+
+func (m *MockApprovalRepository) ListActiveSteps(ctx context.Context) ([]*domain.ReservationApproval, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReservationApproval), args.Error(1)
+}
+
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Create(ctx context.Context, entry *domain.WaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Update(ctx context.Context, entry *domain.WaitlistEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) HasActiveEntry(ctx context.Context, resourceID, userID uuid.UUID, startAt, endAt time.Time) (bool, error) {
+	args := m.Called(ctx, resourceID, userID, startAt, endAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListWaiting(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, resourceID, startAt, endAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) ListExpiredOffers(ctx context.Context, now time.Time) ([]*domain.WaitlistEntry, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.WaitlistEntry), args.Error(1)
+}
+
+type MockAssetRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssetRepository) Create(ctx context.Context, asset *domain.Asset) error {
+	args := m.Called(ctx, asset)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) GetByTag(ctx context.Context, assetTag string) (*domain.Asset, error) {
+	args := m.Called(ctx, assetTag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) ListByResource(ctx context.Context, resourceID uuid.UUID) ([]*domain.Asset, error) {
+	args := m.Called(ctx, resourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) AssignToReservation(ctx context.Context, assignments []*domain.ReservationAsset) error {
+	args := m.Called(ctx, assignments)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) ListAssignments(ctx context.Context, reservationID uuid.UUID) ([]*domain.ReservationAsset, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReservationAsset), args.Error(1)
+}
+
+func (m *MockAssetRepository) IsAssignedInPeriod(ctx context.Context, assetID uuid.UUID, startAt, endAt time.Time, excludeReservationID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, assetID, startAt, endAt, excludeReservationID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockCheckoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockCheckoutRepository) Create(ctx context.Context, checkout *domain.EquipmentCheckout) error {
+	args := m.Called(ctx, checkout)
+	return args.Error(0)
+}
+
+func (m *MockCheckoutRepository) GetOpenByAsset(ctx context.Context, assetID uuid.UUID) (*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, assetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EquipmentCheckout), args.Error(1)
+}
+
+func (m *MockCheckoutRepository) Update(ctx context.Context, checkout *domain.EquipmentCheckout) error {
+	args := m.Called(ctx, checkout)
+	return args.Error(0)
+}
+
+func (m *MockCheckoutRepository) ListByAsset(ctx context.Context, assetID uuid.UUID) ([]*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, assetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EquipmentCheckout), args.Error(1)
+}
+
+func (m *MockCheckoutRepository) ListOverdue(ctx context.Context, now time.Time) ([]*domain.EquipmentCheckout, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EquipmentCheckout), args.Error(1)
+}
+
+func (m *MockCheckoutRepository) HasOverdue(ctx context.Context, borrowerID uuid.UUID, now time.Time) (bool, error) {
+	args := m.Called(ctx, borrowerID, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCheckoutRepository) CountOpenByInstance(ctx context.Context, reservationInstanceID, resourceID uuid.UUID) (int, error) {
+	args := m.Called(ctx, reservationInstanceID, resourceID)
+	return args.Int(0), args.Error(1)
+}
+
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Create(ctx context.Context, hold *domain.ResourceHold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ResourceHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourceHold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Update(ctx context.Context, hold *domain.ResourceHold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*domain.ResourceHold, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceHold), args.Error(1)
+}
+
+func (m *MockHoldRepository) ListExpired(ctx context.Context, now time.Time) ([]*domain.ResourceHold, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceHold), args.Error(1)
+}
+
+type MockBumpRepository struct {
+	mock.Mock
+}
+
+func (m *MockBumpRepository) Apply(ctx context.Context, bumps []*domain.ReservationBump) error {
+	args := m.Called(ctx, bumps)
+	return args.Error(0)
+}
+
+func (m *MockBumpRepository) ListDisplacedInstanceIDs(ctx context.Context, reservationID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type MockQuotaRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuotaRepository) Create(ctx context.Context, rule *domain.QuotaRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockQuotaRepository) List(ctx context.Context) ([]*domain.QuotaRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRule), args.Error(1)
+}
+
+func (m *MockQuotaRepository) ListActive(ctx context.Context) ([]*domain.QuotaRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRule), args.Error(1)
+}
+
+func (m *MockQuotaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuotaRepository) BookedMinutes(ctx context.Context, filter repository.QuotaUsageFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockQuotaRepository) CountUpcoming(ctx context.Context, filter repository.QuotaUsageFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+type MockPenaltyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPenaltyRepository) CreateEvent(ctx context.Context, event *domain.PenaltyEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockPenaltyRepository) ListEventsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PenaltyEvent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PenaltyEvent), args.Error(1)
+}
+
+func (m *MockPenaltyRepository) ExpireEvents(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockPenaltyRepository) RecalculateScore(ctx context.Context, userID uuid.UUID) (int, *time.Time, error) {
+	args := m.Called(ctx, userID)
+	var expireAt *time.Time
+	if args.Get(1) != nil {
+		expireAt = args.Get(1).(*time.Time)
+	}
+	return args.Int(0), expireAt, args.Error(2)
+}
+
+func (m *MockPenaltyRepository) CreateThreshold(ctx context.Context, threshold *domain.PenaltyThreshold) error {
+	args := m.Called(ctx, threshold)
+	return args.Error(0)
+}
+
+func (m *MockPenaltyRepository) ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PenaltyThreshold), args.Error(1)
+}
+
+func (m *MockPenaltyRepository) DeleteThreshold(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockIdentityConflictRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityConflictRepository) Record(ctx context.Context, conflict *domain.IdentityConflict) error {
+	args := m.Called(ctx, conflict)
+	return args.Error(0)
+}
+
+func (m *MockIdentityConflictRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.IdentityConflict, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdentityConflict), args.Error(1)
+}
+
+func (m *MockIdentityConflictRepository) List(ctx context.Context, includeResolved bool) ([]*domain.IdentityConflict, error) {
+	args := m.Called(ctx, includeResolved)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.IdentityConflict), args.Error(1)
+}
+
+func (m *MockIdentityConflictRepository) Resolve(ctx context.Context, conflict *domain.IdentityConflict) error {
+	args := m.Called(ctx, conflict)
+	return args.Error(0)
+}
+
+type MockAPITokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPITokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIToken, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.APIToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) List(ctx context.Context, filter repository.APITokenFilter) ([]*domain.APIToken, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIToken), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) Revoke(ctx context.Context, id, revokedBy uuid.UUID, revokedAt time.Time) error {
+	args := m.Called(ctx, id, revokedBy, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPITokenRepository) RevokeByServiceAccount(ctx context.Context, serviceAccountID, revokedBy uuid.UUID, revokedAt time.Time) (int64, error) {
+	args := m.Called(ctx, serviceAccountID, revokedBy, revokedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAPITokenRepository) RecordUse(ctx context.Context, id uuid.UUID, usedAt time.Time, ip string) error {
+	args := m.Called(ctx, id, usedAt, ip)
+	return args.Error(0)
+}
+
+type MockServiceAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceAccountRepository) Create(ctx context.Context, account *domain.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func (m *MockServiceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ServiceAccount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) List(ctx context.Context) ([]*domain.ServiceAccount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ServiceAccount), args.Error(1)
+}
+
+func (m *MockServiceAccountRepository) Update(ctx context.Context, account *domain.ServiceAccount) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
@@ -28,6 +28,10 @@ const (
 	NotificationTypeReservationRejected NotificationType = "reservation_rejected"
 	NotificationTypeReservationCanceled NotificationType = "reservation_canceled"
 	NotificationTypeReservationReminder NotificationType = "reservation_reminder"
+	NotificationTypeApprovalRequested   NotificationType = "approval_requested"
+	NotificationTypeWaitlistOffer       NotificationType = "waitlist_offer"
+	NotificationTypeEquipmentOverdue    NotificationType = "equipment_overdue"
+	NotificationTypeReservationBumped   NotificationType = "reservation_bumped"
 )
 
 // EmailSender はメール送信インターフェース
@@ -122,6 +126,55 @@ func (s *NotificationService) initTemplates() {
 場所: {{.Location}}
 
 まもなく予約時刻です。ご準備ください。
+`))
+
+	// 承認依頼通知テンプレート
+	s.templates[NotificationTypeApprovalRequested] = template.Must(template.New("approval_requested").Parse(`
+予約の承認依頼が届きました
+
+タイトル: {{.Title}}
+開始時刻: {{.StartAt}}
+終了時刻: {{.EndAt}}
+主催者: {{.OrganizerName}}
+
+システムにログインし、承認または却下してください。
+`))
+
+	// ウェイトリスト繰り上げ通知テンプレート
+	s.templates[NotificationTypeWaitlistOffer] = template.Must(template.New("waitlist_offer").Parse(`
+空き待ちの枠が空きました
+
+リソース: {{.ResourceName}}
+タイトル: {{.Title}}
+開始時刻: {{.StartAt}}
+終了時刻: {{.EndAt}}
+確認期限: {{.ExpiresAt}}
+
+確認期限までにシステムで予約を確定してください。期限を過ぎると次の方へ繰り上げられます。
+`))
+
+	// 備品の返却遅延通知テンプレート
+	s.templates[NotificationTypeEquipmentOverdue] = template.Must(template.New("equipment_overdue").Parse(`
+貸出中の備品の返却期限を過ぎています
+
+資産番号: {{.AssetTag}}
+貸出日時: {{.CheckedOutAt}}
+返却期限: {{.DueAt}}
+
+至急返却してください。返却が確認されるまで新しい予約はできません。
+`))
+
+	// 優先確保による予約の移動・強制キャンセル通知テンプレート
+	s.templates[NotificationTypeReservationBumped] = template.Must(template.New("reservation_bumped").Parse(`
+{{if .Replacement}}優先利用のため予約の会議室が変更されました{{else}}優先利用のため予約がキャンセルされました{{end}}
+
+タイトル: {{.Title}}
+開始時刻: {{.StartAt}}
+終了時刻: {{.EndAt}}
+会議室: {{.ResourceName}}{{if .Replacement}} → {{.Replacement}}{{end}}
+理由: {{.Reason}}
+
+ご迷惑をおかけします。この変更によるキャンセルペナルティは発生しません。
 `))
 }
 
@@ -232,6 +285,154 @@ func (s *NotificationService) NotifyReservationRejected(ctx context.Context, res
 	return nil
 }
 
+// NotifyApprovalRequested は承認者に承認依頼通知を送信します
+func (s *NotificationService) NotifyApprovalRequested(ctx context.Context, reservation *domain.Reservation, organizer *domain.User, approver *domain.User) error {
+	cacheKey := fmt.Sprintf("approval_requested_%s_%s", reservation.ID.String(), approver.ID.String())
+	if s.isDuplicate(cacheKey) {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"Title":         reservation.Title,
+		"StartAt":       reservation.StartAt.Format("2006-01-02 15:04"),
+		"EndAt":         reservation.EndAt.Format("2006-01-02 15:04"),
+		"OrganizerName": organizer.Name,
+	}
+
+	body, err := s.renderTemplate(NotificationTypeApprovalRequested, data)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"to":      approver.Email,
+		"subject": "予約の承認依頼",
+		"body":    body,
+	}
+
+	_, err = s.jobQueue.Enqueue(ctx, "send_email", payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email job: %w", err)
+	}
+
+	s.markAsSent(cacheKey)
+	return nil
+}
+
+// NotifyWaitlistOffer はウェイトリスト登録者に繰り上げ提示を通知します
+func (s *NotificationService) NotifyWaitlistOffer(ctx context.Context, entry *domain.WaitlistEntry, resource *domain.Resource, user *domain.User) error {
+	cacheKey := fmt.Sprintf("waitlist_offer_%s", entry.ID.String())
+	if s.isDuplicate(cacheKey) {
+		return nil
+	}
+
+	expiresAt := ""
+	if entry.OfferExpiresAt != nil {
+		expiresAt = entry.OfferExpiresAt.Format("2006-01-02 15:04")
+	}
+	data := map[string]interface{}{
+		"ResourceName": resource.Name,
+		"Title":        entry.Title,
+		"StartAt":      entry.StartAt.Format("2006-01-02 15:04"),
+		"EndAt":        entry.EndAt.Format("2006-01-02 15:04"),
+		"ExpiresAt":    expiresAt,
+	}
+
+	body, err := s.renderTemplate(NotificationTypeWaitlistOffer, data)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"to":      user.Email,
+		"subject": "空き待ちの枠が空きました",
+		"body":    body,
+	}
+
+	_, err = s.jobQueue.Enqueue(ctx, "send_email", payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email job: %w", err)
+	}
+
+	s.markAsSent(cacheKey)
+	return nil
+}
+
+// NotifyEquipmentOverdue は借用者に備品の返却遅延を通知します
+func (s *NotificationService) NotifyEquipmentOverdue(ctx context.Context, checkout *domain.EquipmentCheckout, borrower *domain.User) error {
+	cacheKey := fmt.Sprintf("equipment_overdue_%s", checkout.ID.String())
+	if s.isDuplicate(cacheKey) {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"AssetTag":     checkout.AssetTag,
+		"CheckedOutAt": checkout.CheckedOutAt.Format("2006-01-02 15:04"),
+		"DueAt":        checkout.DueAt.Format("2006-01-02 15:04"),
+	}
+
+	body, err := s.renderTemplate(NotificationTypeEquipmentOverdue, data)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"to":      borrower.Email,
+		"subject": "備品の返却期限を過ぎています",
+		"body":    body,
+	}
+
+	_, err = s.jobQueue.Enqueue(ctx, "send_email", payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email job: %w", err)
+	}
+
+	s.markAsSent(cacheKey)
+	return nil
+}
+
+// NotifyReservationBumped は会議室の優先確保で押しのけられた予約の主催者に移動先または強制キャンセルを通知します
+// replacement が nil の場合は強制キャンセルとして通知します
+func (s *NotificationService) NotifyReservationBumped(ctx context.Context, bump *domain.ReservationBump, instance *domain.ReservationInstance, resource, replacement *domain.Resource, organizer *domain.User) error {
+	cacheKey := fmt.Sprintf("bumped_%s", bump.DisplacedInstanceID.String())
+	if s.isDuplicate(cacheKey) {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"Title":        instance.Reservation.Title,
+		"StartAt":      instance.StartAt.Format("2006-01-02 15:04"),
+		"EndAt":        instance.EndAt.Format("2006-01-02 15:04"),
+		"ResourceName": resource.Name,
+		"Replacement":  "",
+		"Reason":       bump.Reason,
+	}
+	subject := "予約がキャンセルされました（優先利用）"
+	if replacement != nil {
+		data["Replacement"] = replacement.Name
+		subject = "予約の会議室が変更されました（優先利用）"
+	}
+
+	body, err := s.renderTemplate(NotificationTypeReservationBumped, data)
+	if err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"to":      organizer.Email,
+		"subject": subject,
+		"body":    body,
+	}
+
+	_, err = s.jobQueue.Enqueue(ctx, "send_email", payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue email job: %w", err)
+	}
+
+	s.markAsSent(cacheKey)
+	return nil
+}
+
 // renderTemplate はテンプレートをレンダリングします
 func (s *NotificationService) renderTemplate(notifType NotificationType, data map[string]interface{}) (string, error) {
 	tmpl, ok := s.templates[notifType]
@@ -180,3 +180,40 @@ func TestNotificationService_NotifyReservationRejected(t *testing.T) {
 	assert.NoError(t, err)
 	mockJobQueue.AssertExpectations(t)
 }
+
+func TestNotificationService_NotifyApprovalRequested(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockEmailSender := new(MockEmailSender)
+
+	svc := service.NewNotificationService(mockUserRepo, mockJobQueue, mockEmailSender)
+
+	ctx := context.Background()
+	reservation := &domain.Reservation{
+		ID:      uuid.New(),
+		Title:   "Board Meeting",
+		StartAt: time.Now().Add(24 * time.Hour),
+		EndAt:   time.Now().Add(25 * time.Hour),
+	}
+
+	organizer := &domain.User{
+		ID:    uuid.New(),
+		Email: "organizer@example.com",
+		Name:  "Test Organizer",
+	}
+
+	approver := &domain.User{
+		ID:    uuid.New(),
+		Email: "approver@example.com",
+		Name:  "Test Approver",
+	}
+
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(payload map[string]interface{}) bool {
+		return payload["to"] == approver.Email && payload["subject"] == "予約の承認依頼"
+	})).Return("job-id", nil)
+
+	err := svc.NotifyApprovalRequested(ctx, reservation, organizer, approver)
+
+	assert.NoError(t, err)
+	mockJobQueue.AssertExpectations(t)
+}
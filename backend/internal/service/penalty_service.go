@@ -0,0 +1,286 @@
+// backend/internal/service/penalty_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrPenaltyRestricted    = errors.New("penalty score restricts booking this resource")
+	ErrPenaltyAdvanceWindow = errors.New("penalty score restricts how far ahead you can book")
+	ErrInvalidPenaltyPoints = errors.New("penalty adjustment must be non-zero and include a reason")
+)
+
+// PenaltyService はキャンセルペナルティスコア（加算・減衰・予約制限）に関するビジネスロジックを提供します
+type PenaltyService struct {
+	penaltyRepo     repository.PenaltyRepository
+	userRepo        repository.UserRepository
+	reservationRepo repository.ReservationRepository
+	bumpRepo        repository.BumpRepository
+	auditLogRepo    repository.AuditLogRepository
+}
+
+// NewPenaltyService は新しいPenaltyServiceを作成します
+func NewPenaltyService(
+	penaltyRepo repository.PenaltyRepository,
+	userRepo repository.UserRepository,
+	reservationRepo repository.ReservationRepository,
+	bumpRepo repository.BumpRepository,
+	auditLogRepo repository.AuditLogRepository,
+) *PenaltyService {
+	return &PenaltyService{
+		penaltyRepo:     penaltyRepo,
+		userRepo:        userRepo,
+		reservationRepo: reservationRepo,
+		bumpRepo:        bumpRepo,
+		auditLogRepo:    auditLogRepo,
+	}
+}
+
+// PenaltyHistory はユーザーのペナルティスコアと、その原因となったイベントの履歴
+type PenaltyHistory struct {
+	UserID      uuid.UUID                  `json:"user_id"`
+	Score       int                        `json:"score"`
+	ExpireAt    *time.Time                 `json:"expire_at,omitempty"`
+	Restriction *domain.PenaltyRestriction `json:"restriction,omitempty"`
+	Events      []*domain.PenaltyEvent     `json:"events"`
+}
+
+// lateCancellation は確定済みの予約が開始24時間以内にキャンセルされる場合に加算される直前キャンセルのイベントを記録せずに返します
+// 優先確保で押しのけられた回は主催者の都合ではないため対象外です。加算しない場合は nil を返します
+// 予約の削除後は回を参照できないため、削除前に判定し、削除に成功してから recordLateCancellation で記録します
+func (s *PenaltyService) lateCancellation(ctx context.Context, reservation *domain.Reservation, now time.Time) (*domain.PenaltyEvent, error) {
+	if reservation.ApprovalStatus != domain.ApprovalStatusConfirmed {
+		return nil, nil
+	}
+
+	instances, err := s.reservationRepo.GetInstancesByReservationID(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation instances: %w", err)
+	}
+	displaced, err := s.bumpRepo.ListDisplacedInstanceIDs(ctx, reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list displaced instances: %w", err)
+	}
+
+	// 直前キャンセルとなる最初の回（繰り返し予約でも1回のキャンセルにつき1点）
+	var late *domain.ReservationInstance
+	for _, inst := range instances {
+		if inst.Status != domain.ReservationStatusConfirmed || containsID(displaced, inst.ID) {
+			continue
+		}
+		if !domain.IsLateCancellation(inst.StartAt, inst.EndAt, now) {
+			continue
+		}
+		if late == nil || inst.StartAt.Before(late.StartAt) {
+			late = inst
+		}
+	}
+	if late == nil {
+		return nil, nil
+	}
+
+	event := &domain.PenaltyEvent{
+		ID:                 uuid.New(),
+		UserID:             reservation.OrganizerID,
+		Kind:               domain.PenaltyEventLateCancellation,
+		Points:             1,
+		ReservationID:      &reservation.ID,
+		ReservationStartAt: &late.StartAt,
+		Reason:             reservation.Title,
+		OccurredAt:         now,
+		ExpiresAt:          now.Add(domain.PenaltyScoreRotation),
+		CreatedAt:          now,
+	}
+	return event, nil
+}
+
+// recordLateCancellation は直前キャンセルのイベントを記録し、ペナルティスコアを再計算します
+func (s *PenaltyService) recordLateCancellation(ctx context.Context, event *domain.PenaltyEvent) error {
+	score, err := s.record(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, event.UserID, domain.AuditActionCancelWithPenalty, "reservation", event.ReservationID.String(), map[string]interface{}{
+		"instance_start_at": *event.ReservationStartAt,
+		"points":            event.Points,
+		"penalty_score":     score,
+	})
+	return nil
+}
+
+// AdjustScore は管理者がユーザーのペナルティスコアを調整します（負の値で減算）
+func (s *PenaltyService) AdjustScore(ctx context.Context, actorID, userID uuid.UUID, points int, reason string) (*domain.PenaltyEvent, error) {
+	if points == 0 || reason == "" {
+		return nil, ErrInvalidPenaltyPoints
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	now := time.Now()
+	event := &domain.PenaltyEvent{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Kind:       domain.PenaltyEventAdminAdjustment,
+		Points:     points,
+		Reason:     reason,
+		CreatedBy:  &actorID,
+		OccurredAt: now,
+		ExpiresAt:  now.Add(domain.PenaltyScoreRotation),
+		CreatedAt:  now,
+	}
+	score, err := s.record(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAudit(ctx, actorID, domain.AuditActionPenaltyAdjust, "user", userID.String(), map[string]interface{}{
+		"points":        points,
+		"reason":        reason,
+		"penalty_score": score,
+	})
+	return event, nil
+}
+
+// record はイベントを記録し、ユーザーのスコアを再計算します
+func (s *PenaltyService) record(ctx context.Context, event *domain.PenaltyEvent) (int, error) {
+	if err := s.penaltyRepo.CreateEvent(ctx, event); err != nil {
+		return 0, fmt.Errorf("failed to record penalty: %w", err)
+	}
+	score, _, err := s.penaltyRepo.RecalculateScore(ctx, event.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update penalty score: %w", err)
+	}
+	return score, nil
+}
+
+// ExpireScores は90日を過ぎたイベントをスコアから除外し、該当するユーザーのスコアを再計算します（ワーカーから定期実行）
+// 戻り値はスコアが減衰したユーザー数です
+func (s *PenaltyService) ExpireScores(ctx context.Context, now time.Time) (int, error) {
+	userIDs, err := s.penaltyRepo.ExpireEvents(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire penalty events: %w", err)
+	}
+
+	decayed := 0
+	for _, userID := range userIDs {
+		score, _, err := s.penaltyRepo.RecalculateScore(ctx, userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return decayed, fmt.Errorf("failed to update penalty score: %w", err)
+		}
+		decayed++
+
+		s.recordAudit(ctx, domain.SystemUserID, domain.AuditActionPenaltyExpire, "user", userID.String(), map[string]interface{}{
+			"penalty_score": score,
+		})
+	}
+	return decayed, nil
+}
+
+// Restriction はユーザーの有効なペナルティスコアに該当する予約制限を返します（該当しない場合は nil）
+func (s *PenaltyService) Restriction(ctx context.Context, user *domain.User) (*domain.PenaltyRestriction, error) {
+	score := user.GetActivePenaltyScore()
+	if score <= 0 {
+		return nil, nil
+	}
+	thresholds, err := s.penaltyRepo.ListThresholds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list penalty thresholds: %w", err)
+	}
+	return domain.RestrictionFor(score, thresholds), nil
+}
+
+// History はユーザーのペナルティスコア、適用中の予約制限、イベントの履歴を返します
+func (s *PenaltyService) History(ctx context.Context, userID uuid.UUID) (*PenaltyHistory, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	restriction, err := s.Restriction(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.penaltyRepo.ListEventsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list penalty events: %w", err)
+	}
+
+	history := &PenaltyHistory{
+		UserID:      userID,
+		Score:       user.GetActivePenaltyScore(),
+		Restriction: restriction,
+		Events:      events,
+	}
+	if history.Score > 0 {
+		history.ExpireAt = user.PenaltyScoreExpireAt
+	}
+	if history.Events == nil {
+		history.Events = []*domain.PenaltyEvent{}
+	}
+	return history, nil
+}
+
+// CreateThreshold は予約制限の閾値を作成します
+func (s *PenaltyService) CreateThreshold(ctx context.Context, actorID uuid.UUID, threshold *domain.PenaltyThreshold) error {
+	if err := threshold.Validate(); err != nil {
+		return err
+	}
+	threshold.ID = uuid.New()
+	threshold.IsActive = true
+	threshold.CreatedAt = time.Now()
+	threshold.UpdatedAt = threshold.CreatedAt
+
+	if err := s.penaltyRepo.CreateThreshold(ctx, threshold); err != nil {
+		return fmt.Errorf("failed to create penalty threshold: %w", err)
+	}
+
+	s.recordAudit(ctx, actorID, domain.AuditActionCreate, "penalty_threshold", threshold.ID.String(), map[string]interface{}{
+		"name":      threshold.Name,
+		"min_score": threshold.MinScore,
+	})
+	return nil
+}
+
+// ListThresholds は予約制限の閾値を全て返します
+func (s *PenaltyService) ListThresholds(ctx context.Context) ([]*domain.PenaltyThreshold, error) {
+	thresholds, err := s.penaltyRepo.ListThresholds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list penalty thresholds: %w", err)
+	}
+	return thresholds, nil
+}
+
+// DeleteThreshold は予約制限の閾値を削除します
+func (s *PenaltyService) DeleteThreshold(ctx context.Context, actorID, thresholdID uuid.UUID) error {
+	if err := s.penaltyRepo.DeleteThreshold(ctx, thresholdID); err != nil {
+		return fmt.Errorf("failed to delete penalty threshold: %w", err)
+	}
+	s.recordAudit(ctx, actorID, domain.AuditActionDelete, "penalty_threshold", thresholdID.String(), nil)
+	return nil
+}
+
+// recordAudit はペナルティの監査ログを記録します（エラーは無視）
+func (s *PenaltyService) recordAudit(ctx context.Context, userID uuid.UUID, action domain.AuditAction, targetType, targetID string, details map[string]interface{}) {
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
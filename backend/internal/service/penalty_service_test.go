@@ -0,0 +1,370 @@
+// backend/internal/service/penalty_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// newPenaltyUser はペナルティスコアが score のユーザーを作成します（0 の場合はスコアなし）
+func newPenaltyUser(score int) *domain.User {
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	if score > 0 {
+		expireAt := time.Now().Add(domain.PenaltyScoreRotation)
+		user.PenaltyScore = score
+		user.PenaltyScoreExpireAt = &expireAt
+	}
+	return user
+}
+
+func TestPenaltyService_ExpireScores(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	svc := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	user := newPenaltyUser(0)
+	ctx := context.Background()
+	now := time.Now()
+
+	deleted := uuid.New()
+	mockPenaltyRepo.On("ExpireEvents", ctx, now).Return([]uuid.UUID{user.ID, deleted}, nil)
+	mockPenaltyRepo.On("RecalculateScore", ctx, user.ID).Return(0, nil, nil)
+	mockPenaltyRepo.On("RecalculateScore", ctx, deleted).Return(0, nil, repository.ErrNotFound)
+
+	decayed, err := svc.ExpireScores(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, decayed)
+	mockPenaltyRepo.AssertExpectations(t)
+}
+
+func TestPenaltyService_AdjustScore(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	svc := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	user := newPenaltyUser(0)
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	_, err := svc.AdjustScore(ctx, adminID, user.ID, 0, "noop")
+	assert.ErrorIs(t, err, service.ErrInvalidPenaltyPoints)
+	_, err = svc.AdjustScore(ctx, adminID, user.ID, -1, "")
+	assert.ErrorIs(t, err, service.ErrInvalidPenaltyPoints)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockPenaltyRepo.On("CreateEvent", ctx, mock.MatchedBy(func(e *domain.PenaltyEvent) bool {
+		return e.Kind == domain.PenaltyEventAdminAdjustment && e.Points == -2 && *e.CreatedBy == adminID
+	})).Return(nil)
+	mockPenaltyRepo.On("RecalculateScore", ctx, user.ID).Return(1, nil, nil)
+
+	event, err := svc.AdjustScore(ctx, adminID, user.ID, -2, "Cancelled due to building closure")
+	assert.NoError(t, err)
+	assert.Equal(t, -2, event.Points)
+	mockPenaltyRepo.AssertExpectations(t)
+}
+
+func TestPenaltyService_History(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	svc := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	user := newPenaltyUser(5)
+	ctx := context.Background()
+
+	approval := &domain.PenaltyThreshold{MinScore: 5, RequiresApproval: true, IsActive: true}
+	event := &domain.PenaltyEvent{ID: uuid.New(), UserID: user.ID, Kind: domain.PenaltyEventLateCancellation, Points: 1}
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockPenaltyRepo.On("ListThresholds", ctx).Return([]*domain.PenaltyThreshold{approval}, nil)
+	mockPenaltyRepo.On("ListEventsByUser", ctx, user.ID).Return([]*domain.PenaltyEvent{event}, nil)
+
+	history, err := svc.History(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, history.Score)
+	assert.Equal(t, user.PenaltyScoreExpireAt, history.ExpireAt)
+	assert.True(t, history.Restriction.RequiresApproval)
+	assert.Len(t, history.Events, 1)
+}
+
+func TestPenaltyService_CreateThreshold_Invalid(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	svc := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+
+	err := svc.CreateThreshold(context.Background(), uuid.New(), &domain.PenaltyThreshold{Name: "Nothing", MinScore: 3})
+	assert.ErrorIs(t, err, domain.ErrInvalidPenaltyThreshold)
+	mockPenaltyRepo.AssertNotCalled(t, "CreateThreshold", mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CreateReservation_PenaltyRestrictions(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	penaltyService := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, penaltyService)
+
+	ctx := context.Background()
+	user := newPenaltyUser(3)
+	seven, twelve := 7, 12
+	threshold := &domain.PenaltyThreshold{MinScore: 3, MaxAdvanceDays: &seven, PremiumMinCapacity: &twelve, IsActive: true}
+	large := 20
+	boardRoom := &domain.Resource{ID: uuid.New(), Name: "Board Room", Type: domain.ResourceTypeMeetingRoom, Capacity: &large, IsActive: true}
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockPenaltyRepo.On("ListThresholds", ctx).Return([]*domain.PenaltyThreshold{threshold}, nil)
+	mockResourceRepo.On("GetByID", ctx, boardRoom.ID).Return(boardRoom, nil)
+
+	// 高額会議室は予約できない
+	startAt := time.Now().Add(24 * time.Hour)
+	_, err := svc.CreateReservation(ctx, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{boardRoom.ID},
+		Title:       "Planning",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, service.ErrPenaltyRestricted)
+
+	// 予約可能期間を超える予約はできない
+	projector := &domain.Resource{ID: uuid.New(), Name: "Projector", Type: domain.ResourceTypeEquipment, IsActive: true}
+	mockResourceRepo.On("GetByID", ctx, projector.ID).Return(projector, nil)
+	mockResourceRepo.On("FindAvailable", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]*domain.Resource{projector}, nil)
+
+	startAt = time.Now().AddDate(0, 0, 10)
+	_, err = svc.CreateReservation(ctx, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{projector.ID},
+		Title:       "Planning",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, service.ErrPenaltyAdvanceWindow)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHoldService_PlaceHold_PenaltyRestricted(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockHoldRepo := new(MockHoldRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	penaltyService := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, penaltyService)
+	svc := service.NewHoldService(mockHoldRepo, mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, reservationService, 0)
+
+	ctx := context.Background()
+	user := newPenaltyUser(3)
+	twelve := 12
+	threshold := &domain.PenaltyThreshold{MinScore: 3, PremiumMinCapacity: &twelve, IsActive: true}
+	large := 20
+	boardRoom := &domain.Resource{ID: uuid.New(), Name: "Board Room", Type: domain.ResourceTypeMeetingRoom, Capacity: &large, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockPenaltyRepo.On("ListThresholds", ctx).Return([]*domain.PenaltyThreshold{threshold}, nil)
+	mockHoldRepo.On("ListActiveByUser", ctx, user.ID).Return([]*domain.ResourceHold{}, nil)
+	mockResourceRepo.On("GetByID", ctx, boardRoom.ID).Return(boardRoom, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, startAt.Add(time.Hour)).Return([]*domain.Resource{boardRoom}, nil)
+
+	// 予約できない高額会議室は仮押さえもできない
+	_, err := svc.PlaceHold(ctx, &service.PlaceHoldRequest{
+		UserID:      user.ID,
+		ResourceIDs: []uuid.UUID{boardRoom.ID},
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+	})
+	assert.ErrorIs(t, err, service.ErrPenaltyRestricted)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockHoldRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CancelReservation_RecordsLatePenalty(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	penaltyService := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, penaltyService)
+
+	ctx := context.Background()
+	user := newPenaltyUser(0)
+	now := time.Now()
+	displaced := &domain.ReservationInstance{ID: uuid.New(), StartAt: now.Add(2 * time.Hour), EndAt: now.Add(3 * time.Hour), Status: domain.ReservationStatusConfirmed}
+	late := &domain.ReservationInstance{ID: uuid.New(), StartAt: now.Add(20 * time.Hour), EndAt: now.Add(21 * time.Hour), Status: domain.ReservationStatusConfirmed}
+	future := &domain.ReservationInstance{ID: uuid.New(), StartAt: now.Add(7 * 24 * time.Hour), EndAt: now.Add(7*24*time.Hour + time.Hour), Status: domain.ReservationStatusConfirmed}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: user.ID, Title: "Weekly sync", StartAt: displaced.StartAt, ApprovalStatus: domain.ApprovalStatusConfirmed}
+
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, reservation.StartAt).Return(reservation, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, reservation.ID).Return([]*domain.ReservationInstance{displaced, late, future}, nil)
+	mockBumpRepo.On("ListDisplacedInstanceIDs", ctx, reservation.ID).Return([]uuid.UUID{displaced.ID}, nil)
+	mockPenaltyRepo.On("CreateEvent", ctx, mock.MatchedBy(func(e *domain.PenaltyEvent) bool {
+		return e.UserID == user.ID && e.Kind == domain.PenaltyEventLateCancellation && e.Points == 1 &&
+			*e.ReservationID == reservation.ID && e.ReservationStartAt.Equal(late.StartAt) &&
+			e.ExpiresAt.Equal(e.OccurredAt.Add(domain.PenaltyScoreRotation))
+	})).Return(nil)
+	mockPenaltyRepo.On("RecalculateScore", ctx, user.ID).Return(1, nil, nil)
+	mockReservationRepo.On("Delete", ctx, reservation.ID, reservation.StartAt).Return(nil)
+
+	// 優先確保で押しのけられた回は対象外とし、次の直前の回を原因とする
+	err := svc.CancelReservation(ctx, reservation.ID, reservation.StartAt, user.ID)
+	assert.NoError(t, err)
+	mockPenaltyRepo.AssertExpectations(t)
+	mockReservationRepo.AssertExpectations(t)
+	mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(log *domain.AuditLog) bool {
+		return log.Action == domain.AuditActionCancelWithPenalty && log.UserID == user.ID
+	}))
+}
+
+func TestReservationService_CancelReservation_NoLatePenalty(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		status    domain.ApprovalStatus
+		instances []*domain.ReservationInstance
+	}{
+		{
+			name:   "Cancelled more than 24 hours ahead",
+			status: domain.ApprovalStatusConfirmed,
+			instances: []*domain.ReservationInstance{
+				{ID: uuid.New(), StartAt: now.Add(48 * time.Hour), EndAt: now.Add(49 * time.Hour), Status: domain.ReservationStatusConfirmed},
+			},
+		},
+		{
+			// 承認待ちの予約のキャンセルは対象外（回の判定も行わない）
+			name:   "Pending approval",
+			status: domain.ApprovalStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPenaltyRepo := new(MockPenaltyRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockReservationRepo := new(MockReservationRepository)
+			mockBumpRepo := new(MockBumpRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			mockResourceRepo := new(MockResourceRepository)
+			mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			penaltyService := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+			svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, penaltyService)
+
+			ctx := context.Background()
+			user := newPenaltyUser(0)
+			startAt := now.Add(48 * time.Hour)
+			reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: user.ID, Title: "Planning", StartAt: startAt, ApprovalStatus: tt.status}
+
+			mockReservationRepo.On("GetByID", ctx, reservation.ID, startAt).Return(reservation, nil)
+			if tt.instances != nil {
+				mockReservationRepo.On("GetInstancesByReservationID", ctx, reservation.ID).Return(tt.instances, nil)
+				mockBumpRepo.On("ListDisplacedInstanceIDs", ctx, reservation.ID).Return([]uuid.UUID{}, nil)
+			}
+			mockReservationRepo.On("Delete", ctx, reservation.ID, startAt).Return(nil)
+
+			err := svc.CancelReservation(ctx, reservation.ID, startAt, user.ID)
+			assert.NoError(t, err)
+			mockReservationRepo.AssertExpectations(t)
+			mockPenaltyRepo.AssertNotCalled(t, "CreateEvent", mock.Anything, mock.Anything)
+			mockAuditLogRepo.AssertNotCalled(t, "Create", ctx, mock.MatchedBy(func(log *domain.AuditLog) bool {
+				return log.Action == domain.AuditActionCancelWithPenalty
+			}))
+		})
+	}
+}
+
+func TestReservationService_CancelReservation_DeleteFailedSkipsPenalty(t *testing.T) {
+	mockPenaltyRepo := new(MockPenaltyRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockBumpRepo := new(MockBumpRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	penaltyService := service.NewPenaltyService(mockPenaltyRepo, mockUserRepo, mockReservationRepo, mockBumpRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, penaltyService)
+
+	ctx := context.Background()
+	user := newPenaltyUser(0)
+	startAt := time.Now().Add(2 * time.Hour)
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: user.ID, Title: "Standup", StartAt: startAt, ApprovalStatus: domain.ApprovalStatusConfirmed}
+
+	mockReservationRepo.On("GetByID", ctx, reservation.ID, startAt).Return(reservation, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, reservation.ID).Return([]*domain.ReservationInstance{
+		{ID: uuid.New(), StartAt: startAt, EndAt: startAt.Add(time.Hour), Status: domain.ReservationStatusConfirmed},
+	}, nil)
+	mockBumpRepo.On("ListDisplacedInstanceIDs", ctx, reservation.ID).Return([]uuid.UUID{}, nil)
+	mockReservationRepo.On("Delete", ctx, reservation.ID, startAt).Return(assert.AnError)
+
+	// 削除できなかったキャンセルではペナルティスコアを加算しない
+	err := svc.CancelReservation(ctx, reservation.ID, startAt, user.ID)
+	assert.ErrorIs(t, err, assert.AnError)
+	mockPenaltyRepo.AssertNotCalled(t, "CreateEvent", mock.Anything, mock.Anything)
+	mockPenaltyRepo.AssertNotCalled(t, "RecalculateScore", mock.Anything, mock.Anything)
+}
+
+func TestApprovalService_WithManagerApproval(t *testing.T) {
+	svc := service.NewApprovalService(nil, nil, nil, nil, nil, nil, nil)
+
+	managerID := uuid.New()
+	organizer := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, ManagerID: &managerID}
+	reservation := &domain.Reservation{ID: uuid.New(), OrganizerID: organizer.ID}
+	ownerID := uuid.New()
+	activatedAt := time.Now()
+	chain := []*domain.ReservationApproval{{ID: uuid.New(), StepOrder: 1, ApproverID: &ownerID, ActivatedAt: &activatedAt}}
+
+	chain, err := svc.WithManagerApproval(reservation, chain, organizer)
+	assert.NoError(t, err)
+	assert.Len(t, chain, 2)
+	assert.Equal(t, managerID, *chain[0].ApproverID)
+	assert.NotNil(t, chain[0].ActivatedAt)
+	assert.Equal(t, 2, chain[1].StepOrder)
+	assert.Nil(t, chain[1].ActivatedAt)
+
+	// 上長が既にチェーンに含まれる場合は加えない
+	again, err := svc.WithManagerApproval(reservation, chain, organizer)
+	assert.NoError(t, err)
+	assert.Len(t, again, 2)
+
+	// 上長が未登録のユーザーは承認チェーンを解決できない
+	_, err = svc.WithManagerApproval(reservation, nil, &domain.User{ID: uuid.New()})
+	assert.ErrorIs(t, err, service.ErrApprovalChainUnresolved)
+}
@@ -0,0 +1,500 @@
+// backend/internal/service/provisioning_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrProvisioningConflict = errors.New("a user or group with the same identifier already exists")
+	ErrInvalidProvisioning  = errors.New("invalid provisioning request")
+)
+
+// provisioningSource は監査ログに記録するプロビジョニング元
+const provisioningSource = "SCIM"
+
+// ProvisioningService はIdPのコネクターからSCIMで送られるユーザー・グループの変更を反映するサービス
+// ユーザーは未連携（issuer, sub なし）で作成し、初回ログイン時にメールアドレスで紐付けます
+// グループはロールの判定に使用し、RoleMapping のルールをグループの表示名・externalId と照合します
+// （ロールの管理元がIdPの場合のみ。LOCAL の場合はグループを記録するのみでロールは変更しません）
+type ProvisioningService struct {
+	userRepo     repository.UserRepository
+	groupRepo    repository.ProvisionedGroupRepository
+	auditLogRepo repository.AuditLogRepository
+	sessionStore SessionStore
+	roleMapping  RoleMapping
+}
+
+// NewProvisioningService は新しいProvisioningServiceを作成します
+func NewProvisioningService(
+	userRepo repository.UserRepository,
+	groupRepo repository.ProvisionedGroupRepository,
+	auditLogRepo repository.AuditLogRepository,
+	sessionStore SessionStore,
+	roleMapping RoleMapping,
+) *ProvisioningService {
+	return &ProvisioningService{
+		userRepo:     userRepo,
+		groupRepo:    groupRepo,
+		auditLogRepo: auditLogRepo,
+		sessionStore: sessionStore,
+		roleMapping:  roleMapping,
+	}
+}
+
+// ListUsers は削除されていないユーザーを絞り込んで取得します
+func (s *ProvisioningService) ListUsers(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	return s.userRepo.List(ctx, filter, offset, limit)
+}
+
+// GetUser はユーザーを取得します（削除されたユーザーは repository.ErrNotFound）
+func (s *ProvisioningService) GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, repository.ErrNotFound
+	}
+	return user, nil
+}
+
+// CreateUser はIdPから送られたユーザーを作成します
+// メールアドレス・externalId が既存のユーザーと重複する場合は ErrProvisioningConflict を返します
+func (s *ProvisioningService) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	if err := s.validateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	created := &domain.User{
+		ID:         uuid.New(),
+		ExternalID: user.ExternalID,
+		Email:      user.Email,
+		Name:       user.Name,
+		Role:       domain.RoleGeneral,
+		ManagerID:  user.ManagerID,
+		Department: user.Department,
+		IsActive:   user.IsActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.userRepo.Create(ctx, created); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, domain.AuditActionProvisionUserCreate, "user", created.ID, map[string]interface{}{
+		"email":       created.Email,
+		"external_id": created.ExternalID,
+		"active":      created.IsActive,
+		"department":  created.Department,
+		"manager_id":  created.ManagerID,
+	})
+	return created, nil
+}
+
+// UpdateUser はIdPから送られた属性（メールアドレス・表示名・externalId・部署・上長・有効フラグ）でユーザーを更新します
+// ロール・IdPとの紐付け・ペナルティスコアは変更しません
+// 無効化されたユーザーのセッションは全て削除します
+func (s *ProvisioningService) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	current, err := s.GetUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	changes := map[string]interface{}{}
+	if current.Email != user.Email {
+		changes["email"] = map[string]interface{}{"from": current.Email, "to": user.Email}
+		current.Email = user.Email
+	}
+	if current.Name != user.Name {
+		changes["name"] = map[string]interface{}{"from": current.Name, "to": user.Name}
+		current.Name = user.Name
+	}
+	if current.ExternalID != user.ExternalID {
+		changes["external_id"] = map[string]interface{}{"from": current.ExternalID, "to": user.ExternalID}
+		current.ExternalID = user.ExternalID
+	}
+	if stringValue(current.Department) != stringValue(user.Department) {
+		changes["department"] = map[string]interface{}{"from": current.Department, "to": user.Department}
+		current.Department = user.Department
+	}
+	if !sameUserID(current.ManagerID, user.ManagerID) {
+		changes["manager_id"] = map[string]interface{}{"from": current.ManagerID, "to": user.ManagerID}
+		current.ManagerID = user.ManagerID
+	}
+	deactivated := current.IsActive && !user.IsActive
+	if current.IsActive != user.IsActive {
+		changes["active"] = map[string]interface{}{"from": current.IsActive, "to": user.IsActive}
+		current.IsActive = user.IsActive
+	}
+	if len(changes) == 0 {
+		return current, nil
+	}
+
+	if err := s.userRepo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, domain.AuditActionProvisionUserUpdate, "user", current.ID, map[string]interface{}{
+		"email":   current.Email,
+		"changes": changes,
+	})
+
+	if deactivated {
+		s.deactivate(ctx, current, domain.AuditActionProvisionUserDeactivate)
+	}
+	return current, nil
+}
+
+// DeleteUser はユーザーを論理削除します（予約・監査ログの参照を残すため物理削除はしません）
+// セッションを全て削除し、所属していたグループから外します
+func (s *ProvisioningService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.IsActive = false
+	user.DeletedAt = &now
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	groups, err := s.groupRepo.ListByMember(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list groups of user: %w", err)
+	}
+	for _, group := range groups {
+		group.MemberIDs = removeUserID(group.MemberIDs, user.ID)
+		if err := s.groupRepo.Update(ctx, group); err != nil {
+			return fmt.Errorf("failed to remove user from group: %w", err)
+		}
+	}
+
+	s.deactivate(ctx, user, domain.AuditActionProvisionUserDelete)
+	return nil
+}
+
+// deactivate は無効化・削除したユーザーのセッションを全て削除し、監査ログを記録します
+func (s *ProvisioningService) deactivate(ctx context.Context, user *domain.User, action domain.AuditAction) {
+	revoked, err := s.sessionStore.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		log.Printf("Warning: failed to revoke sessions of deprovisioned user %s: %v", user.ID, err)
+	}
+	s.audit(ctx, action, "user", user.ID, map[string]interface{}{
+		"email":            user.Email,
+		"revoked_sessions": revoked,
+	})
+}
+
+// validateUser はユーザーの属性を検証し、メールアドレス・externalId の重複と上長の存在を確認します
+func (s *ProvisioningService) validateUser(ctx context.Context, user *domain.User) error {
+	if user.Email == "" {
+		return fmt.Errorf("%w: email is required", ErrInvalidProvisioning)
+	}
+	if user.Name == "" {
+		user.Name = user.Email
+	}
+
+	others, err := s.userRepo.ListByEmail(ctx, user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to list users by email: %w", err)
+	}
+	if len(excludeUser(others, user.ID)) > 0 {
+		return fmt.Errorf("%w: email %s", ErrProvisioningConflict, user.Email)
+	}
+
+	if user.ExternalID != "" {
+		externalID := user.ExternalID
+		others, _, err := s.userRepo.List(ctx, repository.UserFilter{ExternalID: &externalID}, 0, 2)
+		if err != nil {
+			return fmt.Errorf("failed to list users by external id: %w", err)
+		}
+		if len(excludeUser(others, user.ID)) > 0 {
+			return fmt.Errorf("%w: externalId %s", ErrProvisioningConflict, user.ExternalID)
+		}
+	}
+
+	if user.ManagerID != nil {
+		if *user.ManagerID == user.ID {
+			return fmt.Errorf("%w: a user cannot be their own manager", ErrInvalidProvisioning)
+		}
+		if _, err := s.GetUser(ctx, *user.ManagerID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%w: manager %s not found", ErrInvalidProvisioning, user.ManagerID)
+			}
+			return fmt.Errorf("failed to get manager: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListGroups はグループを絞り込んで取得します
+func (s *ProvisioningService) ListGroups(ctx context.Context, filter repository.ProvisionedGroupFilter, offset, limit int) ([]*domain.ProvisionedGroup, int64, error) {
+	return s.groupRepo.List(ctx, filter, offset, limit)
+}
+
+// GetGroup はグループを取得します
+func (s *ProvisioningService) GetGroup(ctx context.Context, id uuid.UUID) (*domain.ProvisionedGroup, error) {
+	return s.groupRepo.GetByID(ctx, id)
+}
+
+// ListUserGroups はユーザーが所属するグループを取得します
+func (s *ProvisioningService) ListUserGroups(ctx context.Context, userID uuid.UUID) ([]*domain.ProvisionedGroup, error) {
+	return s.groupRepo.ListByMember(ctx, userID)
+}
+
+// CreateGroup はIdPから送られたグループを作成し、メンバーのロールを同期します
+func (s *ProvisioningService) CreateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error) {
+	if err := s.validateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	created := &domain.ProvisionedGroup{
+		ID:          uuid.New(),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		MemberIDs:   uniqueUserIDs(group.MemberIDs),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.groupRepo.Create(ctx, created); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, domain.AuditActionProvisionGroupCreate, "provisioned_group", created.ID, map[string]interface{}{
+		"display_name": created.DisplayName,
+		"external_id":  created.ExternalID,
+		"members":      created.MemberIDs,
+	})
+	s.syncGroupRoles(ctx, created.MemberIDs)
+	return created, nil
+}
+
+// UpdateGroup はIdPから送られた表示名・externalId・メンバーでグループを更新し、影響を受けるユーザーのロールを同期します
+func (s *ProvisioningService) UpdateGroup(ctx context.Context, group *domain.ProvisionedGroup) (*domain.ProvisionedGroup, error) {
+	current, err := s.groupRepo.GetByID(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	members := uniqueUserIDs(group.MemberIDs)
+	var added, removed []uuid.UUID
+	for _, id := range members {
+		if !current.HasMember(id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range current.MemberIDs {
+		if !containsUserID(members, id) {
+			removed = append(removed, id)
+		}
+	}
+	renamed := current.DisplayName != group.DisplayName || current.ExternalID != group.ExternalID
+	if !renamed && len(added) == 0 && len(removed) == 0 {
+		return current, nil
+	}
+
+	details := map[string]interface{}{
+		"display_name": group.DisplayName,
+	}
+	if renamed {
+		details["previous_display_name"] = current.DisplayName
+		details["external_id"] = group.ExternalID
+	}
+	if len(added) > 0 {
+		details["added_members"] = added
+	}
+	if len(removed) > 0 {
+		details["removed_members"] = removed
+	}
+
+	current.DisplayName = group.DisplayName
+	current.ExternalID = group.ExternalID
+	current.MemberIDs = members
+	if err := s.groupRepo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, domain.AuditActionProvisionGroupUpdate, "provisioned_group", current.ID, details)
+
+	// グループ名が変わった場合はルールとの一致が変わるため全メンバーを同期する
+	affected := append(added, removed...)
+	if renamed {
+		affected = append(affected, members...)
+	}
+	s.syncGroupRoles(ctx, uniqueUserIDs(affected))
+	return current, nil
+}
+
+// DeleteGroup はグループを削除し、元のメンバーのロールを同期します
+func (s *ProvisioningService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.groupRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, domain.AuditActionProvisionGroupDelete, "provisioned_group", group.ID, map[string]interface{}{
+		"display_name": group.DisplayName,
+		"members":      group.MemberIDs,
+	})
+	s.syncGroupRoles(ctx, group.MemberIDs)
+	return nil
+}
+
+// validateGroup はグループの属性を検証し、表示名・externalId の重複とメンバーの存在を確認します
+func (s *ProvisioningService) validateGroup(ctx context.Context, group *domain.ProvisionedGroup) error {
+	if group.DisplayName == "" {
+		return fmt.Errorf("%w: displayName is required", ErrInvalidProvisioning)
+	}
+
+	displayName := group.DisplayName
+	filters := []repository.ProvisionedGroupFilter{{DisplayName: &displayName}}
+	if group.ExternalID != "" {
+		externalID := group.ExternalID
+		filters = append(filters, repository.ProvisionedGroupFilter{ExternalID: &externalID})
+	}
+	for _, filter := range filters {
+		others, _, err := s.groupRepo.List(ctx, filter, 0, 2)
+		if err != nil {
+			return fmt.Errorf("failed to list groups: %w", err)
+		}
+		for _, other := range others {
+			if other.ID != group.ID {
+				return fmt.Errorf("%w: group %s", ErrProvisioningConflict, group.DisplayName)
+			}
+		}
+	}
+
+	for _, memberID := range group.MemberIDs {
+		if _, err := s.GetUser(ctx, memberID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return fmt.Errorf("%w: member %s not found", ErrInvalidProvisioning, memberID)
+			}
+			return fmt.Errorf("failed to get member: %w", err)
+		}
+	}
+	return nil
+}
+
+// syncGroupRoles はユーザーの所属グループからロールを決定して反映します
+// ロールの管理元がIdPでない場合やルールが設定されていない場合は何もしません
+func (s *ProvisioningService) syncGroupRoles(ctx context.Context, userIDs []uuid.UUID) {
+	if s.roleMapping.Source != RoleSourceIDP {
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := s.syncGroupRole(ctx, userID); err != nil {
+			log.Printf("Warning: failed to sync role of user %s from provisioned groups: %v", userID, err)
+		}
+	}
+}
+
+func (s *ProvisioningService) syncGroupRole(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	groups, err := s.groupRepo.ListByMember(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list groups of user: %w", err)
+	}
+
+	var values, names []string
+	for _, group := range groups {
+		values = append(values, group.RoleValues()...)
+		names = append(names, group.DisplayName)
+	}
+	role, ok := s.roleMapping.ResolveRoleFromGroups(values)
+	if !ok || role == user.Role {
+		return nil
+	}
+
+	previousRole := user.Role
+	user.Role = role
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	s.audit(ctx, domain.AuditActionRoleSync, "user", user.ID, map[string]interface{}{
+		"email":         user.Email,
+		"previous_role": previousRole,
+		"role":          role,
+		"groups":        names,
+		"source":        provisioningSource,
+	})
+	return nil
+}
+
+// audit はプロビジョニング（IdPのコネクター）による変更の監査ログを記録します
+func (s *ProvisioningService) audit(ctx context.Context, action domain.AuditAction, targetType string, targetID uuid.UUID, details map[string]interface{}) {
+	details["source"] = provisioningSource
+	_ = s.auditLogRepo.Create(ctx, &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     domain.SystemUserID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// stringValue は任意の文字列の値を返します（nil の場合は空文字列）
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// uniqueUserIDs は重複を除いたユーザーIDを返します（順序は維持します）
+func uniqueUserIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := []uuid.UUID{}
+	for _, id := range ids {
+		if !containsUserID(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func containsUserID(ids []uuid.UUID, target uuid.UUID) bool {
+	for _, id := range ids {
+		if id == target {
+			return true
+		}
+	}
+	return false
+}
+
+func removeUserID(ids []uuid.UUID, target uuid.UUID) []uuid.UUID {
+	kept := []uuid.UUID{}
+	for _, id := range ids {
+		if id != target {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
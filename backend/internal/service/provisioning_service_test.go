@@ -0,0 +1,312 @@
+// backend/internal/service/provisioning_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// auditActions は記録された監査ログのアクションを順に返します
+func auditActions(m *MockAuditLogRepository) []domain.AuditAction {
+	var actions []domain.AuditAction
+	for _, call := range m.Calls {
+		if call.Method == "Create" {
+			actions = append(actions, call.Arguments.Get(1).(*domain.AuditLog).Action)
+		}
+	}
+	return actions
+}
+
+func TestProvisioningService_CreateUser(t *testing.T) {
+	managerID := uuid.New()
+	department := "営業部"
+
+	tests := []struct {
+		name       string
+		user       *domain.User
+		setupMocks func(*MockUserRepository)
+		wantErr    error
+	}{
+		{
+			name: "Provisioned user is created unlinked",
+			user: &domain.User{Email: "taro@example.com", Name: "山田 太郎", ExternalID: "00u1ab2cd3", Department: &department, ManagerID: &managerID, IsActive: true},
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{}, nil)
+				mu.On("List", mock.Anything, mock.Anything, 0, 2).Return([]*domain.User{}, int64(0), nil)
+				mu.On("GetByID", mock.Anything, managerID).Return(&domain.User{ID: managerID, IsActive: true}, nil)
+				mu.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Email == "taro@example.com" && u.ExternalID == "00u1ab2cd3" && !u.IsLinked() &&
+						u.Role == domain.RoleGeneral && *u.Department == "営業部" && *u.ManagerID == managerID && u.IsActive
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Duplicate email",
+			user: &domain.User{Email: "taro@example.com", IsActive: true},
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{{ID: uuid.New()}}, nil)
+			},
+			wantErr: service.ErrProvisioningConflict,
+		},
+		{
+			name: "Duplicate externalId",
+			user: &domain.User{Email: "taro@example.com", ExternalID: "00u1ab2cd3", IsActive: true},
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{}, nil)
+				mu.On("List", mock.Anything, mock.MatchedBy(func(f repository.UserFilter) bool {
+					return f.ExternalID != nil && *f.ExternalID == "00u1ab2cd3"
+				}), 0, 2).Return([]*domain.User{{ID: uuid.New()}}, int64(1), nil)
+			},
+			wantErr: service.ErrProvisioningConflict,
+		},
+		{
+			name: "Unknown manager",
+			user: &domain.User{Email: "taro@example.com", ManagerID: &managerID, IsActive: true},
+			setupMocks: func(mu *MockUserRepository) {
+				mu.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{}, nil)
+				mu.On("GetByID", mock.Anything, managerID).Return(nil, repository.ErrNotFound)
+			},
+			wantErr: service.ErrInvalidProvisioning,
+		},
+		{
+			name:       "Missing email",
+			user:       &domain.User{Name: "No Email"},
+			setupMocks: func(mu *MockUserRepository) {},
+			wantErr:    service.ErrInvalidProvisioning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+			svc := service.NewProvisioningService(mockUserRepo, new(MockProvisionedGroupRepository), mockAuditRepo, service.NewMemorySessionStore(), service.RoleMapping{})
+			tt.setupMocks(mockUserRepo)
+			mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			user, err := svc.CreateUser(context.Background(), tt.user)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				assert.Empty(t, auditActions(mockAuditRepo))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, user.ID)
+			assert.Equal(t, []domain.AuditAction{domain.AuditActionProvisionUserCreate}, auditActions(mockAuditRepo))
+			assert.Equal(t, domain.SystemUserID, mockAuditRepo.Calls[0].Arguments.Get(1).(*domain.AuditLog).UserID)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProvisioningService_UpdateUser_Deactivate(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	store := service.NewMemorySessionStore()
+	svc := service.NewProvisioningService(mockUserRepo, new(MockProvisionedGroupRepository), mockAuditRepo, store, service.RoleMapping{})
+	ctx := context.Background()
+
+	current := &domain.User{ID: uuid.New(), Email: "taro@example.com", Name: "山田 太郎", Role: domain.RoleManager, IsActive: true}
+	require.NoError(t, store.SaveSession(ctx, "session-1", &service.Session{ID: "session-1", UserID: current.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	mockUserRepo.On("GetByID", mock.Anything, current.ID).Return(current, nil)
+	mockUserRepo.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{current}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		// ロールはプロビジョニングでは変更しない
+		return u.ID == current.ID && !u.IsActive && u.Role == domain.RoleManager
+	})).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	user, err := svc.UpdateUser(ctx, &domain.User{ID: current.ID, Email: "taro@example.com", Name: "山田 太郎", Role: domain.RoleAdmin, IsActive: false})
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	// 無効化したユーザーのセッションは全て削除する
+	_, err = store.GetSession(ctx, "session-1")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionProvisionUserUpdate, domain.AuditActionProvisionUserDeactivate}, auditActions(mockAuditRepo))
+	deactivation := mockAuditRepo.Calls[1].Arguments.Get(1).(*domain.AuditLog)
+	assert.Equal(t, 1, deactivation.Details["revoked_sessions"])
+	assert.Equal(t, "SCIM", deactivation.Details["source"])
+}
+
+func TestProvisioningService_UpdateUser_Unchanged(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	svc := service.NewProvisioningService(mockUserRepo, new(MockProvisionedGroupRepository), mockAuditRepo, service.NewMemorySessionStore(), service.RoleMapping{})
+
+	current := &domain.User{ID: uuid.New(), Email: "taro@example.com", Name: "山田 太郎", IsActive: true}
+	mockUserRepo.On("GetByID", mock.Anything, current.ID).Return(current, nil)
+	mockUserRepo.On("ListByEmail", mock.Anything, "taro@example.com").Return([]*domain.User{current}, nil)
+
+	_, err := svc.UpdateUser(context.Background(), &domain.User{ID: current.ID, Email: "taro@example.com", Name: "山田 太郎", IsActive: true})
+	assert.NoError(t, err)
+	mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	assert.Empty(t, auditActions(mockAuditRepo))
+}
+
+func TestProvisioningService_UpdateUser_Deleted(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewProvisioningService(mockUserRepo, new(MockProvisionedGroupRepository), new(MockAuditLogRepository), service.NewMemorySessionStore(), service.RoleMapping{})
+
+	deletedAt := time.Now()
+	id := uuid.New()
+	mockUserRepo.On("GetByID", mock.Anything, id).Return(&domain.User{ID: id, Email: "taro@example.com", DeletedAt: &deletedAt}, nil)
+
+	_, err := svc.UpdateUser(context.Background(), &domain.User{ID: id, Email: "taro@example.com", IsActive: true})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestProvisioningService_DeleteUser(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockGroupRepo := new(MockProvisionedGroupRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	store := service.NewMemorySessionStore()
+	svc := service.NewProvisioningService(mockUserRepo, mockGroupRepo, mockAuditRepo, store, service.RoleMapping{})
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "taro@example.com", IsActive: true}
+	other := uuid.New()
+	group := &domain.ProvisionedGroup{ID: uuid.New(), DisplayName: "esms-managers", MemberIDs: []uuid.UUID{user.ID, other}}
+	require.NoError(t, store.SaveSession(ctx, "session-1", &service.Session{ID: "session-1", UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == user.ID && !u.IsActive && u.DeletedAt != nil
+	})).Return(nil)
+	mockGroupRepo.On("ListByMember", mock.Anything, user.ID).Return([]*domain.ProvisionedGroup{group}, nil)
+	mockGroupRepo.On("Update", mock.Anything, mock.MatchedBy(func(g *domain.ProvisionedGroup) bool {
+		return g.ID == group.ID && len(g.MemberIDs) == 1 && g.MemberIDs[0] == other
+	})).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, svc.DeleteUser(ctx, user.ID))
+
+	_, err := store.GetSession(ctx, "session-1")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionProvisionUserDelete}, auditActions(mockAuditRepo))
+	mockUserRepo.AssertExpectations(t)
+	mockGroupRepo.AssertExpectations(t)
+}
+
+func TestProvisioningService_GroupRoleSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		wantRole domain.Role
+	}{
+		{name: "IdP-managed roles follow group membership", source: "IDP", wantRole: domain.RoleAdmin},
+		{name: "Locally-managed roles are kept", source: "LOCAL", wantRole: domain.RoleGeneral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping, err := service.ParseRoleMapping("groups", "esms-admins=ADMIN,esms-managers=MANAGER", "", tt.source)
+			require.NoError(t, err)
+
+			mockUserRepo := new(MockUserRepository)
+			mockGroupRepo := new(MockProvisionedGroupRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+			svc := service.NewProvisioningService(mockUserRepo, mockGroupRepo, mockAuditRepo, service.NewMemorySessionStore(), mapping)
+			ctx := context.Background()
+
+			user := &domain.User{ID: uuid.New(), Email: "taro@example.com", Role: domain.RoleGeneral, IsActive: true}
+			mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+			mockGroupRepo.On("List", mock.Anything, mock.Anything, 0, 2).Return([]*domain.ProvisionedGroup{}, int64(0), nil)
+			mockGroupRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			// Microsoft Entra ID のグループのクレームはオブジェクトIDのため externalId とも照合する
+			mockGroupRepo.On("ListByMember", mock.Anything, user.ID).Return([]*domain.ProvisionedGroup{
+				{DisplayName: "all-staff"},
+				{DisplayName: "ESMS Administrators", ExternalID: "esms-admins"},
+			}, nil)
+			mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Role == domain.RoleAdmin
+			})).Return(nil).Maybe()
+			mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			group, err := svc.CreateGroup(ctx, &domain.ProvisionedGroup{DisplayName: "ESMS Administrators", ExternalID: "esms-admins", MemberIDs: []uuid.UUID{user.ID, user.ID}})
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{user.ID}, group.MemberIDs)
+			assert.Equal(t, tt.wantRole, user.Role)
+
+			wantActions := []domain.AuditAction{domain.AuditActionProvisionGroupCreate}
+			if tt.wantRole != domain.RoleGeneral {
+				wantActions = append(wantActions, domain.AuditActionRoleSync)
+			}
+			assert.Equal(t, wantActions, auditActions(mockAuditRepo))
+		})
+	}
+}
+
+func TestProvisioningService_UpdateGroup_RemoveMember(t *testing.T) {
+	mapping, err := service.ParseRoleMapping("groups", "esms-managers=MANAGER", "", "IDP")
+	require.NoError(t, err)
+
+	mockUserRepo := new(MockUserRepository)
+	mockGroupRepo := new(MockProvisionedGroupRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	svc := service.NewProvisioningService(mockUserRepo, mockGroupRepo, mockAuditRepo, service.NewMemorySessionStore(), mapping)
+	ctx := context.Background()
+
+	kept := &domain.User{ID: uuid.New(), Email: "kept@example.com", Role: domain.RoleManager, IsActive: true}
+	removed := &domain.User{ID: uuid.New(), Email: "removed@example.com", Role: domain.RoleManager, IsActive: true}
+	current := &domain.ProvisionedGroup{ID: uuid.New(), DisplayName: "esms-managers", MemberIDs: []uuid.UUID{kept.ID, removed.ID}}
+
+	mockGroupRepo.On("GetByID", mock.Anything, current.ID).Return(current, nil)
+	mockGroupRepo.On("List", mock.Anything, mock.Anything, 0, 2).Return([]*domain.ProvisionedGroup{current}, int64(1), nil)
+	mockUserRepo.On("GetByID", mock.Anything, kept.ID).Return(kept, nil)
+	mockUserRepo.On("GetByID", mock.Anything, removed.ID).Return(removed, nil)
+	mockGroupRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockGroupRepo.On("ListByMember", mock.Anything, removed.ID).Return([]*domain.ProvisionedGroup{}, nil)
+	mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == removed.ID && u.Role == domain.RoleGeneral
+	})).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err = svc.UpdateGroup(ctx, &domain.ProvisionedGroup{ID: current.ID, DisplayName: "esms-managers", MemberIDs: []uuid.UUID{kept.ID}})
+	require.NoError(t, err)
+
+	// 外れたメンバーのみ同期する
+	assert.Equal(t, domain.RoleManager, kept.Role)
+	assert.Equal(t, domain.RoleGeneral, removed.Role)
+	mockGroupRepo.AssertNotCalled(t, "ListByMember", mock.Anything, kept.ID)
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionProvisionGroupUpdate, domain.AuditActionRoleSync}, auditActions(mockAuditRepo))
+	update := mockAuditRepo.Calls[0].Arguments.Get(1).(*domain.AuditLog)
+	assert.Equal(t, []uuid.UUID{removed.ID}, update.Details["removed_members"])
+}
+
+func TestProvisioningService_CreateGroup_Invalid(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	mockGroupRepo := new(MockProvisionedGroupRepository)
+	svc := service.NewProvisioningService(mockUserRepo, mockGroupRepo, new(MockAuditLogRepository), service.NewMemorySessionStore(), service.RoleMapping{})
+	ctx := context.Background()
+
+	existing := &domain.ProvisionedGroup{ID: uuid.New(), DisplayName: "esms-admins"}
+	unknown := uuid.New()
+	mockGroupRepo.On("List", mock.Anything, mock.MatchedBy(func(f repository.ProvisionedGroupFilter) bool {
+		return f.DisplayName != nil && *f.DisplayName == "esms-admins"
+	}), 0, 2).Return([]*domain.ProvisionedGroup{existing}, int64(1), nil)
+	mockGroupRepo.On("List", mock.Anything, mock.Anything, 0, 2).Return([]*domain.ProvisionedGroup{}, int64(0), nil)
+	mockUserRepo.On("GetByID", mock.Anything, unknown).Return(nil, repository.ErrNotFound)
+
+	_, err := svc.CreateGroup(ctx, &domain.ProvisionedGroup{DisplayName: "esms-admins"})
+	assert.ErrorIs(t, err, service.ErrProvisioningConflict)
+
+	_, err = svc.CreateGroup(ctx, &domain.ProvisionedGroup{DisplayName: "esms-managers", MemberIDs: []uuid.UUID{unknown}})
+	assert.ErrorIs(t, err, service.ErrInvalidProvisioning)
+
+	_, err = svc.CreateGroup(ctx, &domain.ProvisionedGroup{})
+	assert.ErrorIs(t, err, service.ErrInvalidProvisioning)
+	mockGroupRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
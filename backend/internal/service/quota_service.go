@@ -0,0 +1,279 @@
+// backend/internal/service/quota_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("booking quota exceeded")
+)
+
+// QuotaExceededError は予約が予約上限を超える場合のエラー
+// 超過したルールの予約前の消費状況（残り）を Usage に含みます
+type QuotaExceededError struct {
+	Usage *domain.QuotaUsage
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("%s: %s (remaining %g of %d)", ErrQuotaExceeded, e.Usage.Name, e.Usage.Remaining, e.Usage.Limit)
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// QuotaService は予約上限（利用時間・同時予約数）に関するビジネスロジックを提供します
+type QuotaService struct {
+	quotaRepo    repository.QuotaRepository
+	userRepo     repository.UserRepository
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewQuotaService は新しいQuotaServiceを作成します
+func NewQuotaService(
+	quotaRepo repository.QuotaRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+) *QuotaService {
+	return &QuotaService{
+		quotaRepo:    quotaRepo,
+		userRepo:     userRepo,
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// CreateRule は予約上限のルールを作成します
+func (s *QuotaService) CreateRule(ctx context.Context, actorID uuid.UUID, rule *domain.QuotaRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	rule.ID = uuid.New()
+	rule.IsActive = true
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+
+	if err := s.quotaRepo.Create(ctx, rule); err != nil {
+		return fmt.Errorf("failed to create quota rule: %w", err)
+	}
+
+	s.recordAudit(ctx, actorID, domain.AuditActionCreate, rule.ID, map[string]interface{}{
+		"name":  rule.Name,
+		"scope": string(rule.Scope),
+		"kind":  string(rule.Kind),
+		"limit": rule.Limit,
+	})
+	return nil
+}
+
+// ListRules は予約上限のルールを全て返します
+func (s *QuotaService) ListRules(ctx context.Context) ([]*domain.QuotaRule, error) {
+	rules, err := s.quotaRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quota rules: %w", err)
+	}
+	return rules, nil
+}
+
+// DeleteRule は予約上限のルールを削除します
+func (s *QuotaService) DeleteRule(ctx context.Context, actorID, ruleID uuid.UUID) error {
+	if err := s.quotaRepo.Delete(ctx, ruleID); err != nil {
+		return fmt.Errorf("failed to delete quota rule: %w", err)
+	}
+	s.recordAudit(ctx, actorID, domain.AuditActionDelete, ruleID, nil)
+	return nil
+}
+
+// CheckReservation は予約の各回を追加しても予約上限を超えないかを検証します
+// 利用時間のルールは集計期間（loc のタイムゾーンの週・月）ごとに、同時予約数のルールは終了していない回の数で判定し、
+// 超える場合は超過したルールの残りを含む QuotaExceededError を返します
+func (s *QuotaService) CheckReservation(ctx context.Context, user *domain.User, reservation *domain.Reservation, instances []*domain.ReservationInstance, resources []*domain.Resource, loc *time.Location, now time.Time) error {
+	rules, err := s.quotaRepo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list quota rules: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Resource, len(resources))
+	for _, resource := range resources {
+		byID[resource.ID] = resource
+	}
+
+	for _, rule := range rules {
+		if !rule.AppliesToUser(user) {
+			continue
+		}
+
+		// ルールの対象のリソースを含む回
+		var matched []*domain.ReservationInstance
+		for _, inst := range instances {
+			resourceIDs := reservation.ResourceIDs
+			if inst.ResourceIDs != nil {
+				resourceIDs = inst.ResourceIDs
+			}
+			for _, id := range resourceIDs {
+				if resource, ok := byID[id]; ok && rule.AppliesTo(resource) {
+					matched = append(matched, inst)
+					break
+				}
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		if rule.Kind.IsHours() {
+			err = s.checkHours(ctx, rule, user, matched, loc)
+		} else {
+			err = s.checkConcurrent(ctx, rule, user, matched, now)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHours は集計期間ごとに予約する時間と既存の予約の利用時間の合計が上限を超えないかを検証します
+func (s *QuotaService) checkHours(ctx context.Context, rule *domain.QuotaRule, user *domain.User, instances []*domain.ReservationInstance, loc *time.Location) error {
+	// 予約する時間を集計期間ごとに振り分ける（期間をまたぐ回は分割する）
+	requested := make(map[time.Time]int)
+	for _, inst := range instances {
+		for at := inst.StartAt; at.Before(inst.EndAt); {
+			start, end := rule.Period(at, loc)
+			until := inst.EndAt
+			if end.Before(until) {
+				until = end
+			}
+			requested[start] += int(until.Sub(at).Minutes())
+			at = until
+		}
+	}
+
+	periods := make([]time.Time, 0, len(requested))
+	for start := range requested {
+		periods = append(periods, start)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Before(periods[j]) })
+
+	for _, start := range periods {
+		_, end := rule.Period(start, loc)
+		filter := quotaFilter(rule, user)
+		filter.From = start
+		filter.To = end
+		used, err := s.quotaRepo.BookedMinutes(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to get quota usage: %w", err)
+		}
+		if used+requested[start] > rule.LimitUnits() {
+			usage := domain.NewQuotaUsage(rule, user.Department, used)
+			usage.PeriodStart = &start
+			usage.PeriodEnd = &end
+			return &QuotaExceededError{Usage: usage}
+		}
+	}
+	return nil
+}
+
+// checkConcurrent は予約する回と終了していない既存の予約の回の合計が上限を超えないかを検証します
+func (s *QuotaService) checkConcurrent(ctx context.Context, rule *domain.QuotaRule, user *domain.User, instances []*domain.ReservationInstance, now time.Time) error {
+	requested := 0
+	for _, inst := range instances {
+		if inst.EndAt.After(now) {
+			requested++
+		}
+	}
+	if requested == 0 {
+		return nil
+	}
+
+	filter := quotaFilter(rule, user)
+	filter.From = now
+	used, err := s.quotaRepo.CountUpcoming(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get quota usage: %w", err)
+	}
+	if used+requested > rule.LimitUnits() {
+		return &QuotaExceededError{Usage: domain.NewQuotaUsage(rule, user.Department, used)}
+	}
+	return nil
+}
+
+// Usage はユーザーに適用される予約上限のルールごとの現在の消費状況を返します
+// 利用時間のルールは now を含む集計期間（loc のタイムゾーンの週・月）の消費量です
+func (s *QuotaService) Usage(ctx context.Context, userID uuid.UUID, loc *time.Location, now time.Time) ([]*domain.QuotaUsage, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	rules, err := s.quotaRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quota rules: %w", err)
+	}
+
+	usages := make([]*domain.QuotaUsage, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.AppliesToUser(user) {
+			continue
+		}
+
+		filter := quotaFilter(rule, user)
+		if !rule.Kind.IsHours() {
+			filter.From = now
+			used, err := s.quotaRepo.CountUpcoming(ctx, filter)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get quota usage: %w", err)
+			}
+			usages = append(usages, domain.NewQuotaUsage(rule, user.Department, used))
+			continue
+		}
+
+		start, end := rule.Period(now, loc)
+		filter.From = start
+		filter.To = end
+		used, err := s.quotaRepo.BookedMinutes(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quota usage: %w", err)
+		}
+		usage := domain.NewQuotaUsage(rule, user.Department, used)
+		usage.PeriodStart = &start
+		usage.PeriodEnd = &end
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// quotaFilter はルールの集計単位と対象のリソースから消費量の集計条件を作成します
+func quotaFilter(rule *domain.QuotaRule, user *domain.User) repository.QuotaUsageFilter {
+	filter := repository.QuotaUsageFilter{
+		ResourceType: rule.ResourceType,
+		MinCapacity:  rule.MinCapacity,
+	}
+	if rule.Scope == domain.QuotaScopeDepartment {
+		filter.Department = user.Department
+	} else {
+		filter.OrganizerID = &user.ID
+	}
+	return filter
+}
+
+// recordAudit は予約上限のルールの監査ログを記録します（エラーは無視）
+func (s *QuotaService) recordAudit(ctx context.Context, userID uuid.UUID, action domain.AuditAction, ruleID uuid.UUID, details map[string]interface{}) {
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		TargetType: "booking_quota",
+		TargetID:   ruleID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
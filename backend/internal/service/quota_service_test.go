@@ -0,0 +1,354 @@
+// backend/internal/service/quota_service_test.go
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+)
+
+// newQuotaUser は予約上限のテストで使用する部署に所属するユーザーを作成します
+func newQuotaUser() *domain.User {
+	sales := "Sales"
+	return &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, Department: &sales, IsActive: true}
+}
+
+// newQuotaRoom は予約上限のテストで使用する会議室を作成します
+func newQuotaRoom(name string, capacity int) *domain.Resource {
+	return &domain.Resource{ID: uuid.New(), Name: name, Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+}
+
+// largeRoomRule は大会議室を対象とするルールを作成します
+func largeRoomRule(scope domain.QuotaScope, kind domain.QuotaKind, limit int) *domain.QuotaRule {
+	meetingRoom := domain.ResourceTypeMeetingRoom
+	minCapacity := 12
+	return &domain.QuotaRule{
+		ID:           uuid.New(),
+		Name:         "Large rooms",
+		Scope:        scope,
+		Kind:         kind,
+		ResourceType: &meetingRoom,
+		MinCapacity:  &minCapacity,
+		Limit:        limit,
+		IsActive:     true,
+	}
+}
+
+func TestQuotaService_CheckReservation_WeeklyHoursExceeded(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindWeeklyHours, 10)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+
+	// 2026-04-08 (水) 10:00 から 3時間。既に同じ週に 8時間予約済み
+	startAt := time.Date(2026, 4, 8, 10, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+	mockQuotaRepo.On("BookedMinutes", ctx, mock.MatchedBy(func(f repository.QuotaUsageFilter) bool {
+		return *f.OrganizerID == user.ID && f.Department == nil && f.From.Equal(weekStart) && f.To.Equal(weekStart.AddDate(0, 0, 7))
+	})).Return(8*60, nil)
+
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{largeRoom.ID}}
+	instances := []*domain.ReservationInstance{{StartAt: startAt, EndAt: startAt.Add(3 * time.Hour)}}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, []*domain.Resource{largeRoom}, time.UTC, startAt.Add(-time.Hour))
+
+	var quotaErr *service.QuotaExceededError
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.ErrorIs(t, err, service.ErrQuotaExceeded)
+	assert.Equal(t, 2.0, quotaErr.Usage.Remaining)
+	assert.Equal(t, 10, quotaErr.Usage.Limit)
+	assert.Equal(t, weekStart, *quotaErr.Usage.PeriodStart)
+	assert.Contains(t, err.Error(), "remaining 2 of 10")
+	mockQuotaRepo.AssertExpectations(t)
+}
+
+func TestQuotaService_CheckReservation_WithinAllowance(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindWeeklyHours, 10)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+	mockQuotaRepo.On("BookedMinutes", ctx, mock.Anything).Return(7*60, nil)
+
+	startAt := time.Date(2026, 4, 8, 10, 0, 0, 0, time.UTC)
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{largeRoom.ID}}
+	instances := []*domain.ReservationInstance{{StartAt: startAt, EndAt: startAt.Add(3 * time.Hour)}}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, []*domain.Resource{largeRoom}, time.UTC, startAt)
+	assert.NoError(t, err)
+	mockQuotaRepo.AssertExpectations(t)
+}
+
+func TestQuotaService_CheckReservation_OtherResourceClass(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	smallRoom := newQuotaRoom("Huddle", 4)
+
+	// 小会議室の予約は大会議室のルールの対象外（消費量も集計しない）
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindWeeklyHours, 0)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+
+	startAt := time.Date(2026, 4, 8, 10, 0, 0, 0, time.UTC)
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{smallRoom.ID}}
+	instances := []*domain.ReservationInstance{{StartAt: startAt, EndAt: startAt.Add(3 * time.Hour)}}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, []*domain.Resource{smallRoom}, time.UTC, startAt)
+	assert.NoError(t, err)
+	mockQuotaRepo.AssertNotCalled(t, "BookedMinutes", mock.Anything, mock.Anything)
+}
+
+func TestQuotaService_CheckReservation_DepartmentMonthlyPerPeriod(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+
+	rule := largeRoomRule(domain.QuotaScopeDepartment, domain.QuotaKindMonthlyHours, 20)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+
+	// 4月は余裕があるが、5月は部署で既に 19時間予約済み
+	april := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockQuotaRepo.On("BookedMinutes", ctx, mock.MatchedBy(func(f repository.QuotaUsageFilter) bool {
+		return f.OrganizerID == nil && *f.Department == "Sales" && f.From.Equal(april)
+	})).Return(0, nil)
+	mockQuotaRepo.On("BookedMinutes", ctx, mock.MatchedBy(func(f repository.QuotaUsageFilter) bool {
+		return f.From.Equal(may)
+	})).Return(19*60, nil)
+
+	// 繰り返し予約の 4月と 5月の回（2時間ずつ）、5月の回は代替の大会議室
+	otherLarge := uuid.New()
+	capacity := 30
+	resources := []*domain.Resource{largeRoom, {ID: otherLarge, Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}}
+	aprilAt := time.Date(2026, 4, 28, 10, 0, 0, 0, time.UTC)
+	mayAt := time.Date(2026, 5, 5, 10, 0, 0, 0, time.UTC)
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{largeRoom.ID}}
+	instances := []*domain.ReservationInstance{
+		{StartAt: aprilAt, EndAt: aprilAt.Add(2 * time.Hour)},
+		{StartAt: mayAt, EndAt: mayAt.Add(2 * time.Hour), ResourceIDs: []uuid.UUID{otherLarge}},
+	}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, resources, time.UTC, aprilAt)
+
+	var quotaErr *service.QuotaExceededError
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, 1.0, quotaErr.Usage.Remaining)
+	assert.Equal(t, "Sales", *quotaErr.Usage.Department)
+	assert.Equal(t, may, *quotaErr.Usage.PeriodStart)
+	mockQuotaRepo.AssertExpectations(t)
+}
+
+func TestQuotaService_CheckReservation_ConcurrentBookings(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindConcurrentBookings, 5)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	mockQuotaRepo.On("CountUpcoming", ctx, mock.MatchedBy(func(f repository.QuotaUsageFilter) bool {
+		return *f.OrganizerID == user.ID && f.From.Equal(now)
+	})).Return(3, nil)
+
+	// 3件予約済みのところに毎週3回の繰り返し予約は上限5を超える
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{largeRoom.ID}}
+	var instances []*domain.ReservationInstance
+	for i := 0; i < 3; i++ {
+		at := now.AddDate(0, 0, 7*(i+1))
+		instances = append(instances, &domain.ReservationInstance{StartAt: at, EndAt: at.Add(time.Hour)})
+	}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, []*domain.Resource{largeRoom}, time.UTC, now)
+
+	var quotaErr *service.QuotaExceededError
+	assert.True(t, errors.As(err, &quotaErr))
+	assert.Equal(t, 2.0, quotaErr.Usage.Remaining)
+	assert.Nil(t, quotaErr.Usage.PeriodStart)
+
+	// 2回なら上限内
+	err = svc.CheckReservation(ctx, user, reservation, instances[:2], []*domain.Resource{largeRoom}, time.UTC, now)
+	assert.NoError(t, err)
+}
+
+func TestQuotaService_CheckReservation_DepartmentRuleSkipsUsersWithoutDepartment(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+	user.Department = nil
+
+	rule := largeRoomRule(domain.QuotaScopeDepartment, domain.QuotaKindMonthlyHours, 0)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+
+	startAt := time.Date(2026, 4, 8, 10, 0, 0, 0, time.UTC)
+	reservation := &domain.Reservation{ResourceIDs: []uuid.UUID{largeRoom.ID}}
+	instances := []*domain.ReservationInstance{{StartAt: startAt, EndAt: startAt.Add(time.Hour)}}
+
+	err := svc.CheckReservation(ctx, user, reservation, instances, []*domain.Resource{largeRoom}, time.UTC, startAt)
+	assert.NoError(t, err)
+}
+
+func TestQuotaService_Usage(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockUserRepo := new(MockUserRepository)
+	svc := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+
+	ctx := context.Background()
+	user := newQuotaUser()
+
+	weekly := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindWeeklyHours, 10)
+	concurrent := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindConcurrentBookings, 5)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{weekly, concurrent}, nil)
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2026, 4, 8, 3, 0, 0, 0, time.UTC)
+	weekStart := time.Date(2026, 4, 6, 0, 0, 0, 0, tokyo)
+	mockQuotaRepo.On("BookedMinutes", ctx, mock.MatchedBy(func(f repository.QuotaUsageFilter) bool {
+		return f.From.Equal(weekStart)
+	})).Return(150, nil)
+	mockQuotaRepo.On("CountUpcoming", ctx, mock.Anything).Return(4, nil)
+
+	usages, err := svc.Usage(ctx, user.ID, tokyo, now)
+	assert.NoError(t, err)
+	assert.Len(t, usages, 2)
+	assert.Equal(t, 2.5, usages[0].Used)
+	assert.Equal(t, 7.5, usages[0].Remaining)
+	assert.True(t, usages[0].PeriodStart.Equal(weekStart))
+	assert.Equal(t, 4.0, usages[1].Used)
+	assert.Equal(t, 1.0, usages[1].Remaining)
+	mockQuotaRepo.AssertExpectations(t)
+}
+
+func TestReservationService_CreateReservation_QuotaExceeded(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	quotaService := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, quotaService, nil)
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+	startAt := time.Now().Add(24 * time.Hour)
+
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindConcurrentBookings, 2)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, largeRoom.ID).Return(largeRoom, nil)
+	mockResourceRepo.On("FindAvailable", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]*domain.Resource{largeRoom}, nil)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+	mockQuotaRepo.On("CountUpcoming", ctx, mock.Anything).Return(2, nil)
+
+	_, err := svc.CreateReservation(ctx, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{largeRoom.ID},
+		Title:       "Planning",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrQuotaExceeded)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// 管理者は予約上限の対象外
+	admin := &domain.User{ID: uuid.New(), Role: domain.RoleAdmin, IsActive: true}
+	mockUserRepo.On("GetByID", ctx, admin.ID).Return(admin, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.AnythingOfType("*domain.Reservation"), mock.AnythingOfType("[]*domain.ReservationInstance"), mock.AnythingOfType("[]uuid.UUID")).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	_, err = svc.CreateReservation(ctx, &service.CreateReservationRequest{
+		OrganizerID: admin.ID,
+		ResourceIDs: []uuid.UUID{largeRoom.ID},
+		Title:       "Planning",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+		Timezone:    "Asia/Tokyo",
+	})
+	assert.NoError(t, err)
+	mockQuotaRepo.AssertNumberOfCalls(t, "ListActive", 1)
+}
+
+func TestWaitlistService_Accept_QuotaExceeded(t *testing.T) {
+	mockQuotaRepo := new(MockQuotaRepository)
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	quotaService := service.NewQuotaService(mockQuotaRepo, mockUserRepo, new(MockAuditLogRepository))
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, quotaService, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo,
+		nil, nil, reservationService, 15*time.Minute)
+
+	ctx := context.Background()
+	user := newQuotaUser()
+	largeRoom := newQuotaRoom("Board Room", 20)
+	startAt := time.Now().Add(24 * time.Hour)
+	holdID := uuid.New()
+	entry := &domain.WaitlistEntry{
+		ID:         uuid.New(),
+		ResourceID: largeRoom.ID,
+		UserID:     user.ID,
+		Title:      "Planning",
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Timezone:   "Asia/Tokyo",
+		Status:     domain.WaitlistStatusWaiting,
+	}
+	entry.Offer(holdID, time.Now(), 15*time.Minute)
+	hold := &domain.Reservation{ID: holdID, OrganizerID: user.ID, StartAt: startAt, EndAt: entry.EndAt, Timezone: "Asia/Tokyo", ApprovalStatus: domain.ApprovalStatusOffered}
+
+	rule := largeRoomRule(domain.QuotaScopeUser, domain.QuotaKindConcurrentBookings, 2)
+	mockWaitlistRepo.On("GetByID", ctx, entry.ID).Return(entry, nil)
+	mockReservationRepo.On("GetByID", ctx, holdID, startAt).Return(hold, nil)
+	mockResourceRepo.On("GetByID", ctx, largeRoom.ID).Return(largeRoom, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockQuotaRepo.On("ListActive", ctx).Return([]*domain.QuotaRule{rule}, nil)
+	mockQuotaRepo.On("CountUpcoming", ctx, mock.Anything).Return(2, nil)
+
+	_, err := svc.Accept(ctx, entry.ID, user.ID)
+
+	assert.ErrorIs(t, err, service.ErrQuotaExceeded)
+	assert.Equal(t, domain.WaitlistStatusOffered, entry.Status)
+	assert.Equal(t, domain.ApprovalStatusOffered, hold.ApprovalStatus)
+	mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/queue"
 	"github.com/your-org/esms/internal/repository"
 )
 
@@ -16,6 +17,16 @@ var (
 	ErrResourceNotAvailable = errors.New("resource is not available for the requested time")
 	ErrInvalidTimeRange     = errors.New("invalid time range")
 	ErrUnauthorized         = errors.New("unauthorized")
+	ErrInvalidUnits         = errors.New("requested units must be at least 1")
+	ErrInsufficientUnits    = errors.New("not enough units are available for the requested time")
+	ErrOverdueEquipment     = errors.New("organizer has overdue equipment that must be returned first")
+	ErrInvalidDaySlot       = errors.New("desks and parking spaces must be booked for a full day or half day")
+	ErrNoSuitableRoom       = errors.New("no available meeting room matches the requirements")
+
+	ErrOccupancyOverrideForbidden = errors.New("only administrators can override room capacity rules")
+	ErrSeriesConflict             = errors.New("some occurrences conflict with existing bookings")
+	ErrInvalidResolution          = errors.New("conflict resolution does not match the series")
+	ErrNoOccurrences              = errors.New("no occurrences left to book")
 )
 
 // ReservationService は予約に関するビジネスロジックを提供します
@@ -24,20 +35,40 @@ type ReservationService struct {
 	resourceRepo    repository.ResourceRepository
 	userRepo        repository.UserRepository
 	auditLogRepo    repository.AuditLogRepository
+	approvalService *ApprovalService
+	jobQueue        queue.JobQueue
+	checkoutRepo    repository.CheckoutRepository
+	quotaService    *QuotaService
+	penaltyService  *PenaltyService
 }
 
 // NewReservationService は新しいReservationServiceを作成します
+// approvalService が nil の場合、承認依頼の通知は行いません
+// jobQueue が nil の場合、キャンセル時のウェイトリスト繰り上げは行いません
+// checkoutRepo が nil の場合、備品の返却遅延による予約ブロックは行いません
+// quotaService が nil の場合、予約上限の確認は行いません
+// penaltyService が nil の場合、ペナルティスコアによる予約制限と直前キャンセルのスコア加算は行いません
 func NewReservationService(
 	reservationRepo repository.ReservationRepository,
 	resourceRepo repository.ResourceRepository,
 	userRepo repository.UserRepository,
 	auditLogRepo repository.AuditLogRepository,
+	approvalService *ApprovalService,
+	jobQueue queue.JobQueue,
+	checkoutRepo repository.CheckoutRepository,
+	quotaService *QuotaService,
+	penaltyService *PenaltyService,
 ) *ReservationService {
 	return &ReservationService{
 		reservationRepo: reservationRepo,
 		resourceRepo:    resourceRepo,
 		userRepo:        userRepo,
 		auditLogRepo:    auditLogRepo,
+		approvalService: approvalService,
+		jobQueue:        jobQueue,
+		checkoutRepo:    checkoutRepo,
+		quotaService:    quotaService,
+		penaltyService:  penaltyService,
 	}
 }
 
@@ -52,10 +83,178 @@ type CreateReservationRequest struct {
 	RRule       string
 	IsPrivate   bool
 	Timezone    string
+	Units       map[uuid.UUID]int // プール型備品の予約数量（未指定のリソースは1）
+
+	// Room を指定すると条件に最も適した空き会議室を選び、ResourceIDs の備品と合わせて予約する
+	Room *domain.RoomRequirement
+
+	ParticipantIDs    []uuid.UUID // 招待する参加者（主催者は自動的に含まれる）
+	OverrideOccupancy bool        // 収容人数・最低利用人数の検証を省略する（管理者のみ）
+
+	// Resolutions は繰り返し予約で競合した回の扱い（除外または代替リソース）
+	Resolutions []*domain.OccurrenceResolution
+
+	// Hold は変換元の仮押さえ（HoldService が所有者と有効期限を確認済みのもの）
+	// 仮押さえした時間帯に収まる回では、仮押さえで確保済みのリソースを空きとして扱う
+	Hold *domain.ResourceHold
+
+	// preempt は優先確保する会議室（BumpService のみが設定する）
+	// 既存の予約は押しのけるため空きとして扱い、会議室のポリシーで許可された確保として承認を経ずに確定する
+	preempt uuid.UUID
+}
+
+// SeriesConflictError は繰り返し予約に解決されていない競合がある場合のエラー
+// 各回の競合状況（ドライランと同じ内容）を Report に含みます
+type SeriesConflictError struct {
+	Report *domain.SeriesConflictReport
+}
+
+func (e *SeriesConflictError) Error() string {
+	return fmt.Sprintf("%s: %d occurrence(s)", ErrSeriesConflict, len(e.Report.Conflicts))
+}
+
+func (e *SeriesConflictError) Unwrap() error {
+	return ErrSeriesConflict
+}
+
+// MaxAlternativeRooms は競合した回ごとに提示する代替会議室の上限
+const MaxAlternativeRooms = 3
+
+// reservationPlan は予約を作成する前の検証結果
+type reservationPlan struct {
+	user                *domain.User
+	reservation         *domain.Reservation
+	resourceIDs         []uuid.UUID // 予約するリソース（会議室の条件から選んだ会議室を含む）
+	resources           []*domain.Resource
+	instances           []*domain.ReservationInstance
+	report              *domain.SeriesConflictReport
+	shortOfUnits        bool // 競合の原因がプール型備品の数量不足のみか（単発予約のエラー判定用）
+	requiresApproval    bool
+	headcount           int
+	occupancyOverridden bool
+	restriction         *domain.PenaltyRestriction // ペナルティスコアによる予約制限（該当しない場合は nil）
+	penaltyApproval     bool                       // ペナルティスコアにより上長の承認が必要か
+}
+
+// hasResource は検証済みのリソースに id が含まれるかを判定します
+func (p *reservationPlan) hasResource(id uuid.UUID) bool {
+	for _, resource := range p.resources {
+		if resource.ID == id {
+			return true
+		}
+	}
+	return false
 }
 
 // CreateReservation は新しい予約を作成します
+// 繰り返し予約で解決されていない競合がある場合は予約を作成せず SeriesConflictError を返します
 func (s *ReservationService) CreateReservation(ctx context.Context, req *CreateReservationRequest) (*domain.Reservation, error) {
+	plan, err := s.planReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	reservation := plan.reservation
+
+	// 二重予約はしない（単発予約は従来どおりのエラー、繰り返し予約は各回の競合状況を返す）
+	if plan.report.HasConflicts() {
+		if !reservation.IsRecurring() {
+			if plan.shortOfUnits {
+				return nil, ErrInsufficientUnits
+			}
+			return nil, ErrResourceNotAvailable
+		}
+		return nil, &SeriesConflictError{Report: plan.report}
+	}
+	if len(plan.instances) == 0 {
+		return nil, ErrNoOccurrences
+	}
+
+	// 予約上限を超える予約はしない（管理者は対象外）
+	if s.quotaService != nil && !plan.user.IsAdmin() {
+		loc, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		if err := s.quotaService.CheckReservation(ctx, plan.user, reservation, plan.instances, plan.resources, loc, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	// 承認チェーンの組み立て（承認者が決まらない場合は予約を作成しない）
+	var approvalChain []*domain.ReservationApproval
+	if plan.requiresApproval && s.approvalService != nil {
+		approvalChain, err = s.approvalService.BuildApprovalChain(ctx, reservation, plan.resources, plan.user)
+		if err != nil {
+			return nil, err
+		}
+		if plan.penaltyApproval {
+			approvalChain, err = s.approvalService.WithManagerApproval(reservation, approvalChain, plan.user)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// トランザクション内で予約とインスタンスを作成（除外日・代替リソースも同じトランザクションで確定する）
+	err = s.reservationRepo.CreateWithInstances(ctx, reservation, plan.instances, plan.resourceIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	// 監査ログ記録
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     req.OrganizerID,
+		Action:     domain.AuditActionCreate,
+		TargetType: "reservation",
+		TargetID:   reservation.ID.String(),
+		Details: map[string]interface{}{
+			"title":     req.Title,
+			"start_at":  req.StartAt,
+			"end_at":    req.EndAt,
+			"resources": len(plan.resourceIDs),
+			"approval":  string(reservation.ApprovalStatus),
+			"headcount": plan.headcount,
+		},
+		CreatedAt: time.Now(),
+	}
+	if plan.occupancyOverridden {
+		auditLog.Details["occupancy_override"] = true
+	}
+	if plan.penaltyApproval {
+		auditLog.Details["penalty_approval"] = true
+	}
+	if reservation.IsRecurring() {
+		auditLog.Details["occurrences"] = plan.report.Occurrences
+		auditLog.Details["skipped"] = len(plan.report.Skipped)
+		auditLog.Details["substituted"] = len(plan.report.Substituted)
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog) // エラーは無視（監査ログ失敗で予約失敗にしない）
+
+	// 承認チェーンを記録し承認者へ依頼
+	// 記録に失敗した場合は承認できない予約が残らないよう取り消す
+	if plan.requiresApproval && s.approvalService != nil {
+		if err := s.approvalService.RequestApproval(ctx, reservation, approvalChain, plan.user); err != nil {
+			_ = s.reservationRepo.Delete(ctx, reservation.ID, reservation.StartAt)
+			return nil, fmt.Errorf("failed to request approval: %w", err)
+		}
+	}
+
+	return reservation, nil
+}
+
+// CheckConflicts は予約を作成せずに各回の競合状況を返します（ドライラン）
+// 主催者は結果をもとに競合した回の除外・代替リソースを Resolutions に指定して CreateReservation を呼び出します
+func (s *ReservationService) CheckConflicts(ctx context.Context, req *CreateReservationRequest) (*domain.SeriesConflictReport, error) {
+	plan, err := s.planReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return plan.report, nil
+}
+
+// planReservation は予約リクエストを検証し、全インスタンスの展開と各回の空き状況の確認を行います
+func (s *ReservationService) planReservation(ctx context.Context, req *CreateReservationRequest) (*reservationPlan, error) {
 	// 入力検証
 	if req.StartAt.After(req.EndAt) || req.StartAt.Equal(req.EndAt) {
 		return nil, ErrInvalidTimeRange
@@ -67,38 +266,111 @@ func (s *ReservationService) CreateReservation(ctx context.Context, req *CreateR
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// リソース存在確認と権限チェック
-	for _, resourceID := range req.ResourceIDs {
-		resource, err := s.resourceRepo.GetByID(ctx, resourceID)
+	// 返却期限を過ぎた備品を持っている場合は返却されるまで予約できない
+	if s.checkoutRepo != nil {
+		overdue, err := s.checkoutRepo.HasOverdue(ctx, user.ID, time.Now())
 		if err != nil {
-			return nil, fmt.Errorf("failed to get resource: %w", err)
+			return nil, fmt.Errorf("failed to check overdue equipment: %w", err)
 		}
+		if overdue {
+			return nil, ErrOverdueEquipment
+		}
+	}
+
+	// 収容人数・最低利用人数の検証を省略できるのは管理者のみ
+	if req.OverrideOccupancy && !user.IsAdmin() {
+		return nil, ErrOccupancyOverrideForbidden
+	}
 
-		if !resource.CanBeReservedBy(user) {
-			return nil, ErrUnauthorized
+	plan := &reservationPlan{user: user}
+
+	// ペナルティスコアによる予約制限（管理者は対象外）
+	if s.penaltyService != nil && !user.IsAdmin() {
+		plan.restriction, err = s.penaltyService.Restriction(ctx, user)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// リソースの空き状況確認
-	availableResources, err := s.resourceRepo.FindAvailable(ctx, req.StartAt, req.EndAt)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find available resources: %w", err)
+	// 利用人数は主催者と招待者の合計（会議室の条件で参加人数が指定されていればその大きい方）
+	participantIDs := uniqueParticipants(req.OrganizerID, req.ParticipantIDs)
+	plan.headcount = 1 + len(participantIDs)
+	if req.Room != nil && req.Room.Attendees > plan.headcount {
+		plan.headcount = req.Room.Attendees
 	}
 
-	// リクエストされたリソースが全て利用可能か確認
-	availableMap := make(map[uuid.UUID]bool)
-	for _, r := range availableResources {
-		availableMap[r.ID] = true
+	// 会議室の条件が指定された場合は最適な空き会議室を選び、備品と同じトランザクションで予約する
+	// 呼び出し元のリクエストは変更しない（同じリクエストで再計画しても会議室が重複しないように）
+	plan.resourceIDs = append([]uuid.UUID(nil), req.ResourceIDs...)
+	if req.Room != nil {
+		roomID, err := s.selectRoom(ctx, user, req, plan.headcount, plan.restriction)
+		if err != nil {
+			return nil, err
+		}
+		plan.resourceIDs = append([]uuid.UUID{roomID}, req.ResourceIDs...)
 	}
 
-	for _, resourceID := range req.ResourceIDs {
-		if !availableMap[resourceID] {
-			return nil, ErrResourceNotAvailable
+	// リソース存在確認と権限チェック
+	for _, resourceID := range plan.resourceIDs {
+		resource, err := s.loadReservableResource(ctx, user, req, plan, resourceID)
+		if err != nil {
+			return nil, err
+		}
+		if units, ok := req.Units[resourceID]; ok {
+			if units < 1 {
+				return nil, ErrInvalidUnits
+			}
+			if units > resource.TotalUnits() {
+				return nil, ErrInsufficientUnits
+			}
+		}
+	}
+
+	// 代替リソースの検証（競合した回だけに割り当てるリソースも同じ条件を満たす必要がある）
+	units := make(map[uuid.UUID]int, len(req.Units))
+	for id, n := range req.Units {
+		units[id] = n
+	}
+	for _, resolution := range req.Resolutions {
+		if !resolution.Action.IsValid() {
+			return nil, ErrInvalidResolution
 		}
+		for original, replacement := range resolution.Replacements {
+			if !containsID(plan.resourceIDs, original) {
+				return nil, ErrInvalidResolution
+			}
+			if n, ok := req.Units[original]; ok {
+				units[replacement] = n
+			}
+			if plan.hasResource(replacement) {
+				continue
+			}
+			if _, err := s.loadReservableResource(ctx, user, req, plan, replacement); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// ペナルティスコアが閾値を超えている場合は上長の承認を必須にする
+	if plan.restriction != nil && plan.restriction.RequiresApproval {
+		plan.requiresApproval = true
+		plan.penaltyApproval = true
+	}
+
+	if req.preempt != uuid.Nil {
+		plan.requiresApproval = false
+		plan.penaltyApproval = false
 	}
 
-	// 予約作成
-	reservation := &domain.Reservation{
+	// 承認が必要なリソースを含む場合は承認待ちとして仮押さえする
+	approvalStatus := domain.ApprovalStatusConfirmed
+	instanceStatus := domain.ReservationStatusConfirmed
+	if plan.requiresApproval {
+		approvalStatus = domain.ApprovalStatusPending
+		instanceStatus = domain.ReservationStatusTentative
+	}
+
+	plan.reservation = &domain.Reservation{
 		ID:             uuid.New(),
 		OrganizerID:    req.OrganizerID,
 		Title:          req.Title,
@@ -108,47 +380,348 @@ func (s *ReservationService) CreateReservation(ctx context.Context, req *CreateR
 		RRule:          req.RRule,
 		IsPrivate:      req.IsPrivate,
 		Timezone:       req.Timezone,
-		ApprovalStatus: domain.ApprovalStatusConfirmed,
+		ApprovalStatus: approvalStatus,
+		ResourceUnits:  units,
+		ResourceIDs:    plan.resourceIDs,
+		ParticipantIDs: participantIDs,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
 
-	// 予約インスタンス生成
-	instances, err := reservation.ExpandInstances(req.StartAt, req.EndAt)
+	// 予約インスタンス生成（繰り返し予約は全回を展開する）
+	instances, err := plan.reservation.ExpandSeries()
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand instances: %w", err)
 	}
 
-	// ドメインインスタンスをリポジトリ用に変換
-	repoInstances := make([]*domain.ReservationInstance, len(instances))
-	for i, inst := range instances {
-		repoInstances[i] = &inst
+	// 解決方法は展開したいずれかの回を指している必要がある
+	resolutions := make(map[int64]*domain.OccurrenceResolution, len(req.Resolutions))
+	for _, resolution := range req.Resolutions {
+		resolutions[resolution.StartAt.Unix()] = resolution
+	}
+	occurrences := make(map[int64]bool, len(instances))
+	for _, inst := range instances {
+		occurrences[inst.StartAt.Unix()] = true
+	}
+	for key := range resolutions {
+		if !occurrences[key] {
+			return nil, ErrInvalidResolution
+		}
+	}
+
+	// 各回の空き状況を確認し、除外・代替リソースを適用する
+	plan.report = &domain.SeriesConflictReport{}
+	for i := range instances {
+		inst := &instances[i]
+		inst.Status = instanceStatus
+
+		resourceIDs := plan.resourceIDs
+		if resolution, ok := resolutions[inst.StartAt.Unix()]; ok {
+			if resolution.Action == domain.ConflictActionSkip {
+				plan.reservation.ExDates = append(plan.reservation.ExDates, inst.StartAt)
+				plan.report.Skipped = append(plan.report.Skipped, inst.StartAt)
+				continue
+			}
+			resourceIDs = replaceIDs(plan.resourceIDs, resolution.Replacements)
+			inst.ResourceIDs = resourceIDs
+			plan.report.Substituted = append(plan.report.Substituted, inst.StartAt)
+		}
+
+		checked := unheldResources(req.Hold, resourceIDs, units, inst.StartAt, inst.EndAt)
+		if req.preempt != uuid.Nil {
+			checked = withoutID(checked, req.preempt)
+		}
+		unavailable, shortOfUnits, err := s.unavailableResources(ctx, checked, units, inst.StartAt, inst.EndAt)
+		if err != nil {
+			return nil, err
+		}
+		if len(unavailable) > 0 {
+			conflict := &domain.OccurrenceConflict{
+				StartAt:     inst.StartAt,
+				EndAt:       inst.EndAt,
+				ResourceIDs: unavailable,
+			}
+			// 繰り返し予約では代替会議室の候補を提示する
+			if plan.reservation.IsRecurring() {
+				conflict.Alternatives, err = s.alternativeRooms(ctx, plan, resourceIDs, unavailable, inst.StartAt, inst.EndAt)
+				if err != nil {
+					return nil, err
+				}
+			}
+			plan.report.Conflicts = append(plan.report.Conflicts, conflict)
+			plan.shortOfUnits = shortOfUnits
+		}
+
+		plan.instances = append(plan.instances, inst)
+	}
+	plan.report.Occurrences = len(plan.instances)
+
+	// ペナルティスコアによる予約可能期間の短縮（繰り返し予約は全ての回が期間内である必要がある）
+	if plan.restriction != nil {
+		now := time.Now()
+		for _, inst := range plan.instances {
+			if !plan.restriction.AllowsStart(inst.StartAt, now) {
+				return nil, fmt.Errorf("%w: bookings are limited to %d days ahead", ErrPenaltyAdvanceWindow, *plan.restriction.MaxAdvanceDays)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// checkBookingLimits はウェイトリストの繰り上げ確定・仮押さえなど、予約の作成以外の経路で枠を確保する場合に
+// ペナルティスコアによる予約制限と予約上限を検証します（管理者は対象外）
+// 上長の承認が必要な制限に該当する場合は、承認チェーンに加えられるよう適用中の制限を返します
+func (s *ReservationService) checkBookingLimits(ctx context.Context, user *domain.User, reservation *domain.Reservation, instances []*domain.ReservationInstance, resources []*domain.Resource) (*domain.PenaltyRestriction, error) {
+	if user.IsAdmin() {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var restriction *domain.PenaltyRestriction
+	if s.penaltyService != nil {
+		var err error
+		restriction, err = s.penaltyService.Restriction(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if restriction != nil {
+		for _, resource := range resources {
+			if restriction.Blocks(resource) {
+				return nil, fmt.Errorf("%w: %s", ErrPenaltyRestricted, resource.Name)
+			}
+		}
+		for _, inst := range instances {
+			if !restriction.AllowsStart(inst.StartAt, now) {
+				return nil, fmt.Errorf("%w: bookings are limited to %d days ahead", ErrPenaltyAdvanceWindow, *restriction.MaxAdvanceDays)
+			}
+		}
 	}
 
-	// トランザクション内で予約とインスタンスを作成
-	err = s.reservationRepo.CreateWithInstances(ctx, reservation, repoInstances, req.ResourceIDs)
+	if s.quotaService != nil {
+		loc, err := time.LoadLocation(reservation.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		if err := s.quotaService.CheckReservation(ctx, user, reservation, instances, resources, loc, now); err != nil {
+			return nil, err
+		}
+	}
+	return restriction, nil
+}
+
+// loadReservableResource はリソースを取得し、主催者が予約できるか（権限・予約枠・利用人数）を検証します
+func (s *ReservationService) loadReservableResource(ctx context.Context, user *domain.User, req *CreateReservationRequest, plan *reservationPlan, resourceID uuid.UUID) (*domain.Resource, error) {
+	resource, err := s.resourceRepo.GetByID(ctx, resourceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create reservation: %w", err)
+		return nil, fmt.Errorf("failed to get resource: %w", err)
 	}
 
-	// 監査ログ記録
-	auditLog := &domain.AuditLog{
-		ID:         uuid.New(),
-		UserID:     req.OrganizerID,
-		Action:     domain.AuditActionCreate,
-		TargetType: "reservation",
-		TargetID:   reservation.ID.String(),
-		Details: map[string]interface{}{
-			"title":     req.Title,
-			"start_at":  req.StartAt,
-			"end_at":    req.EndAt,
-			"resources": len(req.ResourceIDs),
-		},
-		CreatedAt: time.Now(),
+	if !resource.CanBeReservedBy(user) {
+		return nil, ErrUnauthorized
+	}
+	if plan.restriction != nil && plan.restriction.Blocks(resource) {
+		return nil, fmt.Errorf("%w: %s", ErrPenaltyRestricted, resource.Name)
 	}
-	_ = s.auditLogRepo.Create(ctx, auditLog) // エラーは無視（監査ログ失敗で予約失敗にしない）
 
-	return reservation, nil
+	// デスク・駐車区画は終日・午前・午後の枠単位でのみ予約できる
+	if resource.IsDayBooked() && !matchesDaySlot(req) {
+		return nil, ErrInvalidDaySlot
+	}
+
+	// 会議室は利用人数が収容人数・最低利用人数の範囲内である必要がある
+	if err := resource.CheckOccupancy(plan.headcount); err != nil {
+		if !req.OverrideOccupancy {
+			return nil, err
+		}
+		plan.occupancyOverridden = true
+	}
+
+	if resource.NeedsApproval() {
+		plan.requiresApproval = true
+	}
+	plan.resources = append(plan.resources, resource)
+	return resource, nil
+}
+
+// unavailableResources は期間 [startAt, endAt) に空いていないリソースを返します
+// shortOfUnits は空いていない原因がすべてプール型備品の数量不足の場合に true になります
+func (s *ReservationService) unavailableResources(ctx context.Context, resourceIDs []uuid.UUID, units map[uuid.UUID]int, startAt, endAt time.Time) ([]uuid.UUID, bool, error) {
+	availableResources, err := s.resourceRepo.FindAvailable(ctx, startAt, endAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find available resources: %w", err)
+	}
+	availableMap := make(map[uuid.UUID]bool)
+	for _, r := range availableResources {
+		availableMap[r.ID] = true
+	}
+
+	var unavailable []uuid.UUID
+	for _, resourceID := range resourceIDs {
+		if !availableMap[resourceID] {
+			unavailable = append(unavailable, resourceID)
+		}
+	}
+	if len(unavailable) > 0 {
+		return unavailable, false, nil
+	}
+
+	// プール型備品で複数単位を要求する場合は残り数量を確認
+	for _, resourceID := range resourceIDs {
+		if units[resourceID] <= 1 {
+			continue
+		}
+		remaining, err := s.resourceRepo.AvailableUnits(ctx, resourceID, startAt, endAt)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get available units: %w", err)
+		}
+		if remaining < units[resourceID] {
+			unavailable = append(unavailable, resourceID)
+		}
+	}
+	return unavailable, len(unavailable) > 0, nil
+}
+
+// unheldResources は resourceIDs のうち仮押さえで確保されていないリソースを返します
+// 仮押さえ自体も枠を占有しているため、確保済みのリソースは空き状況の確認から除きます
+func unheldResources(hold *domain.ResourceHold, resourceIDs []uuid.UUID, units map[uuid.UUID]int, startAt, endAt time.Time) []uuid.UUID {
+	if hold == nil || !hold.Covers(startAt, endAt) {
+		return resourceIDs
+	}
+	var unheld []uuid.UUID
+	for _, resourceID := range resourceIDs {
+		if !hold.Guarantees(resourceID, units[resourceID]) {
+			unheld = append(unheld, resourceID)
+		}
+	}
+	return unheld
+}
+
+// alternativeRooms は競合した会議室の代わりにその回だけ予約できる会議室の候補を返します
+// 利用人数を満たす会議室を収容人数の小さい順に最大 MaxAlternativeRooms 件返します
+func (s *ReservationService) alternativeRooms(ctx context.Context, plan *reservationPlan, resourceIDs, unavailable []uuid.UUID, startAt, endAt time.Time) ([]*domain.Resource, error) {
+	needsRoom := false
+	for _, resource := range plan.resources {
+		if resource.IsMeetingRoom() && containsID(unavailable, resource.ID) {
+			needsRoom = true
+			break
+		}
+	}
+	if !needsRoom {
+		return nil, nil
+	}
+
+	rooms, err := s.resourceRepo.FindAvailableRooms(ctx, startAt, endAt, plan.headcount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available rooms: %w", err)
+	}
+
+	requirement := &domain.RoomRequirement{Attendees: plan.headcount}
+	var alternatives []*domain.Resource
+	for _, room := range rooms {
+		if containsID(resourceIDs, room.ID) || !requirement.Fits(room) || !plan.user.CanAccessResource(room.RequiredRole) {
+			continue
+		}
+		if plan.restriction != nil && plan.restriction.Blocks(room) {
+			continue
+		}
+		alternatives = append(alternatives, room)
+		if len(alternatives) == MaxAlternativeRooms {
+			break
+		}
+	}
+	return alternatives, nil
+}
+
+// containsID は ids に id が含まれるかを判定します
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutID は ids から id を除いた新しいスライスを返します
+func withoutID(ids []uuid.UUID, id uuid.UUID) []uuid.UUID {
+	var rest []uuid.UUID
+	for _, v := range ids {
+		if v != id {
+			rest = append(rest, v)
+		}
+	}
+	return rest
+}
+
+// replaceIDs は ids のうち replacements に含まれるものを置き換えた新しいスライスを返します
+func replaceIDs(ids []uuid.UUID, replacements map[uuid.UUID]uuid.UUID) []uuid.UUID {
+	replaced := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		if replacement, ok := replacements[id]; ok {
+			replaced[i] = replacement
+		} else {
+			replaced[i] = id
+		}
+	}
+	return replaced
+}
+
+// selectRoom は予約期間に空いている会議室から条件に最も適したものを選びます
+// 収容人数が足りる最小の会議室を優先し、同じ収容人数なら希望フロアに近いものを選びます
+func (s *ReservationService) selectRoom(ctx context.Context, user *domain.User, req *CreateReservationRequest, headcount int, restriction *domain.PenaltyRestriction) (uuid.UUID, error) {
+	if err := req.Room.Validate(); err != nil {
+		return uuid.Nil, err
+	}
+	requirement := *req.Room
+	requirement.Attendees = headcount
+
+	rooms, err := s.resourceRepo.FindAvailableRooms(ctx, req.StartAt, req.EndAt, requirement.Attendees)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find available rooms: %w", err)
+	}
+
+	var best *domain.Resource
+	for _, room := range rooms {
+		if !requirement.Fits(room) || !user.CanAccessResource(room.RequiredRole) {
+			continue
+		}
+		if restriction != nil && restriction.Blocks(room) {
+			continue
+		}
+		if best == nil || requirement.BetterFit(room, best) {
+			best = room
+		}
+	}
+	if best == nil {
+		return uuid.Nil, ErrNoSuitableRoom
+	}
+	return best.ID, nil
+}
+
+// uniqueParticipants は招待者から主催者と重複を除きます
+func uniqueParticipants(organizerID uuid.UUID, participantIDs []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{organizerID: true}
+	var unique []uuid.UUID
+	for _, id := range participantIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// matchesDaySlot は予約期間が予約者のタイムゾーンにおける終日・午前・午後の枠に一致するかを判定します
+func matchesDaySlot(req *CreateReservationRequest) bool {
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return false
+	}
+	_, ok := domain.MatchDaySlot(req.StartAt, req.EndAt, loc)
+	return ok
 }
 
 // CancelReservation は予約をキャンセルします
@@ -164,12 +737,36 @@ func (s *ReservationService) CancelReservation(ctx context.Context, reservationI
 		return ErrUnauthorized
 	}
 
+	// 開始24時間以内のキャンセルはペナルティスコアを加算する（対象の回は削除前に判定し、削除に成功してから記録する）
+	var lateCancellation *domain.PenaltyEvent
+	if s.penaltyService != nil {
+		lateCancellation, err = s.penaltyService.lateCancellation(ctx, reservation, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	// 削除前に解放される枠を把握しておく
+	released, err := s.releasedSlots(ctx, reservationID)
+	if err != nil {
+		return err
+	}
+
 	// 予約削除
 	err = s.reservationRepo.Delete(ctx, reservationID, startAt)
 	if err != nil {
 		return fmt.Errorf("failed to delete reservation: %w", err)
 	}
 
+	if lateCancellation != nil {
+		if err := s.penaltyService.recordLateCancellation(ctx, lateCancellation); err != nil {
+			return err
+		}
+	}
+
+	// 空いた枠をウェイトリストの登録者へ繰り上げる（失敗してもキャンセルは成功とする）
+	s.enqueueWaitlistPromotion(ctx, released)
+
 	// 監査ログ記録
 	auditLog := &domain.AuditLog{
 		ID:         uuid.New(),
@@ -187,6 +784,72 @@ func (s *ReservationService) CancelReservation(ctx context.Context, reservationI
 	return nil
 }
 
+// discardReservation は複数の予約をまとめて確保する処理が途中で失敗した場合に、作成済みの予約を取り消します（エラーは無視）
+// 利用者によるキャンセルではないため、ペナルティスコアの加算・監査ログ・ウェイトリストの繰り上げは行いません
+func (s *ReservationService) discardReservation(ctx context.Context, reservation *domain.Reservation) {
+	_ = s.reservationRepo.Delete(ctx, reservation.ID, reservation.StartAt)
+}
+
+// releasedSlot はキャンセルなどで解放されるリソースの枠を表します
+type releasedSlot struct {
+	resourceID uuid.UUID
+	startAt    time.Time
+	endAt      time.Time
+}
+
+// releasedSlots は予約が削除された場合に解放されるリソースの枠を返します
+// 繰り返し予約の場合は全インスタンスを覆う期間を1つの枠とします
+func (s *ReservationService) releasedSlots(ctx context.Context, reservationID uuid.UUID) ([]releasedSlot, error) {
+	if s.jobQueue == nil {
+		return nil, nil
+	}
+	return collectReleasedSlots(ctx, s.reservationRepo, reservationID)
+}
+
+// enqueueWaitlistPromotion は解放された枠ごとにウェイトリスト繰り上げジョブを登録します
+func (s *ReservationService) enqueueWaitlistPromotion(ctx context.Context, slots []releasedSlot) {
+	enqueueWaitlistPromotionJobs(ctx, s.jobQueue, slots)
+}
+
+// collectReleasedSlots は予約の全インスタンスを覆う期間を、予約の各リソースの解放される枠として返します
+// 承認の却下・期限切れなど、予約サービス以外で予約を解放する場合にも使用します
+func collectReleasedSlots(ctx context.Context, reservationRepo repository.ReservationRepository, reservationID uuid.UUID) ([]releasedSlot, error) {
+	instances, err := reservationRepo.GetInstancesByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation instances: %w", err)
+	}
+	startAt, endAt, ok := domain.InstancesSpan(instances)
+	if !ok {
+		return nil, nil
+	}
+
+	resourceIDs, err := reservationRepo.GetResourceIDs(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation resources: %w", err)
+	}
+
+	slots := make([]releasedSlot, 0, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		slots = append(slots, releasedSlot{resourceID: resourceID, startAt: startAt, endAt: endAt})
+	}
+	return slots, nil
+}
+
+// enqueueWaitlistPromotionJobs は解放された枠ごとにウェイトリスト繰り上げジョブを登録します（jobQueue が nil の場合は何もしない）
+func enqueueWaitlistPromotionJobs(ctx context.Context, jobQueue queue.JobQueue, slots []releasedSlot) {
+	if jobQueue == nil {
+		return
+	}
+	for _, slot := range slots {
+		payload := map[string]interface{}{
+			"resource_id": slot.resourceID.String(),
+			"start_at":    slot.startAt.Format(time.RFC3339),
+			"end_at":      slot.endAt.Format(time.RFC3339),
+		}
+		_, _ = jobQueue.Enqueue(ctx, "waitlist_promote", payload)
+	}
+}
+
 // FindAlternativeResources は代替リソースを提案します
 func (s *ReservationService) FindAlternativeResources(ctx context.Context, startAt, endAt time.Time, resourceType domain.ResourceType) ([]*domain.Resource, error) {
 	// 指定時間帯に利用可能なリソースを検索
@@ -19,7 +19,7 @@ func TestReservationService_CreateReservation_Success(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
 
-	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -78,7 +78,7 @@ func TestReservationService_CreateReservation_ResourceNotAvailable(t *testing.T)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
 
-	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -120,13 +120,465 @@ func TestReservationService_CreateReservation_ResourceNotAvailable(t *testing.T)
 	assert.Nil(t, reservation)
 }
 
+func TestReservationService_CreateReservation_InsufficientUnits(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(1 * time.Hour)
+
+	user := &domain.User{ID: userID, Role: domain.RoleGeneral, IsActive: true}
+	laptops := &domain.Resource{
+		ID:       uuid.New(),
+		Name:     "Laptop",
+		Type:     domain.ResourceTypeEquipment,
+		Quantity: 5,
+		IsActive: true,
+	}
+
+	mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, laptops.ID).Return(laptops, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{laptops}, nil)
+	mockResourceRepo.On("AvailableUnits", ctx, laptops.ID, startAt, endAt).Return(2, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: userID,
+		ResourceIDs: []uuid.UUID{laptops.ID},
+		Title:       "Training",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+		Units:       map[uuid.UUID]int{laptops.ID: 3},
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.ErrorIs(t, err, service.ErrInsufficientUnits)
+	assert.Nil(t, reservation)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CreateReservation_OverdueEquipment(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockCheckoutRepo := new(MockCheckoutRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, mockCheckoutRepo, nil, nil)
+
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockCheckoutRepo.On("HasOverdue", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(true, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{uuid.New()},
+		Title:       "Team Meeting",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(time.Hour),
+		Timezone:    "Asia/Tokyo",
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.ErrorIs(t, err, service.ErrOverdueEquipment)
+	assert.Nil(t, reservation)
+	mockResourceRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CreateReservation_SelectsRoomWithBundle(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(1 * time.Hour)
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+
+	room := func(name string, capacity, floor int, vc bool) *domain.Resource {
+		return &domain.Resource{
+			ID:        uuid.New(),
+			Name:      name,
+			Type:      domain.ResourceTypeMeetingRoom,
+			Capacity:  &capacity,
+			Floor:     &floor,
+			Equipment: map[string]interface{}{"vc": vc},
+			IsActive:  true,
+		}
+	}
+	noVC := room("Room 3B", 8, 3, false)
+	farRoom := room("Room 7A", 8, 7, true)
+	nearRoom := room("Room 4A", 8, 4, true)
+	boardroom := room("Boardroom", 20, 3, true)
+	projector := &domain.Resource{ID: uuid.New(), Name: "Projector", Type: domain.ResourceTypeEquipment, IsActive: true}
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("FindAvailableRooms", ctx, startAt, endAt, 7).Return([]*domain.Resource{noVC, farRoom, nearRoom, boardroom}, nil)
+	mockResourceRepo.On("GetByID", ctx, nearRoom.ID).Return(nearRoom, nil)
+	mockResourceRepo.On("GetByID", ctx, projector.ID).Return(projector, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{noVC, farRoom, nearRoom, boardroom, projector}, nil)
+	// 会議室と備品が1回の呼び出し（同一トランザクション）で予約される
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.AnythingOfType("*domain.Reservation"), mock.AnythingOfType("[]*domain.ReservationInstance"), []uuid.UUID{nearRoom.ID, projector.ID}).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	floor := 3
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{projector.ID},
+		Title:       "Client Call",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+		Room:        &domain.RoomRequirement{Attendees: 7, Amenities: []string{"vc"}, NearFloor: &floor},
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{nearRoom.ID, projector.ID}, reservation.ResourceIDs)
+	// 選んだ会議室は呼び出し元のリクエストに追加しない
+	assert.Equal(t, []uuid.UUID{projector.ID}, req.ResourceIDs)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestReservationService_CreateReservation_NoSuitableRoom(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(1 * time.Hour)
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+
+	capacity := 12
+	managerOnly := domain.RoleManager
+	restricted := &domain.Resource{
+		ID:           uuid.New(),
+		Name:         "Executive Room",
+		Type:         domain.ResourceTypeMeetingRoom,
+		Capacity:     &capacity,
+		RequiredRole: &managerOnly,
+		IsActive:     true,
+	}
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("FindAvailableRooms", ctx, startAt, endAt, 10).Return([]*domain.Resource{restricted}, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		Title:       "All Hands Prep",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+		Room:        &domain.RoomRequirement{Attendees: 10},
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.ErrorIs(t, err, service.ErrNoSuitableRoom)
+	assert.Nil(t, reservation)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CreateReservation_Occupancy(t *testing.T) {
+	capacity := 6
+	minOccupancy := 3
+
+	tests := []struct {
+		name         string
+		role         domain.Role
+		participants int
+		override     bool
+		wantErr      error
+	}{
+		{name: "Within capacity", role: domain.RoleGeneral, participants: 3},
+		{name: "Capacity exceeded", role: domain.RoleGeneral, participants: 6, wantErr: domain.ErrCapacityExceeded},
+		{name: "Below minimum occupancy", role: domain.RoleGeneral, participants: 0, wantErr: domain.ErrBelowMinOccupancy},
+		{name: "Override by non-admin", role: domain.RoleGeneral, participants: 0, override: true, wantErr: service.ErrOccupancyOverrideForbidden},
+		{name: "Override by admin", role: domain.RoleAdmin, participants: 0, override: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockResourceRepo := new(MockResourceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+
+			svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+			ctx := context.Background()
+			startAt := time.Now().Add(24 * time.Hour)
+			endAt := startAt.Add(1 * time.Hour)
+			user := &domain.User{ID: uuid.New(), Role: tt.role, IsActive: true}
+			room := &domain.Resource{
+				ID:           uuid.New(),
+				Name:         "Room 5C",
+				Type:         domain.ResourceTypeMeetingRoom,
+				Capacity:     &capacity,
+				MinOccupancy: &minOccupancy,
+				IsActive:     true,
+			}
+
+			// 主催者自身と重複は人数に含めない
+			participantIDs := []uuid.UUID{user.ID}
+			for i := 0; i < tt.participants; i++ {
+				participantIDs = append(participantIDs, uuid.New())
+			}
+			if tt.participants > 0 {
+				participantIDs = append(participantIDs, participantIDs[1])
+			}
+
+			mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+			mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+			mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{room}, nil)
+			mockReservationRepo.On("CreateWithInstances", ctx, mock.AnythingOfType("*domain.Reservation"), mock.AnythingOfType("[]*domain.ReservationInstance"), []uuid.UUID{room.ID}).Return(nil)
+			mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			req := &service.CreateReservationRequest{
+				OrganizerID:       user.ID,
+				ResourceIDs:       []uuid.UUID{room.ID},
+				Title:             "Sprint Planning",
+				StartAt:           startAt,
+				EndAt:             endAt,
+				Timezone:          "Asia/Tokyo",
+				ParticipantIDs:    participantIDs,
+				OverrideOccupancy: tt.override,
+			}
+
+			reservation, err := svc.CreateReservation(ctx, req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, reservation)
+				mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, reservation.ParticipantIDs, tt.participants)
+			if tt.override {
+				mockAuditLogRepo.AssertCalled(t, "Create", ctx, mock.MatchedBy(func(log *domain.AuditLog) bool {
+					return log.Details["occupancy_override"] == true
+				}))
+			}
+		})
+	}
+}
+
+// sameTime は同じ時刻を表す time.Time に一致します
+func sameTime(want time.Time) interface{} {
+	return mock.MatchedBy(func(got time.Time) bool { return got.Equal(want) })
+}
+
+func TestReservationService_CreateReservation_SeriesConflict(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	capacity := 6
+	room := &domain.Resource{ID: uuid.New(), Name: "Room 2A", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+	alternative := &domain.Resource{ID: uuid.New(), Name: "Room 2B", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+
+	week := 7 * 24 * time.Hour
+	first := time.Date(2030, 3, 4, 1, 0, 0, 0, time.UTC)
+	second := first.Add(week)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockResourceRepo.On("FindAvailable", ctx, sameTime(first), sameTime(first.Add(time.Hour))).Return([]*domain.Resource{room, alternative}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, sameTime(second), sameTime(second.Add(time.Hour))).Return([]*domain.Resource{alternative}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, sameTime(second.Add(week)), sameTime(second.Add(week+time.Hour))).Return([]*domain.Resource{room, alternative}, nil)
+	mockResourceRepo.On("FindAvailableRooms", ctx, sameTime(second), sameTime(second.Add(time.Hour)), 1).Return([]*domain.Resource{room, alternative}, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		Title:       "Weekly Sync",
+		StartAt:     first,
+		EndAt:       first.Add(time.Hour),
+		RRule:       "FREQ=WEEKLY;COUNT=3",
+		Timezone:    "Asia/Tokyo",
+	}
+
+	// ドライランでは予約せずに競合した回と代替会議室を返す
+	report, err := svc.CheckConflicts(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Occurrences)
+	if assert.Len(t, report.Conflicts, 1) {
+		assert.True(t, report.Conflicts[0].StartAt.Equal(second))
+		assert.Equal(t, []uuid.UUID{room.ID}, report.Conflicts[0].ResourceIDs)
+		assert.Equal(t, []*domain.Resource{alternative}, report.Conflicts[0].Alternatives)
+	}
+
+	// 解決方法を指定せずに作成すると二重予約せず競合状況を返す
+	reservation, err := svc.CreateReservation(ctx, req)
+	assert.Nil(t, reservation)
+	assert.ErrorIs(t, err, service.ErrSeriesConflict)
+	var conflictErr *service.SeriesConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Len(t, conflictErr.Report.Conflicts, 1)
+	}
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReservationService_CreateReservation_SeriesResolutions(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	capacity := 6
+	room := &domain.Resource{ID: uuid.New(), Name: "Room 2A", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+	alternative := &domain.Resource{ID: uuid.New(), Name: "Room 2B", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+
+	week := 7 * 24 * time.Hour
+	first := time.Date(2030, 3, 4, 1, 0, 0, 0, time.UTC)
+	second := first.Add(week)
+	third := second.Add(week)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+	mockResourceRepo.On("GetByID", ctx, alternative.ID).Return(alternative, nil)
+	mockResourceRepo.On("FindAvailable", ctx, sameTime(first), sameTime(first.Add(time.Hour))).Return([]*domain.Resource{room}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, sameTime(third), sameTime(third.Add(time.Hour))).Return([]*domain.Resource{alternative}, nil)
+
+	var created *domain.Reservation
+	var createdInstances []*domain.ReservationInstance
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.AnythingOfType("*domain.Reservation"), mock.AnythingOfType("[]*domain.ReservationInstance"), []uuid.UUID{room.ID}).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Reservation)
+			createdInstances = args.Get(2).([]*domain.ReservationInstance)
+		}).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		Title:       "Weekly Sync",
+		StartAt:     first,
+		EndAt:       first.Add(time.Hour),
+		RRule:       "FREQ=WEEKLY;COUNT=3",
+		Timezone:    "Asia/Tokyo",
+		Resolutions: []*domain.OccurrenceResolution{
+			{StartAt: second, Action: domain.ConflictActionSkip},
+			{StartAt: third, Action: domain.ConflictActionSubstitute, Replacements: map[uuid.UUID]uuid.UUID{room.ID: alternative.ID}},
+		},
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, reservation)
+	// 除外した回は EXDATE として記録し、代替会議室はその回だけに割り当てる
+	if assert.Len(t, created.ExDates, 1) {
+		assert.True(t, created.ExDates[0].Equal(second))
+	}
+	if assert.Len(t, createdInstances, 2) {
+		assert.Nil(t, createdInstances[0].ResourceIDs)
+		assert.Equal(t, []uuid.UUID{alternative.ID}, createdInstances[1].ResourceIDs)
+	}
+	mockReservationRepo.AssertNumberOfCalls(t, "CreateWithInstances", 1)
+}
+
+func TestReservationService_CreateReservation_InvalidResolution(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	capacity := 6
+	room := &domain.Resource{ID: uuid.New(), Name: "Room 2A", Type: domain.ResourceTypeMeetingRoom, Capacity: &capacity, IsActive: true}
+	first := time.Date(2030, 3, 4, 1, 0, 0, 0, time.UTC)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, room.ID).Return(room, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{room.ID},
+		Title:       "Weekly Sync",
+		StartAt:     first,
+		EndAt:       first.Add(time.Hour),
+		RRule:       "FREQ=WEEKLY;COUNT=3",
+		Timezone:    "Asia/Tokyo",
+		// 繰り返しに含まれない日時
+		Resolutions: []*domain.OccurrenceResolution{{StartAt: first.Add(24 * time.Hour), Action: domain.ConflictActionSkip}},
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.ErrorIs(t, err, service.ErrInvalidResolution)
+	assert.Nil(t, reservation)
+}
+
+func TestReservationService_CreateReservation_InvalidDaySlot(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	desk := &domain.Resource{ID: uuid.New(), Name: "Desk A", Type: domain.ResourceTypeDesk, IsActive: true}
+	startAt := time.Date(2030, 6, 3, 9, 0, 0, 0, loc)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, desk.ID).Return(desk, nil)
+
+	_, err := svc.CreateReservation(ctx, &service.CreateReservationRequest{
+		OrganizerID: user.ID,
+		ResourceIDs: []uuid.UUID{desk.ID},
+		Title:       "Desk",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(9 * time.Hour),
+		Timezone:    "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrInvalidDaySlot)
+	mockResourceRepo.AssertNotCalled(t, "FindAvailable", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestReservationService_CancelReservation_Success(t *testing.T) {
 	mockReservationRepo := new(MockReservationRepository)
 	mockResourceRepo := new(MockResourceRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockAuditLogRepo := new(MockAuditLogRepository)
 
-	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
 
 	ctx := context.Background()
 	reservationID := uuid.New()
@@ -149,3 +601,181 @@ func TestReservationService_CancelReservation_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockReservationRepo.AssertExpectations(t)
 }
+
+func TestReservationService_CancelReservation_EnqueuesWaitlistPromotion(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, mockJobQueue, nil, nil, nil)
+
+	ctx := context.Background()
+	reservationID := uuid.New()
+	userID := uuid.New()
+	resourceID := uuid.New()
+	startAt := time.Date(2030, 4, 1, 10, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(time.Hour)
+
+	reservation := &domain.Reservation{
+		ID:          reservationID,
+		OrganizerID: userID,
+		Title:       "Test Meeting",
+		StartAt:     startAt,
+	}
+	instances := []*domain.ReservationInstance{
+		{ID: uuid.New(), ReservationID: reservationID, StartAt: startAt, EndAt: endAt},
+	}
+
+	mockReservationRepo.On("GetByID", ctx, reservationID, startAt).Return(reservation, nil)
+	mockReservationRepo.On("GetInstancesByReservationID", ctx, reservationID).Return(instances, nil)
+	mockReservationRepo.On("GetResourceIDs", ctx, reservationID).Return([]uuid.UUID{resourceID}, nil)
+	mockReservationRepo.On("Delete", ctx, reservationID, startAt).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+	mockJobQueue.On("Enqueue", ctx, "waitlist_promote", map[string]interface{}{
+		"resource_id": resourceID.String(),
+		"start_at":    startAt.Format(time.RFC3339),
+		"end_at":      endAt.Format(time.RFC3339),
+	}).Return("job-1", nil)
+
+	err := svc.CancelReservation(ctx, reservationID, startAt, userID)
+
+	assert.NoError(t, err)
+	mockReservationRepo.AssertExpectations(t)
+	mockJobQueue.AssertExpectations(t)
+}
+
+func TestReservationService_CreateReservation_RequiresApproval(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApproverGroupRepo := new(MockApproverGroupRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+	mockJobQueue := new(MockJobQueue)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	approvalService := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, mockApproverGroupRepo, mockApprovalRepo, notificationService, nil)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, approvalService, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	resourceID := uuid.New()
+	groupID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(1 * time.Hour)
+
+	user := &domain.User{
+		ID:       userID,
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Role:     domain.RoleGeneral,
+		IsActive: true,
+	}
+
+	capacity := 20
+	resource := &domain.Resource{
+		ID:               resourceID,
+		Name:             "Executive Boardroom",
+		Type:             domain.ResourceTypeMeetingRoom,
+		Capacity:         &capacity,
+		IsActive:         true,
+		RequiresApproval: true,
+		ApproverGroupID:  &groupID,
+	}
+
+	approver := &domain.User{ID: uuid.New(), Email: "approver@example.com", Role: domain.RoleManager}
+
+	mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, resourceID).Return(resource, nil)
+	mockResourceRepo.On("FindAvailable", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]*domain.Resource{resource}, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.MatchedBy(func(r *domain.Reservation) bool {
+		return r.ApprovalStatus == domain.ApprovalStatusPending
+	}), mock.MatchedBy(func(instances []*domain.ReservationInstance) bool {
+		for _, inst := range instances {
+			if inst.Status != domain.ReservationStatusTentative {
+				return false
+			}
+		}
+		return len(instances) == 1
+	}), []uuid.UUID{resourceID}).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+	mockApprovalRepo.On("CreateSteps", ctx, mock.MatchedBy(func(steps []*domain.ReservationApproval) bool {
+		return len(steps) == 1 && *steps[0].ApproverGroupID == groupID
+	})).Return(nil)
+	mockApproverGroupRepo.On("ListMembers", ctx, groupID).Return([]*domain.User{approver}, nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(payload map[string]interface{}) bool {
+		return payload["to"] == approver.Email
+	})).Return("job-id", nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: userID,
+		ResourceIDs: []uuid.UUID{resourceID},
+		Title:       "Board Meeting",
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Timezone:    "Asia/Tokyo",
+	}
+
+	reservation, err := svc.CreateReservation(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusPending, reservation.ApprovalStatus)
+	mockReservationRepo.AssertExpectations(t)
+	mockApprovalRepo.AssertExpectations(t)
+	mockApproverGroupRepo.AssertExpectations(t)
+	mockJobQueue.AssertExpectations(t)
+}
+
+func TestReservationService_CreateReservation_ApprovalChainUnresolved(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockApprovalRepo := new(MockApprovalRepository)
+
+	approvalService := service.NewApprovalService(mockReservationRepo, mockUserRepo, mockAuditLogRepo, nil, mockApprovalRepo, nil, nil)
+	svc := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, approvalService, nil, nil, nil, nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	resourceID := uuid.New()
+	policyID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+
+	// 上長が未設定のユーザー
+	user := &domain.User{ID: userID, Role: domain.RoleGeneral, IsActive: true}
+	resource := &domain.Resource{
+		ID:               resourceID,
+		Name:             "Company Car",
+		Type:             domain.ResourceTypeEquipment,
+		IsActive:         true,
+		RequiresApproval: true,
+		ApprovalPolicyID: &policyID,
+	}
+	policy := &domain.ApprovalPolicy{
+		ID:    policyID,
+		Name:  "manager",
+		Steps: []domain.ApprovalPolicyStep{{StepOrder: 1, Type: domain.ApprovalStepManager}},
+	}
+
+	mockUserRepo.On("GetByID", ctx, userID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, resourceID).Return(resource, nil)
+	mockResourceRepo.On("FindAvailable", ctx, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]*domain.Resource{resource}, nil)
+	mockApprovalRepo.On("GetPolicyByID", ctx, policyID).Return(policy, nil)
+
+	req := &service.CreateReservationRequest{
+		OrganizerID: userID,
+		ResourceIDs: []uuid.UUID{resourceID},
+		Title:       "Client Visit",
+		StartAt:     startAt,
+		EndAt:       startAt.Add(2 * time.Hour),
+		Timezone:    "Asia/Tokyo",
+	}
+
+	_, err := svc.CreateReservation(ctx, req)
+
+	assert.ErrorIs(t, err, service.ErrApprovalChainUnresolved)
+	mockReservationRepo.AssertNotCalled(t, "CreateWithInstances", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
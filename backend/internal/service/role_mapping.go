@@ -0,0 +1,125 @@
+// backend/internal/service/role_mapping.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/your-org/esms/internal/domain"
+	pkgoidc "github.com/your-org/esms/pkg/oidc"
+)
+
+var (
+	ErrInvalidRoleMapping = errors.New("invalid role mapping")
+)
+
+// RoleSource はロール・上長をどちらで管理するか（ログイン時の同期の優先順位）を表します
+type RoleSource string
+
+const (
+	// RoleSourceIDP はIdPのクレームを正とし、ログインのたびにロール・上長を上書きします
+	RoleSourceIDP RoleSource = "IDP"
+	// RoleSourceLocal はローカル（管理者の編集）を正とし、IdPのクレームは初回ログイン時のみ使用します
+	RoleSourceLocal RoleSource = "LOCAL"
+)
+
+// RoleMappingRule はクレームの値とロールの対応
+type RoleMappingRule struct {
+	ClaimValue string
+	Role       domain.Role
+}
+
+// RoleMapping はIdPのクレームからロール・上長への変換設定
+// RoleClaim が空の場合はロールを同期せず、新規ユーザーは RoleGeneral で作成します
+type RoleMapping struct {
+	RoleClaim    string            // ロールの判定に使うクレーム（ドット区切り。例: "groups", "realm_access.roles"）
+	Rules        []RoleMappingRule // 先に一致したルールが優先されます
+	ManagerClaim string            // 上長のメールアドレスを表すクレーム（空の場合は同期しない）
+	Source       RoleSource        // 既存ユーザーのロール・上長の管理元
+}
+
+// ParseRoleMapping は設定値からRoleMappingを作成します
+// rules は "クレームの値=ロール" をカンマ区切りで指定します（例: "esms-admins=ADMIN,esms-managers=MANAGER"）
+// source が空の場合は RoleSourceLocal として扱います
+func ParseRoleMapping(roleClaim, rules, managerClaim, source string) (RoleMapping, error) {
+	mapping := RoleMapping{
+		RoleClaim:    strings.TrimSpace(roleClaim),
+		ManagerClaim: strings.TrimSpace(managerClaim),
+		Source:       RoleSource(strings.ToUpper(strings.TrimSpace(source))),
+	}
+	if mapping.Source == "" {
+		mapping.Source = RoleSourceLocal
+	}
+	if mapping.Source != RoleSourceIDP && mapping.Source != RoleSourceLocal {
+		return RoleMapping{}, fmt.Errorf("%w: unknown role source %q", ErrInvalidRoleMapping, source)
+	}
+
+	for _, entry := range strings.Split(rules, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		value, role, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(value) == "" {
+			return RoleMapping{}, fmt.Errorf("%w: rule %q must be in the form value=ROLE", ErrInvalidRoleMapping, entry)
+		}
+		r := domain.Role(strings.ToUpper(strings.TrimSpace(role)))
+		if !r.IsValid() {
+			return RoleMapping{}, fmt.Errorf("%w: unknown role %q", ErrInvalidRoleMapping, role)
+		}
+		mapping.Rules = append(mapping.Rules, RoleMappingRule{ClaimValue: strings.TrimSpace(value), Role: r})
+	}
+
+	if mapping.RoleClaim != "" && len(mapping.Rules) == 0 {
+		return RoleMapping{}, fmt.Errorf("%w: role claim %q has no rules", ErrInvalidRoleMapping, mapping.RoleClaim)
+	}
+	return mapping, nil
+}
+
+// ResolveRole はクレームからロールを決定します
+// クレーム自体がトークンに含まれない場合（IdPの設定漏れやグループ数超過による省略など）は判定できないため ok=false を返します
+// クレームが含まれていてどのルールにも一致しない場合は RoleGeneral を返します
+func (m RoleMapping) ResolveRole(claims map[string]interface{}) (domain.Role, bool) {
+	if m.RoleClaim == "" {
+		return "", false
+	}
+	values := pkgoidc.ClaimValues(claims, m.RoleClaim)
+	if values == nil {
+		return "", false
+	}
+	return m.matchRole(values), true
+}
+
+// ResolveRoleFromGroups はSCIMでプロビジョニングされたグループの値（表示名・externalId）からロールを決定します
+// ルールはクレームの値と同じものを使用し、ルールが設定されていない場合は判定できないため ok=false を返します
+func (m RoleMapping) ResolveRoleFromGroups(values []string) (domain.Role, bool) {
+	if len(m.Rules) == 0 {
+		return "", false
+	}
+	return m.matchRole(values), true
+}
+
+// matchRole は最初に一致したルールのロールを返します（どのルールにも一致しない場合は RoleGeneral）
+func (m RoleMapping) matchRole(values []string) domain.Role {
+	for _, rule := range m.Rules {
+		for _, value := range values {
+			if value == rule.ClaimValue {
+				return rule.Role
+			}
+		}
+	}
+	return domain.RoleGeneral
+}
+
+// ResolveManagerEmail はクレームから上長のメールアドレスを取得します
+func (m RoleMapping) ResolveManagerEmail(claims map[string]interface{}) (string, bool) {
+	if m.ManagerClaim == "" {
+		return "", false
+	}
+	values := pkgoidc.ClaimValues(claims, m.ManagerClaim)
+	if len(values) == 0 || values[0] == "" {
+		return "", false
+	}
+	return values[0], true
+}
@@ -0,0 +1,59 @@
+// backend/internal/service/role_mapping_test.go
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestParseRoleMapping(t *testing.T) {
+	mapping, err := service.ParseRoleMapping("realm_access.roles", "esms-admins=ADMIN, esms-managers=manager", "manager_email", "idp")
+	assert.NoError(t, err)
+	assert.Equal(t, service.RoleSourceIDP, mapping.Source)
+	assert.Equal(t, []service.RoleMappingRule{
+		{ClaimValue: "esms-admins", Role: domain.RoleAdmin},
+		{ClaimValue: "esms-managers", Role: domain.RoleManager},
+	}, mapping.Rules)
+
+	// 未設定の場合は同期せず、管理元はローカル
+	mapping, err = service.ParseRoleMapping("", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, service.RoleSourceLocal, mapping.Source)
+
+	invalid := []struct{ claim, rules, source string }{
+		{"groups", "esms-admins=ROOT", ""},
+		{"groups", "esms-admins", ""},
+		{"groups", "", ""},
+		{"groups", "esms-admins=ADMIN", "BOTH"},
+	}
+	for _, tt := range invalid {
+		_, err := service.ParseRoleMapping(tt.claim, tt.rules, "", tt.source)
+		assert.ErrorIs(t, err, service.ErrInvalidRoleMapping)
+	}
+}
+
+func TestRoleMapping_ResolveRole(t *testing.T) {
+	mapping, err := service.ParseRoleMapping("groups", "esms-admins=ADMIN,esms-managers=MANAGER", "", "IDP")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		claims   map[string]interface{}
+		expected domain.Role
+		ok       bool
+	}{
+		{"First matching rule wins", map[string]interface{}{"groups": []interface{}{"esms-managers", "esms-admins"}}, domain.RoleAdmin, true},
+		{"No matching rule", map[string]interface{}{"groups": []interface{}{"all-staff"}}, domain.RoleGeneral, true},
+		{"Claim missing", map[string]interface{}{"email": "user@example.com"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, ok := mapping.ResolveRole(tt.claims)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, role)
+		})
+	}
+}
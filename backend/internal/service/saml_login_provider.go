@@ -0,0 +1,140 @@
+// backend/internal/service/saml_login_provider.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgsaml "github.com/your-org/esms/pkg/saml"
+)
+
+// DefaultSAMLSessionTTL はIdPがセッションの有効期限（SessionNotOnOrAfter）を指定しない場合のセッションの有効期間
+const DefaultSAMLSessionTTL = 8 * time.Hour
+
+// SAMLServiceProvider はSAML SPのインターフェース（pkg/saml.ServiceProvider が実装）
+type SAMLServiceProvider interface {
+	AuthnRequestURL(relayState string) (redirectURL, requestID string, err error)
+	ParseResponse(samlResponse, expectedRequestID string) (*pkgsaml.Assertion, error)
+	Metadata() ([]byte, error)
+}
+
+// SAMLAttributeMapping はアサーションの属性からユーザー情報への対応
+// 属性名は Name（URI形式など）と FriendlyName のどちらでも指定できます
+type SAMLAttributeMapping struct {
+	EmailAttribute string // メールアドレスの属性（空の場合は "mail"）
+	NameAttribute  string // 表示名の属性（空の場合は "displayName"）
+}
+
+// samlLoginProvider はSAML 2.0（SP-initiated、HTTP-Redirect で要求し HTTP-POST で応答を受け取る）のログインプロバイダー
+type samlLoginProvider struct {
+	providerOptions
+	sp         SAMLServiceProvider
+	mapping    SAMLAttributeMapping
+	sessionTTL time.Duration
+}
+
+// NewSAMLLoginProvider は新しいSAMLのLoginProviderを作成します
+// sessionTTL はセッションの有効期間の上限です（0 の場合は DefaultSAMLSessionTTL）
+func NewSAMLLoginProvider(sp SAMLServiceProvider, mapping SAMLAttributeMapping, sessionTTL time.Duration, opts ProviderOptions) LoginProvider {
+	if mapping.EmailAttribute == "" {
+		mapping.EmailAttribute = "mail"
+	}
+	if mapping.NameAttribute == "" {
+		mapping.NameAttribute = "displayName"
+	}
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSAMLSessionTTL
+	}
+	return &samlLoginProvider{
+		providerOptions: newProviderOptions(opts, ProviderSAML),
+		sp:              sp,
+		mapping:         mapping,
+		sessionTTL:      sessionTTL,
+	}
+}
+
+// Protocol はプロバイダーのプロトコルを返します
+func (p *samlLoginProvider) Protocol() string {
+	return ProviderSAML
+}
+
+// AuthURL は AuthnRequest を含むIdPへのリダイレクト先URLを生成し、要求IDを authReq に設定します
+// state は RelayState としてIdPに渡され、ACSへのPOSTでそのまま返されます
+func (p *samlLoginProvider) AuthURL(ctx context.Context, state string, authReq *AuthRequest) (string, error) {
+	redirectURL, requestID, err := p.sp.AuthnRequestURL(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to create authn request: %w", err)
+	}
+	authReq.RequestID = requestID
+	return redirectURL, nil
+}
+
+// Authenticate は SAMLResponse の署名・条件を検証し、アサーションの属性からユーザー情報を返します
+func (p *samlLoginProvider) Authenticate(ctx context.Context, params CallbackParams, authReq *AuthRequest) (*ExternalIdentity, error) {
+	if params.SAMLResponse == "" {
+		return nil, errors.New("no SAMLResponse in request")
+	}
+
+	assertion, err := p.sp.ParseResponse(params.SAMLResponse, authReq.RequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify saml response: %w", err)
+	}
+
+	// transient の NameID はログインのたびに変わり、ユーザーを特定するキーにできない
+	if assertion.NameIDFormat == pkgsaml.NameIDFormatTransient {
+		return nil, errors.New("transient NameID is not supported; configure the IdP to send a persistent NameID")
+	}
+
+	email := assertion.Attribute(p.mapping.EmailAttribute)
+	if email == "" && assertion.NameIDFormat == pkgsaml.NameIDFormatEmail {
+		email = assertion.NameID
+	}
+	if email == "" {
+		return nil, fmt.Errorf("no email attribute %q in assertion", p.mapping.EmailAttribute)
+	}
+	name := assertion.Attribute(p.mapping.NameAttribute)
+	if name == "" {
+		name = email
+	}
+
+	// セッションの有効期限はIdPのセッションの有効期限を超えないようにする
+	expiresAt := time.Now().Add(p.sessionTTL)
+	if !assertion.SessionNotOnOrAfter.IsZero() && assertion.SessionNotOnOrAfter.Before(expiresAt) {
+		expiresAt = assertion.SessionNotOnOrAfter
+	}
+
+	return &ExternalIdentity{
+		Issuer:    assertion.Issuer,
+		Subject:   assertion.NameID,
+		Email:     strings.TrimSpace(email),
+		Name:      name,
+		Claims:    samlClaims(assertion),
+		ExpiresAt: expiresAt,
+		AuditDetails: map[string]interface{}{
+			"assertion_id":   assertion.ID,
+			"name_id_format": assertion.NameIDFormat,
+			"session_index":  assertion.SessionIndex,
+		},
+	}, nil
+}
+
+// Metadata はIdPに登録するSPのメタデータを返します
+func (p *samlLoginProvider) Metadata() ([]byte, error) {
+	return p.sp.Metadata()
+}
+
+// samlClaims はアサーションの属性をロールの判定に使うクレームの形式（属性名ごとの値の配列）に変換します
+func samlClaims(assertion *pkgsaml.Assertion) map[string]interface{} {
+	claims := make(map[string]interface{}, len(assertion.Attributes))
+	for name, values := range assertion.Attributes {
+		items := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			items = append(items, v)
+		}
+		claims[name] = items
+	}
+	return claims
+}
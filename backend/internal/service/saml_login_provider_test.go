@@ -0,0 +1,224 @@
+// backend/internal/service/saml_login_provider_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+	"github.com/your-org/esms/internal/service"
+	pkgsaml "github.com/your-org/esms/pkg/saml"
+)
+
+// MockSAMLServiceProvider はSAML SPのモック
+type MockSAMLServiceProvider struct {
+	mock.Mock
+}
+
+func (m *MockSAMLServiceProvider) AuthnRequestURL(relayState string) (string, string, error) {
+	args := m.Called(relayState)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockSAMLServiceProvider) ParseResponse(samlResponse, expectedRequestID string) (*pkgsaml.Assertion, error) {
+	args := m.Called(samlResponse, expectedRequestID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pkgsaml.Assertion), args.Error(1)
+}
+
+func (m *MockSAMLServiceProvider) Metadata() ([]byte, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+const samlIdPEntityID = "https://idp.example.com/saml"
+
+// newSAMLAssertion はテスト用の検証済みアサーションを作成します
+func newSAMLAssertion() *pkgsaml.Assertion {
+	return &pkgsaml.Assertion{
+		ID:                  "_assert-1",
+		Issuer:              samlIdPEntityID,
+		NameID:              "persistent-id-123",
+		NameIDFormat:        pkgsaml.NameIDFormatPersistent,
+		SessionIndex:        "_session-1",
+		SessionNotOnOrAfter: time.Now().Add(2 * time.Hour),
+		Attributes: map[string][]string{
+			"mail":        {"saml.user@example.com"},
+			"displayName": {"SAML User"},
+			"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups": {"all-staff", "esms-managers"},
+		},
+	}
+}
+
+func TestAuthService_SAMLLogin(t *testing.T) {
+	mockSP := new(MockSAMLServiceProvider)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	mapping, err := service.ParseRoleMapping("http://schemas.microsoft.com/ws/2008/06/identity/claims/groups", "esms-managers=MANAGER", "", "IDP")
+	require.NoError(t, err)
+
+	svc := service.NewAuthService(new(MockOIDCClient), mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), mapping, 0,
+		service.NewSAMLLoginProvider(mockSP, service.SAMLAttributeMapping{}, 8*time.Hour, service.ProviderOptions{}))
+	ctx := context.Background()
+	assertion := newSAMLAssertion()
+
+	mockSP.On("AuthnRequestURL", mock.AnythingOfType("string")).Return("https://idp.example.com/saml/sso?SAMLRequest=xxx", "_req-1", nil)
+	mockSP.On("ParseResponse", "saml-response", "_req-1").Return(assertion, nil)
+	mockUserRepo.On("GetBySubject", mock.Anything, samlIdPEntityID, "persistent-id-123").Return(nil, repository.ErrNotFound)
+	mockUserRepo.On("ListByEmail", mock.Anything, "saml.user@example.com").Return([]*domain.User{}, nil)
+	mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == "saml.user@example.com" && u.Name == "SAML User" && u.Role == domain.RoleManager &&
+			u.HasIdentity(samlIdPEntityID, "persistent-id-123")
+	})).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionRoleSync
+	})).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.AuditLog) bool {
+		return l.Action == domain.AuditActionLogin && l.Details["provider"] == service.ProviderSAML &&
+			l.Details["session_index"] == "_session-1"
+	})).Return(nil)
+
+	authURL, relayState, err := svc.BeginLogin(ctx, service.ProviderSAML)
+	require.NoError(t, err)
+	assert.Contains(t, authURL, "SAMLRequest=")
+
+	session, err := svc.CompleteLogin(ctx, service.ProviderSAML, relayState, service.CallbackParams{SAMLResponse: "saml-response"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "saml.user@example.com", session.Email)
+	assert.Equal(t, domain.RoleManager, session.Role)
+	assert.Empty(t, session.RefreshToken)
+	// IdPのセッションの有効期限（SessionNotOnOrAfter）を超えない
+	assert.WithinDuration(t, assertion.SessionNotOnOrAfter, session.ExpiresAt, time.Second)
+
+	// 同じ RelayState は再利用できない
+	_, err = svc.CompleteLogin(ctx, service.ProviderSAML, relayState, service.CallbackParams{SAMLResponse: "saml-response"}, "")
+	assert.ErrorIs(t, err, service.ErrInvalidState)
+
+	// リフレッシュトークンのないセッションは更新できない
+	_, err = svc.RefreshSession(ctx, session.ID)
+	assert.ErrorIs(t, err, service.ErrRefreshNotSupported)
+
+	mockSP.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuthService_SAMLLogin_Identity(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(a *pkgsaml.Assertion)
+		wantEmail string
+		wantName  string
+		wantErr   bool
+	}{
+		{
+			name:      "attributes",
+			wantEmail: "saml.user@example.com",
+			wantName:  "SAML User",
+		},
+		{
+			name: "email from NameID",
+			mutate: func(a *pkgsaml.Assertion) {
+				delete(a.Attributes, "mail")
+				delete(a.Attributes, "displayName")
+				a.NameID = "nameid.user@example.com"
+				a.NameIDFormat = pkgsaml.NameIDFormatEmail
+			},
+			wantEmail: "nameid.user@example.com",
+			wantName:  "nameid.user@example.com",
+		},
+		{
+			name:    "missing email",
+			mutate:  func(a *pkgsaml.Assertion) { delete(a.Attributes, "mail") },
+			wantErr: true,
+		},
+		{
+			name:    "transient NameID",
+			mutate:  func(a *pkgsaml.Assertion) { a.NameIDFormat = pkgsaml.NameIDFormatTransient },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSP := new(MockSAMLServiceProvider)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditRepo := new(MockAuditLogRepository)
+			svc := service.NewAuthService(new(MockOIDCClient), mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0,
+				service.NewSAMLLoginProvider(mockSP, service.SAMLAttributeMapping{}, 0, service.ProviderOptions{}))
+			ctx := context.Background()
+
+			assertion := newSAMLAssertion()
+			if tt.mutate != nil {
+				tt.mutate(assertion)
+			}
+			mockSP.On("AuthnRequestURL", mock.AnythingOfType("string")).Return("https://idp.example.com/saml/sso", "_req-1", nil)
+			mockSP.On("ParseResponse", "saml-response", "_req-1").Return(assertion, nil)
+			mockUserRepo.On("GetBySubject", mock.Anything, samlIdPEntityID, assertion.NameID).Return(nil, repository.ErrNotFound)
+			mockUserRepo.On("ListByEmail", mock.Anything, tt.wantEmail).Return([]*domain.User{}, nil)
+			mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+				return u.Email == tt.wantEmail && u.Name == tt.wantName
+			})).Return(nil)
+			mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			_, state, err := svc.BeginLogin(ctx, service.ProviderSAML)
+			require.NoError(t, err)
+			_, err = svc.CompleteLogin(ctx, service.ProviderSAML, state, service.CallbackParams{SAMLResponse: "saml-response"}, "")
+			if tt.wantErr {
+				assert.Error(t, err)
+				mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthService_CompleteLogin_ProviderMismatch(t *testing.T) {
+	mockOIDC := new(MockOIDCClient)
+	mockSP := new(MockSAMLServiceProvider)
+	svc := service.NewAuthService(mockOIDC, new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0,
+		service.NewSAMLLoginProvider(mockSP, service.SAMLAttributeMapping{}, 0, service.ProviderOptions{}))
+	ctx := context.Background()
+
+	mockOIDC.On("GetAuthURL", mock.Anything).Return("http://auth.example.com")
+
+	// OIDCで開始した state はSAMLのACSでは受け付けない
+	_, state, err := svc.BeginLogin(ctx, service.ProviderOIDC)
+	require.NoError(t, err)
+	_, err = svc.CompleteLogin(ctx, service.ProviderSAML, state, service.CallbackParams{SAMLResponse: "saml-response"}, "")
+	assert.ErrorIs(t, err, service.ErrInvalidState)
+	mockSP.AssertNotCalled(t, "ParseResponse", mock.Anything, mock.Anything)
+
+	_, _, err = svc.BeginLogin(ctx, "unknown")
+	assert.ErrorIs(t, err, service.ErrProviderNotFound)
+}
+
+func TestAuthService_ProviderMetadata(t *testing.T) {
+	mockSP := new(MockSAMLServiceProvider)
+	svc := service.NewAuthService(new(MockOIDCClient), new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), service.NewMemorySessionStore(), service.RoleMapping{}, 0,
+		service.NewSAMLLoginProvider(mockSP, service.SAMLAttributeMapping{}, 0, service.ProviderOptions{}))
+
+	mockSP.On("Metadata").Return([]byte("<EntityDescriptor/>"), nil)
+
+	metadata, err := svc.ProviderMetadata(service.ProviderSAML)
+	assert.NoError(t, err)
+	assert.Equal(t, "<EntityDescriptor/>", string(metadata))
+
+	_, err = svc.ProviderMetadata(service.ProviderOIDC)
+	assert.ErrorIs(t, err, service.ErrMetadataNotSupported)
+
+	_, err = svc.ProviderMetadata("unknown")
+	assert.ErrorIs(t, err, service.ErrProviderNotFound)
+}
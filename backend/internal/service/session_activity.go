@@ -0,0 +1,134 @@
+// backend/internal/service/session_activity.go
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// sessionActivityInterval は最終アクセス日時を更新する最小間隔（リクエストごとにセッションを書き込まないため）
+const sessionActivityInterval = time.Minute
+
+// ClientInfo はリクエスト元の端末の情報
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo はリクエスト元の端末の情報をコンテキストに設定します
+// ログイン時はセッションに記録し、GetSession では最終アクセス日時とともに更新します
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// clientInfoFromContext はコンテキストからリクエスト元の端末の情報を取得します
+func clientInfoFromContext(ctx context.Context) (ClientInfo, bool) {
+	client, ok := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return client, ok
+}
+
+// SessionInfo は利用者・管理者に表示するセッションの情報
+// セッションIDはそれ自体が認証情報のため返さず、ハッシュから作成した識別子（ID）で指定します
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Provider   string    `json:"provider"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // リクエストに使用したセッション
+}
+
+// sessionHandle はセッションIDから表示用の識別子を作成します
+func sessionHandle(sessionID string) string {
+	sum := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// newSessionInfo はセッションを表示用の情報に変換します
+func newSessionInfo(session *Session, currentSessionID string) *SessionInfo {
+	provider := session.Provider
+	if provider == "" {
+		provider = ProviderOIDC
+	}
+	lastSeenAt := session.LastSeenAt
+	if lastSeenAt.IsZero() {
+		lastSeenAt = session.CreatedAt
+	}
+	return &SessionInfo{
+		ID:         sessionHandle(session.ID),
+		Device:     describeDevice(session.UserAgent),
+		IPAddress:  session.IPAddress,
+		UserAgent:  session.UserAgent,
+		Provider:   provider,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: lastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    currentSessionID != "" && session.ID == currentSessionID,
+	}
+}
+
+// describeDevice は User-Agent からブラウザとOSを判定し、「Chrome on Windows」の形式で返します
+// 判定できない場合は "Unknown" を返します
+func describeDevice(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown"
+	}
+
+	// 他のブラウザの名前を含む User-Agent があるため、判定の順序に意味があります
+	browsers := []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"FxiOS/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	}
+	systems := []struct{ token, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	}
+
+	browser := ""
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	system := ""
+	for _, sys := range systems {
+		if strings.Contains(userAgent, sys.token) {
+			system = sys.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		// ブラウザ以外のクライアント（curl など）は製品名をそのまま表示する
+		if product, _, _ := strings.Cut(userAgent, " "); product != "" {
+			return product
+		}
+		return "Unknown"
+	}
+}
@@ -0,0 +1,188 @@
+// backend/internal/service/session_activity_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coreosoidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+	"github.com/your-org/esms/pkg/oidc"
+	"golang.org/x/oauth2"
+)
+
+const chromeOnWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+func saveTestSession(t *testing.T, store service.SessionStore, sessionID string, userID uuid.UUID, lastSeenAt time.Time) {
+	t.Helper()
+	assert.NoError(t, store.SaveSession(context.Background(), sessionID, &service.Session{
+		ID:         sessionID,
+		UserID:     userID,
+		ExpiresAt:  time.Now().Add(time.Hour),
+		CreatedAt:  lastSeenAt,
+		LastSeenAt: lastSeenAt,
+		IPAddress:  "192.0.2.1",
+		UserAgent:  chromeOnWindows,
+	}))
+}
+
+func TestAuthService_GetSession_RecordsActivity(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	svc := service.NewAuthService(nil, new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+
+	userID := uuid.New()
+	saveTestSession(t, store, "session-1", userID, time.Now().Add(-10*time.Minute))
+
+	// 端末の情報がない場合（内部の呼び出し）は更新しない
+	session, err := svc.GetSession(context.Background(), "session-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.1", session.IPAddress)
+
+	ctx := service.WithClientInfo(context.Background(), service.ClientInfo{IPAddress: "198.51.100.7", UserAgent: chromeOnWindows})
+	_, err = svc.GetSession(ctx, "session-1")
+	assert.NoError(t, err)
+
+	stored, err := store.GetSession(context.Background(), "session-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "198.51.100.7", stored.IPAddress)
+	assert.WithinDuration(t, time.Now(), stored.LastSeenAt, time.Second)
+}
+
+func TestAuthService_ListSessions(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	svc := service.NewAuthService(nil, new(MockUserRepository), new(MockAuditLogRepository), new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+
+	userID := uuid.New()
+	saveTestSession(t, store, "older", userID, time.Now().Add(-time.Hour))
+	saveTestSession(t, store, "current", userID, time.Now())
+	saveTestSession(t, store, "other-user", uuid.New(), time.Now())
+
+	sessions, err := svc.ListSessions(context.Background(), userID, "current")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	// 最終アクセスの新しい順。セッションIDそのものは返さない
+	assert.True(t, sessions[0].Current)
+	assert.False(t, sessions[1].Current)
+	assert.NotEqual(t, "current", sessions[0].ID)
+	assert.Equal(t, "Chrome on Windows", sessions[0].Device)
+	assert.Equal(t, service.ProviderOIDC, sessions[0].Provider)
+}
+
+func TestAuthService_RevokeUserSessions(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	mockAuditRepo := new(MockAuditLogRepository)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	svc := service.NewAuthService(nil, new(MockUserRepository), mockAuditRepo, new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+	ctx := context.Background()
+
+	userID, adminID := uuid.New(), uuid.New()
+	saveTestSession(t, store, "session-1", userID, time.Now())
+	saveTestSession(t, store, "session-2", userID, time.Now())
+
+	revoked, err := svc.RevokeUserSessions(ctx, adminID, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	remaining, err := svc.ListSessions(ctx, userID, "")
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	// 全てのセッションの無効化はログアウトではなく、無効化を行った管理者の操作として記録する
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionSessionRevoke}, auditActions(mockAuditRepo))
+	auditLog := mockAuditRepo.Calls[0].Arguments.Get(1).(*domain.AuditLog)
+	assert.Equal(t, adminID, auditLog.UserID)
+	assert.Equal(t, userID.String(), auditLog.TargetID)
+	assert.Equal(t, "revoked_all", auditLog.Details["reason"])
+	assert.Equal(t, 2, auditLog.Details["revoked_sessions"])
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	mockAuditRepo := new(MockAuditLogRepository)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	svc := service.NewAuthService(nil, new(MockUserRepository), mockAuditRepo, new(MockIdentityConflictRepository), store, service.RoleMapping{}, 0)
+	ctx := context.Background()
+
+	userID, otherID, adminID := uuid.New(), uuid.New(), uuid.New()
+	saveTestSession(t, store, "session-1", userID, time.Now())
+	saveTestSession(t, store, "session-2", userID, time.Now())
+	saveTestSession(t, store, "other-session", otherID, time.Now())
+
+	otherSessions, err := svc.ListSessions(ctx, otherID, "")
+	assert.NoError(t, err)
+	sessions, err := svc.ListSessions(ctx, userID, "")
+	assert.NoError(t, err)
+
+	// 他のユーザーのセッションは指定できない
+	assert.ErrorIs(t, svc.RevokeSession(ctx, userID, userID, otherSessions[0].ID), service.ErrSessionNotFound)
+	assert.ErrorIs(t, svc.RevokeSession(ctx, userID, userID, "unknown"), service.ErrSessionNotFound)
+
+	assert.NoError(t, svc.RevokeSession(ctx, adminID, userID, sessions[0].ID))
+	remaining, err := svc.ListSessions(ctx, userID, "")
+	assert.NoError(t, err)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, sessions[1].ID, remaining[0].ID)
+
+	assert.Equal(t, []domain.AuditAction{domain.AuditActionSessionRevoke}, auditActions(mockAuditRepo))
+	auditLog := mockAuditRepo.Calls[0].Arguments.Get(1).(*domain.AuditLog)
+	assert.Equal(t, adminID, auditLog.UserID)
+	assert.Equal(t, userID.String(), auditLog.TargetID)
+	assert.Equal(t, sessions[0].ID, auditLog.Details["session"])
+	assert.Equal(t, "revoked", auditLog.Details["reason"])
+}
+
+func TestAuthService_CompleteLogin_SessionLimit(t *testing.T) {
+	mockOIDC := new(MockOIDCClient)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditLogRepository)
+	store := service.NewMemorySessionStore()
+	svc := service.NewAuthService(mockOIDC, mockUserRepo, mockAuditRepo, new(MockIdentityConflictRepository), store, service.RoleMapping{}, 2)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Issuer: "https://idp.example.com", Sub: "sub", Email: "user@example.com", Role: domain.RoleGeneral, IsActive: true}
+	saveTestSession(t, store, "oldest", user.ID, time.Now().Add(-2*time.Hour))
+	saveTestSession(t, store, "recent", user.ID, time.Now().Add(-time.Minute))
+
+	token := (&oauth2.Token{AccessToken: "access-token", Expiry: time.Now().Add(time.Hour)}).WithExtra(map[string]interface{}{"id_token": "raw-id-token"})
+	mockOIDC.On("GetAuthURL", mock.Anything).Return("http://auth.example.com")
+	mockOIDC.On("ExchangeCode", mock.Anything, "code", mock.AnythingOfType("string")).Return(token, nil)
+	mockOIDC.On("ParseIDTokenClaimsWithValidation", mock.Anything, "raw-id-token", mock.AnythingOfType("string"), "access-token").Return(&oidc.TokenClaims{Issuer: "https://idp.example.com", Subject: "sub"}, nil)
+	mockOIDC.On("VerifyIDToken", mock.Anything, "raw-id-token").Return(&coreosoidc.IDToken{}, nil)
+	mockOIDC.On("GetUserInfo", mock.Anything, mock.Anything).Return(&oidc.UserInfo{Email: user.Email, Name: "User"}, nil)
+	mockUserRepo.On("GetBySubject", mock.Anything, "https://idp.example.com", "sub").Return(user, nil)
+	mockUserRepo.On("Update", mock.Anything, user).Return(nil)
+	mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, state, err := svc.GetAuthURL(ctx)
+	assert.NoError(t, err)
+	loginCtx := service.WithClientInfo(ctx, service.ClientInfo{IPAddress: "203.0.113.5", UserAgent: "curl/8.5.0"})
+	session, err := svc.HandleCallback(loginCtx, "code", state, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "203.0.113.5", session.IPAddress)
+	assert.Equal(t, "curl/8.5.0", session.UserAgent)
+
+	// 上限を超えた分は最終アクセスの古いセッションから無効化する
+	_, err = svc.GetSession(ctx, "oldest")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	_, err = svc.GetSession(ctx, "recent")
+	assert.NoError(t, err)
+	_, err = svc.GetSession(ctx, session.ID)
+	assert.NoError(t, err)
+
+	sessions, err := svc.ListSessions(ctx, user.ID, session.ID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, "curl/8.5.0", sessions[0].Device)
+
+	assert.Contains(t, auditActions(mockAuditRepo), domain.AuditActionSessionRevoke)
+	for _, call := range mockAuditRepo.Calls {
+		if auditLog := call.Arguments.Get(1).(*domain.AuditLog); auditLog.Action == domain.AuditActionSessionRevoke {
+			assert.Equal(t, "session_limit", auditLog.Details["reason"])
+		}
+	}
+}
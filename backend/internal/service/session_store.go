@@ -0,0 +1,429 @@
+// backend/internal/service/session_store.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/your-org/esms/internal/cache"
+)
+
+// AuthRequestTTL は認証開始から IdP のコールバックまでに許容する時間（state・code_verifier・nonce・要求IDの有効期間）
+const AuthRequestTTL = 10 * time.Minute
+
+// AuthRequest は認証開始時に発行した state に紐づく一度限りの検証値
+type AuthRequest struct {
+	Provider     string `json:"provider,omitempty"`   // 認証を開始したログインプロバイダー
+	CodeVerifier string `json:"code_verifier"`        // OIDC: PKCE の code_verifier
+	Nonce        string `json:"nonce"`                // OIDC: IDトークンの nonce
+	RequestID    string `json:"request_id,omitempty"` // SAML: AuthnRequest のID（InResponseTo の検証に使用）
+}
+
+// SessionStore はセッションと認証開始時の検証値を保存するストアのインターフェース
+// 複数のAPIインスタンスで共有できるよう、本番環境ではRedisの実装を使用します
+type SessionStore interface {
+	// SaveSession はセッションを保存します。有効期間はセッションの有効期限（トークンの有効期限）に合わせます
+	SaveSession(ctx context.Context, sessionID string, session *Session) error
+	// GetSession はセッションを取得します。存在しないか期限切れの場合は ErrSessionNotFound を返します
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	// DeleteSession はセッションを削除します（存在しない場合もエラーにしない）
+	DeleteSession(ctx context.Context, sessionID string) error
+	// UpdateSession は既存のセッションを上書きします（最終アクセス日時の更新用）
+	// 削除済みのセッションを復活させないよう、存在しない場合は保存せず ErrSessionNotFound を返します
+	UpdateSession(ctx context.Context, sessionID string, session *Session) error
+	// ListUserSessions はユーザーの有効なセッションの一覧を返します（Session.ID は保存したセッションIDです）
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	// DeleteUserSessions はユーザーの全てのセッションを削除し、削除した件数を返します
+	DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// SaveAuthRequest は state に紐づく検証値を保存します
+	SaveAuthRequest(ctx context.Context, state string, req *AuthRequest, ttl time.Duration) error
+	// ConsumeAuthRequest は state に紐づく検証値を取り出して削除します（一度限り）
+	// 存在しないか期限切れの場合は ErrInvalidState を返します
+	ConsumeAuthRequest(ctx context.Context, state string) (*AuthRequest, error)
+}
+
+// sessionTTL はセッションの有効期限までの残り時間を返します
+func sessionTTL(session *Session) (time.Duration, error) {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return 0, errors.New("session already expired")
+	}
+	return ttl, nil
+}
+
+// redisSessionStore はRedisを使用したSessionStoreの実装
+//
+// キー設計（docs/detailed/01_auth_security.md 3.2）:
+//
+//	session:{session_id}       セッション情報（TTL: セッションの有効期限まで）
+//	user_sessions:{user_id}    ユーザーのセッションIDの集合（強制ログアウト用）
+//	oauth_state:{state}        state に紐づく検証値（TTL: AuthRequestTTL）
+type redisSessionStore struct {
+	client *cache.RedisClient
+}
+
+// NewRedisSessionStore は新しいRedisのSessionStoreを作成します
+func NewRedisSessionStore(client *cache.RedisClient) SessionStore {
+	return &redisSessionStore{client: client}
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func userSessionsKey(userID uuid.UUID) string {
+	return "user_sessions:" + userID.String()
+}
+
+func authRequestKey(state string) string {
+	return "oauth_state:" + state
+}
+
+// SaveSession はセッションを保存し、ユーザーのセッション集合に登録します
+func (s *redisSessionStore) SaveSession(ctx context.Context, sessionID string, session *Session) error {
+	ttl, err := sessionTTL(session)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	_, err = s.client.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(sessionID), data, ttl)
+		pipe.SAdd(ctx, userSessionsKey(session.UserID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// GetSession はセッションを取得します
+func (s *redisSessionStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	var session Session
+	if err := s.client.Get(ctx, sessionKey(sessionID), &session); err != nil {
+		if errors.Is(err, cache.ErrCacheMiss) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+// UpdateSession は SET XX で既存のセッションのみを上書きします
+func (s *redisSessionStore) UpdateSession(ctx context.Context, sessionID string, session *Session) error {
+	ttl, err := sessionTTL(session)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	updated, err := s.client.Client.SetXX(ctx, sessionKey(sessionID), data, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	if !updated {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// ListUserSessions はユーザーのセッション集合からセッションを取得します
+// 期限切れで既に消えたセッションのIDは集合から取り除きます
+func (s *redisSessionStore) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error) {
+	sessionIDs, err := s.client.Client.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	if len(sessionIDs) == 0 {
+		return []*Session{}, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+	values, err := s.client.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	now := time.Now()
+	sessions := make([]*Session, 0, len(values))
+	var stale []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		var session Session
+		if err := json.Unmarshal([]byte(data), &session); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal session: %w", err)
+		}
+		if now.After(session.ExpiresAt) {
+			continue
+		}
+		session.ID = sessionIDs[i]
+		sessions = append(sessions, &session)
+	}
+	if len(stale) > 0 {
+		// 集合の掃除に失敗しても一覧の取得は失敗させない（次回の取得時に再度取り除く）
+		_ = s.client.Client.SRem(ctx, userSessionsKey(userID), stale...).Err()
+	}
+	return sessions, nil
+}
+
+// DeleteSession はセッションを削除し、ユーザーのセッション集合から外します
+func (s *redisSessionStore) DeleteSession(ctx context.Context, sessionID string) error {
+	session, err := s.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = s.client.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(sessionID))
+		pipe.SRem(ctx, userSessionsKey(session.UserID), sessionID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserSessions はユーザーの全てのセッションを削除します
+// 期限切れで既に消えたセッションは件数に含めません
+func (s *redisSessionStore) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	sessionIDs, err := s.client.Client.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	if len(sessionIDs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+
+	var deleted *redis.IntCmd
+	_, err = s.client.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, keys...)
+		pipe.Del(ctx, userSessionsKey(userID))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return int(deleted.Val()), nil
+}
+
+// SaveAuthRequest は state に紐づく検証値を保存します
+func (s *redisSessionStore) SaveAuthRequest(ctx context.Context, state string, req *AuthRequest, ttl time.Duration) error {
+	if err := s.client.Set(ctx, authRequestKey(state), req, ttl); err != nil {
+		return fmt.Errorf("failed to save auth request: %w", err)
+	}
+	return nil
+}
+
+// ConsumeAuthRequest は GETDEL で検証値の取得と削除を原子的に行い、同じ state の再利用を防ぎます
+func (s *redisSessionStore) ConsumeAuthRequest(ctx context.Context, state string) (*AuthRequest, error) {
+	val, err := s.client.Client.GetDel(ctx, authRequestKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrInvalidState
+		}
+		return nil, fmt.Errorf("failed to consume auth request: %w", err)
+	}
+
+	var req AuthRequest
+	if err := json.Unmarshal([]byte(val), &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth request: %w", err)
+	}
+	return &req, nil
+}
+
+// memorySessionStore はプロセス内のメモリを使用したSessionStoreの実装（テスト・単一インスタンスでの開発用）
+type memorySessionStore struct {
+	mu           sync.Mutex
+	sessions     map[string]*Session
+	userSessions map[uuid.UUID]map[string]bool
+	authRequests map[string]memoryAuthRequest
+}
+
+// memoryAuthRequest は有効期限付きの検証値
+type memoryAuthRequest struct {
+	req       AuthRequest
+	expiresAt time.Time
+}
+
+// NewMemorySessionStore は新しいメモリ内のSessionStoreを作成します
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions:     make(map[string]*Session),
+		userSessions: make(map[uuid.UUID]map[string]bool),
+		authRequests: make(map[string]memoryAuthRequest),
+	}
+}
+
+// SaveSession はセッションを保存します
+func (s *memorySessionStore) SaveSession(ctx context.Context, sessionID string, session *Session) error {
+	if _, err := sessionTTL(session); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *session
+	s.sessions[sessionID] = &stored
+	if s.userSessions[session.UserID] == nil {
+		s.userSessions[session.UserID] = make(map[string]bool)
+	}
+	s.userSessions[session.UserID][sessionID] = true
+	return nil
+}
+
+// GetSession はセッションを取得します（期限切れのセッションは削除します）
+func (s *memorySessionStore) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	if time.Now().After(session.ExpiresAt) {
+		s.deleteLocked(sessionID)
+		return nil, ErrSessionNotFound
+	}
+
+	found := *session
+	return &found, nil
+}
+
+// UpdateSession は既存のセッションを上書きします
+func (s *memorySessionStore) UpdateSession(ctx context.Context, sessionID string, session *Session) error {
+	if _, err := sessionTTL(session); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[sessionID]; !ok {
+		return ErrSessionNotFound
+	}
+	stored := *session
+	s.sessions[sessionID] = &stored
+	return nil
+}
+
+// ListUserSessions はユーザーの有効なセッションの一覧を返します（期限切れのセッションは削除します）
+func (s *memorySessionStore) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]*Session, 0, len(s.userSessions[userID]))
+	for sessionID := range s.userSessions[userID] {
+		session, ok := s.sessions[sessionID]
+		if !ok {
+			continue
+		}
+		if now.After(session.ExpiresAt) {
+			s.deleteLocked(sessionID)
+			continue
+		}
+		found := *session
+		found.ID = sessionID
+		sessions = append(sessions, &found)
+	}
+	return sessions, nil
+}
+
+// DeleteSession はセッションを削除します
+func (s *memorySessionStore) DeleteSession(ctx context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deleteLocked(sessionID)
+	return nil
+}
+
+// DeleteUserSessions はユーザーの全てのセッションを削除します
+func (s *memorySessionStore) DeleteUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for sessionID := range s.userSessions[userID] {
+		if session, ok := s.sessions[sessionID]; ok && !time.Now().After(session.ExpiresAt) {
+			deleted++
+		}
+		delete(s.sessions, sessionID)
+	}
+	delete(s.userSessions, userID)
+	return deleted, nil
+}
+
+// deleteLocked はロックを保持した状態でセッションを削除します
+func (s *memorySessionStore) deleteLocked(sessionID string) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return
+	}
+	delete(s.sessions, sessionID)
+	delete(s.userSessions[session.UserID], sessionID)
+	if len(s.userSessions[session.UserID]) == 0 {
+		delete(s.userSessions, session.UserID)
+	}
+}
+
+// SaveAuthRequest は state に紐づく検証値を保存します
+func (s *memorySessionStore) SaveAuthRequest(ctx context.Context, state string, req *AuthRequest, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authRequests[state] = memoryAuthRequest{req: *req, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// ConsumeAuthRequest は state に紐づく検証値を取り出して削除します
+func (s *memorySessionStore) ConsumeAuthRequest(ctx context.Context, state string) (*AuthRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.authRequests[state]
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	delete(s.authRequests, state)
+	if time.Now().After(stored.expiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	req := stored.req
+	return &req, nil
+}
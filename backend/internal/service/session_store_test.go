@@ -0,0 +1,205 @@
+// backend/internal/service/session_store_test.go
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/internal/cache"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+func newTestSession(userID uuid.UUID, ttl time.Duration) *service.Session {
+	return &service.Session{
+		UserID:      userID,
+		Email:       "user@example.com",
+		Role:        domain.RoleGeneral,
+		AccessToken: "access-token",
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+}
+
+func TestMemorySessionStore_Sessions(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	assert.NoError(t, store.SaveSession(ctx, "session-1", newTestSession(userID, time.Hour)))
+	assert.NoError(t, store.SaveSession(ctx, "session-2", newTestSession(userID, time.Hour)))
+	assert.NoError(t, store.SaveSession(ctx, "other", newTestSession(uuid.New(), time.Hour)))
+
+	session, err := store.GetSession(ctx, "session-1")
+	assert.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+
+	// 期限切れのセッションは保存できない
+	assert.Error(t, store.SaveSession(ctx, "expired", newTestSession(userID, -time.Minute)))
+
+	assert.NoError(t, store.DeleteSession(ctx, "session-1"))
+	_, err = store.GetSession(ctx, "session-1")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+
+	// ユーザーの全てのセッションを削除しても他のユーザーのセッションは残る
+	revoked, err := store.DeleteUserSessions(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	_, err = store.GetSession(ctx, "session-2")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	_, err = store.GetSession(ctx, "other")
+	assert.NoError(t, err)
+}
+
+func TestMemorySessionStore_ConsumeAuthRequestOnce(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	ctx := context.Background()
+
+	assert.NoError(t, store.SaveAuthRequest(ctx, "state-1", &service.AuthRequest{CodeVerifier: "verifier", Nonce: "nonce"}, time.Minute))
+
+	req, err := store.ConsumeAuthRequest(ctx, "state-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier", req.CodeVerifier)
+	assert.Equal(t, "nonce", req.Nonce)
+
+	_, err = store.ConsumeAuthRequest(ctx, "state-1")
+	assert.ErrorIs(t, err, service.ErrInvalidState)
+
+	// 期限切れの state は使用できない
+	assert.NoError(t, store.SaveAuthRequest(ctx, "state-2", &service.AuthRequest{}, -time.Second))
+	_, err = store.ConsumeAuthRequest(ctx, "state-2")
+	assert.ErrorIs(t, err, service.ErrInvalidState)
+}
+
+func TestRedisSessionStore_GetSession(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := service.NewRedisSessionStore(&cache.RedisClient{Client: db})
+	ctx := context.Background()
+
+	session := newTestSession(uuid.New(), time.Hour)
+	data, _ := json.Marshal(session)
+	mock.ExpectGet("session:session-1").SetVal(string(data))
+	mock.ExpectGet("session:missing").RedisNil()
+
+	found, err := store.GetSession(ctx, "session-1")
+	assert.NoError(t, err)
+	assert.Equal(t, session.UserID, found.UserID)
+	assert.Equal(t, "access-token", found.AccessToken)
+
+	_, err = store.GetSession(ctx, "missing")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisSessionStore_DeleteUserSessions(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := service.NewRedisSessionStore(&cache.RedisClient{Client: db})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	mock.ExpectSMembers("user_sessions:" + userID.String()).SetVal([]string{"session-1", "session-2"})
+	mock.ExpectTxPipeline()
+	mock.ExpectDel("session:session-1", "session:session-2").SetVal(1)
+	mock.ExpectDel("user_sessions:" + userID.String()).SetVal(1)
+	mock.ExpectTxPipelineExec()
+
+	// 期限切れで既に消えたセッションは件数に含めない
+	revoked, err := store.DeleteUserSessions(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisSessionStore_ConsumeAuthRequest(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := service.NewRedisSessionStore(&cache.RedisClient{Client: db})
+	ctx := context.Background()
+
+	mock.ExpectGetDel("oauth_state:state-1").SetVal(`{"code_verifier":"verifier","nonce":"nonce"}`)
+	mock.ExpectGetDel("oauth_state:state-1").RedisNil()
+
+	req, err := store.ConsumeAuthRequest(ctx, "state-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier", req.CodeVerifier)
+	assert.Equal(t, "nonce", req.Nonce)
+
+	_, err = store.ConsumeAuthRequest(ctx, "state-1")
+	assert.ErrorIs(t, err, service.ErrInvalidState)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemorySessionStore_ListAndUpdateSessions(t *testing.T) {
+	store := service.NewMemorySessionStore()
+	ctx := context.Background()
+
+	userID := uuid.New()
+	assert.NoError(t, store.SaveSession(ctx, "session-1", newTestSession(userID, time.Hour)))
+	assert.NoError(t, store.SaveSession(ctx, "session-2", newTestSession(userID, time.Hour)))
+	assert.NoError(t, store.SaveSession(ctx, "other", newTestSession(uuid.New(), time.Hour)))
+
+	sessions, err := store.ListUserSessions(ctx, userID)
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	assert.ElementsMatch(t, []string{"session-1", "session-2"}, ids)
+
+	updated := newTestSession(userID, time.Hour)
+	updated.IPAddress = "192.0.2.10"
+	assert.NoError(t, store.UpdateSession(ctx, "session-1", updated))
+	found, err := store.GetSession(ctx, "session-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.0.2.10", found.IPAddress)
+
+	// 削除済みのセッションは更新で復活させない
+	assert.NoError(t, store.DeleteSession(ctx, "session-2"))
+	assert.ErrorIs(t, store.UpdateSession(ctx, "session-2", updated), service.ErrSessionNotFound)
+	_, err = store.GetSession(ctx, "session-2")
+	assert.ErrorIs(t, err, service.ErrSessionNotFound)
+}
+
+func TestRedisSessionStore_ListUserSessions(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := service.NewRedisSessionStore(&cache.RedisClient{Client: db})
+	ctx := context.Background()
+
+	userID := uuid.New()
+	data, _ := json.Marshal(newTestSession(userID, time.Hour))
+	mock.ExpectSMembers("user_sessions:" + userID.String()).SetVal([]string{"session-1", "expired"})
+	mock.ExpectMGet("session:session-1", "session:expired").SetVal([]interface{}{string(data), nil})
+	mock.ExpectSRem("user_sessions:"+userID.String(), "expired").SetVal(1)
+
+	// 期限切れで既に消えたセッションは一覧に含めず、集合から取り除く
+	sessions, err := store.ListUserSessions(ctx, userID)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, "session-1", sessions[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisSessionStore_UpdateSession(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	store := service.NewRedisSessionStore(&cache.RedisClient{Client: db})
+	ctx := context.Background()
+
+	// 有効期間は呼び出し時点で計算するため、キーと XX（既存のキーのみ）だけを照合する
+	matchKeyXX := func(expected, actual []interface{}) error {
+		if expected[1] != actual[1] || actual[len(actual)-1] != "xx" {
+			return fmt.Errorf("unexpected command: %v", actual)
+		}
+		return nil
+	}
+	session := newTestSession(uuid.New(), time.Hour)
+	mock.CustomMatch(matchKeyXX).ExpectSetXX("session:session-1", nil, time.Hour).SetVal(true)
+	mock.CustomMatch(matchKeyXX).ExpectSetXX("session:revoked", nil, time.Hour).SetVal(false)
+
+	assert.NoError(t, store.UpdateSession(ctx, "session-1", session))
+	assert.ErrorIs(t, store.UpdateSession(ctx, "revoked", session), service.ErrSessionNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,469 @@
+// backend/internal/service/waitlist_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrAlreadyWaitlisted     = errors.New("user is already on the waitlist for this slot")
+	ErrSlotAvailable         = errors.New("resource is available for the requested time; book it directly")
+	ErrWaitlistEntryInactive = errors.New("waitlist entry is no longer active")
+	ErrWaitlistNotOffered    = errors.New("waitlist entry has not been offered a slot")
+	ErrWaitlistOfferExpired  = errors.New("waitlist offer has expired")
+)
+
+// DefaultWaitlistHoldDuration は繰り上げ時の仮押さえの既定の確認期限
+const DefaultWaitlistHoldDuration = 15 * time.Minute
+
+// WaitlistService はウェイトリストに関するビジネスロジックを提供します
+type WaitlistService struct {
+	waitlistRepo        repository.WaitlistRepository
+	reservationRepo     repository.ReservationRepository
+	resourceRepo        repository.ResourceRepository
+	userRepo            repository.UserRepository
+	auditLogRepo        repository.AuditLogRepository
+	approvalService     *ApprovalService
+	notificationService *NotificationService
+	reservationService  *ReservationService
+	holdDuration        time.Duration
+}
+
+// NewWaitlistService は新しいWaitlistServiceを作成します
+// holdDuration が0以下の場合は DefaultWaitlistHoldDuration を使用します
+// approvalService が nil の場合、承認が必要なリソースの繰り上げ確定時も承認依頼を行いません
+// reservationService が nil の場合、繰り上げ確定時の予約上限・ペナルティスコアによる制限の検証を行いません
+func NewWaitlistService(
+	waitlistRepo repository.WaitlistRepository,
+	reservationRepo repository.ReservationRepository,
+	resourceRepo repository.ResourceRepository,
+	userRepo repository.UserRepository,
+	auditLogRepo repository.AuditLogRepository,
+	approvalService *ApprovalService,
+	notificationService *NotificationService,
+	reservationService *ReservationService,
+	holdDuration time.Duration,
+) *WaitlistService {
+	if holdDuration <= 0 {
+		holdDuration = DefaultWaitlistHoldDuration
+	}
+	return &WaitlistService{
+		waitlistRepo:        waitlistRepo,
+		reservationRepo:     reservationRepo,
+		resourceRepo:        resourceRepo,
+		userRepo:            userRepo,
+		auditLogRepo:        auditLogRepo,
+		approvalService:     approvalService,
+		notificationService: notificationService,
+		reservationService:  reservationService,
+		holdDuration:        holdDuration,
+	}
+}
+
+// JoinWaitlistRequest はウェイトリスト登録リクエスト
+type JoinWaitlistRequest struct {
+	UserID     uuid.UUID
+	ResourceID uuid.UUID
+	Title      string
+	StartAt    time.Time
+	EndAt      time.Time
+	Timezone   string
+}
+
+// Join は満室のリソースと時間帯に対してウェイトリストに登録します
+// 空いている枠への登録は ErrSlotAvailable を返します（直接予約を促す）
+func (s *WaitlistService) Join(ctx context.Context, req *JoinWaitlistRequest) (*domain.WaitlistEntry, error) {
+	if !req.StartAt.Before(req.EndAt) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	resource, err := s.resourceRepo.GetByID(ctx, req.ResourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	if !resource.CanBeReservedBy(user) {
+		return nil, ErrUnauthorized
+	}
+
+	available, err := s.isAvailable(ctx, req.ResourceID, req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, err
+	}
+	if available {
+		return nil, ErrSlotAvailable
+	}
+
+	exists, err := s.waitlistRepo.HasActiveEntry(ctx, req.ResourceID, req.UserID, req.StartAt, req.EndAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check waitlist: %w", err)
+	}
+	if exists {
+		return nil, ErrAlreadyWaitlisted
+	}
+
+	now := time.Now()
+	entry := &domain.WaitlistEntry{
+		ID:         uuid.New(),
+		ResourceID: req.ResourceID,
+		UserID:     req.UserID,
+		Title:      req.Title,
+		StartAt:    req.StartAt,
+		EndAt:      req.EndAt,
+		Timezone:   req.Timezone,
+		Status:     domain.WaitlistStatusWaiting,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.waitlistRepo.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+
+	s.recordAudit(ctx, req.UserID, domain.AuditActionWaitlistJoin, entry, nil)
+
+	return entry, nil
+}
+
+// ListMine はユーザーの有効なウェイトリスト登録を取得します
+func (s *WaitlistService) ListMine(ctx context.Context, userID uuid.UUID) ([]*domain.WaitlistEntry, error) {
+	entries, err := s.waitlistRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+// Leave はウェイトリスト登録を取り消します
+// 繰り上げ提示中の場合は仮押さえを解放し、次の登録者へ繰り上げます
+func (s *WaitlistService) Leave(ctx context.Context, entryID, userID uuid.UUID) error {
+	entry, err := s.getOwnedEntry(ctx, entryID, userID)
+	if err != nil {
+		return err
+	}
+	if !entry.IsActive() {
+		return ErrWaitlistEntryInactive
+	}
+
+	wasOffered := entry.Status == domain.WaitlistStatusOffered
+	if err := s.closeEntry(ctx, entry, domain.WaitlistStatusCancelled); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, userID, domain.AuditActionWaitlistLeave, entry, nil)
+
+	if wasOffered {
+		if _, err := s.PromoteNext(ctx, entry.ResourceID, entry.StartAt, entry.EndAt); err != nil {
+			return fmt.Errorf("failed to promote next waitlist entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Accept は繰り上げ提示を確定し、仮押さえを予約に変換します
+// 予約の作成と同じく予約上限とペナルティスコアによる制限を検証し、超える場合は確定しません
+// 承認が必要なリソース、または上長の承認が必要な制限に該当する場合は承認待ちとなり、承認チェーンが開始されます
+func (s *WaitlistService) Accept(ctx context.Context, entryID, userID uuid.UUID) (*domain.Reservation, error) {
+	entry, err := s.getOwnedEntry(ctx, entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != domain.WaitlistStatusOffered || entry.HoldReservationID == nil {
+		return nil, ErrWaitlistNotOffered
+	}
+	if !entry.IsOfferActive(time.Now()) {
+		return nil, ErrWaitlistOfferExpired
+	}
+
+	reservation, err := s.reservationRepo.GetByID(ctx, *entry.HoldReservationID, entry.StartAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hold reservation: %w", err)
+	}
+	reservation.ResourceIDs = []uuid.UUID{entry.ResourceID}
+
+	resource, err := s.resourceRepo.GetByID(ctx, entry.ResourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource: %w", err)
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var restriction *domain.PenaltyRestriction
+	if s.reservationService != nil {
+		instances := []*domain.ReservationInstance{{ReservationID: reservation.ID, StartAt: entry.StartAt, EndAt: entry.EndAt}}
+		restriction, err = s.reservationService.checkBookingLimits(ctx, user, reservation, instances, []*domain.Resource{resource})
+		if err != nil {
+			return nil, err
+		}
+	}
+	penaltyApproval := restriction != nil && restriction.RequiresApproval
+
+	if (resource.NeedsApproval() || penaltyApproval) && s.approvalService != nil {
+		// 承認待ちとして仮押さえを維持し、承認チェーンを開始する
+		chain, err := s.approvalService.BuildApprovalChain(ctx, reservation, []*domain.Resource{resource}, user)
+		if err != nil {
+			return nil, err
+		}
+		if penaltyApproval {
+			chain, err = s.approvalService.WithManagerApproval(reservation, chain, user)
+			if err != nil {
+				return nil, err
+			}
+		}
+		reservation.ApprovalStatus = domain.ApprovalStatusPending
+		reservation.UpdatedBy = &userID
+		if err := s.reservationRepo.Update(ctx, reservation); err != nil {
+			return nil, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		if err := s.approvalService.RequestApproval(ctx, reservation, chain, user); err != nil {
+			return nil, fmt.Errorf("failed to request approval: %w", err)
+		}
+	} else {
+		reservation.ApprovalStatus = domain.ApprovalStatusConfirmed
+		reservation.UpdatedBy = &userID
+		if err := s.reservationRepo.Update(ctx, reservation); err != nil {
+			return nil, fmt.Errorf("failed to update reservation: %w", err)
+		}
+		if err := s.reservationRepo.UpdateInstancesStatus(ctx, reservation.ID, domain.ReservationStatusConfirmed); err != nil {
+			return nil, fmt.Errorf("failed to confirm reservation instances: %w", err)
+		}
+	}
+
+	entry.Status = domain.WaitlistStatusAccepted
+	if err := s.waitlistRepo.Update(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	s.recordAudit(ctx, userID, domain.AuditActionWaitlistAccept, entry, map[string]interface{}{
+		"reservation_id": reservation.ID.String(),
+		"approval":       string(reservation.ApprovalStatus),
+	})
+
+	return reservation, nil
+}
+
+// Decline は繰り上げ提示を辞退し、次の登録者へ繰り上げます
+func (s *WaitlistService) Decline(ctx context.Context, entryID, userID uuid.UUID) error {
+	entry, err := s.getOwnedEntry(ctx, entryID, userID)
+	if err != nil {
+		return err
+	}
+	if entry.Status != domain.WaitlistStatusOffered {
+		return ErrWaitlistNotOffered
+	}
+
+	if err := s.closeEntry(ctx, entry, domain.WaitlistStatusPassed); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, userID, domain.AuditActionWaitlistPass, entry, nil)
+
+	if _, err := s.PromoteNext(ctx, entry.ResourceID, entry.StartAt, entry.EndAt); err != nil {
+		return fmt.Errorf("failed to promote next waitlist entry: %w", err)
+	}
+
+	return nil
+}
+
+// PromoteNext は空いた枠に対して空き待ち登録を登録順に確認し、枠が確保できる登録者へ繰り上げを提示します
+// 期間内で重ならない複数の登録があればそれぞれに提示します。提示した件数を返します
+func (s *WaitlistService) PromoteNext(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error) {
+	entries, err := s.waitlistRepo.ListWaiting(ctx, resourceID, startAt, endAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list waiting entries: %w", err)
+	}
+
+	offered := 0
+	for _, entry := range entries {
+		ok, err := s.offer(ctx, entry)
+		if err != nil {
+			return offered, err
+		}
+		if ok {
+			offered++
+		}
+	}
+
+	return offered, nil
+}
+
+// ExpireOffers は確認期限を過ぎた繰り上げ提示を失効させ、次の登録者へ繰り上げます
+// ワーカーから定期的に呼び出されることを想定しています。失効させた件数を返します
+func (s *WaitlistService) ExpireOffers(ctx context.Context, now time.Time) (int, error) {
+	entries, err := s.waitlistRepo.ListExpiredOffers(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired offers: %w", err)
+	}
+
+	expired := 0
+	var errs []error
+	for _, entry := range entries {
+		if err := s.closeEntry(ctx, entry, domain.WaitlistStatusExpired); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		expired++
+
+		s.recordAudit(ctx, domain.SystemUserID, domain.AuditActionWaitlistExpire, entry, nil)
+
+		if _, err := s.PromoteNext(ctx, entry.ResourceID, entry.StartAt, entry.EndAt); err != nil {
+			errs = append(errs, fmt.Errorf("failed to promote next waitlist entry: %w", err))
+		}
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+// offer は空き待ち登録者が枠を確保できる場合に仮押さえを作成し、繰り上げを提示します
+// 枠が確保できない、または登録者が予約できない状態の場合は false を返します
+func (s *WaitlistService) offer(ctx context.Context, entry *domain.WaitlistEntry) (bool, error) {
+	available, err := s.isAvailable(ctx, entry.ResourceID, entry.StartAt, entry.EndAt)
+	if err != nil {
+		return false, err
+	}
+	if !available {
+		return false, nil
+	}
+
+	// 登録後に無効化されたユーザーなどは飛ばす
+	user, err := s.userRepo.GetByID(ctx, entry.UserID)
+	if err != nil {
+		return false, nil
+	}
+	resource, err := s.resourceRepo.GetByID(ctx, entry.ResourceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get resource: %w", err)
+	}
+	if !resource.CanBeReservedBy(user) {
+		return false, nil
+	}
+
+	now := time.Now()
+	hold := &domain.Reservation{
+		ID:             uuid.New(),
+		OrganizerID:    entry.UserID,
+		Title:          entry.Title,
+		StartAt:        entry.StartAt,
+		EndAt:          entry.EndAt,
+		Timezone:       entry.Timezone,
+		ApprovalStatus: domain.ApprovalStatusOffered,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	instance := &domain.ReservationInstance{
+		ID:                 uuid.New(),
+		ReservationID:      hold.ID,
+		ReservationStartAt: hold.StartAt,
+		StartAt:            hold.StartAt,
+		EndAt:              hold.EndAt,
+		Status:             domain.ReservationStatusTentative,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	err = s.reservationRepo.CreateWithInstances(ctx, hold, []*domain.ReservationInstance{instance}, []uuid.UUID{entry.ResourceID})
+	if err != nil {
+		return false, fmt.Errorf("failed to create waitlist hold: %w", err)
+	}
+
+	entry.Offer(hold.ID, now, s.holdDuration)
+	if err := s.waitlistRepo.Update(ctx, entry); err != nil {
+		_ = s.reservationRepo.Delete(ctx, hold.ID, hold.StartAt)
+		return false, fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+
+	s.recordAudit(ctx, domain.SystemUserID, domain.AuditActionWaitlistOffer, entry, map[string]interface{}{
+		"reservation_id":   hold.ID.String(),
+		"offer_expires_at": *entry.OfferExpiresAt,
+	})
+
+	if s.notificationService != nil {
+		_ = s.notificationService.NotifyWaitlistOffer(ctx, entry, resource, user)
+	}
+
+	return true, nil
+}
+
+// closeEntry はウェイトリスト登録を終了し、繰り上げ時の仮押さえがあれば解放します
+func (s *WaitlistService) closeEntry(ctx context.Context, entry *domain.WaitlistEntry, status domain.WaitlistStatus) error {
+	if entry.Status == domain.WaitlistStatusOffered && entry.HoldReservationID != nil {
+		err := s.reservationRepo.Delete(ctx, *entry.HoldReservationID, entry.StartAt)
+		// 予約側で既に取り消されている場合はそのまま終了する
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("failed to release waitlist hold: %w", err)
+		}
+	}
+
+	entry.Status = status
+	if err := s.waitlistRepo.Update(ctx, entry); err != nil {
+		return fmt.Errorf("failed to update waitlist entry: %w", err)
+	}
+	return nil
+}
+
+// getOwnedEntry はウェイトリスト登録を取得し、本人の登録であることを確認します
+func (s *WaitlistService) getOwnedEntry(ctx context.Context, entryID, userID uuid.UUID) (*domain.WaitlistEntry, error) {
+	entry, err := s.waitlistRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+	if entry.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	return entry, nil
+}
+
+// isAvailable はリソースが指定された期間に空いているかを確認します
+func (s *WaitlistService) isAvailable(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (bool, error) {
+	availableResources, err := s.resourceRepo.FindAvailable(ctx, startAt, endAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to find available resources: %w", err)
+	}
+	for _, r := range availableResources {
+		if r.ID == resourceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordAudit はウェイトリスト操作の監査ログを記録します
+func (s *WaitlistService) recordAudit(ctx context.Context, userID uuid.UUID, action domain.AuditAction, entry *domain.WaitlistEntry, extra map[string]interface{}) {
+	details := map[string]interface{}{
+		"resource_id": entry.ResourceID.String(),
+		"user_id":     entry.UserID.String(),
+		"start_at":    entry.StartAt,
+		"end_at":      entry.EndAt,
+		"status":      string(entry.Status),
+	}
+	for k, v := range extra {
+		details[k] = v
+	}
+
+	auditLog := &domain.AuditLog{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Action:     action,
+		TargetType: "waitlist_entry",
+		TargetID:   entry.ID.String(),
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}
+	_ = s.auditLogRepo.Create(ctx, auditLog)
+}
@@ -0,0 +1,287 @@
+// backend/internal/service/waitlist_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+func newWaitlistEntry(resourceID, userID uuid.UUID, startAt time.Time) *domain.WaitlistEntry {
+	return &domain.WaitlistEntry{
+		ID:         uuid.New(),
+		ResourceID: resourceID,
+		UserID:     userID,
+		Title:      "Design review",
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Timezone:   "Asia/Tokyo",
+		Status:     domain.WaitlistStatusWaiting,
+	}
+}
+
+func TestWaitlistService_Join_SlotAvailable(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	resource := &domain.Resource{ID: uuid.New(), Name: "Room A", Type: domain.ResourceTypeMeetingRoom, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, resource.ID).Return(resource, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, startAt.Add(time.Hour)).Return([]*domain.Resource{resource}, nil)
+
+	_, err := svc.Join(ctx, &service.JoinWaitlistRequest{
+		UserID:     user.ID,
+		ResourceID: resource.ID,
+		Title:      "Design review",
+		StartAt:    startAt,
+		EndAt:      startAt.Add(time.Hour),
+		Timezone:   "Asia/Tokyo",
+	})
+
+	assert.ErrorIs(t, err, service.ErrSlotAvailable)
+	mockWaitlistRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWaitlistService_Join_Success(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	resource := &domain.Resource{ID: uuid.New(), Name: "Room A", Type: domain.ResourceTypeMeetingRoom, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("GetByID", ctx, resource.ID).Return(resource, nil)
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil)
+	mockWaitlistRepo.On("HasActiveEntry", ctx, resource.ID, user.ID, startAt, endAt).Return(false, nil)
+	mockWaitlistRepo.On("Create", ctx, mock.AnythingOfType("*domain.WaitlistEntry")).Return(nil)
+
+	entry, err := svc.Join(ctx, &service.JoinWaitlistRequest{
+		UserID:     user.ID,
+		ResourceID: resource.ID,
+		Title:      "Design review",
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Timezone:   "Asia/Tokyo",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WaitlistStatusWaiting, entry.Status)
+	mockWaitlistRepo.AssertExpectations(t)
+}
+
+func TestWaitlistService_PromoteNext_OffersFirstEligible(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	resource := &domain.Resource{ID: uuid.New(), Name: "Room A", Type: domain.ResourceTypeMeetingRoom, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+	endAt := startAt.Add(time.Hour)
+
+	first := newWaitlistEntry(resource.ID, uuid.New(), startAt)
+	second := newWaitlistEntry(resource.ID, uuid.New(), startAt)
+	firstUser := &domain.User{ID: first.UserID, Email: "first@example.com", Role: domain.RoleGeneral, IsActive: true}
+
+	mockWaitlistRepo.On("ListWaiting", ctx, resource.ID, startAt, endAt).Return([]*domain.WaitlistEntry{first, second}, nil)
+	// 1人目の仮押さえ後は枠が埋まる
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{resource}, nil).Once()
+	mockResourceRepo.On("FindAvailable", ctx, startAt, endAt).Return([]*domain.Resource{}, nil).Once()
+	mockUserRepo.On("GetByID", ctx, first.UserID).Return(firstUser, nil)
+	mockResourceRepo.On("GetByID", ctx, resource.ID).Return(resource, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx,
+		mock.MatchedBy(func(r *domain.Reservation) bool {
+			return r.OrganizerID == first.UserID && r.ApprovalStatus == domain.ApprovalStatusOffered
+		}),
+		mock.MatchedBy(func(instances []*domain.ReservationInstance) bool {
+			return len(instances) == 1 && instances[0].Status == domain.ReservationStatusTentative
+		}),
+		[]uuid.UUID{resource.ID},
+	).Return(nil)
+	mockWaitlistRepo.On("Update", ctx, first).Return(nil)
+	mockJobQueue.On("Enqueue", ctx, "send_email", mock.MatchedBy(func(payload map[string]interface{}) bool {
+		return payload["to"] == "first@example.com"
+	})).Return("job-1", nil)
+
+	offered, err := svc.PromoteNext(ctx, resource.ID, startAt, endAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, offered)
+	assert.Equal(t, domain.WaitlistStatusOffered, first.Status)
+	assert.NotNil(t, first.HoldReservationID)
+	assert.Equal(t, domain.WaitlistStatusWaiting, second.Status)
+	mockReservationRepo.AssertExpectations(t)
+	mockJobQueue.AssertExpectations(t)
+}
+
+func TestWaitlistService_Accept_ConfirmsHold(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	resource := &domain.Resource{ID: uuid.New(), Name: "Room A", Type: domain.ResourceTypeMeetingRoom, IsActive: true}
+	startAt := time.Now().Add(24 * time.Hour)
+	entry := newWaitlistEntry(resource.ID, uuid.New(), startAt)
+	holdID := uuid.New()
+	entry.Offer(holdID, time.Now(), 15*time.Minute)
+
+	hold := &domain.Reservation{
+		ID:             holdID,
+		OrganizerID:    entry.UserID,
+		StartAt:        startAt,
+		EndAt:          entry.EndAt,
+		ApprovalStatus: domain.ApprovalStatusOffered,
+	}
+
+	mockWaitlistRepo.On("GetByID", ctx, entry.ID).Return(entry, nil)
+	mockReservationRepo.On("GetByID", ctx, holdID, startAt).Return(hold, nil)
+	mockResourceRepo.On("GetByID", ctx, resource.ID).Return(resource, nil)
+	mockUserRepo.On("GetByID", ctx, entry.UserID).Return(&domain.User{ID: entry.UserID, Role: domain.RoleGeneral, IsActive: true}, nil)
+	mockReservationRepo.On("Update", ctx, hold).Return(nil)
+	mockReservationRepo.On("UpdateInstancesStatus", ctx, holdID, domain.ReservationStatusConfirmed).Return(nil)
+	mockWaitlistRepo.On("Update", ctx, entry).Return(nil)
+
+	reservation, err := svc.Accept(ctx, entry.ID, entry.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ApprovalStatusConfirmed, reservation.ApprovalStatus)
+	assert.Equal(t, domain.WaitlistStatusAccepted, entry.Status)
+	mockReservationRepo.AssertExpectations(t)
+}
+
+func TestWaitlistService_Accept_Expired(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	entry := newWaitlistEntry(uuid.New(), uuid.New(), time.Now().Add(24*time.Hour))
+	entry.Offer(uuid.New(), time.Now().Add(-time.Hour), 15*time.Minute)
+
+	mockWaitlistRepo.On("GetByID", ctx, entry.ID).Return(entry, nil)
+
+	_, err := svc.Accept(ctx, entry.ID, entry.UserID)
+
+	assert.ErrorIs(t, err, service.ErrWaitlistOfferExpired)
+	mockReservationRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestWaitlistService_Accept_NotOwner(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	entry := newWaitlistEntry(uuid.New(), uuid.New(), time.Now().Add(24*time.Hour))
+	mockWaitlistRepo.On("GetByID", ctx, entry.ID).Return(entry, nil)
+
+	_, err := svc.Accept(ctx, entry.ID, uuid.New())
+
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
+func TestWaitlistService_ExpireOffers_ReleasesHoldAndPromotes(t *testing.T) {
+	mockWaitlistRepo := new(MockWaitlistRepository)
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	mockJobQueue := new(MockJobQueue)
+	mockAuditLogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	notificationService := service.NewNotificationService(mockUserRepo, mockJobQueue, nil)
+	svc := service.NewWaitlistService(mockWaitlistRepo, mockReservationRepo, mockResourceRepo, mockUserRepo,
+		mockAuditLogRepo, nil, notificationService, nil, 15*time.Minute)
+
+	ctx := context.Background()
+
+	resourceID := uuid.New()
+	startAt := time.Now().Add(24 * time.Hour)
+	entry := newWaitlistEntry(resourceID, uuid.New(), startAt)
+	holdID := uuid.New()
+	entry.Offer(holdID, time.Now().Add(-time.Hour), 15*time.Minute)
+	now := time.Now()
+
+	mockWaitlistRepo.On("ListExpiredOffers", ctx, now).Return([]*domain.WaitlistEntry{entry}, nil)
+	mockReservationRepo.On("Delete", ctx, holdID, startAt).Return(nil)
+	mockWaitlistRepo.On("Update", ctx, entry).Return(nil)
+	mockWaitlistRepo.On("ListWaiting", ctx, resourceID, startAt, entry.EndAt).Return([]*domain.WaitlistEntry{}, nil)
+
+	expired, err := svc.ExpireOffers(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, domain.WaitlistStatusExpired, entry.Status)
+	mockReservationRepo.AssertExpectations(t)
+	mockWaitlistRepo.AssertExpectations(t)
+}
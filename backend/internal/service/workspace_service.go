@@ -0,0 +1,250 @@
+// backend/internal/service/workspace_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/repository"
+)
+
+var (
+	ErrNotDayBookedType   = errors.New("resource type does not support zone booking")
+	ErrZoneRequired       = errors.New("zone is required")
+	ErrDatesRequired      = errors.New("at least one date is required")
+	ErrNoFreeResourceZone = errors.New("no free resource in the zone")
+)
+
+// MaxZoneBookingDays はゾーン予約・占有状況の取得で一度に指定できる日数の上限
+const MaxZoneBookingDays = 31
+
+// WorkspaceService はデスク・駐車区画のゾーン予約と占有状況に関するビジネスロジックを提供します
+type WorkspaceService struct {
+	resourceRepo       repository.ResourceRepository
+	userRepo           repository.UserRepository
+	reservationService *ReservationService
+}
+
+// NewWorkspaceService は新しいWorkspaceServiceを作成します
+func NewWorkspaceService(
+	resourceRepo repository.ResourceRepository,
+	userRepo repository.UserRepository,
+	reservationService *ReservationService,
+) *WorkspaceService {
+	return &WorkspaceService{
+		resourceRepo:       resourceRepo,
+		userRepo:           userRepo,
+		reservationService: reservationService,
+	}
+}
+
+// ZoneBookingRequest はゾーン内の空きリソースの自動予約リクエスト
+type ZoneBookingRequest struct {
+	OrganizerID uuid.UUID
+	Type        domain.ResourceType
+	Zone        string
+	Dates       []time.Time // 予約する日付（Timezone における暦日として扱う）
+	Slot        domain.DaySlot
+	Timezone    string
+	Title       string
+}
+
+// ZoneBookingResult は日付ごとの自動予約の結果
+type ZoneBookingResult struct {
+	Date        time.Time
+	Resource    *domain.Resource
+	Reservation *domain.Reservation
+}
+
+// BookInZone はゾーン内の空いているリソースを日付ごとに1つ選んで予約します
+// 可能な限り全日程で同じリソースを選び、いずれかの日に空きがない場合は何も予約しません
+func (s *WorkspaceService) BookInZone(ctx context.Context, req *ZoneBookingRequest) ([]*ZoneBookingResult, error) {
+	if !req.Type.IsDayBooked() {
+		return nil, ErrNotDayBookedType
+	}
+	if req.Zone == "" {
+		return nil, ErrZoneRequired
+	}
+	if len(req.Dates) == 0 {
+		return nil, ErrDatesRequired
+	}
+	if len(req.Dates) > MaxZoneBookingDays {
+		return nil, fmt.Errorf("at most %d dates can be booked at once", MaxZoneBookingDays)
+	}
+	if !req.Slot.IsValid() {
+		return nil, ErrInvalidDaySlot
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.OrganizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	candidates, err := s.resourceRepo.ListByZone(ctx, req.Type, req.Zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone resources: %w", err)
+	}
+	var reservable []*domain.Resource
+	for _, r := range candidates {
+		if user.CanAccessResource(r.RequiredRole) {
+			reservable = append(reservable, r)
+		}
+	}
+
+	// 全日程の割り当てを先に決め、空きがない日があれば予約を作成せずに終了する
+	results := make([]*ZoneBookingResult, 0, len(req.Dates))
+	var previous *domain.Resource
+	for _, date := range req.Dates {
+		startAt, endAt := req.Slot.Range(date, loc)
+		picked, err := s.pickFree(ctx, reservable, previous, startAt, endAt)
+		if err != nil {
+			return nil, err
+		}
+		if picked == nil {
+			return nil, fmt.Errorf("%w on %s", ErrNoFreeResourceZone, startAt.Format("2006-01-02"))
+		}
+		previous = picked
+		results = append(results, &ZoneBookingResult{Date: startAt, Resource: picked})
+	}
+
+	title := req.Title
+	if title == "" {
+		title = string(req.Type) + " " + req.Zone
+	}
+	for i, result := range results {
+		startAt, endAt := req.Slot.Range(result.Date, loc)
+		reservation, err := s.reservationService.CreateReservation(ctx, &CreateReservationRequest{
+			OrganizerID: req.OrganizerID,
+			ResourceIDs: []uuid.UUID{result.Resource.ID},
+			Title:       title,
+			StartAt:     startAt,
+			EndAt:       endAt,
+			Timezone:    req.Timezone,
+		})
+		if err != nil {
+			// 途中で失敗した場合は作成済みの予約を取り消し、一部だけ予約された状態を残さない
+			for _, created := range results[:i] {
+				s.reservationService.discardReservation(ctx, created.Reservation)
+			}
+			return nil, fmt.Errorf("failed to book %s: %w", startAt.Format("2006-01-02"), err)
+		}
+		result.Reservation = reservation
+	}
+
+	return results, nil
+}
+
+// pickFree は期間内に空いている候補リソースを返します。直前の日程と同じリソースが空いていれば優先します
+func (s *WorkspaceService) pickFree(ctx context.Context, candidates []*domain.Resource, preferred *domain.Resource, startAt, endAt time.Time) (*domain.Resource, error) {
+	available, err := s.resourceRepo.FindAvailable(ctx, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available resources: %w", err)
+	}
+	free := make(map[uuid.UUID]bool, len(available))
+	for _, r := range available {
+		free[r.ID] = true
+	}
+
+	if preferred != nil && free[preferred.ID] {
+		return preferred, nil
+	}
+	for _, r := range candidates {
+		if free[r.ID] {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// ResourceOccupancy はリソース1件の1日の予約状況
+type ResourceOccupancy struct {
+	ResourceID   uuid.UUID
+	ResourceName string
+	AMBookedBy   *uuid.UUID // 午前を予約しているユーザー（空きの場合は nil）
+	PMBookedBy   *uuid.UUID // 午後を予約しているユーザー（空きの場合は nil）
+}
+
+// ZoneOccupancy はゾーンの1日の占有状況
+type ZoneOccupancy struct {
+	Date       time.Time
+	Total      int
+	AMOccupied int
+	PMOccupied int
+	Resources  []*ResourceOccupancy
+}
+
+// GetZoneOccupancy は指定された日付から days 日分のゾーンの占有状況を日ごとに集計します
+func (s *WorkspaceService) GetZoneOccupancy(ctx context.Context, resourceType domain.ResourceType, zone string, from time.Time, days int, timezone string) ([]*ZoneOccupancy, error) {
+	if !resourceType.IsDayBooked() {
+		return nil, ErrNotDayBookedType
+	}
+	if zone == "" {
+		return nil, ErrZoneRequired
+	}
+	if days < 1 || days > MaxZoneBookingDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", MaxZoneBookingDays)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	resources, err := s.resourceRepo.ListByZone(ctx, resourceType, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone resources: %w", err)
+	}
+
+	y, m, d := from.In(loc).Date()
+	rangeStart, _ := domain.DaySlotFullDay.Range(from, loc)
+	_, rangeEnd := domain.DaySlotFullDay.Range(time.Date(y, m, d+days-1, 0, 0, 0, 0, loc), loc)
+	bookings, err := s.resourceRepo.ListZoneBookings(ctx, resourceType, zone, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone bookings: %w", err)
+	}
+
+	occupancies := make([]*ZoneOccupancy, 0, days)
+	for i := 0; i < days; i++ {
+		date := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		amStart, amEnd := domain.DaySlotAM.Range(date, loc)
+		pmStart, pmEnd := domain.DaySlotPM.Range(date, loc)
+
+		occupancy := &ZoneOccupancy{
+			Date:      date,
+			Total:     len(resources),
+			Resources: make([]*ResourceOccupancy, 0, len(resources)),
+		}
+		for _, r := range resources {
+			ro := &ResourceOccupancy{ResourceID: r.ID, ResourceName: r.Name}
+			for _, b := range bookings {
+				if b.ResourceID != r.ID {
+					continue
+				}
+				organizerID := b.OrganizerID
+				if ro.AMBookedBy == nil && b.Overlaps(amStart, amEnd) {
+					ro.AMBookedBy = &organizerID
+				}
+				if ro.PMBookedBy == nil && b.Overlaps(pmStart, pmEnd) {
+					ro.PMBookedBy = &organizerID
+				}
+			}
+			if ro.AMBookedBy != nil {
+				occupancy.AMOccupied++
+			}
+			if ro.PMBookedBy != nil {
+				occupancy.PMOccupied++
+			}
+			occupancy.Resources = append(occupancy.Resources, ro)
+		}
+		occupancies = append(occupancies, occupancy)
+	}
+
+	return occupancies, nil
+}
@@ -0,0 +1,176 @@
+// backend/internal/service/workspace_service_test.go
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/esms/internal/domain"
+	"github.com/your-org/esms/internal/service"
+)
+
+func TestWorkspaceService_BookInZone(t *testing.T) {
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	zone := "3F-A"
+	day1 := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+	day2 := time.Date(2030, 6, 4, 0, 0, 0, 0, loc)
+
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	deskA := &domain.Resource{ID: uuid.New(), Name: "Desk A", Type: domain.ResourceTypeDesk, Zone: &zone, IsActive: true}
+	deskB := &domain.Resource{ID: uuid.New(), Name: "Desk B", Type: domain.ResourceTypeDesk, Zone: &zone, IsActive: true}
+
+	tests := []struct {
+		name       string
+		day1Free   []*domain.Resource
+		day2Free   []*domain.Resource
+		wantDesks  []uuid.UUID
+		wantErr    error
+		wantCreate int
+	}{
+		{
+			name:       "Keeps the same desk across days",
+			day1Free:   []*domain.Resource{deskB},
+			day2Free:   []*domain.Resource{deskA, deskB},
+			wantDesks:  []uuid.UUID{deskB.ID, deskB.ID},
+			wantCreate: 2,
+		},
+		{
+			name:       "Falls back to another desk",
+			day1Free:   []*domain.Resource{deskA, deskB},
+			day2Free:   []*domain.Resource{deskB},
+			wantDesks:  []uuid.UUID{deskA.ID, deskB.ID},
+			wantCreate: 2,
+		},
+		{
+			name:     "No free desk on one day",
+			day1Free: []*domain.Resource{deskA},
+			day2Free: []*domain.Resource{},
+			wantErr:  service.ErrNoFreeResourceZone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReservationRepo := new(MockReservationRepository)
+			mockResourceRepo := new(MockResourceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockAuditLogRepo := new(MockAuditLogRepository)
+			reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+			svc := service.NewWorkspaceService(mockResourceRepo, mockUserRepo, reservationService)
+
+			mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+			mockResourceRepo.On("ListByZone", ctx, domain.ResourceTypeDesk, zone).Return([]*domain.Resource{deskA, deskB}, nil)
+			mockResourceRepo.On("FindAvailable", ctx, day1, day1.Add(24*time.Hour)).Return(tt.day1Free, nil)
+			mockResourceRepo.On("FindAvailable", ctx, day2, day2.Add(24*time.Hour)).Return(tt.day2Free, nil)
+			mockResourceRepo.On("GetByID", ctx, deskA.ID).Return(deskA, nil)
+			mockResourceRepo.On("GetByID", ctx, deskB.ID).Return(deskB, nil)
+			mockReservationRepo.On("CreateWithInstances", ctx, mock.AnythingOfType("*domain.Reservation"), mock.AnythingOfType("[]*domain.ReservationInstance"), mock.AnythingOfType("[]uuid.UUID")).Return(nil)
+			mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+			results, err := svc.BookInZone(ctx, &service.ZoneBookingRequest{
+				OrganizerID: user.ID,
+				Type:        domain.ResourceTypeDesk,
+				Zone:        zone,
+				Dates:       []time.Time{day1, day2},
+				Slot:        domain.DaySlotFullDay,
+				Timezone:    "Asia/Tokyo",
+			})
+
+			mockReservationRepo.AssertNumberOfCalls(t, "CreateWithInstances", tt.wantCreate)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, results, 2)
+			for i, result := range results {
+				assert.Equal(t, tt.wantDesks[i], result.Resource.ID)
+				assert.Equal(t, "DESK 3F-A", result.Reservation.Title)
+			}
+		})
+	}
+}
+
+func TestWorkspaceService_BookInZone_DiscardsBookedDaysOnFailure(t *testing.T) {
+	mockReservationRepo := new(MockReservationRepository)
+	mockResourceRepo := new(MockResourceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockAuditLogRepo := new(MockAuditLogRepository)
+	reservationService := service.NewReservationService(mockReservationRepo, mockResourceRepo, mockUserRepo, mockAuditLogRepo, nil, nil, nil, nil, nil)
+	svc := service.NewWorkspaceService(mockResourceRepo, mockUserRepo, reservationService)
+
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	zone := "3F-A"
+	day1 := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+	day2 := time.Date(2030, 6, 4, 0, 0, 0, 0, loc)
+	user := &domain.User{ID: uuid.New(), Role: domain.RoleGeneral, IsActive: true}
+	desk := &domain.Resource{ID: uuid.New(), Name: "Desk A", Type: domain.ResourceTypeDesk, Zone: &zone, IsActive: true}
+
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockResourceRepo.On("ListByZone", ctx, domain.ResourceTypeDesk, zone).Return([]*domain.Resource{desk}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, day1, day1.Add(24*time.Hour)).Return([]*domain.Resource{desk}, nil)
+	mockResourceRepo.On("FindAvailable", ctx, day2, day2.Add(24*time.Hour)).Return([]*domain.Resource{desk}, nil)
+	mockResourceRepo.On("GetByID", ctx, desk.ID).Return(desk, nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.MatchedBy(func(r *domain.Reservation) bool { return r.StartAt.Equal(day1) }),
+		mock.AnythingOfType("[]*domain.ReservationInstance"), mock.AnythingOfType("[]uuid.UUID")).Return(nil)
+	mockReservationRepo.On("CreateWithInstances", ctx, mock.MatchedBy(func(r *domain.Reservation) bool { return r.StartAt.Equal(day2) }),
+		mock.AnythingOfType("[]*domain.ReservationInstance"), mock.AnythingOfType("[]uuid.UUID")).Return(assert.AnError)
+	mockReservationRepo.On("Delete", ctx, mock.AnythingOfType("uuid.UUID"), day1).Return(nil)
+	mockAuditLogRepo.On("Create", ctx, mock.AnythingOfType("*domain.AuditLog")).Return(nil)
+
+	_, err := svc.BookInZone(ctx, &service.ZoneBookingRequest{
+		OrganizerID: user.ID,
+		Type:        domain.ResourceTypeDesk,
+		Zone:        zone,
+		Dates:       []time.Time{day1, day2},
+		Slot:        domain.DaySlotFullDay,
+		Timezone:    "Asia/Tokyo",
+	})
+
+	// 予約済みの日は取り消すが、利用者のキャンセルではないため監査ログ・ペナルティの判定は行わない
+	assert.ErrorIs(t, err, assert.AnError)
+	mockReservationRepo.AssertNumberOfCalls(t, "Delete", 1)
+	mockReservationRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	mockReservationRepo.AssertNotCalled(t, "GetInstancesByReservationID", mock.Anything, mock.Anything)
+	mockAuditLogRepo.AssertNotCalled(t, "Create", ctx, mock.MatchedBy(func(l *domain.AuditLog) bool { return l.Action == domain.AuditActionCancel }))
+}
+
+func TestWorkspaceService_GetZoneOccupancy(t *testing.T) {
+	mockResourceRepo := new(MockResourceRepository)
+	svc := service.NewWorkspaceService(mockResourceRepo, new(MockUserRepository), nil)
+
+	ctx := context.Background()
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	zone := "B1"
+	day := time.Date(2030, 6, 3, 0, 0, 0, 0, loc)
+	p1 := &domain.Resource{ID: uuid.New(), Name: "P-01", Type: domain.ResourceTypeParking, Zone: &zone}
+	p2 := &domain.Resource{ID: uuid.New(), Name: "P-02", Type: domain.ResourceTypeParking, Zone: &zone}
+	alice, bob := uuid.New(), uuid.New()
+
+	mockResourceRepo.On("ListByZone", ctx, domain.ResourceTypeParking, zone).Return([]*domain.Resource{p1, p2}, nil)
+	mockResourceRepo.On("ListZoneBookings", ctx, domain.ResourceTypeParking, zone, day, day.Add(48*time.Hour)).Return([]*domain.ZoneBooking{
+		{ResourceID: p1.ID, OrganizerID: alice, StartAt: day, EndAt: day.Add(24 * time.Hour)},
+		{ResourceID: p2.ID, OrganizerID: bob, StartAt: day.Add(36 * time.Hour), EndAt: day.Add(48 * time.Hour)},
+	}, nil)
+
+	occupancies, err := svc.GetZoneOccupancy(ctx, domain.ResourceTypeParking, zone, day, 2, "Asia/Tokyo")
+
+	assert.NoError(t, err)
+	assert.Len(t, occupancies, 2)
+
+	assert.Equal(t, 2, occupancies[0].Total)
+	assert.Equal(t, 1, occupancies[0].AMOccupied)
+	assert.Equal(t, 1, occupancies[0].PMOccupied)
+	assert.Equal(t, alice, *occupancies[0].Resources[0].AMBookedBy)
+	assert.Nil(t, occupancies[0].Resources[1].AMBookedBy)
+
+	assert.Equal(t, 0, occupancies[1].AMOccupied)
+	assert.Equal(t, 1, occupancies[1].PMOccupied)
+	assert.Equal(t, bob, *occupancies[1].Resources[1].PMBookedBy)
+}
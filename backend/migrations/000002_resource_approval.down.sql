@@ -0,0 +1,13 @@
+-- backend/migrations/000002_resource_approval.down.sql
+-- リソース承認設定のロールバック
+
+DROP TRIGGER IF EXISTS trigger_approver_groups_updated_at ON approver_groups;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS approver_group_id,
+    DROP COLUMN IF EXISTS requires_approval;
+
+COMMENT ON COLUMN reservation_instances.status IS 'ステータス: CONFIRMED, CANCELLED, CHECKED_IN, COMPLETED, NO_SHOW';
+
+DROP TABLE IF EXISTS approver_group_members CASCADE;
+DROP TABLE IF EXISTS approver_groups CASCADE;
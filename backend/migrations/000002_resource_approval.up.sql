@@ -0,0 +1,52 @@
+-- backend/migrations/000002_resource_approval.up.sql
+-- リソース承認設定の追加
+--
+-- このマイグレーションは以下を追加します:
+-- - approver_groups: 承認者グループ
+-- - approver_group_members: 承認者グループのメンバー
+-- - resources.requires_approval / resources.approver_group_id: リソースの承認要否と承認者グループ
+
+-- ============================================================================
+-- ApproverGroups テーブル
+-- ============================================================================
+CREATE TABLE approver_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE approver_groups IS '承認者グループ（役員会議室、社用車などの承認担当）';
+
+-- ============================================================================
+-- ApproverGroupMembers テーブル
+-- ============================================================================
+CREATE TABLE approver_group_members (
+    group_id UUID NOT NULL REFERENCES approver_groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, user_id)
+);
+
+COMMENT ON TABLE approver_group_members IS '承認者グループのメンバー';
+
+CREATE INDEX idx_approver_group_members_user ON approver_group_members(user_id);
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN requires_approval BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN approver_group_id UUID REFERENCES approver_groups(id);
+
+COMMENT ON COLUMN resources.requires_approval IS '予約に承認が必要かどうか（trueの場合は PENDING で作成）';
+COMMENT ON COLUMN resources.approver_group_id IS '承認依頼の通知先となる承認者グループ';
+
+COMMENT ON COLUMN reservation_instances.status IS 'ステータス: TENTATIVE, CONFIRMED, CANCELLED, CHECKED_IN, COMPLETED, NO_SHOW';
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_approver_groups_updated_at
+    BEFORE UPDATE ON approver_groups
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- backend/migrations/000003_approval_chain.down.sql
+-- 多段階承認チェーンのロールバック
+
+DROP TRIGGER IF EXISTS trigger_reservation_approvals_updated_at ON reservation_approvals;
+DROP TRIGGER IF EXISTS trigger_approval_policies_updated_at ON approval_policies;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS approval_policy_id,
+    DROP COLUMN IF EXISTS owner_id;
+
+DROP TABLE IF EXISTS reservation_approvals CASCADE;
+DROP TABLE IF EXISTS approval_policy_steps CASCADE;
+DROP TABLE IF EXISTS approval_policies CASCADE;
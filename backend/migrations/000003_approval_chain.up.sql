@@ -0,0 +1,90 @@
+-- backend/migrations/000003_approval_chain.up.sql
+-- 多段階承認チェーンの追加
+--
+-- このマイグレーションは以下を追加します:
+-- - approval_policies: 承認ポリシー（承認チェーンの定義）
+-- - approval_policy_steps: 承認ポリシーの各ステップ
+-- - reservation_approvals: 予約ごとの承認ステップの記録
+-- - resources.owner_id / resources.approval_policy_id: リソース所有者と承認ポリシー
+
+-- ============================================================================
+-- ApprovalPolicies テーブル
+-- ============================================================================
+CREATE TABLE approval_policies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE approval_policies IS '承認ポリシー（承認チェーンの定義）';
+
+-- ============================================================================
+-- ApprovalPolicySteps テーブル
+-- ============================================================================
+CREATE TABLE approval_policy_steps (
+    policy_id UUID NOT NULL REFERENCES approval_policies(id) ON DELETE CASCADE,
+    step_order INT NOT NULL,
+    step_type VARCHAR(50) NOT NULL,  -- MANAGER, RESOURCE_OWNER, APPROVER_GROUP
+    approver_group_id UUID REFERENCES approver_groups(id),  -- APPROVER_GROUP の場合の承認者グループ
+    min_duration_minutes INT NOT NULL DEFAULT 0,  -- 予約時間がこの値を超える場合のみ適用（0は常に適用）
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (policy_id, step_order)
+);
+
+COMMENT ON TABLE approval_policy_steps IS '承認ポリシーの各ステップ';
+COMMENT ON COLUMN approval_policy_steps.step_type IS 'ステップ種別: MANAGER, RESOURCE_OWNER, APPROVER_GROUP';
+COMMENT ON COLUMN approval_policy_steps.approver_group_id IS '承認者グループ（未指定の場合はリソースの承認者グループ）';
+COMMENT ON COLUMN approval_policy_steps.min_duration_minutes IS '予約時間がこの分数を超える場合のみステップを適用';
+
+-- ============================================================================
+-- ReservationApprovals テーブル
+-- ============================================================================
+CREATE TABLE reservation_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    reservation_id UUID NOT NULL,
+    reservation_start_at TIMESTAMPTZ NOT NULL,
+    step_order INT NOT NULL,
+    step_type VARCHAR(50) NOT NULL,
+    approver_id UUID REFERENCES users(id),  -- 個人が承認者の場合（MANAGER, RESOURCE_OWNER）
+    approver_group_id UUID REFERENCES approver_groups(id),  -- グループが承認者の場合
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',  -- PENDING, APPROVED, REJECTED, SKIPPED
+    decided_by UUID REFERENCES users(id),
+    decided_at TIMESTAMPTZ,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_reservation_approvals_reservation
+        FOREIGN KEY (reservation_id, reservation_start_at)
+        REFERENCES reservations(id, start_at)
+        ON DELETE CASCADE,
+    UNIQUE (reservation_id, step_order)
+);
+
+COMMENT ON TABLE reservation_approvals IS '予約ごとの承認ステップの記録';
+COMMENT ON COLUMN reservation_approvals.status IS 'ステータス: PENDING, APPROVED, REJECTED, SKIPPED';
+
+CREATE INDEX idx_reservation_approvals_approver ON reservation_approvals(approver_id) WHERE status = 'PENDING';
+CREATE INDEX idx_reservation_approvals_group ON reservation_approvals(approver_group_id) WHERE status = 'PENDING';
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN owner_id UUID REFERENCES users(id),
+    ADD COLUMN approval_policy_id UUID REFERENCES approval_policies(id);
+
+COMMENT ON COLUMN resources.owner_id IS 'リソース所有者（RESOURCE_OWNER ステップの承認者）';
+COMMENT ON COLUMN resources.approval_policy_id IS '承認ポリシー（未指定の場合は承認者グループによる1段階承認）';
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_approval_policies_updated_at
+    BEFORE UPDATE ON approval_policies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_reservation_approvals_updated_at
+    BEFORE UPDATE ON reservation_approvals
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,21 @@
+-- backend/migrations/000004_approval_sla.down.sql
+-- 承認SLAのロールバック
+
+DROP INDEX IF EXISTS idx_reservation_approvals_active;
+
+COMMENT ON COLUMN reservations.approval_status IS '承認状態: PENDING, CONFIRMED, REJECTED';
+COMMENT ON COLUMN reservation_approvals.status IS 'ステータス: PENDING, APPROVED, REJECTED, SKIPPED';
+
+ALTER TABLE reservation_approvals
+    DROP COLUMN IF EXISTS escalated_to,
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS reminded_at,
+    DROP COLUMN IF EXISTS activated_at,
+    DROP COLUMN IF EXISTS policy_id;
+
+ALTER TABLE approval_policies
+    DROP COLUMN IF EXISTS delegate_id,
+    DROP COLUMN IF EXISTS timeout_action,
+    DROP COLUMN IF EXISTS decision_deadline_minutes,
+    DROP COLUMN IF EXISTS escalate_after_minutes,
+    DROP COLUMN IF EXISTS reminder_after_minutes;
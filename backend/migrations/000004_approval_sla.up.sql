@@ -0,0 +1,41 @@
+-- backend/migrations/000004_approval_sla.up.sql
+-- 承認SLA（リマインド・エスカレーション・タイムアウト時の自動判断）の追加
+--
+-- このマイグレーションは以下を追加します:
+-- - approval_policies: SLA設定（リマインド、エスカレーション、最終期限、タイムアウト時の動作、代理承認者）
+-- - reservation_approvals: ステップの開始日時、リマインド・エスカレーションの記録
+
+-- ============================================================================
+-- ApprovalPolicies テーブルへの列追加
+-- ============================================================================
+ALTER TABLE approval_policies
+    ADD COLUMN reminder_after_minutes INT NOT NULL DEFAULT 0,  -- 0は無効
+    ADD COLUMN escalate_after_minutes INT NOT NULL DEFAULT 0,  -- 0は無効
+    ADD COLUMN decision_deadline_minutes INT NOT NULL DEFAULT 0,  -- 0は無効
+    ADD COLUMN timeout_action VARCHAR(20),  -- AUTO_APPROVE, AUTO_REJECT, RELEASE
+    ADD COLUMN delegate_id UUID REFERENCES users(id);  -- 上長がいない場合のエスカレーション先
+
+COMMENT ON COLUMN approval_policies.reminder_after_minutes IS 'ステップ開始からリマインドを送るまでの分数（0は無効）';
+COMMENT ON COLUMN approval_policies.escalate_after_minutes IS 'ステップ開始からエスカレーションするまでの分数（0は無効）';
+COMMENT ON COLUMN approval_policies.decision_deadline_minutes IS 'ステップ開始からタイムアウト動作を適用するまでの分数（0は無効）';
+COMMENT ON COLUMN approval_policies.timeout_action IS 'タイムアウト時の動作: AUTO_APPROVE, AUTO_REJECT, RELEASE';
+COMMENT ON COLUMN approval_policies.delegate_id IS 'エスカレーション先の代理承認者（承認者に上長がいない場合）';
+
+-- ============================================================================
+-- ReservationApprovals テーブルへの列追加
+-- ============================================================================
+ALTER TABLE reservation_approvals
+    ADD COLUMN policy_id UUID REFERENCES approval_policies(id),
+    ADD COLUMN activated_at TIMESTAMPTZ,  -- ステップが承認待ちになった日時（SLA起点）
+    ADD COLUMN reminded_at TIMESTAMPTZ,
+    ADD COLUMN escalated_at TIMESTAMPTZ,
+    ADD COLUMN escalated_to UUID REFERENCES users(id);
+
+COMMENT ON COLUMN reservation_approvals.policy_id IS 'ステップの元になった承認ポリシー（SLA参照用）';
+COMMENT ON COLUMN reservation_approvals.activated_at IS 'ステップが承認待ちになった日時（SLAの起点）';
+COMMENT ON COLUMN reservation_approvals.escalated_to IS 'エスカレーション先の承認者';
+COMMENT ON COLUMN reservation_approvals.status IS 'ステータス: PENDING, APPROVED, REJECTED, SKIPPED, EXPIRED';
+COMMENT ON COLUMN reservations.approval_status IS '承認状態: PENDING, CONFIRMED, REJECTED, EXPIRED';
+
+CREATE INDEX idx_reservation_approvals_active ON reservation_approvals(activated_at)
+    WHERE status = 'PENDING' AND policy_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- backend/migrations/000005_waitlist.down.sql
+-- ウェイトリストのロールバック
+
+DROP TRIGGER IF EXISTS trigger_waitlist_entries_updated_at ON waitlist_entries;
+
+DROP TABLE IF EXISTS waitlist_entries CASCADE;
@@ -0,0 +1,44 @@
+-- backend/migrations/000005_waitlist.up.sql
+-- 満室リソースのウェイトリスト
+--
+-- このマイグレーションは以下を追加します:
+-- - waitlist_entries: リソースと時間帯ごとの空き待ち登録
+
+-- ============================================================================
+-- WaitlistEntries テーブル
+-- ============================================================================
+CREATE TABLE waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    title VARCHAR(255) NOT NULL,
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ NOT NULL,
+    timezone VARCHAR(50) NOT NULL DEFAULT 'Asia/Tokyo',
+    status VARCHAR(20) NOT NULL DEFAULT 'WAITING',  -- WAITING, OFFERED, ACCEPTED, PASSED, EXPIRED, CANCELLED
+    hold_reservation_id UUID,  -- 繰り上げ時に作成した仮押さえ予約（start_at は本テーブルの start_at と同じ）
+    offered_at TIMESTAMPTZ,
+    offer_expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_waitlist_entries_time_range CHECK (end_at > start_at)
+);
+
+COMMENT ON TABLE waitlist_entries IS 'ウェイトリスト（満室リソースの空き待ち登録）';
+COMMENT ON COLUMN waitlist_entries.status IS 'ステータス: WAITING, OFFERED, ACCEPTED, PASSED, EXPIRED, CANCELLED';
+COMMENT ON COLUMN waitlist_entries.hold_reservation_id IS '繰り上げ時に作成した仮押さえ予約のID';
+COMMENT ON COLUMN waitlist_entries.offer_expires_at IS '繰り上げ提示の確認期限（過ぎると次の登録者へ繰り上げ）';
+
+-- 繰り上げ対象の検索（登録順）
+CREATE INDEX idx_waitlist_entries_waiting ON waitlist_entries(resource_id, start_at, created_at) WHERE status = 'WAITING';
+-- 確認期限切れの検索
+CREATE INDEX idx_waitlist_entries_offer_expires ON waitlist_entries(offer_expires_at) WHERE status = 'OFFERED';
+-- 同一ユーザーによる同一枠への重複登録を防止
+CREATE UNIQUE INDEX idx_waitlist_entries_active ON waitlist_entries(resource_id, user_id, start_at, end_at)
+    WHERE status IN ('WAITING', 'OFFERED');
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_waitlist_entries_updated_at
+    BEFORE UPDATE ON waitlist_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,13 @@
+-- backend/migrations/000006_pooled_equipment.down.sql
+-- プール型備品のロールバック
+
+DROP TRIGGER IF EXISTS trigger_resource_assets_updated_at ON resource_assets;
+
+DROP TABLE IF EXISTS reservation_assets CASCADE;
+DROP TABLE IF EXISTS resource_assets CASCADE;
+
+ALTER TABLE reservation_resources
+    DROP COLUMN IF EXISTS units;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS quantity;
@@ -0,0 +1,64 @@
+-- backend/migrations/000006_pooled_equipment.up.sql
+-- 数量管理されたプール型備品の追加
+--
+-- このマイグレーションは以下を追加します:
+-- - resources.quantity: リソースの保有数量（プール型備品。単一リソースは1）
+-- - reservation_resources.units: 予約インスタンスごとの予約数量
+-- - resource_assets: プール型備品の個体（資産タグ）
+-- - reservation_assets: 予約への個体の割り当て
+
+-- ============================================================================
+-- Resources / ReservationResources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN quantity INT NOT NULL DEFAULT 1 CHECK (quantity >= 1);
+
+COMMENT ON COLUMN resources.quantity IS '保有数量（2以上の場合はプール型備品として数量単位で予約）';
+
+ALTER TABLE reservation_resources
+    ADD COLUMN units INT NOT NULL DEFAULT 1 CHECK (units >= 1);
+
+COMMENT ON COLUMN reservation_resources.units IS '予約数量（プール型備品の場合に使用）';
+
+-- ============================================================================
+-- ResourceAssets テーブル
+-- ============================================================================
+CREATE TABLE resource_assets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    asset_tag VARCHAR(100) NOT NULL UNIQUE,  -- 資産管理番号
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    notes TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE resource_assets IS 'プール型備品の個体（資産タグ単位）';
+
+CREATE INDEX idx_resource_assets_resource ON resource_assets(resource_id);
+
+-- ============================================================================
+-- ReservationAssets テーブル
+-- ============================================================================
+CREATE TABLE reservation_assets (
+    reservation_id UUID NOT NULL,
+    reservation_start_at TIMESTAMPTZ NOT NULL,
+    asset_id UUID NOT NULL REFERENCES resource_assets(id),
+    assigned_by UUID REFERENCES users(id),
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_reservation_assets_reservation
+        FOREIGN KEY (reservation_id, reservation_start_at)
+        REFERENCES reservations(id, start_at)
+        ON DELETE CASCADE,
+    PRIMARY KEY (reservation_id, asset_id)
+);
+
+COMMENT ON TABLE reservation_assets IS '予約に割り当てられた備品の個体';
+
+CREATE INDEX idx_reservation_assets_asset ON reservation_assets(asset_id);
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_resource_assets_updated_at
+    BEFORE UPDATE ON resource_assets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- backend/migrations/000007_equipment_checkouts.down.sql
+-- 備品の貸出・返却管理のロールバック
+
+DROP TRIGGER IF EXISTS trigger_equipment_checkouts_updated_at ON equipment_checkouts;
+
+DROP TABLE IF EXISTS equipment_checkouts CASCADE;
@@ -0,0 +1,52 @@
+-- backend/migrations/000007_equipment_checkouts.up.sql
+-- 備品の貸出・返却管理の追加
+--
+-- このマイグレーションは以下を追加します:
+-- - equipment_checkouts: 備品個体の貸出（持ち出し・返却・返却遅延）の履歴
+
+-- ============================================================================
+-- EquipmentCheckouts テーブル
+-- ============================================================================
+CREATE TABLE equipment_checkouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    asset_id UUID NOT NULL REFERENCES resource_assets(id),
+    reservation_id UUID NOT NULL,
+    reservation_start_at TIMESTAMPTZ NOT NULL,
+    reservation_instance_id UUID NOT NULL,
+    borrower_id UUID NOT NULL REFERENCES users(id),
+    checked_out_by UUID NOT NULL REFERENCES users(id),
+    checked_out_at TIMESTAMPTZ NOT NULL,
+    checkout_condition VARCHAR(20) NOT NULL CHECK (checkout_condition IN ('GOOD', 'FAIR', 'DAMAGED')),
+    checkout_notes TEXT,
+    due_at TIMESTAMPTZ NOT NULL,  -- 返却期限（予約インスタンスの終了日時）
+    returned_at TIMESTAMPTZ,
+    returned_by UUID REFERENCES users(id),
+    return_condition VARCHAR(20) CHECK (return_condition IN ('GOOD', 'FAIR', 'DAMAGED')),
+    damage_notes TEXT,
+    overdue_notified_at TIMESTAMPTZ,  -- 返却遅延を借用者へ通知した日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- 予約が削除されても貸出履歴は残すため、外部キーは設定しない
+    CONSTRAINT chk_equipment_checkouts_returned
+        CHECK (returned_at IS NULL OR (returned_by IS NOT NULL AND return_condition IS NOT NULL))
+);
+
+COMMENT ON TABLE equipment_checkouts IS '備品個体の貸出・返却履歴';
+
+-- 1つの個体に同時に存在できる未返却の貸出は1件のみ
+CREATE UNIQUE INDEX idx_equipment_checkouts_open_asset
+    ON equipment_checkouts(asset_id)
+    WHERE returned_at IS NULL;
+
+CREATE INDEX idx_equipment_checkouts_asset ON equipment_checkouts(asset_id, checked_out_at DESC);
+
+-- 返却遅延の検出と予約ブロック判定用
+CREATE INDEX idx_equipment_checkouts_open_due
+    ON equipment_checkouts(borrower_id, due_at)
+    WHERE returned_at IS NULL;
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_equipment_checkouts_updated_at
+    BEFORE UPDATE ON equipment_checkouts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- backend/migrations/000008_desk_parking.down.sql
+-- デスク・駐車区画のロールバック
+
+DROP INDEX IF EXISTS idx_resources_type_zone;
+
+COMMENT ON COLUMN resources.type IS 'リソース種別: MEETING_ROOM, EQUIPMENT';
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS zone;
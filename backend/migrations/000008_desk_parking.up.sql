@@ -0,0 +1,18 @@
+-- backend/migrations/000008_desk_parking.up.sql
+-- デスク・駐車区画のリソース種別とゾーン予約
+--
+-- このマイグレーションは以下を追加します:
+-- - resources.zone: フロア・エリア（デスク・駐車区画のゾーン予約用）
+-- - resources.type の値に DESK, PARKING を追加
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN zone VARCHAR(100);
+
+COMMENT ON COLUMN resources.zone IS 'フロア・エリア（デスク・駐車区画のゾーン予約用）';
+COMMENT ON COLUMN resources.type IS 'リソース種別: MEETING_ROOM, EQUIPMENT, DESK, PARKING';
+
+-- ゾーン内の空き検索・占有状況の集計用
+CREATE INDEX idx_resources_type_zone ON resources(type, zone) WHERE zone IS NOT NULL;
@@ -0,0 +1,7 @@
+-- backend/migrations/000009_room_selection.down.sql
+-- 会議室の自動選択のロールバック
+
+DROP INDEX IF EXISTS idx_resources_room_capacity;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS floor;
@@ -0,0 +1,16 @@
+-- backend/migrations/000009_room_selection.up.sql
+-- 条件指定による会議室の自動選択
+--
+-- このマイグレーションは以下を追加します:
+-- - resources.floor: 所在フロア（会議室の自動選択で希望フロアからの近さを評価する）
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN floor INT;
+
+COMMENT ON COLUMN resources.floor IS '所在フロア（会議室の自動選択で希望フロアからの近さを評価する）';
+
+-- 収容人数を満たす会議室の検索用
+CREATE INDEX idx_resources_room_capacity ON resources(capacity) WHERE type = 'MEETING_ROOM' AND is_active = true;
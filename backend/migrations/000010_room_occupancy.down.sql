@@ -0,0 +1,10 @@
+-- backend/migrations/000010_room_occupancy.down.sql
+-- 会議室の収容人数・最低利用人数の検証のロールバック
+
+DROP INDEX IF EXISTS idx_participants_instance_status;
+
+ALTER TABLE resources
+    DROP CONSTRAINT IF EXISTS chk_resources_min_occupancy;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS min_occupancy;
@@ -0,0 +1,20 @@
+-- backend/migrations/000010_room_occupancy.up.sql
+-- 参加人数に対する会議室の収容人数・最低利用人数の検証
+--
+-- このマイグレーションは以下を追加します:
+-- - resources.min_occupancy: 最低利用人数（大会議室を少人数で占有しないための下限）
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN min_occupancy INT;
+
+ALTER TABLE resources
+    ADD CONSTRAINT chk_resources_min_occupancy
+    CHECK (min_occupancy IS NULL OR (min_occupancy >= 1 AND (capacity IS NULL OR min_occupancy <= capacity)));
+
+COMMENT ON COLUMN resources.min_occupancy IS '最低利用人数（会議室のみ。大会議室を少人数で占有しないための下限）';
+
+-- 参加人数の集計用（回答待ち・参加予定のみ）
+CREATE INDEX idx_participants_instance_status ON reservation_participants(reservation_instance_id, status);
@@ -0,0 +1,5 @@
+-- backend/migrations/000011_reservation_exdates.down.sql
+-- 繰り返し予約の除外日のロールバック
+
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS exdates;
@@ -0,0 +1,13 @@
+-- backend/migrations/000011_reservation_exdates.up.sql
+-- 繰り返し予約の競合した回の除外 (EXDATE)
+--
+-- このマイグレーションは以下を追加します:
+-- - reservations.exdates: 繰り返しから除外する回の開始日時（RFC 5545 EXDATE。JSON配列）
+
+-- ============================================================================
+-- Reservations テーブルへの列追加
+-- ============================================================================
+ALTER TABLE reservations
+    ADD COLUMN exdates JSONB;
+
+COMMENT ON COLUMN reservations.exdates IS '繰り返しから除外する回の開始日時（RFC 5545 EXDATE。JSON配列）';
@@ -0,0 +1,6 @@
+-- backend/migrations/000012_resource_holds.down.sql
+-- 一時的な仮押さえのロールバック
+
+DROP TRIGGER IF EXISTS trigger_resource_holds_updated_at ON resource_holds;
+
+DROP TABLE IF EXISTS resource_holds CASCADE;
@@ -0,0 +1,39 @@
+-- backend/migrations/000012_resource_holds.up.sql
+-- 予約フォーム入力中の一時的な仮押さえ
+--
+-- このマイグレーションは以下を追加します:
+-- - resource_holds: リソースと時間帯の短時間の仮押さえ（期限切れはワーカーが解放）
+
+-- ============================================================================
+-- ResourceHolds テーブル
+-- ============================================================================
+CREATE TABLE resource_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    reservation_id UUID NOT NULL,  -- 枠を占有する仮押さえ予約（start_at は本テーブルの start_at と同じ）
+    start_at TIMESTAMPTZ NOT NULL,
+    end_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',  -- ACTIVE, CONVERTED, RELEASED, EXPIRED
+    converted_reservation_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_resource_holds_time_range CHECK (end_at > start_at)
+);
+
+COMMENT ON TABLE resource_holds IS '予約フォーム入力中の一時的な仮押さえ';
+COMMENT ON COLUMN resource_holds.reservation_id IS '枠を占有する仮押さえ予約のID（インスタンスは TENTATIVE）';
+COMMENT ON COLUMN resource_holds.status IS 'ステータス: ACTIVE, CONVERTED, RELEASED, EXPIRED';
+COMMENT ON COLUMN resource_holds.expires_at IS '仮押さえの有効期限（過ぎるとワーカーが解放）';
+COMMENT ON COLUMN resource_holds.converted_reservation_id IS '仮押さえから作成した予約のID';
+
+-- 期限切れの検索
+CREATE INDEX idx_resource_holds_expires ON resource_holds(expires_at) WHERE status = 'ACTIVE';
+-- ユーザーごとの有効な仮押さえの検索
+CREATE INDEX idx_resource_holds_user ON resource_holds(user_id) WHERE status = 'ACTIVE';
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_resource_holds_updated_at
+    BEFORE UPDATE ON resource_holds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- backend/migrations/000013_reservation_bumps.down.sql
+-- 会議室の優先確保のロールバック
+
+DROP TABLE IF EXISTS reservation_bumps CASCADE;
+
+ALTER TABLE resources
+    DROP COLUMN IF EXISTS bump_role;
@@ -0,0 +1,46 @@
+-- backend/migrations/000013_reservation_bumps.up.sql
+-- 役員予約による会議室の優先確保（バンプ）
+--
+-- このマイグレーションは以下を追加します:
+-- - resources.bump_role: 既存の予約を押しのけて会議室を確保できる最低ロール
+-- - reservation_bumps: 押しのけられた予約ごとの記録（移動先・理由。ペナルティ免除の根拠）
+
+-- ============================================================================
+-- Resources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE resources
+    ADD COLUMN bump_role VARCHAR(50);
+
+COMMENT ON COLUMN resources.bump_role IS '既存の予約を押しのけて確保できる最低ロール（会議室のみ。NULL の場合は管理者のみ）';
+
+-- ============================================================================
+-- ReservationBumps テーブル
+-- ============================================================================
+CREATE TABLE reservation_bumps (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resource_id UUID NOT NULL REFERENCES resources(id),
+    reservation_id UUID NOT NULL,  -- 優先確保した予約
+    bumped_by UUID NOT NULL REFERENCES users(id),
+    on_behalf_of UUID REFERENCES users(id),
+    displaced_reservation_id UUID NOT NULL,
+    displaced_instance_id UUID NOT NULL,
+    displaced_organizer_id UUID NOT NULL REFERENCES users(id),
+    outcome VARCHAR(20) NOT NULL,  -- MOVED, CANCELLED
+    replacement_resource_id UUID REFERENCES resources(id),
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_reservation_bumps_outcome CHECK (
+        (outcome = 'MOVED' AND replacement_resource_id IS NOT NULL) OR
+        (outcome = 'CANCELLED' AND replacement_resource_id IS NULL)
+    )
+);
+
+COMMENT ON TABLE reservation_bumps IS '優先確保で押しのけられた予約の記録（押しのけられた予約はペナルティの対象外）';
+COMMENT ON COLUMN reservation_bumps.bumped_by IS '優先確保を実行したユーザー（秘書の場合は代理実行者）';
+COMMENT ON COLUMN reservation_bumps.on_behalf_of IS '秘書が代理で確保した場合の役員';
+COMMENT ON COLUMN reservation_bumps.outcome IS '押しのけられた回の扱い: MOVED（同等の空き会議室へ移動）, CANCELLED（強制キャンセル）';
+
+-- 押しのけられた回の検索（ペナルティ免除の判定用）
+CREATE INDEX idx_reservation_bumps_displaced_instance ON reservation_bumps(displaced_instance_id);
+-- 主催者ごとの履歴
+CREATE INDEX idx_reservation_bumps_displaced_organizer ON reservation_bumps(displaced_organizer_id, created_at DESC);
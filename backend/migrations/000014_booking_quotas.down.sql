@@ -0,0 +1,11 @@
+-- backend/migrations/000014_booking_quotas.down.sql
+-- 予約枠の上限のロールバック
+
+DROP TRIGGER IF EXISTS trigger_booking_quotas_updated_at ON booking_quotas;
+
+DROP TABLE IF EXISTS booking_quotas CASCADE;
+
+DROP INDEX IF EXISTS idx_users_department;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS department;
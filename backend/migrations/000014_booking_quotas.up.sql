@@ -0,0 +1,48 @@
+-- backend/migrations/000014_booking_quotas.up.sql
+-- ユーザー・部署ごとの予約枠の上限
+--
+-- このマイグレーションは以下を追加します:
+-- - users.department: 所属部署（部署単位の月間上限の集計用）
+-- - booking_quotas: 予約枠の上限ルール（週間利用時間・同時予約数・部署の月間利用時間）
+
+-- ============================================================================
+-- Users テーブルへの列追加
+-- ============================================================================
+ALTER TABLE users
+    ADD COLUMN department VARCHAR(255);
+
+COMMENT ON COLUMN users.department IS '所属部署（部署単位の予約上限の集計用）';
+
+CREATE INDEX idx_users_department ON users(department) WHERE department IS NOT NULL;
+
+-- ============================================================================
+-- BookingQuotas テーブル
+-- ============================================================================
+CREATE TABLE booking_quotas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    scope VARCHAR(20) NOT NULL,  -- USER, DEPARTMENT
+    kind VARCHAR(30) NOT NULL,  -- WEEKLY_HOURS, MONTHLY_HOURS, CONCURRENT_BOOKINGS
+    resource_type VARCHAR(50),  -- 対象のリソース種別（NULL は全種別）
+    min_capacity INT,  -- 対象の会議室の収容人数の下限（大会議室など）
+    department VARCHAR(255),  -- DEPARTMENT スコープで対象とする部署（NULL は全部署それぞれ）
+    limit_value INT NOT NULL,  -- 上限（時間または件数）
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_booking_quotas_scope CHECK (scope IN ('USER', 'DEPARTMENT')),
+    CONSTRAINT chk_booking_quotas_kind CHECK (kind IN ('WEEKLY_HOURS', 'MONTHLY_HOURS', 'CONCURRENT_BOOKINGS')),
+    CONSTRAINT chk_booking_quotas_limit CHECK (limit_value >= 0),
+    CONSTRAINT chk_booking_quotas_department CHECK (department IS NULL OR scope = 'DEPARTMENT')
+);
+
+COMMENT ON TABLE booking_quotas IS '予約枠の上限ルール';
+COMMENT ON COLUMN booking_quotas.scope IS '集計単位: USER（主催者ごと）, DEPARTMENT（主催者の所属部署ごと）';
+COMMENT ON COLUMN booking_quotas.kind IS '上限の種類: WEEKLY_HOURS（週間利用時間）, MONTHLY_HOURS（月間利用時間）, CONCURRENT_BOOKINGS（今後の予約数）';
+COMMENT ON COLUMN booking_quotas.limit_value IS '上限（利用時間は時間単位、同時予約数は件数）';
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_booking_quotas_updated_at
+    BEFORE UPDATE ON booking_quotas
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- backend/migrations/000015_penalty_lifecycle.down.sql
+-- キャンセルペナルティスコアのライフサイクルのロールバック
+
+DROP TRIGGER IF EXISTS trigger_penalty_thresholds_updated_at ON penalty_thresholds;
+
+DROP TABLE IF EXISTS penalty_thresholds CASCADE;
+DROP TABLE IF EXISTS penalty_events CASCADE;
@@ -0,0 +1,66 @@
+-- backend/migrations/000015_penalty_lifecycle.up.sql
+-- キャンセルペナルティスコアのライフサイクル
+--
+-- このマイグレーションは以下を追加します:
+-- - penalty_events: スコアの加算・調整の履歴（各イベントは90日で消滅する）
+-- - penalty_thresholds: スコアに応じた予約制限（承認必須化・高額会議室の制限・予約可能期間の短縮）
+--
+-- users.penalty_score / penalty_score_expire_at は有効なイベントの合計と最も遅い消滅日時を保持します
+
+-- ============================================================================
+-- PenaltyEvents テーブル
+-- ============================================================================
+CREATE TABLE penalty_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,  -- LATE_CANCELLATION, ADMIN_ADJUSTMENT
+    points INT NOT NULL,
+    reservation_id UUID,  -- 原因となった予約
+    reservation_start_at TIMESTAMPTZ,  -- 原因となった回の開始日時
+    reason TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id),  -- 管理者による調整の場合の実行者
+    occurred_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    expired_at TIMESTAMPTZ,  -- 減衰ジョブでスコアから除外した日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_penalty_events_kind CHECK (kind IN ('LATE_CANCELLATION', 'ADMIN_ADJUSTMENT'))
+);
+
+COMMENT ON TABLE penalty_events IS 'キャンセルペナルティスコアの加算・調整の履歴';
+COMMENT ON COLUMN penalty_events.points IS 'スコアの増減（管理者による調整は負の値も可）';
+COMMENT ON COLUMN penalty_events.expires_at IS 'スコアから消滅する日時（発生から90日）';
+
+-- ユーザーごとの履歴
+CREATE INDEX idx_penalty_events_user ON penalty_events(user_id, occurred_at DESC);
+-- 減衰ジョブ用
+CREATE INDEX idx_penalty_events_expiry ON penalty_events(expires_at) WHERE expired_at IS NULL;
+
+-- ============================================================================
+-- PenaltyThresholds テーブル
+-- ============================================================================
+CREATE TABLE penalty_thresholds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    min_score INT NOT NULL,
+    requires_approval BOOLEAN NOT NULL DEFAULT false,  -- 上長の承認を必須にする
+    max_advance_days INT,  -- 予約可能な期間（開始日時までの日数）の上限
+    premium_min_capacity INT,  -- この収容人数以上の会議室を予約できなくする
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_penalty_thresholds_min_score CHECK (min_score >= 1),
+    CONSTRAINT chk_penalty_thresholds_advance CHECK (max_advance_days IS NULL OR max_advance_days >= 0),
+    CONSTRAINT chk_penalty_thresholds_capacity CHECK (premium_min_capacity IS NULL OR premium_min_capacity >= 1)
+);
+
+COMMENT ON TABLE penalty_thresholds IS 'ペナルティスコアに応じた予約制限（スコアが min_score 以上のユーザーに適用）';
+
+-- Updated_at トリガー
+CREATE TRIGGER trigger_penalty_thresholds_updated_at
+    BEFORE UPDATE ON penalty_thresholds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- 既定の制限（スコア5以上は新規予約に承認が必要）
+INSERT INTO penalty_thresholds (name, min_score, requires_approval)
+VALUES ('Approval required', 5, true);
@@ -0,0 +1,15 @@
+-- backend/migrations/000016_user_identity.down.sql
+-- OIDCの (issuer, sub) によるユーザーの識別のロールバック
+-- 未連携（sub が NULL）のユーザーが存在する場合、NOT NULL 制約の復元は失敗します
+
+DROP TABLE IF EXISTS identity_conflicts CASCADE;
+
+DROP INDEX IF EXISTS idx_users_email_lower;
+DROP INDEX IF EXISTS idx_users_identity;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_sub_key UNIQUE (sub),
+    ALTER COLUMN sub SET NOT NULL;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS issuer;
@@ -0,0 +1,50 @@
+-- backend/migrations/000016_user_identity.up.sql
+-- OIDCの (issuer, sub) によるユーザーの識別
+--
+-- このマイグレーションは以下を変更します:
+-- - users.issuer: IdPの発行者（sub と組み合わせてユーザーを一意に識別する）
+-- - users.sub: 初回ログイン前の（メールアドレスのみで登録された）ユーザーを許容するため NULL 可に変更
+-- - identity_conflicts: メールアドレスでの紐付けや変更が曖昧な場合の突合レポート
+--
+-- issuer が NULL のユーザーは未連携として扱い、初回ログイン時にメールアドレスで紐付けます
+-- （シードデータ等で設定された sub は紐付け時に IdP の値で上書きします）
+
+ALTER TABLE users
+    ADD COLUMN issuer VARCHAR(255);
+
+ALTER TABLE users
+    ALTER COLUMN sub DROP NOT NULL,
+    DROP CONSTRAINT IF EXISTS users_sub_key;
+
+CREATE UNIQUE INDEX idx_users_identity ON users(issuer, sub) WHERE issuer IS NOT NULL;
+-- 未連携ユーザーの紐付け・メールアドレスの重複確認用
+CREATE INDEX idx_users_email_lower ON users(LOWER(email)) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN users.issuer IS 'IdPの発行者（sub と組み合わせて一意。NULL は未連携）';
+
+-- ============================================================================
+-- IdentityConflicts テーブル
+-- ============================================================================
+CREATE TABLE identity_conflicts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(50) NOT NULL,  -- EMAIL_LINKED_TO_OTHER_SUBJECT, MULTIPLE_EMAIL_MATCHES, EMAIL_TAKEN
+    issuer VARCHAR(255) NOT NULL,
+    sub VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,  -- IdPから受け取ったメールアドレス
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,  -- 連携済みのユーザー（EMAIL_TAKEN の場合）
+    candidate_user_ids JSONB NOT NULL DEFAULT '[]',  -- メールアドレスが一致したユーザー
+    detected_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES users(id),
+    resolution VARCHAR(20),  -- LINK, CREATE, DISMISS
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_identity_conflicts_kind CHECK (kind IN ('EMAIL_LINKED_TO_OTHER_SUBJECT', 'MULTIPLE_EMAIL_MATCHES', 'EMAIL_TAKEN')),
+    CONSTRAINT chk_identity_conflicts_resolution CHECK (resolution IS NULL OR resolution IN ('LINK', 'CREATE', 'DISMISS'))
+);
+
+COMMENT ON TABLE identity_conflicts IS 'IdPのユーザーとの突合で自動判定できなかった事象（管理者が解決する）';
+
+-- 同じ事象の未解決レコードはログインのたびに増やさず検出日時を更新する
+CREATE UNIQUE INDEX idx_identity_conflicts_open ON identity_conflicts(kind, issuer, sub) WHERE resolved_at IS NULL;
@@ -0,0 +1,10 @@
+-- backend/migrations/000017_scim_provisioning.down.sql
+-- SCIM 2.0 によるユーザー・グループのプロビジョニングのロールバック
+
+DROP TABLE IF EXISTS provisioned_group_members CASCADE;
+DROP TABLE IF EXISTS provisioned_groups CASCADE;
+
+DROP INDEX IF EXISTS idx_users_external_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,45 @@
+-- backend/migrations/000017_scim_provisioning.up.sql
+-- SCIM 2.0 によるユーザー・グループのプロビジョニング
+--
+-- このマイグレーションは以下を追加します:
+-- - users.external_id: IdP（プロビジョニング元）でのユーザーの識別子（SCIM の externalId）
+-- - provisioned_groups: IdPからプロビジョニングされたグループ（ロールの判定に使用）
+-- - provisioned_group_members: グループのメンバー
+
+-- ============================================================================
+-- Users テーブルへの列追加
+-- ============================================================================
+ALTER TABLE users
+    ADD COLUMN external_id VARCHAR(255);
+
+COMMENT ON COLUMN users.external_id IS 'プロビジョニング元のIdPでのユーザーの識別子（SCIM の externalId）';
+
+CREATE UNIQUE INDEX idx_users_external_id ON users(external_id) WHERE external_id IS NOT NULL AND deleted_at IS NULL;
+
+-- ============================================================================
+-- ProvisionedGroups テーブル
+-- ============================================================================
+CREATE TABLE provisioned_groups (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    external_id VARCHAR(255),  -- プロビジョニング元のIdPでのグループの識別子
+    display_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE provisioned_groups IS 'IdPからSCIMでプロビジョニングされたグループ（ロールの判定に使用）';
+
+CREATE UNIQUE INDEX idx_provisioned_groups_display_name ON provisioned_groups(display_name);
+CREATE UNIQUE INDEX idx_provisioned_groups_external_id ON provisioned_groups(external_id) WHERE external_id IS NOT NULL;
+
+-- ============================================================================
+-- ProvisionedGroupMembers テーブル
+-- ============================================================================
+CREATE TABLE provisioned_group_members (
+    group_id UUID NOT NULL REFERENCES provisioned_groups(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+-- ユーザーの所属グループからロールを判定する
+CREATE INDEX idx_provisioned_group_members_user_id ON provisioned_group_members(user_id);
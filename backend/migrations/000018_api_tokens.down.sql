@@ -0,0 +1,5 @@
+-- backend/migrations/000018_api_tokens.down.sql
+-- 個人用アクセストークンとサービスアカウントのAPIキーのロールバック
+
+DROP TABLE IF EXISTS api_tokens CASCADE;
+DROP TABLE IF EXISTS service_accounts CASCADE;
@@ -0,0 +1,53 @@
+-- backend/migrations/000018_api_tokens.up.sql
+-- 個人用アクセストークンとサービスアカウントのAPIキー
+--
+-- このマイグレーションは以下を追加します:
+-- - service_accounts: 特定の個人に紐付かないAPIの利用者（会議室の表示端末・集計スクリプト等）
+-- - api_tokens: ユーザーまたはサービスアカウントのAPIトークン
+--
+-- トークン自体は保存せず、SHA-256 のハッシュ値のみを保存します（発行時に一度だけ表示）
+
+-- ============================================================================
+-- ServiceAccounts テーブル
+-- ============================================================================
+CREATE TABLE service_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    role VARCHAR(50) NOT NULL DEFAULT 'GENERAL',  -- トークンで操作する際のロール
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    disabled_at TIMESTAMPTZ  -- 無効化日時（無効化したアカウントのトークンは使用不可）
+);
+
+COMMENT ON TABLE service_accounts IS '特定の個人に紐付かないAPIの利用者';
+
+CREATE UNIQUE INDEX idx_service_accounts_name ON service_accounts(name);
+
+-- ============================================================================
+-- APITokens テーブル
+-- ============================================================================
+CREATE TABLE api_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,  -- 用途を識別するための名前
+    prefix VARCHAR(20) NOT NULL,  -- トークンの先頭部分（一覧での識別用）
+    token_hash CHAR(64) NOT NULL,  -- トークンの SHA-256（16進数）
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,  -- 個人用アクセストークンの所有者
+    service_account_id UUID REFERENCES service_accounts(id) ON DELETE CASCADE,  -- サービスアカウントのAPIキーの所有者
+    scopes JSONB NOT NULL DEFAULT '[]',  -- read, write
+    expires_at TIMESTAMPTZ NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    last_used_ip VARCHAR(45),
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ,
+    revoked_by UUID REFERENCES users(id),
+    CONSTRAINT chk_api_tokens_owner CHECK ((user_id IS NULL) <> (service_account_id IS NULL))
+);
+
+COMMENT ON TABLE api_tokens IS '個人用アクセストークン・サービスアカウントのAPIキー（ハッシュ値のみ保存）';
+
+CREATE UNIQUE INDEX idx_api_tokens_hash ON api_tokens(token_hash);
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_api_tokens_service_account ON api_tokens(service_account_id) WHERE service_account_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- backend/migrations/000019_reservation_resource_release.down.sql
+-- 予約インスタンスのリソースごとの早期解放のロールバック
+
+ALTER TABLE reservation_resources
+    DROP COLUMN IF EXISTS released_at;
@@ -0,0 +1,16 @@
+-- backend/migrations/000019_reservation_resource_release.up.sql
+-- 予約インスタンスのリソースごとの早期解放
+--
+-- このマイグレーションは以下を追加します:
+-- - reservation_resources.released_at: 備品の早期返却などでリソースの割り当てだけを解放した日時
+--
+-- 会議室とプロジェクターのように複数のリソースを予約した場合でも、
+-- 返却したリソースだけを解放し、予約インスタンスの終了日時は変更しません
+
+-- ============================================================================
+-- ReservationResources テーブルへの列追加
+-- ============================================================================
+ALTER TABLE reservation_resources
+    ADD COLUMN released_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN reservation_resources.released_at IS '割り当てを解放した日時（以降はインスタンスの終了日時まで空きとして扱う）';
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -41,6 +42,9 @@ type Config struct {
 	ClientSecret string
 	RedirectURL  string
 	Scopes       []string
+
+	// PostLogoutRedirectURL はIdPでのログアウト後に戻るURL（IdPに登録が必要）
+	PostLogoutRedirectURL string
 }
 
 // Client はOIDCクライアント
@@ -49,6 +53,9 @@ type Client struct {
 	provider     *oidc.Provider
 	verifier     *oidc.IDTokenVerifier
 	oauth2Config *oauth2.Config
+
+	// endSessionEndpoint はIdPのログアウトエンドポイント（RP-Initiated Logout、未対応のIdPでは空）
+	endSessionEndpoint string
 }
 
 // NewClient は新しいOIDCクライアントを作成します
@@ -94,11 +101,20 @@ func NewClient(ctx context.Context, cfg *Config) (*Client, error) {
 		Scopes:       cfg.Scopes,
 	}
 
+	// RP-Initiated Logout のエンドポイント（Discovery の任意項目）
+	var metadata struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse provider metadata: %w", err)
+	}
+
 	return &Client{
-		config:       cfg,
-		provider:     provider,
-		verifier:     verifier,
-		oauth2Config: oauth2Config,
+		config:             cfg,
+		provider:           provider,
+		verifier:           verifier,
+		oauth2Config:       oauth2Config,
+		endSessionEndpoint: metadata.EndSessionEndpoint,
 	}, nil
 }
 
@@ -199,6 +215,9 @@ type UserInfo struct {
 	EmailVerified bool   `json:"email_verified"`
 	Name          string `json:"name"`
 	Picture       string `json:"picture"`
+
+	// RawClaims はIDトークンの全クレーム（グループ・ロール等のIdP固有クレームのマッピング用）
+	RawClaims map[string]interface{} `json:"-"`
 }
 
 // GetUserInfo はIDトークンからユーザー情報を取得します
@@ -207,6 +226,9 @@ func (c *Client) GetUserInfo(ctx context.Context, idToken *oidc.IDToken) (*UserI
 	if err := idToken.Claims(&userInfo); err != nil {
 		return nil, fmt.Errorf("failed to parse user info: %w", err)
 	}
+	if err := idToken.Claims(&userInfo.RawClaims); err != nil {
+		return nil, fmt.Errorf("failed to parse raw claims: %w", err)
+	}
 
 	return &userInfo, nil
 }
@@ -254,6 +276,29 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*oauth2
 	return newToken, nil
 }
 
+// EndSessionURL はIdPのセッションを終了させるログアウトURL（RP-Initiated Logout）を生成します
+// IdPがログアウトエンドポイントを公開していない場合は空文字を返します
+func (c *Client) EndSessionURL(idTokenHint string) string {
+	if c.endSessionEndpoint == "" {
+		return ""
+	}
+
+	u, err := url.Parse(c.endSessionEndpoint)
+	if err != nil {
+		return ""
+	}
+	q := u.Query()
+	q.Set("client_id", c.config.ClientID)
+	if idTokenHint != "" {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	if c.config.PostLogoutRedirectURL != "" {
+		q.Set("post_logout_redirect_uri", c.config.PostLogoutRedirectURL)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
 // TokenClaims はトークンのクレームを表します
 // TODO: 本番環境では以下のクレームの検証も必要:
 // - nonce: リプレイアタック防止（Authorization Code Flowで必須）
@@ -271,6 +316,9 @@ type TokenClaims struct {
 	Nonce  string `json:"nonce,omitempty"`   // リプレイアタック防止
 	AtHash string `json:"at_hash,omitempty"` // アクセストークンハッシュ
 	Azp    string `json:"azp,omitempty"`     // Authorized party
+
+	// RawClaims はIDトークンの全クレーム（グループ・ロール等のIdP固有クレームのマッピング用）
+	RawClaims map[string]interface{} `json:"-"`
 }
 
 // audienceWrapper はaudienceクレームの柔軟な型対応
@@ -324,6 +372,9 @@ func (c *Client) ParseIDTokenClaimsWithValidation(ctx context.Context, rawIDToke
 	if err := idToken.Claims(&claims); err != nil {
 		return nil, fmt.Errorf("failed to parse claims: %w", err)
 	}
+	if err := idToken.Claims(&claims.RawClaims); err != nil {
+		return nil, fmt.Errorf("failed to parse raw claims: %w", err)
+	}
 
 	// 追加の検証（クロックスキュー許容）
 	if err := c.validateClaims(&claims, expectedNonce, accessToken); err != nil {
@@ -394,3 +445,42 @@ func verifyAtHash(atHash, accessToken string) bool {
 
 	return atHash == expected
 }
+
+// ClaimValues はドット区切りのパス（例: "realm_access.roles"）でクレームを辿り、値を文字列の一覧として返します
+// パスと同じ名前のクレームがある場合はそれを優先します（SAMLの属性名のようにドットを含むURIの場合）
+// 値が文字列の場合は1件、配列の場合は文字列の要素のみを返します。パスが存在しない場合は nil を返します
+func ClaimValues(claims map[string]interface{}, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	current, ok := claims[path]
+	if !ok {
+		current = claims
+		for _, key := range strings.Split(path, ".") {
+			obj, ok := current.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			if current, ok = obj[key]; !ok {
+				return nil
+			}
+		}
+	}
+
+	switch v := current.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	case []string:
+		return v
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -67,6 +68,7 @@ func (m *mockOIDCServer) handleDiscovery(w http.ResponseWriter, r *http.Request)
 		"authorization_endpoint":                m.issuer + "/authorize",
 		"token_endpoint":                        m.issuer + "/token",
 		"jwks_uri":                              m.issuer + "/jwks",
+		"end_session_endpoint":                  m.issuer + "/logout",
 		"response_types_supported":              []string{"code"},
 		"subject_types_supported":               []string{"public"},
 		"id_token_signing_alg_values_supported": []string{"RS256"},
@@ -190,6 +192,31 @@ func TestNewClient_InvalidConfig(t *testing.T) {
 	}
 }
 
+// TestEndSessionURL はIdPのログアウトURLの生成をテストします
+func TestEndSessionURL(t *testing.T) {
+	mock := newMockOIDCServer(t)
+	defer mock.Close()
+
+	client, err := oidc.NewClient(context.Background(), &oidc.Config{
+		IssuerURL:             mock.issuer,
+		ClientID:              "test-client-id",
+		PostLogoutRedirectURL: "http://localhost:8080/logged-out",
+	})
+	require.NoError(t, err)
+
+	u, err := url.Parse(client.EndSessionURL("raw-id-token"))
+	require.NoError(t, err)
+	assert.Equal(t, mock.issuer+"/logout", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "test-client-id", u.Query().Get("client_id"))
+	assert.Equal(t, "raw-id-token", u.Query().Get("id_token_hint"))
+	assert.Equal(t, "http://localhost:8080/logged-out", u.Query().Get("post_logout_redirect_uri"))
+
+	// IDトークンがない場合は id_token_hint を付けない
+	u, err = url.Parse(client.EndSessionURL(""))
+	require.NoError(t, err)
+	assert.False(t, u.Query().Has("id_token_hint"))
+}
+
 // TestGetAuthURL_WithPKCEAndNonce はPKCEとnonceを含む認証URLをテストします
 func TestGetAuthURL_WithPKCEAndNonce(t *testing.T) {
 	mock := newMockOIDCServer(t)
@@ -441,3 +468,67 @@ func TestVerifyAtHash_Invalid(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, oidc.ErrInvalidAtHash)
 }
+
+// TestGetUserInfo_RawClaims はIdP固有のクレームを RawClaims から参照できることをテストします
+func TestGetUserInfo_RawClaims(t *testing.T) {
+	mock := newMockOIDCServer(t)
+	defer mock.Close()
+
+	cfg := &oidc.Config{
+		IssuerURL:    mock.issuer,
+		ClientID:     "test-client-id",
+		ClientSecret: "test-client-secret",
+	}
+
+	ctx := context.Background()
+	client, err := oidc.NewClient(ctx, cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":          mock.issuer,
+		"sub":          "user-123",
+		"aud":          "test-client-id",
+		"exp":          now.Add(1 * time.Hour).Unix(),
+		"iat":          now.Unix(),
+		"email":        "test@example.com",
+		"groups":       []string{"esms-admins", "all-staff"},
+		"realm_access": map[string]interface{}{"roles": []string{"manager"}},
+	}
+
+	idToken, err := mock.generateIDToken(claims)
+	require.NoError(t, err)
+
+	verified, err := client.VerifyIDToken(ctx, idToken)
+	require.NoError(t, err)
+
+	userInfo, err := client.GetUserInfo(ctx, verified)
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", userInfo.Email)
+	assert.Equal(t, []string{"esms-admins", "all-staff"}, oidc.ClaimValues(userInfo.RawClaims, "groups"))
+	assert.Equal(t, []string{"manager"}, oidc.ClaimValues(userInfo.RawClaims, "realm_access.roles"))
+
+	parsedClaims, err := client.ParseIDTokenClaimsWithValidation(ctx, idToken, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test@example.com"}, oidc.ClaimValues(parsedClaims.RawClaims, "email"))
+}
+
+// TestClaimValues はドット区切りのパスでのクレーム参照をテストします
+func TestClaimValues(t *testing.T) {
+	claims := map[string]interface{}{
+		"groups":       []interface{}{"a", 1, "b"},
+		"manager":      "boss@example.com",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"admin"}},
+		"http://schemas.microsoft.com/ws/2008/06/identity/claims/groups": []interface{}{"esms-admins"},
+	}
+
+	assert.Equal(t, []string{"a", "b"}, oidc.ClaimValues(claims, "groups"))
+	assert.Equal(t, []string{"boss@example.com"}, oidc.ClaimValues(claims, "manager"))
+	assert.Equal(t, []string{"admin"}, oidc.ClaimValues(claims, "realm_access.roles"))
+	assert.Nil(t, oidc.ClaimValues(claims, "realm_access.missing"))
+	assert.Nil(t, oidc.ClaimValues(claims, "manager.name"))
+	assert.Nil(t, oidc.ClaimValues(claims, ""))
+
+	// ドットを含むクレーム名（SAMLの属性のURI）はそのまま参照できる
+	assert.Equal(t, []string{"esms-admins"}, oidc.ClaimValues(claims, "http://schemas.microsoft.com/ws/2008/06/identity/claims/groups"))
+}
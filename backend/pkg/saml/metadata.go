@@ -0,0 +1,175 @@
+// backend/pkg/saml/metadata.go
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxMetadataSize は取得するIdPメタデータの上限サイズ
+const maxMetadataSize = 5 << 20
+
+// entityDescriptor はメタデータの EntityDescriptor のXML表現（IdP・SPの両方で使用）
+type entityDescriptor struct {
+	XMLName          xml.Name          `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID         string            `xml:"entityID,attr"`
+	IDPSSODescriptor *idpSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor,omitempty"`
+	SPSSODescriptor  *spSSODescriptor  `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor,omitempty"`
+}
+
+type idpSSODescriptor struct {
+	KeyDescriptors      []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnService []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool       `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool       `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string     `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormats              []string   `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat,omitempty"`
+	AssertionConsumerServices  []endpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr,omitempty"`
+	Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     *int   `xml:"index,attr,omitempty"`
+	IsDefault *bool  `xml:"isDefault,attr,omitempty"`
+}
+
+// ParseIdPMetadata はIdPのメタデータ（EntityDescriptor）から連携に必要な情報を取り出します
+// 署名用（use="signing" または use 未指定）の証明書と HTTP-Redirect バインディングの SSO URL が必要です
+func ParseIdPMetadata(data []byte) (*IdPMetadata, error) {
+	var ed entityDescriptor
+	if err := xml.Unmarshal(data, &ed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	if ed.EntityID == "" || ed.IDPSSODescriptor == nil {
+		return nil, fmt.Errorf("%w: IDPSSODescriptor with entityID is required", ErrInvalidMetadata)
+	}
+
+	idp := &IdPMetadata{EntityID: ed.EntityID}
+	for _, sso := range ed.IDPSSODescriptor.SingleSignOnService {
+		if sso.Binding == HTTPRedirectBinding {
+			idp.SSOURL = sso.Location
+			break
+		}
+	}
+	if idp.SSOURL == "" {
+		return nil, fmt.Errorf("%w: no HTTP-Redirect SingleSignOnService", ErrInvalidMetadata)
+	}
+
+	for _, kd := range ed.IDPSSODescriptor.KeyDescriptors {
+		if kd.Use != "" && kd.Use != "signing" {
+			continue
+		}
+		for _, encoded := range kd.Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid certificate encoding: %v", ErrInvalidMetadata, err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid certificate: %v", ErrInvalidMetadata, err)
+			}
+			idp.Certificates = append(idp.Certificates, cert)
+		}
+	}
+	if len(idp.Certificates) == 0 {
+		return nil, fmt.Errorf("%w: no signing certificate", ErrInvalidMetadata)
+	}
+
+	return idp, nil
+}
+
+// FetchIdPMetadata はIdPのメタデータURLからメタデータを取得して解析します
+func FetchIdPMetadata(ctx context.Context, metadataURL string) (*IdPMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata request: %w", err)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch IdP metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch IdP metadata: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read IdP metadata: %w", err)
+	}
+	return ParseIdPMetadata(data)
+}
+
+// ParseCertificatePEM はPEM形式の証明書（複数可）を読み込みます（メタデータを使わずに設定する場合）
+func ParseCertificatePEM(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid certificate: %v", ErrInvalidConfig, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%w: no PEM certificate found", ErrInvalidConfig)
+	}
+	return certs, nil
+}
+
+// Metadata はIdPに登録するSPのメタデータ（EntityDescriptor）を生成します
+// アサーションへの署名を必須とし、ACS は HTTP-POST バインディングで受け付けます
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	index := 0
+	isDefault := true
+	ed := entityDescriptor{
+		EntityID: sp.config.EntityID,
+		SPSSODescriptor: &spSSODescriptor{
+			AuthnRequestsSigned:        false,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: ProtocolNamespace,
+			AssertionConsumerServices: []endpoint{{
+				Binding:   HTTPPostBinding,
+				Location:  sp.config.ACSURL,
+				Index:     &index,
+				IsDefault: &isDefault,
+			}},
+		},
+	}
+	if sp.config.NameIDFormat != "" {
+		ed.SPSSODescriptor.NameIDFormats = []string{sp.config.NameIDFormat}
+	}
+
+	data, err := xml.MarshalIndent(ed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SP metadata: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,317 @@
+// backend/pkg/saml/response.go
+package saml
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+// Assertion は署名と条件の検証を通過したアサーションの内容
+type Assertion struct {
+	ID                  string
+	Issuer              string // IdPのエンティティID
+	NameID              string
+	NameIDFormat        string
+	SessionIndex        string
+	AuthnInstant        time.Time
+	SessionNotOnOrAfter time.Time           // IdPのセッションの有効期限（ゼロ値の場合は指定なし）
+	Attributes          map[string][]string // 属性名（Name と FriendlyName の両方）ごとの値
+}
+
+// Attribute は属性の最初の値を返します（存在しない場合は空文字列）
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// responseHeader は Response 要素のうち検証に使う部分のXML表現
+type responseHeader struct {
+	XMLName      xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string   `xml:"ID,attr"`
+	InResponseTo string   `xml:"InResponseTo,attr"`
+	Destination  string   `xml:"Destination,attr"`
+	Issuer       string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       struct {
+		StatusCode struct {
+			Value string `xml:"Value,attr"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusCode"`
+		StatusMessage string `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusMessage"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status"`
+}
+
+// assertionXML は Assertion 要素のXML表現
+type assertionXML struct {
+	XMLName xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	ID      string   `xml:"ID,attr"`
+	Issuer  string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject struct {
+		NameID struct {
+			Format string `xml:"Format,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+		SubjectConfirmations []struct {
+			Method string `xml:"Method,attr"`
+			Data   struct {
+				Recipient    string    `xml:"Recipient,attr"`
+				InResponseTo string    `xml:"InResponseTo,attr"`
+				NotOnOrAfter time.Time `xml:"NotOnOrAfter,attr"`
+			} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions *struct {
+		NotBefore            time.Time `xml:"NotBefore,attr"`
+		NotOnOrAfter         time.Time `xml:"NotOnOrAfter,attr"`
+		AudienceRestrictions []struct {
+			Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AuthnStatements []struct {
+		AuthnInstant        time.Time `xml:"AuthnInstant,attr"`
+		SessionIndex        string    `xml:"SessionIndex,attr"`
+		SessionNotOnOrAfter time.Time `xml:"SessionNotOnOrAfter,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AuthnStatement"`
+	Attributes []struct {
+		Name         string   `xml:"Name,attr"`
+		FriendlyName string   `xml:"FriendlyName,attr"`
+		Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement>Attribute"`
+}
+
+// ParseResponse はACSにPOSTされた SAMLResponse（Base64）を検証し、アサーションの内容を返します
+//
+// 検証内容:
+//   - ステータスが Success であること
+//   - Response と Assertion の少なくとも一方がIdPの証明書で署名されていること
+//     （XML Signature Wrapping を防ぐため、以降の検証と値の取り出しは署名検証済みの要素のみを対象とします）
+//   - InResponseTo が AuthnRequest のID（expectedRequestID）と一致すること（IdP-initiated は受け付けません）
+//   - Destination・Recipient がACSのURL、Issuer がIdPのエンティティID、Audience がSPのエンティティIDであること
+//   - Conditions・SubjectConfirmationData の有効期間内であること（ClockSkew を許容）
+func (sp *ServiceProvider) ParseResponse(samlResponse, expectedRequestID string) (*Assertion, error) {
+	if expectedRequestID == "" {
+		return nil, fmt.Errorf("%w: expected request ID is required", ErrInvalidInResponseTo)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(samlResponse), ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid base64: %v", ErrInvalidResponse, err)
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid xml: %v", ErrInvalidResponse, err)
+	}
+	root := doc.Root()
+	if root == nil || root.Tag != "Response" || root.NamespaceURI() != ProtocolNamespace {
+		return nil, fmt.Errorf("%w: root element must be samlp:Response", ErrInvalidResponse)
+	}
+
+	var header responseHeader
+	if err := unmarshalElement(root, &header); err != nil {
+		return nil, err
+	}
+	if header.Status.StatusCode.Value != StatusSuccess {
+		return nil, fmt.Errorf("%w: %s %s", ErrStatusNotSuccess, header.Status.StatusCode.Value, header.Status.StatusMessage)
+	}
+
+	// Response が署名されている場合は検証済みの内容で読み直す
+	response := root
+	responseSigned := hasSignature(root)
+	if responseSigned {
+		if response, err = sp.verifySignature(root); err != nil {
+			return nil, err
+		}
+		if err := unmarshalElement(response, &header); err != nil {
+			return nil, err
+		}
+	}
+
+	if header.InResponseTo != expectedRequestID {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrInvalidInResponseTo, expectedRequestID, header.InResponseTo)
+	}
+	if header.Destination != "" && header.Destination != sp.config.ACSURL {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDestination, header.Destination)
+	}
+	if header.Issuer != "" && header.Issuer != sp.config.IdP.EntityID {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIssuer, header.Issuer)
+	}
+
+	assertionEl, err := sp.verifiedAssertion(response, responseSigned)
+	if err != nil {
+		return nil, err
+	}
+
+	var assertion assertionXML
+	if err := unmarshalElement(assertionEl, &assertion); err != nil {
+		return nil, err
+	}
+	return sp.validateAssertion(&assertion, expectedRequestID, TimeFunc())
+}
+
+// verifiedAssertion は Response 直下の唯一の Assertion を取り出し、署名されていれば検証します
+// Response が署名されていない場合は Assertion の署名が必須です
+func (sp *ServiceProvider) verifiedAssertion(response *etree.Element, responseSigned bool) (*etree.Element, error) {
+	var assertions []*etree.Element
+	for _, child := range response.ChildElements() {
+		if child.NamespaceURI() != AssertionNamespace {
+			continue
+		}
+		switch child.Tag {
+		case "EncryptedAssertion":
+			return nil, fmt.Errorf("%w: encrypted assertions are not supported", ErrInvalidResponse)
+		case "Assertion":
+			assertions = append(assertions, child)
+		}
+	}
+	if len(assertions) != 1 {
+		return nil, fmt.Errorf("%w: exactly one assertion is required, got %d", ErrInvalidResponse, len(assertions))
+	}
+
+	// 親要素で宣言された名前空間を引き継いで切り出す
+	ctx, err := etreeutils.NSBuildParentContext(assertions[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	assertion, err := etreeutils.NSDetatch(ctx, assertions[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	if hasSignature(assertion) {
+		return sp.verifySignature(assertion)
+	}
+	if !responseSigned {
+		return nil, fmt.Errorf("%w: neither the response nor the assertion is signed", ErrInvalidSignature)
+	}
+	return assertion, nil
+}
+
+// verifySignature は要素のエンベロープ署名をIdPの証明書で検証し、署名の対象として検証済みの要素を返します
+func (sp *ServiceProvider) verifySignature(el *etree.Element) (*etree.Element, error) {
+	vctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: sp.config.IdP.Certificates,
+	})
+	// 証明書の有効期間の判定にも TimeFunc の時刻を使う
+	vctx.Clock = dsig.NewFakeClockAt(TimeFunc())
+
+	verified, err := vctx.Validate(el)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return verified, nil
+}
+
+// validateAssertion はアサーションの発行者・有効期間・Audience・SubjectConfirmation を検証します
+func (sp *ServiceProvider) validateAssertion(a *assertionXML, expectedRequestID string, now time.Time) (*Assertion, error) {
+	if a.Issuer != sp.config.IdP.EntityID {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIssuer, a.Issuer)
+	}
+
+	conditions := a.Conditions
+	if conditions == nil {
+		return nil, fmt.Errorf("%w: conditions are required", ErrInvalidResponse)
+	}
+	if !conditions.NotBefore.IsZero() && now.Add(ClockSkew).Before(conditions.NotBefore) {
+		return nil, fmt.Errorf("%w: not before %v (now: %v)", ErrAssertionNotYetValid, conditions.NotBefore, now)
+	}
+	if !conditions.NotOnOrAfter.IsZero() && !now.Add(-ClockSkew).Before(conditions.NotOnOrAfter) {
+		return nil, fmt.Errorf("%w: not on or after %v (now: %v)", ErrAssertionExpired, conditions.NotOnOrAfter, now)
+	}
+
+	// AudienceRestriction は全てを満たす必要がある
+	if len(conditions.AudienceRestrictions) == 0 {
+		return nil, fmt.Errorf("%w: audience restriction is required", ErrInvalidAudience)
+	}
+	for _, restriction := range conditions.AudienceRestrictions {
+		if !containsString(restriction.Audiences, sp.config.EntityID) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAudience, restriction.Audiences)
+		}
+	}
+
+	// Bearer の SubjectConfirmation が少なくとも1つ有効であること
+	confirmed := false
+	for _, sc := range a.Subject.SubjectConfirmations {
+		data := sc.Data
+		if sc.Method != bearerConfirmationMethod ||
+			data.Recipient != sp.config.ACSURL ||
+			data.InResponseTo != expectedRequestID ||
+			data.NotOnOrAfter.IsZero() || !now.Add(-ClockSkew).Before(data.NotOnOrAfter) {
+			continue
+		}
+		confirmed = true
+		break
+	}
+	if !confirmed {
+		return nil, fmt.Errorf("%w: no valid bearer subject confirmation", ErrInvalidResponse)
+	}
+
+	nameID := strings.TrimSpace(a.Subject.NameID.Value)
+	if nameID == "" {
+		return nil, fmt.Errorf("%w: NameID is required", ErrInvalidResponse)
+	}
+
+	result := &Assertion{
+		ID:           a.ID,
+		Issuer:       a.Issuer,
+		NameID:       nameID,
+		NameIDFormat: a.Subject.NameID.Format,
+		Attributes:   make(map[string][]string),
+	}
+	if len(a.AuthnStatements) > 0 {
+		result.SessionIndex = a.AuthnStatements[0].SessionIndex
+		result.AuthnInstant = a.AuthnStatements[0].AuthnInstant
+		result.SessionNotOnOrAfter = a.AuthnStatements[0].SessionNotOnOrAfter
+	}
+	for _, attr := range a.Attributes {
+		values := make([]string, 0, len(attr.Values))
+		for _, v := range attr.Values {
+			values = append(values, strings.TrimSpace(v))
+		}
+		result.Attributes[attr.Name] = append(result.Attributes[attr.Name], values...)
+		if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+			result.Attributes[attr.FriendlyName] = append(result.Attributes[attr.FriendlyName], values...)
+		}
+	}
+
+	return result, nil
+}
+
+// hasSignature は要素の直下に ds:Signature があるかを判定します
+func hasSignature(el *etree.Element) bool {
+	for _, child := range el.ChildElements() {
+		if child.Tag == dsig.SignatureTag && child.NamespaceURI() == dsig.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// unmarshalElement は要素を（親要素の名前空間の宣言を引き継いで）構造体に変換します
+func unmarshalElement(el *etree.Element, v interface{}) error {
+	ctx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if err := etreeutils.NSUnmarshalElement(ctx, el, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	return nil
+}
+
+// containsString は文字列の一覧に指定した値が含まれるかを判定します
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
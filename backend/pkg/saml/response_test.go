@@ -0,0 +1,355 @@
+// backend/pkg/saml/response_test.go
+package saml_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/pkg/saml"
+)
+
+// フィクスチャ（testdata/response.xml）の値
+const (
+	fixtureSPEntityID  = "https://esms.example.com/saml/metadata"
+	fixtureACSURL      = "https://esms.example.com/api/v1/auth/saml/acs"
+	fixtureIdPEntityID = "https://idp.example.com/saml"
+	fixtureSSOURL      = "https://idp.example.com/saml/sso"
+	fixtureRequestID   = "_req-0123456789abcdef"
+)
+
+// fixtureNow はフィクスチャのアサーションが有効な時刻
+var fixtureNow = time.Date(2026, 1, 15, 9, 1, 0, 0, time.UTC)
+
+// setTime はテスト中の現在時刻を固定します
+func setTime(t *testing.T, now time.Time) {
+	t.Helper()
+	original := saml.TimeFunc
+	saml.TimeFunc = func() time.Time { return now }
+	t.Cleanup(func() { saml.TimeFunc = original })
+}
+
+// loadFixtureKeyPair はフィクスチャのIdPの署名鍵と証明書を読み込みます
+func loadFixtureKeyPair(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	certPEM, err := os.ReadFile("testdata/idp.crt")
+	require.NoError(t, err)
+	certs, err := saml.ParseCertificatePEM(certPEM)
+	require.NoError(t, err)
+
+	keyPEM, err := os.ReadFile("testdata/idp.key")
+	require.NoError(t, err)
+	block, _ := pem.Decode(keyPEM)
+	require.NotNil(t, block)
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	return key, certs[0]
+}
+
+// newFixtureSP はフィクスチャのIdPと連携するSPを作成します
+func newFixtureSP(t *testing.T) *saml.ServiceProvider {
+	t.Helper()
+
+	_, cert := loadFixtureKeyPair(t)
+	sp, err := saml.NewServiceProvider(&saml.Config{
+		EntityID: fixtureSPEntityID,
+		ACSURL:   fixtureACSURL,
+		IdP: &saml.IdPMetadata{
+			EntityID:     fixtureIdPEntityID,
+			SSOURL:       fixtureSSOURL,
+			Certificates: []*x509.Certificate{cert},
+		},
+	})
+	require.NoError(t, err)
+	return sp
+}
+
+// fixtureOptions はフィクスチャから SAMLResponse を組み立てる際の加工内容
+type fixtureOptions struct {
+	mutate        func(doc *etree.Document) // 署名前の加工
+	signAssertion bool
+	signResponse  bool
+	key           *rsa.PrivateKey           // 署名鍵（nil の場合はフィクスチャの鍵）
+	afterSign     func(doc *etree.Document) // 署名後の加工（改ざん）
+}
+
+// buildResponse はフィクスチャを加工・署名して SAMLResponse（Base64）を作成します
+func buildResponse(t *testing.T, opts fixtureOptions) string {
+	t.Helper()
+
+	key, cert := loadFixtureKeyPair(t)
+	if opts.key != nil {
+		key = opts.key
+	}
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromFile("testdata/response.xml"))
+	if opts.mutate != nil {
+		opts.mutate(doc)
+	}
+
+	sign := func(el *etree.Element) *etree.Element {
+		ctx, err := dsig.NewSigningContext(key, [][]byte{cert.Raw})
+		require.NoError(t, err)
+		ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+		signed, err := ctx.SignEnveloped(el)
+		require.NoError(t, err)
+
+		// スキーマに合わせて ds:Signature を Issuer の直後に移動する
+		sig := signed.RemoveChildAt(len(signed.Child) - 1)
+		signed.InsertChildAt(1, sig)
+		return signed
+	}
+
+	if opts.signAssertion {
+		assertion := doc.FindElement("./Response/Assertion")
+		require.NotNil(t, assertion)
+		parent := assertion.Parent()
+		index := assertion.Index()
+		parent.RemoveChild(assertion)
+		parent.InsertChildAt(index, sign(assertion))
+	}
+	if opts.signResponse {
+		doc.SetRoot(sign(doc.Root()))
+	}
+	if opts.afterSign != nil {
+		opts.afterSign(doc)
+	}
+
+	data, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func TestParseResponse_SignedFixture(t *testing.T) {
+	setTime(t, fixtureNow)
+	sp := newFixtureSP(t)
+
+	data, err := os.ReadFile("testdata/signed_response.xml")
+	require.NoError(t, err)
+
+	assertion, err := sp.ParseResponse(base64.StdEncoding.EncodeToString(data), fixtureRequestID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "_assert-5e8d1c0b2a3f4e6d", assertion.ID)
+	assert.Equal(t, fixtureIdPEntityID, assertion.Issuer)
+	assert.Equal(t, "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d", assertion.NameID)
+	assert.Equal(t, saml.NameIDFormatPersistent, assertion.NameIDFormat)
+	assert.Equal(t, "_session-42", assertion.SessionIndex)
+	assert.Equal(t, time.Date(2026, 1, 15, 17, 0, 0, 0, time.UTC), assertion.SessionNotOnOrAfter)
+
+	// 属性は Name と FriendlyName の両方で参照できる
+	assert.Equal(t, "taro.yamada@example.com", assertion.Attribute("mail"))
+	assert.Equal(t, "taro.yamada@example.com", assertion.Attribute("urn:oid:0.9.2342.19200300.100.1.3"))
+	assert.Equal(t, "山田 太郎", assertion.Attribute("displayName"))
+	assert.Equal(t, []string{"esms-managers", "all-staff"}, assertion.Attributes["groups"])
+	assert.Empty(t, assertion.Attribute("unknown"))
+}
+
+func TestParseResponse_TamperedFixture(t *testing.T) {
+	setTime(t, fixtureNow)
+	sp := newFixtureSP(t)
+
+	data, err := os.ReadFile("testdata/signed_response.xml")
+	require.NoError(t, err)
+	tampered := strings.Replace(string(data), "taro.yamada@example.com", "admin@example.com", 1)
+	require.NotEqual(t, string(data), tampered)
+
+	_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte(tampered)), fixtureRequestID)
+	assert.ErrorIs(t, err, saml.ErrInvalidSignature)
+}
+
+func TestParseResponse_Signatures(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		opts    fixtureOptions
+		wantErr error
+	}{
+		{
+			name: "assertion signed",
+			opts: fixtureOptions{signAssertion: true},
+		},
+		{
+			name: "response signed",
+			opts: fixtureOptions{signResponse: true},
+		},
+		{
+			name: "response and assertion signed",
+			opts: fixtureOptions{signAssertion: true, signResponse: true},
+		},
+		{
+			name:    "unsigned",
+			opts:    fixtureOptions{},
+			wantErr: saml.ErrInvalidSignature,
+		},
+		{
+			name:    "signed by untrusted key",
+			opts:    fixtureOptions{signAssertion: true, key: otherKey},
+			wantErr: saml.ErrInvalidSignature,
+		},
+		{
+			// 署名済みのアサーションの横に偽のアサーションを差し込む（XML Signature Wrapping）
+			name: "wrapped assertion",
+			opts: fixtureOptions{
+				signAssertion: true,
+				afterSign: func(doc *etree.Document) {
+					forged := doc.FindElement("./Response/Assertion").Copy()
+					forged.FindElement("./Signature").Parent().RemoveChild(forged.FindElement("./Signature"))
+					forged.FindElement("./Subject/NameID").SetText("attacker")
+					doc.Root().InsertChildAt(2, forged)
+				},
+			},
+			wantErr: saml.ErrInvalidResponse,
+		},
+		{
+			name: "encrypted assertion",
+			opts: fixtureOptions{
+				signResponse: true,
+				mutate: func(doc *etree.Document) {
+					assertion := doc.FindElement("./Response/Assertion")
+					assertion.Tag = "EncryptedAssertion"
+				},
+			},
+			wantErr: saml.ErrInvalidResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTime(t, fixtureNow)
+			sp := newFixtureSP(t)
+
+			assertion, err := sp.ParseResponse(buildResponse(t, tt.opts), fixtureRequestID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d", assertion.NameID)
+		})
+	}
+}
+
+func TestParseResponse_Validation(t *testing.T) {
+	setAttr := func(path, key, value string) func(doc *etree.Document) {
+		return func(doc *etree.Document) {
+			doc.FindElement(path).CreateAttr(key, value)
+		}
+	}
+	setText := func(path, value string) func(doc *etree.Document) {
+		return func(doc *etree.Document) {
+			doc.FindElement(path).SetText(value)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		requestID string
+		mutate    func(doc *etree.Document)
+		wantErr   error
+	}{
+		{
+			name:    "within clock skew before NotBefore",
+			now:     time.Date(2026, 1, 15, 8, 58, 30, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "not yet valid",
+			now:     time.Date(2026, 1, 15, 8, 57, 0, 0, time.UTC),
+			wantErr: saml.ErrAssertionNotYetValid,
+		},
+		{
+			name:    "expired",
+			now:     time.Date(2026, 1, 15, 9, 7, 0, 0, time.UTC),
+			wantErr: saml.ErrAssertionExpired,
+		},
+		{
+			name:      "unexpected InResponseTo",
+			requestID: "_req-other",
+			wantErr:   saml.ErrInvalidInResponseTo,
+		},
+		{
+			name:    "wrong destination",
+			mutate:  setAttr("./Response", "Destination", "https://evil.example.com/acs"),
+			wantErr: saml.ErrInvalidDestination,
+		},
+		{
+			name:    "wrong assertion issuer",
+			mutate:  setText("./Response/Assertion/Issuer", "https://evil.example.com"),
+			wantErr: saml.ErrInvalidIssuer,
+		},
+		{
+			name:    "wrong audience",
+			mutate:  setText("./Response/Assertion/Conditions/AudienceRestriction/Audience", "https://other-sp.example.com"),
+			wantErr: saml.ErrInvalidAudience,
+		},
+		{
+			name:    "wrong recipient",
+			mutate:  setAttr("./Response/Assertion/Subject/SubjectConfirmation/SubjectConfirmationData", "Recipient", "https://evil.example.com/acs"),
+			wantErr: saml.ErrInvalidResponse,
+		},
+		{
+			name:    "subject confirmation for another request",
+			mutate:  setAttr("./Response/Assertion/Subject/SubjectConfirmation/SubjectConfirmationData", "InResponseTo", "_req-other"),
+			wantErr: saml.ErrInvalidResponse,
+		},
+		{
+			name:    "status not success",
+			mutate:  setAttr("./Response/Status/StatusCode", "Value", "urn:oasis:names:tc:SAML:2.0:status:Requester"),
+			wantErr: saml.ErrStatusNotSuccess,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := tt.now
+			if now.IsZero() {
+				now = fixtureNow
+			}
+			setTime(t, now)
+			requestID := tt.requestID
+			if requestID == "" {
+				requestID = fixtureRequestID
+			}
+			sp := newFixtureSP(t)
+
+			_, err := sp.ParseResponse(buildResponse(t, fixtureOptions{mutate: tt.mutate, signAssertion: true}), requestID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseResponse_InvalidInput(t *testing.T) {
+	setTime(t, fixtureNow)
+	sp := newFixtureSP(t)
+
+	_, err := sp.ParseResponse("not base64!", fixtureRequestID)
+	assert.ErrorIs(t, err, saml.ErrInvalidResponse)
+
+	_, err = sp.ParseResponse(base64.StdEncoding.EncodeToString([]byte("<foo/>")), fixtureRequestID)
+	assert.ErrorIs(t, err, saml.ErrInvalidResponse)
+
+	// IdP-initiated（要求IDなし）は受け付けない
+	_, err = sp.ParseResponse(buildResponse(t, fixtureOptions{signAssertion: true}), "")
+	assert.ErrorIs(t, err, saml.ErrInvalidInResponseTo)
+}
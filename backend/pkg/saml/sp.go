@@ -0,0 +1,187 @@
+// backend/pkg/saml/sp.go
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	ProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	AssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	MetadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	HTTPRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	HTTPPostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	NameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	NameIDFormatEmail       = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDFormatPersistent  = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	NameIDFormatTransient   = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+
+	StatusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+	bearerConfirmationMethod = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+var (
+	ErrInvalidConfig        = errors.New("invalid configuration")
+	ErrInvalidMetadata      = errors.New("invalid metadata")
+	ErrInvalidResponse      = errors.New("invalid saml response")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrInvalidIssuer        = errors.New("invalid issuer")
+	ErrInvalidAudience      = errors.New("invalid audience")
+	ErrInvalidDestination   = errors.New("invalid destination")
+	ErrInvalidInResponseTo  = errors.New("invalid InResponseTo")
+	ErrAssertionExpired     = errors.New("assertion expired")
+	ErrAssertionNotYetValid = errors.New("assertion not yet valid")
+	ErrStatusNotSuccess     = errors.New("saml status is not success")
+)
+
+// ClockSkew はアサーション検証時の許容クロックスキュー
+const ClockSkew = 1 * time.Minute
+
+// TimeFunc は現在時刻を返す関数（テスト用に注入可能）
+var TimeFunc = time.Now
+
+// Config はSAML SP（サービスプロバイダー）の設定
+type Config struct {
+	EntityID     string       // SPのエンティティID（AuthnRequest の Issuer、アサーションの Audience）
+	ACSURL       string       // Assertion Consumer Service のURL（HTTP-POST バインディング）
+	NameIDFormat string       // 要求する NameID の形式（空の場合は指定しない）
+	IdP          *IdPMetadata // 連携するIdPのメタデータ
+}
+
+// IdPMetadata はIdPのメタデータから取り出した連携に必要な情報
+type IdPMetadata struct {
+	EntityID     string
+	SSOURL       string              // SingleSignOnService（HTTP-Redirect バインディング）のURL
+	Certificates []*x509.Certificate // アサーションの署名検証に使う証明書（ロールオーバー中は複数）
+}
+
+// ServiceProvider はSAML 2.0 のSPとして SP-initiated のログインを処理します
+type ServiceProvider struct {
+	config *Config
+}
+
+// NewServiceProvider は新しいServiceProviderを作成します
+func NewServiceProvider(cfg *Config) (*ServiceProvider, error) {
+	if cfg == nil {
+		return nil, ErrInvalidConfig
+	}
+	if cfg.EntityID == "" || cfg.ACSURL == "" {
+		return nil, fmt.Errorf("%w: entity ID and ACS URL are required", ErrInvalidConfig)
+	}
+	if cfg.IdP == nil || cfg.IdP.EntityID == "" || cfg.IdP.SSOURL == "" {
+		return nil, fmt.Errorf("%w: IdP entity ID and SSO URL are required", ErrInvalidConfig)
+	}
+	if len(cfg.IdP.Certificates) == 0 {
+		return nil, fmt.Errorf("%w: at least one IdP signing certificate is required", ErrInvalidConfig)
+	}
+
+	return &ServiceProvider{config: cfg}, nil
+}
+
+// EntityID はSPのエンティティIDを返します
+func (sp *ServiceProvider) EntityID() string {
+	return sp.config.EntityID
+}
+
+// IdPEntityID は連携するIdPのエンティティIDを返します（ユーザーの issuer として使用）
+func (sp *ServiceProvider) IdPEntityID() string {
+	return sp.config.IdP.EntityID
+}
+
+// authnRequest は AuthnRequest のXML表現
+type authnRequest struct {
+	XMLName                     xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string        `xml:"ID,attr"`
+	Version                     string        `xml:"Version,attr"`
+	IssueInstant                string        `xml:"IssueInstant,attr"`
+	Destination                 string        `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string        `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string        `xml:"ProtocolBinding,attr"`
+	Issuer                      string        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                *nameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy,omitempty"`
+}
+
+type nameIDPolicy struct {
+	Format      string `xml:"Format,attr,omitempty"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// NewRequestID は AuthnRequest のIDを生成します（xs:ID のため先頭は英字以外にしない）
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	return "_" + hex.EncodeToString(b), nil
+}
+
+// AuthnRequestURL は SP-initiated ログインのリダイレクト先URL（HTTP-Redirect バインディング）を生成します
+// 返した requestID は ParseResponse で InResponseTo の検証に使うため、呼び出し元で relayState と紐付けて保存してください
+func (sp *ServiceProvider) AuthnRequestURL(relayState string) (redirectURL, requestID string, err error) {
+	requestID, err = NewRequestID()
+	if err != nil {
+		return "", "", err
+	}
+
+	req := authnRequest{
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                formatTime(TimeFunc()),
+		Destination:                 sp.config.IdP.SSOURL,
+		AssertionConsumerServiceURL: sp.config.ACSURL,
+		ProtocolBinding:             HTTPPostBinding,
+		Issuer:                      sp.config.EntityID,
+	}
+	if sp.config.NameIDFormat != "" {
+		req.NameIDPolicy = &nameIDPolicy{Format: sp.config.NameIDFormat, AllowCreate: true}
+	}
+
+	data, err := xml.Marshal(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal authn request: %w", err)
+	}
+
+	// HTTP-Redirect バインディングは DEFLATE で圧縮して Base64 エンコードする
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create deflate writer: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", "", fmt.Errorf("failed to deflate authn request: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to deflate authn request: %w", err)
+	}
+
+	u, err := url.Parse(sp.config.IdP.SSOURL)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid SSO URL: %v", ErrInvalidConfig, err)
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String(), requestID, nil
+}
+
+// formatTime はSAMLの日時（UTCの xs:dateTime）に変換します
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
@@ -0,0 +1,199 @@
+// backend/pkg/saml/sp_test.go
+package saml_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/pkg/saml"
+)
+
+// idpMetadataXML はフィクスチャのIdPのメタデータを作成します
+func idpMetadataXML(t *testing.T, use, binding string) []byte {
+	t.Helper()
+
+	_, cert := loadFixtureKeyPair(t)
+	return []byte(fmt.Sprintf(`<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="%s">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="%s">
+      <ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+        %s
+      </ds:X509Certificate></ds:X509Data></ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/saml/post"/>
+    <md:SingleSignOnService Binding="%s" Location="%s"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, fixtureIdPEntityID, use, base64.StdEncoding.EncodeToString(cert.Raw), binding, fixtureSSOURL))
+}
+
+func TestNewServiceProvider_InvalidConfig(t *testing.T) {
+	_, cert := loadFixtureKeyPair(t)
+	idp := &saml.IdPMetadata{EntityID: fixtureIdPEntityID, SSOURL: fixtureSSOURL, Certificates: []*x509.Certificate{cert}}
+
+	tests := []struct {
+		name string
+		cfg  *saml.Config
+	}{
+		{name: "nil config", cfg: nil},
+		{name: "missing entity ID", cfg: &saml.Config{ACSURL: fixtureACSURL, IdP: idp}},
+		{name: "missing ACS URL", cfg: &saml.Config{EntityID: fixtureSPEntityID, IdP: idp}},
+		{name: "missing IdP", cfg: &saml.Config{EntityID: fixtureSPEntityID, ACSURL: fixtureACSURL}},
+		{
+			name: "missing IdP certificate",
+			cfg: &saml.Config{EntityID: fixtureSPEntityID, ACSURL: fixtureACSURL, IdP: &saml.IdPMetadata{
+				EntityID: fixtureIdPEntityID, SSOURL: fixtureSSOURL,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := saml.NewServiceProvider(tt.cfg)
+			assert.ErrorIs(t, err, saml.ErrInvalidConfig)
+		})
+	}
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	setTime(t, fixtureNow)
+	sp := newFixtureSP(t)
+
+	redirectURL, requestID, err := sp.AuthnRequestURL("relay-state-123")
+	require.NoError(t, err)
+	require.NotEmpty(t, requestID)
+
+	u, err := url.Parse(redirectURL)
+	require.NoError(t, err)
+	assert.Equal(t, "idp.example.com", u.Host)
+	assert.Equal(t, "/saml/sso", u.Path)
+	assert.Equal(t, "relay-state-123", u.Query().Get("RelayState"))
+
+	// HTTP-Redirect バインディング（DEFLATE + Base64）で復元できる
+	compressed, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	require.NoError(t, err)
+
+	var req struct {
+		XMLName      xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+		ID           string   `xml:"ID,attr"`
+		IssueInstant string   `xml:"IssueInstant,attr"`
+		Destination  string   `xml:"Destination,attr"`
+		ACSURL       string   `xml:"AssertionConsumerServiceURL,attr"`
+		Binding      string   `xml:"ProtocolBinding,attr"`
+		Issuer       string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	}
+	require.NoError(t, xml.Unmarshal(raw, &req))
+	assert.Equal(t, requestID, req.ID)
+	assert.Equal(t, "2026-01-15T09:01:00Z", req.IssueInstant)
+	assert.Equal(t, fixtureSSOURL, req.Destination)
+	assert.Equal(t, fixtureACSURL, req.ACSURL)
+	assert.Equal(t, saml.HTTPPostBinding, req.Binding)
+	assert.Equal(t, fixtureSPEntityID, req.Issuer)
+
+	// 要求IDは毎回異なる
+	_, otherID, err := sp.AuthnRequestURL("")
+	require.NoError(t, err)
+	assert.NotEqual(t, requestID, otherID)
+}
+
+func TestParseIdPMetadata(t *testing.T) {
+	_, cert := loadFixtureKeyPair(t)
+
+	idp, err := saml.ParseIdPMetadata(idpMetadataXML(t, "signing", saml.HTTPRedirectBinding))
+	require.NoError(t, err)
+	assert.Equal(t, fixtureIdPEntityID, idp.EntityID)
+	assert.Equal(t, fixtureSSOURL, idp.SSOURL)
+	require.Len(t, idp.Certificates, 1)
+	assert.True(t, cert.Equal(idp.Certificates[0]))
+
+	// use 未指定の鍵も署名用として扱う
+	idp, err = saml.ParseIdPMetadata(idpMetadataXML(t, "", saml.HTTPRedirectBinding))
+	require.NoError(t, err)
+	assert.Len(t, idp.Certificates, 1)
+}
+
+func TestParseIdPMetadata_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not xml", data: []byte("not xml")},
+		{name: "encryption key only", data: idpMetadataXML(t, "encryption", saml.HTTPRedirectBinding)},
+		{name: "no redirect binding", data: idpMetadataXML(t, "signing", saml.HTTPPostBinding)},
+		{
+			name: "sp metadata",
+			data: []byte(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com"><md:SPSSODescriptor/></md:EntityDescriptor>`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := saml.ParseIdPMetadata(tt.data)
+			assert.ErrorIs(t, err, saml.ErrInvalidMetadata)
+		})
+	}
+}
+
+func TestFetchIdPMetadata(t *testing.T) {
+	metadata := idpMetadataXML(t, "signing", saml.HTTPRedirectBinding)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		w.Write(metadata)
+	}))
+	defer server.Close()
+
+	idp, err := saml.FetchIdPMetadata(context.Background(), server.URL+"/metadata")
+	require.NoError(t, err)
+	assert.Equal(t, fixtureIdPEntityID, idp.EntityID)
+
+	_, err = saml.FetchIdPMetadata(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+}
+
+func TestMetadata(t *testing.T) {
+	sp := newFixtureSP(t)
+
+	data, err := sp.Metadata()
+	require.NoError(t, err)
+
+	var ed struct {
+		EntityID string `xml:"entityID,attr"`
+		SP       struct {
+			WantAssertionsSigned bool   `xml:"WantAssertionsSigned,attr"`
+			Protocol             string `xml:"protocolSupportEnumeration,attr"`
+			ACS                  []struct {
+				Binding  string `xml:"Binding,attr"`
+				Location string `xml:"Location,attr"`
+			} `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &ed))
+	assert.Equal(t, fixtureSPEntityID, ed.EntityID)
+	assert.True(t, ed.SP.WantAssertionsSigned)
+	assert.Equal(t, saml.ProtocolNamespace, ed.SP.Protocol)
+	require.Len(t, ed.SP.ACS, 1)
+	assert.Equal(t, saml.HTTPPostBinding, ed.SP.ACS[0].Binding)
+	assert.Equal(t, fixtureACSURL, ed.SP.ACS[0].Location)
+
+	// SPのメタデータはIdPのメタデータとしては読み込めない
+	_, err = saml.ParseIdPMetadata(data)
+	assert.ErrorIs(t, err, saml.ErrInvalidMetadata)
+}
@@ -0,0 +1,136 @@
+// backend/pkg/scim/filter.go
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Condition は属性の等値条件（attribute eq "value"）
+type Condition struct {
+	Attribute string
+	Value     string
+}
+
+// Filter は等値条件を and で連結したフィルター（例: userName eq "taro@example.com"）
+// IdPのコネクターがプロビジョニング前の存在確認に使用する範囲（eq と and）のみ対応します
+type Filter []Condition
+
+// Value は属性の条件の値を返します（属性名は大文字小文字を区別しません）
+func (f Filter) Value(attribute string) (string, bool) {
+	for _, c := range f {
+		if strings.EqualFold(c.Attribute, attribute) {
+			return c.Value, true
+		}
+	}
+	return "", false
+}
+
+// ParseFilter はフィルターの文字列を解析します
+// 値は文字列（JSONの文字列リテラル）・真偽値・数値を受け付け、いずれも文字列として扱います
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenizeFilter(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty filter", ErrInvalidFilter)
+	}
+
+	var filter Filter
+	for i := 0; i < len(tokens); i += 4 {
+		if i+3 > len(tokens) {
+			return nil, fmt.Errorf("%w: incomplete expression in %q", ErrInvalidFilter, s)
+		}
+		attribute, operator, value := tokens[i], tokens[i+1], tokens[i+2]
+		if !strings.EqualFold(operator, "eq") {
+			return nil, fmt.Errorf("%w: unsupported operator %q", ErrInvalidFilter, operator)
+		}
+		if !isAttributePath(attribute) {
+			return nil, fmt.Errorf("%w: invalid attribute %q", ErrInvalidFilter, attribute)
+		}
+		parsed, err := parseFilterValue(value)
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, Condition{Attribute: attribute, Value: parsed})
+
+		if i+3 < len(tokens) && !strings.EqualFold(tokens[i+3], "and") {
+			return nil, fmt.Errorf("%w: unsupported logical operator %q", ErrInvalidFilter, tokens[i+3])
+		}
+		if i+3 == len(tokens)-1 {
+			return nil, fmt.Errorf("%w: expression expected after %q", ErrInvalidFilter, tokens[i+3])
+		}
+	}
+	return filter, nil
+}
+
+// tokenizeFilter はフィルターを空白で区切ります（二重引用符で囲まれた文字列は1つのトークンとして扱います）
+func tokenizeFilter(s string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == ' ' || s[i] == '\t':
+			i++
+		case s[i] == '"':
+			end := i + 1
+			for ; end < len(s); end++ {
+				if s[end] == '\\' {
+					end++
+					continue
+				}
+				if s[end] == '"' {
+					break
+				}
+			}
+			if end >= len(s) {
+				return nil, fmt.Errorf("%w: unterminated string in %q", ErrInvalidFilter, s)
+			}
+			tokens = append(tokens, s[i:end+1])
+			i = end + 1
+		case s[i] == '(' || s[i] == ')' || s[i] == '[' || s[i] == ']':
+			return nil, fmt.Errorf("%w: grouping is not supported in %q", ErrInvalidFilter, s)
+		default:
+			end := i
+			for end < len(s) && s[end] != ' ' && s[end] != '\t' {
+				end++
+			}
+			tokens = append(tokens, s[i:end])
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parseFilterValue はフィルターの値を文字列に変換します
+func parseFilterValue(token string) (string, error) {
+	if strings.HasPrefix(token, `"`) {
+		var value string
+		if err := json.Unmarshal([]byte(token), &value); err != nil {
+			return "", fmt.Errorf("%w: invalid string %s", ErrInvalidFilter, token)
+		}
+		return value, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(token), &value); err != nil || value == nil {
+		return "", fmt.Errorf("%w: invalid value %q", ErrInvalidFilter, token)
+	}
+	return token, nil
+}
+
+// isAttributePath は属性のパス（例: userName, name.givenName, スキーマのURNで修飾した属性）として有効かを判定します
+func isAttributePath(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.' || r == ':' || r == '_' || r == '-' || r == '$':
+		default:
+			return false
+		}
+	}
+	return true
+}
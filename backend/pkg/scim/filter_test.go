@@ -0,0 +1,115 @@
+// backend/pkg/scim/filter_test.go
+package scim_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/your-org/esms/pkg/scim"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    scim.Filter
+		wantErr bool
+	}{
+		{
+			name:   "userName eq",
+			filter: `userName eq "taro.yamada@example.com"`,
+			want:   scim.Filter{{Attribute: "userName", Value: "taro.yamada@example.com"}},
+		},
+		{
+			name:   "case insensitive operator and escaped quote",
+			filter: `displayName EQ "Sales \"East\""`,
+			want:   scim.Filter{{Attribute: "displayName", Value: `Sales "East"`}},
+		},
+		{
+			name:   "and",
+			filter: `externalId eq "00u1ab2cd3" and active eq true`,
+			want: scim.Filter{
+				{Attribute: "externalId", Value: "00u1ab2cd3"},
+				{Attribute: "active", Value: "true"},
+			},
+		},
+		{name: "unsupported operator", filter: `userName co "taro"`, wantErr: true},
+		{name: "or", filter: `userName eq "a" or userName eq "b"`, wantErr: true},
+		{name: "grouping", filter: `(userName eq "a")`, wantErr: true},
+		{name: "dangling and", filter: `userName eq "a" and`, wantErr: true},
+		{name: "unterminated string", filter: `userName eq "taro`, wantErr: true},
+		{name: "unquoted string", filter: `userName eq taro`, wantErr: true},
+		{name: "empty", filter: ` `, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scim.ParseFilter(tt.filter)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, scim.ErrInvalidFilter)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilter_Value(t *testing.T) {
+	filter, err := scim.ParseFilter(`userName eq "taro@example.com"`)
+	assert.NoError(t, err)
+
+	value, ok := filter.Value("USERNAME")
+	assert.True(t, ok)
+	assert.Equal(t, "taro@example.com", value)
+
+	_, ok = filter.Value("externalId")
+	assert.False(t, ok)
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    scim.Path
+		wantErr bool
+	}{
+		{name: "attribute", path: "active", want: scim.Path{Attribute: "active"}},
+		{name: "sub-attribute", path: "name.givenName", want: scim.Path{Attribute: "name", SubAttribute: "givenName"}},
+		{
+			name: "value filter",
+			path: `members[value eq "2819c223-7f76-453a-919d-413861904646"]`,
+			want: scim.Path{Attribute: "members", Filter: scim.Filter{{Attribute: "value", Value: "2819c223-7f76-453a-919d-413861904646"}}},
+		},
+		{
+			name: "value filter with sub-attribute",
+			path: `emails[type eq "work"].value`,
+			want: scim.Path{Attribute: "emails", Filter: scim.Filter{{Attribute: "type", Value: "work"}}, SubAttribute: "value"},
+		},
+		{
+			name: "enterprise extension",
+			path: "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department",
+			want: scim.Path{Schema: scim.SchemaEnterpriseUser, Attribute: "department"},
+		},
+		{
+			name: "core schema",
+			path: "urn:ietf:params:scim:schemas:core:2.0:User:userName",
+			want: scim.Path{Attribute: "userName"},
+		},
+		{name: "empty", path: "", wantErr: true},
+		{name: "unterminated filter", path: `members[value eq "x"`, wantErr: true},
+		{name: "invalid character", path: "display name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scim.ParsePath(tt.path)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, scim.ErrInvalidPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,504 @@
+// backend/pkg/scim/patch.go
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PatchRequest はPATCHのリクエスト（RFC 7644 3.5.2）
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation はPATCHの操作
+// op は大文字小文字を区別しません（Microsoft Entra ID は "Replace" のように送信します）
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// Path はPATCHの操作の対象（例: displayName, name.givenName, members[value eq "id"], emails[type eq "work"].value）
+type Path struct {
+	Schema       string // 属性を修飾するスキーマのURN（コアのスキーマの場合は空文字列）
+	Attribute    string
+	Filter       Filter // 複数値の属性の要素の絞り込み（[...]）
+	SubAttribute string
+}
+
+// ParsePath はPATCHの操作の path を解析します
+func ParsePath(s string) (Path, error) {
+	var path Path
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return path, fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+
+	// スキーマのURNで修飾された属性（例: urn:...:enterprise:2.0:User:department）
+	for _, schema := range []string{SchemaEnterpriseUser, SchemaUser, SchemaGroup} {
+		if len(rest) > len(schema) && strings.EqualFold(rest[:len(schema)+1], schema+":") {
+			if schema == SchemaEnterpriseUser {
+				path.Schema = SchemaEnterpriseUser
+			}
+			rest = rest[len(schema)+1:]
+			break
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(rest), "urn:") {
+		i := strings.LastIndex(rest, ":")
+		path.Schema, rest = rest[:i], rest[i+1:]
+	}
+
+	if open := strings.Index(rest, "["); open >= 0 {
+		closeIndex := strings.LastIndex(rest, "]")
+		if closeIndex < open {
+			return path, fmt.Errorf("%w: unterminated filter in %q", ErrInvalidPath, s)
+		}
+		filter, err := ParseFilter(rest[open+1 : closeIndex])
+		if err != nil {
+			return path, fmt.Errorf("%w: %v", ErrInvalidPath, err)
+		}
+		path.Filter = filter
+		suffix := rest[closeIndex+1:]
+		rest = rest[:open]
+		if suffix != "" {
+			if !strings.HasPrefix(suffix, ".") {
+				return path, fmt.Errorf("%w: unexpected %q after filter", ErrInvalidPath, suffix)
+			}
+			path.SubAttribute = suffix[1:]
+		}
+	} else if attribute, sub, ok := strings.Cut(rest, "."); ok {
+		rest, path.SubAttribute = attribute, sub
+	}
+
+	if !isAttributePath(rest) || strings.Contains(rest, ".") || (path.SubAttribute != "" && !isAttributePath(path.SubAttribute)) {
+		return path, fmt.Errorf("%w: %q", ErrInvalidPath, s)
+	}
+	path.Attribute = rest
+	return path, nil
+}
+
+// is は属性名が一致するかを判定します（属性名は大文字小文字を区別しません）
+func (p Path) is(attribute string) bool {
+	return strings.EqualFold(p.Attribute, attribute)
+}
+
+// isEnterprise はエンタープライズユーザーの拡張スキーマの属性かを判定します
+func (p Path) isEnterprise() bool {
+	return strings.EqualFold(p.Schema, SchemaEnterpriseUser)
+}
+
+// ApplyPatch はPATCHの操作をユーザーに適用します
+// ESMSで管理しない属性（電話番号・役職など）への操作は無視します
+func (u *User) ApplyPatch(operations []PatchOperation) error {
+	return applyOperations(operations, u.setAttribute, u.removeAttribute, func(key string, value json.RawMessage) (bool, error) {
+		// path を省略した操作では拡張スキーマの属性をURNのキーの下にまとめて指定できる
+		if !strings.EqualFold(key, SchemaEnterpriseUser) {
+			return false, nil
+		}
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(value, &attributes); err != nil {
+			return true, fmt.Errorf("%w: %s must be an object", ErrInvalidValue, key)
+		}
+		for name, v := range attributes {
+			if err := u.setAttribute(opReplace, Path{Schema: SchemaEnterpriseUser, Attribute: name}, v); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	})
+}
+
+func (u *User) setAttribute(op string, path Path, value json.RawMessage) error {
+	if path.isEnterprise() {
+		return u.setEnterpriseAttribute(path, value)
+	}
+	if path.Schema != "" {
+		return nil
+	}
+
+	switch {
+	case path.is("userName"):
+		return decodeString(value, &u.UserName)
+	case path.is("externalId"):
+		return decodeString(value, &u.ExternalID)
+	case path.is("displayName"):
+		return decodeString(value, &u.DisplayName)
+	case path.is("active"):
+		active, err := decodeBool(value)
+		if err != nil {
+			return err
+		}
+		u.Active = &active
+		return nil
+	case path.is("name"):
+		return u.setName(path.SubAttribute, value)
+	case path.is("emails"):
+		return u.setEmails(op, path, value)
+	}
+	return nil
+}
+
+func (u *User) setName(subAttribute string, value json.RawMessage) error {
+	if u.Name == nil {
+		u.Name = &Name{}
+	}
+	switch strings.ToLower(subAttribute) {
+	case "":
+		var name Name
+		if err := json.Unmarshal(value, &name); err != nil {
+			return fmt.Errorf("%w: name must be an object", ErrInvalidValue)
+		}
+		*u.Name = name
+		return nil
+	case "formatted":
+		return decodeString(value, &u.Name.Formatted)
+	case "familyname":
+		return decodeString(value, &u.Name.FamilyName)
+	case "givenname":
+		return decodeString(value, &u.Name.GivenName)
+	}
+	return nil
+}
+
+func (u *User) setEmails(op string, path Path, value json.RawMessage) error {
+	// emails[type eq "work"].value のように要素を指定した場合は、一致する要素（なければ追加した要素）の値を設定する
+	if path.Filter != nil {
+		if path.SubAttribute != "" && !strings.EqualFold(path.SubAttribute, "value") {
+			return nil
+		}
+		var address string
+		if path.SubAttribute != "" {
+			if err := decodeString(value, &address); err != nil {
+				return err
+			}
+		} else {
+			var email Email
+			if err := json.Unmarshal(value, &email); err != nil {
+				return fmt.Errorf("%w: email must be an object", ErrInvalidValue)
+			}
+			address = email.Value
+		}
+		for i := range u.Emails {
+			if matchesEmail(u.Emails[i], path.Filter) {
+				u.Emails[i].Value = address
+				return nil
+			}
+		}
+		email := Email{Value: address}
+		email.Type, _ = path.Filter.Value("type")
+		if primary, ok := path.Filter.Value("primary"); ok {
+			email.Primary = strings.EqualFold(primary, "true")
+		}
+		u.Emails = append(u.Emails, email)
+		return nil
+	}
+
+	var emails []Email
+	if err := json.Unmarshal(value, &emails); err != nil {
+		return fmt.Errorf("%w: emails must be an array", ErrInvalidValue)
+	}
+	if op == opReplace {
+		u.Emails = emails
+		return nil
+	}
+	// add は同じ種類のメールアドレスを置き換え、それ以外は追加する
+	for _, email := range emails {
+		replaced := false
+		for i := range u.Emails {
+			if email.Type != "" && strings.EqualFold(u.Emails[i].Type, email.Type) {
+				u.Emails[i] = email
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			u.Emails = append(u.Emails, email)
+		}
+	}
+	return nil
+}
+
+func (u *User) setEnterpriseAttribute(path Path, value json.RawMessage) error {
+	if u.Enterprise == nil {
+		u.Enterprise = &EnterpriseUser{}
+	}
+	switch {
+	case path.is("department"):
+		return decodeString(value, &u.Enterprise.Department)
+	case path.is("manager"):
+		// 上長はユーザーのIDの文字列（Microsoft Entra ID）または {"value": "..."} で指定される
+		var managerID string
+		if err := decodeString(value, &managerID); err != nil {
+			var reference Reference
+			if json.Unmarshal(value, &reference) != nil {
+				return fmt.Errorf("%w: manager must be a string or an object", ErrInvalidValue)
+			}
+			managerID = reference.Value
+		}
+		if managerID == "" {
+			u.Enterprise.Manager = nil
+			return nil
+		}
+		u.Enterprise.Manager = &Reference{Value: managerID}
+	}
+	return nil
+}
+
+func (u *User) removeAttribute(path Path, value json.RawMessage) error {
+	if path.isEnterprise() {
+		if u.Enterprise == nil {
+			return nil
+		}
+		switch {
+		case path.is("department"):
+			u.Enterprise.Department = ""
+		case path.is("manager"):
+			u.Enterprise.Manager = nil
+		}
+		return nil
+	}
+	if path.Schema != "" {
+		return nil
+	}
+
+	switch {
+	case path.is("externalId"):
+		u.ExternalID = ""
+	case path.is("displayName"):
+		u.DisplayName = ""
+	case path.is("name"):
+		if path.SubAttribute == "" {
+			u.Name = nil
+		} else if u.Name != nil {
+			return u.setName(path.SubAttribute, json.RawMessage(`""`))
+		}
+	case path.is("emails"):
+		var kept []Email
+		for _, email := range u.Emails {
+			if path.Filter != nil && !matchesEmail(email, path.Filter) {
+				kept = append(kept, email)
+			}
+		}
+		u.Emails = kept
+	case path.is("userName"), path.is("active"):
+		return fmt.Errorf("%w: %s cannot be removed", ErrInvalidPath, path.Attribute)
+	}
+	return nil
+}
+
+// matchesEmail はメールアドレスが要素の絞り込みの条件（type・value・primary）に一致するかを判定します
+func matchesEmail(email Email, filter Filter) bool {
+	for _, c := range filter {
+		switch strings.ToLower(c.Attribute) {
+		case "type":
+			if !strings.EqualFold(email.Type, c.Value) {
+				return false
+			}
+		case "value":
+			if !strings.EqualFold(email.Value, c.Value) {
+				return false
+			}
+		case "primary":
+			if email.Primary != strings.EqualFold(c.Value, "true") {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyPatch はPATCHの操作をグループに適用します
+func (g *Group) ApplyPatch(operations []PatchOperation) error {
+	return applyOperations(operations, g.setAttribute, g.removeAttribute, nil)
+}
+
+func (g *Group) setAttribute(op string, path Path, value json.RawMessage) error {
+	if path.Schema != "" {
+		return nil
+	}
+	switch {
+	case path.is("displayName"):
+		return decodeString(value, &g.DisplayName)
+	case path.is("externalId"):
+		return decodeString(value, &g.ExternalID)
+	case path.is("members"):
+		var members []Reference
+		if err := json.Unmarshal(value, &members); err != nil {
+			return fmt.Errorf("%w: members must be an array", ErrInvalidValue)
+		}
+		if op == opReplace {
+			g.Members = nil
+		}
+		for _, member := range members {
+			if member.Value == "" {
+				return fmt.Errorf("%w: member value is required", ErrInvalidValue)
+			}
+			if !g.hasMember(member.Value) {
+				g.Members = append(g.Members, Reference{Value: member.Value})
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Group) removeAttribute(path Path, value json.RawMessage) error {
+	if path.Schema != "" {
+		return nil
+	}
+	switch {
+	case path.is("externalId"):
+		g.ExternalID = ""
+	case path.is("members"):
+		// members[value eq "id"] の形式、または value に削除するメンバーを列挙する形式（Microsoft Entra ID）
+		var removed []string
+		if id, ok := path.Filter.Value("value"); ok {
+			removed = append(removed, id)
+		} else if len(value) > 0 && string(value) != "null" {
+			var members []Reference
+			if err := json.Unmarshal(value, &members); err != nil {
+				return fmt.Errorf("%w: members must be an array", ErrInvalidValue)
+			}
+			for _, member := range members {
+				removed = append(removed, member.Value)
+			}
+		} else if path.Filter != nil {
+			return fmt.Errorf("%w: members can only be filtered by value", ErrInvalidPath)
+		} else {
+			g.Members = nil
+			return nil
+		}
+
+		var kept []Reference
+		for _, member := range g.Members {
+			if !containsFold(removed, member.Value) {
+				kept = append(kept, member)
+			}
+		}
+		g.Members = kept
+	case path.is("displayName"):
+		return fmt.Errorf("%w: displayName cannot be removed", ErrInvalidPath)
+	}
+	return nil
+}
+
+func (g *Group) hasMember(id string) bool {
+	for _, member := range g.Members {
+		if strings.EqualFold(member.Value, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyOperations はPATCHの操作を順に適用します
+// path を省略した add・replace は value のオブジェクトの属性ごとに適用し、extra が処理した属性は除きます
+func applyOperations(
+	operations []PatchOperation,
+	set func(op string, path Path, value json.RawMessage) error,
+	remove func(path Path, value json.RawMessage) error,
+	extra func(key string, value json.RawMessage) (bool, error),
+) error {
+	if len(operations) == 0 {
+		return fmt.Errorf("%w: no operations", ErrInvalidSyntax)
+	}
+
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != opAdd && op != opReplace && op != opRemove {
+			return fmt.Errorf("%w: unsupported op %q", ErrInvalidSyntax, operation.Op)
+		}
+
+		if operation.Path == "" {
+			if op == opRemove {
+				return fmt.Errorf("%w: remove requires a path", ErrNoTarget)
+			}
+			var attributes map[string]json.RawMessage
+			if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+				return fmt.Errorf("%w: value must be an object when path is omitted", ErrInvalidValue)
+			}
+			for key, value := range attributes {
+				if extra != nil {
+					handled, err := extra(key, value)
+					if err != nil {
+						return err
+					}
+					if handled {
+						continue
+					}
+				}
+				path, err := ParsePath(key)
+				if err != nil {
+					return err
+				}
+				if err := set(op, path, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		path, err := ParsePath(operation.Path)
+		if err != nil {
+			return err
+		}
+		if op == opRemove {
+			err = remove(path, operation.Value)
+		} else {
+			err = set(op, path, operation.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeString は文字列の値を読み取ります（null は空文字列として扱います）
+func decodeString(value json.RawMessage, dest *string) error {
+	if len(value) == 0 || string(value) == "null" {
+		*dest = ""
+		return nil
+	}
+	if err := json.Unmarshal(value, dest); err != nil {
+		return fmt.Errorf("%w: expected a string but got %s", ErrInvalidValue, value)
+	}
+	return nil
+}
+
+// decodeBool は真偽値を読み取ります
+// Microsoft Entra ID は active を "False" のような文字列で送信するため、文字列も受け付けます
+func decodeBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if parsed, err := strconv.ParseBool(s); err == nil {
+			return parsed, nil
+		}
+	}
+	return false, fmt.Errorf("%w: expected a boolean but got %s", ErrInvalidValue, value)
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+// backend/pkg/scim/patch_test.go
+package scim_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/esms/pkg/scim"
+)
+
+// parseOperations はPATCHのリクエストの本文から操作を読み取ります
+func parseOperations(t *testing.T, body string) []scim.PatchOperation {
+	t.Helper()
+	var request scim.PatchRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &request))
+	return request.Operations
+}
+
+func newUser() *scim.User {
+	active := true
+	return &scim.User{
+		Schemas:     []string{scim.SchemaUser},
+		ID:          "b5a1c3f0-0d4e-4f6a-9b7c-2d8e1f3a4b5c",
+		UserName:    "taro.yamada@example.com",
+		DisplayName: "山田 太郎",
+		Emails:      []scim.Email{{Value: "taro.yamada@example.com", Type: "work", Primary: true}},
+		Active:      &active,
+	}
+}
+
+func TestUser_ApplyPatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		assert func(t *testing.T, u *scim.User)
+	}{
+		{
+			name: "Entra ID deactivation with a string boolean",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[{"op":"Replace","path":"active","value":"False"}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.False(t, u.IsActive())
+			},
+		},
+		{
+			name: "Okta deactivation without a path",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.False(t, u.IsActive())
+			},
+		},
+		{
+			name: "Entra ID attribute update",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[
+					{"op":"Replace","path":"emails[type eq \"work\"].value","value":"taro.sato@example.com"},
+					{"op":"Replace","path":"displayName","value":"佐藤 太郎"},
+					{"op":"Add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department","value":"営業部"},
+					{"op":"Add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager","value":"7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f"},
+					{"op":"Add","path":"title","value":"課長"}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.Equal(t, "taro.sato@example.com", u.PrimaryEmail())
+				assert.Equal(t, "佐藤 太郎", u.FormattedName())
+				assert.Equal(t, "営業部", u.Enterprise.Department)
+				assert.Equal(t, "7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f", u.Enterprise.Manager.Value)
+			},
+		},
+		{
+			name: "Extension attributes without a path",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[{"op":"replace","value":{
+					"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":"経理部","manager":{"value":"7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f"}}}}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.Equal(t, "経理部", u.Enterprise.Department)
+				assert.Equal(t, "7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f", u.Enterprise.Manager.Value)
+			},
+		},
+		{
+			name: "Remove manager",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[
+					{"op":"Add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager","value":"7d4f1f9e-3c2b-4a1d-8e6f-5b9a0c1d2e3f"},
+					{"op":"Remove","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:manager"}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.Nil(t, u.Enterprise.Manager)
+			},
+		},
+		{
+			name: "Name parts",
+			body: `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations":[
+					{"op":"remove","path":"displayName"},
+					{"op":"replace","path":"name.familyName","value":"鈴木"},
+					{"op":"replace","path":"name.givenName","value":"花子"}]}`,
+			assert: func(t *testing.T, u *scim.User) {
+				assert.Equal(t, "鈴木 花子", u.FormattedName())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := newUser()
+			require.NoError(t, user.ApplyPatch(parseOperations(t, tt.body)))
+			tt.assert(t, user)
+		})
+	}
+}
+
+func TestUser_ApplyPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{
+			name:    "unknown op",
+			body:    `{"Operations":[{"op":"move","path":"active","value":false}]}`,
+			wantErr: scim.ErrInvalidSyntax,
+		},
+		{
+			name:    "no operations",
+			body:    `{"Operations":[]}`,
+			wantErr: scim.ErrInvalidSyntax,
+		},
+		{
+			name:    "remove without a path",
+			body:    `{"Operations":[{"op":"remove"}]}`,
+			wantErr: scim.ErrNoTarget,
+		},
+		{
+			name:    "invalid boolean",
+			body:    `{"Operations":[{"op":"replace","path":"active","value":"maybe"}]}`,
+			wantErr: scim.ErrInvalidValue,
+		},
+		{
+			name:    "remove userName",
+			body:    `{"Operations":[{"op":"remove","path":"userName"}]}`,
+			wantErr: scim.ErrInvalidPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newUser().ApplyPatch(parseOperations(t, tt.body))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGroup_ApplyPatch(t *testing.T) {
+	const alice, bob, carol = "11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"
+
+	tests := []struct {
+		name        string
+		body        string
+		wantName    string
+		wantMembers []string
+	}{
+		{
+			name:        "Entra ID add member",
+			body:        `{"Operations":[{"op":"Add","path":"members","value":[{"value":"` + carol + `"},{"value":"` + alice + `"}]}]}`,
+			wantName:    "esms-managers",
+			wantMembers: []string{alice, bob, carol},
+		},
+		{
+			name:        "Entra ID remove member by value",
+			body:        `{"Operations":[{"op":"Remove","path":"members","value":[{"value":"` + alice + `"}]}]}`,
+			wantName:    "esms-managers",
+			wantMembers: []string{bob},
+		},
+		{
+			name:        "Okta remove member by filter",
+			body:        `{"Operations":[{"op":"remove","path":"members[value eq \"` + bob + `\"]"}]}`,
+			wantName:    "esms-managers",
+			wantMembers: []string{alice},
+		},
+		{
+			name:        "Okta rename without a path",
+			body:        `{"Operations":[{"op":"replace","value":{"id":"ignored","displayName":"esms-admins"}}]}`,
+			wantName:    "esms-admins",
+			wantMembers: []string{alice, bob},
+		},
+		{
+			name:        "replace members",
+			body:        `{"Operations":[{"op":"replace","path":"members","value":[{"value":"` + carol + `"}]}]}`,
+			wantName:    "esms-managers",
+			wantMembers: []string{carol},
+		},
+		{
+			name:     "remove all members",
+			body:     `{"Operations":[{"op":"remove","path":"members"}]}`,
+			wantName: "esms-managers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := &scim.Group{
+				DisplayName: "esms-managers",
+				Members:     []scim.Reference{{Value: alice}, {Value: bob}},
+			}
+			require.NoError(t, group.ApplyPatch(parseOperations(t, tt.body)))
+
+			assert.Equal(t, tt.wantName, group.DisplayName)
+			var members []string
+			for _, member := range group.Members {
+				members = append(members, member.Value)
+			}
+			assert.Equal(t, tt.wantMembers, members)
+		})
+	}
+}
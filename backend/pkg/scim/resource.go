@@ -0,0 +1,188 @@
+// backend/pkg/scim/resource.go
+package scim
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	SchemaUser           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaEnterpriseUser = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SchemaGroup          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp        = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError          = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// ContentType はSCIMのリクエスト・レスポンスのメディアタイプ
+	ContentType = "application/scim+json"
+
+	ResourceTypeUser  = "User"
+	ResourceTypeGroup = "Group"
+)
+
+// scimType はエラーの詳細な種類（RFC 7644 3.12）
+const (
+	ErrorTypeInvalidFilter = "invalidFilter"
+	ErrorTypeInvalidSyntax = "invalidSyntax"
+	ErrorTypeInvalidPath   = "invalidPath"
+	ErrorTypeInvalidValue  = "invalidValue"
+	ErrorTypeNoTarget      = "noTarget"
+	ErrorTypeUniqueness    = "uniqueness"
+)
+
+var (
+	ErrInvalidFilter = errors.New("invalid scim filter")
+	ErrInvalidSyntax = errors.New("invalid scim request")
+	ErrInvalidPath   = errors.New("invalid scim path")
+	ErrInvalidValue  = errors.New("invalid scim value")
+	ErrNoTarget      = errors.New("scim operation has no target")
+)
+
+// ErrorType はパッケージのエラーに対応する scimType を返します（該当しない場合は空文字列）
+func ErrorType(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidFilter):
+		return ErrorTypeInvalidFilter
+	case errors.Is(err, ErrInvalidSyntax):
+		return ErrorTypeInvalidSyntax
+	case errors.Is(err, ErrInvalidPath):
+		return ErrorTypeInvalidPath
+	case errors.Is(err, ErrInvalidValue):
+		return ErrorTypeInvalidValue
+	case errors.Is(err, ErrNoTarget):
+		return ErrorTypeNoTarget
+	}
+	return ""
+}
+
+// Meta はリソースのメタデータ
+type Meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location,omitempty"`
+}
+
+// Name はユーザーの氏名
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+// Email はユーザーのメールアドレス
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Reference は他のリソース（グループのメンバー・ユーザーの所属グループ・上長）への参照
+type Reference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// EnterpriseUser はエンタープライズユーザーの拡張スキーマ（RFC 7643 4.3）のうち使用する属性
+type EnterpriseUser struct {
+	Department string     `json:"department,omitempty"`
+	Manager    *Reference `json:"manager,omitempty"`
+}
+
+// User はユーザーのリソース
+type User struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	ExternalID  string          `json:"externalId,omitempty"`
+	UserName    string          `json:"userName"`
+	Name        *Name           `json:"name,omitempty"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Emails      []Email         `json:"emails,omitempty"`
+	Active      *bool           `json:"active,omitempty"`
+	Groups      []Reference     `json:"groups,omitempty"`
+	Enterprise  *EnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *Meta           `json:"meta,omitempty"`
+}
+
+// PrimaryEmail は主たるメールアドレスを返します
+// primary の指定、type が work のもの、先頭のものの順に選び、emails がない場合は userName を使用します
+func (u *User) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary && email.Value != "" {
+			return email.Value
+		}
+	}
+	for _, email := range u.Emails {
+		if strings.EqualFold(email.Type, "work") && email.Value != "" {
+			return email.Value
+		}
+	}
+	if len(u.Emails) > 0 && u.Emails[0].Value != "" {
+		return u.Emails[0].Value
+	}
+	return u.UserName
+}
+
+// FormattedName は表示に使用する氏名を返します
+// displayName、name.formatted、name.familyName と name.givenName の連結の順に使用します
+func (u *User) FormattedName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.FamilyName + " " + u.Name.GivenName)
+}
+
+// IsActive はユーザーが有効かどうかを返します（active が省略された場合は有効）
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// Group はグループのリソース
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Reference `json:"members,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// ListResponse は検索結果のレスポンス
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int64         `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+// NewListResponse は検索結果のレスポンスを作成します
+func NewListResponse(resources []interface{}, total int64, startIndex int) *ListResponse {
+	if resources == nil {
+		resources = []interface{}{}
+	}
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// Error はエラーのレスポンス（RFC 7644 3.12）
+type Error struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
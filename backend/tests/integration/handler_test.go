@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -360,6 +361,47 @@ func (m *mockUserRepository) GetByEmail(ctx context.Context, email string) (*dom
 	return nil, repository.ErrNotFound
 }
 
+func (m *mockUserRepository) GetBySubject(ctx context.Context, issuer, sub string) (*domain.User, error) {
+	for _, user := range m.users {
+		if user.HasIdentity(issuer, sub) {
+			return user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockUserRepository) ListByEmail(ctx context.Context, email string) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range m.users {
+		if strings.EqualFold(user.Email, email) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (m *mockUserRepository) List(ctx context.Context, filter repository.UserFilter, offset, limit int) ([]*domain.User, int64, error) {
+	var users []*domain.User
+	for _, user := range m.users {
+		if filter.Email != nil && !strings.EqualFold(user.Email, *filter.Email) {
+			continue
+		}
+		if filter.ExternalID != nil && user.ExternalID != *filter.ExternalID {
+			continue
+		}
+		users = append(users, user)
+	}
+	total := int64(len(users))
+	if offset >= len(users) {
+		return nil, total, nil
+	}
+	users = users[offset:]
+	if limit < len(users) {
+		users = users[:limit]
+	}
+	return users, total, nil
+}
+
 func (m *mockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	m.users[user.ID] = user
 	return nil
@@ -392,6 +434,22 @@ func (m *mockResourceRepository) FindAvailable(ctx context.Context, startAt, end
 	return []*domain.Resource{}, nil
 }
 
+func (m *mockResourceRepository) FindAvailableRooms(ctx context.Context, startAt, endAt time.Time, minCapacity int) ([]*domain.Resource, error) {
+	return []*domain.Resource{}, nil
+}
+
+func (m *mockResourceRepository) AvailableUnits(ctx context.Context, resourceID uuid.UUID, startAt, endAt time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockResourceRepository) ListByZone(ctx context.Context, resourceType domain.ResourceType, zone string) ([]*domain.Resource, error) {
+	return nil, nil
+}
+
+func (m *mockResourceRepository) ListZoneBookings(ctx context.Context, resourceType domain.ResourceType, zone string, startAt, endAt time.Time) ([]*domain.ZoneBooking, error) {
+	return nil, nil
+}
+
 type mockAuthService struct {
 	sessions map[string]*service.Session
 }
@@ -434,6 +492,10 @@ func (m *mockReservationService) CancelReservation(ctx context.Context, id uuid.
 	return nil
 }
 
+func (m *mockReservationService) CheckConflicts(ctx context.Context, req *service.CreateReservationRequest) (*domain.SeriesConflictReport, error) {
+	return &domain.SeriesConflictReport{}, nil
+}
+
 type mockApprovalService struct{}
 
 func (m *mockApprovalService) ApproveReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID) error {
@@ -443,3 +505,11 @@ func (m *mockApprovalService) ApproveReservation(ctx context.Context, reservatio
 func (m *mockApprovalService) RejectReservation(ctx context.Context, reservationID uuid.UUID, startAt time.Time, approverID uuid.UUID, reason string) error {
 	return nil
 }
+
+func (m *mockApprovalService) GetPendingApprovals(ctx context.Context, approverID uuid.UUID, query service.PendingApprovalsQuery) ([]*domain.Reservation, int, error) {
+	return []*domain.Reservation{}, 0, nil
+}
+
+func (m *mockApprovalService) DecideBulk(ctx context.Context, approverID uuid.UUID, items []service.BulkDecisionItem) ([]service.BulkDecisionResult, error) {
+	return []service.BulkDecisionResult{}, nil
+}
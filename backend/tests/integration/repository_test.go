@@ -51,7 +51,7 @@ func TestMain(m *testing.M) {
 
 // cleanupDB はテストデータをクリーンアップします
 func cleanupDB(t *testing.T) {
-	_, err := testDB.Exec("TRUNCATE TABLE audit_logs, reservation_resources, reservation_participants, reservation_instances, reservations_2025, reservations_2026, reservations_2027, resources, users CASCADE")
+	_, err := testDB.Exec("TRUNCATE TABLE audit_logs, approver_group_members, approver_groups, reservation_resources, reservation_participants, reservation_instances, reservations_2025, reservations_2026, reservations_2027, resources, users CASCADE")
 	require.NoError(t, err)
 }
 
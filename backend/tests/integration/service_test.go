@@ -37,11 +37,20 @@ func TestServiceIntegration_ReservationFlow(t *testing.T) {
 		resourceRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	approvalService := service.NewApprovalService(
 		reservationRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// テストデータ準備: ユーザー
@@ -138,6 +147,10 @@ func TestServiceIntegration_ApprovalFlow(t *testing.T) {
 		reservationRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// テストデータ準備
@@ -227,6 +240,11 @@ func TestServiceIntegration_ResourceConflict(t *testing.T) {
 		resourceRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// テストデータ準備
@@ -318,6 +336,11 @@ func TestServiceIntegration_DoubleBooking_AuditLog(t *testing.T) {
 		resourceRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// テストデータ準備
@@ -420,6 +443,10 @@ func TestServiceIntegration_Approval_Unauthorized(t *testing.T) {
 		reservationRepo,
 		userRepo,
 		auditLogRepo,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// テストデータ準備